@@ -0,0 +1,233 @@
+package main
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/reflection"
+
+	grpcAdapter "github.com/quentinrf/plant-monitor/services/api-gateway/internal/adapters/grpc"
+	"github.com/quentinrf/plant-monitor/services/api-gateway/internal/ports"
+	"github.com/quentinrf/plant-monitor/services/api-gateway/pkg/pb"
+	"github.com/quentinrf/plant-monitor/services/api-gateway/pkg/tlsconfig"
+)
+
+func main() {
+	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
+
+	log.Info().Msg("starting api-gateway")
+
+	config := loadConfig()
+	if err := config.checkEnv(); err != nil {
+		log.Fatal().Err(err).Msg("invalid configuration")
+	}
+
+	// Build the TLS config for the outbound calls to the backends (client role).
+	var clientTLSCfg *tls.Config
+	if config.TLSCert != "" {
+		cfg, err := tlsconfig.LoadClientTLS(config.TLSCert, config.TLSKey, config.TLSCA, logCertReload(config.TLSCert))
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to load client TLS config")
+		}
+		clientTLSCfg = cfg
+		log.Info().Msg("mTLS enabled for backend connections")
+	} else {
+		log.Warn().Msg("TLS_CERT not set — connecting to backends without TLS (ENV=development only)")
+	}
+
+	// Connect to light-service, the one required backend.
+	lightClient, err := grpcAdapter.NewLightClientAdapter(config.LightServiceAddr, clientTLSCfg, config.LightToken)
+	if err != nil {
+		log.Fatal().Err(err).Str("addr", config.LightServiceAddr).Msg("failed to connect to light-service")
+	}
+	defer lightClient.Close()
+
+	log.Info().Str("addr", config.LightServiceAddr).Msg("connected to light-service")
+
+	opts := []ports.AggregatorOption{
+		ports.WithBackendTimeout(config.BackendTimeout),
+		ports.WithAlertWindow(config.AlertWindow),
+	}
+
+	// The other backends are optional — an empty address leaves them out.
+	if config.PlantServiceAddr != "" {
+		c, err := grpcAdapter.NewPlantClientAdapter(config.PlantServiceAddr, clientTLSCfg)
+		if err != nil {
+			log.Fatal().Err(err).Str("addr", config.PlantServiceAddr).Msg("failed to connect to plant-service")
+		}
+		defer c.Close()
+		opts = append(opts, ports.WithPlants(c))
+		log.Info().Str("addr", config.PlantServiceAddr).Msg("connected to plant-service")
+	}
+	if config.MoistureServiceAddr != "" {
+		c, err := grpcAdapter.NewMoistureClientAdapter(config.MoistureServiceAddr, clientTLSCfg)
+		if err != nil {
+			log.Fatal().Err(err).Str("addr", config.MoistureServiceAddr).Msg("failed to connect to moisture-service")
+		}
+		defer c.Close()
+		opts = append(opts, ports.WithMoisture(c))
+		log.Info().Str("addr", config.MoistureServiceAddr).Msg("connected to moisture-service")
+	}
+	if config.ClimateServiceAddr != "" {
+		c, err := grpcAdapter.NewClimateClientAdapter(config.ClimateServiceAddr, clientTLSCfg)
+		if err != nil {
+			log.Fatal().Err(err).Str("addr", config.ClimateServiceAddr).Msg("failed to connect to climate-service")
+		}
+		defer c.Close()
+		opts = append(opts, ports.WithClimate(c))
+		log.Info().Str("addr", config.ClimateServiceAddr).Msg("connected to climate-service")
+	}
+
+	aggregator := ports.NewAggregator(lightClient, opts...)
+
+	// Build gRPC server — mTLS if certs provided, insecure otherwise.
+	handler := grpcAdapter.NewGatewayServiceHandler(aggregator)
+
+	var serverOpts []grpc.ServerOption
+	if config.TLSCert != "" {
+		tlsCfg, err := tlsconfig.LoadServerTLS(config.TLSCert, config.TLSKey, config.TLSCA, logCertReload(config.TLSCert))
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to load server TLS config")
+		}
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsCfg)))
+		log.Info().Msg("mTLS enabled for incoming connections")
+	} else {
+		log.Warn().Msg("starting gRPC server without TLS (ENV=development only)")
+	}
+
+	grpcServer := grpc.NewServer(serverOpts...)
+	pb.RegisterGatewayServiceServer(grpcServer, handler)
+	// Reflection is for grpcurl during development only
+	if !config.production() {
+		reflection.Register(grpcServer)
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", config.Port))
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to listen")
+	}
+
+	log.Info().Str("port", config.Port).Msg("gRPC server listening")
+
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Fatal().Err(err).Msg("failed to serve")
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Info().Msg("shutting down api-gateway...")
+
+	grpcServer.GracefulStop()
+
+	log.Info().Msg("api-gateway stopped")
+}
+
+// Config holds application configuration read from environment variables.
+type Config struct {
+	Port                string
+	Env                 string // "development" | "production": whether plaintext gRPC and reflection are allowed
+	LightServiceAddr    string
+	LightToken          string        // API key for light-service, if it requires one
+	PlantServiceAddr    string        // empty disables plant profiles
+	MoistureServiceAddr string        // empty leaves moisture out of the status
+	ClimateServiceAddr  string        // empty leaves climate out of the status
+	BackendTimeout      time.Duration // per-backend call deadline
+	AlertWindow         time.Duration // how far back to report alerts
+	TLSCert             string
+	TLSKey              string
+	TLSCA               string
+}
+
+func loadConfig() Config {
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "50055"
+	}
+
+	lightAddr := os.Getenv("LIGHT_SERVICE_ADDR")
+	if lightAddr == "" {
+		lightAddr = "localhost:50051"
+	}
+
+	backendTimeout := ports.DefaultBackendTimeout
+	if timeoutStr := os.Getenv("BACKEND_TIMEOUT"); timeoutStr != "" {
+		if d, err := time.ParseDuration(timeoutStr); err == nil && d > 0 {
+			backendTimeout = d
+		}
+	}
+
+	alertWindow := ports.DefaultAlertWindow
+	if windowStr := os.Getenv("ALERT_WINDOW"); windowStr != "" {
+		if d, err := time.ParseDuration(windowStr); err == nil && d > 0 {
+			alertWindow = d
+		}
+	}
+
+	env := os.Getenv("ENV")
+	if env == "" {
+		env = "development"
+	}
+
+	return Config{
+		Env:                 env,
+		Port:                port,
+		LightServiceAddr:    lightAddr,
+		LightToken:          os.Getenv("LIGHT_SERVICE_TOKEN"),
+		PlantServiceAddr:    os.Getenv("PLANT_SERVICE_ADDR"),
+		MoistureServiceAddr: os.Getenv("MOISTURE_SERVICE_ADDR"),
+		ClimateServiceAddr:  os.Getenv("CLIMATE_SERVICE_ADDR"),
+		BackendTimeout:      backendTimeout,
+		AlertWindow:         alertWindow,
+		TLSCert:             os.Getenv("TLS_CERT"),
+		TLSKey:              os.Getenv("TLS_KEY"),
+		TLSCA:               os.Getenv("TLS_CA"),
+	}
+}
+
+// checkEnv rejects an unknown ENV and, in production, a missing TLS_CERT:
+// production refuses plaintext rather than warning about it. The services
+// share no module, so each main carries its own copy; keep them in step.
+func (c Config) checkEnv() error {
+	switch c.Env {
+	case "development":
+		return nil
+	case "production":
+		if c.TLSCert == "" {
+			return errors.New("TLS_CERT is required when ENV is production")
+		}
+		return nil
+	}
+	return fmt.Errorf("ENV must be development or production, got %q", c.Env)
+}
+
+// production reports whether ENV is production, which turns off reflection
+func (c Config) production() bool {
+	return c.Env == "production"
+}
+
+// logCertReload logs each reload of a rotated certificate, which is picked
+// up at the next handshake after the periodic check
+func logCertReload(certFile string) tlsconfig.Option {
+	return tlsconfig.WithReloadHook(func(err error) {
+		if err != nil {
+			log.Error().Err(err).Msg("failed to reload TLS certificate, keeping the current one")
+			return
+		}
+		log.Info().Str("cert", certFile).Msg("TLS certificate reloaded")
+	})
+}
@@ -0,0 +1,54 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/quentinrf/plant-monitor/services/api-gateway/internal/domain"
+
+	climatepb "github.com/quentinrf/plant-monitor/services/climate-service/pkg/pb"
+)
+
+// ClimateClientAdapter implements ports.ClimateClient by calling climate-service over gRPC.
+type ClimateClientAdapter struct {
+	conn   *grpc.ClientConn
+	client climatepb.ClimateServiceClient
+}
+
+// NewClimateClientAdapter dials climate-service. Pass nil tlsConfig for insecure (dev) mode.
+func NewClimateClientAdapter(addr string, tlsConfig *tls.Config) (*ClimateClientAdapter, error) {
+	conn, err := dial("climate-service", addr, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &ClimateClientAdapter{
+		conn:   conn,
+		client: climatepb.NewClimateServiceClient(conn),
+	}, nil
+}
+
+// GetCurrentClimate fetches the most recent reading from climate-service.
+func (a *ClimateClientAdapter) GetCurrentClimate(ctx context.Context) (*domain.ClimateStatus, error) {
+	resp, err := a.client.GetCurrentClimate(ctx, &climatepb.GetCurrentClimateRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("GetCurrentClimate: %w", err)
+	}
+
+	r := resp.GetReading()
+	return &domain.ClimateStatus{
+		TemperatureCelsius: r.GetTemperatureCelsius(),
+		HumidityPercent:    r.GetHumidityPercent(),
+		DewPointCelsius:    r.GetDewPointCelsius(),
+		VPDKilopascals:     r.GetVpdKpa(),
+		Timestamp:          time.UnixMilli(r.GetTimestampMs()),
+	}, nil
+}
+
+// Close releases the underlying gRPC connection.
+func (a *ClimateClientAdapter) Close() error {
+	return a.conn.Close()
+}
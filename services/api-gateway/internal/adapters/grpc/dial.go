@@ -0,0 +1,27 @@
+package grpc
+
+import (
+	"crypto/tls"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// dial connects to service at addr. Pass nil tlsConfig for insecure (dev) mode.
+// opts are applied after the transport credentials.
+func dial(service, addr string, tlsConfig *tls.Config, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	var dialOpt grpc.DialOption
+	if tlsConfig != nil {
+		dialOpt = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
+	} else {
+		dialOpt = grpc.WithTransportCredentials(insecure.NewCredentials())
+	}
+
+	conn, err := grpc.NewClient(addr, append([]grpc.DialOption{dialOpt}, opts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("dial %s at %s: %w", service, addr, err)
+	}
+	return conn, nil
+}
@@ -0,0 +1,100 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/api-gateway/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/api-gateway/internal/ports"
+	"github.com/quentinrf/plant-monitor/services/api-gateway/pkg/pb"
+)
+
+// GatewayServiceHandler implements the gRPC GatewayService
+type GatewayServiceHandler struct {
+	pb.UnimplementedGatewayServiceServer
+	aggregator *ports.Aggregator
+}
+
+// NewGatewayServiceHandler creates a new gRPC handler
+func NewGatewayServiceHandler(aggregator *ports.Aggregator) *GatewayServiceHandler {
+	return &GatewayServiceHandler{aggregator: aggregator}
+}
+
+// GetPlantStatus combines every service's view of a plant
+func (h *GatewayServiceHandler) GetPlantStatus(ctx context.Context, req *pb.GetPlantStatusRequest) (*pb.GetPlantStatusResponse, error) {
+	log.Info().Int64("plant_id", req.PlantId).Msg("GetPlantStatus called")
+
+	if req.PlantId < 0 {
+		return nil, status.Error(codes.InvalidArgument, "plant_id cannot be negative")
+	}
+
+	st, err := h.aggregator.PlantStatus(ctx, req.PlantId)
+	switch {
+	case errors.Is(err, domain.ErrPlantNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrPlantsDisabled):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case err != nil:
+		log.Error().Err(err).Msg("failed to get plant status")
+		return nil, status.Error(codes.Internal, "failed to get plant status")
+	}
+
+	return &pb.GetPlantStatusResponse{Status: convertStatusToProto(st)}, nil
+}
+
+// convertStatusToProto converts domain model to protobuf
+func convertStatusToProto(st *domain.PlantStatus) *pb.PlantStatus {
+	resp := &pb.PlantStatus{Unavailable: st.Unavailable}
+	if p := st.Plant; p != nil {
+		resp.Plant = &pb.Plant{
+			Id:       p.ID,
+			Name:     p.Name,
+			Species:  p.Species,
+			MinLux:   p.MinLux,
+			MaxLux:   p.MaxLux,
+			Location: p.Location,
+		}
+	}
+	if l := st.Light; l != nil {
+		resp.Light = &pb.LightStatus{
+			Lux:            l.Lux,
+			Category:       l.Category,
+			TimestampMs:    l.Timestamp.UnixMilli(),
+			Fit:            l.Fit,
+			Recommendation: l.Recommendation,
+			Trend:          l.Trend,
+		}
+	}
+	if m := st.Moisture; m != nil {
+		resp.Moisture = &pb.MoistureStatus{
+			Percent:     m.Percent,
+			Category:    m.Category,
+			TimestampMs: m.Timestamp.UnixMilli(),
+		}
+	}
+	if c := st.Climate; c != nil {
+		resp.Climate = &pb.ClimateStatus{
+			TemperatureCelsius: c.TemperatureCelsius,
+			HumidityPercent:    c.HumidityPercent,
+			DewPointCelsius:    c.DewPointCelsius,
+			VpdKpa:             c.VPDKilopascals,
+			TimestampMs:        c.Timestamp.UnixMilli(),
+		}
+	}
+	for _, a := range st.Alerts {
+		resp.Alerts = append(resp.Alerts, &pb.Alert{
+			Id:        a.ID,
+			RuleId:    a.RuleID,
+			RuleName:  a.RuleName,
+			Message:   a.Message,
+			FiredAtMs: a.FiredAt.UnixMilli(),
+			// Without a current reading, whether it still applies is unknown
+			Active: st.Light != nil && a.Breached(st.Light.Lux),
+		})
+	}
+	return resp
+}
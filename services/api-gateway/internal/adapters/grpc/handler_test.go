@@ -0,0 +1,204 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/api-gateway/internal/ports"
+	"github.com/quentinrf/plant-monitor/services/api-gateway/pkg/pb"
+
+	lightpb "github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+	moisturepb "github.com/quentinrf/plant-monitor/services/moisture-service/pkg/pb"
+	plantpb "github.com/quentinrf/plant-monitor/services/plant-service/pkg/pb"
+)
+
+// readingTime is when the fake services' readings were taken
+var readingTime = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+type fakeLightService struct {
+	lightpb.UnimplementedLightServiceServer
+	alertsDisabled bool
+}
+
+func (fakeLightService) GetCurrentLight(ctx context.Context, req *lightpb.GetCurrentLightRequest) (*lightpb.GetCurrentLightResponse, error) {
+	return &lightpb.GetCurrentLightResponse{Reading: &lightpb.LightReading{
+		Lux: 120, Category: "Low Light", TimestampMs: readingTime.UnixMilli(),
+	}}, nil
+}
+
+func (s fakeLightService) GetAlerts(ctx context.Context, req *lightpb.GetAlertsRequest) (*lightpb.GetAlertsResponse, error) {
+	if s.alertsDisabled {
+		return nil, status.Error(codes.FailedPrecondition, "alerts are not enabled")
+	}
+	return &lightpb.GetAlertsResponse{Alerts: []*lightpb.Alert{
+		{Id: 1, RuleId: 1, RuleName: "dark", Condition: lightpb.AlertCondition_ALERT_CONDITION_BELOW, ThresholdLux: 150, FiredAtMs: readingTime.UnixMilli()},
+		{Id: 2, RuleId: 2, RuleName: "scorching", Condition: lightpb.AlertCondition_ALERT_CONDITION_ABOVE, ThresholdLux: 20000, FiredAtMs: readingTime.UnixMilli()},
+	}}, nil
+}
+
+type fakePlantService struct {
+	plantpb.UnimplementedPlantServiceServer
+}
+
+func (fakePlantService) GetPlantStatus(ctx context.Context, req *plantpb.GetPlantStatusRequest) (*plantpb.GetPlantStatusResponse, error) {
+	if req.PlantId == 0 {
+		return &plantpb.GetPlantStatusResponse{Status: &plantpb.PlantStatus{
+			Fit: "unknown", Recommendation: "Low light suits shade plants", Trend: "stable",
+		}}, nil
+	}
+	if req.PlantId != 3 {
+		return nil, status.Error(codes.NotFound, "plant not found")
+	}
+	return &plantpb.GetPlantStatusResponse{Status: &plantpb.PlantStatus{
+		Fit:            "too_dark",
+		Recommendation: "Monstera needs at least 1000 lux",
+		Trend:          "stable",
+		Plant:          &plantpb.Plant{Id: 3, Name: "Monstera", MinLux: 1000, MaxLux: 10000},
+	}}, nil
+}
+
+type fakeMoistureService struct {
+	moisturepb.UnimplementedMoistureServiceServer
+}
+
+func (fakeMoistureService) GetCurrentMoisture(ctx context.Context, req *moisturepb.GetCurrentMoistureRequest) (*moisturepb.GetCurrentMoistureResponse, error) {
+	return &moisturepb.GetCurrentMoistureResponse{Reading: &moisturepb.MoistureReading{
+		Percent: 25, Category: "Dry", TimestampMs: readingTime.UnixMilli(),
+	}}, nil
+}
+
+// startBackends serves the fake light-, plant- and moisture-services on
+// one loopback listener and returns its address
+func startBackends(t *testing.T, light fakeLightService) string {
+	t.Helper()
+
+	server := grpc.NewServer()
+	lightpb.RegisterLightServiceServer(server, light)
+	plantpb.RegisterPlantServiceServer(server, fakePlantService{})
+	moisturepb.RegisterMoistureServiceServer(server, fakeMoistureService{})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+// startGateway runs the gateway against the backends at addr, with
+// climate-service configured at an address nothing listens on
+func startGateway(t *testing.T, addr string) pb.GatewayServiceClient {
+	t.Helper()
+
+	light, err := NewLightClientAdapter(addr, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plants, err := NewPlantClientAdapter(addr, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	moisture, err := NewMoistureClientAdapter(addr, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	climate, err := NewClimateClientAdapter("127.0.0.1:1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		light.Close()
+		plants.Close()
+		moisture.Close()
+		climate.Close()
+	})
+	aggregator := ports.NewAggregator(light,
+		ports.WithPlants(plants),
+		ports.WithMoisture(moisture),
+		ports.WithClimate(climate),
+		ports.WithBackendTimeout(time.Second),
+	)
+
+	server := grpc.NewServer()
+	pb.RegisterGatewayServiceServer(server, NewGatewayServiceHandler(aggregator))
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewGatewayServiceClient(conn)
+}
+
+func TestGetPlantStatus(t *testing.T) {
+	client := startGateway(t, startBackends(t, fakeLightService{}))
+
+	resp, err := client.GetPlantStatus(context.Background(), &pb.GetPlantStatusRequest{PlantId: 3})
+	if err != nil {
+		t.Fatalf("GetPlantStatus failed: %v", err)
+	}
+	st := resp.Status
+
+	if st.Plant.GetName() != "Monstera" || st.Plant.GetMinLux() != 1000 {
+		t.Errorf("expected the Monstera, got %v", st.Plant)
+	}
+	if st.Light.GetLux() != 120 || st.Light.GetFit() != "too_dark" || st.Light.GetTimestampMs() != readingTime.UnixMilli() {
+		t.Errorf("unexpected light %v", st.Light)
+	}
+	if st.Moisture.GetPercent() != 25 || st.Moisture.GetCategory() != "Dry" {
+		t.Errorf("unexpected moisture %v", st.Moisture)
+	}
+
+	// Only the alert whose threshold 120 lux still breaches is active
+	if len(st.Alerts) != 2 || !st.Alerts[0].Active || st.Alerts[1].Active {
+		t.Errorf("expected the dark alert active and the bright one not, got %v", st.Alerts)
+	}
+
+	if st.Climate != nil || len(st.Unavailable) != 1 || st.Unavailable[0] != "climate-service" {
+		t.Errorf("expected climate-service unavailable, got %v, %v", st.Climate, st.Unavailable)
+	}
+}
+
+func TestGetPlantStatus_AlertsDisabled(t *testing.T) {
+	client := startGateway(t, startBackends(t, fakeLightService{alertsDisabled: true}))
+
+	resp, err := client.GetPlantStatus(context.Background(), &pb.GetPlantStatusRequest{})
+	if err != nil {
+		t.Fatalf("GetPlantStatus failed: %v", err)
+	}
+	if len(resp.Status.Alerts) != 0 || resp.Status.Light == nil {
+		t.Errorf("expected light without alerts, got %v", resp.Status)
+	}
+	for _, service := range resp.Status.Unavailable {
+		if service == "light-service" {
+			t.Error("expected disabled alerts not to count as light-service being down")
+		}
+	}
+}
+
+func TestGetPlantStatus_Errors(t *testing.T) {
+	client := startGateway(t, startBackends(t, fakeLightService{}))
+
+	_, err := client.GetPlantStatus(context.Background(), &pb.GetPlantStatusRequest{PlantId: 42})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+	_, err = client.GetPlantStatus(context.Background(), &pb.GetPlantStatusRequest{PlantId: -1})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
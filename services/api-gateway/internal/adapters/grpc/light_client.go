@@ -0,0 +1,99 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/api-gateway/internal/domain"
+
+	lightclient "github.com/quentinrf/plant-monitor/services/light-service/pkg/client"
+	lightpb "github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+// LightClientAdapter implements ports.LightClient by calling light-service over gRPC.
+type LightClientAdapter struct {
+	conn   *grpc.ClientConn
+	client lightpb.LightServiceClient
+}
+
+// NewLightClientAdapter dials light-service. Pass nil tlsConfig for insecure (dev) mode,
+// and an empty token if light-service doesn't require API keys.
+func NewLightClientAdapter(addr string, tlsConfig *tls.Config, token string) (*LightClientAdapter, error) {
+	var opts []grpc.DialOption
+	if token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(lightclient.TokenCredentials(token)))
+	}
+	conn, err := dial("light-service", addr, tlsConfig, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &LightClientAdapter{
+		conn:   conn,
+		client: lightpb.NewLightServiceClient(conn),
+	}, nil
+}
+
+// GetCurrentLight fetches the most recent reading from light-service.
+func (a *LightClientAdapter) GetCurrentLight(ctx context.Context) (*domain.LightStatus, error) {
+	resp, err := a.client.GetCurrentLight(ctx, &lightpb.GetCurrentLightRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("GetCurrentLight: %w", err)
+	}
+
+	r := resp.GetReading()
+	return &domain.LightStatus{
+		Lux:       r.GetLux(),
+		Category:  r.GetCategory(),
+		Timestamp: time.UnixMilli(r.GetTimestampMs()),
+	}, nil
+}
+
+// GetAlerts fetches the alerts light-service fired in [start, end). A
+// light-service without alerting enabled has none.
+func (a *LightClientAdapter) GetAlerts(ctx context.Context, start, end time.Time) ([]domain.Alert, error) {
+	resp, err := a.client.GetAlerts(ctx, &lightpb.GetAlertsRequest{
+		StartTimeMs: start.UnixMilli(),
+		EndTimeMs:   end.UnixMilli(),
+	})
+	if status.Code(err) == codes.FailedPrecondition {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GetAlerts: %w", err)
+	}
+
+	alerts := make([]domain.Alert, len(resp.GetAlerts()))
+	for i, al := range resp.GetAlerts() {
+		alerts[i] = domain.Alert{
+			ID:           al.GetId(),
+			RuleID:       al.GetRuleId(),
+			RuleName:     al.GetRuleName(),
+			Condition:    alertConditionFromProto(al.GetCondition()),
+			ThresholdLux: al.GetThresholdLux(),
+			Message:      al.GetMessage(),
+			FiredAt:      time.UnixMilli(al.GetFiredAtMs()),
+		}
+	}
+	return alerts, nil
+}
+
+// Close releases the underlying gRPC connection.
+func (a *LightClientAdapter) Close() error {
+	return a.conn.Close()
+}
+
+func alertConditionFromProto(c lightpb.AlertCondition) domain.AlertCondition {
+	switch c {
+	case lightpb.AlertCondition_ALERT_CONDITION_BELOW:
+		return domain.AlertBelow
+	case lightpb.AlertCondition_ALERT_CONDITION_ABOVE:
+		return domain.AlertAbove
+	}
+	return 0
+}
@@ -0,0 +1,52 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/quentinrf/plant-monitor/services/api-gateway/internal/domain"
+
+	moisturepb "github.com/quentinrf/plant-monitor/services/moisture-service/pkg/pb"
+)
+
+// MoistureClientAdapter implements ports.MoistureClient by calling moisture-service over gRPC.
+type MoistureClientAdapter struct {
+	conn   *grpc.ClientConn
+	client moisturepb.MoistureServiceClient
+}
+
+// NewMoistureClientAdapter dials moisture-service. Pass nil tlsConfig for insecure (dev) mode.
+func NewMoistureClientAdapter(addr string, tlsConfig *tls.Config) (*MoistureClientAdapter, error) {
+	conn, err := dial("moisture-service", addr, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &MoistureClientAdapter{
+		conn:   conn,
+		client: moisturepb.NewMoistureServiceClient(conn),
+	}, nil
+}
+
+// GetCurrentMoisture fetches the most recent reading from moisture-service.
+func (a *MoistureClientAdapter) GetCurrentMoisture(ctx context.Context) (*domain.MoistureStatus, error) {
+	resp, err := a.client.GetCurrentMoisture(ctx, &moisturepb.GetCurrentMoistureRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("GetCurrentMoisture: %w", err)
+	}
+
+	r := resp.GetReading()
+	return &domain.MoistureStatus{
+		Percent:   r.GetPercent(),
+		Category:  r.GetCategory(),
+		Timestamp: time.UnixMilli(r.GetTimestampMs()),
+	}, nil
+}
+
+// Close releases the underlying gRPC connection.
+func (a *MoistureClientAdapter) Close() error {
+	return a.conn.Close()
+}
@@ -0,0 +1,67 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/api-gateway/internal/domain"
+
+	plantpb "github.com/quentinrf/plant-monitor/services/plant-service/pkg/pb"
+)
+
+// PlantClientAdapter implements ports.PlantClient by calling plant-service over gRPC.
+type PlantClientAdapter struct {
+	conn   *grpc.ClientConn
+	client plantpb.PlantServiceClient
+}
+
+// NewPlantClientAdapter dials plant-service. Pass nil tlsConfig for insecure (dev) mode.
+func NewPlantClientAdapter(addr string, tlsConfig *tls.Config) (*PlantClientAdapter, error) {
+	conn, err := dial("plant-service", addr, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &PlantClientAdapter{
+		conn:   conn,
+		client: plantpb.NewPlantServiceClient(conn),
+	}, nil
+}
+
+// AssessPlant fetches plant-service's status for the plant with plantID.
+func (a *PlantClientAdapter) AssessPlant(ctx context.Context, plantID int64) (*domain.PlantAssessment, error) {
+	resp, err := a.client.GetPlantStatus(ctx, &plantpb.GetPlantStatusRequest{PlantId: plantID})
+	if status.Code(err) == codes.NotFound {
+		return nil, domain.ErrPlantNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GetPlantStatus: %w", err)
+	}
+
+	st := resp.GetStatus()
+	assessment := &domain.PlantAssessment{
+		Fit:            st.GetFit(),
+		Recommendation: st.GetRecommendation(),
+		Trend:          st.GetTrend(),
+	}
+	if p := st.GetPlant(); p != nil {
+		assessment.Plant = &domain.Plant{
+			ID:       p.GetId(),
+			Name:     p.GetName(),
+			Species:  p.GetSpecies(),
+			MinLux:   p.GetMinLux(),
+			MaxLux:   p.GetMaxLux(),
+			Location: p.GetLocation(),
+		}
+	}
+	return assessment, nil
+}
+
+// Close releases the underlying gRPC connection.
+func (a *PlantClientAdapter) Close() error {
+	return a.conn.Close()
+}
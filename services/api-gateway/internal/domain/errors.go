@@ -0,0 +1,12 @@
+package domain
+
+import "errors"
+
+var (
+	// ErrPlantNotFound indicates plant-service has no plant with the ID
+	ErrPlantNotFound = errors.New("plant not found")
+
+	// ErrPlantsDisabled indicates a plant was asked for without
+	// plant-service configured
+	ErrPlantsDisabled = errors.New("plant-service is not configured")
+)
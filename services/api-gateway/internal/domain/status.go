@@ -0,0 +1,92 @@
+package domain
+
+import "time"
+
+// PlantStatus combines what each service knows about a plant's surroundings.
+// The readings come from different services, so each section is nil when its
+// service is not configured or could not be reached.
+type PlantStatus struct {
+	Plant       *Plant // nil when no plant was asked for
+	Light       *LightStatus
+	Moisture    *MoistureStatus
+	Climate     *ClimateStatus
+	Alerts      []Alert
+	Unavailable []string // names of the services that failed
+}
+
+// Plant is a plant profile as plant-service keeps it
+type Plant struct {
+	ID       int64
+	Name     string
+	Species  string
+	MinLux   float64
+	MaxLux   float64
+	Location string
+}
+
+// LightStatus is the latest light reading, with plant-service's judgement
+// of it when available
+type LightStatus struct {
+	Lux       float64
+	Category  string
+	Timestamp time.Time
+
+	Fit            string // empty without a plant
+	Recommendation string
+	Trend          string
+}
+
+// PlantAssessment is plant-service's view of the current light for a plant
+type PlantAssessment struct {
+	Plant          *Plant // nil when no plant was asked for
+	Fit            string
+	Recommendation string
+	Trend          string
+}
+
+// MoistureStatus is the latest soil moisture reading
+type MoistureStatus struct {
+	Percent   float64
+	Category  string
+	Timestamp time.Time
+}
+
+// ClimateStatus is the latest air temperature and humidity reading
+type ClimateStatus struct {
+	TemperatureCelsius float64
+	HumidityPercent    float64
+	DewPointCelsius    float64
+	VPDKilopascals     float64
+	Timestamp          time.Time
+}
+
+// AlertCondition is the direction of a light alert rule's threshold
+type AlertCondition int
+
+const (
+	AlertBelow AlertCondition = iota + 1 // lux < threshold
+	AlertAbove                           // lux > threshold
+)
+
+// Alert is a light alert light-service fired
+type Alert struct {
+	ID           int64
+	RuleID       int64
+	RuleName     string
+	Condition    AlertCondition
+	ThresholdLux float64
+	Message      string
+	FiredAt      time.Time
+}
+
+// Breached reports whether lux is still on the wrong side of the alert's
+// threshold
+func (a Alert) Breached(lux float64) bool {
+	switch a.Condition {
+	case AlertBelow:
+		return lux < a.ThresholdLux
+	case AlertAbove:
+		return lux > a.ThresholdLux
+	}
+	return false
+}
@@ -0,0 +1,174 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/quentinrf/plant-monitor/services/api-gateway/internal/domain"
+)
+
+// Service names, as reported in domain.PlantStatus.Unavailable
+const (
+	LightService    = "light-service"
+	PlantService    = "plant-service"
+	MoistureService = "moisture-service"
+	ClimateService  = "climate-service"
+)
+
+// DefaultBackendTimeout bounds each call to a service unless overridden
+const DefaultBackendTimeout = 2 * time.Second
+
+// DefaultAlertWindow is how far back alerts are reported unless overridden
+const DefaultAlertWindow = 24 * time.Hour
+
+// Aggregator fans a status request out to the services and combines their
+// answers. Only light-service is required; the others are optional.
+type Aggregator struct {
+	light       LightClient
+	plants      PlantClient
+	moisture    MoistureClient
+	climate     ClimateClient
+	timeout     time.Duration
+	alertWindow time.Duration
+}
+
+// AggregatorOption configures optional Aggregator behaviour
+type AggregatorOption func(*Aggregator)
+
+// WithPlants evaluates the light against plant profiles from plant-service
+func WithPlants(client PlantClient) AggregatorOption {
+	return func(a *Aggregator) {
+		a.plants = client
+	}
+}
+
+// WithMoisture adds soil moisture from moisture-service
+func WithMoisture(client MoistureClient) AggregatorOption {
+	return func(a *Aggregator) {
+		a.moisture = client
+	}
+}
+
+// WithClimate adds temperature and humidity from climate-service
+func WithClimate(client ClimateClient) AggregatorOption {
+	return func(a *Aggregator) {
+		a.climate = client
+	}
+}
+
+// WithBackendTimeout bounds each service call, so one slow service can't
+// hold up the others' answers
+func WithBackendTimeout(d time.Duration) AggregatorOption {
+	return func(a *Aggregator) {
+		a.timeout = d
+	}
+}
+
+// WithAlertWindow sets how far back alerts are reported
+func WithAlertWindow(d time.Duration) AggregatorOption {
+	return func(a *Aggregator) {
+		a.alertWindow = d
+	}
+}
+
+// NewAggregator creates an aggregator over light-service and any optional
+// services given as options
+func NewAggregator(light LightClient, opts ...AggregatorOption) *Aggregator {
+	a := &Aggregator{
+		light:       light,
+		timeout:     DefaultBackendTimeout,
+		alertWindow: DefaultAlertWindow,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// PlantStatus asks every service for its part of the status of the plant
+// with plantID (0 for none) at once. A service that fails is listed in
+// Unavailable with its section left nil; only a missing plant, or a plant
+// asked for without plant-service, fails the whole status.
+func (a *Aggregator) PlantStatus(ctx context.Context, plantID int64) (*domain.PlantStatus, error) {
+	if plantID != 0 && a.plants == nil {
+		return nil, domain.ErrPlantsDisabled
+	}
+
+	var (
+		wg         sync.WaitGroup
+		mu         sync.Mutex
+		status     domain.PlantStatus
+		assessment *domain.PlantAssessment
+		assessErr  error
+	)
+	// Each call sets only its own variables, so only Unavailable is shared
+	call := func(service string, fn func(ctx context.Context) error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, a.timeout)
+			defer cancel()
+
+			if err := fn(ctx); err != nil {
+				log.Warn().Err(err).Str("service", service).Msg("service unavailable for status")
+				mu.Lock()
+				status.Unavailable = append(status.Unavailable, service)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	call(LightService, func(ctx context.Context) (err error) {
+		status.Light, err = a.light.GetCurrentLight(ctx)
+		return err
+	})
+	call(LightService, func(ctx context.Context) (err error) {
+		now := time.Now()
+		status.Alerts, err = a.light.GetAlerts(ctx, now.Add(-a.alertWindow), now)
+		return err
+	})
+	if a.plants != nil {
+		call(PlantService, func(ctx context.Context) error {
+			assessment, assessErr = a.plants.AssessPlant(ctx, plantID)
+			if errors.Is(assessErr, domain.ErrPlantNotFound) {
+				return nil // reported below rather than as an outage
+			}
+			return assessErr
+		})
+	}
+	if a.moisture != nil {
+		call(MoistureService, func(ctx context.Context) (err error) {
+			status.Moisture, err = a.moisture.GetCurrentMoisture(ctx)
+			return err
+		})
+	}
+	if a.climate != nil {
+		call(ClimateService, func(ctx context.Context) (err error) {
+			status.Climate, err = a.climate.GetCurrentClimate(ctx)
+			return err
+		})
+	}
+	wg.Wait()
+
+	if errors.Is(assessErr, domain.ErrPlantNotFound) {
+		return nil, assessErr
+	}
+	if assessErr == nil && assessment != nil {
+		status.Plant = assessment.Plant
+		if status.Light != nil {
+			status.Light.Fit = assessment.Fit
+			status.Light.Recommendation = assessment.Recommendation
+			status.Light.Trend = assessment.Trend
+		}
+	}
+
+	// Both light-service calls may have failed
+	slices.Sort(status.Unavailable)
+	status.Unavailable = slices.Compact(status.Unavailable)
+	return &status, nil
+}
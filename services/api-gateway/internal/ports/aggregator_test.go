@@ -0,0 +1,149 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/api-gateway/internal/domain"
+)
+
+type fakeLight struct {
+	light  *domain.LightStatus
+	alerts []domain.Alert
+	err    error
+}
+
+func (f *fakeLight) GetCurrentLight(ctx context.Context) (*domain.LightStatus, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	copied := *f.light
+	return &copied, nil
+}
+
+func (f *fakeLight) GetAlerts(ctx context.Context, start, end time.Time) ([]domain.Alert, error) {
+	return f.alerts, f.err
+}
+
+func (f *fakeLight) Close() error { return nil }
+
+type fakePlants struct{ plants map[int64]*domain.Plant }
+
+func (f *fakePlants) AssessPlant(ctx context.Context, plantID int64) (*domain.PlantAssessment, error) {
+	if plantID == 0 {
+		return &domain.PlantAssessment{Recommendation: "generic", Trend: "stable"}, nil
+	}
+	p, ok := f.plants[plantID]
+	if !ok {
+		return nil, domain.ErrPlantNotFound
+	}
+	return &domain.PlantAssessment{Plant: p, Fit: "too_dark", Recommendation: "move it", Trend: "darkening"}, nil
+}
+
+func (f *fakePlants) Close() error { return nil }
+
+type fakeMoisture struct{ err error }
+
+func (f *fakeMoisture) GetCurrentMoisture(ctx context.Context) (*domain.MoistureStatus, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &domain.MoistureStatus{Percent: 45, Category: "Moist"}, nil
+}
+
+func (f *fakeMoisture) Close() error { return nil }
+
+// slowClimate answers only once its context is done
+type slowClimate struct{}
+
+func (slowClimate) GetCurrentClimate(ctx context.Context) (*domain.ClimateStatus, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (slowClimate) Close() error { return nil }
+
+func TestAggregator_CombinesServices(t *testing.T) {
+	light := &fakeLight{
+		light:  &domain.LightStatus{Lux: 80, Category: "Low Light"},
+		alerts: []domain.Alert{{ID: 1, RuleName: "too dark", Condition: domain.AlertBelow, ThresholdLux: 100}},
+	}
+	plants := &fakePlants{plants: map[int64]*domain.Plant{7: {ID: 7, Name: "Fern"}}}
+	a := NewAggregator(light, WithPlants(plants), WithMoisture(&fakeMoisture{}))
+
+	status, err := a.PlantStatus(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("PlantStatus failed: %v", err)
+	}
+	if status.Plant == nil || status.Plant.Name != "Fern" {
+		t.Errorf("expected the Fern, got %+v", status.Plant)
+	}
+	if status.Light == nil || status.Light.Lux != 80 || status.Light.Fit != "too_dark" || status.Light.Trend != "darkening" {
+		t.Errorf("expected light with plant-service's assessment, got %+v", status.Light)
+	}
+	if status.Moisture == nil || status.Moisture.Percent != 45 {
+		t.Errorf("expected moisture, got %+v", status.Moisture)
+	}
+	if status.Climate != nil {
+		t.Errorf("expected no climate without climate-service, got %+v", status.Climate)
+	}
+	if len(status.Alerts) != 1 || len(status.Unavailable) != 0 {
+		t.Errorf("expected 1 alert and nothing unavailable, got %v and %v", status.Alerts, status.Unavailable)
+	}
+
+	// Without a plant, plant-service still judges the light generically
+	status, err = a.PlantStatus(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("PlantStatus failed: %v", err)
+	}
+	if status.Plant != nil || status.Light.Recommendation != "generic" {
+		t.Errorf("expected no plant and the generic recommendation, got %+v, %+v", status.Plant, status.Light)
+	}
+}
+
+func TestAggregator_PartialFailure(t *testing.T) {
+	light := &fakeLight{err: errors.New("connection refused")}
+	a := NewAggregator(light,
+		WithPlants(&fakePlants{}),
+		WithMoisture(&fakeMoisture{}),
+		WithClimate(slowClimate{}),
+		WithBackendTimeout(10*time.Millisecond),
+	)
+
+	start := time.Now()
+	status, err := a.PlantStatus(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("PlantStatus failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the slow service to be cut off, took %v", elapsed)
+	}
+
+	// Both light-service calls failed, but it is listed once
+	if want := []string{ClimateService, LightService}; !slices.Equal(status.Unavailable, want) {
+		t.Errorf("expected %v unavailable, got %v", want, status.Unavailable)
+	}
+	if status.Light != nil || status.Climate != nil || status.Alerts != nil {
+		t.Errorf("expected failed sections unset, got %+v", status)
+	}
+	if status.Moisture == nil {
+		t.Error("expected moisture despite the other failures")
+	}
+}
+
+func TestAggregator_PlantErrors(t *testing.T) {
+	light := &fakeLight{light: &domain.LightStatus{Lux: 500}}
+
+	a := NewAggregator(light, WithPlants(&fakePlants{}))
+	if _, err := a.PlantStatus(context.Background(), 99); !errors.Is(err, domain.ErrPlantNotFound) {
+		t.Errorf("expected ErrPlantNotFound, got %v", err)
+	}
+
+	a = NewAggregator(light)
+	if _, err := a.PlantStatus(context.Background(), 1); !errors.Is(err, domain.ErrPlantsDisabled) {
+		t.Errorf("expected ErrPlantsDisabled, got %v", err)
+	}
+}
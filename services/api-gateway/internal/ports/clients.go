@@ -0,0 +1,54 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/api-gateway/internal/domain"
+)
+
+// LightClient is the port for light-service.
+// Implementations: adapters/grpc.LightClientAdapter.
+type LightClient interface {
+	// GetCurrentLight returns the most recent light reading
+	GetCurrentLight(ctx context.Context) (*domain.LightStatus, error)
+
+	// GetAlerts returns the alerts fired in [start, end), oldest first
+	GetAlerts(ctx context.Context, start, end time.Time) ([]domain.Alert, error)
+
+	// Close releases the underlying connection
+	Close() error
+}
+
+// PlantClient is the port for plant-service.
+// Implementations: adapters/grpc.PlantClientAdapter.
+type PlantClient interface {
+	// AssessPlant judges the current light for the plant with plantID, or
+	// generically when plantID is 0. A missing plant is
+	// domain.ErrPlantNotFound.
+	AssessPlant(ctx context.Context, plantID int64) (*domain.PlantAssessment, error)
+
+	// Close releases the underlying connection
+	Close() error
+}
+
+// MoistureClient is the port for moisture-service.
+// Implementations: adapters/grpc.MoistureClientAdapter.
+type MoistureClient interface {
+	// GetCurrentMoisture returns the most recent soil moisture reading
+	GetCurrentMoisture(ctx context.Context) (*domain.MoistureStatus, error)
+
+	// Close releases the underlying connection
+	Close() error
+}
+
+// ClimateClient is the port for climate-service.
+// Implementations: adapters/grpc.ClimateClientAdapter.
+type ClimateClient interface {
+	// GetCurrentClimate returns the most recent temperature and humidity
+	// reading
+	GetCurrentClimate(ctx context.Context) (*domain.ClimateStatus, error)
+
+	// Close releases the underlying connection
+	Close() error
+}
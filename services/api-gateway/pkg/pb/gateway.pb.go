@@ -0,0 +1,702 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.4
+// source: api/proto/gateway.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetPlantStatusRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Evaluate the light against this plant's requirements; 0 reports the
+	// readings with plant-service's generic recommendation
+	PlantId       int64 `protobuf:"varint,1,opt,name=plant_id,json=plantId,proto3" json:"plant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlantStatusRequest) Reset() {
+	*x = GetPlantStatusRequest{}
+	mi := &file_api_proto_gateway_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlantStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlantStatusRequest) ProtoMessage() {}
+
+func (x *GetPlantStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gateway_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlantStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetPlantStatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_gateway_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetPlantStatusRequest) GetPlantId() int64 {
+	if x != nil {
+		return x.PlantId
+	}
+	return 0
+}
+
+type GetPlantStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *PlantStatus           `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlantStatusResponse) Reset() {
+	*x = GetPlantStatusResponse{}
+	mi := &file_api_proto_gateway_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlantStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlantStatusResponse) ProtoMessage() {}
+
+func (x *GetPlantStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gateway_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlantStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetPlantStatusResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_gateway_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetPlantStatusResponse) GetStatus() *PlantStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type PlantStatus struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Plant    *Plant                 `protobuf:"bytes,1,opt,name=plant,proto3" json:"plant,omitempty"` // unset without plant_id
+	Light    *LightStatus           `protobuf:"bytes,2,opt,name=light,proto3" json:"light,omitempty"`
+	Moisture *MoistureStatus        `protobuf:"bytes,3,opt,name=moisture,proto3" json:"moisture,omitempty"` // unset when moisture-service isn't configured
+	Climate  *ClimateStatus         `protobuf:"bytes,4,opt,name=climate,proto3" json:"climate,omitempty"`   // unset when climate-service isn't configured
+	// Light alerts fired within the gateway's alert window, oldest first
+	Alerts []*Alert `protobuf:"bytes,5,rep,name=alerts,proto3" json:"alerts,omitempty"`
+	// Services that failed to answer, e.g. "moisture-service"
+	Unavailable   []string `protobuf:"bytes,6,rep,name=unavailable,proto3" json:"unavailable,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlantStatus) Reset() {
+	*x = PlantStatus{}
+	mi := &file_api_proto_gateway_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlantStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlantStatus) ProtoMessage() {}
+
+func (x *PlantStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gateway_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlantStatus.ProtoReflect.Descriptor instead.
+func (*PlantStatus) Descriptor() ([]byte, []int) {
+	return file_api_proto_gateway_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PlantStatus) GetPlant() *Plant {
+	if x != nil {
+		return x.Plant
+	}
+	return nil
+}
+
+func (x *PlantStatus) GetLight() *LightStatus {
+	if x != nil {
+		return x.Light
+	}
+	return nil
+}
+
+func (x *PlantStatus) GetMoisture() *MoistureStatus {
+	if x != nil {
+		return x.Moisture
+	}
+	return nil
+}
+
+func (x *PlantStatus) GetClimate() *ClimateStatus {
+	if x != nil {
+		return x.Climate
+	}
+	return nil
+}
+
+func (x *PlantStatus) GetAlerts() []*Alert {
+	if x != nil {
+		return x.Alerts
+	}
+	return nil
+}
+
+func (x *PlantStatus) GetUnavailable() []string {
+	if x != nil {
+		return x.Unavailable
+	}
+	return nil
+}
+
+type Plant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Species       string                 `protobuf:"bytes,3,opt,name=species,proto3" json:"species,omitempty"`
+	MinLux        float64                `protobuf:"fixed64,4,opt,name=min_lux,json=minLux,proto3" json:"min_lux,omitempty"` // light requirement range, inclusive
+	MaxLux        float64                `protobuf:"fixed64,5,opt,name=max_lux,json=maxLux,proto3" json:"max_lux,omitempty"`
+	Location      string                 `protobuf:"bytes,6,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Plant) Reset() {
+	*x = Plant{}
+	mi := &file_api_proto_gateway_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Plant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Plant) ProtoMessage() {}
+
+func (x *Plant) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gateway_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Plant.ProtoReflect.Descriptor instead.
+func (*Plant) Descriptor() ([]byte, []int) {
+	return file_api_proto_gateway_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Plant) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Plant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Plant) GetSpecies() string {
+	if x != nil {
+		return x.Species
+	}
+	return ""
+}
+
+func (x *Plant) GetMinLux() float64 {
+	if x != nil {
+		return x.MinLux
+	}
+	return 0
+}
+
+func (x *Plant) GetMaxLux() float64 {
+	if x != nil {
+		return x.MaxLux
+	}
+	return 0
+}
+
+func (x *Plant) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+type LightStatus struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Lux         float64                `protobuf:"fixed64,1,opt,name=lux,proto3" json:"lux,omitempty"`
+	Category    string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`                           // "Low Light", "Medium Light", "High Light"
+	TimestampMs int64                  `protobuf:"varint,3,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"` // Unix milliseconds
+	// From plant-service; empty when it isn't configured or didn't answer
+	Fit            string `protobuf:"bytes,4,opt,name=fit,proto3" json:"fit,omitempty"` // "too_dark" | "ok" | "too_bright"; empty without plant_id
+	Recommendation string `protobuf:"bytes,5,opt,name=recommendation,proto3" json:"recommendation,omitempty"`
+	Trend          string `protobuf:"bytes,6,opt,name=trend,proto3" json:"trend,omitempty"` // "stable" | "brightening" | "darkening"
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *LightStatus) Reset() {
+	*x = LightStatus{}
+	mi := &file_api_proto_gateway_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LightStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LightStatus) ProtoMessage() {}
+
+func (x *LightStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gateway_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LightStatus.ProtoReflect.Descriptor instead.
+func (*LightStatus) Descriptor() ([]byte, []int) {
+	return file_api_proto_gateway_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LightStatus) GetLux() float64 {
+	if x != nil {
+		return x.Lux
+	}
+	return 0
+}
+
+func (x *LightStatus) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *LightStatus) GetTimestampMs() int64 {
+	if x != nil {
+		return x.TimestampMs
+	}
+	return 0
+}
+
+func (x *LightStatus) GetFit() string {
+	if x != nil {
+		return x.Fit
+	}
+	return ""
+}
+
+func (x *LightStatus) GetRecommendation() string {
+	if x != nil {
+		return x.Recommendation
+	}
+	return ""
+}
+
+func (x *LightStatus) GetTrend() string {
+	if x != nil {
+		return x.Trend
+	}
+	return ""
+}
+
+type MoistureStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Percent       float64                `protobuf:"fixed64,1,opt,name=percent,proto3" json:"percent,omitempty"` // volumetric water content, 0-100
+	Category      string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"` // "Dry", "Moist", "Wet"
+	TimestampMs   int64                  `protobuf:"varint,3,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoistureStatus) Reset() {
+	*x = MoistureStatus{}
+	mi := &file_api_proto_gateway_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoistureStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoistureStatus) ProtoMessage() {}
+
+func (x *MoistureStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gateway_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoistureStatus.ProtoReflect.Descriptor instead.
+func (*MoistureStatus) Descriptor() ([]byte, []int) {
+	return file_api_proto_gateway_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MoistureStatus) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *MoistureStatus) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *MoistureStatus) GetTimestampMs() int64 {
+	if x != nil {
+		return x.TimestampMs
+	}
+	return 0
+}
+
+type ClimateStatus struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	TemperatureCelsius float64                `protobuf:"fixed64,1,opt,name=temperature_celsius,json=temperatureCelsius,proto3" json:"temperature_celsius,omitempty"`
+	HumidityPercent    float64                `protobuf:"fixed64,2,opt,name=humidity_percent,json=humidityPercent,proto3" json:"humidity_percent,omitempty"`
+	DewPointCelsius    float64                `protobuf:"fixed64,3,opt,name=dew_point_celsius,json=dewPointCelsius,proto3" json:"dew_point_celsius,omitempty"`
+	VpdKpa             float64                `protobuf:"fixed64,4,opt,name=vpd_kpa,json=vpdKpa,proto3" json:"vpd_kpa,omitempty"`
+	TimestampMs        int64                  `protobuf:"varint,5,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ClimateStatus) Reset() {
+	*x = ClimateStatus{}
+	mi := &file_api_proto_gateway_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClimateStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClimateStatus) ProtoMessage() {}
+
+func (x *ClimateStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gateway_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClimateStatus.ProtoReflect.Descriptor instead.
+func (*ClimateStatus) Descriptor() ([]byte, []int) {
+	return file_api_proto_gateway_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ClimateStatus) GetTemperatureCelsius() float64 {
+	if x != nil {
+		return x.TemperatureCelsius
+	}
+	return 0
+}
+
+func (x *ClimateStatus) GetHumidityPercent() float64 {
+	if x != nil {
+		return x.HumidityPercent
+	}
+	return 0
+}
+
+func (x *ClimateStatus) GetDewPointCelsius() float64 {
+	if x != nil {
+		return x.DewPointCelsius
+	}
+	return 0
+}
+
+func (x *ClimateStatus) GetVpdKpa() float64 {
+	if x != nil {
+		return x.VpdKpa
+	}
+	return 0
+}
+
+func (x *ClimateStatus) GetTimestampMs() int64 {
+	if x != nil {
+		return x.TimestampMs
+	}
+	return 0
+}
+
+type Alert struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	RuleId    int64                  `protobuf:"varint,2,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	RuleName  string                 `protobuf:"bytes,3,opt,name=rule_name,json=ruleName,proto3" json:"rule_name,omitempty"`
+	Message   string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	FiredAtMs int64                  `protobuf:"varint,5,opt,name=fired_at_ms,json=firedAtMs,proto3" json:"fired_at_ms,omitempty"`
+	// Whether the current light still breaches the rule's threshold
+	Active        bool `protobuf:"varint,6,opt,name=active,proto3" json:"active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Alert) Reset() {
+	*x = Alert{}
+	mi := &file_api_proto_gateway_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Alert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alert) ProtoMessage() {}
+
+func (x *Alert) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gateway_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alert.ProtoReflect.Descriptor instead.
+func (*Alert) Descriptor() ([]byte, []int) {
+	return file_api_proto_gateway_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Alert) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Alert) GetRuleId() int64 {
+	if x != nil {
+		return x.RuleId
+	}
+	return 0
+}
+
+func (x *Alert) GetRuleName() string {
+	if x != nil {
+		return x.RuleName
+	}
+	return ""
+}
+
+func (x *Alert) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Alert) GetFiredAtMs() int64 {
+	if x != nil {
+		return x.FiredAtMs
+	}
+	return 0
+}
+
+func (x *Alert) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+var File_api_proto_gateway_proto protoreflect.FileDescriptor
+
+const file_api_proto_gateway_proto_rawDesc = "" +
+	"\n" +
+	"\x17api/proto/gateway.proto\x12\n" +
+	"gateway.v1\"2\n" +
+	"\x15GetPlantStatusRequest\x12\x19\n" +
+	"\bplant_id\x18\x01 \x01(\x03R\aplantId\"I\n" +
+	"\x16GetPlantStatusResponse\x12/\n" +
+	"\x06status\x18\x01 \x01(\v2\x17.gateway.v1.PlantStatusR\x06status\"\x9f\x02\n" +
+	"\vPlantStatus\x12'\n" +
+	"\x05plant\x18\x01 \x01(\v2\x11.gateway.v1.PlantR\x05plant\x12-\n" +
+	"\x05light\x18\x02 \x01(\v2\x17.gateway.v1.LightStatusR\x05light\x126\n" +
+	"\bmoisture\x18\x03 \x01(\v2\x1a.gateway.v1.MoistureStatusR\bmoisture\x123\n" +
+	"\aclimate\x18\x04 \x01(\v2\x19.gateway.v1.ClimateStatusR\aclimate\x12)\n" +
+	"\x06alerts\x18\x05 \x03(\v2\x11.gateway.v1.AlertR\x06alerts\x12 \n" +
+	"\vunavailable\x18\x06 \x03(\tR\vunavailable\"\x93\x01\n" +
+	"\x05Plant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aspecies\x18\x03 \x01(\tR\aspecies\x12\x17\n" +
+	"\amin_lux\x18\x04 \x01(\x01R\x06minLux\x12\x17\n" +
+	"\amax_lux\x18\x05 \x01(\x01R\x06maxLux\x12\x1a\n" +
+	"\blocation\x18\x06 \x01(\tR\blocation\"\xae\x01\n" +
+	"\vLightStatus\x12\x10\n" +
+	"\x03lux\x18\x01 \x01(\x01R\x03lux\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12!\n" +
+	"\ftimestamp_ms\x18\x03 \x01(\x03R\vtimestampMs\x12\x10\n" +
+	"\x03fit\x18\x04 \x01(\tR\x03fit\x12&\n" +
+	"\x0erecommendation\x18\x05 \x01(\tR\x0erecommendation\x12\x14\n" +
+	"\x05trend\x18\x06 \x01(\tR\x05trend\"i\n" +
+	"\x0eMoistureStatus\x12\x18\n" +
+	"\apercent\x18\x01 \x01(\x01R\apercent\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12!\n" +
+	"\ftimestamp_ms\x18\x03 \x01(\x03R\vtimestampMs\"\xd3\x01\n" +
+	"\rClimateStatus\x12/\n" +
+	"\x13temperature_celsius\x18\x01 \x01(\x01R\x12temperatureCelsius\x12)\n" +
+	"\x10humidity_percent\x18\x02 \x01(\x01R\x0fhumidityPercent\x12*\n" +
+	"\x11dew_point_celsius\x18\x03 \x01(\x01R\x0fdewPointCelsius\x12\x17\n" +
+	"\avpd_kpa\x18\x04 \x01(\x01R\x06vpdKpa\x12!\n" +
+	"\ftimestamp_ms\x18\x05 \x01(\x03R\vtimestampMs\"\x9f\x01\n" +
+	"\x05Alert\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\arule_id\x18\x02 \x01(\x03R\x06ruleId\x12\x1b\n" +
+	"\trule_name\x18\x03 \x01(\tR\bruleName\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x1e\n" +
+	"\vfired_at_ms\x18\x05 \x01(\x03R\tfiredAtMs\x12\x16\n" +
+	"\x06active\x18\x06 \x01(\bR\x06active2i\n" +
+	"\x0eGatewayService\x12W\n" +
+	"\x0eGetPlantStatus\x12!.gateway.v1.GetPlantStatusRequest\x1a\".gateway.v1.GetPlantStatusResponseB@Z>github.com/quentinrf/plant-monitor/services/api-gateway/pkg/pbb\x06proto3"
+
+var (
+	file_api_proto_gateway_proto_rawDescOnce sync.Once
+	file_api_proto_gateway_proto_rawDescData []byte
+)
+
+func file_api_proto_gateway_proto_rawDescGZIP() []byte {
+	file_api_proto_gateway_proto_rawDescOnce.Do(func() {
+		file_api_proto_gateway_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_gateway_proto_rawDesc), len(file_api_proto_gateway_proto_rawDesc)))
+	})
+	return file_api_proto_gateway_proto_rawDescData
+}
+
+var file_api_proto_gateway_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_api_proto_gateway_proto_goTypes = []any{
+	(*GetPlantStatusRequest)(nil),  // 0: gateway.v1.GetPlantStatusRequest
+	(*GetPlantStatusResponse)(nil), // 1: gateway.v1.GetPlantStatusResponse
+	(*PlantStatus)(nil),            // 2: gateway.v1.PlantStatus
+	(*Plant)(nil),                  // 3: gateway.v1.Plant
+	(*LightStatus)(nil),            // 4: gateway.v1.LightStatus
+	(*MoistureStatus)(nil),         // 5: gateway.v1.MoistureStatus
+	(*ClimateStatus)(nil),          // 6: gateway.v1.ClimateStatus
+	(*Alert)(nil),                  // 7: gateway.v1.Alert
+}
+var file_api_proto_gateway_proto_depIdxs = []int32{
+	2, // 0: gateway.v1.GetPlantStatusResponse.status:type_name -> gateway.v1.PlantStatus
+	3, // 1: gateway.v1.PlantStatus.plant:type_name -> gateway.v1.Plant
+	4, // 2: gateway.v1.PlantStatus.light:type_name -> gateway.v1.LightStatus
+	5, // 3: gateway.v1.PlantStatus.moisture:type_name -> gateway.v1.MoistureStatus
+	6, // 4: gateway.v1.PlantStatus.climate:type_name -> gateway.v1.ClimateStatus
+	7, // 5: gateway.v1.PlantStatus.alerts:type_name -> gateway.v1.Alert
+	0, // 6: gateway.v1.GatewayService.GetPlantStatus:input_type -> gateway.v1.GetPlantStatusRequest
+	1, // 7: gateway.v1.GatewayService.GetPlantStatus:output_type -> gateway.v1.GetPlantStatusResponse
+	7, // [7:8] is the sub-list for method output_type
+	6, // [6:7] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_gateway_proto_init() }
+func file_api_proto_gateway_proto_init() {
+	if File_api_proto_gateway_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_gateway_proto_rawDesc), len(file_api_proto_gateway_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_proto_gateway_proto_goTypes,
+		DependencyIndexes: file_api_proto_gateway_proto_depIdxs,
+		MessageInfos:      file_api_proto_gateway_proto_msgTypes,
+	}.Build()
+	File_api_proto_gateway_proto = out.File
+	file_api_proto_gateway_proto_goTypes = nil
+	file_api_proto_gateway_proto_depIdxs = nil
+}
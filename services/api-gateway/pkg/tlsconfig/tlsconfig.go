@@ -0,0 +1,63 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// LoadServerTLS creates a tls.Config for a gRPC server requiring client certs (mTLS).
+// The server's certificate is reloaded when its files change; see CertReloader.
+// The CA is read once, so rotating the CA itself needs a restart.
+func LoadServerTLS(certFile, keyFile, caFile string, opts ...Option) (*tls.Config, error) {
+	certs, err := NewCertReloader(certFile, keyFile, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	caPool, err := loadCAPool(caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		GetCertificate: certs.GetCertificate,
+		ClientCAs:      caPool,
+		ClientAuth:     tls.RequireAndVerifyClientCert,
+	}, nil
+}
+
+// LoadClientTLS creates a tls.Config for a gRPC client that presents a cert (mTLS).
+// The client's certificate is reloaded when its files change; see CertReloader.
+// The CA is read once, so rotating the CA itself needs a restart.
+func LoadClientTLS(certFile, keyFile, caFile string, opts ...Option) (*tls.Config, error) {
+	certs, err := NewCertReloader(certFile, keyFile, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	caPool, err := loadCAPool(caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		GetClientCertificate: certs.GetClientCertificate,
+		RootCAs:              caPool,
+	}, nil
+}
+
+// loadCAPool reads the CA certificates peers are verified against
+func loadCAPool(caFile string) (*x509.CertPool, error) {
+	caCert, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("read CA cert: %w", err)
+	}
+
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("failed to parse CA certificate")
+	}
+	return caPool, nil
+}
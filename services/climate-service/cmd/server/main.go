@@ -0,0 +1,283 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/quentinrf/plant-monitor/services/climate-service/internal/adapters/dht"
+	grpcAdapter "github.com/quentinrf/plant-monitor/services/climate-service/internal/adapters/grpc"
+	"github.com/quentinrf/plant-monitor/services/climate-service/internal/adapters/i2c"
+	"github.com/quentinrf/plant-monitor/services/climate-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/climate-service/internal/adapters/mock"
+	"github.com/quentinrf/plant-monitor/services/climate-service/internal/adapters/sqlite"
+	"github.com/quentinrf/plant-monitor/services/climate-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/climate-service/internal/ports"
+	"github.com/quentinrf/plant-monitor/services/climate-service/pkg/pb"
+	"github.com/quentinrf/plant-monitor/services/climate-service/pkg/tlsconfig"
+)
+
+func main() {
+	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
+
+	log.Info().Msg("starting climate-service")
+
+	config := loadConfig()
+	if err := config.checkEnv(); err != nil {
+		log.Fatal().Err(err).Msg("invalid configuration")
+	}
+
+	// Initialize repository
+	var repo domain.ReadingRepository
+	switch config.RepoType {
+	case "sqlite":
+		r, err := sqlite.NewReadingRepository(config.DBPath)
+		if err != nil {
+			log.Fatal().Err(err).Str("db_path", config.DBPath).Msg("failed to open SQLite database")
+		}
+		defer r.Close()
+		repo = r
+		log.Info().Str("db_path", config.DBPath).Msg("initialized SQLite repository")
+	default:
+		repo = memory.NewReadingRepository()
+		log.Info().Msg("initialized in-memory repository")
+	}
+
+	// Initialize sensor
+	var sensor ports.ClimateSensor
+	switch config.SensorType {
+	case "mock":
+		sensor = mock.NewFakeSensor(21.0, 50.0, 2.0) // 21±2 °C, 50±2% (a heated living room)
+		log.Info().Msg("initialized mock sensor")
+	case "sht31":
+		dev, err := i2c.Open(config.I2CBus, config.I2CAddress)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to open I2C device")
+		}
+		sht31, err := i2c.NewSHT31(dev)
+		if err != nil {
+			dev.Close()
+			log.Fatal().Err(err).Msg("failed to initialize SHT31")
+		}
+		sensor = sht31
+		log.Info().
+			Int("bus", config.I2CBus).
+			Str("address", fmt.Sprintf("%#x", config.I2CAddress)).
+			Msg("initialized SHT31 sensor")
+	case "dht22":
+		dht22, err := dht.NewDHT22(config.DHT22Device)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to initialize DHT22; is the dht11 overlay enabled?")
+		}
+		sensor = dht22
+		log.Info().Str("device", config.DHT22Device).Msg("initialized DHT22 sensor")
+	default:
+		log.Fatal().Str("type", config.SensorType).Msg("unknown SENSOR_TYPE; expected mock, sht31 or dht22")
+	}
+	defer sensor.Close()
+
+	handler := grpcAdapter.NewClimateServiceHandler(repo, sensor)
+
+	// Configure TLS if certificates are provided
+	var serverOpts []grpc.ServerOption
+	if config.TLSCert != "" {
+		tlsCfg, err := tlsconfig.LoadServerTLS(config.TLSCert, config.TLSKey, config.TLSCA, logCertReload(config.TLSCert))
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to load TLS config")
+		}
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsCfg)))
+		log.Info().Msg("mTLS enabled")
+	} else {
+		log.Warn().Msg("TLS_CERT not set — starting without TLS (ENV=development only)")
+	}
+
+	grpcServer := grpc.NewServer(serverOpts...)
+	pb.RegisterClimateServiceServer(grpcServer, handler)
+
+	// Reflection is for grpcurl during development only
+	if !config.production() {
+		reflection.Register(grpcServer)
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", config.Port))
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to listen")
+	}
+
+	log.Info().Str("port", config.Port).Msg("gRPC server listening")
+
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Fatal().Err(err).Msg("failed to serve")
+		}
+	}()
+
+	// Start background recorder
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	recorder := ports.NewRecorder(sensor, repo, config.RecordInterval, ports.WithRetention(config.Retention))
+	recorderDone := make(chan struct{})
+	go func() {
+		defer close(recorderDone)
+		recorder.Start(ctx)
+	}()
+
+	// Wait for interrupt signal
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Info().Msg("shutting down climate-service...")
+
+	// Stop the recorder before the repository closes under it
+	cancel()
+	<-recorderDone
+	grpcServer.GracefulStop()
+
+	log.Info().Msg("climate-service stopped")
+}
+
+// Config holds application configuration
+type Config struct {
+	Port           string
+	Env            string // "development" | "production": whether plaintext gRPC and reflection are allowed
+	RecordInterval time.Duration
+	Retention      time.Duration // how long readings are kept (0 = forever)
+	RepoType       string        // "memory" | "sqlite"
+	DBPath         string        // SQLite database file path (used when RepoType=sqlite)
+	SensorType     string        // "mock" | "sht31" | "dht22"
+	I2CBus         int           // I2C bus number, i.e. /dev/i2c-<n> (sht31 only)
+	I2CAddress     uint16        // SHT31 address, 0x44 or 0x45
+	DHT22Device    string        // IIO device directory the kernel driver exposes (dht22 only)
+	TLSCert        string        // path to this service's certificate
+	TLSKey         string        // path to this service's private key
+	TLSCA          string        // path to the CA certificate
+}
+
+// loadConfig reads configuration from environment variables; invalid values
+// fall back to defaults
+func loadConfig() Config {
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "50054"
+	}
+
+	// Air changes faster than soil but slower than light
+	recordInterval := 5 * time.Minute
+	if intervalStr := os.Getenv("RECORD_INTERVAL"); intervalStr != "" {
+		if d, err := time.ParseDuration(intervalStr); err == nil && d > 0 {
+			recordInterval = d
+		}
+	}
+
+	retention := ports.DefaultRetention
+	if retentionStr := os.Getenv("RETENTION"); retentionStr != "" {
+		if d, err := time.ParseDuration(retentionStr); err == nil && d >= 0 {
+			retention = d
+		}
+	}
+
+	repoType := os.Getenv("REPO_TYPE")
+	if repoType == "" {
+		repoType = "memory"
+	}
+
+	dbPath := os.Getenv("DB_PATH")
+	if dbPath == "" {
+		dbPath = "./climate.db"
+	}
+
+	env := os.Getenv("ENV")
+	if env == "" {
+		env = "development"
+	}
+
+	sensorType := os.Getenv("SENSOR_TYPE")
+	if sensorType == "" {
+		sensorType = "mock"
+	}
+
+	// Raspberry Pi header pins
+	i2cBus := 1
+	if busStr := os.Getenv("I2C_BUS"); busStr != "" {
+		if n, err := strconv.Atoi(busStr); err == nil && n >= 0 {
+			i2cBus = n
+		}
+	}
+
+	i2cAddress := i2c.SHT31AddressLow
+	if addrStr := os.Getenv("I2C_ADDRESS"); addrStr != "" {
+		// Accepts hex, e.g. 0x45
+		if n, err := strconv.ParseUint(addrStr, 0, 7); err == nil {
+			i2cAddress = uint16(n)
+		}
+	}
+
+	dht22Device := os.Getenv("DHT22_DEVICE")
+	if dht22Device == "" {
+		dht22Device = dht.DefaultDevice
+	}
+
+	return Config{
+		Port:           port,
+		Env:            env,
+		RecordInterval: recordInterval,
+		Retention:      retention,
+		RepoType:       repoType,
+		DBPath:         dbPath,
+		SensorType:     sensorType,
+		I2CBus:         i2cBus,
+		I2CAddress:     i2cAddress,
+		DHT22Device:    dht22Device,
+		TLSCert:        os.Getenv("TLS_CERT"),
+		TLSKey:         os.Getenv("TLS_KEY"),
+		TLSCA:          os.Getenv("TLS_CA"),
+	}
+}
+
+// checkEnv rejects an unknown ENV and, in production, a missing TLS_CERT:
+// production refuses plaintext rather than warning about it. The services
+// share no module, so each main carries its own copy; keep them in step.
+func (c Config) checkEnv() error {
+	switch c.Env {
+	case "development":
+		return nil
+	case "production":
+		if c.TLSCert == "" {
+			return errors.New("TLS_CERT is required when ENV is production")
+		}
+		return nil
+	}
+	return fmt.Errorf("ENV must be development or production, got %q", c.Env)
+}
+
+// production reports whether ENV is production, which turns off reflection
+func (c Config) production() bool {
+	return c.Env == "production"
+}
+
+// logCertReload logs each reload of a rotated certificate, which is picked
+// up at the next handshake after the periodic check
+func logCertReload(certFile string) tlsconfig.Option {
+	return tlsconfig.WithReloadHook(func(err error) {
+		if err != nil {
+			log.Error().Err(err).Msg("failed to reload TLS certificate, keeping the current one")
+			return
+		}
+		log.Info().Str("cert", certFile).Msg("TLS certificate reloaded")
+	})
+}
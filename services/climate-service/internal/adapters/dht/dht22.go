@@ -0,0 +1,105 @@
+// Package dht reads DHT22 (AM2302) temperature and humidity sensors through
+// the Linux kernel's dht11 IIO driver, which handles the sensor's
+// microsecond-level single-wire protocol. On a Raspberry Pi, enable it with
+// "dtoverlay=dht22,gpiopin=4" in config.txt for a sensor on GPIO 4.
+package dht
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultDevice is where the driver exposes the first IIO device
+const DefaultDevice = "/sys/bus/iio/devices/iio:device0"
+
+// The driver misses the sensor's timing now and then and fails the read, so
+// reads are retried, no faster than the DHT22 can measure
+const (
+	readAttempts = 3
+	retryDelay   = 2 * time.Second
+)
+
+// DHT22 reads a DHT22 through its IIO device directory, accurate to about
+// ±0.5 °C and ±2-5% RH
+// This implements the ports.ClimateSensor interface
+type DHT22 struct {
+	dir string
+
+	mu   sync.Mutex
+	wait func(ctx context.Context, d time.Duration) error
+}
+
+// NewDHT22 reads the sensor exposed at dir, e.g. DefaultDevice, failing if
+// it is not an IIO device with temperature and humidity channels
+func NewDHT22(dir string) (*DHT22, error) {
+	for _, channel := range []string{"in_temp_input", "in_humidityrelative_input"} {
+		if _, err := os.Stat(filepath.Join(dir, channel)); err != nil {
+			return nil, fmt.Errorf("no DHT22 at %s: %w", dir, err)
+		}
+	}
+	return &DHT22{dir: dir, wait: sleep}, nil
+}
+
+// ReadClimate returns the temperature in °C and relative humidity in
+// percent. The driver caches each measurement for two seconds, so reading
+// humidity straight after temperature gets the same measurement.
+func (s *DHT22) ReadClimate(ctx context.Context) (float64, float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var err error
+	for attempt := range readAttempts {
+		if attempt > 0 {
+			if err := s.wait(ctx, retryDelay); err != nil {
+				return 0, 0, err
+			}
+		}
+
+		var temperature, humidity float64
+		if temperature, err = s.readChannel("in_temp_input"); err != nil {
+			continue
+		}
+		if humidity, err = s.readChannel("in_humidityrelative_input"); err != nil {
+			continue
+		}
+		return temperature, humidity, nil
+	}
+	return 0, 0, fmt.Errorf("failed to read DHT22 after %d attempts: %w", readAttempts, err)
+}
+
+// readChannel reads a channel, which the driver reports in thousandths
+// (of a degree, or a percent)
+func (s *DHT22) readChannel(name string) (float64, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, name))
+	if err != nil {
+		return 0, err
+	}
+	milli, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value %q: %w", name, data, err)
+	}
+	return float64(milli) / 1000, nil
+}
+
+// Close is a no-op; each read opens and closes the channel files
+func (s *DHT22) Close() error {
+	return nil
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
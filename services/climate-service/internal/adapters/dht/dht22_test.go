@@ -0,0 +1,101 @@
+package dht
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fakeDevice creates an IIO device directory with the given channel values
+func fakeDevice(t *testing.T, temperature, humidity string) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeChannel(t, dir, "in_temp_input", temperature)
+	writeChannel(t, dir, "in_humidityrelative_input", humidity)
+	return dir
+}
+
+func writeChannel(t *testing.T, dir, name, value string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(value+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDHT22_ReadClimate(t *testing.T) {
+	s, err := NewDHT22(fakeDevice(t, "21400", "55300"))
+	if err != nil {
+		t.Fatalf("NewDHT22 failed: %v", err)
+	}
+
+	temperature, humidity, err := s.ReadClimate(context.Background())
+	if err != nil {
+		t.Fatalf("ReadClimate failed: %v", err)
+	}
+	if temperature != 21.4 || humidity != 55.3 {
+		t.Errorf("expected 21.4 °C at 55.3%%, got %v °C at %v%%", temperature, humidity)
+	}
+}
+
+func TestDHT22_RetriesFailedReads(t *testing.T) {
+	dir := fakeDevice(t, "-5200", "")
+	s, err := NewDHT22(dir)
+	if err != nil {
+		t.Fatalf("NewDHT22 failed: %v", err)
+	}
+
+	// The first read fails; by the retry the driver has a value
+	var waits []time.Duration
+	s.wait = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		writeChannel(t, dir, "in_humidityrelative_input", "80000")
+		return nil
+	}
+
+	temperature, humidity, err := s.ReadClimate(context.Background())
+	if err != nil {
+		t.Fatalf("ReadClimate failed: %v", err)
+	}
+	if temperature != -5.2 || humidity != 80 {
+		t.Errorf("expected -5.2 °C at 80%%, got %v °C at %v%%", temperature, humidity)
+	}
+	if len(waits) != 1 || waits[0] != 2*time.Second {
+		t.Errorf("expected one 2s wait, got %v", waits)
+	}
+}
+
+func TestDHT22_GivesUp(t *testing.T) {
+	s, err := NewDHT22(fakeDevice(t, "garbage", "50000"))
+	if err != nil {
+		t.Fatalf("NewDHT22 failed: %v", err)
+	}
+	var waits []time.Duration
+	s.wait = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	if _, _, err := s.ReadClimate(context.Background()); err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(waits) != readAttempts-1 {
+		t.Errorf("expected %d retries, got %d", readAttempts-1, len(waits))
+	}
+
+	// A cancelled context stops the retries
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.wait = sleep
+	if _, _, err := s.ReadClimate(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestNewDHT22_MissingDevice(t *testing.T) {
+	if _, err := NewDHT22(t.TempDir()); err == nil {
+		t.Error("expected an error for a directory without DHT22 channels")
+	}
+}
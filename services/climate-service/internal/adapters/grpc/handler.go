@@ -0,0 +1,148 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/climate-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/climate-service/internal/ports"
+	"github.com/quentinrf/plant-monitor/services/climate-service/pkg/pb"
+)
+
+// ClimateServiceHandler implements the gRPC ClimateService
+type ClimateServiceHandler struct {
+	pb.UnimplementedClimateServiceServer
+	repo   domain.ReadingRepository
+	sensor ports.ClimateSensor
+}
+
+// NewClimateServiceHandler creates a new gRPC handler
+func NewClimateServiceHandler(repo domain.ReadingRepository, sensor ports.ClimateSensor) *ClimateServiceHandler {
+	return &ClimateServiceHandler{
+		repo:   repo,
+		sensor: sensor,
+	}
+}
+
+// GetCurrentClimate returns the most recent reading, reading the sensor if
+// nothing has been recorded yet
+func (h *ClimateServiceHandler) GetCurrentClimate(ctx context.Context, req *pb.GetCurrentClimateRequest) (*pb.GetCurrentClimateResponse, error) {
+	log.Info().Msg("GetCurrentClimate called")
+
+	reading, err := h.repo.GetLatestReading(ctx)
+	if errors.Is(err, domain.ErrReadingNotFound) {
+		log.Info().Msg("no readings in database, reading sensor")
+
+		temperature, humidity, err := h.sensor.ReadClimate(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to read sensor")
+			return nil, status.Error(codes.Unavailable, "failed to read sensor")
+		}
+
+		reading, err = domain.NewClimateReading(temperature, humidity)
+		if err != nil {
+			log.Error().Err(err).
+				Float64("temperature_celsius", temperature).
+				Float64("humidity_percent", humidity).
+				Msg("sensor returned an invalid reading")
+			return nil, status.Error(codes.Internal, "sensor returned an invalid reading")
+		}
+
+		// Save for next time
+		if err := h.repo.SaveReading(ctx, reading); err != nil {
+			log.Error().Err(err).Msg("failed to save reading")
+			// Don't fail - we still have the reading
+		}
+	} else if err != nil {
+		log.Error().Err(err).Msg("failed to get latest reading")
+		return nil, status.Error(codes.Internal, "failed to get reading")
+	}
+
+	return &pb.GetCurrentClimateResponse{
+		Reading: convertReadingToProto(reading),
+	}, nil
+}
+
+// GetHistory returns readings within time range with statistics
+func (h *ClimateServiceHandler) GetHistory(ctx context.Context, req *pb.GetHistoryRequest) (*pb.GetHistoryResponse, error) {
+	log.Info().
+		Int64("start_ms", req.StartTimeMs).
+		Int64("end_ms", req.EndTimeMs).
+		Msg("GetHistory called")
+
+	start, end := time.UnixMilli(req.StartTimeMs), time.UnixMilli(req.EndTimeMs)
+	if end.Before(start) {
+		return nil, status.Error(codes.InvalidArgument, "end_time_ms cannot be before start_time_ms")
+	}
+
+	readings, err := h.repo.GetReadingsInRange(ctx, start, end)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to get readings")
+		return nil, status.Error(codes.Internal, "failed to get readings")
+	}
+
+	// Convert to protobuf
+	pbReadings := make([]*pb.ClimateReading, len(readings))
+	for i, r := range readings {
+		pbReadings[i] = convertReadingToProto(r)
+	}
+
+	temperature := calculateStatistics(readings, func(r *domain.ClimateReading) float64 { return r.TemperatureCelsius })
+	humidity := calculateStatistics(readings, func(r *domain.ClimateReading) float64 { return r.HumidityPercent })
+	return &pb.GetHistoryResponse{
+		Readings:                  pbReadings,
+		AverageTemperatureCelsius: temperature.average,
+		MinTemperatureCelsius:     temperature.min,
+		MaxTemperatureCelsius:     temperature.max,
+		AverageHumidityPercent:    humidity.average,
+		MinHumidityPercent:        humidity.min,
+		MaxHumidityPercent:        humidity.max,
+	}, nil
+}
+
+// convertReadingToProto converts domain model to protobuf
+func convertReadingToProto(r *domain.ClimateReading) *pb.ClimateReading {
+	return &pb.ClimateReading{
+		Id:                 r.ID,
+		TemperatureCelsius: r.TemperatureCelsius,
+		HumidityPercent:    r.HumidityPercent,
+		TimestampMs:        r.Timestamp.UnixMilli(),
+		DewPointCelsius:    r.DewPointCelsius(),
+		VpdKpa:             r.VPDKilopascals(),
+	}
+}
+
+// statistics holds calculated statistics
+type statistics struct {
+	average float64
+	min     float64
+	max     float64
+}
+
+// calculateStatistics computes stats of one measurement across readings;
+// all zero if there are none
+func calculateStatistics(readings []*domain.ClimateReading, value func(*domain.ClimateReading) float64) statistics {
+	if len(readings) == 0 {
+		return statistics{}
+	}
+
+	var sum float64
+	lo, hi := value(readings[0]), value(readings[0])
+	for _, r := range readings {
+		v := value(r)
+		sum += v
+		lo = min(lo, v)
+		hi = max(hi, v)
+	}
+
+	return statistics{
+		average: sum / float64(len(readings)),
+		min:     lo,
+		max:     hi,
+	}
+}
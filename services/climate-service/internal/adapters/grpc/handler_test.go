@@ -0,0 +1,147 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/climate-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/climate-service/internal/adapters/mock"
+	"github.com/quentinrf/plant-monitor/services/climate-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/climate-service/internal/ports"
+	"github.com/quentinrf/plant-monitor/services/climate-service/pkg/pb"
+)
+
+// startTestServer runs the handler on a loopback listener and returns a
+// client connected to it
+func startTestServer(t *testing.T, repo domain.ReadingRepository, sensor ports.ClimateSensor) pb.ClimateServiceClient {
+	t.Helper()
+
+	server := grpc.NewServer()
+	pb.RegisterClimateServiceServer(server, NewClimateServiceHandler(repo, sensor))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewClimateServiceClient(conn)
+}
+
+// failingSensor can't be read
+type failingSensor struct{}
+
+func (failingSensor) ReadClimate(ctx context.Context) (float64, float64, error) {
+	return 0, 0, errors.New("no response from sensor")
+}
+func (failingSensor) Close() error { return nil }
+
+func TestGetCurrentClimate_ReadsSensorWhenEmpty(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	client := startTestServer(t, repo, mock.NewFakeSensor(20, 50, 0))
+	ctx := context.Background()
+
+	resp, err := client.GetCurrentClimate(ctx, &pb.GetCurrentClimateRequest{})
+	if err != nil {
+		t.Fatalf("GetCurrentClimate failed: %v", err)
+	}
+	r := resp.Reading
+	if r.TemperatureCelsius != 20 || r.HumidityPercent != 50 {
+		t.Errorf("expected 20 °C at 50%%, got %v", r)
+	}
+	if r.DewPointCelsius < 9 || r.DewPointCelsius > 9.5 || r.VpdKpa < 1.1 || r.VpdKpa > 1.2 {
+		t.Errorf("expected a dew point near 9.3 °C and VPD near 1.17 kPa, got %v", r)
+	}
+
+	// Saved for next time
+	if _, err := repo.GetLatestReading(ctx); err != nil {
+		t.Errorf("expected the live reading to be saved, got %v", err)
+	}
+}
+
+func TestGetCurrentClimate_PrefersStoredReading(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	ctx := context.Background()
+	stored, _ := domain.NewClimateReading(18, 70)
+	_ = repo.SaveReading(ctx, stored)
+
+	client := startTestServer(t, repo, failingSensor{})
+	resp, err := client.GetCurrentClimate(ctx, &pb.GetCurrentClimateRequest{})
+	if err != nil {
+		t.Fatalf("GetCurrentClimate failed: %v", err)
+	}
+	if resp.Reading.Id != stored.ID {
+		t.Errorf("expected stored reading %d, got %d", stored.ID, resp.Reading.Id)
+	}
+}
+
+func TestGetCurrentClimate_SensorUnavailable(t *testing.T) {
+	client := startTestServer(t, memory.NewReadingRepository(), failingSensor{})
+
+	_, err := client.GetCurrentClimate(context.Background(), &pb.GetCurrentClimateRequest{})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("expected Unavailable, got %v", err)
+	}
+}
+
+func TestGetHistory(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	client := startTestServer(t, repo, mock.NewFakeSensor(20, 50, 0))
+	ctx := context.Background()
+
+	now := time.Now()
+	for i, climate := range [][2]float64{{18, 60}, {21, 45}, {24, 30}} {
+		r, _ := domain.NewClimateReadingAt(climate[0], climate[1], now.Add(time.Duration(i-3)*time.Hour))
+		_ = repo.SaveReading(ctx, r)
+	}
+
+	resp, err := client.GetHistory(ctx, &pb.GetHistoryRequest{
+		StartTimeMs: now.Add(-4 * time.Hour).UnixMilli(),
+		EndTimeMs:   now.UnixMilli(),
+	})
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(resp.Readings) != 3 {
+		t.Fatalf("expected 3 readings, got %d", len(resp.Readings))
+	}
+	if resp.AverageTemperatureCelsius != 21 || resp.MinTemperatureCelsius != 18 || resp.MaxTemperatureCelsius != 24 {
+		t.Errorf("expected temperatures 21/18/24, got %v/%v/%v",
+			resp.AverageTemperatureCelsius, resp.MinTemperatureCelsius, resp.MaxTemperatureCelsius)
+	}
+	if resp.AverageHumidityPercent != 45 || resp.MinHumidityPercent != 30 || resp.MaxHumidityPercent != 60 {
+		t.Errorf("expected humidity 45/30/60, got %v/%v/%v",
+			resp.AverageHumidityPercent, resp.MinHumidityPercent, resp.MaxHumidityPercent)
+	}
+
+	// Empty range
+	resp, err = client.GetHistory(ctx, &pb.GetHistoryRequest{
+		StartTimeMs: now.Add(-48 * time.Hour).UnixMilli(),
+		EndTimeMs:   now.Add(-47 * time.Hour).UnixMilli(),
+	})
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(resp.Readings) != 0 || resp.AverageTemperatureCelsius != 0 {
+		t.Errorf("expected no readings and zero statistics, got %d readings", len(resp.Readings))
+	}
+
+	_, err = client.GetHistory(ctx, &pb.GetHistoryRequest{StartTimeMs: 2000, EndTimeMs: 1000})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for a reversed range, got %v", err)
+	}
+}
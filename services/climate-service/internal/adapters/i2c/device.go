@@ -0,0 +1,13 @@
+// Package i2c drives climate sensors attached over I2C, such as the SHT31
+// on a Raspberry Pi
+package i2c
+
+// Device is one peripheral on an I2C bus
+type Device interface {
+	// Tx writes w to the device, then reads len(r) bytes into r. Either may
+	// be empty.
+	Tx(w, r []byte) error
+
+	// Close releases the bus
+	Close() error
+}
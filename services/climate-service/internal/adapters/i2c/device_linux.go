@@ -0,0 +1,51 @@
+//go:build linux
+
+package i2c
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// i2cSlave is the i2c-dev ioctl that sets the address later reads and
+// writes go to
+const i2cSlave = 0x0703
+
+// linuxDevice talks to a peripheral through the kernel's i2c-dev interface
+type linuxDevice struct {
+	f *os.File
+}
+
+// Open opens the device at addr on /dev/i2c-<bus>. On a Raspberry Pi the
+// header pins are bus 1, once I2C is enabled with raspi-config.
+func Open(bus int, addr uint16) (Device, error) {
+	f, err := os.OpenFile(fmt.Sprintf("/dev/i2c-%d", bus), os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open I2C bus %d: %w", bus, err)
+	}
+	if err := unix.IoctlSetInt(int(f.Fd()), i2cSlave, int(addr)); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to select I2C address %#x: %w", addr, err)
+	}
+	return &linuxDevice{f: f}, nil
+}
+
+func (d *linuxDevice) Tx(w, r []byte) error {
+	if len(w) > 0 {
+		if _, err := d.f.Write(w); err != nil {
+			return err
+		}
+	}
+	if len(r) > 0 {
+		if _, err := d.f.Read(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *linuxDevice) Close() error {
+	return d.f.Close()
+}
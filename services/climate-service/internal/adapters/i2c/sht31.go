@@ -0,0 +1,120 @@
+package i2c
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SHT31 addresses: ADDR pin low (most breakout boards' default) or high
+const (
+	SHT31AddressLow  uint16 = 0x44
+	SHT31AddressHigh uint16 = 0x45
+)
+
+// SHT31 commands, each sent as two bytes
+var (
+	// sht31MeasureHigh takes one high-repeatability measurement without
+	// clock stretching, which not every I2C master supports
+	sht31MeasureHigh = []byte{0x24, 0x00}
+	sht31SoftReset   = []byte{0x30, 0xa2}
+)
+
+// Datasheet maximums for a high-repeatability measurement and a soft reset
+const (
+	sht31MeasurementTime = 16 * time.Millisecond
+	sht31ResetTime       = 2 * time.Millisecond
+)
+
+// SHT31 reads a Sensirion temperature and humidity sensor, accurate to about
+// ±0.3 °C and ±2% RH
+// This implements the ports.ClimateSensor interface
+type SHT31 struct {
+	dev Device
+
+	mu    sync.Mutex
+	ready time.Time // when the reset is done; zero once waited for
+	wait  func(ctx context.Context, d time.Duration) error
+}
+
+// NewSHT31 soft-resets the sensor, clearing any state left by a previous
+// process, ready for single-shot measurements
+func NewSHT31(dev Device) (*SHT31, error) {
+	if err := dev.Tx(sht31SoftReset, nil); err != nil {
+		return nil, fmt.Errorf("failed to reset SHT31: %w", err)
+	}
+	return &SHT31{dev: dev, ready: time.Now().Add(sht31ResetTime), wait: sleep}, nil
+}
+
+// ReadClimate measures the temperature in °C and relative humidity in percent
+func (s *SHT31) ReadClimate(ctx context.Context) (float64, float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.ready.IsZero() {
+		if err := s.wait(ctx, time.Until(s.ready)); err != nil {
+			return 0, 0, err
+		}
+		s.ready = time.Time{}
+	}
+
+	if err := s.dev.Tx(sht31MeasureHigh, nil); err != nil {
+		return 0, 0, fmt.Errorf("failed to start SHT31 measurement: %w", err)
+	}
+	if err := s.wait(ctx, sht31MeasurementTime); err != nil {
+		return 0, 0, err
+	}
+
+	// Temperature then humidity, each two bytes and a checksum
+	var buf [6]byte
+	if err := s.dev.Tx(nil, buf[:]); err != nil {
+		return 0, 0, fmt.Errorf("failed to read SHT31: %w", err)
+	}
+	if crc8(buf[0:2]) != buf[2] || crc8(buf[3:5]) != buf[5] {
+		return 0, 0, fmt.Errorf("SHT31 checksum mismatch in %x", buf)
+	}
+
+	rawTemperature := float64(binary.BigEndian.Uint16(buf[0:2]))
+	rawHumidity := float64(binary.BigEndian.Uint16(buf[3:5]))
+	temperature := -45 + 175*rawTemperature/0xffff
+	humidity := 100 * rawHumidity / 0xffff
+	return temperature, humidity, nil
+}
+
+// Close releases the bus; between single-shot measurements the sensor is
+// already idle
+func (s *SHT31) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dev.Close()
+}
+
+// crc8 is Sensirion's checksum: polynomial 0x31, initial value 0xff
+func crc8(data []byte) byte {
+	crc := byte(0xff)
+	for _, b := range data {
+		crc ^= b
+		for range 8 {
+			if crc&0x80 != 0 {
+				crc = crc<<1 ^ 0x31
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
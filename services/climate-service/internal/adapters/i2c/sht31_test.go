@@ -0,0 +1,146 @@
+package i2c
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// fakeDevice records writes and answers reads with a fixed response
+type fakeDevice struct {
+	writes   [][]byte
+	response []byte
+	closed   bool
+}
+
+func (d *fakeDevice) Tx(w, r []byte) error {
+	if len(w) > 0 {
+		d.writes = append(d.writes, append([]byte(nil), w...))
+	}
+	copy(r, d.response)
+	return nil
+}
+
+func (d *fakeDevice) Close() error {
+	d.closed = true
+	return nil
+}
+
+// noWait skips measurement delays, recording them
+func noWait(waits *[]time.Duration) func(context.Context, time.Duration) error {
+	return func(ctx context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		return ctx.Err()
+	}
+}
+
+// sht31Response encodes raw temperature and humidity words with checksums
+func sht31Response(temperature, humidity uint16) []byte {
+	t := []byte{byte(temperature >> 8), byte(temperature)}
+	h := []byte{byte(humidity >> 8), byte(humidity)}
+	return []byte{t[0], t[1], crc8(t), h[0], h[1], crc8(h)}
+}
+
+func TestCRC8(t *testing.T) {
+	// The datasheet's example
+	if got := crc8([]byte{0xbe, 0xef}); got != 0x92 {
+		t.Errorf("crc8(beef) = %#x, want 0x92", got)
+	}
+}
+
+func TestSHT31_ReadClimate(t *testing.T) {
+	tests := []struct {
+		name                  string
+		rawT, rawH            uint16
+		temperature, humidity float64
+	}{
+		{"minimum", 0, 0, -45, 0},
+		{"maximum", 0xffff, 0xffff, 130, 100},
+		{"room", 0x6666, 0x8000, -45 + 175*0.4, 100 * 32768.0 / 65535},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev := &fakeDevice{response: sht31Response(tt.rawT, tt.rawH)}
+			s, err := NewSHT31(dev)
+			if err != nil {
+				t.Fatalf("NewSHT31 failed: %v", err)
+			}
+			var waits []time.Duration
+			s.wait = noWait(&waits)
+
+			temperature, humidity, err := s.ReadClimate(context.Background())
+			if err != nil {
+				t.Fatalf("ReadClimate failed: %v", err)
+			}
+			if math.Abs(temperature-tt.temperature) > 1e-9 || math.Abs(humidity-tt.humidity) > 1e-9 {
+				t.Errorf("expected %v °C at %v%%, got %v °C at %v%%", tt.temperature, tt.humidity, temperature, humidity)
+			}
+		})
+	}
+}
+
+func TestSHT31_Commands(t *testing.T) {
+	dev := &fakeDevice{response: sht31Response(0x6666, 0x8000)}
+	s, err := NewSHT31(dev)
+	if err != nil {
+		t.Fatalf("NewSHT31 failed: %v", err)
+	}
+	var waits []time.Duration
+	s.wait = noWait(&waits)
+	for range 2 {
+		if _, _, err := s.ReadClimate(context.Background()); err != nil {
+			t.Fatalf("ReadClimate failed: %v", err)
+		}
+	}
+
+	// Reset once, then a single-shot measurement per read
+	want := [][]byte{{0x30, 0xa2}, {0x24, 0x00}, {0x24, 0x00}}
+	if len(dev.writes) != len(want) {
+		t.Fatalf("expected writes %x, got %x", want, dev.writes)
+	}
+	for i := range want {
+		if !bytes.Equal(dev.writes[i], want[i]) {
+			t.Errorf("expected writes %x, got %x", want, dev.writes)
+			break
+		}
+	}
+	// The reset wait, then one measurement wait per read
+	if len(waits) != 3 || waits[1] != 16*time.Millisecond || waits[2] != 16*time.Millisecond {
+		t.Errorf("expected a reset wait and two 16ms waits, got %v", waits)
+	}
+
+	if err := s.Close(); err != nil || !dev.closed {
+		t.Errorf("expected the device closed, got %v", err)
+	}
+}
+
+func TestSHT31_ChecksumMismatch(t *testing.T) {
+	response := sht31Response(0x6666, 0x8000)
+	response[5] ^= 0xff
+	s, err := NewSHT31(&fakeDevice{response: response})
+	if err != nil {
+		t.Fatalf("NewSHT31 failed: %v", err)
+	}
+	var waits []time.Duration
+	s.wait = noWait(&waits)
+
+	if _, _, err := s.ReadClimate(context.Background()); err == nil {
+		t.Error("expected an error for a corrupted humidity word")
+	}
+}
+
+func TestSHT31_ReadHonorsContext(t *testing.T) {
+	s, err := NewSHT31(&fakeDevice{response: sht31Response(0, 0)})
+	if err != nil {
+		t.Fatalf("NewSHT31 failed: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, _, err := s.ReadClimate(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/climate-service/internal/domain"
+)
+
+// ReadingRepository implements domain.ReadingRepository with in-memory storage
+// This is perfect for development - no database setup needed
+type ReadingRepository struct {
+	mu       sync.RWMutex
+	readings map[int64]*domain.ClimateReading
+	nextID   int64
+}
+
+// NewReadingRepository creates an empty in-memory repository
+func NewReadingRepository() *ReadingRepository {
+	return &ReadingRepository{
+		readings: make(map[int64]*domain.ClimateReading),
+		nextID:   1,
+	}
+}
+
+// SaveReading stores a reading in memory
+func (r *ReadingRepository) SaveReading(ctx context.Context, reading *domain.ClimateReading) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Assign ID if not set
+	if reading.ID == 0 {
+		reading.ID = r.nextID
+		r.nextID++
+	}
+
+	// Store a copy, so later changes by the caller don't leak in
+	stored := *reading
+	r.readings[reading.ID] = &stored
+	return nil
+}
+
+// GetReading retrieves a reading by ID
+func (r *ReadingRepository) GetReading(ctx context.Context, id int64) (*domain.ClimateReading, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reading, exists := r.readings[id]
+	if !exists {
+		return nil, domain.ErrReadingNotFound
+	}
+
+	copied := *reading
+	return &copied, nil
+}
+
+// GetReadingsInRange returns all readings within time range, oldest first
+func (r *ReadingRepository) GetReadingsInRange(ctx context.Context, start, end time.Time) ([]*domain.ClimateReading, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var results []*domain.ClimateReading
+	for _, reading := range r.readings {
+		if !reading.Timestamp.Before(start) && reading.Timestamp.Before(end) {
+			copied := *reading
+			results = append(results, &copied)
+		}
+	}
+
+	slices.SortFunc(results, func(a, b *domain.ClimateReading) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+	return results, nil
+}
+
+// GetLatestReading returns the most recent reading
+func (r *ReadingRepository) GetLatestReading(ctx context.Context) (*domain.ClimateReading, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var latest *domain.ClimateReading
+	for _, reading := range r.readings {
+		if latest == nil || reading.Timestamp.After(latest.Timestamp) {
+			latest = reading
+		}
+	}
+	if latest == nil {
+		return nil, domain.ErrReadingNotFound
+	}
+
+	copied := *latest
+	return &copied, nil
+}
+
+// DeleteOldReadings removes readings older than specified duration
+func (r *ReadingRepository) DeleteOldReadings(ctx context.Context, olderThan time.Duration) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cutoff := time.Now().Add(-olderThan)
+
+	var deleted int64
+	for id, reading := range r.readings {
+		if reading.Timestamp.Before(cutoff) {
+			delete(r.readings, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
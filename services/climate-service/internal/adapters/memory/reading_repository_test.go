@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/climate-service/internal/domain"
+)
+
+func TestReadingRepository_SaveAndGet(t *testing.T) {
+	repo := NewReadingRepository()
+	ctx := context.Background()
+
+	if _, err := repo.GetLatestReading(ctx); !errors.Is(err, domain.ErrReadingNotFound) {
+		t.Errorf("expected ErrReadingNotFound from an empty repository, got %v", err)
+	}
+
+	now := time.Now()
+	for i, humidity := range []float64{40, 35, 30} {
+		r, _ := domain.NewClimateReadingAt(20+float64(i), humidity, now.Add(time.Duration(i-3)*time.Hour))
+		if err := repo.SaveReading(ctx, r); err != nil {
+			t.Fatalf("SaveReading failed: %v", err)
+		}
+		if r.ID != int64(i+1) {
+			t.Errorf("expected ID %d, got %d", i+1, r.ID)
+		}
+	}
+
+	got, err := repo.GetReading(ctx, 2)
+	if err != nil || got.HumidityPercent != 35 {
+		t.Errorf("expected reading 2 at 35%% humidity, got %+v, %v", got, err)
+	}
+	latest, err := repo.GetLatestReading(ctx)
+	if err != nil || latest.HumidityPercent != 30 {
+		t.Errorf("expected latest at 30%% humidity, got %+v, %v", latest, err)
+	}
+
+	// [start, end): the reading exactly at end is excluded
+	inRange, err := repo.GetReadingsInRange(ctx, now.Add(-3*time.Hour), now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("GetReadingsInRange failed: %v", err)
+	}
+	if len(inRange) != 2 || inRange[0].HumidityPercent != 40 || inRange[1].HumidityPercent != 35 {
+		t.Errorf("expected readings at 40%% then 35%%, got %d readings", len(inRange))
+	}
+}
+
+func TestReadingRepository_DeleteOldReadings(t *testing.T) {
+	repo := NewReadingRepository()
+	ctx := context.Background()
+
+	old, _ := domain.NewClimateReadingAt(18, 50, time.Now().Add(-48*time.Hour))
+	recent, _ := domain.NewClimateReading(21, 45)
+	_ = repo.SaveReading(ctx, old)
+	_ = repo.SaveReading(ctx, recent)
+
+	deleted, err := repo.DeleteOldReadings(ctx, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("DeleteOldReadings failed: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("expected 1 deleted, got %d", deleted)
+	}
+	if _, err := repo.GetReading(ctx, old.ID); !errors.Is(err, domain.ErrReadingNotFound) {
+		t.Errorf("expected old reading gone, got %v", err)
+	}
+}
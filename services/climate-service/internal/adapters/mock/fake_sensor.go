@@ -0,0 +1,37 @@
+package mock
+
+import (
+	"context"
+	"math/rand"
+)
+
+// FakeSensor simulates a temperature and humidity sensor for development
+// This implements the ports.ClimateSensor interface
+type FakeSensor struct {
+	temperature float64
+	humidity    float64
+	variation   float64
+}
+
+// NewFakeSensor creates a sensor that returns realistic values
+// temperature, humidity: averages (e.g., 21 °C and 50% for a living room)
+// variation: +/- range applied to both (e.g., 2 means 19-23 °C and 48-52%)
+func NewFakeSensor(temperature, humidity, variation float64) *FakeSensor {
+	return &FakeSensor{
+		temperature: temperature,
+		humidity:    humidity,
+		variation:   variation,
+	}
+}
+
+// ReadClimate returns a simulated reading, with humidity clamped to 0-100
+func (s *FakeSensor) ReadClimate(ctx context.Context) (float64, float64, error) {
+	temperature := s.temperature + (rand.Float64()-0.5)*2*s.variation
+	humidity := s.humidity + (rand.Float64()-0.5)*2*s.variation
+	return temperature, min(max(humidity, 0), 100), nil
+}
+
+// Close is a no-op for fake sensor
+func (s *FakeSensor) Close() error {
+	return nil
+}
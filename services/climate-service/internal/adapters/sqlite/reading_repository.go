@@ -0,0 +1,166 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/quentinrf/plant-monitor/services/climate-service/internal/domain"
+)
+
+// ReadingRepository implements domain.ReadingRepository with SQLite.
+// Timestamps are stored as Unix milliseconds, so range queries compare
+// integers and are independent of time zones.
+type ReadingRepository struct {
+	db *sql.DB
+}
+
+const schema = `
+CREATE TABLE IF NOT EXISTS climate_readings (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	temperature_celsius REAL NOT NULL,
+	humidity_percent REAL NOT NULL,
+	timestamp_ms INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_climate_timestamp ON climate_readings(timestamp_ms);
+`
+
+// NewReadingRepository creates a SQLite-backed repository
+func NewReadingRepository(dbPath string) (*ReadingRepository, error) {
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	// SQLite serializes writes anyway; one connection avoids "database is
+	// locked" errors and keeps :memory: databases to a single instance
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	return &ReadingRepository{db: db}, nil
+}
+
+// SaveReading stores a reading in SQLite
+func (r *ReadingRepository) SaveReading(ctx context.Context, reading *domain.ClimateReading) error {
+	query := `INSERT INTO climate_readings (temperature_celsius, humidity_percent, timestamp_ms) VALUES (?, ?, ?)`
+
+	result, err := r.db.ExecContext(ctx, query, reading.TemperatureCelsius, reading.HumidityPercent, reading.Timestamp.UnixMilli())
+	if err != nil {
+		return fmt.Errorf("failed to insert reading: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get insert id: %w", err)
+	}
+
+	reading.ID = id
+	return nil
+}
+
+// GetReading retrieves a reading by ID
+func (r *ReadingRepository) GetReading(ctx context.Context, id int64) (*domain.ClimateReading, error) {
+	query := `SELECT id, temperature_celsius, humidity_percent, timestamp_ms FROM climate_readings WHERE id = ?`
+
+	reading, err := scanReading(r.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrReadingNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reading: %w", err)
+	}
+	return reading, nil
+}
+
+// GetReadingsInRange returns all readings within time range, oldest first
+func (r *ReadingRepository) GetReadingsInRange(ctx context.Context, start, end time.Time) ([]*domain.ClimateReading, error) {
+	query := `
+		SELECT id, temperature_celsius, humidity_percent, timestamp_ms
+		FROM climate_readings
+		WHERE timestamp_ms >= ? AND timestamp_ms < ?
+		ORDER BY timestamp_ms ASC, id ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, start.UnixMilli(), end.UnixMilli())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query readings: %w", err)
+	}
+	defer rows.Close()
+
+	var readings []*domain.ClimateReading
+	for rows.Next() {
+		reading, err := scanReading(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reading: %w", err)
+		}
+		readings = append(readings, reading)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate readings: %w", err)
+	}
+
+	return readings, nil
+}
+
+// GetLatestReading returns the most recent reading
+func (r *ReadingRepository) GetLatestReading(ctx context.Context) (*domain.ClimateReading, error) {
+	query := `
+		SELECT id, temperature_celsius, humidity_percent, timestamp_ms
+		FROM climate_readings
+		ORDER BY timestamp_ms DESC, id DESC
+		LIMIT 1
+	`
+
+	reading, err := scanReading(r.db.QueryRowContext(ctx, query))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrReadingNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latest reading: %w", err)
+	}
+	return reading, nil
+}
+
+// DeleteOldReadings removes readings older than specified duration
+func (r *ReadingRepository) DeleteOldReadings(ctx context.Context, olderThan time.Duration) (int64, error) {
+	cutoff := time.Now().Add(-olderThan)
+	query := `DELETE FROM climate_readings WHERE timestamp_ms < ?`
+
+	result, err := r.db.ExecContext(ctx, query, cutoff.UnixMilli())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete old readings: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count deleted readings: %w", err)
+	}
+	return deleted, nil
+}
+
+// Close closes the database connection
+func (r *ReadingRepository) Close() error {
+	return r.db.Close()
+}
+
+// scanner is satisfied by *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanReading(s scanner) (*domain.ClimateReading, error) {
+	var reading domain.ClimateReading
+	var timestampMs int64
+	if err := s.Scan(&reading.ID, &reading.TemperatureCelsius, &reading.HumidityPercent, &timestampMs); err != nil {
+		return nil, err
+	}
+	reading.Timestamp = time.UnixMilli(timestampMs)
+	return &reading, nil
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "errors"
+
+var (
+	// ErrInvalidTemperature indicates a temperature outside what the
+	// supported sensors can measure
+	ErrInvalidTemperature = errors.New("temperature must be between -40 and 125 °C")
+
+	// ErrInvalidHumidity indicates a relative humidity outside 0-100
+	ErrInvalidHumidity = errors.New("humidity must be between 0 and 100 percent")
+
+	// ErrReadingNotFound indicates requested reading doesn't exist
+	ErrReadingNotFound = errors.New("reading not found")
+
+	// ErrSensorUnavailable indicates sensor cannot be read
+	ErrSensorUnavailable = errors.New("sensor unavailable")
+)
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// Measurable range: the SHT31's -40 to 125 °C covers the DHT22's -40 to 80 °C
+const (
+	MinTemperatureCelsius = -40.0
+	MaxTemperatureCelsius = 125.0
+)
+
+// minDewPointHumidity floors the humidity DewPointCelsius works with, as the
+// dew point of perfectly dry air is minus infinity
+const minDewPointHumidity = 0.1
+
+// ClimateReading represents a single air temperature and humidity measurement
+// This is pure domain logic - no database, no gRPC, just business concepts
+type ClimateReading struct {
+	ID                 int64
+	TemperatureCelsius float64
+	HumidityPercent    float64 // relative humidity, 0-100
+	Timestamp          time.Time
+}
+
+// NewClimateReading creates a new reading taken now, with validation
+func NewClimateReading(temperatureCelsius, humidityPercent float64) (*ClimateReading, error) {
+	return NewClimateReadingAt(temperatureCelsius, humidityPercent, time.Now())
+}
+
+// NewClimateReadingAt creates a new reading taken at the given time
+func NewClimateReadingAt(temperatureCelsius, humidityPercent float64, at time.Time) (*ClimateReading, error) {
+	if math.IsNaN(temperatureCelsius) || temperatureCelsius < MinTemperatureCelsius || temperatureCelsius > MaxTemperatureCelsius {
+		return nil, ErrInvalidTemperature
+	}
+	if math.IsNaN(humidityPercent) || humidityPercent < 0 || humidityPercent > 100 {
+		return nil, ErrInvalidHumidity
+	}
+
+	return &ClimateReading{
+		TemperatureCelsius: temperatureCelsius,
+		HumidityPercent:    humidityPercent,
+		Timestamp:          at,
+	}, nil
+}
+
+// DewPointCelsius returns the temperature at which the air would start to
+// condense, by the Magnus formula. Leaves and windows colder than this get
+// wet, which invites fungal disease.
+func (r *ClimateReading) DewPointCelsius() float64 {
+	const a, b = 17.62, 243.12
+	gamma := math.Log(max(r.HumidityPercent, minDewPointHumidity)/100) + a*r.TemperatureCelsius/(b+r.TemperatureCelsius)
+	return b * gamma / (a - gamma)
+}
+
+// VPDKilopascals returns the vapour pressure deficit: how much more water the
+// air could hold, which drives transpiration. Most plants do best between
+// about 0.4 and 1.6 kPa.
+func (r *ClimateReading) VPDKilopascals() float64 {
+	// Tetens' saturation vapour pressure in kPa
+	saturation := 0.6108 * math.Exp(17.27*r.TemperatureCelsius/(r.TemperatureCelsius+237.3))
+	return saturation * (1 - r.HumidityPercent/100)
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNewClimateReading(t *testing.T) {
+	tests := []struct {
+		name        string
+		temperature float64
+		humidity    float64
+		wantErr     error
+	}{
+		{"typical", 21.5, 55, nil},
+		{"freezer", -40, 0, nil},
+		{"sensor maximum", 125, 100, nil},
+		{"too cold", -40.1, 50, ErrInvalidTemperature},
+		{"too hot", 125.1, 50, ErrInvalidTemperature},
+		{"NaN temperature", math.NaN(), 50, ErrInvalidTemperature},
+		{"negative humidity", 20, -1, ErrInvalidHumidity},
+		{"humidity above 100", 20, 100.1, ErrInvalidHumidity},
+		{"NaN humidity", 20, math.NaN(), ErrInvalidHumidity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reading, err := NewClimateReading(tt.temperature, tt.humidity)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if reading.TemperatureCelsius != tt.temperature || reading.HumidityPercent != tt.humidity || reading.Timestamp.IsZero() {
+				t.Errorf("unexpected reading %+v", reading)
+			}
+		})
+	}
+}
+
+func TestDewPointAndVPD(t *testing.T) {
+	tests := []struct {
+		temperature, humidity float64
+		dewPoint, vpd         float64
+	}{
+		{20, 100, 20, 0},
+		{20, 50, 9.26, 1.169},
+		{25, 60, 16.69, 1.267},
+		{30, 40, 14.93, 2.546},
+	}
+	for _, tt := range tests {
+		r := &ClimateReading{TemperatureCelsius: tt.temperature, HumidityPercent: tt.humidity}
+		if got := r.DewPointCelsius(); math.Abs(got-tt.dewPoint) > 0.01 {
+			t.Errorf("DewPointCelsius(%v °C, %v%%) = %.3f, want %v", tt.temperature, tt.humidity, got, tt.dewPoint)
+		}
+		if got := r.VPDKilopascals(); math.Abs(got-tt.vpd) > 0.001 {
+			t.Errorf("VPDKilopascals(%v °C, %v%%) = %.4f, want %v", tt.temperature, tt.humidity, got, tt.vpd)
+		}
+	}
+
+	dry := &ClimateReading{TemperatureCelsius: 20, HumidityPercent: 0}
+	if dp := dry.DewPointCelsius(); math.IsNaN(dp) || math.IsInf(dp, 0) {
+		t.Errorf("expected a finite dew point for dry air, got %v", dp)
+	}
+}
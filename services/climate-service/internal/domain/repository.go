@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ReadingRepository defines operations for storing/retrieving readings
+// This is a PORT - adapters (SQLite, Memory) implement it
+type ReadingRepository interface {
+	// SaveReading persists a reading, assigning its ID
+	SaveReading(ctx context.Context, reading *ClimateReading) error
+
+	// GetReading retrieves a specific reading by ID
+	GetReading(ctx context.Context, id int64) (*ClimateReading, error)
+
+	// GetReadingsInRange retrieves all readings within time range, oldest first.
+	// Uses a half-open interval: inclusive start, exclusive end [start, end).
+	GetReadingsInRange(ctx context.Context, start, end time.Time) ([]*ClimateReading, error)
+
+	// GetLatestReading retrieves the most recent reading
+	GetLatestReading(ctx context.Context) (*ClimateReading, error)
+
+	// DeleteOldReadings removes readings older than specified duration and
+	// returns how many were removed
+	DeleteOldReadings(ctx context.Context, olderThan time.Duration) (int64, error)
+}
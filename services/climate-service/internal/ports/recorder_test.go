@@ -0,0 +1,50 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/quentinrf/plant-monitor/services/climate-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/climate-service/internal/adapters/mock"
+	"github.com/quentinrf/plant-monitor/services/climate-service/internal/domain"
+)
+
+// fixedSensor always returns the same temperature and humidity
+type fixedSensor struct{ temperature, humidity float64 }
+
+func (s fixedSensor) ReadClimate(ctx context.Context) (float64, float64, error) {
+	return s.temperature, s.humidity, nil
+}
+func (s fixedSensor) Close() error { return nil }
+
+func TestRecordOnce_SavesReading(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	recorder := NewRecorder(mock.NewFakeSensor(21, 50, 0), repo, 0)
+	ctx := context.Background()
+
+	if err := recorder.recordOnce(ctx); err != nil {
+		t.Fatalf("recordOnce failed: %v", err)
+	}
+
+	latest, err := repo.GetLatestReading(ctx)
+	if err != nil {
+		t.Fatalf("GetLatestReading failed: %v", err)
+	}
+	if latest.TemperatureCelsius != 21 || latest.HumidityPercent != 50 {
+		t.Errorf("expected 21 °C at 50%%, got %v °C at %v%%", latest.TemperatureCelsius, latest.HumidityPercent)
+	}
+}
+
+func TestRecordOnce_RejectsOutOfRangeSensor(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	recorder := NewRecorder(fixedSensor{temperature: 21, humidity: 120}, repo, 0)
+	ctx := context.Background()
+
+	if err := recorder.recordOnce(ctx); !errors.Is(err, domain.ErrInvalidHumidity) {
+		t.Errorf("expected ErrInvalidHumidity, got %v", err)
+	}
+	if _, err := repo.GetLatestReading(ctx); !errors.Is(err, domain.ErrReadingNotFound) {
+		t.Errorf("expected nothing saved, got %v", err)
+	}
+}
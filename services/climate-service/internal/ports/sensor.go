@@ -0,0 +1,14 @@
+package ports
+
+import "context"
+
+// ClimateSensor defines how to read air temperature and humidity
+// This is a PORT - adapters (DHT22, SHT31, Mock) implement it
+type ClimateSensor interface {
+	// ReadClimate returns the current temperature in °C and relative
+	// humidity in percent, measured together
+	ReadClimate(ctx context.Context) (temperatureCelsius, humidityPercent float64, err error)
+
+	// Close releases any resources
+	Close() error
+}
@@ -0,0 +1,422 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.4
+// source: api/proto/climate.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetCurrentClimateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCurrentClimateRequest) Reset() {
+	*x = GetCurrentClimateRequest{}
+	mi := &file_api_proto_climate_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCurrentClimateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrentClimateRequest) ProtoMessage() {}
+
+func (x *GetCurrentClimateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_climate_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrentClimateRequest.ProtoReflect.Descriptor instead.
+func (*GetCurrentClimateRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_climate_proto_rawDescGZIP(), []int{0}
+}
+
+type GetCurrentClimateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reading       *ClimateReading        `protobuf:"bytes,1,opt,name=reading,proto3" json:"reading,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCurrentClimateResponse) Reset() {
+	*x = GetCurrentClimateResponse{}
+	mi := &file_api_proto_climate_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCurrentClimateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrentClimateResponse) ProtoMessage() {}
+
+func (x *GetCurrentClimateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_climate_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrentClimateResponse.ProtoReflect.Descriptor instead.
+func (*GetCurrentClimateResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_climate_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetCurrentClimateResponse) GetReading() *ClimateReading {
+	if x != nil {
+		return x.Reading
+	}
+	return nil
+}
+
+type GetHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Half-open range [start_time_ms, end_time_ms), in Unix milliseconds
+	StartTimeMs   int64 `protobuf:"varint,1,opt,name=start_time_ms,json=startTimeMs,proto3" json:"start_time_ms,omitempty"`
+	EndTimeMs     int64 `protobuf:"varint,2,opt,name=end_time_ms,json=endTimeMs,proto3" json:"end_time_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHistoryRequest) Reset() {
+	*x = GetHistoryRequest{}
+	mi := &file_api_proto_climate_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryRequest) ProtoMessage() {}
+
+func (x *GetHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_climate_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_climate_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetHistoryRequest) GetStartTimeMs() int64 {
+	if x != nil {
+		return x.StartTimeMs
+	}
+	return 0
+}
+
+func (x *GetHistoryRequest) GetEndTimeMs() int64 {
+	if x != nil {
+		return x.EndTimeMs
+	}
+	return 0
+}
+
+type GetHistoryResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Readings []*ClimateReading      `protobuf:"bytes,1,rep,name=readings,proto3" json:"readings,omitempty"`
+	// Statistics; zero when there are no readings
+	AverageTemperatureCelsius float64 `protobuf:"fixed64,2,opt,name=average_temperature_celsius,json=averageTemperatureCelsius,proto3" json:"average_temperature_celsius,omitempty"`
+	MinTemperatureCelsius     float64 `protobuf:"fixed64,3,opt,name=min_temperature_celsius,json=minTemperatureCelsius,proto3" json:"min_temperature_celsius,omitempty"`
+	MaxTemperatureCelsius     float64 `protobuf:"fixed64,4,opt,name=max_temperature_celsius,json=maxTemperatureCelsius,proto3" json:"max_temperature_celsius,omitempty"`
+	AverageHumidityPercent    float64 `protobuf:"fixed64,5,opt,name=average_humidity_percent,json=averageHumidityPercent,proto3" json:"average_humidity_percent,omitempty"`
+	MinHumidityPercent        float64 `protobuf:"fixed64,6,opt,name=min_humidity_percent,json=minHumidityPercent,proto3" json:"min_humidity_percent,omitempty"`
+	MaxHumidityPercent        float64 `protobuf:"fixed64,7,opt,name=max_humidity_percent,json=maxHumidityPercent,proto3" json:"max_humidity_percent,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *GetHistoryResponse) Reset() {
+	*x = GetHistoryResponse{}
+	mi := &file_api_proto_climate_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryResponse) ProtoMessage() {}
+
+func (x *GetHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_climate_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_climate_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetHistoryResponse) GetReadings() []*ClimateReading {
+	if x != nil {
+		return x.Readings
+	}
+	return nil
+}
+
+func (x *GetHistoryResponse) GetAverageTemperatureCelsius() float64 {
+	if x != nil {
+		return x.AverageTemperatureCelsius
+	}
+	return 0
+}
+
+func (x *GetHistoryResponse) GetMinTemperatureCelsius() float64 {
+	if x != nil {
+		return x.MinTemperatureCelsius
+	}
+	return 0
+}
+
+func (x *GetHistoryResponse) GetMaxTemperatureCelsius() float64 {
+	if x != nil {
+		return x.MaxTemperatureCelsius
+	}
+	return 0
+}
+
+func (x *GetHistoryResponse) GetAverageHumidityPercent() float64 {
+	if x != nil {
+		return x.AverageHumidityPercent
+	}
+	return 0
+}
+
+func (x *GetHistoryResponse) GetMinHumidityPercent() float64 {
+	if x != nil {
+		return x.MinHumidityPercent
+	}
+	return 0
+}
+
+func (x *GetHistoryResponse) GetMaxHumidityPercent() float64 {
+	if x != nil {
+		return x.MaxHumidityPercent
+	}
+	return 0
+}
+
+type ClimateReading struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TemperatureCelsius float64                `protobuf:"fixed64,2,opt,name=temperature_celsius,json=temperatureCelsius,proto3" json:"temperature_celsius,omitempty"`
+	HumidityPercent    float64                `protobuf:"fixed64,3,opt,name=humidity_percent,json=humidityPercent,proto3" json:"humidity_percent,omitempty"` // relative humidity, 0-100
+	TimestampMs        int64                  `protobuf:"varint,4,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"`              // Unix milliseconds
+	DewPointCelsius    float64                `protobuf:"fixed64,5,opt,name=dew_point_celsius,json=dewPointCelsius,proto3" json:"dew_point_celsius,omitempty"`
+	VpdKpa             float64                `protobuf:"fixed64,6,opt,name=vpd_kpa,json=vpdKpa,proto3" json:"vpd_kpa,omitempty"` // vapour pressure deficit; most plants like 0.4-1.6
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ClimateReading) Reset() {
+	*x = ClimateReading{}
+	mi := &file_api_proto_climate_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClimateReading) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClimateReading) ProtoMessage() {}
+
+func (x *ClimateReading) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_climate_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClimateReading.ProtoReflect.Descriptor instead.
+func (*ClimateReading) Descriptor() ([]byte, []int) {
+	return file_api_proto_climate_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ClimateReading) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ClimateReading) GetTemperatureCelsius() float64 {
+	if x != nil {
+		return x.TemperatureCelsius
+	}
+	return 0
+}
+
+func (x *ClimateReading) GetHumidityPercent() float64 {
+	if x != nil {
+		return x.HumidityPercent
+	}
+	return 0
+}
+
+func (x *ClimateReading) GetTimestampMs() int64 {
+	if x != nil {
+		return x.TimestampMs
+	}
+	return 0
+}
+
+func (x *ClimateReading) GetDewPointCelsius() float64 {
+	if x != nil {
+		return x.DewPointCelsius
+	}
+	return 0
+}
+
+func (x *ClimateReading) GetVpdKpa() float64 {
+	if x != nil {
+		return x.VpdKpa
+	}
+	return 0
+}
+
+var File_api_proto_climate_proto protoreflect.FileDescriptor
+
+const file_api_proto_climate_proto_rawDesc = "" +
+	"\n" +
+	"\x17api/proto/climate.proto\x12\n" +
+	"climate.v1\"\x1a\n" +
+	"\x18GetCurrentClimateRequest\"Q\n" +
+	"\x19GetCurrentClimateResponse\x124\n" +
+	"\areading\x18\x01 \x01(\v2\x1a.climate.v1.ClimateReadingR\areading\"W\n" +
+	"\x11GetHistoryRequest\x12\"\n" +
+	"\rstart_time_ms\x18\x01 \x01(\x03R\vstartTimeMs\x12\x1e\n" +
+	"\vend_time_ms\x18\x02 \x01(\x03R\tendTimeMs\"\x9a\x03\n" +
+	"\x12GetHistoryResponse\x126\n" +
+	"\breadings\x18\x01 \x03(\v2\x1a.climate.v1.ClimateReadingR\breadings\x12>\n" +
+	"\x1baverage_temperature_celsius\x18\x02 \x01(\x01R\x19averageTemperatureCelsius\x126\n" +
+	"\x17min_temperature_celsius\x18\x03 \x01(\x01R\x15minTemperatureCelsius\x126\n" +
+	"\x17max_temperature_celsius\x18\x04 \x01(\x01R\x15maxTemperatureCelsius\x128\n" +
+	"\x18average_humidity_percent\x18\x05 \x01(\x01R\x16averageHumidityPercent\x120\n" +
+	"\x14min_humidity_percent\x18\x06 \x01(\x01R\x12minHumidityPercent\x120\n" +
+	"\x14max_humidity_percent\x18\a \x01(\x01R\x12maxHumidityPercent\"\xe4\x01\n" +
+	"\x0eClimateReading\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12/\n" +
+	"\x13temperature_celsius\x18\x02 \x01(\x01R\x12temperatureCelsius\x12)\n" +
+	"\x10humidity_percent\x18\x03 \x01(\x01R\x0fhumidityPercent\x12!\n" +
+	"\ftimestamp_ms\x18\x04 \x01(\x03R\vtimestampMs\x12*\n" +
+	"\x11dew_point_celsius\x18\x05 \x01(\x01R\x0fdewPointCelsius\x12\x17\n" +
+	"\avpd_kpa\x18\x06 \x01(\x01R\x06vpdKpa2\xbf\x01\n" +
+	"\x0eClimateService\x12`\n" +
+	"\x11GetCurrentClimate\x12$.climate.v1.GetCurrentClimateRequest\x1a%.climate.v1.GetCurrentClimateResponse\x12K\n" +
+	"\n" +
+	"GetHistory\x12\x1d.climate.v1.GetHistoryRequest\x1a\x1e.climate.v1.GetHistoryResponseBDZBgithub.com/quentinrf/plant-monitor/services/climate-service/pkg/pbb\x06proto3"
+
+var (
+	file_api_proto_climate_proto_rawDescOnce sync.Once
+	file_api_proto_climate_proto_rawDescData []byte
+)
+
+func file_api_proto_climate_proto_rawDescGZIP() []byte {
+	file_api_proto_climate_proto_rawDescOnce.Do(func() {
+		file_api_proto_climate_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_climate_proto_rawDesc), len(file_api_proto_climate_proto_rawDesc)))
+	})
+	return file_api_proto_climate_proto_rawDescData
+}
+
+var file_api_proto_climate_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_api_proto_climate_proto_goTypes = []any{
+	(*GetCurrentClimateRequest)(nil),  // 0: climate.v1.GetCurrentClimateRequest
+	(*GetCurrentClimateResponse)(nil), // 1: climate.v1.GetCurrentClimateResponse
+	(*GetHistoryRequest)(nil),         // 2: climate.v1.GetHistoryRequest
+	(*GetHistoryResponse)(nil),        // 3: climate.v1.GetHistoryResponse
+	(*ClimateReading)(nil),            // 4: climate.v1.ClimateReading
+}
+var file_api_proto_climate_proto_depIdxs = []int32{
+	4, // 0: climate.v1.GetCurrentClimateResponse.reading:type_name -> climate.v1.ClimateReading
+	4, // 1: climate.v1.GetHistoryResponse.readings:type_name -> climate.v1.ClimateReading
+	0, // 2: climate.v1.ClimateService.GetCurrentClimate:input_type -> climate.v1.GetCurrentClimateRequest
+	2, // 3: climate.v1.ClimateService.GetHistory:input_type -> climate.v1.GetHistoryRequest
+	1, // 4: climate.v1.ClimateService.GetCurrentClimate:output_type -> climate.v1.GetCurrentClimateResponse
+	3, // 5: climate.v1.ClimateService.GetHistory:output_type -> climate.v1.GetHistoryResponse
+	4, // [4:6] is the sub-list for method output_type
+	2, // [2:4] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_climate_proto_init() }
+func file_api_proto_climate_proto_init() {
+	if File_api_proto_climate_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_climate_proto_rawDesc), len(file_api_proto_climate_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_proto_climate_proto_goTypes,
+		DependencyIndexes: file_api_proto_climate_proto_depIdxs,
+		MessageInfos:      file_api_proto_climate_proto_msgTypes,
+	}.Build()
+	File_api_proto_climate_proto = out.File
+	file_api_proto_climate_proto_goTypes = nil
+	file_api_proto_climate_proto_depIdxs = nil
+}
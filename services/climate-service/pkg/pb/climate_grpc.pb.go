@@ -0,0 +1,167 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.1
+// - protoc             v6.33.4
+// source: api/proto/climate.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ClimateService_GetCurrentClimate_FullMethodName = "/climate.v1.ClimateService/GetCurrentClimate"
+	ClimateService_GetHistory_FullMethodName        = "/climate.v1.ClimateService/GetHistory"
+)
+
+// ClimateServiceClient is the client API for ClimateService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ClimateService provides air temperature and humidity monitoring
+type ClimateServiceClient interface {
+	// GetCurrentClimate returns the most recent temperature and humidity reading
+	GetCurrentClimate(ctx context.Context, in *GetCurrentClimateRequest, opts ...grpc.CallOption) (*GetCurrentClimateResponse, error)
+	// GetHistory returns climate readings within a time range
+	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
+}
+
+type climateServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewClimateServiceClient(cc grpc.ClientConnInterface) ClimateServiceClient {
+	return &climateServiceClient{cc}
+}
+
+func (c *climateServiceClient) GetCurrentClimate(ctx context.Context, in *GetCurrentClimateRequest, opts ...grpc.CallOption) (*GetCurrentClimateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCurrentClimateResponse)
+	err := c.cc.Invoke(ctx, ClimateService_GetCurrentClimate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *climateServiceClient) GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHistoryResponse)
+	err := c.cc.Invoke(ctx, ClimateService_GetHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ClimateServiceServer is the server API for ClimateService service.
+// All implementations must embed UnimplementedClimateServiceServer
+// for forward compatibility.
+//
+// ClimateService provides air temperature and humidity monitoring
+type ClimateServiceServer interface {
+	// GetCurrentClimate returns the most recent temperature and humidity reading
+	GetCurrentClimate(context.Context, *GetCurrentClimateRequest) (*GetCurrentClimateResponse, error)
+	// GetHistory returns climate readings within a time range
+	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
+	mustEmbedUnimplementedClimateServiceServer()
+}
+
+// UnimplementedClimateServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedClimateServiceServer struct{}
+
+func (UnimplementedClimateServiceServer) GetCurrentClimate(context.Context, *GetCurrentClimateRequest) (*GetCurrentClimateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCurrentClimate not implemented")
+}
+func (UnimplementedClimateServiceServer) GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetHistory not implemented")
+}
+func (UnimplementedClimateServiceServer) mustEmbedUnimplementedClimateServiceServer() {}
+func (UnimplementedClimateServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeClimateServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ClimateServiceServer will
+// result in compilation errors.
+type UnsafeClimateServiceServer interface {
+	mustEmbedUnimplementedClimateServiceServer()
+}
+
+func RegisterClimateServiceServer(s grpc.ServiceRegistrar, srv ClimateServiceServer) {
+	// If the following call panics, it indicates UnimplementedClimateServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ClimateService_ServiceDesc, srv)
+}
+
+func _ClimateService_GetCurrentClimate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCurrentClimateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClimateServiceServer).GetCurrentClimate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClimateService_GetCurrentClimate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClimateServiceServer).GetCurrentClimate(ctx, req.(*GetCurrentClimateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClimateService_GetHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClimateServiceServer).GetHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClimateService_GetHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClimateServiceServer).GetHistory(ctx, req.(*GetHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ClimateService_ServiceDesc is the grpc.ServiceDesc for ClimateService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ClimateService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "climate.v1.ClimateService",
+	HandlerType: (*ClimateServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCurrentClimate",
+			Handler:    _ClimateService_GetCurrentClimate_Handler,
+		},
+		{
+			MethodName: "GetHistory",
+			Handler:    _ClimateService_GetHistory_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/proto/climate.proto",
+}
@@ -0,0 +1,158 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultReloadInterval is how often a CertReloader checks its files for a
+// rotated certificate
+const DefaultReloadInterval = time.Minute
+
+// Option configures how LoadServerTLS, LoadClientTLS and NewCertReloader
+// keep the certificate current
+type Option func(*options)
+
+type options struct {
+	reloadInterval time.Duration
+	onReload       func(error)
+}
+
+// WithReloadInterval sets how often the certificate and key files are
+// checked for changes; 0 loads them once and never again
+func WithReloadInterval(d time.Duration) Option {
+	return func(o *options) {
+		o.reloadInterval = d
+	}
+}
+
+// WithReloadHook calls fn after each attempt to load changed files, with
+// nil once the new certificate is in use or the error that kept the old one
+func WithReloadHook(fn func(err error)) Option {
+	return func(o *options) {
+		o.onReload = fn
+	}
+}
+
+// CertReloader serves a certificate from a pair of files, picking up a
+// rotated certificate without a restart. The files are checked during
+// handshakes at most once per interval, and a changed pair is swapped in
+// only once it loads; until then the old certificate keeps being served,
+// so a pair caught half written is simply retried at the next check.
+type CertReloader struct {
+	certFile, keyFile string
+	interval          time.Duration
+	onReload          func(error)
+
+	cert atomic.Pointer[tls.Certificate]
+
+	mu      sync.Mutex // held while checking the files
+	checked time.Time  // when they were last checked
+	loaded  fileStamps // the files the current certificate came from
+}
+
+// fileStamps identifies a version of the certificate and key files
+type fileStamps struct {
+	certMod, keyMod   time.Time
+	certSize, keySize int64
+}
+
+// NewCertReloader loads the certificate in certFile and keyFile
+func NewCertReloader(certFile, keyFile string, opts ...Option) (*CertReloader, error) {
+	o := options{reloadInterval: DefaultReloadInterval}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	r := &CertReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		interval: o.reloadInterval,
+		onReload: o.onReload,
+	}
+	stamps, err := r.stat()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.load(stamps); err != nil {
+		return nil, err
+	}
+	r.checked = time.Now()
+	return r, nil
+}
+
+// GetCertificate serves the current certificate to clients, for
+// tls.Config.GetCertificate
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return r.current(), nil
+}
+
+// GetClientCertificate presents the current certificate to servers, for
+// tls.Config.GetClientCertificate
+func (r *CertReloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return r.current(), nil
+}
+
+// current returns the certificate, first reloading it if the files have
+// changed and are due a check
+func (r *CertReloader) current() *tls.Certificate {
+	// A handshake never waits on another's check; it serves the
+	// certificate that is in use until the check is done
+	if r.interval > 0 && r.mu.TryLock() {
+		if time.Since(r.checked) >= r.interval {
+			r.checked = time.Now()
+			r.reload()
+		}
+		r.mu.Unlock()
+	}
+	return r.cert.Load()
+}
+
+// reload loads the files if they have changed since the certificate was.
+// r.mu must be held.
+func (r *CertReloader) reload() {
+	stamps, err := r.stat()
+	if err == nil {
+		if stamps == r.loaded {
+			return
+		}
+		err = r.load(stamps)
+	}
+	if r.onReload != nil {
+		r.onReload(err)
+	}
+}
+
+// load replaces the certificate with the files' contents, recording stamps
+// (taken before reading, so a write during the read is seen next time)
+func (r *CertReloader) load(stamps fileStamps) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("load key pair: %w", err)
+	}
+	r.cert.Store(&cert)
+	r.loaded = stamps
+	return nil
+}
+
+// stat identifies the current version of the files
+func (r *CertReloader) stat() (fileStamps, error) {
+	cert, err := os.Stat(r.certFile)
+	if err != nil {
+		return fileStamps{}, fmt.Errorf("stat certificate: %w", err)
+	}
+	key, err := os.Stat(r.keyFile)
+	if err != nil {
+		return fileStamps{}, fmt.Errorf("stat key: %w", err)
+	}
+	return fileStamps{
+		certMod:  cert.ModTime(),
+		keyMod:   key.ModTime(),
+		certSize: cert.Size(),
+		keySize:  key.Size(),
+	}, nil
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+// newCurrentCmd prints the latest reading
+func newCurrentCmd(g *globalFlags) *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "current",
+		Short: "Show the latest reading",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			w, err := newReadingWriter(format, cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			c, err := g.dial()
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			current, err := c.Current(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return w.write([]reading{fromClient(current)})
+		},
+	}
+	cmd.Flags().StringVar(&format, "format", formatTable, "output format: table, json or csv")
+	return cmd
+}
+
+// newHistoryCmd prints the readings in a recent window
+func newHistoryCmd(g *globalFlags) *cobra.Command {
+	var (
+		since  time.Duration
+		format string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show the readings recorded recently, oldest first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if since <= 0 {
+				return errors.New("--since must be positive")
+			}
+			w, err := newReadingWriter(format, cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			c, err := g.dial()
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			end := time.Now()
+			history, err := c.History(cmd.Context(), end.Add(-since), end)
+			if err != nil {
+				return err
+			}
+			readings := make([]reading, len(history))
+			for i, r := range history {
+				readings[i] = fromClient(r)
+			}
+			return w.write(readings)
+		},
+	}
+	cmd.Flags().DurationVar(&since, "since", 24*time.Hour, "how far back to look")
+	cmd.Flags().StringVar(&format, "format", formatTable, "output format: table, json or csv")
+	return cmd
+}
+
+// newRecordCmd submits a manual reading
+func newRecordCmd(g *globalFlags) *cobra.Command {
+	var (
+		lux         float64
+		temperature float64
+		at          string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "record",
+		Short: "Record a reading taken by hand",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &pb.RecordReadingRequest{Lux: lux}
+			if cmd.Flags().Changed("temperature") {
+				req.TemperatureCelsius = &temperature
+			}
+			if at != "" {
+				t, err := time.Parse(time.RFC3339, at)
+				if err != nil {
+					return fmt.Errorf("--at: %w", err)
+				}
+				ms := t.UnixMilli()
+				req.TimestampMs = &ms
+			}
+
+			c, err := g.dial()
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			ctx, cancel := g.callContext(cmd.Context())
+			defer cancel()
+			resp, err := c.Raw().RecordReading(ctx, req)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "recorded reading %d: %g lux (%s) at %s\n",
+				resp.GetReading().GetId(), resp.GetReading().GetLux(), resp.GetReading().GetCategory(),
+				time.UnixMilli(resp.GetReading().GetTimestampMs()).Format(time.RFC3339))
+			return nil
+		},
+	}
+	cmd.Flags().Float64Var(&lux, "lux", 0, "light level in lux")
+	cmd.Flags().Float64Var(&temperature, "temperature", 0, "ambient temperature in °C, if measured")
+	cmd.Flags().StringVar(&at, "at", "", "when the reading was taken, RFC 3339 (default now)")
+	cmd.MarkFlagRequired("lux")
+	return cmd
+}
+
+// newExportCmd downloads readings as a CSV or NDJSON file
+func newExportCmd(g *globalFlags) *cobra.Command {
+	var (
+		since  time.Duration
+		format string
+		output string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Download readings as CSV or NDJSON for offline analysis",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var exportFormat pb.ExportFormat
+			switch format {
+			case "csv":
+				exportFormat = pb.ExportFormat_EXPORT_FORMAT_CSV
+			case "ndjson":
+				exportFormat = pb.ExportFormat_EXPORT_FORMAT_NDJSON
+			default:
+				return fmt.Errorf("unknown --format %q (want csv or ndjson)", format)
+			}
+			if since <= 0 {
+				return errors.New("--since must be positive")
+			}
+
+			c, err := g.dial()
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			end := time.Now()
+			stream, err := c.Raw().DownloadReadings(cmd.Context(), &pb.DownloadReadingsRequest{
+				StartTimeMs: end.Add(-since).UnixMilli(),
+				EndTimeMs:   end.UnixMilli(),
+				Format:      exportFormat,
+			})
+			if err != nil {
+				return err
+			}
+
+			out, closeOut, err := createOutput(output, cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			for {
+				chunk, err := stream.Recv()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err == nil {
+					_, err = out.Write(chunk.GetData())
+				}
+				if err != nil {
+					closeOut()
+					return err
+				}
+			}
+			return closeOut()
+		},
+	}
+	cmd.Flags().DurationVar(&since, "since", 30*24*time.Hour, "how far back to export")
+	cmd.Flags().StringVar(&format, "format", "csv", "file format: csv or ndjson")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "file to write (default stdout)")
+	return cmd
+}
+
+// createOutput opens path for writing, or returns stdout for "" or "-"
+func createOutput(path string, stdout io.Writer) (io.Writer, func() error, error) {
+	if path == "" || path == "-" {
+		return stdout, func() error { return nil }, nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, f.Close, nil
+}
+
+// newPurgeCmd deletes old readings
+func newPurgeCmd(g *globalFlags) *cobra.Command {
+	var (
+		olderThan time.Duration
+		yes       bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "purge",
+		Short: "Delete readings older than a retention period",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if olderThan <= 0 {
+				return errors.New("--older-than must be positive")
+			}
+			if !yes {
+				cutoff := time.Now().Add(-olderThan).Format(time.RFC3339)
+				fmt.Fprintf(cmd.OutOrStdout(), "Delete every reading from before %s on %s? [y/N] ", cutoff, g.addr)
+				answer, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+				if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+					return errors.New("aborted")
+				}
+			}
+
+			c, err := g.dial()
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			ctx, cancel := g.callContext(cmd.Context())
+			defer cancel()
+			resp, err := c.Raw().PruneReadings(ctx, &pb.PruneRequest{RetentionSeconds: int64(olderThan / time.Second)})
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "deleted %d readings\n", resp.GetDeletedCount())
+			return nil
+		},
+	}
+	cmd.Flags().DurationVar(&olderThan, "older-than", 0, "delete readings older than this, e.g. 720h")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "don't ask for confirmation")
+	cmd.MarkFlagRequired("older-than")
+	return cmd
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	lightclient "github.com/quentinrf/plant-monitor/services/light-service/pkg/client"
+)
+
+// Output formats for readings
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+// reading is a reading as lightctl prints it
+type reading struct {
+	ID                 int64    `json:"id,omitempty"`
+	Time               string   `json:"time"`
+	Lux                float64  `json:"lux"`
+	Category           string   `json:"category"`
+	Source             string   `json:"source"`
+	Quality            string   `json:"quality"`
+	TemperatureCelsius *float64 `json:"temperature_celsius,omitempty"`
+}
+
+// fromClient converts a client reading for printing
+func fromClient(r lightclient.Reading) reading {
+	return reading{
+		ID:                 r.ID,
+		Time:               r.Time.UTC().Format(time.RFC3339),
+		Lux:                r.Lux,
+		Category:           r.Category,
+		Source:             enumName(r.Source.String(), "READING_SOURCE_"),
+		Quality:            enumName(r.Quality.String(), "READING_QUALITY_"),
+		TemperatureCelsius: r.TemperatureCelsius,
+	}
+}
+
+// enumName shortens a protobuf enum value name, e.g. READING_SOURCE_MANUAL
+// to "manual"; unspecified values print as empty
+func enumName(name, prefix string) string {
+	name = strings.ToLower(strings.TrimPrefix(name, prefix))
+	if name == "unspecified" {
+		return ""
+	}
+	return name
+}
+
+// readingWriter prints readings in one format
+type readingWriter struct {
+	format string
+	out    io.Writer
+}
+
+// newReadingWriter checks format before anything is fetched
+func newReadingWriter(format string, out io.Writer) (*readingWriter, error) {
+	switch format {
+	case formatTable, formatJSON, formatCSV:
+		return &readingWriter{format: format, out: out}, nil
+	default:
+		return nil, fmt.Errorf("unknown --format %q (want table, json or csv)", format)
+	}
+}
+
+var csvHeader = []string{"id", "time", "lux", "category", "source", "quality", "temperature_celsius"}
+
+func (w *readingWriter) write(readings []reading) error {
+	switch w.format {
+	case formatJSON:
+		enc := json.NewEncoder(w.out)
+		enc.SetIndent("", "  ")
+		if readings == nil {
+			readings = []reading{}
+		}
+		return enc.Encode(readings)
+	case formatCSV:
+		cw := csv.NewWriter(w.out)
+		cw.Write(csvHeader)
+		for _, r := range readings {
+			cw.Write([]string{
+				strconv.FormatInt(r.ID, 10),
+				r.Time,
+				strconv.FormatFloat(r.Lux, 'f', -1, 64),
+				r.Category,
+				r.Source,
+				r.Quality,
+				formatTemperature(r.TemperatureCelsius),
+			})
+		}
+		cw.Flush()
+		return cw.Error()
+	default:
+		tw := tabwriter.NewWriter(w.out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tTIME\tLUX\tCATEGORY\tSOURCE\tQUALITY\tTEMP °C")
+		for _, r := range readings {
+			id := "-"
+			if r.ID != 0 {
+				id = strconv.FormatInt(r.ID, 10)
+			}
+			temp := formatTemperature(r.TemperatureCelsius)
+			if temp == "" {
+				temp = "-"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%.1f\t%s\t%s\t%s\t%s\n", id, r.Time, r.Lux, r.Category, r.Source, r.Quality, temp)
+		}
+		return tw.Flush()
+	}
+}
+
+// formatTemperature prints a temperature, or nothing if none was recorded
+func formatTemperature(t *float64) string {
+	if t == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*t, 'f', -1, 64)
+}
@@ -0,0 +1,105 @@
+// Command lightctl queries and administers a running light-service over
+// gRPC, e.g.
+//
+//	lightctl current
+//	lightctl history --since 24h --format csv
+//	lightctl --tls-cert certs/plant-service.crt --tls-key certs/plant-service.key \
+//	    --tls-ca certs/ca.crt --addr light-service:50051 purge --older-than 720h
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	lightclient "github.com/quentinrf/plant-monitor/services/light-service/pkg/client"
+)
+
+// globalFlags are the connection settings shared by every subcommand
+type globalFlags struct {
+	addr    string
+	tlsCert string
+	tlsKey  string
+	tlsCA   string
+	token   string
+	timeout time.Duration
+}
+
+func main() {
+	if err := newRootCmd().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// newRootCmd builds the command tree
+func newRootCmd() *cobra.Command {
+	var g globalFlags
+
+	root := &cobra.Command{
+		Use:          "lightctl",
+		Short:        "Query and administer light-service",
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// All three or none, as the server requires client certificates
+			set := 0
+			for _, f := range []string{g.tlsCert, g.tlsKey, g.tlsCA} {
+				if f != "" {
+					set++
+				}
+			}
+			if set != 0 && set != 3 {
+				return fmt.Errorf("--tls-cert, --tls-key and --tls-ca must be given together")
+			}
+			return nil
+		},
+	}
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&g.addr, "addr", envOr("LIGHT_SERVICE_ADDR", "localhost:50051"), "light-service address (env LIGHT_SERVICE_ADDR)")
+	flags.StringVar(&g.tlsCert, "tls-cert", os.Getenv("LIGHTCTL_TLS_CERT"), "client certificate for mTLS (env LIGHTCTL_TLS_CERT)")
+	flags.StringVar(&g.tlsKey, "tls-key", os.Getenv("LIGHTCTL_TLS_KEY"), "client private key for mTLS (env LIGHTCTL_TLS_KEY)")
+	flags.StringVar(&g.tlsCA, "tls-ca", os.Getenv("LIGHTCTL_TLS_CA"), "CA certificate for mTLS (env LIGHTCTL_TLS_CA)")
+	flags.StringVar(&g.token, "token", os.Getenv("LIGHTCTL_TOKEN"), "API key, when the server requires one (env LIGHTCTL_TOKEN)")
+	flags.DurationVar(&g.timeout, "timeout", lightclient.DefaultTimeout, "limit on each call to the service")
+
+	root.AddCommand(
+		newCurrentCmd(&g),
+		newHistoryCmd(&g),
+		newRecordCmd(&g),
+		newExportCmd(&g),
+		newPurgeCmd(&g),
+	)
+	return root
+}
+
+// dial connects to light-service with the global flags
+func (g *globalFlags) dial() (*lightclient.Client, error) {
+	opts := []lightclient.Option{lightclient.WithTimeout(g.timeout)}
+	if g.tlsCert != "" {
+		opts = append(opts, lightclient.WithTLS(g.tlsCert, g.tlsKey, g.tlsCA))
+	}
+	if g.token != "" {
+		opts = append(opts, lightclient.WithToken(g.token))
+	}
+	return lightclient.Dial(g.addr, opts...)
+}
+
+// callContext bounds a single call that the client doesn't already bound,
+// such as those made through Raw
+func (g *globalFlags) callContext(parent context.Context) (context.Context, context.CancelFunc) {
+	if g.timeout <= 0 {
+		return parent, func() {}
+	}
+	return context.WithTimeout(parent, g.timeout)
+}
+
+// envOr returns the environment variable key, or fallback if it is unset
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	grpcAdapter "github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/grpc"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/mock"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+// startServer serves repo with a steady 500 lux sensor
+func startServer(t *testing.T, repo domain.ReadingRepository) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	srv := grpc.NewServer()
+	pb.RegisterLightServiceServer(srv, grpcAdapter.NewLightServiceHandler(repo, mock.NewFakeSensor(500, 0)))
+
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	return lis.Addr().String()
+}
+
+// run executes lightctl against addr and returns its output
+func run(t *testing.T, addr, stdin string, args ...string) (string, error) {
+	t.Helper()
+
+	var out bytes.Buffer
+	cmd := newRootCmd()
+	cmd.SetArgs(append([]string{"--addr", addr}, args...))
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	err := cmd.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+// seedReadings stores readings every hour for the past n hours
+func seedReadings(t *testing.T, repo domain.ReadingRepository, n int) {
+	t.Helper()
+	now := time.Now()
+	for i := n; i >= 1; i-- {
+		r, _ := domain.NewLightReadingAt(float64(100*i), now.Add(-time.Duration(i)*time.Hour))
+		if err := repo.SaveReading(context.Background(), r); err != nil {
+			t.Fatalf("SaveReading failed: %v", err)
+		}
+	}
+}
+
+func TestHistory_Formats(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	seedReadings(t, repo, 30)
+	addr := startServer(t, repo)
+
+	out, err := run(t, addr, "", "history", "--since", "5h30m", "--format", "csv")
+	if err != nil {
+		t.Fatalf("history failed: %v\n%s", err, out)
+	}
+	rows, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v\n%s", err, out)
+	}
+	if len(rows) != 6 || strings.Join(rows[0], ",") != strings.Join(csvHeader, ",") {
+		t.Fatalf("expected a header and 5 rows, got %v", rows)
+	}
+	if rows[1][2] != "500" || rows[5][2] != "100" || rows[1][4] != "sensor" {
+		t.Errorf("expected the oldest (500 lux) first, got %v", rows[1:])
+	}
+
+	out, err = run(t, addr, "", "history", "--since", "2h30m", "--format", "json")
+	if err != nil {
+		t.Fatalf("history failed: %v\n%s", err, out)
+	}
+	var readings []reading
+	if err := json.Unmarshal([]byte(out), &readings); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(readings) != 2 || readings[1].Lux != 100 {
+		t.Errorf("unexpected readings %+v", readings)
+	}
+
+	out, err = run(t, addr, "", "history", "--since", "1h30m")
+	if err != nil || !strings.HasPrefix(out, "ID") || strings.Count(out, "\n") != 2 {
+		t.Errorf("expected a table with one row, got %v\n%s", err, out)
+	}
+
+	if _, err := run(t, addr, "", "history", "--format", "xml"); err == nil {
+		t.Error("expected an unknown format rejected")
+	}
+}
+
+func TestRecordThenCurrent(t *testing.T) {
+	addr := startServer(t, memory.NewReadingRepository())
+
+	at := time.Now().Add(-time.Minute).UTC().Truncate(time.Second).Format(time.RFC3339)
+	out, err := run(t, addr, "", "record", "--lux", "1234", "--temperature", "21.5", "--at", at)
+	if err != nil || !strings.Contains(out, "1234 lux") || !strings.Contains(out, at) {
+		t.Fatalf("unexpected record output %v\n%s", err, out)
+	}
+
+	out, err = run(t, addr, "", "current", "--format", "json")
+	if err != nil {
+		t.Fatalf("current failed: %v\n%s", err, out)
+	}
+	var readings []reading
+	if err := json.Unmarshal([]byte(out), &readings); err != nil || len(readings) != 1 {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if r := readings[0]; r.Lux != 1234 || r.Time != at || r.TemperatureCelsius == nil || *r.TemperatureCelsius != 21.5 {
+		t.Errorf("unexpected reading %+v", r)
+	}
+
+	if _, err := run(t, addr, "", "record"); err == nil {
+		t.Error("expected --lux to be required")
+	}
+}
+
+func TestExport(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	seedReadings(t, repo, 3)
+	addr := startServer(t, repo)
+
+	path := filepath.Join(t.TempDir(), "readings.ndjson")
+	if out, err := run(t, addr, "", "export", "--since", "24h", "--format", "ndjson", "-o", path); err != nil {
+		t.Fatalf("export failed: %v\n%s", err, out)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read export: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(data)), "\n"); len(lines) != 3 {
+		t.Errorf("expected 3 NDJSON lines, got %d:\n%s", len(lines), data)
+	}
+}
+
+func TestPurge(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	seedReadings(t, repo, 72)
+	addr := startServer(t, repo)
+
+	// Declining the prompt deletes nothing
+	if _, err := run(t, addr, "n\n", "purge", "--older-than", "48h"); err == nil {
+		t.Fatal("expected purge to abort")
+	}
+	if stats, _ := repo.Stats(context.Background()); stats.ReadingCount != 72 {
+		t.Fatalf("expected nothing deleted, got %d left", stats.ReadingCount)
+	}
+
+	out, err := run(t, addr, "yes\n", "purge", "--older-than", "48h30m")
+	if err != nil || !strings.Contains(out, "deleted 24 readings") {
+		t.Errorf("unexpected purge output %v\n%s", err, out)
+	}
+	out, err = run(t, addr, "", "purge", "--older-than", "24h30m", "-y")
+	if err != nil || !strings.Contains(out, "deleted 24 readings") {
+		t.Errorf("unexpected purge output %v\n%s", err, out)
+	}
+}
+
+func TestPartialTLSFlags(t *testing.T) {
+	if _, err := run(t, "127.0.0.1:1", "", "--tls-cert", "client.crt", "current"); err == nil {
+		t.Error("expected --tls-cert without --tls-key and --tls-ca rejected")
+	}
+}
@@ -2,25 +2,48 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
+	"io"
 	"net"
+	"net/http"
+	"net/http/pprof"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
+	"golang.org/x/net/netutil"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/grafana"
 	grpcAdapter "github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/grpc"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/i2c"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/importer"
 	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/metrics"
 	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/mock"
-	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/sqlite"
+	natsAdapter "github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/nats"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/readonly"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/rest"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/tracing"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/webhook"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/auth"
+	appConfig "github.com/quentinrf/plant-monitor/services/light-service/internal/config"
 	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/logging"
 	"github.com/quentinrf/plant-monitor/services/light-service/internal/ports"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/repository"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/middleware"
 	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
 	"github.com/quentinrf/plant-monitor/services/light-service/pkg/tlsconfig"
 )
@@ -29,68 +52,454 @@ func main() {
 	// Initialize logger
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
 	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
+	// Handlers log through zerolog.Ctx; calls that didn't come through the
+	// gRPC middleware, like the REST gateway's, fall back to the global logger
+	zerolog.DefaultContextLogger = &log.Logger
 
 	log.Info().Msg("starting light service")
 
 	// Read configuration from environment
-	config := loadConfig()
+	configPath := flag.String("config", "", "YAML or TOML config file; environment variables override its settings")
+	flag.Parse()
+	config, err := appConfig.Load(*configPath)
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid configuration")
+	}
+	if *configPath != "" {
+		log.Info().Str("path", *configPath).Msg("loaded config file")
+	}
+	log.Info().Str("env", config.Env).Msg("deployment environment")
+	tracingEnabled := tracing.Enabled()
+	if config.LogLevel != "" {
+		level, err := zerolog.ParseLevel(config.LogLevel)
+		if err != nil {
+			log.Fatal().Str("level", config.LogLevel).Msg("invalid LOG_LEVEL")
+		}
+		zerolog.SetGlobalLevel(level)
+	}
 
-	// Initialize repository
-	var repo domain.ReadingRepository
-	switch config.RepoType {
-	case "sqlite":
-		r, err := sqlite.NewReadingRepository(config.DBPath)
+	interval, err := clampRecordInterval(config.RecordInterval, config.MinRecordInterval, config.MaxRecordInterval)
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid RECORD_INTERVAL")
+	}
+	if interval != config.RecordInterval {
+		log.Warn().
+			Dur("requested", config.RecordInterval).
+			Dur("using", interval).
+			Msg("RECORD_INTERVAL out of range; clamped")
+		config.RecordInterval = interval
+	}
+	if err := checkPollInterval(config.PollInterval, config.RecordInterval); err != nil {
+		log.Fatal().Err(err).Msg("invalid POLL_INTERVAL")
+	}
+
+	// Export traces when an OTLP endpoint is configured; the rest of the
+	// exporter settings come from the standard OTEL_* variables
+	var shutdownTracing func(context.Context) error
+	if tracingEnabled {
+		shutdownTracing, err = tracing.Setup(context.Background(), "light-service")
 		if err != nil {
-			log.Fatal().Err(err).Str("db_path", config.DBPath).Msg("failed to open SQLite database")
+			log.Fatal().Err(err).Msg("failed to set up tracing")
 		}
-		defer r.Close()
-		repo = r
-		log.Info().Str("db_path", config.DBPath).Msg("initialized SQLite repository")
-	default:
-		repo = memory.NewReadingRepository()
-		log.Info().Msg("initialized in-memory repository")
+		log.Info().Msg("exporting traces over OTLP")
+	}
+
+	// Initialize repository
+	repo, err := repository.New(repository.RepoConfig{
+		Type:     config.RepoType,
+		ReadOnly: config.ReadOnly,
+		SQLite: repository.SQLiteConfig{
+			Path:         config.DBPath,
+			JournalMode:  config.SQLiteJournalMode,
+			BusyTimeout:  config.SQLiteBusyTimeout,
+			Synchronous:  config.SQLiteSynchronous,
+			MaxOpenConns: config.SQLiteMaxOpenConns,
+
+			MaxIdleConns:    config.SQLiteMaxIdleConns,
+			ConnMaxLifetime: config.SQLiteConnMaxLifetime,
+			QueryTimeout:    config.SQLiteQueryTimeout,
+		},
+		Postgres: repository.PostgresConfig{
+			URL: config.DatabaseURL,
+		},
+		Influx: repository.InfluxConfig{
+			URL:       config.InfluxURL,
+			Token:     config.InfluxToken,
+			Org:       config.InfluxOrg,
+			Bucket:    config.InfluxBucket,
+			Retention: config.InfluxRetention,
+		},
+	})
+	if err != nil {
+		log.Fatal().Err(err).Str("repo_type", config.RepoType).Msg("failed to initialize repository")
+	}
+	if closer, ok := repo.(io.Closer); ok {
+		defer closer.Close()
+	}
+	if config.RepoType == repository.TypeSQLite {
+		log.Info().
+			Str("db_path", config.DBPath).
+			Str("journal_mode", config.SQLiteJournalMode).
+			Dur("busy_timeout", config.SQLiteBusyTimeout).
+			Msg("initialized SQLite repository")
+	} else {
+		log.Info().Str("repo_type", config.RepoType).Msg("initialized repository")
+	}
+	// SQL stores keep calibrations alongside readings; the in-memory store
+	// keeps them separately, so they are lost on restart like the readings
+	calibrations, ok := repo.(domain.CalibrationRepository)
+	if !ok {
+		calibrations = memory.NewCalibrationRepository()
+	}
+	if config.ReadOnly {
+		calibrations = readonly.NewCalibrationRepository(calibrations)
+	}
+	if tracingEnabled {
+		repo = tracing.NewReadingRepository(repo, dbSystemName(config.RepoType))
+	}
+
+	// Demo history, written before the publishing wrapper since nobody can
+	// be watching yet
+	if config.SeedData {
+		if config.ReadOnly {
+			log.Warn().Msg("SEED_DATA ignored in read-only mode")
+		} else {
+			// Seed the curve the diurnal sensor will carry on from
+			lux := func(at time.Time) float64 { return mock.DaylightLux(at, 3000) }
+			if config.SensorType == "diurnal" {
+				model, err := mock.NewDiurnalSensor(config.DiurnalOptions()...)
+				if err != nil {
+					log.Fatal().Err(err).Msg("invalid diurnal sensor settings")
+				}
+				lux = model.LuxAt
+			}
+			seeded, err := ports.SeedReadings(context.Background(), repo, ports.SeedConfig{
+				End:      time.Now(),
+				Span:     24 * time.Hour,
+				Interval: config.RecordInterval,
+				Lux:      lux,
+				Force:    config.SeedDataForce,
+			})
+			if err != nil {
+				log.Fatal().Err(err).Msg("failed to seed demo data")
+			}
+			if seeded == 0 {
+				log.Info().Msg("store already has readings; skipping SEED_DATA (set SEED_DATA_FORCE to seed anyway)")
+			} else {
+				log.Info().Int("readings", seeded).Bool("forced", config.SeedDataForce).Msg("seeded a day of demo readings")
+			}
+		}
+	}
+
+	// Every write publishes here for WatchDataChanges subscribers
+	changes := ports.NewDataChangeBus()
+	if config.ReadOnly {
+		repo = readonly.NewReadingRepository(repo)
+		log.Warn().Msg("READ-ONLY MODE: all writes are rejected and the recorder is disabled")
+	} else {
+		repo = ports.NewPublishingRepository(repo, changes)
 	}
 
 	// Initialize sensor
-	var sensor ports.LightSensor
-	switch config.SensorType {
-	case "gpio":
-		log.Fatal().Msg("gpio sensor not yet implemented; set SENSOR_TYPE=mock")
+	sensor, err := openSensor(config.SensorType, config, config.I2CAddress)
+	if err != nil {
+		log.Fatal().Err(err).Str("type", config.SensorType).Msg("failed to initialize sensor")
+	}
+	traced := func(s ports.LightSensor, name string) ports.LightSensor {
+		if !tracingEnabled {
+			return s
+		}
+		// Innermost, so spans time the driver rather than cache hits
+		return tracing.NewSensor(s, name)
+	}
+	sensor = traced(sensor, config.SensorType)
+	var watchdog *ports.SensorWatchdog
+	if config.SensorFailureThreshold > 0 {
+		var watchdogOpts []ports.WatchdogOption
+		if config.SensorReinit && config.SensorType == "gpio" {
+			watchdogOpts = append(watchdogOpts, ports.WithReinit(func(ctx context.Context) (ports.LightSensor, error) {
+				s, err := openBH1750(config, config.I2CAddress)
+				if err != nil {
+					return nil, err
+				}
+				return traced(s, config.SensorType), nil
+			}))
+		}
+		if config.FallbackSensorType != "" {
+			fallback, err := openSensor(config.FallbackSensorType, config, config.FallbackI2CAddress)
+			if err != nil {
+				log.Fatal().Err(err).Str("type", config.FallbackSensorType).Msg("failed to initialize fallback sensor")
+			}
+			watchdogOpts = append(watchdogOpts, ports.WithFallbackSensor(traced(fallback, config.FallbackSensorType)))
+		}
+		watchdog = ports.NewSensorWatchdog(sensor, config.SensorFailureThreshold, watchdogOpts...)
+		sensor = watchdog
+		log.Info().
+			Int("failure_threshold", config.SensorFailureThreshold).
+			Bool("reinit", config.SensorReinit && config.SensorType == "gpio").
+			Str("fallback", config.FallbackSensorType).
+			Msg("supervising the light sensor")
+	}
+	// Calibrate before filtering and caching, so both work on corrected lux
+	sensorID := config.SensorID
+	if sensorID == "" {
+		sensorID = config.SensorType
+	}
+	calibrated, err := ports.LoadCalibratedSensor(context.Background(), sensor, calibrations, sensorID)
+	if err != nil {
+		log.Fatal().Err(err).Str("sensor_id", sensorID).Msg("failed to load sensor calibration")
+	}
+	if cal := calibrated.Calibration(); !cal.IsIdentity() {
+		log.Info().
+			Str("sensor_id", sensorID).
+			Float64("scale", cal.Scale).
+			Float64("offset_lux", cal.OffsetLux).
+			Msg("applying sensor calibration")
+	}
+	sensor = calibrated
+	if config.MedianFilterWindow > 1 {
+		// Below the cache, so a cached value is already filtered
+		sensor = ports.NewMedianFilterSensor(sensor, config.MedianFilterWindow)
+		log.Info().Int("window", config.MedianFilterWindow).Msg("filtering sensor spikes with a rolling median")
+	}
+	if config.SensorCacheTTL > 0 {
+		// Shared by the recorder and live reads, so samples taken closer
+		// together than the TTL would all see the same value
+		if config.SamplesPerReading > 1 && config.SampleInterval < config.SensorCacheTTL {
+			log.Warn().Msg("SAMPLE_INTERVAL is shorter than SENSOR_CACHE_TTL; samples will repeat cached values")
+		}
+		sensor = ports.NewCachedSensor(sensor, config.SensorCacheTTL)
+		log.Info().Dur("ttl", config.SensorCacheTTL).Msg("caching sensor reads")
+	}
+	defer sensor.Close()
+
+	// Initialize optional temperature sensor
+	var recorderOpts []ports.RecorderOption
+	switch config.TemperatureSensorType {
+	case "mock":
+		tempSensor := mock.NewFakeTemperatureSensor(21.0, 2.0) // 21±2 °C (heated room)
+		defer tempSensor.Close()
+		recorderOpts = append(recorderOpts, ports.WithTemperatureSensor(tempSensor))
+		log.Info().Msg("initialized mock temperature sensor")
+	case "", "none":
+		log.Info().Msg("no temperature sensor configured")
 	default:
-		sensor = mock.NewFakeSensor(500.0, 100.0) // 500±100 lux (indoor lighting)
-		log.Info().Msg("initialized mock sensor")
+		log.Fatal().Str("type", config.TemperatureSensorType).Msg("unknown TEMPERATURE_SENSOR_TYPE; use mock or none")
+	}
+
+	// A custom scheme names readings and is what category events record
+	var scheme *domain.CategoryScheme
+	if config.CategoryScheme != "" {
+		scheme, err = config.Scheme()
+		if err != nil {
+			log.Fatal().Err(err).Msg("invalid CATEGORY_SCHEME")
+		}
+		recorderOpts = append(recorderOpts, ports.WithCategoryScheme(scheme))
+		log.Info().Int("levels", scheme.Levels()).Msg("using custom category scheme")
+	}
+
+	// Build the background recorder; it starts once the servers are up
+	recorderOpts = append(recorderOpts,
+		ports.WithCategoryHysteresis(config.CategoryHysteresis),
+		ports.WithSamplesPerReading(config.SamplesPerReading, config.SampleInterval, config.SampleDropOutliers),
+		ports.WithStartupRetries(config.StartupRetries, config.StartupRetryDelay),
+	)
+	if nightMode := config.NightMode(); nightMode != nil {
+		recorderOpts = append(recorderOpts, ports.WithNightMode(*nightMode))
+	}
+	if config.DedupMaxSkip > 0 {
+		recorderOpts = append(recorderOpts, ports.WithSkipUnchanged(config.DedupLuxEpsilon, config.DedupMaxSkip))
+	}
+	if config.PollInterval > 0 {
+		recorderOpts = append(recorderOpts, ports.WithPollInterval(config.PollInterval))
+		log.Info().
+			Dur("poll_interval", config.PollInterval).
+			Dur("record_interval", config.RecordInterval).
+			Msg("polling the sensor between recordings")
+	}
+	if config.DropSaturated {
+		recorderOpts = append(recorderOpts, ports.WithDropSaturated())
+	}
+	if config.WriteBufferSize > 0 {
+		recorderOpts = append(recorderOpts, ports.WithWriteBuffer(config.WriteBufferSize, config.WriteBufferFlush))
+		log.Info().
+			Int("batch_size", config.WriteBufferSize).
+			Dur("flush_interval", config.WriteBufferFlush).
+			Msg("buffering readings for batch inserts")
 	}
+	// A read stuck longer than shutdown would wait for it is abandoned
+	recorderOpts = append(recorderOpts, ports.WithReadTimeout(recorderStopTimeout))
+	// New readings go out as events to StreamReadings and alert evaluation
+	events := ports.NewEventBus()
+	recorderOpts = append(recorderOpts, ports.WithEventBus(events))
+	// Alert rules and fired alerts are kept in memory, so rules must be
+	// recreated after a restart
+	alerts := memory.NewAlertRepository()
+	var notifiers ports.Notifiers
+	if config.AlertWebhookURL != "" {
+		notifiers = append(notifiers, webhook.NewNotifier(config.AlertWebhookURL, webhook.WithTimeout(config.AlertWebhookTimeout)))
+		log.Info().Str("url", config.AlertWebhookURL).Msg("sending alerts to webhook")
+	}
+	// Readings and alerts also go to NATS for services that react to them
+	var publisher *natsAdapter.Publisher
+	if config.NATSURL != "" {
+		var natsOpts []natsAdapter.Option
+		if scheme != nil {
+			natsOpts = append(natsOpts, natsAdapter.WithCategoryScheme(scheme))
+		}
+		publisher, err = natsAdapter.NewPublisher(config.NATSURL, natsOpts...)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to set up NATS publisher")
+		}
+		notifiers = append(notifiers, publisher)
+		log.Info().Msg("publishing readings and alerts to NATS")
+	}
+	var notifier ports.Notifier
+	if len(notifiers) > 0 {
+		notifier = notifiers
+	}
+	alertEvaluator := ports.NewAlertEvaluator(alerts, notifier)
+	recorder := ports.NewRecorder(sensor, repo, config.RecordInterval, recorderOpts...)
 
 	// Initialize gRPC handler
-	handler := grpcAdapter.NewLightServiceHandler(repo, sensor)
+	var handlerOpts []grpcAdapter.HandlerOption
+	if labels := config.Labels(); labels != nil {
+		handlerOpts = append(handlerOpts, grpcAdapter.WithCategoryLabeler(labels))
+		log.Info().Interface("labels", labels).Msg("using custom category labels")
+	}
+	if scheme != nil {
+		handlerOpts = append(handlerOpts, grpcAdapter.WithCategoryScheme(scheme))
+	}
+	handlerOpts = append(handlerOpts,
+		grpcAdapter.WithCategoryHysteresis(config.CategoryHysteresis),
+		grpcAdapter.WithRecordInterval(config.RecordInterval),
+		grpcAdapter.WithMinPruneRetention(config.MinPruneRetention),
+		grpcAdapter.WithMaxRecentLimit(config.MaxRecentLimit),
+		grpcAdapter.WithMaxBatchSize(config.MaxBatchSize),
+		grpcAdapter.WithMaxCategoryGap(config.MaxCategoryGap),
+		grpcAdapter.WithLuxToPPFD(config.LuxToPPFD),
+		grpcAdapter.WithPhotoperiodThreshold(config.PhotoperiodLux),
+		grpcAdapter.WithMaxHistorySpan(config.MaxHistorySpan),
+		grpcAdapter.WithMaxHistoryReadings(config.MaxHistoryReadings),
+		grpcAdapter.WithDataChanges(changes),
+		grpcAdapter.WithAlerts(alerts),
+		grpcAdapter.WithCalibration(calibrations, calibrated),
+	)
+	if !config.ReadOnly {
+		handlerOpts = append(handlerOpts,
+			grpcAdapter.WithRecorderStatus(recorder),
+			grpcAdapter.WithReadingStream(events),
+		)
+	}
+	handler := grpcAdapter.NewLightServiceHandler(repo, sensor, handlerOpts...)
 
 	// Configure TLS if certificates are provided
 	var serverOpts []grpc.ServerOption
 	if config.TLSCert != "" {
-		tlsCfg, err := tlsconfig.LoadServerTLS(config.TLSCert, config.TLSKey, config.TLSCA)
+		// A rotated certificate is picked up at the next handshake after
+		// the periodic check
+		tlsCfg, err := tlsconfig.LoadServerTLS(config.TLSCert, config.TLSKey, config.TLSCA,
+			tlsconfig.WithReloadHook(func(err error) {
+				if err != nil {
+					log.Error().Err(err).Msg("failed to reload TLS certificate, keeping the current one")
+					return
+				}
+				log.Info().Str("cert", config.TLSCert).Msg("TLS certificate reloaded")
+			}),
+		)
 		if err != nil {
 			log.Fatal().Err(err).Msg("failed to load TLS config")
 		}
 		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsCfg)))
 		log.Info().Msg("mTLS enabled")
 	} else {
-		log.Warn().Msg("TLS_CERT not set — starting without TLS (dev mode only)")
+		// Config validation refuses this in production
+		log.Warn().Msg("TLS_CERT not set — starting without TLS (ENV=development only)")
 	}
 
+	// API keys decide what each caller may do, over TLS or not
+	var keys *auth.KeyStore
+	if config.AuthKeysFile != "" {
+		keys, err = auth.LoadKeyFile(config.AuthKeysFile)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to load API keys")
+		}
+		log.Info().Int("keys", keys.Len()).Msg("API key auth enabled")
+	} else {
+		log.Warn().Msg("AUTH_KEYS_FILE not set — any client may read and write")
+	}
+
+	serverOpts = append(serverOpts,
+		grpc.MaxRecvMsgSize(config.MaxMsgSize),
+		grpc.MaxSendMsgSize(config.MaxMsgSize),
+	)
+	serverOpts = append(serverOpts, config.Keepalive().ServerOptions()...)
+	if tracingEnabled {
+		// Message events mark when the request was decoded and the response
+		// encoded, separating serialization from handler time
+		serverOpts = append(serverOpts, grpc.StatsHandler(otelgrpc.NewServerHandler(
+			otelgrpc.WithMessageEvents(otelgrpc.ReceivedEvents, otelgrpc.SentEvents),
+			otelgrpc.WithFilter(filters.Not(filters.HealthCheck())),
+		)))
+	}
+
+	// Served on the metrics port below; created here so the access log can
+	// register its per-peer counter
+	registry := prometheus.NewRegistry()
+
+	// Count in-flight RPCs so shutdown can report what it is draining, tag
+	// each call with a request ID, check its API key, log which client made
+	// it, give handlers a logger carrying the ID, and turn a panicking
+	// handler into an Internal error rather than a crash
+	inFlight := grpcAdapter.NewInFlightCounter()
+	var accessLogOpts []grpcAdapter.AccessLogOption
+	if config.PeerMetrics {
+		accessLogOpts = append(accessLogOpts, grpcAdapter.WithPeerMetrics(registry))
+	}
+	accessLog := grpcAdapter.NewAccessLogger(log.Logger, accessLogOpts...)
+	unary := []grpc.UnaryServerInterceptor{inFlight.UnaryInterceptor(), middleware.UnaryRequestID()}
+	stream := []grpc.StreamServerInterceptor{inFlight.StreamInterceptor(), middleware.StreamRequestID()}
+	if keys != nil {
+		authenticator := grpcAdapter.NewAuthenticator(keys, log.Logger)
+		unary = append(unary, authenticator.UnaryInterceptor())
+		stream = append(stream, authenticator.StreamInterceptor())
+	}
+	serverOpts = append(serverOpts,
+		grpc.ChainUnaryInterceptor(append(unary,
+			accessLog.UnaryInterceptor(),
+			middleware.UnaryLogging(log.Logger),
+			middleware.UnaryRecovery(log.Logger),
+		)...),
+		grpc.ChainStreamInterceptor(append(stream,
+			accessLog.StreamInterceptor(),
+			middleware.StreamLogging(log.Logger),
+			middleware.StreamRecovery(log.Logger),
+		)...),
+	)
+
 	// Create gRPC server
 	grpcServer := grpc.NewServer(serverOpts...)
 	pb.RegisterLightServiceServer(grpcServer, handler)
+	healthServer := grpcAdapter.RegisterHealth(grpcServer)
 
-	// Enable gRPC reflection for grpcurl testing
-	reflection.Register(grpcServer)
+	// Enable gRPC reflection for grpcurl testing, but don't describe the API
+	// to anyone who connects in production
+	if !config.Production() {
+		reflection.Register(grpcServer)
+		log.Info().Msg("gRPC reflection enabled")
+	}
 
 	// Start gRPC server
-	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", config.Port))
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", config.Port))
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to listen")
 	}
+	if config.MaxConnections > 0 {
+		// Connections beyond the cap wait in the accept queue
+		listener = netutil.LimitListener(listener, config.MaxConnections)
+	}
 
-	log.Info().Str("port", config.Port).Msg("gRPC server listening")
+	log.Info().Int("port", config.Port).Msg("gRPC server listening")
 
 	// Start server in goroutine
 	go func() {
@@ -99,76 +508,261 @@ func main() {
 		}
 	}()
 
+	// Start metrics HTTP server
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		metrics.NewStorageCollector(repo),
+	)
+	if !config.ReadOnly {
+		registry.MustRegister(metrics.NewRecorderCollector(recorder))
+	}
+	if watchdog != nil {
+		registry.MustRegister(metrics.NewSensorCollector(watchdog))
+	}
+	if config.EnablePprof {
+		log.Warn().Int("port", config.MetricsPort).Msg("pprof enabled on /debug/pprof/")
+	}
+	metricsServer := &http.Server{
+		Addr:              fmt.Sprintf(":%d", config.MetricsPort),
+		Handler:           newMetricsMux(registry, repo, scheme, newGateway(handler, config.RESTAllowedOrigins, keys), keys, config.EnablePprof),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		log.Info().Int("port", config.MetricsPort).Msg("metrics server listening")
+		if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatal().Err(err).Msg("failed to serve metrics")
+		}
+	}()
+
 	// Start background recorder
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	recorder := ports.NewRecorder(sensor, repo, config.RecordInterval)
-	go recorder.Start(ctx)
+	recorderDone := make(chan struct{})
+	if config.ReadOnly {
+		// Recording and cleanup would only fail against a read-only repository
+		close(recorderDone)
+	} else {
+		// Subscribed before the first recording, so it isn't missed
+		alertEvaluator.Subscribe(ctx, events)
+		if publisher != nil {
+			publisher.Subscribe(ctx, events)
+		}
+		go func() {
+			recorder.Start(ctx)
+			close(recorderDone)
+		}()
+		if config.HealthFailureThreshold > 0 {
+			go grpcAdapter.WatchRecorder(ctx, healthServer, recorder.Status, config.HealthFailureThreshold, healthPollInterval)
+		}
+	}
+	if watchdog != nil {
+		go grpcAdapter.WatchSensor(ctx, healthServer, watchdog.Status, healthPollInterval)
+	}
+
+	// SIGUSR1 turns on debug logging for a while, e.g. to watch per-reading
+	// logs from a misbehaving sensor without restarting at debug level
+	debugWindow := logging.NewDebugWindow()
+	debugSignal := make(chan os.Signal, 1)
+	signal.Notify(debugSignal, syscall.SIGUSR1)
+	go func() {
+		for range debugSignal {
+			debugWindow.Open(config.DebugWindow)
+		}
+	}()
 
 	// Wait for interrupt signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	log.Info().Msg("shutting down server...")
+	shutdownStart := time.Now()
+	log.Info().Int64("active_rpcs", inFlight.Active()).Msg("shutting down server...")
 
-	// Graceful shutdown
-	cancel() // Stop recorder
+	// Phase 0: report not ready and keep serving while load balancers notice
+	grpcAdapter.StartDraining(healthServer, config.ShutdownGracePeriod)
+
+	// Graceful shutdown, phase 1: stop the recorder. Start flushes the
+	// write buffer before returning, so once it has there are no pending
+	// writes.
+	phaseStart := time.Now()
+	cancel()
+	recorderFlushed := true
+	select {
+	case <-recorderDone:
+	case <-time.After(recorderStopTimeout):
+		recorderFlushed = false
+	}
+	log.Info().
+		Bool("recorder_flushed", recorderFlushed).
+		Dur("duration", time.Since(phaseStart)).
+		Msg("recorder stopped")
+
+	if publisher != nil {
+		if err := publisher.Close(); err != nil {
+			log.Error().Err(err).Msg("failed to send pending NATS messages")
+		}
+	}
+
+	// Phase 2: stop accepting RPCs and wait for in-flight ones to finish
+	phaseStart = time.Now()
+	log.Info().Int64("active_rpcs", inFlight.Active()).Msg("draining in-flight RPCs")
 	grpcServer.GracefulStop()
+	log.Info().
+		Int64("active_rpcs", inFlight.Active()).
+		Dur("duration", time.Since(phaseStart)).
+		Msg("gRPC server drained")
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer shutdownCancel()
+	if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+		log.Error().Err(err).Msg("failed to stop metrics server")
+	}
+	if shutdownTracing != nil {
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			log.Error().Err(err).Msg("failed to flush traces")
+		}
+	}
 
-	log.Info().Msg("server stopped")
+	log.Info().Dur("duration", time.Since(shutdownStart)).Msg("server stopped")
 }
 
-// Config holds application configuration
-type Config struct {
-	Port           string
-	RecordInterval time.Duration
-	RepoType       string // "memory" | "sqlite"
-	DBPath         string // SQLite database file path (used when RepoType=sqlite)
-	SensorType     string // "mock" | "gpio"
-	TLSCert        string // path to this service's certificate
-	TLSKey         string // path to this service's private key
-	TLSCA          string // path to the CA certificate
+// recorderStopTimeout bounds how long shutdown waits for an in-progress
+// recording to finish before moving on
+const recorderStopTimeout = 10 * time.Second
+
+// healthPollInterval is how often the health status is re-derived from the
+// recorder's; cycles are minutes apart, so this adds little delay
+const healthPollInterval = 5 * time.Second
+
+// dbSystemName maps REPO_TYPE to the OpenTelemetry db.system.name value
+func dbSystemName(repoType string) string {
+	switch repoType {
+	case repository.TypePostgres:
+		return "postgresql"
+	case repository.TypeSQLite:
+		return "sqlite"
+	case repository.TypeInflux:
+		return "influxdb"
+	default:
+		return "memory"
+	}
 }
 
-// loadConfig reads configuration from environment variables
-func loadConfig() Config {
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "50051"
+// clampRecordInterval checks the recording interval and pulls it into
+// [lo, hi]. A zero or negative interval is an error rather than something to
+// clamp, since it can only be a mistake.
+func clampRecordInterval(d, lo, hi time.Duration) (time.Duration, error) {
+	if lo <= 0 || hi < lo {
+		return 0, fmt.Errorf("interval range %v-%v is invalid", lo, hi)
 	}
+	if d <= 0 {
+		return 0, fmt.Errorf("interval must be positive, got %v", d)
+	}
+	return min(max(d, lo), hi), nil
+}
 
-	recordInterval := 5 * time.Minute
-	if intervalStr := os.Getenv("RECORD_INTERVAL"); intervalStr != "" {
-		if d, err := time.ParseDuration(intervalStr); err == nil {
-			recordInterval = d
+// openSensor initializes a light sensor of kind "gpio", "diurnal" or
+// "mock", a gpio one at address on the configured bus
+func openSensor(kind string, config appConfig.Config, address uint16) (ports.LightSensor, error) {
+	switch kind {
+	case "gpio":
+		return openBH1750(config, address)
+	case "diurnal":
+		opts := append(config.DiurnalOptions(), mock.WithClouds(config.DiurnalClouds, time.Now().UnixNano()))
+		diurnal, err := mock.NewDiurnalSensor(opts...)
+		if err != nil {
+			return nil, fmt.Errorf("invalid diurnal sensor settings: %w", err)
 		}
+		sunrise, sunset, _ := diurnal.SunTimes(time.Now())
+		log.Info().
+			Float64("peak_lux", config.DiurnalPeakLux).
+			Dur("sunrise", sunrise).
+			Dur("sunset", sunset).
+			Msg("initialized diurnal mock sensor")
+		return diurnal, nil
+	default:
+		log.Info().Msg("initialized mock sensor")
+		return mock.NewFakeSensor(500.0, 100.0), nil // 500±100 lux (indoor lighting)
+	}
+}
+
+// openBH1750 opens the I2C device at address and starts a BH1750 on it
+func openBH1750(config appConfig.Config, address uint16) (*i2c.BH1750, error) {
+	mode, err := i2c.ParseMode(config.BH1750Mode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid BH1750_MODE: %w", err)
+	}
+	dev, err := i2c.Open(config.I2CBus, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open I2C device: %w", err)
 	}
+	bh1750, err := i2c.NewBH1750(dev, mode)
+	if err != nil {
+		dev.Close()
+		return nil, err
+	}
+	log.Info().
+		Int("bus", config.I2CBus).
+		Str("address", fmt.Sprintf("%#x", address)).
+		Str("mode", config.BH1750Mode).
+		Msg("initialized BH1750 sensor")
+	return bh1750, nil
+}
 
-	repoType := os.Getenv("REPO_TYPE")
-	if repoType == "" {
-		repoType = "memory"
+// checkPollInterval checks that polls, if enabled, come at least as often as
+// recordings; otherwise some recordings would have no polls to aggregate
+func checkPollInterval(poll, record time.Duration) error {
+	if poll < 0 {
+		return fmt.Errorf("poll interval must not be negative, got %v", poll)
+	}
+	if poll > record {
+		return fmt.Errorf("poll interval %v exceeds the recording interval %v", poll, record)
 	}
+	return nil
+}
 
-	dbPath := os.Getenv("DB_PATH")
-	if dbPath == "" {
-		dbPath = "./light.db"
+// newGateway creates the REST gateway, requiring API keys if keys is set
+func newGateway(handler pb.LightServiceServer, origins []string, keys *auth.KeyStore) *rest.Gateway {
+	opts := []rest.Option{rest.WithAllowedOrigins(origins...)}
+	if keys != nil {
+		opts = append(opts, rest.WithAuth(keys))
 	}
+	return rest.NewGateway(handler, opts...)
+}
 
-	sensorType := os.Getenv("SENSOR_TYPE")
-	if sensorType == "" {
-		sensorType = "mock"
+// newMetricsMux routes the metrics port: Prometheus on /metrics, JSON
+// backfill on POST /import, the REST gateway on /v1/, pprof on
+// /debug/pprof/ when enabled, and the Grafana SimpleJSON datasource on
+// everything else. pprof exposes process internals, so it is off by default
+// and never served over gRPC. With keys, importing and pprof need a writer
+// key and Grafana a reader key; the gateway checks its own, and metrics stay
+// open to scrapers. Grafana charts categories as levels of scheme, nil
+// meaning the default.
+func newMetricsMux(gatherer prometheus.Gatherer, repo domain.ReadingRepository, scheme *domain.CategoryScheme, gateway http.Handler, keys *auth.KeyStore, enablePprof bool) *http.ServeMux {
+	require := func(role auth.Role, h http.Handler) http.Handler {
+		if keys == nil {
+			return h
+		}
+		return rest.RequireRole(keys, role, h)
 	}
 
-	return Config{
-		Port:           port,
-		RecordInterval: recordInterval,
-		RepoType:       repoType,
-		DBPath:         dbPath,
-		SensorType:     sensorType,
-		TLSCert:        os.Getenv("TLS_CERT"),
-		TLSKey:         os.Getenv("TLS_KEY"),
-		TLSCA:          os.Getenv("TLS_CA"),
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{}))
+	mux.Handle("POST /import", require(auth.RoleWriter, importer.NewHandler(repo)))
+	mux.Handle("/v1/", gateway)
+	if enablePprof {
+		mux.Handle("/debug/pprof/", require(auth.RoleWriter, http.HandlerFunc(pprof.Index)))
+		mux.Handle("/debug/pprof/cmdline", require(auth.RoleWriter, http.HandlerFunc(pprof.Cmdline)))
+		mux.Handle("/debug/pprof/profile", require(auth.RoleWriter, http.HandlerFunc(pprof.Profile)))
+		mux.Handle("/debug/pprof/symbol", require(auth.RoleWriter, http.HandlerFunc(pprof.Symbol)))
+		mux.Handle("/debug/pprof/trace", require(auth.RoleWriter, http.HandlerFunc(pprof.Trace)))
+	}
+	var grafanaOpts []grafana.Option
+	if scheme != nil {
+		grafanaOpts = append(grafanaOpts, grafana.WithCategoryScheme(scheme))
 	}
+	mux.Handle("/", require(auth.RoleReader, grafana.NewHandler(repo, grafanaOpts...)))
+	return mux
 }
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+)
+
+func TestClampRecordInterval(t *testing.T) {
+	const lo, hi = time.Second, 24 * time.Hour
+
+	tests := []struct {
+		name    string
+		in      time.Duration
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "in range", in: 5 * time.Minute, want: 5 * time.Minute},
+		{name: "at minimum", in: lo, want: lo},
+		{name: "at maximum", in: hi, want: hi},
+		{name: "just below minimum", in: lo - time.Nanosecond, want: lo},
+		{name: "typo'd milliseconds", in: 5 * time.Millisecond, want: lo},
+		{name: "just above maximum", in: hi + time.Nanosecond, want: hi},
+		{name: "far above maximum", in: 30 * 24 * time.Hour, want: hi},
+		{name: "zero", in: 0, wantErr: true},
+		{name: "negative", in: -time.Minute, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := clampRecordInterval(tt.in, lo, hi)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("clampRecordInterval(%v) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClampRecordInterval_InvalidRange(t *testing.T) {
+	for _, r := range [][2]time.Duration{{0, time.Hour}, {-time.Second, time.Hour}, {time.Hour, time.Minute}} {
+		if _, err := clampRecordInterval(time.Minute, r[0], r[1]); err == nil {
+			t.Errorf("expected error for range %v-%v", r[0], r[1])
+		}
+	}
+}
+
+func TestCheckPollInterval(t *testing.T) {
+	const record = 5 * time.Minute
+	for _, poll := range []time.Duration{0, 10 * time.Second, record} {
+		if err := checkPollInterval(poll, record); err != nil {
+			t.Errorf("poll %v: unexpected error %v", poll, err)
+		}
+	}
+	for _, poll := range []time.Duration{-time.Second, record + time.Second} {
+		if err := checkPollInterval(poll, record); err == nil {
+			t.Errorf("poll %v: expected an error", poll)
+		}
+	}
+}
+
+func TestMetricsMux_Pprof(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		srv := httptest.NewServer(newMetricsMux(prometheus.NewRegistry(), memory.NewReadingRepository(), nil, http.NotFoundHandler(), nil, enabled))
+		t.Cleanup(srv.Close)
+
+		for _, path := range []string{"/debug/pprof/", "/debug/pprof/goroutine?debug=1"} {
+			resp, err := http.Get(srv.URL + path)
+			if err != nil {
+				t.Fatalf("GET %s: %v", path, err)
+			}
+			resp.Body.Close()
+
+			want := http.StatusNotFound
+			if enabled {
+				want = http.StatusOK
+			}
+			if resp.StatusCode != want {
+				t.Errorf("pprof enabled=%v: GET %s returned %d, want %d", enabled, path, resp.StatusCode, want)
+			}
+		}
+
+		// The other routes are unaffected
+		resp, err := http.Get(srv.URL + "/metrics")
+		if err != nil {
+			t.Fatalf("GET /metrics: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("pprof enabled=%v: /metrics returned %d", enabled, resp.StatusCode)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package grafana
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// Metric names exposed to Grafana
+const (
+	MetricLux      = "lux"
+	MetricCategory = "category" // level under the category scheme, 0 the darkest (0 = low, 1 = medium, 2 = high by default)
+)
+
+// Handler implements the minimal Grafana SimpleJSON datasource contract
+// (GET /, POST /search, POST /query) on top of the reading repository
+type Handler struct {
+	repo   domain.ReadingRepository
+	scheme *domain.CategoryScheme
+	mux    *http.ServeMux
+}
+
+// Option configures a Handler
+type Option func(*Handler)
+
+// WithCategoryScheme charts the category metric as levels of scheme instead
+// of the default Low/Medium/High
+func WithCategoryScheme(scheme *domain.CategoryScheme) Option {
+	return func(h *Handler) { h.scheme = scheme }
+}
+
+// NewHandler creates a SimpleJSON handler backed by repo
+func NewHandler(repo domain.ReadingRepository, opts ...Option) *Handler {
+	h := &Handler{
+		repo:   repo,
+		scheme: domain.DefaultCategoryScheme,
+		mux:    http.NewServeMux(),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	h.mux.HandleFunc("GET /{$}", h.handleTest)
+	h.mux.HandleFunc("POST /search", h.handleSearch)
+	h.mux.HandleFunc("POST /query", h.handleQuery)
+	return h
+}
+
+// ServeHTTP dispatches to the SimpleJSON endpoints
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// handleTest answers Grafana's "Save & test" connectivity check
+func (h *Handler) handleTest(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleSearch lists the metrics that can be charted
+func (h *Handler) handleSearch(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, []string{MetricLux, MetricCategory})
+}
+
+// queryRequest is the subset of Grafana's /query body we use
+type queryRequest struct {
+	Range struct {
+		From time.Time `json:"from"`
+		To   time.Time `json:"to"`
+	} `json:"range"`
+	Targets []struct {
+		Target string `json:"target"`
+	} `json:"targets"`
+}
+
+// timeSeries is one SimpleJSON series; each datapoint is [value, epoch_ms]
+type timeSeries struct {
+	Target     string       `json:"target"`
+	Datapoints [][2]float64 `json:"datapoints"`
+}
+
+// handleQuery returns one time series per requested target, oldest first
+func (h *Handler) handleQuery(w http.ResponseWriter, r *http.Request) {
+	var req queryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid query body", http.StatusBadRequest)
+		return
+	}
+
+	for _, t := range req.Targets {
+		if t.Target != MetricLux && t.Target != MetricCategory {
+			http.Error(w, "unknown target "+t.Target, http.StatusBadRequest)
+			return
+		}
+	}
+
+	readings, err := h.repo.GetReadingsInRange(r.Context(), req.Range.From, req.Range.To)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to get readings for grafana query")
+		http.Error(w, "failed to get readings", http.StatusInternalServerError)
+		return
+	}
+
+	series := make([]timeSeries, 0, len(req.Targets))
+	for _, t := range req.Targets {
+		points := make([][2]float64, len(readings))
+		for i, reading := range readings {
+			value := reading.Lux
+			if t.Target == MetricCategory {
+				value = float64(h.scheme.Categorize(reading.Lux))
+			}
+			points[i] = [2]float64{value, float64(reading.Timestamp.UnixMilli())}
+		}
+		series = append(series, timeSeries{Target: t.Target, Datapoints: points})
+	}
+
+	writeJSON(w, series)
+}
+
+// writeJSON encodes v as the response body
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Error().Err(err).Msg("failed to encode response")
+	}
+}
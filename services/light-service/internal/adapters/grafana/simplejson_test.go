@@ -0,0 +1,148 @@
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+func TestHandler_Search(t *testing.T) {
+	h := NewHandler(memory.NewReadingRepository())
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/search", strings.NewReader(`{"target":""}`)))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var metrics []string
+	if err := json.Unmarshal(rec.Body.Bytes(), &metrics); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if len(metrics) != 2 || metrics[0] != MetricLux || metrics[1] != MetricCategory {
+		t.Errorf("unexpected metrics %v", metrics)
+	}
+}
+
+func TestHandler_Query(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	ctx := context.Background()
+
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	// Saved out of order; the response must be chronological
+	for _, r := range []struct {
+		offset time.Duration
+		lux    float64
+	}{
+		{2 * time.Minute, 3000},
+		{0, 100},
+		{time.Minute, 800},
+		{time.Hour, 50}, // outside the queried range
+	} {
+		reading, _ := domain.NewLightReading(r.lux)
+		reading.Timestamp = base.Add(r.offset)
+		_ = repo.SaveReading(ctx, reading)
+	}
+
+	body := fmt.Sprintf(`{
+		"range": {"from": %q, "to": %q},
+		"targets": [{"target": "lux", "refId": "A"}, {"target": "category", "refId": "B"}]
+	}`, base.Format(time.RFC3339), base.Add(10*time.Minute).Format(time.RFC3339))
+
+	rec := httptest.NewRecorder()
+	NewHandler(repo).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(body)))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var series []struct {
+		Target     string      `json:"target"`
+		Datapoints [][]float64 `json:"datapoints"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &series); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if len(series) != 2 {
+		t.Fatalf("expected 2 series, got %d", len(series))
+	}
+
+	wantTimes := []float64{
+		float64(base.UnixMilli()),
+		float64(base.Add(time.Minute).UnixMilli()),
+		float64(base.Add(2 * time.Minute).UnixMilli()),
+	}
+	wantValues := map[string][]float64{
+		"lux":      {100, 800, 3000},
+		"category": {float64(domain.CategoryLow), float64(domain.CategoryMedium), float64(domain.CategoryHigh)},
+	}
+
+	for _, s := range series {
+		want := wantValues[s.Target]
+		if len(s.Datapoints) != len(want) {
+			t.Fatalf("%s: expected %d datapoints, got %d", s.Target, len(want), len(s.Datapoints))
+		}
+		for i, dp := range s.Datapoints {
+			if len(dp) != 2 {
+				t.Fatalf("%s: datapoint %d should be [value, epoch_ms], got %v", s.Target, i, dp)
+			}
+			if dp[0] != want[i] || dp[1] != wantTimes[i] {
+				t.Errorf("%s: datapoint %d expected [%v, %v], got %v", s.Target, i, want[i], wantTimes[i], dp)
+			}
+		}
+	}
+}
+
+func TestHandler_QueryUnknownTarget(t *testing.T) {
+	body := `{"range": {"from": "2024-06-01T00:00:00Z", "to": "2024-06-02T00:00:00Z"}, "targets": [{"target": "humidity"}]}`
+
+	rec := httptest.NewRecorder()
+	NewHandler(memory.NewReadingRepository()).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(body)))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for unknown target, got %d", rec.Code)
+	}
+}
+
+func TestHandler_QueryCategoryUsesScheme(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	scheme, err := domain.NewCategoryScheme([]string{"Dark", "Dim", "Moderate", "Bright", "Direct sun"}, []float64{10, 100, 1000, 10000})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	for i, lux := range []float64{5, 500, 50000} {
+		reading, _ := domain.NewLightReadingAt(lux, base.Add(time.Duration(i)*time.Minute))
+		_ = repo.SaveReading(context.Background(), reading)
+	}
+
+	body := fmt.Sprintf(`{"range": {"from": %q, "to": %q}, "targets": [{"target": "category"}]}`,
+		base.Format(time.RFC3339), base.Add(10*time.Minute).Format(time.RFC3339))
+	rec := httptest.NewRecorder()
+	NewHandler(repo, WithCategoryScheme(scheme)).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(body)))
+
+	var series []struct {
+		Datapoints [][]float64 `json:"datapoints"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &series); err != nil || len(series) != 1 {
+		t.Fatalf("invalid response %s: %v", rec.Body.String(), err)
+	}
+	want := []float64{0, 2, 4}
+	if len(series[0].Datapoints) != len(want) {
+		t.Fatalf("expected %d datapoints, got %v", len(want), series[0].Datapoints)
+	}
+	for i, dp := range series[0].Datapoints {
+		if dp[0] != want[i] {
+			t.Errorf("datapoint %d: expected level %v, got %v", i, want[i], dp[0])
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/auth"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/middleware"
+)
+
+// unauthenticatedPeer labels calls without a verified client certificate in
+// metrics. Addresses aren't used there since every connection would add a
+// new series.
+const unauthenticatedPeer = "unauthenticated"
+
+// PeerIdentity is who made a call: the verified client certificate's subject
+// under mTLS, and the network address either way
+type PeerIdentity struct {
+	Addr       string
+	CommonName string   // empty without a verified client certificate
+	SANs       []string // DNS names, email addresses, IPs and URIs
+}
+
+// PeerIdentityFromContext extracts the caller's identity from a server
+// context. Only a certificate that passed verification is trusted; a
+// presented but unverified one is ignored.
+func PeerIdentityFromContext(ctx context.Context) PeerIdentity {
+	var id PeerIdentity
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return id
+	}
+	if p.Addr != nil {
+		id.Addr = p.Addr.String()
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return id
+	}
+
+	cert := tlsInfo.State.VerifiedChains[0][0]
+	id.CommonName = cert.Subject.CommonName
+	id.SANs = append(id.SANs, cert.DNSNames...)
+	id.SANs = append(id.SANs, cert.EmailAddresses...)
+	for _, ip := range cert.IPAddresses {
+		id.SANs = append(id.SANs, ip.String())
+	}
+	for _, uri := range cert.URIs {
+		id.SANs = append(id.SANs, uri.String())
+	}
+	return id
+}
+
+// AccessLogger logs one line per RPC with the caller's identity, for
+// auditing which client made each call
+type AccessLogger struct {
+	logger zerolog.Logger
+	calls  *prometheus.CounterVec // nil unless WithPeerMetrics
+}
+
+// AccessLogOption configures an AccessLogger
+type AccessLogOption func(*AccessLogger)
+
+// WithPeerMetrics also counts calls by method, peer common name and status
+// code in reg
+func WithPeerMetrics(reg prometheus.Registerer) AccessLogOption {
+	return func(a *AccessLogger) {
+		a.calls = prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "light_grpc_requests_total",
+			Help: "gRPC calls handled, by method, client certificate common name and status code.",
+		}, []string{"method", "peer", "code"})
+		reg.MustRegister(a.calls)
+	}
+}
+
+// NewAccessLogger creates an access logger writing to logger
+func NewAccessLogger(logger zerolog.Logger, opts ...AccessLogOption) *AccessLogger {
+	a := &AccessLogger{logger: logger}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// UnaryInterceptor logs each unary RPC once the handler returns
+func (a *AccessLogger) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		a.record(ctx, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamInterceptor logs each streaming RPC once the stream ends
+func (a *AccessLogger) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		a.record(ss.Context(), info.FullMethod, start, err)
+		return err
+	}
+}
+
+func (a *AccessLogger) record(ctx context.Context, method string, start time.Time, err error) {
+	id := PeerIdentityFromContext(ctx)
+	code := status.Code(err)
+
+	event := a.logger.Info().
+		Str("method", method).
+		Str("code", code.String()).
+		Dur("duration", time.Since(start)).
+		Str("peer_addr", id.Addr)
+	if requestID := middleware.RequestIDFromContext(ctx); requestID != "" {
+		event = event.Str("request_id", requestID)
+	}
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		event = event.Str("principal", principal.Name)
+	}
+	if id.CommonName != "" {
+		event = event.Str("peer_cn", id.CommonName).Strs("peer_sans", id.SANs)
+	}
+	event.Msg("rpc")
+
+	if a.calls != nil {
+		peerLabel := id.CommonName
+		if peerLabel == "" {
+			peerLabel = unauthenticatedPeer
+		}
+		a.calls.WithLabelValues(method, peerLabel, code.String()).Inc()
+	}
+}
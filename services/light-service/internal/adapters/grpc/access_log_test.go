@@ -0,0 +1,198 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/mock"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+// testCA issues short-lived certificates for an in-process mTLS server
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pool *x509.CertPool
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate CA key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create CA cert: %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return &testCA{cert: cert, key: key, pool: pool}
+}
+
+func (ca *testCA) issue(t *testing.T, serial int64, tmpl *x509.Certificate) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	tmpl.SerialNumber = big.NewInt(serial)
+	tmpl.NotBefore = time.Now().Add(-time.Hour)
+	tmpl.NotAfter = time.Now().Add(time.Hour)
+	tmpl.KeyUsage = x509.KeyUsageDigitalSignature
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("create cert: %v", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// serveWithAccessLog starts a server with an access logger writing JSON
+// lines to the returned buffer
+func serveWithAccessLog(t *testing.T, creds credentials.TransportCredentials, reg prometheus.Registerer) (string, *bytes.Buffer) {
+	t.Helper()
+
+	var logs bytes.Buffer
+	accessLog := NewAccessLogger(zerolog.New(&logs), WithPeerMetrics(reg))
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	srv := grpc.NewServer(
+		grpc.Creds(creds),
+		grpc.ChainUnaryInterceptor(accessLog.UnaryInterceptor()),
+	)
+	pb.RegisterLightServiceServer(srv, NewLightServiceHandler(memory.NewReadingRepository(), mock.NewFakeSensor(500.0, 0)))
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	return lis.Addr().String(), &logs
+}
+
+func callOnce(t *testing.T, addr string, creds credentials.TransportCredentials) {
+	t.Helper()
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := pb.NewLightServiceClient(conn).GetCurrentLight(context.Background(), &pb.GetCurrentLightRequest{}); err != nil {
+		t.Fatalf("GetCurrentLight failed: %v", err)
+	}
+}
+
+func decodeAccessLine(t *testing.T, logs *bytes.Buffer) map[string]any {
+	t.Helper()
+	var line map[string]any
+	if err := json.Unmarshal(logs.Bytes(), &line); err != nil {
+		t.Fatalf("expected one JSON log line, got %q: %v", logs.String(), err)
+	}
+	return line
+}
+
+func peerCallCount(t *testing.T, reg *prometheus.Registry, peerLabel string) float64 {
+	t.Helper()
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather failed: %v", err)
+	}
+	var total float64
+	for _, f := range families {
+		for _, m := range f.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "peer" && l.GetValue() == peerLabel {
+					total += m.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return total
+}
+
+func TestAccessLogger_MTLSPeerIdentity(t *testing.T) {
+	ca := newTestCA(t)
+	serverCert := ca.issue(t, 2, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "light-service"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	clientCert := ca.issue(t, 3, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "plant-service"},
+		DNSNames:    []string{"plant-service.local"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+
+	reg := prometheus.NewRegistry()
+	addr, logs := serveWithAccessLog(t, credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    ca.pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}), reg)
+
+	callOnce(t, addr, credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{clientCert},
+		RootCAs:      ca.pool,
+	}))
+
+	line := decodeAccessLine(t, logs)
+	if line["peer_cn"] != "plant-service" {
+		t.Errorf("expected peer_cn plant-service, got %v", line["peer_cn"])
+	}
+	if sans, _ := line["peer_sans"].([]any); len(sans) != 1 || sans[0] != "plant-service.local" {
+		t.Errorf("expected peer_sans [plant-service.local], got %v", line["peer_sans"])
+	}
+	if line["method"] != pb.LightService_GetCurrentLight_FullMethodName || line["code"] != "OK" {
+		t.Errorf("unexpected method/code: %v %v", line["method"], line["code"])
+	}
+	if got := peerCallCount(t, reg, "plant-service"); got != 1 {
+		t.Errorf("expected 1 call counted for plant-service, got %v", got)
+	}
+}
+
+func TestAccessLogger_PlaintextLogsAddress(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	addr, logs := serveWithAccessLog(t, insecure.NewCredentials(), reg)
+
+	callOnce(t, addr, insecure.NewCredentials())
+
+	line := decodeAccessLine(t, logs)
+	if _, ok := line["peer_cn"]; ok {
+		t.Errorf("expected no peer_cn without TLS, got %v", line["peer_cn"])
+	}
+	if host, _, err := net.SplitHostPort(line["peer_addr"].(string)); err != nil || host != "127.0.0.1" {
+		t.Errorf("expected a loopback peer_addr, got %v", line["peer_addr"])
+	}
+	if got := peerCallCount(t, reg, unauthenticatedPeer); got != 1 {
+		t.Errorf("expected 1 unauthenticated call counted, got %v", got)
+	}
+}
@@ -0,0 +1,160 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+// errAlertsDisabled is returned by the alert RPCs without WithAlerts
+var errAlertsDisabled = status.Error(codes.FailedPrecondition, "alerting is not enabled")
+
+// CreateAlertRule validates and stores a new alert rule
+func (h *LightServiceHandler) CreateAlertRule(ctx context.Context, req *pb.CreateAlertRuleRequest) (*pb.CreateAlertRuleResponse, error) {
+	zerolog.Ctx(ctx).Info().Str("name", req.GetRule().GetName()).Msg("CreateAlertRule called")
+
+	if h.alerts == nil {
+		return nil, errAlertsDisabled
+	}
+	if req.GetRule().GetForMs() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "for_ms cannot be negative")
+	}
+
+	rule := &domain.AlertRule{
+		Name:         req.GetRule().GetName(),
+		Condition:    convertAlertConditionFromProto(req.GetRule().GetCondition()),
+		ThresholdLux: req.GetRule().GetThresholdLux(),
+		For:          time.Duration(req.GetRule().GetForMs()) * time.Millisecond,
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := h.alerts.CreateRule(ctx, rule); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to create alert rule")
+		return nil, status.Error(codes.Internal, "failed to create alert rule")
+	}
+
+	return &pb.CreateAlertRuleResponse{Rule: convertAlertRuleToProto(rule)}, nil
+}
+
+// ListAlertRules returns every alert rule, oldest first
+func (h *LightServiceHandler) ListAlertRules(ctx context.Context, req *pb.ListAlertRulesRequest) (*pb.ListAlertRulesResponse, error) {
+	zerolog.Ctx(ctx).Info().Msg("ListAlertRules called")
+
+	if h.alerts == nil {
+		return nil, errAlertsDisabled
+	}
+
+	rules, err := h.alerts.ListRules(ctx)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to list alert rules")
+		return nil, status.Error(codes.Internal, "failed to list alert rules")
+	}
+
+	resp := &pb.ListAlertRulesResponse{Rules: make([]*pb.AlertRule, len(rules))}
+	for i, rule := range rules {
+		resp.Rules[i] = convertAlertRuleToProto(rule)
+	}
+	return resp, nil
+}
+
+// DeleteAlertRule removes an alert rule
+func (h *LightServiceHandler) DeleteAlertRule(ctx context.Context, req *pb.DeleteAlertRuleRequest) (*pb.DeleteAlertRuleResponse, error) {
+	zerolog.Ctx(ctx).Info().Int64("id", req.Id).Msg("DeleteAlertRule called")
+
+	if h.alerts == nil {
+		return nil, errAlertsDisabled
+	}
+
+	err := h.alerts.DeleteRule(ctx, req.Id)
+	if errors.Is(err, domain.ErrAlertRuleNotFound) {
+		return nil, status.Error(codes.NotFound, "alert rule not found")
+	}
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to delete alert rule")
+		return nil, status.Error(codes.Internal, "failed to delete alert rule")
+	}
+	return &pb.DeleteAlertRuleResponse{}, nil
+}
+
+// GetAlerts returns the alerts fired in a time range
+func (h *LightServiceHandler) GetAlerts(ctx context.Context, req *pb.GetAlertsRequest) (*pb.GetAlertsResponse, error) {
+	zerolog.Ctx(ctx).Info().
+		Int64("start_ms", req.StartTimeMs).
+		Int64("end_ms", req.EndTimeMs).
+		Msg("GetAlerts called")
+
+	if h.alerts == nil {
+		return nil, errAlertsDisabled
+	}
+
+	start, end := time.UnixMilli(req.StartTimeMs), time.UnixMilli(req.EndTimeMs)
+	if !end.After(start) {
+		return nil, status.Error(codes.InvalidArgument, "end_time_ms must be after start_time_ms")
+	}
+
+	alerts, err := h.alerts.GetAlerts(ctx, start, end)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to get alerts")
+		return nil, status.Error(codes.Internal, "failed to get alerts")
+	}
+
+	resp := &pb.GetAlertsResponse{Alerts: make([]*pb.Alert, len(alerts))}
+	for i, a := range alerts {
+		resp.Alerts[i] = &pb.Alert{
+			Id:           a.ID,
+			RuleId:       a.RuleID,
+			RuleName:     a.RuleName,
+			Condition:    convertAlertConditionToProto(a.Condition),
+			ThresholdLux: a.ThresholdLux,
+			Lux:          a.Lux,
+			SinceMs:      a.Since.UnixMilli(),
+			FiredAtMs:    a.FiredAt.UnixMilli(),
+			Message:      a.Message(),
+		}
+	}
+	return resp, nil
+}
+
+func convertAlertRuleToProto(r *domain.AlertRule) *pb.AlertRule {
+	return &pb.AlertRule{
+		Id:           r.ID,
+		Name:         r.Name,
+		Condition:    convertAlertConditionToProto(r.Condition),
+		ThresholdLux: r.ThresholdLux,
+		ForMs:        r.For.Milliseconds(),
+		CreatedAtMs:  r.CreatedAt.UnixMilli(),
+		Description:  r.String(),
+	}
+}
+
+func convertAlertConditionToProto(c domain.AlertCondition) pb.AlertCondition {
+	switch c {
+	case domain.AlertBelow:
+		return pb.AlertCondition_ALERT_CONDITION_BELOW
+	case domain.AlertAbove:
+		return pb.AlertCondition_ALERT_CONDITION_ABOVE
+	default:
+		return pb.AlertCondition_ALERT_CONDITION_UNSPECIFIED
+	}
+}
+
+// convertAlertConditionFromProto maps UNSPECIFIED to an empty condition,
+// which validation rejects
+func convertAlertConditionFromProto(c pb.AlertCondition) domain.AlertCondition {
+	switch c {
+	case pb.AlertCondition_ALERT_CONDITION_BELOW:
+		return domain.AlertBelow
+	case pb.AlertCondition_ALERT_CONDITION_ABOVE:
+		return domain.AlertAbove
+	default:
+		return ""
+	}
+}
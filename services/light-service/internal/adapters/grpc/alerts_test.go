@@ -0,0 +1,115 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+func TestAlertRules_CreateListDelete(t *testing.T) {
+	alerts := memory.NewAlertRepository()
+	client := startTestServerWithRepo(t, memory.NewReadingRepository(), WithAlerts(alerts))
+	ctx := context.Background()
+
+	created, err := client.CreateAlertRule(ctx, &pb.CreateAlertRuleRequest{Rule: &pb.AlertRule{
+		Name:         " too dark ",
+		Condition:    pb.AlertCondition_ALERT_CONDITION_BELOW,
+		ThresholdLux: 150,
+		ForMs:        (2 * time.Hour).Milliseconds(),
+	}})
+	if err != nil {
+		t.Fatalf("CreateAlertRule failed: %v", err)
+	}
+	rule := created.Rule
+	if rule.Id == 0 || rule.Name != "too dark" || rule.CreatedAtMs == 0 || rule.Description != "lux < 150 for 2h0m0s" {
+		t.Errorf("unexpected rule %v", rule)
+	}
+
+	list, err := client.ListAlertRules(ctx, &pb.ListAlertRulesRequest{})
+	if err != nil {
+		t.Fatalf("ListAlertRules failed: %v", err)
+	}
+	if len(list.Rules) != 1 || list.Rules[0].Id != rule.Id {
+		t.Errorf("expected the created rule, got %v", list.Rules)
+	}
+
+	if _, err := client.DeleteAlertRule(ctx, &pb.DeleteAlertRuleRequest{Id: rule.Id}); err != nil {
+		t.Fatalf("DeleteAlertRule failed: %v", err)
+	}
+	_, err = client.DeleteAlertRule(ctx, &pb.DeleteAlertRuleRequest{Id: rule.Id})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound deleting twice, got %v", err)
+	}
+}
+
+func TestCreateAlertRule_Invalid(t *testing.T) {
+	client := startTestServerWithRepo(t, memory.NewReadingRepository(), WithAlerts(memory.NewAlertRepository()))
+	ctx := context.Background()
+
+	for name, rule := range map[string]*pb.AlertRule{
+		"no name":      {Condition: pb.AlertCondition_ALERT_CONDITION_BELOW, ThresholdLux: 150},
+		"no condition": {Name: "x", ThresholdLux: 150},
+		"negative lux": {Name: "x", Condition: pb.AlertCondition_ALERT_CONDITION_ABOVE, ThresholdLux: -1},
+		"negative for": {Name: "x", Condition: pb.AlertCondition_ALERT_CONDITION_ABOVE, ForMs: -1},
+		"no rule":      nil,
+	} {
+		_, err := client.CreateAlertRule(ctx, &pb.CreateAlertRuleRequest{Rule: rule})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: expected InvalidArgument, got %v", name, err)
+		}
+	}
+}
+
+func TestGetAlerts(t *testing.T) {
+	alerts := memory.NewAlertRepository()
+	client := startTestServerWithRepo(t, memory.NewReadingRepository(), WithAlerts(alerts))
+	ctx := context.Background()
+
+	fired := time.Date(2024, 6, 1, 20, 0, 0, 0, time.UTC)
+	_ = alerts.SaveAlert(ctx, &domain.Alert{
+		RuleID:       1,
+		RuleName:     "too dark",
+		Condition:    domain.AlertBelow,
+		ThresholdLux: 150,
+		Lux:          80,
+		Since:        fired.Add(-2 * time.Hour),
+		FiredAt:      fired,
+	})
+
+	resp, err := client.GetAlerts(ctx, &pb.GetAlertsRequest{
+		StartTimeMs: fired.Add(-time.Hour).UnixMilli(),
+		EndTimeMs:   fired.Add(time.Hour).UnixMilli(),
+	})
+	if err != nil {
+		t.Fatalf("GetAlerts failed: %v", err)
+	}
+	if len(resp.Alerts) != 1 {
+		t.Fatalf("expected 1 alert, got %d", len(resp.Alerts))
+	}
+	a := resp.Alerts[0]
+	if a.Condition != pb.AlertCondition_ALERT_CONDITION_BELOW || a.FiredAtMs != fired.UnixMilli() || a.Message == "" {
+		t.Errorf("unexpected alert %v", a)
+	}
+
+	// The range is half-open
+	resp, _ = client.GetAlerts(ctx, &pb.GetAlertsRequest{StartTimeMs: 0, EndTimeMs: fired.UnixMilli()})
+	if len(resp.GetAlerts()) != 0 {
+		t.Errorf("expected no alerts before %v, got %d", fired, len(resp.GetAlerts()))
+	}
+}
+
+func TestAlertRPCs_Disabled(t *testing.T) {
+	client := startTestServer(t)
+
+	_, err := client.ListAlertRules(context.Background(), &pb.ListAlertRulesRequest{})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition without alerting, got %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/auth"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/middleware"
+)
+
+// authorizationKey is the metadata key carrying "Bearer <API key>"
+const authorizationKey = "authorization"
+
+// Authenticator rejects calls without an API key whose role allows the
+// method, per auth.RequiredRole, and passes the caller's auth.Principal on
+// to the handler
+type Authenticator struct {
+	keys   *auth.KeyStore
+	logger zerolog.Logger
+}
+
+// NewAuthenticator creates an authenticator checking keys, logging refused
+// calls to logger
+func NewAuthenticator(keys *auth.KeyStore, logger zerolog.Logger) *Authenticator {
+	return &Authenticator{keys: keys, logger: logger}
+}
+
+// UnaryInterceptor authorizes each unary RPC before the handler runs
+func (a *Authenticator) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := a.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor authorizes each streaming RPC before the handler runs
+func (a *Authenticator) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authorize(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authorize returns ctx carrying the caller's principal, or Unauthenticated
+// for a missing or unknown key and PermissionDenied for one whose role is
+// too low
+func (a *Authenticator) authorize(ctx context.Context, method string) (context.Context, error) {
+	required := auth.RequiredRole(method)
+	if required == auth.RoleNone {
+		return ctx, nil
+	}
+
+	principal, err := a.keys.Authenticate(bearerToken(ctx))
+	if err != nil {
+		a.refused(ctx, method, "").Msg("rejected call without a valid API key")
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid API key")
+	}
+	if !principal.Role.Allows(required) {
+		a.refused(ctx, method, principal.Name).Str("role", principal.Role.String()).Msg("rejected call beyond the key's role")
+		return nil, status.Errorf(codes.PermissionDenied, "%s requires a %s key", method, required)
+	}
+	return auth.ContextWithPrincipal(ctx, principal), nil
+}
+
+// refused starts a log line about a call authorize turned away
+func (a *Authenticator) refused(ctx context.Context, method, principal string) *zerolog.Event {
+	event := a.logger.Warn().
+		Str("method", method).
+		Str("peer_addr", PeerIdentityFromContext(ctx).Addr)
+	if requestID := middleware.RequestIDFromContext(ctx); requestID != "" {
+		event = event.Str("request_id", requestID)
+	}
+	if principal != "" {
+		event = event.Str("principal", principal)
+	}
+	return event
+}
+
+// bearerToken returns the API key from the incoming "authorization: Bearer"
+// metadata, or "" if there is none
+func bearerToken(ctx context.Context) string {
+	for _, v := range metadata.ValueFromIncomingContext(ctx, authorizationKey) {
+		scheme, token, ok := strings.Cut(v, " ")
+		if ok && strings.EqualFold(scheme, "bearer") {
+			return strings.TrimSpace(token)
+		}
+	}
+	return ""
+}
+
+// authStream hands the stream handler the context carrying the principal
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authStream) Context() context.Context { return s.ctx }
@@ -0,0 +1,144 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/mock"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/auth"
+	lightclient "github.com/quentinrf/plant-monitor/services/light-service/pkg/client"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+// serveWithAuth starts a server requiring keys for a reader "guest" and a
+// writer "recorder", ahead of an access logger writing to the returned buffer
+func serveWithAuth(t *testing.T) (string, *bytes.Buffer) {
+	t.Helper()
+
+	keys := auth.NewKeyStore()
+	keys.Add("guest", auth.RoleReader, "read-key")
+	keys.Add("recorder", auth.RoleWriter, "write-key")
+
+	var logs bytes.Buffer
+	authenticator := NewAuthenticator(keys, zerolog.New(&logs))
+	accessLog := NewAccessLogger(zerolog.New(&logs))
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(authenticator.UnaryInterceptor(), accessLog.UnaryInterceptor()),
+		grpc.ChainStreamInterceptor(authenticator.StreamInterceptor(), accessLog.StreamInterceptor()),
+	)
+	pb.RegisterLightServiceServer(srv, NewLightServiceHandler(memory.NewReadingRepository(), mock.NewFakeSensor(500.0, 0)))
+	RegisterHealth(srv)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	return lis.Addr().String(), &logs
+}
+
+// dialWithKey connects with key as the bearer token, if set
+func dialWithKey(t *testing.T, addr, key string) *grpc.ClientConn {
+	t.Helper()
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if key != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(lightclient.TokenCredentials(key)))
+	}
+	conn, err := grpc.NewClient(addr, opts...)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestAuthenticator_Roles(t *testing.T) {
+	addr, _ := serveWithAuth(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		key       string
+		readCode  codes.Code
+		writeCode codes.Code
+	}{
+		{"no key", "", codes.Unauthenticated, codes.Unauthenticated},
+		{"unknown key", "guess", codes.Unauthenticated, codes.Unauthenticated},
+		{"reader", "read-key", codes.OK, codes.PermissionDenied},
+		{"writer", "write-key", codes.OK, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := pb.NewLightServiceClient(dialWithKey(t, addr, tt.key))
+
+			_, err := client.RecordReading(ctx, &pb.RecordReadingRequest{Lux: 300})
+			if code := status.Code(err); code != tt.writeCode {
+				t.Errorf("RecordReading: got %v, want %v", code, tt.writeCode)
+			}
+			_, err = client.GetHistory(ctx, &pb.GetHistoryRequest{EndTimeMs: 1})
+			if code := status.Code(err); code != tt.readCode {
+				t.Errorf("GetHistory: got %v, want %v", code, tt.readCode)
+			}
+		})
+	}
+}
+
+func TestAuthenticator_Stream(t *testing.T) {
+	addr, _ := serveWithAuth(t)
+
+	for key, want := range map[string]codes.Code{
+		"":         codes.Unauthenticated,
+		"read-key": codes.FailedPrecondition, // authorized, but no reading stream is configured
+	} {
+		stream, err := pb.NewLightServiceClient(dialWithKey(t, addr, key)).StreamReadings(context.Background(), &pb.StreamReadingsRequest{})
+		if err == nil {
+			_, err = stream.Recv()
+		}
+		if code := status.Code(err); code != want {
+			t.Errorf("key %q: got %v, want %v", key, code, want)
+		}
+	}
+}
+
+func TestAuthenticator_HealthIsPublic(t *testing.T) {
+	addr, _ := serveWithAuth(t)
+
+	resp, err := healthpb.NewHealthClient(dialWithKey(t, addr, "")).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("health check without a key failed: %v", err)
+	}
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("expected SERVING, got %v", resp.Status)
+	}
+}
+
+func TestAuthenticator_LogsPrincipal(t *testing.T) {
+	addr, logs := serveWithAuth(t)
+	client := pb.NewLightServiceClient(dialWithKey(t, addr, "read-key"))
+
+	client.RecordReading(context.Background(), &pb.RecordReadingRequest{Lux: 300})
+	if !strings.Contains(logs.String(), `"principal":"guest"`) || !strings.Contains(logs.String(), "beyond the key's role") {
+		t.Errorf("expected the refusal logged with the principal, got %q", logs.String())
+	}
+
+	logs.Reset()
+	if _, err := client.GetCurrentLight(context.Background(), &pb.GetCurrentLightRequest{}); err != nil {
+		t.Fatalf("GetCurrentLight failed: %v", err)
+	}
+	if line := decodeAccessLine(t, logs); line["principal"] != "guest" {
+		t.Errorf("expected the access log to name the principal, got %v", line["principal"])
+	}
+}
@@ -0,0 +1,143 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	pb "github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+// Export batch sizes. Each batch is one page from the repository, so memory
+// use is bounded by the batch size however large the store is.
+const (
+	defaultExportBatchSize = 500
+	maxExportBatchSize     = 5000
+)
+
+// ExportReadings streams the whole store in timestamp order, one repository
+// page per message
+func (h *LightServiceHandler) ExportReadings(req *pb.ExportReadingsRequest, stream pb.LightService_ExportReadingsServer) error {
+	ctx := stream.Context()
+	zerolog.Ctx(ctx).Info().Int32("batch_size", req.BatchSize).Msg("ExportReadings called")
+
+	batchSize := int(req.BatchSize)
+	switch {
+	case batchSize < 0:
+		return status.Error(codes.InvalidArgument, "batch_size cannot be negative")
+	case batchSize == 0:
+		batchSize = defaultExportBatchSize
+	case batchSize > maxExportBatchSize:
+		batchSize = maxExportBatchSize
+	}
+
+	var cursor domain.ReadingCursor
+	var exported int
+	for {
+		page, err := h.repo.ListReadings(ctx, cursor, batchSize)
+		if err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Msg("failed to list readings for export")
+			return status.Error(codes.Internal, "failed to list readings")
+		}
+		if len(page) == 0 {
+			break
+		}
+
+		batch := &pb.ReadingBatch{Readings: make([]*pb.LightReading, len(page))}
+		for i, r := range page {
+			batch.Readings[i] = h.convertReadingToProto(r)
+		}
+		if err := stream.Send(batch); err != nil {
+			return err
+		}
+
+		exported += len(page)
+		cursor = domain.CursorAfter(page[len(page)-1])
+	}
+
+	zerolog.Ctx(ctx).Info().Int("exported", exported).Msg("export completed")
+	return nil
+}
+
+// ImportReadings upserts each received batch in its own transaction, so a
+// restore never holds more than one batch in memory. Batches committed
+// before a failure stay committed; re-running the import is safe.
+func (h *LightServiceHandler) ImportReadings(stream pb.LightService_ImportReadingsServer) error {
+	ctx := stream.Context()
+	zerolog.Ctx(ctx).Info().Msg("ImportReadings called")
+
+	var imported int64
+	for {
+		batch, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		readings, err := readingsFromBackup(batch.Readings)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "batch after %d imported readings: %v", imported, err)
+		}
+		if err := h.saveImported(ctx, readings); err != nil {
+			return err
+		}
+		imported += int64(len(readings))
+	}
+
+	zerolog.Ctx(ctx).Info().Int64("imported", imported).Msg("import completed")
+	return stream.SendAndClose(&pb.ImportReadingsResponse{ImportedCount: imported})
+}
+
+// saveImported upserts one batch, mapping failures to a gRPC status
+func (h *LightServiceHandler) saveImported(ctx context.Context, readings []*domain.LightReading) error {
+	if len(readings) == 0 {
+		return nil
+	}
+	if err := h.repo.UpsertReadings(ctx, readings); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to save imported readings")
+		return writeError(err, "failed to save readings")
+	}
+	return nil
+}
+
+// readingsFromBackup converts exported readings back to domain readings,
+// keeping their original timestamp, source, temperature and quality
+func readingsFromBackup(pbReadings []*pb.LightReading) ([]*domain.LightReading, error) {
+	readings := make([]*domain.LightReading, len(pbReadings))
+	for i, p := range pbReadings {
+		at := timeFromProto(p.Timestamp, p.TimestampMs)
+		if at.Equal(time.Unix(0, 0)) {
+			return nil, fmt.Errorf("reading %d: timestamp is required", i)
+		}
+
+		reading, err := domain.NewLightReadingAt(p.Lux, at)
+		if err != nil {
+			return nil, fmt.Errorf("reading %d: %w", i, err)
+		}
+
+		if source := convertSourceFromProto(p.Source); source != "" {
+			reading.Source = source
+		} else {
+			reading.Source = domain.SourceImport
+		}
+
+		reading.Quality = convertQualityFromProto(p.Quality)
+
+		if p.TemperatureCelsius != nil {
+			if err := reading.SetTemperature(*p.TemperatureCelsius); err != nil {
+				return nil, fmt.Errorf("reading %d: %w", i, err)
+			}
+		}
+		readings[i] = reading
+	}
+	return readings, nil
+}
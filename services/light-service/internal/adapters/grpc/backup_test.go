@@ -0,0 +1,95 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	pb "github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+func TestExportImport_RoundTrip(t *testing.T) {
+	source := memory.NewReadingRepository()
+	target := memory.NewReadingRepository()
+	sourceClient := startTestServerWithRepo(t, source)
+	targetClient := startTestServerWithRepo(t, target)
+	ctx := context.Background()
+
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	sources := []domain.Source{domain.SourceSensor, domain.SourceManual, domain.SourceImport}
+	for i := 0; i < 10; i++ {
+		reading, _ := domain.NewLightReading(float64(100*i) + 0.5)
+		reading.Timestamp = base.Add(time.Duration(i) * time.Minute)
+		reading.Source = sources[i%len(sources)]
+		if i%2 == 0 {
+			_ = reading.SetTemperature(20 + float64(i))
+		}
+		_ = source.SaveReading(ctx, reading)
+	}
+
+	// A batch size that doesn't divide the store exercises a partial last page
+	export, err := sourceClient.ExportReadings(ctx, &pb.ExportReadingsRequest{BatchSize: 3})
+	if err != nil {
+		t.Fatalf("ExportReadings failed: %v", err)
+	}
+	restore, err := targetClient.ImportReadings(ctx)
+	if err != nil {
+		t.Fatalf("ImportReadings failed: %v", err)
+	}
+
+	batches := 0
+	for {
+		batch, err := export.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("export Recv failed: %v", err)
+		}
+		batches++
+		if err := restore.Send(batch); err != nil {
+			t.Fatalf("import Send failed: %v", err)
+		}
+	}
+	resp, err := restore.CloseAndRecv()
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+
+	if batches != 4 {
+		t.Errorf("expected 4 batches of at most 3, got %d", batches)
+	}
+	if resp.ImportedCount != 10 {
+		t.Errorf("expected 10 imported, got %d", resp.ImportedCount)
+	}
+
+	want, _ := source.GetReadingsInRange(ctx, base, base.Add(time.Hour))
+	got, _ := target.GetReadingsInRange(ctx, base, base.Add(time.Hour))
+	if len(got) != len(want) {
+		t.Fatalf("expected %d restored readings, got %d", len(want), len(got))
+	}
+	for i := range want {
+		w, g := want[i], got[i]
+		if !g.Timestamp.Equal(w.Timestamp) || g.Lux != w.Lux || g.Source != w.Source ||
+			(g.TemperatureC == nil) != (w.TemperatureC == nil) ||
+			(g.TemperatureC != nil && *g.TemperatureC != *w.TemperatureC) {
+			t.Errorf("reading %d: expected %+v, got %+v", i, w, g)
+		}
+	}
+}
+
+func TestExportReadings_EmptyStore(t *testing.T) {
+	client := startTestServer(t)
+
+	export, err := client.ExportReadings(context.Background(), &pb.ExportReadingsRequest{})
+	if err != nil {
+		t.Fatalf("ExportReadings failed: %v", err)
+	}
+	if _, err := export.Recv(); !errors.Is(err, io.EOF) {
+		t.Errorf("expected an empty stream, got %v", err)
+	}
+}
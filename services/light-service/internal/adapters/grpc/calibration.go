@@ -0,0 +1,84 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+// CalibrateSensor stores a sensor's calibration, applying it to the live
+// sensor when the IDs match
+func (h *LightServiceHandler) CalibrateSensor(ctx context.Context, req *pb.CalibrateSensorRequest) (*pb.CalibrateSensorResponse, error) {
+	zerolog.Ctx(ctx).Info().Str("sensor_id", req.GetSensorId()).Msg("CalibrateSensor called")
+
+	if h.calibrations == nil || h.calibrated == nil {
+		return nil, status.Error(codes.FailedPrecondition, "calibration is not enabled")
+	}
+
+	live := h.calibrated.Calibration().SensorID
+	cal := &domain.Calibration{
+		SensorID:  req.GetSensorId(),
+		Scale:     1,
+		OffsetLux: req.GetOffsetLux(),
+	}
+	if cal.SensorID == "" {
+		cal.SensorID = live
+	}
+	if req.Scale != nil {
+		cal.Scale = req.GetScale()
+	}
+	if err := cal.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	previous, err := h.calibrations.GetCalibration(ctx, cal.SensorID)
+	if errors.Is(err, domain.ErrCalibrationNotFound) {
+		identity := domain.IdentityCalibration(cal.SensorID)
+		previous, err = &identity, nil
+	}
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to get calibration")
+		return nil, status.Error(codes.Internal, "failed to get calibration")
+	}
+
+	if err := h.calibrations.SaveCalibration(ctx, cal); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to save calibration")
+		return nil, writeError(err, "failed to save calibration")
+	}
+
+	active := cal.SensorID == live
+	if active {
+		h.calibrated.SetCalibration(*cal)
+	}
+	zerolog.Ctx(ctx).Info().
+		Str("sensor_id", cal.SensorID).
+		Float64("scale", cal.Scale).
+		Float64("offset_lux", cal.OffsetLux).
+		Bool("active", active).
+		Msg("sensor calibrated")
+
+	return &pb.CalibrateSensorResponse{
+		Calibration: convertCalibrationToProto(cal),
+		Previous:    convertCalibrationToProto(previous),
+		Active:      active,
+	}, nil
+}
+
+// convertCalibrationToProto converts a domain calibration to protobuf
+func convertCalibrationToProto(cal *domain.Calibration) *pb.Calibration {
+	pbCal := &pb.Calibration{
+		SensorId:  cal.SensorID,
+		Scale:     cal.Scale,
+		OffsetLux: cal.OffsetLux,
+	}
+	if !cal.UpdatedAt.IsZero() {
+		pbCal.UpdatedAtMs = cal.UpdatedAt.UnixMilli()
+	}
+	return pbCal
+}
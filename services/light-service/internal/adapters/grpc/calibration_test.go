@@ -0,0 +1,115 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/mock"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/readonly"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/ports"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+// startCalibratedServer serves a steady 500 lux sensor calibrated as "mock"
+func startCalibratedServer(t *testing.T, calibrations domain.CalibrationRepository) pb.LightServiceClient {
+	t.Helper()
+	sensor := ports.NewCalibratedSensor(mock.NewFakeSensor(500, 0), domain.IdentityCalibration("mock"))
+	return startTestServerWithSensor(t, memory.NewReadingRepository(), sensor, WithCalibration(calibrations, sensor))
+}
+
+func TestCalibrateSensor_AppliesToLiveSensor(t *testing.T) {
+	client := startCalibratedServer(t, memory.NewCalibrationRepository())
+	ctx := context.Background()
+
+	resp, err := client.CalibrateSensor(ctx, &pb.CalibrateSensorRequest{Scale: proto.Float64(1.1), OffsetLux: 20})
+	if err != nil {
+		t.Fatalf("CalibrateSensor failed: %v", err)
+	}
+	if cal := resp.Calibration; cal.SensorId != "mock" || cal.Scale != 1.1 || cal.OffsetLux != 20 || cal.UpdatedAtMs == 0 {
+		t.Errorf("unexpected calibration %v", cal)
+	}
+	if prev := resp.Previous; prev.Scale != 1 || prev.OffsetLux != 0 || prev.UpdatedAtMs != 0 {
+		t.Errorf("expected the identity as previous, got %v", prev)
+	}
+	if !resp.Active {
+		t.Error("expected the calibration to be active")
+	}
+
+	// No readings are stored, so this is a live read
+	current, err := client.GetCurrentLight(ctx, &pb.GetCurrentLightRequest{})
+	if err != nil {
+		t.Fatalf("GetCurrentLight failed: %v", err)
+	}
+	if current.Reading.Lux != 570 {
+		t.Errorf("expected the calibrated 570 lux, got %v", current.Reading.Lux)
+	}
+
+	// Unset scale resets to 1
+	resp, err = client.CalibrateSensor(ctx, &pb.CalibrateSensorRequest{SensorId: "mock"})
+	if err != nil {
+		t.Fatalf("CalibrateSensor failed: %v", err)
+	}
+	if resp.Calibration.Scale != 1 || resp.Previous.Scale != 1.1 || resp.Previous.UpdatedAtMs == 0 {
+		t.Errorf("unexpected response %v", resp)
+	}
+}
+
+func TestCalibrateSensor_OtherSensor(t *testing.T) {
+	calibrations := memory.NewCalibrationRepository()
+	client := startCalibratedServer(t, calibrations)
+	ctx := context.Background()
+
+	resp, err := client.CalibrateSensor(ctx, &pb.CalibrateSensorRequest{SensorId: "gpio", Scale: proto.Float64(2)})
+	if err != nil {
+		t.Fatalf("CalibrateSensor failed: %v", err)
+	}
+	if resp.Active {
+		t.Error("expected another sensor's calibration not to be active")
+	}
+	if _, err := calibrations.GetCalibration(ctx, "gpio"); err != nil {
+		t.Errorf("expected the calibration stored, got %v", err)
+	}
+	current, _ := client.GetCurrentLight(ctx, &pb.GetCurrentLightRequest{})
+	if current.GetReading().GetLux() != 500 {
+		t.Errorf("expected the live sensor uncalibrated, got %v", current.GetReading().GetLux())
+	}
+}
+
+func TestCalibrateSensor_Invalid(t *testing.T) {
+	client := startCalibratedServer(t, memory.NewCalibrationRepository())
+
+	for name, req := range map[string]*pb.CalibrateSensorRequest{
+		"zero scale":     {Scale: proto.Float64(0)},
+		"negative scale": {Scale: proto.Float64(-1)},
+		"blank sensor":   {SensorId: "  "},
+	} {
+		_, err := client.CalibrateSensor(context.Background(), req)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: expected InvalidArgument, got %v", name, err)
+		}
+	}
+}
+
+func TestCalibrateSensor_ReadOnly(t *testing.T) {
+	client := startCalibratedServer(t, readonly.NewCalibrationRepository(memory.NewCalibrationRepository()))
+
+	_, err := client.CalibrateSensor(context.Background(), &pb.CalibrateSensorRequest{OffsetLux: 5})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition in read-only mode, got %v", err)
+	}
+}
+
+func TestCalibrateSensor_Disabled(t *testing.T) {
+	client := startTestServer(t)
+
+	_, err := client.CalibrateSensor(context.Background(), &pb.CalibrateSensorRequest{})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition without calibration, got %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package grpc
+
+import (
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/ports"
+	pb "github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+// dataChangeBuffer is how many events a WatchDataChanges or StreamReadings
+// client can fall behind by before it starts missing them
+const dataChangeBuffer = 64
+
+// WatchDataChanges streams data changes until the client goes away
+func (h *LightServiceHandler) WatchDataChanges(req *pb.WatchDataChangesRequest, stream pb.LightService_WatchDataChangesServer) error {
+	ctx := stream.Context()
+	zerolog.Ctx(ctx).Info().Msg("WatchDataChanges called")
+
+	if h.changes == nil {
+		return status.Error(codes.FailedPrecondition, "data change events are not enabled")
+	}
+
+	changes, unsubscribe := h.changes.Subscribe(dataChangeBuffer)
+	defer unsubscribe()
+
+	// Headers tell the client it is subscribed: nothing changed after it
+	// receives them will be missed for lack of a subscription
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case change := <-changes:
+			if err := stream.Send(convertDataChangeToProto(change)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// StreamReadings streams recorded readings until the client goes away
+func (h *LightServiceHandler) StreamReadings(req *pb.StreamReadingsRequest, stream pb.LightService_StreamReadingsServer) error {
+	ctx := stream.Context()
+	zerolog.Ctx(ctx).Info().Msg("StreamReadings called")
+
+	if h.readings == nil {
+		return status.Error(codes.FailedPrecondition, "reading stream is not enabled")
+	}
+
+	events, unsubscribe := h.readings.Subscribe(dataChangeBuffer)
+	defer unsubscribe()
+
+	// As in WatchDataChanges, headers tell the client it is subscribed
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-events:
+			if err := stream.Send(h.convertReadingToProto(event.Reading)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// convertDataChangeToProto converts a data change to its stream event
+func convertDataChangeToProto(c ports.DataChange) *pb.DataChangeEvent {
+	if c.Kind == ports.DataChangePruned {
+		return &pb.DataChangeEvent{Change: &pb.DataChangeEvent_Pruned{
+			Pruned: &pb.ReadingsPruned{DeletedBeforeMs: c.Timestamp.UnixMilli()},
+		}}
+	}
+	return &pb.DataChangeEvent{Change: &pb.DataChangeEvent_Saved{
+		Saved: &pb.ReadingSaved{Id: c.ReadingID, TimestampMs: c.Timestamp.UnixMilli()},
+	}}
+}
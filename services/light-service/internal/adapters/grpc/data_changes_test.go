@@ -0,0 +1,131 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/mock"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/ports"
+	pb "github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+func TestWatchDataChanges_SaveAndPrune(t *testing.T) {
+	inner := memory.NewReadingRepository()
+	bus := ports.NewDataChangeBus()
+	client := startTestServerWithRepo(t, ports.NewPublishingRepository(inner, bus), WithDataChanges(bus))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Seeded straight into the inner repository, so not published
+	old, _ := domain.NewLightReading(300)
+	old.Timestamp = time.Now().Add(-72 * time.Hour)
+	_ = inner.SaveReading(ctx, old)
+
+	stream, err := client.WatchDataChanges(ctx, &pb.WatchDataChangesRequest{})
+	if err != nil {
+		t.Fatalf("WatchDataChanges failed: %v", err)
+	}
+	if _, err := stream.Header(); err != nil {
+		t.Fatalf("waiting for subscription: %v", err)
+	}
+
+	recorded, err := client.RecordReading(ctx, &pb.RecordReadingRequest{Lux: 800})
+	if err != nil {
+		t.Fatalf("RecordReading failed: %v", err)
+	}
+	event, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv failed: %v", err)
+	}
+	saved := event.GetSaved()
+	if saved == nil || saved.Id != recorded.Reading.Id || saved.TimestampMs != recorded.Reading.TimestampMs {
+		t.Errorf("expected saved event for reading %d, got %v", recorded.Reading.Id, event)
+	}
+
+	retention := 48 * time.Hour
+	before := time.Now().Add(-retention)
+	if _, err := client.PruneReadings(ctx, &pb.PruneRequest{RetentionSeconds: int64(retention.Seconds())}); err != nil {
+		t.Fatalf("PruneReadings failed: %v", err)
+	}
+	event, err = stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv failed: %v", err)
+	}
+	pruned := event.GetPruned()
+	if pruned == nil {
+		t.Fatalf("expected pruned event, got %v", event)
+	}
+	if cutoff := time.UnixMilli(pruned.DeletedBeforeMs); cutoff.Before(before.Truncate(time.Millisecond)) || cutoff.After(time.Now().Add(-retention)) {
+		t.Errorf("deleted-before %v not around now-48h (%v)", cutoff, before)
+	}
+}
+
+func TestStreamReadings(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	bus := ports.NewEventBus()
+	client := startTestServerWithRepo(t, repo, WithReadingStream(bus))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := client.StreamReadings(ctx, &pb.StreamReadingsRequest{})
+	if err != nil {
+		t.Fatalf("StreamReadings failed: %v", err)
+	}
+	if _, err := stream.Header(); err != nil {
+		t.Fatalf("waiting for subscription: %v", err)
+	}
+
+	// The recorder records immediately on start, then every interval
+	recorderCtx, stopRecorder := context.WithCancel(ctx)
+	defer stopRecorder()
+	recorder := ports.NewRecorder(mock.NewFakeSensor(500.0, 0), repo, 10*time.Millisecond, ports.WithEventBus(bus))
+	go recorder.Start(recorderCtx)
+
+	var lastID int64
+	for range 2 {
+		reading, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv failed: %v", err)
+		}
+		if reading.Lux != 500 || reading.Id == lastID {
+			t.Errorf("expected a new 500 lux reading, got %v", reading)
+		}
+		lastID = reading.Id
+	}
+
+	// Disconnecting ends the stream
+	cancel()
+	if _, err := stream.Recv(); status.Code(err) != codes.Canceled {
+		t.Errorf("expected Canceled after disconnect, got %v", err)
+	}
+}
+
+func TestStreamReadings_NotEnabled(t *testing.T) {
+	client := startTestServer(t)
+
+	stream, err := client.StreamReadings(context.Background(), &pb.StreamReadingsRequest{})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition, got %v", err)
+	}
+}
+
+func TestWatchDataChanges_NotEnabled(t *testing.T) {
+	client := startTestServer(t)
+
+	stream, err := client.WatchDataChanges(context.Background(), &pb.WatchDataChangesRequest{})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition, got %v", err)
+	}
+}
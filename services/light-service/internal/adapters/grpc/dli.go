@@ -0,0 +1,109 @@
+package grpc
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+// maxDLIDays caps the dates one GetDailyLightIntegral call covers, as all
+// their readings are loaded at once
+const maxDLIDays = 366
+
+// dliEstimator integrates with factor, or the handler's factor when it is 0,
+// crediting readings for no longer than GetHistory's time-in-category does
+func (h *LightServiceHandler) dliEstimator(factor float64) domain.DLIEstimator {
+	if factor == 0 {
+		factor = h.luxToPPFD
+	}
+	return domain.DLIEstimator{LuxToPPFD: factor, MaxGap: h.maxGap}
+}
+
+// parseDateRange parses an inclusive range of "YYYY-MM-DD" dates in the
+// named time zone into the midnights that start and end it, rejecting
+// ranges of more than maxDays dates
+func parseDateRange(startDate, endDate, timeZone string, maxDays int) (start, end time.Time, err error) {
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return time.Time{}, time.Time{}, status.Errorf(codes.InvalidArgument, "unknown time_zone %q", timeZone)
+	}
+	first, err := time.ParseInLocation(time.DateOnly, startDate, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, status.Errorf(codes.InvalidArgument, "start_date %q is not YYYY-MM-DD", startDate)
+	}
+	last, err := time.ParseInLocation(time.DateOnly, endDate, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, status.Errorf(codes.InvalidArgument, "end_date %q is not YYYY-MM-DD", endDate)
+	}
+	if last.Before(first) {
+		return time.Time{}, time.Time{}, status.Error(codes.InvalidArgument, "end_date cannot be before start_date")
+	}
+	if last.After(first.AddDate(0, 0, maxDays-1)) {
+		return time.Time{}, time.Time{}, status.Errorf(codes.InvalidArgument, "date range cannot exceed %d days", maxDays)
+	}
+	return first, last.AddDate(0, 0, 1), nil
+}
+
+// GetDailyLightIntegral estimates the DLI of each date in the range
+func (h *LightServiceHandler) GetDailyLightIntegral(ctx context.Context, req *pb.GetDailyLightIntegralRequest) (*pb.GetDailyLightIntegralResponse, error) {
+	zerolog.Ctx(ctx).Info().
+		Str("start_date", req.StartDate).
+		Str("end_date", req.EndDate).
+		Str("time_zone", req.TimeZone).
+		Msg("GetDailyLightIntegral called")
+
+	start, end, err := parseDateRange(req.StartDate, req.EndDate, req.TimeZone, maxDLIDays)
+	if err != nil {
+		return nil, err
+	}
+	if req.LuxToPpfd < 0 || math.IsNaN(req.LuxToPpfd) || math.IsInf(req.LuxToPpfd, 0) {
+		return nil, status.Error(codes.InvalidArgument, "lux_to_ppfd cannot be negative")
+	}
+
+	// A reading shortly before the first midnight still covers its start
+	readings, err := h.repo.GetReadingsInRange(ctx, start.Add(-h.maxGap), end)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to get readings")
+		return nil, status.Error(codes.Internal, "failed to get readings")
+	}
+
+	// As in GenerateReport, the last reading doesn't cover the future
+	now := time.Now()
+	estimator := h.dliEstimator(req.LuxToPpfd)
+	days := estimator.Daily(readings, start, end, now)
+
+	resp := &pb.GetDailyLightIntegralResponse{
+		Days:      make([]*pb.DayLightIntegral, len(days)),
+		LuxToPpfd: estimator.LuxToPPFD,
+	}
+	for i, day := range days {
+		resp.Days[i] = &pb.DayLightIntegral{
+			Date:         day.Day.Format(time.DateOnly),
+			Dli:          day.Integral,
+			ReadingCount: int64(day.Readings),
+			Coverage:     dayCoverage(day.Day, day.Covered, now),
+		}
+	}
+	return resp, nil
+}
+
+// dayCoverage returns the share of the day starting at midnight day, or of
+// the part of it before now, that readings covering covered account for
+func dayCoverage(day time.Time, covered time.Duration, now time.Time) float64 {
+	dayEnd := day.AddDate(0, 0, 1)
+	if now.Before(dayEnd) {
+		dayEnd = now
+	}
+	elapsed := dayEnd.Sub(day)
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(covered) / float64(elapsed)
+}
@@ -0,0 +1,95 @@
+package grpc
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+func TestGetDailyLightIntegral(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	ctx := context.Background()
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	// Every 5 minutes at 1000 lux from 06:00 to 18:00 on the first day only
+	for m := 6 * 60; m < 18*60; m += 5 {
+		r, _ := domain.NewLightReadingAt(1000, start.Add(time.Duration(m)*time.Minute))
+		_ = repo.SaveReading(ctx, r)
+	}
+	client := startTestServerWithRepo(t, repo)
+
+	resp, err := client.GetDailyLightIntegral(ctx, &pb.GetDailyLightIntegralRequest{
+		StartDate: "2024-06-01",
+		EndDate:   "2024-06-02",
+	})
+	if err != nil {
+		t.Fatalf("GetDailyLightIntegral failed: %v", err)
+	}
+	if len(resp.Days) != 2 || resp.Days[0].Date != "2024-06-01" || resp.Days[1].Date != "2024-06-02" {
+		t.Fatalf("expected 2024-06-01 and 2024-06-02, got %v", resp.Days)
+	}
+	if resp.LuxToPpfd != domain.LuxToPPFD {
+		t.Errorf("expected the default factor, got %v", resp.LuxToPpfd)
+	}
+
+	// 12 hours of readings, the last credited with the 15 minute cap
+	hours := 12 + 10.0/60
+	day := resp.Days[0]
+	if want := 1000 * domain.LuxToPPFD * hours * 3600 / 1e6; math.Abs(day.Dli-want) > 1e-9 {
+		t.Errorf("expected DLI %v, got %v", want, day.Dli)
+	}
+	if day.ReadingCount != 144 {
+		t.Errorf("expected 144 readings, got %d", day.ReadingCount)
+	}
+	if want := hours / 24; math.Abs(day.Coverage-want) > 1e-9 {
+		t.Errorf("expected coverage %v, got %v", want, day.Coverage)
+	}
+	if resp.Days[1].Dli != 0 || resp.Days[1].Coverage != 0 {
+		t.Errorf("expected nothing on the second day, got %v", resp.Days[1])
+	}
+
+	// A per-request factor scales the result
+	led, err := client.GetDailyLightIntegral(ctx, &pb.GetDailyLightIntegralRequest{
+		StartDate: "2024-06-01",
+		EndDate:   "2024-06-01",
+		LuxToPpfd: domain.LuxToPPFD / 2,
+	})
+	if err != nil {
+		t.Fatalf("GetDailyLightIntegral failed: %v", err)
+	}
+	if math.Abs(led.Days[0].Dli-day.Dli/2) > 1e-9 {
+		t.Errorf("expected half the DLI, got %v", led.Days[0].Dli)
+	}
+}
+
+func TestGetDailyLightIntegral_InvalidRequests(t *testing.T) {
+	client := startTestServer(t)
+
+	tests := []struct {
+		name string
+		req  *pb.GetDailyLightIntegralRequest
+	}{
+		{"bad date", &pb.GetDailyLightIntegralRequest{StartDate: "June 1st", EndDate: "2024-06-02"}},
+		{"missing end", &pb.GetDailyLightIntegralRequest{StartDate: "2024-06-01"}},
+		{"reversed", &pb.GetDailyLightIntegralRequest{StartDate: "2024-06-02", EndDate: "2024-06-01"}},
+		{"too long", &pb.GetDailyLightIntegralRequest{StartDate: "2024-01-01", EndDate: "2025-01-01"}},
+		{"unknown zone", &pb.GetDailyLightIntegralRequest{StartDate: "2024-06-01", EndDate: "2024-06-01", TimeZone: "Mars/Olympus"}},
+		{"negative factor", &pb.GetDailyLightIntegralRequest{StartDate: "2024-06-01", EndDate: "2024-06-01", LuxToPpfd: -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.GetDailyLightIntegral(context.Background(), tt.req)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package grpc
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	pb "github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+// exportColumns is the CSV header, in exportRow field order
+var exportColumns = []string{"id", "timestamp", "timestamp_ms", "lux", "category", "source", "quality", "temperature_celsius"}
+
+// exportRow is one reading in a download
+type exportRow struct {
+	ID           int64    `json:"id"`
+	Timestamp    string   `json:"timestamp"` // RFC 3339 in UTC, to the millisecond
+	TimestampMs  int64    `json:"timestamp_ms"`
+	Lux          float64  `json:"lux"`
+	Category     string   `json:"category"`
+	Source       string   `json:"source"`
+	Quality      string   `json:"quality"`
+	TemperatureC *float64 `json:"temperature_celsius"` // null (empty in CSV) when not recorded
+}
+
+// csvRecord formats the row's fields as CSV columns
+func (r exportRow) csvRecord() []string {
+	var temperature string
+	if r.TemperatureC != nil {
+		temperature = strconv.FormatFloat(*r.TemperatureC, 'f', -1, 64)
+	}
+	return []string{
+		strconv.FormatInt(r.ID, 10),
+		r.Timestamp,
+		strconv.FormatInt(r.TimestampMs, 10),
+		strconv.FormatFloat(r.Lux, 'f', -1, 64),
+		r.Category,
+		r.Source,
+		r.Quality,
+		temperature,
+	}
+}
+
+// DownloadReadings streams the readings in a range as text, one repository
+// page per chunk. A CSV download starts with its header even when the range
+// is empty.
+func (h *LightServiceHandler) DownloadReadings(req *pb.DownloadReadingsRequest, stream pb.LightService_DownloadReadingsServer) error {
+	ctx := stream.Context()
+	zerolog.Ctx(ctx).Info().
+		Int64("start_ms", req.StartTimeMs).
+		Int64("end_ms", req.EndTimeMs).
+		Str("format", req.Format.String()).
+		Msg("DownloadReadings called")
+
+	start, end := time.UnixMilli(req.StartTimeMs), time.UnixMilli(req.EndTimeMs)
+	if !end.After(start) {
+		return status.Error(codes.InvalidArgument, "end_time_ms must be after start_time_ms")
+	}
+	ndjson := false
+	switch req.Format {
+	case pb.ExportFormat_EXPORT_FORMAT_UNSPECIFIED, pb.ExportFormat_EXPORT_FORMAT_CSV:
+	case pb.ExportFormat_EXPORT_FORMAT_NDJSON:
+		ndjson = true
+	default:
+		return status.Errorf(codes.InvalidArgument, "unknown format %v", req.Format)
+	}
+
+	batchSize := int(req.BatchSize)
+	switch {
+	case batchSize < 0:
+		return status.Error(codes.InvalidArgument, "batch_size cannot be negative")
+	case batchSize == 0:
+		batchSize = defaultExportBatchSize
+	case batchSize > maxExportBatchSize:
+		batchSize = maxExportBatchSize
+	}
+
+	var buf bytes.Buffer
+	csvWriter := csv.NewWriter(&buf)
+	jsonEncoder := json.NewEncoder(&buf)
+	if !ndjson {
+		csvWriter.Write(exportColumns)
+	}
+
+	opts := []domain.RangeOption{domain.WithLimit(batchSize)}
+	var downloaded int
+	for first := true; ; first = false {
+		page, err := h.repo.GetReadingsInRange(ctx, start, end, opts...)
+		if err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Msg("failed to get readings for download")
+			return status.Error(codes.Internal, "failed to get readings")
+		}
+		if len(page) == 0 && !first {
+			break
+		}
+
+		for _, r := range page {
+			row := h.exportRow(r)
+			if ndjson {
+				if err := jsonEncoder.Encode(row); err != nil {
+					return status.Error(codes.Internal, "failed to encode reading")
+				}
+			} else {
+				csvWriter.Write(row.csvRecord())
+			}
+		}
+		csvWriter.Flush()
+
+		if buf.Len() > 0 {
+			if err := stream.Send(&pb.DownloadChunk{Data: bytes.Clone(buf.Bytes())}); err != nil {
+				return err
+			}
+			buf.Reset()
+		}
+
+		downloaded += len(page)
+		if len(page) < batchSize {
+			break
+		}
+		opts = []domain.RangeOption{domain.WithLimit(batchSize), domain.WithCursor(domain.CursorAfter(page[len(page)-1]))}
+	}
+
+	zerolog.Ctx(ctx).Info().Int("downloaded", downloaded).Msg("download completed")
+	return nil
+}
+
+// exportRow converts a reading for download
+func (h *LightServiceHandler) exportRow(r *domain.LightReading) exportRow {
+	quality := r.Quality
+	if quality == "" {
+		quality = domain.QualityOK
+	}
+	return exportRow{
+		ID:           r.ID,
+		Timestamp:    r.Timestamp.UTC().Format("2006-01-02T15:04:05.000Z07:00"),
+		TimestampMs:  r.Timestamp.UnixMilli(),
+		Lux:          r.Lux,
+		Category:     h.categoryLabel(r),
+		Source:       string(r.Source),
+		Quality:      string(quality),
+		TemperatureC: r.TemperatureC,
+	}
+}
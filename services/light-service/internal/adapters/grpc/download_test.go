@@ -0,0 +1,141 @@
+package grpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+// download collects a DownloadReadings stream, returning the file and how
+// many chunks it came in
+func download(t *testing.T, client pb.LightServiceClient, req *pb.DownloadReadingsRequest) ([]byte, int, error) {
+	t.Helper()
+	stream, err := client.DownloadReadings(context.Background(), req)
+	if err != nil {
+		t.Fatalf("DownloadReadings failed: %v", err)
+	}
+	var data []byte
+	var chunks int
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return data, chunks, nil
+		}
+		if err != nil {
+			return nil, chunks, err
+		}
+		data = append(data, chunk.Data...)
+		chunks++
+	}
+}
+
+func TestDownloadReadings_CSV(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	ctx := context.Background()
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 5 {
+		r, _ := domain.NewLightReadingAt(float64(100*(i+1)), start.Add(time.Duration(i)*time.Hour))
+		if i == 0 {
+			_ = r.SetTemperature(21.5)
+		}
+		_ = repo.SaveReading(ctx, r)
+	}
+	client := startTestServerWithRepo(t, repo)
+
+	// The last reading is outside the half-open range
+	data, chunks, err := download(t, client, &pb.DownloadReadingsRequest{
+		StartTimeMs: start.UnixMilli(),
+		EndTimeMs:   start.Add(4 * time.Hour).UnixMilli(),
+		BatchSize:   3,
+	})
+	if err != nil {
+		t.Fatalf("download failed: %v", err)
+	}
+	if chunks != 2 {
+		t.Errorf("expected 4 readings in 2 chunks of up to 3, got %d chunks", chunks)
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(records) != 5 {
+		t.Fatalf("expected a header and 4 rows, got %d records", len(records))
+	}
+	if records[0][0] != "id" || records[0][7] != "temperature_celsius" {
+		t.Errorf("unexpected header %v", records[0])
+	}
+	first := records[1]
+	if first[1] != "2024-06-01T00:00:00.000Z" || first[3] != "100" || first[4] != "Low Light" || first[6] != "ok" || first[7] != "21.5" {
+		t.Errorf("unexpected first row %v", first)
+	}
+	if records[4][3] != "400" || records[4][7] != "" {
+		t.Errorf("unexpected last row %v", records[4])
+	}
+}
+
+func TestDownloadReadings_NDJSON(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	ctx := context.Background()
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 3 {
+		r, _ := domain.NewLightReadingAt(3000, start.Add(time.Duration(i)*time.Minute))
+		_ = repo.SaveReading(ctx, r)
+	}
+	client := startTestServerWithRepo(t, repo)
+
+	data, _, err := download(t, client, &pb.DownloadReadingsRequest{
+		StartTimeMs: start.UnixMilli(),
+		EndTimeMs:   start.Add(time.Hour).UnixMilli(),
+		Format:      pb.ExportFormat_EXPORT_FORMAT_NDJSON,
+	})
+	if err != nil {
+		t.Fatalf("download failed: %v", err)
+	}
+
+	var lines int
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var row map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &row); err != nil {
+			t.Fatalf("line %d is not JSON: %v", lines+1, err)
+		}
+		if row["lux"] != 3000.0 || row["category"] != "High Light" || row["temperature_celsius"] != nil {
+			t.Errorf("unexpected row %v", row)
+		}
+		lines++
+	}
+	if lines != 3 {
+		t.Errorf("expected 3 lines, got %d", lines)
+	}
+}
+
+func TestDownloadReadings_EmptyRange(t *testing.T) {
+	client := startTestServer(t)
+
+	data, _, err := download(t, client, &pb.DownloadReadingsRequest{StartTimeMs: 0, EndTimeMs: 1000})
+	if err != nil {
+		t.Fatalf("download failed: %v", err)
+	}
+	if string(data) != "id,timestamp,timestamp_ms,lux,category,source,quality,temperature_celsius\n" {
+		t.Errorf("expected just the CSV header, got %q", data)
+	}
+
+	_, _, err = download(t, client, &pb.DownloadReadingsRequest{StartTimeMs: 1000, EndTimeMs: 1000})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for an empty range, got %v", err)
+	}
+}
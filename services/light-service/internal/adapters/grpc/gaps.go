@@ -0,0 +1,77 @@
+package grpc
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+// defaultGapMultiplier flags a gap at three missed recordings, matching
+// DefaultMaxCategoryGap
+const defaultGapMultiplier = 3
+
+// DetectGaps lists where consecutive readings in a range are further apart
+// than the expected interval allows
+func (h *LightServiceHandler) DetectGaps(ctx context.Context, req *pb.DetectGapsRequest) (*pb.DetectGapsResponse, error) {
+	zerolog.Ctx(ctx).Info().
+		Int64("start_ms", req.StartTimeMs).
+		Int64("end_ms", req.EndTimeMs).
+		Msg("DetectGaps called")
+
+	start, end := time.UnixMilli(req.StartTimeMs), time.UnixMilli(req.EndTimeMs)
+	if !end.After(start) {
+		return nil, status.Error(codes.InvalidArgument, "end_time_ms must be after start_time_ms")
+	}
+	if req.ExpectedIntervalMs < 0 || req.Tolerance < 0 || math.IsNaN(req.Tolerance) {
+		return nil, status.Error(codes.InvalidArgument, "expected_interval_ms and tolerance cannot be negative")
+	}
+
+	readings, err := h.repo.GetReadingsInRange(ctx, start, end)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to get readings")
+		return nil, status.Error(codes.Internal, "failed to get readings")
+	}
+
+	gaps, total := convertGapsToProto(domain.FindGaps(readings, h.gapThreshold(req.ExpectedIntervalMs, req.Tolerance)))
+	return &pb.DetectGapsResponse{
+		Gaps:         gaps,
+		TotalGapMs:   total.Milliseconds(),
+		ReadingCount: int64(len(readings)),
+	}, nil
+}
+
+// gapThreshold is the spacing beyond which readings count as a gap:
+// multiplier times intervalMs, where zero means the recording interval and
+// defaultGapMultiplier respectively
+func (h *LightServiceHandler) gapThreshold(intervalMs int64, multiplier float64) time.Duration {
+	interval := h.interval
+	if intervalMs > 0 {
+		interval = time.Duration(intervalMs) * time.Millisecond
+	}
+	if multiplier == 0 {
+		multiplier = defaultGapMultiplier
+	}
+	return time.Duration(float64(interval) * multiplier)
+}
+
+// convertGapsToProto converts gaps and returns their total duration
+func convertGapsToProto(gaps []domain.Gap) ([]*pb.RecordingGap, time.Duration) {
+	var total time.Duration
+	pbGaps := make([]*pb.RecordingGap, len(gaps))
+	for i, g := range gaps {
+		pbGaps[i] = &pb.RecordingGap{
+			StartTimeMs: g.Start.UnixMilli(),
+			EndTimeMs:   g.End.UnixMilli(),
+			DurationMs:  g.Duration().Milliseconds(),
+		}
+		total += g.Duration()
+	}
+	return pbGaps, total
+}
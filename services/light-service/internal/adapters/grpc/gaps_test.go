@@ -0,0 +1,81 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+func TestDetectGaps(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	ctx := context.Background()
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	// Every 5 minutes for the first hour, nothing until 01:40, then every 5
+	// minutes until 03:00
+	for m := 0; m <= 180; m += 5 {
+		if m > 60 && m < 100 {
+			continue
+		}
+		r, _ := domain.NewLightReadingAt(500, start.Add(time.Duration(m)*time.Minute))
+		_ = repo.SaveReading(ctx, r)
+	}
+	client := startTestServerWithRepo(t, repo)
+
+	resp, err := client.DetectGaps(ctx, &pb.DetectGapsRequest{
+		StartTimeMs: start.UnixMilli(),
+		EndTimeMs:   start.Add(3 * time.Hour).UnixMilli(),
+	})
+	if err != nil {
+		t.Fatalf("DetectGaps failed: %v", err)
+	}
+	if len(resp.Gaps) != 1 {
+		t.Fatalf("expected 1 gap, got %d", len(resp.Gaps))
+	}
+	g := resp.Gaps[0]
+	if g.StartTimeMs != start.Add(60*time.Minute).UnixMilli() || g.EndTimeMs != start.Add(100*time.Minute).UnixMilli() {
+		t.Errorf("expected gap 01:00-01:40, got %v-%v", time.UnixMilli(g.StartTimeMs).UTC(), time.UnixMilli(g.EndTimeMs).UTC())
+	}
+	if want := (40 * time.Minute).Milliseconds(); g.DurationMs != want || resp.TotalGapMs != want {
+		t.Errorf("expected 40m gap, got duration %dms total %dms", g.DurationMs, resp.TotalGapMs)
+	}
+
+	// The evenly spaced first hour has no gaps, even at a tight tolerance
+	resp, err = client.DetectGaps(ctx, &pb.DetectGapsRequest{
+		StartTimeMs:        start.UnixMilli(),
+		EndTimeMs:          start.Add(time.Hour).UnixMilli(),
+		ExpectedIntervalMs: (5 * time.Minute).Milliseconds(),
+		Tolerance:          1,
+	})
+	if err != nil {
+		t.Fatalf("DetectGaps failed: %v", err)
+	}
+	if len(resp.Gaps) != 0 || resp.TotalGapMs != 0 || resp.ReadingCount != 12 {
+		t.Errorf("expected 12 readings and no gaps, got %d readings, %d gaps", resp.ReadingCount, len(resp.Gaps))
+	}
+
+	// A tolerance wider than the gap hides it
+	resp, err = client.DetectGaps(ctx, &pb.DetectGapsRequest{
+		StartTimeMs: start.UnixMilli(),
+		EndTimeMs:   start.Add(3 * time.Hour).UnixMilli(),
+		Tolerance:   10,
+	})
+	if err != nil {
+		t.Fatalf("DetectGaps failed: %v", err)
+	}
+	if len(resp.Gaps) != 0 {
+		t.Errorf("expected no gaps at 10x tolerance, got %d", len(resp.Gaps))
+	}
+
+	_, err = client.DetectGaps(ctx, &pb.DetectGapsRequest{StartTimeMs: 1000, EndTimeMs: 1000})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for an empty range, got %v", err)
+	}
+}
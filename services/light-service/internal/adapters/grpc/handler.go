@@ -2,149 +2,1277 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
 	"time"
 
-	"github.com/rs/zerolog/log"
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
 	"github.com/quentinrf/plant-monitor/services/light-service/internal/ports"
 	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
 )
 
+// tracer starts the handler's own spans, nested in otelgrpc's server span
+var tracer = otel.Tracer("github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/grpc")
+
+// CategoryLabeler turns a numeric light category into a human-readable label
+type CategoryLabeler interface {
+	Label(c domain.Category) string
+}
+
 // LightServiceHandler implements the gRPC LightService
 type LightServiceHandler struct {
 	pb.UnimplementedLightServiceServer
-	repo   domain.ReadingRepository
-	sensor ports.LightSensor
+	repo           domain.ReadingRepository
+	sensor         ports.LightSensor
+	labeler        CategoryLabeler
+	scheme         *domain.CategoryScheme
+	hysteresis     float64
+	recorder       RecorderStatusSource
+	changes        *ports.DataChangeBus
+	readings       *ports.EventBus
+	alerts         domain.AlertRepository
+	calibrations   domain.CalibrationRepository
+	calibrated     *ports.CalibratedSensor
+	minRetention   time.Duration
+	maxRecent      int
+	maxGap         time.Duration
+	interval       time.Duration
+	maxSpan        time.Duration
+	maxHistory     int
+	maxBatch       int
+	luxToPPFD      float64
+	photoperiodLux float64
+}
+
+// HandlerOption configures optional LightServiceHandler behaviour
+type HandlerOption func(*LightServiceHandler)
+
+// WithCategoryLabeler overrides the labels used for reading categories
+// (e.g. to localize them for a non-English UI)
+func WithCategoryLabeler(labeler CategoryLabeler) HandlerOption {
+	return func(h *LightServiceHandler) {
+		h.labeler = labeler
+	}
+}
+
+// WithCategoryScheme labels each reading by a custom set of light levels
+// (e.g. five levels from "Very Low" to "Direct Sun") instead of the
+// three-level labeler, and breaks time-in-category down by those levels
+func WithCategoryScheme(scheme *domain.CategoryScheme) HandlerOption {
+	return func(h *LightServiceHandler) {
+		h.scheme = scheme
+	}
+}
+
+// RecorderStatusSource reports the background recorder's status
+type RecorderStatusSource interface {
+	Status() ports.RecorderStatus
+}
+
+// WithRecorderStatus lets GetRecorderStatus report on the running recorder;
+// without it the recorder is reported as not running
+func WithRecorderStatus(source RecorderStatusSource) HandlerOption {
+	return func(h *LightServiceHandler) {
+		h.recorder = source
+	}
+}
+
+// WithDataChanges lets WatchDataChanges stream changes published on bus;
+// without it the RPC fails with FailedPrecondition
+func WithDataChanges(bus *ports.DataChangeBus) HandlerOption {
+	return func(h *LightServiceHandler) {
+		h.changes = bus
+	}
+}
+
+// WithReadingStream lets StreamReadings stream the readings of the events
+// the recorder publishes on bus; without it the RPC fails with
+// FailedPrecondition
+func WithReadingStream(bus *ports.EventBus) HandlerOption {
+	return func(h *LightServiceHandler) {
+		h.readings = bus
+	}
+}
+
+// WithAlerts lets the alert RPCs manage the rules and fired alerts in repo;
+// without it they fail with FailedPrecondition
+func WithAlerts(repo domain.AlertRepository) HandlerOption {
+	return func(h *LightServiceHandler) {
+		h.alerts = repo
+	}
+}
+
+// WithCalibration lets CalibrateSensor store calibrations in repo, applying
+// them at once when they are for the live sensor; without it the RPC fails
+// with FailedPrecondition
+func WithCalibration(repo domain.CalibrationRepository, sensor *ports.CalibratedSensor) HandlerOption {
+	return func(h *LightServiceHandler) {
+		h.calibrations = repo
+		h.calibrated = sensor
+	}
+}
+
+// WithMinPruneRetention sets the smallest retention PruneReadings accepts,
+// guarding against a typo wiping recent data
+func WithMinPruneRetention(d time.Duration) HandlerOption {
+	return func(h *LightServiceHandler) {
+		h.minRetention = d
+	}
+}
+
+// WithMaxRecentLimit caps how many readings GetRecent returns per call
+func WithMaxRecentLimit(n int) HandlerOption {
+	return func(h *LightServiceHandler) {
+		h.maxRecent = n
+	}
+}
+
+// WithCategoryHysteresis sets the margin RecomputeCategories applies, which
+// should match the recorder's
+func WithCategoryHysteresis(margin float64) HandlerOption {
+	return func(h *LightServiceHandler) {
+		h.hysteresis = margin
+	}
+}
+
+// WithMaxCategoryGap caps how long one reading can count towards its
+// category in GetHistory's time-in-category breakdown (0 disables the cap)
+func WithMaxCategoryGap(d time.Duration) HandlerOption {
+	return func(h *LightServiceHandler) {
+		h.maxGap = d
+	}
+}
+
+// WithRecordInterval tells the handler how often the recorder saves
+// readings, which gap detection expects by default
+func WithRecordInterval(d time.Duration) HandlerOption {
+	return func(h *LightServiceHandler) {
+		h.interval = d
+	}
+}
+
+// WithMaxHistorySpan rejects GetHistory ranges longer than d (0 allows any)
+func WithMaxHistorySpan(d time.Duration) HandlerOption {
+	return func(h *LightServiceHandler) {
+		h.maxSpan = d
+	}
+}
+
+// WithMaxHistoryReadings caps how many readings one GetHistory response may
+// carry (0 disables the cap); stats-only requests are exempt
+func WithMaxHistoryReadings(n int) HandlerOption {
+	return func(h *LightServiceHandler) {
+		h.maxHistory = n
+	}
+}
+
+// WithMaxBatchSize caps how many readings one RecordReadingsBatch call may
+// carry (0 allows any, leaving only the message size limit)
+func WithMaxBatchSize(n int) HandlerOption {
+	return func(h *LightServiceHandler) {
+		h.maxBatch = n
+	}
 }
 
+// WithLuxToPPFD sets the factor daily light integrals convert lux to
+// µmol/m²/s with, e.g. about 0.015 for white LED grow lights
+func WithLuxToPPFD(factor float64) HandlerOption {
+	return func(h *LightServiceHandler) {
+		h.luxToPPFD = factor
+	}
+}
+
+// DefaultRecordInterval is the recorder's default interval
+const DefaultRecordInterval = 5 * time.Minute
+
+// DefaultMaxCategoryGap is three recording intervals at the default rate
+const DefaultMaxCategoryGap = 15 * time.Minute
+
+// DefaultMaxRecentLimit is the GetRecent cap used unless overridden
+const DefaultMaxRecentLimit = 1000
+
+// DefaultMaxHistoryReadings is the GetHistory response cap used unless
+// overridden; about two years of readings at the default interval
+const DefaultMaxHistoryReadings = 200_000
+
+// DefaultMinPruneRetention is the PruneReadings guard used unless overridden
+const DefaultMinPruneRetention = 24 * time.Hour
+
 // NewLightServiceHandler creates a new gRPC handler
-func NewLightServiceHandler(repo domain.ReadingRepository, sensor ports.LightSensor) *LightServiceHandler {
-	return &LightServiceHandler{
-		repo:   repo,
-		sensor: sensor,
+func NewLightServiceHandler(repo domain.ReadingRepository, sensor ports.LightSensor, opts ...HandlerOption) *LightServiceHandler {
+	h := &LightServiceHandler{
+		repo:           repo,
+		sensor:         sensor,
+		labeler:        domain.DefaultCategoryLabels,
+		minRetention:   DefaultMinPruneRetention,
+		maxRecent:      DefaultMaxRecentLimit,
+		maxGap:         DefaultMaxCategoryGap,
+		interval:       DefaultRecordInterval,
+		maxHistory:     DefaultMaxHistoryReadings,
+		luxToPPFD:      domain.LuxToPPFD,
+		photoperiodLux: domain.DefaultPhotoperiodThreshold,
+	}
+	for _, opt := range opts {
+		opt(h)
 	}
+	return h
 }
 
 // GetCurrentLight returns the most recent reading
 func (h *LightServiceHandler) GetCurrentLight(ctx context.Context, req *pb.GetCurrentLightRequest) (*pb.GetCurrentLightResponse, error) {
-	log.Info().Msg("GetCurrentLight called")
+	zerolog.Ctx(ctx).Info().Msg("GetCurrentLight called")
+
+	if window := req.SmoothWindow; window.GetCount() != 0 || window.GetDurationMs() != 0 {
+		resp, err := h.smoothedCurrentLight(ctx, window)
+		if resp != nil || err != nil {
+			return resp, err
+		}
+		// Nothing stored yet: fall through to a live read
+	}
 
+	persisted := true
 	reading, err := h.repo.GetLatestReading(ctx)
 	if err == domain.ErrReadingNotFound {
 		// No readings yet - read sensor now
-		log.Info().Msg("no readings in database, reading sensor")
+		zerolog.Ctx(ctx).Info().Msg("no readings in database, reading sensor")
 
-		lux, err := h.sensor.ReadLux(ctx)
+		reading, err = h.readSensor(ctx)
 		if err != nil {
-			log.Error().Err(err).Msg("failed to read sensor")
-			return nil, status.Error(codes.Internal, "failed to read sensor")
-		}
-
-		reading, err = domain.NewLightReading(lux)
-		if err != nil {
-			log.Error().Err(err).Msg("failed to create reading")
-			return nil, status.Error(codes.Internal, "failed to create reading")
+			return nil, err
 		}
 
 		// Save for next time
 		if err := h.repo.SaveReading(ctx, reading); err != nil {
-			log.Error().Err(err).Msg("failed to save reading")
+			zerolog.Ctx(ctx).Error().Err(err).Msg("failed to save reading")
 			// Don't fail - we still have the reading
+			persisted = false
 		}
 	} else if err != nil {
-		log.Error().Err(err).Msg("failed to get latest reading")
-		return nil, status.Error(codes.Internal, "failed to get reading")
+		// The repository is unhealthy but the sensor may be fine; serve a
+		// live read rather than failing the dashboard. Don't try to save it.
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to get latest reading; falling back to live sensor read")
+
+		reading, err = h.readSensor(ctx)
+		if err != nil {
+			return nil, err
+		}
+		persisted = false
+	}
+
+	return &pb.GetCurrentLightResponse{
+		Reading:   h.convertReadingToProto(reading),
+		Persisted: persisted,
+	}, nil
+}
+
+// readSensor takes a live reading, mapping failures to gRPC errors
+func (h *LightServiceHandler) readSensor(ctx context.Context) (*domain.LightReading, error) {
+	lux, quality, err := ports.ReadLuxWithQuality(ctx, h.sensor)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to read sensor")
+		return nil, status.Error(codes.Internal, "failed to read sensor")
+	}
+
+	reading, err := domain.NewLightReading(lux)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to create reading")
+		return nil, status.Error(codes.Internal, "failed to create reading")
 	}
+	reading.Quality = quality
 
+	return reading, nil
+}
+
+// smoothedCurrentLight averages the readings in the smoothing window. It
+// returns a nil response if there are no stored readings.
+func (h *LightServiceHandler) smoothedCurrentLight(ctx context.Context, window *pb.SmoothWindow) (*pb.GetCurrentLightResponse, error) {
+	if window.GetCount() < 0 || window.GetDurationMs() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "smooth_window cannot be negative")
+	}
+
+	var readings []*domain.LightReading
+	var err error
+	if count := window.GetCount(); count > 0 {
+		readings, err = h.repo.GetRecentReadings(ctx, min(int(count), h.maxRecent))
+	} else {
+		var latest *domain.LightReading
+		latest, err = h.repo.GetLatestReading(ctx)
+		if err == domain.ErrReadingNotFound {
+			return nil, nil
+		}
+		if err == nil {
+			// The range is half-open, so end after the latest reading to include
+			// it. Nothing is later, so a whole second is safe, and survives
+			// repositories that compare bounds at second precision.
+			start := latest.Timestamp.Add(-time.Duration(window.GetDurationMs()) * time.Millisecond)
+			readings, err = h.repo.GetReadingsInRange(ctx, start, latest.Timestamp.Add(time.Second))
+		}
+	}
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to get readings to smooth")
+		return nil, status.Error(codes.Internal, "failed to get readings")
+	}
+	if len(readings) == 0 {
+		return nil, nil
+	}
+
+	latest := readings[len(readings)-1]
+	smoothed := &domain.LightReading{
+		Lux:       calculateStatistics(readings).average,
+		Timestamp: latest.Timestamp,
+		Source:    latest.Source,
+	}
 	return &pb.GetCurrentLightResponse{
-		Reading: convertReadingToProto(reading),
+		Reading:       h.convertReadingToProto(smoothed),
+		Persisted:     true,
+		SmoothedCount: int32(len(readings)),
 	}, nil
 }
 
 // GetHistory returns readings within time range with statistics
 func (h *LightServiceHandler) GetHistory(ctx context.Context, req *pb.GetHistoryRequest) (*pb.GetHistoryResponse, error) {
-	log.Info().
+	zerolog.Ctx(ctx).Info().
 		Int64("start", req.StartTime).
 		Int64("end", req.EndTime).
+		Str("source", req.Source.String()).
 		Msg("GetHistory called")
 
-	start := time.Unix(req.StartTime, 0)
-	end := time.Unix(req.EndTime, 0)
+	if req.Precision != nil && (*req.Precision < 0 || *req.Precision > maxPrecision) {
+		return nil, status.Errorf(codes.InvalidArgument, "precision must be between 0 and %d", maxPrecision)
+	}
+	for _, p := range req.Percentiles {
+		if !(p >= 0 && p <= 100) {
+			return nil, status.Errorf(codes.InvalidArgument, "percentile %v is outside 0-100", p)
+		}
+	}
+	if req.Limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit cannot be negative")
+	}
+
+	descending := req.Order == pb.SortOrder_SORT_ORDER_DESCENDING
+	var opts []domain.RangeOption
+	if descending {
+		opts = append(opts, domain.WithDescending())
+	}
+	if req.PageToken != "" {
+		cursor, err := decodePageToken(req.PageToken, descending)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		opts = append(opts, domain.WithCursor(cursor))
+	}
+	// Fetch one reading past the page to learn whether there is another
+	paged := req.Limit > 0 || req.PageToken != ""
+	limit := int(req.Limit)
+	if h.maxHistory > 0 && limit > h.maxHistory {
+		limit = h.maxHistory
+	}
+	if limit > 0 {
+		opts = append(opts, domain.WithLimit(limit+1))
+	}
+
+	start := timeFromProto(req.StartTime, req.StartTimeMs)
+	end := timeFromProto(req.EndTime, req.EndTimeMs)
+	if span := end.Sub(start); h.maxSpan > 0 && span > h.maxSpan {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"range spans %s, more than the limit of %s; split it into smaller ranges or page through it with ExportReadings",
+			span, h.maxSpan)
+	}
+	if req.AggregationIntervalMs != 0 {
+		return h.aggregatedHistory(ctx, req, start, end)
+	}
+
+	fetch := func(opts ...domain.RangeOption) ([]*domain.LightReading, error) {
+		return h.repo.GetReadingsInRange(ctx, start, end, opts...)
+	}
+	if req.CategoryFilter != nil {
+		scheme := h.eventScheme()
+		categories, err := convertCategoryFilterFromProto(req.CategoryFilter, scheme)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		fetch = func(opts ...domain.RangeOption) ([]*domain.LightReading, error) {
+			return h.repo.GetReadingsInCategories(ctx, start, end, scheme, categories, opts...)
+		}
+	}
+	keep := func(*domain.LightReading) bool { return true }
+	if req.Source != pb.ReadingSource_READING_SOURCE_UNSPECIFIED {
+		source := convertSourceFromProto(req.Source)
+		keep = func(r *domain.LightReading) bool { return r.Source == source }
+	}
 
-	readings, err := h.repo.GetReadingsInRange(ctx, start, end)
+	var readings []*domain.LightReading
+	var nextPageToken string
+	var err error
+	if !paged && !req.StatsOnly && h.maxHistory > 0 {
+		// One reading past the limit is enough to refuse the range, so
+		// don't load the rest of it
+		readings, err = fetchUpTo(fetch, opts, keep, h.maxHistory+1)
+	} else {
+		readings, err = fetch(opts...)
+		// The token resumes after the last reading fetched, before any
+		// source filtering, so a filtered page may be short but none are
+		// skipped
+		if err == nil && limit > 0 && len(readings) > limit {
+			readings = readings[:limit]
+			nextPageToken = encodePageToken(domain.CursorAfter(readings[limit-1]), descending)
+		}
+		if req.Source != pb.ReadingSource_READING_SOURCE_UNSPECIFIED {
+			readings = filterBySource(readings, convertSourceFromProto(req.Source))
+		}
+	}
 	if err != nil {
-		log.Error().Err(err).Msg("failed to get readings")
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to get readings")
 		return nil, status.Error(codes.Internal, "failed to get readings")
 	}
+	if !req.StatsOnly && h.maxHistory > 0 && len(readings) > h.maxHistory {
+		return nil, status.Errorf(codes.ResourceExhausted,
+			"range holds %d readings, more than the limit of %d; set stats_only for statistics alone, or page through the readings with limit and page_token",
+			len(readings), h.maxHistory)
+	}
+
+	// Everything from here is in-process work on the fetched readings; its
+	// span sits beside the repository's to show which one is slow
+	_, span := tracer.Start(ctx, "GetHistory.buildResponse", trace.WithAttributes(
+		attribute.Int("plant_monitor.readings", len(readings)),
+		attribute.Bool("plant_monitor.stats_only", req.StatsOnly),
+	))
+	defer span.End()
 
 	// Convert to protobuf
-	pbReadings := make([]*pb.LightReading, len(readings))
-	for i, r := range readings {
-		pbReadings[i] = convertReadingToProto(r)
+	var pbReadings []*pb.LightReading
+	if !req.StatsOnly {
+		pbReadings = make([]*pb.LightReading, len(readings))
+		for i, r := range readings {
+			pbReadings[i] = h.convertReadingToProto(r)
+		}
 	}
 
 	// Calculate statistics
 	stats := calculateStatistics(readings)
+	if req.Precision != nil {
+		stats = stats.rounded(int(*req.Precision))
+	}
 
-	return &pb.GetHistoryResponse{
-		Readings:   pbReadings,
-		AverageLux: stats.average,
-		MinLux:     stats.min,
-		MaxLux:     stats.max,
-	}, nil
+	// The last reading covers time up to the end of the range, but not
+	// into the future
+	until := end
+	if now := time.Now(); now.Before(until) {
+		until = now
+	}
+
+	resp := &pb.GetHistoryResponse{
+		Readings:      pbReadings,
+		AverageLux:    stats.average,
+		MinLux:        stats.min,
+		MaxLux:        stats.max,
+		NextPageToken: nextPageToken,
+	}
+	if len(req.Percentiles) > 0 {
+		sorted := sortedLux(readings)
+		for _, p := range req.Percentiles {
+			lux := domain.Percentile(sorted, p)
+			if req.Precision != nil {
+				lux = roundTo(lux, int(*req.Precision))
+			}
+			resp.Percentiles = append(resp.Percentiles, &pb.Percentile{Percentile: p, Lux: lux})
+		}
+	}
+	// Gaps left by filtered-out readings would be misattributed, and a page
+	// doesn't cover the range it would be attributed over
+	if req.CategoryFilter == nil && !paged {
+		if descending {
+			readings = slices.Clone(readings)
+			slices.Reverse(readings)
+		}
+		resp.TimeInCategory = h.timeInCategory(readings, until)
+	}
+	return resp, nil
+}
+
+// fetchUpTo fetches readings in chunks of n, keeping those keep accepts,
+// until n are kept or the range runs out
+func fetchUpTo(fetch func(...domain.RangeOption) ([]*domain.LightReading, error), opts []domain.RangeOption, keep func(*domain.LightReading) bool, n int) ([]*domain.LightReading, error) {
+	var kept []*domain.LightReading
+	chunkOpts := append(slices.Clip(opts), domain.WithLimit(n))
+	for {
+		chunk, err := fetch(chunkOpts...)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range chunk {
+			if keep(r) {
+				kept = append(kept, r)
+				if len(kept) == n {
+					return kept, nil
+				}
+			}
+		}
+		if len(chunk) < n {
+			return kept, nil
+		}
+		chunkOpts = append(slices.Clip(opts), domain.WithLimit(n), domain.WithCursor(domain.CursorAfter(chunk[len(chunk)-1])))
+	}
+}
+
+// CompareRanges computes statistics for two ranges and the change between them
+func (h *LightServiceHandler) CompareRanges(ctx context.Context, req *pb.CompareRangesRequest) (*pb.CompareRangesResponse, error) {
+	zerolog.Ctx(ctx).Info().Msg("CompareRanges called")
+
+	if req.RangeA == nil || req.RangeB == nil {
+		return nil, status.Error(codes.InvalidArgument, "range_a and range_b are required")
+	}
+
+	a, err := h.rangeStatistics(ctx, req.RangeA)
+	if err != nil {
+		return nil, err
+	}
+	b, err := h.rangeStatistics(ctx, req.RangeB)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.CompareRangesResponse{A: a, B: b}
+	if a.ReadingCount > 0 && b.ReadingCount > 0 {
+		resp.Comparable = true
+		resp.AverageLuxDelta = b.AverageLux - a.AverageLux
+		resp.MinLuxDelta = b.MinLux - a.MinLux
+		resp.MaxLuxDelta = b.MaxLux - a.MaxLux
+		if a.Dli != nil && b.Dli != nil {
+			resp.DliDelta = proto.Float64(*b.Dli - *a.Dli)
+		}
+	}
+	return resp, nil
+}
+
+// rangeStatistics summarizes the readings in one requested range. The store
+// computes the statistics, so ranges of any length don't load the readings.
+func (h *LightServiceHandler) rangeStatistics(ctx context.Context, r *pb.TimeRange) (*pb.RangeStatistics, error) {
+	if r.EndMs <= r.StartMs {
+		return nil, status.Error(codes.InvalidArgument, "range end must be after start")
+	}
+	start, end := time.UnixMilli(r.StartMs), time.UnixMilli(r.EndMs)
+
+	stats, err := h.repo.GetStatisticsInRange(ctx, start, end)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to get statistics")
+		return nil, status.Error(codes.Internal, "failed to get statistics")
+	}
+	result := &pb.RangeStatistics{
+		ReadingCount: stats.Count,
+		AverageLux:   stats.AverageLux,
+		MinLux:       stats.MinLux,
+		MaxLux:       stats.MaxLux,
+		Empty:        stats.Count == 0,
+	}
+
+	if end.Sub(start) <= maxDLIDays*24*time.Hour {
+		dli, err := h.rangeDLI(ctx, start, end, time.Now())
+		if err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Msg("failed to aggregate readings")
+			return nil, status.Error(codes.Internal, "failed to get statistics")
+		}
+		result.Dli = proto.Float64(dli)
+	}
+	return result, nil
+}
+
+// rangeDLI estimates the average daily light integral over [start, end), or
+// the part of it before now. The store averages the readings per maxGap, and
+// each average counts as one reading at the start of its bucket, so no more
+// than a bucket per maxGap of the range is loaded.
+func (h *LightServiceHandler) rangeDLI(ctx context.Context, start, end, now time.Time) (float64, error) {
+	interval := h.maxGap.Round(time.Second)
+	if interval <= 0 {
+		interval = DefaultMaxCategoryGap
+	}
+	buckets, err := h.repo.AggregateReadingsInRange(ctx, start, end, interval)
+	if err != nil {
+		return 0, err
+	}
+
+	until := end
+	if now.Before(until) {
+		until = now
+	}
+	if !until.After(start) {
+		return 0, nil
+	}
+
+	readings := make([]*domain.LightReading, len(buckets))
+	for i, b := range buckets {
+		at := b.Start
+		if at.Before(start) {
+			at = start
+		}
+		readings[i] = &domain.LightReading{Timestamp: at, Lux: b.AverageLux}
+	}
+	estimator := h.dliEstimator(0)
+	estimator.MaxGap = interval
+	return domain.DailyLightIntegral(estimator.Integral(readings, until), until.Sub(start)), nil
+}
+
+// maxReadingsByIDs is the most IDs one GetReadingsByIDs call may request
+const maxReadingsByIDs = 10000
+
+// GetReadingsByIDs fetches readings by ID, reporting the IDs not found
+func (h *LightServiceHandler) GetReadingsByIDs(ctx context.Context, req *pb.GetReadingsByIDsRequest) (*pb.GetReadingsByIDsResponse, error) {
+	zerolog.Ctx(ctx).Info().Int("count", len(req.Ids)).Msg("GetReadingsByIDs called")
+
+	if len(req.Ids) > maxReadingsByIDs {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d ids per request, got %d", maxReadingsByIDs, len(req.Ids))
+	}
+
+	readings, err := h.repo.GetReadingsByIDs(ctx, req.Ids)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to get readings")
+		return nil, status.Error(codes.Internal, "failed to get readings")
+	}
+
+	resp := &pb.GetReadingsByIDsResponse{Readings: make([]*pb.LightReading, len(readings))}
+	found := make(map[int64]bool, len(readings))
+	for i, r := range readings {
+		resp.Readings[i] = h.convertReadingToProto(r)
+		found[r.ID] = true
+	}
+	for _, id := range req.Ids {
+		if !found[id] {
+			found[id] = true // report each missing ID once
+			resp.MissingIds = append(resp.MissingIds, id)
+		}
+	}
+	return resp, nil
+}
+
+// GetRecordingDays lists the days in the requested time zone that have readings
+func (h *LightServiceHandler) GetRecordingDays(ctx context.Context, req *pb.GetRecordingDaysRequest) (*pb.GetRecordingDaysResponse, error) {
+	zerolog.Ctx(ctx).Info().Str("time_zone", req.TimeZone).Msg("GetRecordingDays called")
+
+	if req.EndTimeMs <= req.StartTimeMs {
+		return nil, status.Error(codes.InvalidArgument, "end_time_ms must be after start_time_ms")
+	}
+	loc, err := time.LoadLocation(req.TimeZone)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown time_zone %q", req.TimeZone)
+	}
+
+	days, err := h.repo.GetRecordingDays(ctx, time.UnixMilli(req.StartTimeMs), time.UnixMilli(req.EndTimeMs), loc)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to get recording days")
+		return nil, status.Error(codes.Internal, "failed to get recording days")
+	}
+
+	resp := &pb.GetRecordingDaysResponse{Days: make([]string, len(days))}
+	for i, day := range days {
+		resp.Days[i] = day.Format(time.DateOnly)
+	}
+	return resp, nil
+}
+
+// GetRecorderStatus reports the recorder's last success, last error and
+// current interval
+func (h *LightServiceHandler) GetRecorderStatus(ctx context.Context, req *pb.GetRecorderStatusRequest) (*pb.GetRecorderStatusResponse, error) {
+	zerolog.Ctx(ctx).Info().Msg("GetRecorderStatus called")
+
+	if h.recorder == nil {
+		return &pb.GetRecorderStatusResponse{Running: false}, nil
+	}
+
+	status := h.recorder.Status()
+	resp := &pb.GetRecorderStatusResponse{
+		Running:             true,
+		LastError:           status.LastError,
+		ConsecutiveFailures: int32(status.ConsecutiveFailures),
+		IntervalMs:          status.Interval.Milliseconds(),
+	}
+	if !status.LastSuccess.IsZero() {
+		resp.LastSuccessMs = status.LastSuccess.UnixMilli()
+	}
+	return resp, nil
 }
 
 // RecordReading manually records a reading (useful for testing)
 func (h *LightServiceHandler) RecordReading(ctx context.Context, req *pb.RecordReadingRequest) (*pb.RecordReadingResponse, error) {
-	log.Info().Float64("lux", req.Lux).Msg("RecordReading called")
+	zerolog.Ctx(ctx).Info().Float64("lux", req.Lux).Msg("RecordReading called")
 
-	reading, err := domain.NewLightReading(req.Lux)
+	reading, err := newTimestampedReading(req, time.Now())
 	if err != nil {
-		log.Error().Err(err).Msg("invalid lux value")
+		zerolog.Ctx(ctx).Error().Err(err).Msg("invalid reading")
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
 	if err := h.repo.SaveReading(ctx, reading); err != nil {
-		log.Error().Err(err).Msg("failed to save reading")
-		return nil, status.Error(codes.Internal, "failed to save reading")
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to save reading")
+		return nil, writeError(err, "failed to save reading")
 	}
 
 	return &pb.RecordReadingResponse{
-		Reading: convertReadingToProto(reading),
+		Reading: h.convertReadingToProto(reading),
+	}, nil
+}
+
+// RecordReadingsBatch manually records several readings in one transaction.
+// Every entry is validated before anything is stored.
+func (h *LightServiceHandler) RecordReadingsBatch(ctx context.Context, req *pb.RecordReadingsBatchRequest) (*pb.RecordReadingsBatchResponse, error) {
+	zerolog.Ctx(ctx).Info().
+		Int("count", len(req.Readings)).
+		Bool("import_mode", req.ImportMode).
+		Msg("RecordReadingsBatch called")
+
+	if len(req.Readings) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "batch must contain at least one reading")
+	}
+	if h.maxBatch > 0 && len(req.Readings) > h.maxBatch {
+		return nil, status.Errorf(codes.InvalidArgument, "batch of %d readings exceeds the limit of %d; split it across calls", len(req.Readings), h.maxBatch)
+	}
+
+	// Validate everything first so the client gets every problem at once
+	now := time.Now()
+	var readings []*domain.LightReading
+	var readingErrors []*pb.ReadingError
+	for i, r := range req.Readings {
+		reading, err := newTimestampedReading(r, now)
+		if err != nil {
+			zerolog.Ctx(ctx).Warn().Err(err).Int("index", i).Msg("invalid reading in batch")
+			readingErrors = append(readingErrors, &pb.ReadingError{
+				Index:  int32(i),
+				Field:  fieldForError(err),
+				Reason: err.Error(),
+			})
+			continue
+		}
+		if req.ImportMode {
+			reading.Source = domain.SourceImport
+		}
+		readings = append(readings, reading)
+	}
+
+	// Then persist the valid subset in one transaction
+	if len(readings) > 0 {
+		save := h.repo.SaveReadings
+		if req.ImportMode {
+			save = h.repo.UpsertReadings
+		}
+		if err := save(ctx, readings); err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Msg("failed to save readings")
+			return nil, writeError(err, "failed to save readings")
+		}
+	}
+
+	pbReadings := make([]*pb.LightReading, len(readings))
+	for i, r := range readings {
+		pbReadings[i] = h.convertReadingToProto(r)
+	}
+
+	return &pb.RecordReadingsBatchResponse{
+		Readings:   pbReadings,
+		Errors:     readingErrors,
+		SavedCount: int32(len(readings)),
 	}, nil
 }
 
+// GetReading returns a single stored reading by ID
+func (h *LightServiceHandler) GetReading(ctx context.Context, req *pb.GetReadingRequest) (*pb.GetReadingResponse, error) {
+	zerolog.Ctx(ctx).Info().Int64("id", req.Id).Msg("GetReading called")
+
+	reading, err := h.repo.GetReading(ctx, req.Id)
+	if err == domain.ErrReadingNotFound {
+		return nil, status.Errorf(codes.NotFound, "reading %d not found", req.Id)
+	} else if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to get reading")
+		return nil, status.Error(codes.Internal, "failed to get reading")
+	}
+
+	return &pb.GetReadingResponse{
+		Reading: h.convertReadingToProto(reading),
+	}, nil
+}
+
+// GetLightAsOf returns the reading that was current at the requested time
+func (h *LightServiceHandler) GetLightAsOf(ctx context.Context, req *pb.GetLightAsOfRequest) (*pb.GetLightAsOfResponse, error) {
+	zerolog.Ctx(ctx).Info().Int64("at_ms", req.AtMs).Msg("GetLightAsOf called")
+
+	reading, err := h.repo.GetReadingAsOf(ctx, time.UnixMilli(req.AtMs))
+	if errors.Is(err, domain.ErrReadingNotFound) {
+		return nil, status.Error(codes.NotFound, "no reading at or before that time")
+	}
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to get reading as of time")
+		return nil, status.Error(codes.Internal, "failed to get reading")
+	}
+
+	return &pb.GetLightAsOfResponse{
+		Reading: h.convertReadingToProto(reading),
+	}, nil
+}
+
+// GetCategoryEvents returns recorded light category transitions in a range
+func (h *LightServiceHandler) GetCategoryEvents(ctx context.Context, req *pb.GetCategoryEventsRequest) (*pb.GetCategoryEventsResponse, error) {
+	zerolog.Ctx(ctx).Info().
+		Int64("start", req.StartTime).
+		Int64("end", req.EndTime).
+		Msg("GetCategoryEvents called")
+
+	events, err := h.repo.GetCategoryEvents(ctx, time.Unix(req.StartTime, 0), time.Unix(req.EndTime, 0))
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to get category events")
+		return nil, status.Error(codes.Internal, "failed to get category events")
+	}
+
+	pbEvents := make([]*pb.CategoryEvent, len(events))
+	for i, e := range events {
+		pbEvents[i] = &pb.CategoryEvent{
+			Id:           e.ID,
+			FromCategory: h.eventLabel(e.From),
+			ToCategory:   h.eventLabel(e.To),
+			Lux:          e.Lux,
+			Timestamp:    e.Timestamp.Unix(),
+		}
+	}
+
+	return &pb.GetCategoryEventsResponse{
+		Events: pbEvents,
+	}, nil
+}
+
+// GetStorageStats reports the size of the reading store
+func (h *LightServiceHandler) GetStorageStats(ctx context.Context, req *pb.GetStorageStatsRequest) (*pb.StorageStatsResponse, error) {
+	zerolog.Ctx(ctx).Info().Msg("GetStorageStats called")
+
+	stats, err := h.repo.Stats(ctx)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to get storage stats")
+		return nil, status.Error(codes.Internal, "failed to get storage stats")
+	}
+
+	resp := &pb.StorageStatsResponse{
+		ReadingCount: stats.ReadingCount,
+		SizeBytes:    stats.SizeBytes,
+	}
+	if !stats.Oldest.IsZero() {
+		resp.OldestTimestampMs = stats.Oldest.UnixMilli()
+		resp.NewestTimestampMs = stats.Newest.UnixMilli()
+	}
+	return resp, nil
+}
+
+// GetRecent returns the latest readings in chronological order
+func (h *LightServiceHandler) GetRecent(ctx context.Context, req *pb.GetRecentRequest) (*pb.GetRecentResponse, error) {
+	zerolog.Ctx(ctx).Info().Int32("limit", req.Limit).Msg("GetRecent called")
+
+	if req.Limit <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit must be positive")
+	}
+
+	limit := min(int(req.Limit), h.maxRecent)
+
+	readings, err := h.repo.GetRecentReadings(ctx, limit)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to get recent readings")
+		return nil, status.Error(codes.Internal, "failed to get readings")
+	}
+
+	pbReadings := make([]*pb.LightReading, len(readings))
+	for i, r := range readings {
+		pbReadings[i] = h.convertReadingToProto(r)
+	}
+
+	return &pb.GetRecentResponse{
+		Readings: pbReadings,
+	}, nil
+}
+
+// PruneReadings deletes readings older than the requested retention on demand,
+// instead of waiting for the recorder's daily cleanup
+func (h *LightServiceHandler) PruneReadings(ctx context.Context, req *pb.PruneRequest) (*pb.PruneResponse, error) {
+	retention := time.Duration(req.RetentionSeconds) * time.Second
+	zerolog.Ctx(ctx).Info().Dur("retention", retention).Msg("PruneReadings called")
+
+	if retention < h.minRetention {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"retention %s is below the minimum of %s", retention, h.minRetention)
+	}
+
+	deleted, err := h.repo.DeleteOldReadings(ctx, retention)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to prune readings")
+		return nil, writeError(err, "failed to prune readings")
+	}
+
+	zerolog.Ctx(ctx).Info().Int64("deleted", deleted).Dur("retention", retention).Msg("pruned readings")
+
+	return &pb.PruneResponse{
+		DeletedCount: deleted,
+	}, nil
+}
+
+// Categorize labels a lux value the way a reading of it would be labelled,
+// without storing anything
+func (h *LightServiceHandler) Categorize(ctx context.Context, req *pb.CategorizeRequest) (*pb.CategorizeResponse, error) {
+	zerolog.Ctx(ctx).Debug().Float64("lux", req.Lux).Msg("Categorize called")
+
+	reading, err := domain.NewLightReading(req.Lux)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &pb.CategorizeResponse{
+		Category:      h.categoryLabel(reading),
+		IsLowLight:    reading.IsLowLight(),
+		IsMediumLight: reading.IsMediumLight(),
+		IsHighLight:   reading.IsHighLight(),
+	}, nil
+}
+
+// RecomputeCategories rebuilds the stored category transitions under the
+// handler's category scheme and hysteresis
+func (h *LightServiceHandler) RecomputeCategories(ctx context.Context, req *pb.RecomputeCategoriesRequest) (*pb.RecomputeCategoriesResponse, error) {
+	zerolog.Ctx(ctx).Info().Msg("RecomputeCategories called")
+
+	result, err := ports.RecomputeCategories(ctx, h.repo, h.eventScheme(), h.hysteresis)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to recompute categories")
+		return nil, writeError(err, "failed to recompute categories")
+	}
+
+	zerolog.Ctx(ctx).Info().
+		Int64("readings", result.ReadingsScanned).
+		Int("updated", result.EventsUpdated).
+		Msg("recomputed category events")
+
+	return &pb.RecomputeCategoriesResponse{
+		ReadingsScanned: result.ReadingsScanned,
+		EventsUpdated:   int64(result.EventsUpdated),
+		EventsTotal:     int64(result.EventsTotal),
+	}, nil
+}
+
+// maxClockSkew is how far into the future a submitted timestamp may be,
+// allowing for devices whose clocks run slightly fast
+const maxClockSkew = time.Minute
+
+// newTimestampedReading builds a reading from a client submission,
+// honouring its explicit timestamp if it has one, so devices that were
+// offline can submit readings late without losing when they were taken
+func newTimestampedReading(req *pb.RecordReadingRequest, now time.Time) (*domain.LightReading, error) {
+	reading, err := newManualReading(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.TimestampMs != nil {
+		reading.Timestamp = time.UnixMilli(*req.TimestampMs)
+	} else if req.Timestamp != nil {
+		reading.Timestamp = time.Unix(*req.Timestamp, 0)
+	}
+	if reading.Timestamp.After(now.Add(maxClockSkew)) {
+		return nil, domain.ErrFutureTimestamp
+	}
+
+	return reading, nil
+}
+
+// fieldForError names the RecordReadingRequest field a validation error
+// is about
+func fieldForError(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrInvalidLux), errors.Is(err, domain.ErrNonFiniteLux):
+		return "lux"
+	case errors.Is(err, domain.ErrInvalidTemperature):
+		return "temperature_celsius"
+	case errors.Is(err, domain.ErrFutureTimestamp):
+		return "timestamp_ms"
+	default:
+		return ""
+	}
+}
+
+// writeError maps a repository write failure to a gRPC status. Writes
+// refused by a read-only repository, or clashing with a stored reading's
+// timestamp, are the caller's problem, not ours.
+func writeError(err error, msg string) error {
+	switch {
+	case errors.Is(err, domain.ErrReadOnly):
+		return status.Error(codes.FailedPrecondition, "service is in read-only mode")
+	case errors.Is(err, domain.ErrDuplicateTimestamp):
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+	return status.Error(codes.Internal, msg)
+}
+
+// newManualReading validates a client-submitted reading
+func newManualReading(req *pb.RecordReadingRequest) (*domain.LightReading, error) {
+	reading, err := domain.NewLightReading(req.Lux)
+	if err != nil {
+		return nil, err
+	}
+	reading.Source = domain.SourceManual
+
+	if req.TemperatureCelsius != nil {
+		if err := reading.SetTemperature(*req.TemperatureCelsius); err != nil {
+			return nil, err
+		}
+	}
+
+	return reading, nil
+}
+
+// timeFromProto resolves a deprecated Unix-seconds field and its
+// millisecond replacement, preferring milliseconds when set
+func timeFromProto(seconds, millis int64) time.Time {
+	if millis != 0 {
+		return time.UnixMilli(millis)
+	}
+	return time.Unix(seconds, 0)
+}
+
 // convertReadingToProto converts domain model to protobuf
-func convertReadingToProto(r *domain.LightReading) *pb.LightReading {
+func (h *LightServiceHandler) convertReadingToProto(r *domain.LightReading) *pb.LightReading {
 	return &pb.LightReading{
-		Id:        r.ID,
-		Lux:       r.Lux,
-		Timestamp: r.Timestamp.Unix(),
-		Category:  r.LightCategory(),
+		Id:                 r.ID,
+		Lux:                r.Lux,
+		Timestamp:          r.Timestamp.Unix(),
+		TimestampMs:        r.Timestamp.UnixMilli(),
+		TimestampRfc3339:   r.Timestamp.UTC().Format(time.RFC3339Nano),
+		Category:           h.categoryLabel(r),
+		Source:             convertSourceToProto(r.Source),
+		TemperatureCelsius: r.TemperatureC,
+		Quality:            convertQualityToProto(r.Quality),
+	}
+}
+
+// eventScheme is the scheme category events are recorded in
+func (h *LightServiceHandler) eventScheme() *domain.CategoryScheme {
+	if h.scheme != nil {
+		return h.scheme
+	}
+	return domain.DefaultCategoryScheme
+}
+
+// eventLabel names an event's category. With a custom scheme, events are
+// recorded as its levels.
+func (h *LightServiceHandler) eventLabel(c domain.Category) string {
+	if h.scheme != nil {
+		return h.scheme.Label(c)
+	}
+	return h.labeler.Label(c)
+}
+
+// categoryLabel names the reading's category under the configured scheme,
+// falling back to the three-level labeler
+func (h *LightServiceHandler) categoryLabel(r *domain.LightReading) string {
+	if h.scheme != nil {
+		return r.LightCategoryIn(h.scheme)
+	}
+	return h.labeler.Label(r.Category())
+}
+
+// convertQualityToProto maps a domain quality to its protobuf enum; readings
+// stored before quality was recorded are reported as OK
+func convertQualityToProto(q domain.Quality) pb.ReadingQuality {
+	switch q {
+	case domain.QualityOK, "":
+		return pb.ReadingQuality_READING_QUALITY_OK
+	case domain.QualitySaturated:
+		return pb.ReadingQuality_READING_QUALITY_SATURATED
+	case domain.QualityLowConfidence:
+		return pb.ReadingQuality_READING_QUALITY_LOW_CONFIDENCE
+	}
+	return pb.ReadingQuality_READING_QUALITY_UNSPECIFIED
+}
+
+// convertQualityFromProto maps a protobuf quality enum to the domain
+// quality, treating UNSPECIFIED as OK
+func convertQualityFromProto(q pb.ReadingQuality) domain.Quality {
+	switch q {
+	case pb.ReadingQuality_READING_QUALITY_SATURATED:
+		return domain.QualitySaturated
+	case pb.ReadingQuality_READING_QUALITY_LOW_CONFIDENCE:
+		return domain.QualityLowConfidence
+	}
+	return domain.QualityOK
+}
+
+// convertSourceToProto maps a domain source to its protobuf enum
+func convertSourceToProto(s domain.Source) pb.ReadingSource {
+	switch s {
+	case domain.SourceSensor:
+		return pb.ReadingSource_READING_SOURCE_SENSOR
+	case domain.SourceManual:
+		return pb.ReadingSource_READING_SOURCE_MANUAL
+	case domain.SourceImport:
+		return pb.ReadingSource_READING_SOURCE_IMPORT
 	}
+	return pb.ReadingSource_READING_SOURCE_UNSPECIFIED
+}
+
+// convertSourceFromProto maps a protobuf source enum to the domain source
+func convertSourceFromProto(s pb.ReadingSource) domain.Source {
+	switch s {
+	case pb.ReadingSource_READING_SOURCE_SENSOR:
+		return domain.SourceSensor
+	case pb.ReadingSource_READING_SOURCE_MANUAL:
+		return domain.SourceManual
+	case pb.ReadingSource_READING_SOURCE_IMPORT:
+		return domain.SourceImport
+	}
+	return ""
+}
+
+// convertCategoryFilterFromProto maps a category filter to levels of scheme.
+// The LightCategory enum only names three levels, so it is refused under a
+// scheme with any other number of levels.
+func convertCategoryFilterFromProto(filter *pb.CategoryFilter, scheme *domain.CategoryScheme) ([]domain.Category, error) {
+	if len(filter.Categories) == 0 && len(filter.Levels) == 0 {
+		return nil, errors.New("category_filter needs at least one category or level")
+	}
+	if len(filter.Categories) > 0 && scheme.Levels() != 3 {
+		return nil, fmt.Errorf("category_filter categories name three levels, but the category scheme has %d; filter by levels instead", scheme.Levels())
+	}
+
+	result := make([]domain.Category, 0, len(filter.Categories)+len(filter.Levels))
+	for _, c := range filter.Categories {
+		switch c {
+		case pb.LightCategory_LIGHT_CATEGORY_LOW:
+			result = append(result, domain.CategoryLow)
+		case pb.LightCategory_LIGHT_CATEGORY_MEDIUM:
+			result = append(result, domain.CategoryMedium)
+		case pb.LightCategory_LIGHT_CATEGORY_HIGH:
+			result = append(result, domain.CategoryHigh)
+		default:
+			return nil, fmt.Errorf("unknown category %v", c)
+		}
+	}
+	for _, level := range filter.Levels {
+		if level < 0 || int(level) >= scheme.Levels() {
+			return nil, fmt.Errorf("level %d is outside the category scheme's 0-%d", level, scheme.Levels()-1)
+		}
+		result = append(result, domain.Category(level))
+	}
+	return result, nil
+}
+
+// filterBySource keeps only the readings produced by the given source
+func filterBySource(readings []*domain.LightReading, source domain.Source) []*domain.LightReading {
+	filtered := make([]*domain.LightReading, 0, len(readings))
+	for _, r := range readings {
+		if r.Source == source {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
 }
 
 // statistics holds calculated statistics
 type statistics struct {
+	count   int
 	average float64
 	min     float64
 	max     float64
 }
 
-// calculateStatistics computes stats for a set of readings
-func calculateStatistics(readings []*domain.LightReading) statistics {
-	if len(readings) == 0 {
-		return statistics{}
+// timeInCategory converts the scheme's TimeInCategory to the response
+// breakdown, darkest level first
+func (h *LightServiceHandler) timeInCategory(readings []*domain.LightReading, end time.Time) []*pb.CategoryDuration {
+	scheme := h.eventScheme()
+	durations := scheme.TimeInCategory(readings, end, h.maxGap)
+
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+
+	result := make([]*pb.CategoryDuration, scheme.Levels())
+	for i := range result {
+		c := domain.Category(i)
+		result[i] = &pb.CategoryDuration{
+			Category: h.eventLabel(c),
+			Seconds:  durations[c].Seconds(),
+		}
+		if total > 0 {
+			result[i].Fraction = float64(durations[c]) / float64(total)
+		}
 	}
+	return result
+}
+
+// maxPrecision is the most decimal places statistics can be rounded to;
+// beyond this float64 can't represent the result meaningfully anyway
+const maxPrecision = 10
 
+// rounded returns the statistics rounded to the given decimal places
+func (s statistics) rounded(places int) statistics {
+	return statistics{
+		count:   s.count,
+		average: roundTo(s.average, places),
+		min:     roundTo(s.min, places),
+		max:     roundTo(s.max, places),
+	}
+}
+
+// roundTo rounds v to the given decimal places, halves away from zero.
+// Scaling by 10^places can land just below a decimal half (2.675 * 100 is
+// 267.49999999999997), so the scaled value is first snapped to 9 decimals to
+// recover the half the caller actually wrote. Results that round to zero are
+// returned as 0 rather than -0.
+func roundTo(v float64, places int) float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return v
+	}
+
+	scale := math.Pow10(places)
+	scaled, err := strconv.ParseFloat(strconv.FormatFloat(v*scale, 'f', 9, 64), 64)
+	if err != nil {
+		return v
+	}
+
+	r := math.Round(scaled) / scale
+	if r == 0 {
+		return 0
+	}
+	return r
+}
+
+// sortedLux returns the finite lux values of readings in ascending order,
+// skipping non-finite ones as calculateStatistics does
+func sortedLux(readings []*domain.LightReading) []float64 {
+	values := make([]float64, 0, len(readings))
+	for _, r := range readings {
+		if !math.IsNaN(r.Lux) && !math.IsInf(r.Lux, 0) {
+			values = append(values, r.Lux)
+		}
+	}
+	slices.Sort(values)
+	return values
+}
+
+// calculateStatistics computes stats for a set of readings. Non-finite lux
+// values (which NewLightReading rejects, but which could still arrive from
+// storage written by older versions) are skipped so one bad row can't turn
+// every statistic into NaN.
+func calculateStatistics(readings []*domain.LightReading) statistics {
 	var sum float64
-	min := readings[0].Lux
-	max := readings[0].Lux
+	var count int
+	min := math.Inf(1)
+	max := math.Inf(-1)
 
 	for _, r := range readings {
+		if math.IsNaN(r.Lux) || math.IsInf(r.Lux, 0) {
+			continue
+		}
+
+		count++
 		sum += r.Lux
 		if r.Lux < min {
 			min = r.Lux
@@ -154,8 +1282,13 @@ func calculateStatistics(readings []*domain.LightReading) statistics {
 		}
 	}
 
+	if count == 0 {
+		return statistics{}
+	}
+
 	return statistics{
-		average: sum / float64(len(readings)),
+		count:   count,
+		average: sum / float64(count),
 		min:     min,
 		max:     max,
 	}
@@ -2,16 +2,27 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
 	"net"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 
 	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
 	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/mock"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/readonly"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/sqlite"
 	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/ports"
 	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
 )
 
@@ -19,10 +30,24 @@ import (
 // The server is stopped when the test ends.
 func startTestServer(t *testing.T) pb.LightServiceClient {
 	t.Helper()
+	return startTestServerWithRepo(t, memory.NewReadingRepository())
+}
+
+// startTestServerWithRepo is like startTestServer but serves from the given
+// repository, so tests can seed readings that don't go through RecordReading.
+func startTestServerWithRepo(t *testing.T, repo domain.ReadingRepository, opts ...HandlerOption) pb.LightServiceClient {
+	t.Helper()
 
-	repo := memory.NewReadingRepository()
 	sensor := mock.NewFakeSensor(500.0, 0) // deterministic: always 500 lux
-	handler := NewLightServiceHandler(repo, sensor)
+	return startTestServerWithSensor(t, repo, sensor, opts...)
+}
+
+// startTestServerWithSensor is like startTestServerWithRepo but also lets
+// the test choose the live sensor.
+func startTestServerWithSensor(t *testing.T, repo domain.ReadingRepository, sensor ports.LightSensor, opts ...HandlerOption) pb.LightServiceClient {
+	t.Helper()
+
+	handler := NewLightServiceHandler(repo, sensor, opts...)
 
 	lis, err := net.Listen("tcp", "127.0.0.1:0")
 	if err != nil {
@@ -195,5 +220,1814 @@ func TestRecordReading_InvalidLux(t *testing.T) {
 	}
 }
 
+func TestRecordReading_SourceIsManual(t *testing.T) {
+	client := startTestServer(t)
+	ctx := context.Background()
+
+	resp, err := client.RecordReading(ctx, &pb.RecordReadingRequest{Lux: 250.0})
+	if err != nil {
+		t.Fatalf("RecordReading failed: %v", err)
+	}
+	if resp.Reading.Source != pb.ReadingSource_READING_SOURCE_MANUAL {
+		t.Errorf("expected source MANUAL, got %v", resp.Reading.Source)
+	}
+}
+
+func TestRecordReading_ExplicitTimestamp(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	client := startTestServerWithRepo(t, repo)
+	ctx := context.Background()
+
+	// A device back online after two hours submits its backlog
+	taken := time.Now().Add(-2 * time.Hour).Truncate(time.Millisecond)
+	takenMs := taken.UnixMilli()
+	resp, err := client.RecordReading(ctx, &pb.RecordReadingRequest{Lux: 250, TimestampMs: &takenMs})
+	if err != nil {
+		t.Fatalf("RecordReading failed: %v", err)
+	}
+	if resp.Reading.TimestampMs != takenMs {
+		t.Errorf("expected timestamp %d, got %d", takenMs, resp.Reading.TimestampMs)
+	}
+	stored, err := repo.GetReading(ctx, resp.Reading.Id)
+	if err != nil || !stored.Timestamp.Equal(taken) {
+		t.Errorf("expected the reading stored at %v, got %v, %v", taken, stored, err)
+	}
+
+	// A fast clock is tolerated, a timestamp well in the future is not
+	slightlyAhead := time.Now().Add(10 * time.Second).UnixMilli()
+	if _, err := client.RecordReading(ctx, &pb.RecordReadingRequest{Lux: 250, TimestampMs: &slightlyAhead}); err != nil {
+		t.Errorf("expected a slightly fast clock accepted, got %v", err)
+	}
+	future := time.Now().Add(time.Hour).UnixMilli()
+	_, err = client.RecordReading(ctx, &pb.RecordReadingRequest{Lux: 250, TimestampMs: &future})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for a future timestamp, got %v", err)
+	}
+}
+
+func TestGetCurrentLight_SensorFallbackSourceIsSensor(t *testing.T) {
+	client := startTestServer(t)
+	ctx := context.Background()
+
+	resp, err := client.GetCurrentLight(ctx, &pb.GetCurrentLightRequest{})
+	if err != nil {
+		t.Fatalf("GetCurrentLight failed: %v", err)
+	}
+	if resp.Reading.Source != pb.ReadingSource_READING_SOURCE_SENSOR {
+		t.Errorf("expected source SENSOR, got %v", resp.Reading.Source)
+	}
+}
+
+func TestGetHistory_FilterBySource(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	client := startTestServerWithRepo(t, repo)
+	ctx := context.Background()
+
+	now := time.Now()
+
+	// One recorder-style reading straight into the repo, one manual via the RPC
+	sensorReading, _ := domain.NewLightReading(800.0)
+	if err := repo.SaveReading(ctx, sensorReading); err != nil {
+		t.Fatalf("SaveReading failed: %v", err)
+	}
+	if _, err := client.RecordReading(ctx, &pb.RecordReadingRequest{Lux: 100.0}); err != nil {
+		t.Fatalf("RecordReading failed: %v", err)
+	}
+
+	start := now.Add(-time.Minute).Unix()
+	end := now.Add(time.Minute).Unix()
+
+	cases := []struct {
+		source  pb.ReadingSource
+		wantLux []float64
+	}{
+		{pb.ReadingSource_READING_SOURCE_UNSPECIFIED, []float64{800.0, 100.0}},
+		{pb.ReadingSource_READING_SOURCE_SENSOR, []float64{800.0}},
+		{pb.ReadingSource_READING_SOURCE_MANUAL, []float64{100.0}},
+		{pb.ReadingSource_READING_SOURCE_IMPORT, nil},
+	}
+
+	for _, tc := range cases {
+		resp, err := client.GetHistory(ctx, &pb.GetHistoryRequest{
+			StartTime: start,
+			EndTime:   end,
+			Source:    tc.source,
+		})
+		if err != nil {
+			t.Fatalf("GetHistory(%v) failed: %v", tc.source, err)
+		}
+		if len(resp.Readings) != len(tc.wantLux) {
+			t.Fatalf("source %v: expected %d readings, got %d", tc.source, len(tc.wantLux), len(resp.Readings))
+		}
+		for i, want := range tc.wantLux {
+			if resp.Readings[i].Lux != want {
+				t.Errorf("source %v: reading %d expected lux %v, got %v", tc.source, i, want, resp.Readings[i].Lux)
+			}
+		}
+	}
+}
+
+func TestRecordReading_CustomCategoryLabels(t *testing.T) {
+	labels := domain.CategoryLabels{
+		domain.CategoryLow:    "Poca luz",
+		domain.CategoryMedium: "Luz media",
+		domain.CategoryHigh:   "Mucha luz",
+	}
+	client := startTestServerWithRepo(t, memory.NewReadingRepository(), WithCategoryLabeler(labels))
+	ctx := context.Background()
+
+	cases := []struct {
+		lux      float64
+		category string
+	}{
+		{50.0, "Poca luz"},
+		{1000.0, "Luz media"},
+		{3000.0, "Mucha luz"},
+	}
+
+	for _, tc := range cases {
+		resp, err := client.RecordReading(ctx, &pb.RecordReadingRequest{Lux: tc.lux})
+		if err != nil {
+			t.Fatalf("RecordReading(%.0f) failed: %v", tc.lux, err)
+		}
+		if resp.Reading.Category != tc.category {
+			t.Errorf("lux %.0f: expected category %q, got %q", tc.lux, tc.category, resp.Reading.Category)
+		}
+	}
+}
+
+// newSQLiteRepo opens a throwaway SQLite repository so round-trip tests
+// exercise real persistence rather than shared in-memory pointers
+func newSQLiteRepo(t *testing.T) *sqlite.ReadingRepository {
+	t.Helper()
+	repo, err := sqlite.NewReadingRepository(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("failed to create SQLite repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return repo
+}
+
+func TestRecordReading_ResponseMatchesPersistedReading(t *testing.T) {
+	client := startTestServerWithRepo(t, newSQLiteRepo(t))
+	ctx := context.Background()
+
+	recorded, err := client.RecordReading(ctx, &pb.RecordReadingRequest{Lux: 750.0})
+	if err != nil {
+		t.Fatalf("RecordReading failed: %v", err)
+	}
+	if recorded.Reading.Id == 0 {
+		t.Fatal("expected server-assigned id")
+	}
+	if recorded.Reading.TimestampRfc3339 == "" {
+		t.Fatal("expected RFC 3339 timestamp to be set")
+	}
+
+	got, err := client.GetReading(ctx, &pb.GetReadingRequest{Id: recorded.Reading.Id})
+	if err != nil {
+		t.Fatalf("GetReading failed: %v", err)
+	}
+	assertSameReading(t, recorded.Reading, got.Reading)
+
+	// Both timestamp representations must describe the same instant
+	parsed, err := time.Parse(time.RFC3339Nano, got.Reading.TimestampRfc3339)
+	if err != nil {
+		t.Fatalf("TimestampRfc3339 %q is not RFC 3339: %v", got.Reading.TimestampRfc3339, err)
+	}
+	if parsed.Unix() != got.Reading.Timestamp {
+		t.Errorf("RFC 3339 timestamp %v disagrees with unix timestamp %d", parsed, got.Reading.Timestamp)
+	}
+}
+
+func TestRecordReadingsBatch_ResponseMatchesPersistedReadings(t *testing.T) {
+	client := startTestServerWithRepo(t, newSQLiteRepo(t))
+	ctx := context.Background()
+
+	resp, err := client.RecordReadingsBatch(ctx, &pb.RecordReadingsBatchRequest{
+		Readings: []*pb.RecordReadingRequest{{Lux: 50.0}, {Lux: 600.0}, {Lux: 4000.0}},
+	})
+	if err != nil {
+		t.Fatalf("RecordReadingsBatch failed: %v", err)
+	}
+	if len(resp.Readings) != 3 {
+		t.Fatalf("expected 3 readings, got %d", len(resp.Readings))
+	}
+
+	for _, recorded := range resp.Readings {
+		got, err := client.GetReading(ctx, &pb.GetReadingRequest{Id: recorded.Id})
+		if err != nil {
+			t.Fatalf("GetReading(%d) failed: %v", recorded.Id, err)
+		}
+		assertSameReading(t, recorded, got.Reading)
+	}
+}
+
+func TestRecordReadingsBatch_OverLimit(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	client := startTestServerWithRepo(t, repo, WithMaxBatchSize(2))
+	ctx := context.Background()
+
+	readings := []*pb.RecordReadingRequest{{Lux: 100}, {Lux: 200}, {Lux: 300}}
+	_, err := client.RecordReadingsBatch(ctx, &pb.RecordReadingsBatchRequest{Readings: readings})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument over the limit, got %v", err)
+	}
+	if recent, _ := repo.GetRecentReadings(ctx, 10); len(recent) != 0 {
+		t.Errorf("expected nothing saved from a rejected batch, got %d", len(recent))
+	}
+
+	resp, err := client.RecordReadingsBatch(ctx, &pb.RecordReadingsBatchRequest{Readings: readings[:2]})
+	if err != nil || resp.SavedCount != 2 {
+		t.Errorf("expected a batch at the limit saved, got %v, %v", resp, err)
+	}
+}
+
+func TestRecordReadingsBatch_PartialFailure(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	client := startTestServerWithRepo(t, repo)
+	ctx := context.Background()
+
+	future := time.Now().Add(time.Hour).UnixMilli()
+	badTemp := -300.0
+	resp, err := client.RecordReadingsBatch(ctx, &pb.RecordReadingsBatchRequest{
+		Readings: []*pb.RecordReadingRequest{
+			{Lux: 100.0},
+			{Lux: -5.0},
+			{Lux: math.NaN()},
+			{Lux: 200.0},
+			{Lux: 300.0, TimestampMs: &future},
+			{Lux: 400.0, TemperatureCelsius: &badTemp},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RecordReadingsBatch failed: %v", err)
+	}
+
+	if resp.SavedCount != 2 {
+		t.Errorf("expected 2 saved, got %d", resp.SavedCount)
+	}
+	if len(resp.Readings) != 2 || resp.Readings[0].Lux != 100 || resp.Readings[1].Lux != 200 {
+		t.Errorf("expected the 100 and 200 lux readings back, got %v", resp.Readings)
+	}
+
+	want := []*pb.ReadingError{
+		{Index: 1, Field: "lux", Reason: domain.ErrInvalidLux.Error()},
+		{Index: 2, Field: "lux", Reason: domain.ErrNonFiniteLux.Error()},
+		{Index: 4, Field: "timestamp_ms", Reason: domain.ErrFutureTimestamp.Error()},
+		{Index: 5, Field: "temperature_celsius", Reason: domain.ErrInvalidTemperature.Error()},
+	}
+	if len(resp.Errors) != len(want) {
+		t.Fatalf("expected %d errors, got %d: %v", len(want), len(resp.Errors), resp.Errors)
+	}
+	for i, w := range want {
+		got := resp.Errors[i]
+		if got.Index != w.Index || got.Field != w.Field || got.Reason != w.Reason {
+			t.Errorf("error %d: expected {%d %q %q}, got {%d %q %q}",
+				i, w.Index, w.Field, w.Reason, got.Index, got.Field, got.Reason)
+		}
+	}
+
+	all, _ := repo.GetReadingsInRange(ctx, time.Now().Add(-time.Hour), time.Now().Add(2*time.Hour))
+	if len(all) != 2 {
+		t.Errorf("expected only the 2 valid readings stored, got %d", len(all))
+	}
+}
+
+func TestGetReading_NotFound(t *testing.T) {
+	client := startTestServer(t)
+
+	_, err := client.GetReading(context.Background(), &pb.GetReadingRequest{Id: 42})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
+
+// assertSameReading fails the test if two protobuf readings differ in any persisted field
+func assertSameReading(t *testing.T, want, got *pb.LightReading) {
+	t.Helper()
+	if got.Id != want.Id {
+		t.Errorf("id: got %d, want %d", got.Id, want.Id)
+	}
+	if got.Lux != want.Lux {
+		t.Errorf("reading %d lux: got %v, want %v", want.Id, got.Lux, want.Lux)
+	}
+	if got.Timestamp != want.Timestamp {
+		t.Errorf("reading %d timestamp: got %d, want %d", want.Id, got.Timestamp, want.Timestamp)
+	}
+	if got.TimestampRfc3339 != want.TimestampRfc3339 {
+		t.Errorf("reading %d RFC 3339 timestamp: got %q, want %q", want.Id, got.TimestampRfc3339, want.TimestampRfc3339)
+	}
+	if got.Category != want.Category {
+		t.Errorf("reading %d category: got %q, want %q", want.Id, got.Category, want.Category)
+	}
+	if got.Source != want.Source {
+		t.Errorf("reading %d source: got %v, want %v", want.Id, got.Source, want.Source)
+	}
+}
+
 // Verify domain.ErrReadingNotFound is never silently swallowed in the test helper
 var _ = domain.ErrReadingNotFound
+
+func TestPruneReadings_ReturnsDeletedCount(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	client := startTestServerWithRepo(t, repo)
+	ctx := context.Background()
+
+	now := time.Now()
+	for _, age := range []time.Duration{72 * time.Hour, 60 * time.Hour, time.Hour} {
+		r, _ := domain.NewLightReading(300)
+		r.Timestamp = now.Add(-age)
+		_ = repo.SaveReading(ctx, r)
+	}
+
+	resp, err := client.PruneReadings(ctx, &pb.PruneRequest{
+		RetentionSeconds: int64((48 * time.Hour).Seconds()),
+	})
+	if err != nil {
+		t.Fatalf("PruneReadings failed: %v", err)
+	}
+	if resp.DeletedCount != 2 {
+		t.Errorf("expected 2 deleted readings, got %d", resp.DeletedCount)
+	}
+
+	latest, err := repo.GetLatestReading(ctx)
+	if err != nil {
+		t.Fatalf("expected the recent reading to remain, got %v", err)
+	}
+	if now.Sub(latest.Timestamp) > 2*time.Hour {
+		t.Errorf("unexpected remaining reading at %v", latest.Timestamp)
+	}
+}
+
+func TestPruneReadings_MinimumRetentionGuard(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	client := startTestServerWithRepo(t, repo, WithMinPruneRetention(7*24*time.Hour))
+	ctx := context.Background()
+
+	r, _ := domain.NewLightReading(300)
+	r.Timestamp = time.Now().Add(-72 * time.Hour)
+	_ = repo.SaveReading(ctx, r)
+
+	_, err := client.PruneReadings(ctx, &pb.PruneRequest{
+		RetentionSeconds: int64((48 * time.Hour).Seconds()),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument below minimum retention, got %v", err)
+	}
+
+	// Nothing should have been deleted
+	if _, err := repo.GetReading(ctx, r.ID); err != nil {
+		t.Errorf("expected reading to survive rejected prune, got %v", err)
+	}
+
+	// Exactly the minimum is allowed
+	resp, err := client.PruneReadings(ctx, &pb.PruneRequest{
+		RetentionSeconds: int64((7 * 24 * time.Hour).Seconds()),
+	})
+	if err != nil {
+		t.Fatalf("PruneReadings at minimum retention failed: %v", err)
+	}
+	if resp.DeletedCount != 0 {
+		t.Errorf("expected 0 deleted readings, got %d", resp.DeletedCount)
+	}
+}
+
+func TestRecordReading_OptionalTemperature(t *testing.T) {
+	client := startTestServerWithRepo(t, newSQLiteRepo(t))
+	ctx := context.Background()
+
+	temp := 23.5
+	withTemp, err := client.RecordReading(ctx, &pb.RecordReadingRequest{Lux: 400.0, TemperatureCelsius: &temp})
+	if err != nil {
+		t.Fatalf("RecordReading failed: %v", err)
+	}
+	withoutTemp, err := client.RecordReading(ctx, &pb.RecordReadingRequest{Lux: 400.0})
+	if err != nil {
+		t.Fatalf("RecordReading failed: %v", err)
+	}
+
+	got, err := client.GetReading(ctx, &pb.GetReadingRequest{Id: withTemp.Reading.Id})
+	if err != nil {
+		t.Fatalf("GetReading failed: %v", err)
+	}
+	if got.Reading.TemperatureCelsius == nil || *got.Reading.TemperatureCelsius != temp {
+		t.Errorf("expected temperature %v, got %v", temp, got.Reading.TemperatureCelsius)
+	}
+
+	got, err = client.GetReading(ctx, &pb.GetReadingRequest{Id: withoutTemp.Reading.Id})
+	if err != nil {
+		t.Fatalf("GetReading failed: %v", err)
+	}
+	if got.Reading.TemperatureCelsius != nil {
+		t.Errorf("expected no temperature, got %v", *got.Reading.TemperatureCelsius)
+	}
+}
+
+func TestRecordReading_InvalidTemperature(t *testing.T) {
+	client := startTestServer(t)
+
+	temp := -500.0
+	_, err := client.RecordReading(context.Background(), &pb.RecordReadingRequest{Lux: 400.0, TemperatureCelsius: &temp})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestGetRecent_ChronologicalOrder(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	client := startTestServerWithRepo(t, repo)
+	ctx := context.Background()
+
+	now := time.Now()
+	for i := 0; i < 30; i++ {
+		r, _ := domain.NewLightReading(float64(i))
+		r.Timestamp = now.Add(time.Duration(i-30) * time.Minute)
+		_ = repo.SaveReading(ctx, r)
+	}
+
+	resp, err := client.GetRecent(ctx, &pb.GetRecentRequest{Limit: 20})
+	if err != nil {
+		t.Fatalf("GetRecent failed: %v", err)
+	}
+	if len(resp.Readings) != 20 {
+		t.Fatalf("expected 20 readings, got %d", len(resp.Readings))
+	}
+
+	// The 20 newest are lux 10..29, ascending
+	for i, r := range resp.Readings {
+		if r.Lux != float64(10+i) {
+			t.Errorf("reading %d: expected lux %d, got %v", i, 10+i, r.Lux)
+		}
+		if i > 0 && r.Timestamp < resp.Readings[i-1].Timestamp {
+			t.Errorf("reading %d is older than the one before it", i)
+		}
+	}
+}
+
+func TestGetRecent_LimitEnforcement(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	client := startTestServerWithRepo(t, repo, WithMaxRecentLimit(5))
+	ctx := context.Background()
+
+	for i := 0; i < 10; i++ {
+		r, _ := domain.NewLightReading(float64(i))
+		r.Timestamp = time.Now().Add(time.Duration(i) * time.Second)
+		_ = repo.SaveReading(ctx, r)
+	}
+
+	resp, err := client.GetRecent(ctx, &pb.GetRecentRequest{Limit: 100})
+	if err != nil {
+		t.Fatalf("GetRecent failed: %v", err)
+	}
+	if len(resp.Readings) != 5 {
+		t.Errorf("expected limit capped to 5, got %d readings", len(resp.Readings))
+	}
+
+	_, err = client.GetRecent(ctx, &pb.GetRecentRequest{Limit: 0})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for zero limit, got %v", err)
+	}
+}
+
+func TestRecordReadingsBatch_ImportModeUpserts(t *testing.T) {
+	repo := newSQLiteRepo(t)
+	client := startTestServerWithRepo(t, repo)
+	ctx := context.Background()
+
+	base := time.Now().Add(-2 * time.Hour).Unix()
+	batch := func(lux float64) *pb.RecordReadingsBatchRequest {
+		req := &pb.RecordReadingsBatchRequest{ImportMode: true}
+		for i := int64(0); i < 3; i++ {
+			ts := base + i*60
+			req.Readings = append(req.Readings, &pb.RecordReadingRequest{Lux: lux, Timestamp: &ts})
+		}
+		return req
+	}
+
+	if _, err := client.RecordReadingsBatch(ctx, batch(100)); err != nil {
+		t.Fatalf("first import failed: %v", err)
+	}
+	resp, err := client.RecordReadingsBatch(ctx, batch(250))
+	if err != nil {
+		t.Fatalf("re-import failed: %v", err)
+	}
+	for _, r := range resp.Readings {
+		if r.Source != pb.ReadingSource_READING_SOURCE_IMPORT {
+			t.Errorf("expected IMPORT source, got %v", r.Source)
+		}
+	}
+
+	history, err := client.GetHistory(ctx, &pb.GetHistoryRequest{StartTime: base - 60, EndTime: base + 600})
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(history.Readings) != 3 {
+		t.Fatalf("expected re-import to leave 3 readings, got %d", len(history.Readings))
+	}
+	for _, r := range history.Readings {
+		if r.Lux != 250 {
+			t.Errorf("expected re-imported lux 250, got %v", r.Lux)
+		}
+	}
+}
+
+func TestRecordReading_NonFiniteLux(t *testing.T) {
+	client := startTestServer(t)
+	ctx := context.Background()
+
+	for _, lux := range []float64{math.NaN(), math.Inf(1)} {
+		_, err := client.RecordReading(ctx, &pb.RecordReadingRequest{Lux: lux})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("lux %v: expected InvalidArgument, got %v", lux, err)
+		}
+	}
+}
+
+func TestCalculateStatistics_IgnoresNonFinite(t *testing.T) {
+	readings := []*domain.LightReading{
+		{Lux: 100},
+		{Lux: math.NaN()},
+		{Lux: 300},
+		{Lux: math.Inf(1)},
+		{Lux: math.Inf(-1)},
+	}
+
+	stats := calculateStatistics(readings)
+
+	if stats.average != 200 {
+		t.Errorf("expected average 200, got %v", stats.average)
+	}
+	if stats.min != 100 || stats.max != 300 {
+		t.Errorf("expected min 100 / max 300, got %v / %v", stats.min, stats.max)
+	}
+
+	if got := calculateStatistics([]*domain.LightReading{{Lux: math.NaN()}}); got != (statistics{}) {
+		t.Errorf("expected zero statistics when no finite readings, got %+v", got)
+	}
+}
+
+func TestRoundTo(t *testing.T) {
+	tests := []struct {
+		name   string
+		v      float64
+		places int
+		want   float64
+	}{
+		{"float noise", 449.99999999999994, 2, 450},
+		{"plain", 123.456, 1, 123.5},
+		{"zero places", 123.456, 0, 123},
+		{"exact half rounds up", 0.125, 2, 0.13},
+		{"binary-inexact half", 2.675, 2, 2.68},
+		{"half at zero places", 2.5, 0, 3},
+		{"negative half away from zero", -2.5, 0, -3},
+		{"negative-looking zero", -0.004, 2, 0},
+		{"already rounded", 500, 2, 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := roundTo(tt.v, tt.places)
+			if got != tt.want {
+				t.Errorf("roundTo(%v, %d) = %v, want %v", tt.v, tt.places, got, tt.want)
+			}
+			if got == 0 && math.Signbit(got) {
+				t.Errorf("roundTo(%v, %d) returned negative zero", tt.v, tt.places)
+			}
+		})
+	}
+}
+
+func TestGetHistory_Precision(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	client := startTestServerWithRepo(t, repo)
+	ctx := context.Background()
+
+	now := time.Now()
+	luxes := []float64{100.1, 200.2, 300.3}
+	for i, lux := range luxes {
+		r, _ := domain.NewLightReading(lux)
+		r.Timestamp = now.Add(time.Duration(i-5) * time.Minute)
+		_ = repo.SaveReading(ctx, r)
+	}
+	req := &pb.GetHistoryRequest{StartTime: now.Add(-time.Hour).Unix(), EndTime: now.Add(time.Hour).Unix()}
+
+	// Default is unrounded
+	resp, err := client.GetHistory(ctx, req)
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if want := (luxes[0] + luxes[1] + luxes[2]) / 3; resp.AverageLux != want {
+		t.Errorf("expected unrounded average %v, got %v", want, resp.AverageLux)
+	}
+
+	precision := int32(0)
+	req.Precision = &precision
+	resp, err = client.GetHistory(ctx, req)
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if resp.AverageLux != 200 || resp.MinLux != 100 || resp.MaxLux != 300 {
+		t.Errorf("expected 200/100/300, got %v/%v/%v", resp.AverageLux, resp.MinLux, resp.MaxLux)
+	}
+
+	precision = -1
+	if _, err := client.GetHistory(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for negative precision, got %v", err)
+	}
+}
+
+func TestGetHistory_Percentiles(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	client := startTestServerWithRepo(t, repo)
+	ctx := context.Background()
+
+	now := time.Now()
+	for i, lux := range []float64{40, 10, 50, 30, 20} {
+		r, _ := domain.NewLightReading(lux)
+		r.Timestamp = now.Add(time.Duration(i-5) * time.Minute)
+		_ = repo.SaveReading(ctx, r)
+	}
+	inRange := [2]time.Time{now.Add(-time.Hour), now.Add(time.Hour)}
+	empty := [2]time.Time{now.Add(-3 * time.Hour), now.Add(-2 * time.Hour)}
+
+	tests := []struct {
+		name       string
+		span       [2]time.Time
+		percentile float64
+		want       float64
+	}{
+		{"p0 is min", inRange, 0, 10},
+		{"p10 interpolates", inRange, 10, 14},
+		{"p25", inRange, 25, 20},
+		{"p50 is median", inRange, 50, 30},
+		{"p75", inRange, 75, 40},
+		{"p95 interpolates", inRange, 95, 48},
+		{"p100 is max", inRange, 100, 50},
+		{"empty range", empty, 95, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.GetHistory(ctx, &pb.GetHistoryRequest{
+				StartTime:   tt.span[0].Unix(),
+				EndTime:     tt.span[1].Unix(),
+				Percentiles: []float64{tt.percentile},
+			})
+			if err != nil {
+				t.Fatalf("GetHistory failed: %v", err)
+			}
+			if len(resp.Percentiles) != 1 {
+				t.Fatalf("expected 1 percentile, got %d", len(resp.Percentiles))
+			}
+			got := resp.Percentiles[0]
+			if got.Percentile != tt.percentile || math.Abs(got.Lux-tt.want) > 1e-9 {
+				t.Errorf("expected p%v = %v, got p%v = %v", tt.percentile, tt.want, got.Percentile, got.Lux)
+			}
+		})
+	}
+
+	for _, p := range []float64{-1, 100.5, math.NaN()} {
+		req := &pb.GetHistoryRequest{
+			StartTime:   inRange[0].Unix(),
+			EndTime:     inRange[1].Unix(),
+			Percentiles: []float64{50, p},
+		}
+		if _, err := client.GetHistory(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected InvalidArgument for percentile %v, got %v", p, err)
+		}
+	}
+}
+
+func TestGetHistory_Limits(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	client := startTestServerWithRepo(t, repo,
+		WithMaxHistorySpan(24*time.Hour),
+		WithMaxHistoryReadings(3),
+	)
+	ctx := context.Background()
+
+	now := time.Now()
+	for i := range 4 {
+		r, _ := domain.NewLightReading(float64(100 * (i + 1)))
+		r.Timestamp = now.Add(time.Duration(i-5) * time.Minute)
+		_ = repo.SaveReading(ctx, r)
+	}
+
+	_, err := client.GetHistory(ctx, &pb.GetHistoryRequest{
+		StartTime: now.Add(-48 * time.Hour).Unix(),
+		EndTime:   now.Unix(),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for a 48h range, got %v", err)
+	}
+
+	_, err = client.GetHistory(ctx, &pb.GetHistoryRequest{
+		StartTime: now.Add(-time.Hour).Unix(),
+		EndTime:   now.Unix(),
+	})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected ResourceExhausted for 4 readings, got %v", err)
+	}
+
+	// Within both limits
+	resp, err := client.GetHistory(ctx, &pb.GetHistoryRequest{
+		StartTime: now.Add(-time.Hour).Unix(),
+		EndTime:   now.Add(-150 * time.Second).Unix(),
+	})
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(resp.Readings) != 3 {
+		t.Errorf("expected 3 readings, got %d", len(resp.Readings))
+	}
+
+	// Statistics alone are not capped
+	resp, err = client.GetHistory(ctx, &pb.GetHistoryRequest{
+		StartTime: now.Add(-time.Hour).Unix(),
+		EndTime:   now.Unix(),
+		StatsOnly: true,
+	})
+	if err != nil {
+		t.Fatalf("stats-only GetHistory failed: %v", err)
+	}
+	if len(resp.Readings) != 0 || resp.AverageLux != 250 || resp.MaxLux != 400 {
+		t.Errorf("expected no readings and average 250, max 400; got %d readings, %v, %v",
+			len(resp.Readings), resp.AverageLux, resp.MaxLux)
+	}
+}
+
+// chunkRecordingRepo records the size of the largest range read, to show a
+// handler never loads more of a range than it asked for
+type chunkRecordingRepo struct {
+	*memory.ReadingRepository
+	largest int
+}
+
+func (r *chunkRecordingRepo) GetReadingsInRange(ctx context.Context, start, end time.Time, opts ...domain.RangeOption) ([]*domain.LightReading, error) {
+	readings, err := r.ReadingRepository.GetReadingsInRange(ctx, start, end, opts...)
+	r.largest = max(r.largest, len(readings))
+	return readings, err
+}
+
+func TestGetHistory_LimitsCountAfterSourceFilter(t *testing.T) {
+	repo := &chunkRecordingRepo{ReadingRepository: memory.NewReadingRepository()}
+	client := startTestServerWithRepo(t, repo, WithMaxHistoryReadings(3))
+	ctx := context.Background()
+
+	// Three manual readings among many sensor ones
+	now := time.Now()
+	for i := range 20 {
+		r, _ := domain.NewLightReading(float64(100 * (i + 1)))
+		r.Timestamp = now.Add(time.Duration(i-30) * time.Minute)
+		if i%7 == 0 {
+			r.Source = domain.SourceManual
+		}
+		if err := repo.SaveReading(ctx, r); err != nil {
+			t.Fatalf("SaveReading failed: %v", err)
+		}
+	}
+
+	resp, err := client.GetHistory(ctx, &pb.GetHistoryRequest{
+		StartTime: now.Add(-time.Hour).Unix(),
+		EndTime:   now.Unix(),
+		Source:    pb.ReadingSource_READING_SOURCE_MANUAL,
+	})
+	if err != nil {
+		t.Fatalf("expected the 3 manual readings to fit the limit, got %v", err)
+	}
+	if len(resp.Readings) != 3 {
+		t.Errorf("expected 3 readings, got %d", len(resp.Readings))
+	}
+	if repo.largest > 4 {
+		t.Errorf("expected reads of at most 4 readings, got one of %d", repo.largest)
+	}
+
+	repo.largest = 0
+	_, err = client.GetHistory(ctx, &pb.GetHistoryRequest{
+		StartTime: now.Add(-time.Hour).Unix(),
+		EndTime:   now.Unix(),
+		Source:    pb.ReadingSource_READING_SOURCE_SENSOR,
+	})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected ResourceExhausted for 17 sensor readings, got %v", err)
+	}
+	if repo.largest > 4 {
+		t.Errorf("expected reads of at most 4 readings, got one of %d", repo.largest)
+	}
+}
+
+func TestGetHistory_Pagination(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	client := startTestServerWithRepo(t, repo)
+	ctx := context.Background()
+
+	now := time.Now()
+	for i := range 5 {
+		r, _ := domain.NewLightReading(float64(100 * (i + 1)))
+		r.Timestamp = now.Add(time.Duration(i-10) * time.Minute)
+		_ = repo.SaveReading(ctx, r)
+	}
+
+	pages := func(order pb.SortOrder) [][]float64 {
+		t.Helper()
+		var result [][]float64
+		token := ""
+		for {
+			resp, err := client.GetHistory(ctx, &pb.GetHistoryRequest{
+				StartTimeMs: now.Add(-time.Hour).UnixMilli(),
+				EndTimeMs:   now.UnixMilli(),
+				Limit:       2,
+				PageToken:   token,
+				Order:       order,
+			})
+			if err != nil {
+				t.Fatalf("GetHistory failed: %v", err)
+			}
+			var page []float64
+			for _, r := range resp.Readings {
+				page = append(page, r.Lux)
+			}
+			result = append(result, page)
+			if resp.NextPageToken == "" {
+				return result
+			}
+			if len(resp.TimeInCategory) != 0 {
+				t.Error("expected time_in_category to be left empty for a page")
+			}
+			token = resp.NextPageToken
+		}
+	}
+
+	asc := fmt.Sprint(pages(pb.SortOrder_SORT_ORDER_UNSPECIFIED))
+	if want := "[[100 200] [300 400] [500]]"; asc != want {
+		t.Errorf("ascending pages: got %s, want %s", asc, want)
+	}
+	desc := fmt.Sprint(pages(pb.SortOrder_SORT_ORDER_DESCENDING))
+	if want := "[[500 400] [300 200] [100]]"; desc != want {
+		t.Errorf("descending pages: got %s, want %s", desc, want)
+	}
+
+	// A token only resumes the order it was issued for
+	first, err := client.GetHistory(ctx, &pb.GetHistoryRequest{
+		StartTimeMs: now.Add(-time.Hour).UnixMilli(),
+		EndTimeMs:   now.UnixMilli(),
+		Limit:       2,
+	})
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	for _, req := range []*pb.GetHistoryRequest{
+		{PageToken: first.NextPageToken, Order: pb.SortOrder_SORT_ORDER_DESCENDING},
+		{PageToken: "not-a-token"},
+		{Limit: -1},
+	} {
+		req.StartTimeMs, req.EndTimeMs = now.Add(-time.Hour).UnixMilli(), now.UnixMilli()
+		if _, err := client.GetHistory(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected InvalidArgument for %v, got %v", req, err)
+		}
+	}
+}
+
+func TestGetHistory_Aggregated(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	client := startTestServerWithRepo(t, repo)
+	ctx := context.Background()
+
+	hour := time.Now().Truncate(time.Hour).Add(-3 * time.Hour)
+	for i, lux := range []float64{100, 200, 300, 1000} {
+		r, _ := domain.NewLightReading(lux)
+		r.Timestamp = hour.Add(time.Duration(i) * 20 * time.Minute) // last one in the next hour
+		_ = repo.SaveReading(ctx, r)
+	}
+
+	req := &pb.GetHistoryRequest{
+		StartTimeMs:           hour.Add(-time.Hour).UnixMilli(),
+		EndTimeMs:             hour.Add(2 * time.Hour).UnixMilli(),
+		AggregationIntervalMs: time.Hour.Milliseconds(),
+		Order:                 pb.SortOrder_SORT_ORDER_DESCENDING,
+	}
+	resp, err := client.GetHistory(ctx, req)
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(resp.Readings) != 0 || len(resp.Buckets) != 2 {
+		t.Fatalf("expected 2 buckets and no readings, got %d buckets, %d readings", len(resp.Buckets), len(resp.Readings))
+	}
+	newest, oldest := resp.Buckets[0], resp.Buckets[1]
+	if oldest.StartTimeMs != hour.UnixMilli() || oldest.EndTimeMs != hour.Add(time.Hour).UnixMilli() ||
+		oldest.ReadingCount != 3 || oldest.AverageLux != 200 || oldest.MinLux != 100 || oldest.MaxLux != 300 {
+		t.Errorf("unexpected first hour %+v", oldest)
+	}
+	if newest.ReadingCount != 1 || newest.AverageLux != 1000 {
+		t.Errorf("unexpected second hour %+v", newest)
+	}
+	if resp.AverageLux != 400 || resp.MinLux != 100 || resp.MaxLux != 1000 {
+		t.Errorf("expected overall average 400, min 100, max 1000; got %v, %v, %v", resp.AverageLux, resp.MinLux, resp.MaxLux)
+	}
+
+	for _, bad := range []*pb.GetHistoryRequest{
+		{AggregationIntervalMs: 1500},
+		{AggregationIntervalMs: -1000},
+		{AggregationIntervalMs: 1000, Percentiles: []float64{50}},
+		{AggregationIntervalMs: 1000, Limit: 10},
+	} {
+		bad.StartTimeMs, bad.EndTimeMs = req.StartTimeMs, req.EndTimeMs
+		if _, err := client.GetHistory(ctx, bad); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected InvalidArgument for %v, got %v", bad, err)
+		}
+	}
+}
+
+func TestGetCategoryEvents(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	client := startTestServerWithRepo(t, repo)
+	ctx := context.Background()
+
+	now := time.Now()
+	_ = repo.SaveCategoryEvent(ctx, &domain.CategoryEvent{
+		From:      domain.CategoryLow,
+		To:        domain.CategoryMedium,
+		Lux:       800,
+		Timestamp: now.Add(-10 * time.Minute),
+	})
+
+	resp, err := client.GetCategoryEvents(ctx, &pb.GetCategoryEventsRequest{
+		StartTime: now.Add(-time.Hour).Unix(),
+		EndTime:   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		t.Fatalf("GetCategoryEvents failed: %v", err)
+	}
+	if len(resp.Events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(resp.Events))
+	}
+	e := resp.Events[0]
+	if e.FromCategory != "Low Light" || e.ToCategory != "Medium Light" || e.Lux != 800 {
+		t.Errorf("unexpected event %+v", e)
+	}
+}
+
+func TestRecomputeCategories_UsesHandlerScheme(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	ctx := context.Background()
+
+	// Stored under the default scheme: one Low -> Medium event at 800 lux
+	now := time.Now().Truncate(time.Second)
+	for i, lux := range []float64{100, 800, 1500, 1600} {
+		r, _ := domain.NewLightReadingAt(lux, now.Add(time.Duration(i-3)*time.Minute))
+		_ = repo.SaveReading(ctx, r)
+	}
+	_ = repo.SaveCategoryEvent(ctx, &domain.CategoryEvent{
+		From: domain.CategoryLow, To: domain.CategoryMedium, Lux: 800, Timestamp: now.Add(-2 * time.Minute),
+	})
+
+	scheme, _ := domain.NewCategoryScheme([]string{"Dark", "Bright"}, []float64{1000})
+	client := startTestServerWithRepo(t, repo, WithCategoryScheme(scheme))
+
+	resp, err := client.RecomputeCategories(ctx, &pb.RecomputeCategoriesRequest{})
+	if err != nil {
+		t.Fatalf("RecomputeCategories failed: %v", err)
+	}
+	if resp.ReadingsScanned != 4 || resp.EventsUpdated != 2 || resp.EventsTotal != 1 {
+		t.Errorf("unexpected response %+v", resp)
+	}
+
+	events, err := client.GetCategoryEvents(ctx, &pb.GetCategoryEventsRequest{
+		StartTime: now.Add(-time.Hour).Unix(),
+		EndTime:   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		t.Fatalf("GetCategoryEvents failed: %v", err)
+	}
+	if len(events.Events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events.Events))
+	}
+	if e := events.Events[0]; e.FromCategory != "Dark" || e.ToCategory != "Bright" || e.Lux != 1500 {
+		t.Errorf("unexpected event %+v", e)
+	}
+}
+
+func TestRecordReading_DuplicateTimestampAlreadyExists(t *testing.T) {
+	client := startTestServer(t)
+	ctx := context.Background()
+	takenMs := time.Now().Add(-time.Minute).UnixMilli()
+
+	if _, err := client.RecordReading(ctx, &pb.RecordReadingRequest{Lux: 250, TimestampMs: &takenMs}); err != nil {
+		t.Fatalf("RecordReading failed: %v", err)
+	}
+	_, err := client.RecordReading(ctx, &pb.RecordReadingRequest{Lux: 300, TimestampMs: &takenMs})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("expected AlreadyExists, got %v", err)
+	}
+}
+
+func TestReadOnlyRepository_WritesFailPrecondition(t *testing.T) {
+	inner := memory.NewReadingRepository()
+	existing, _ := domain.NewLightReading(300)
+	_ = inner.SaveReading(context.Background(), existing)
+
+	client := startTestServerWithRepo(t, readonly.NewReadingRepository(inner))
+	ctx := context.Background()
+
+	_, err := client.RecordReading(ctx, &pb.RecordReadingRequest{Lux: 400})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("RecordReading: expected FailedPrecondition, got %v", err)
+	}
+
+	_, err = client.PruneReadings(ctx, &pb.PruneRequest{RetentionSeconds: int64((48 * time.Hour).Seconds())})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("PruneReadings: expected FailedPrecondition, got %v", err)
+	}
+
+	resp, err := client.GetCurrentLight(ctx, &pb.GetCurrentLightRequest{})
+	if err != nil {
+		t.Fatalf("GetCurrentLight failed: %v", err)
+	}
+	if resp.Reading.Lux != 300 {
+		t.Errorf("expected existing reading of 300 lux, got %v", resp.Reading.Lux)
+	}
+}
+
+func TestMillisecondTimestamps_RoundTrip(t *testing.T) {
+	client := startTestServer(t)
+	ctx := context.Background()
+
+	ts := time.Now().Add(-time.Minute).Truncate(time.Millisecond).Add(123 * time.Millisecond)
+	tsMs := ts.UnixMilli()
+
+	recorded, err := client.RecordReadingsBatch(ctx, &pb.RecordReadingsBatchRequest{
+		Readings: []*pb.RecordReadingRequest{{Lux: 400, TimestampMs: &tsMs}},
+	})
+	if err != nil {
+		t.Fatalf("RecordReadingsBatch failed: %v", err)
+	}
+	if got := recorded.Readings[0].TimestampMs; got != tsMs {
+		t.Errorf("recorded: expected timestamp_ms %d, got %d", tsMs, got)
+	}
+
+	// A millisecond window around the reading: [ts, ts+1ms)
+	history, err := client.GetHistory(ctx, &pb.GetHistoryRequest{
+		StartTimeMs: tsMs,
+		EndTimeMs:   tsMs + 1,
+	})
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(history.Readings) != 1 {
+		t.Fatalf("expected 1 reading in millisecond window, got %d", len(history.Readings))
+	}
+	r := history.Readings[0]
+	if r.TimestampMs != tsMs {
+		t.Errorf("history: expected timestamp_ms %d, got %d", tsMs, r.TimestampMs)
+	}
+	if r.Timestamp != ts.Unix() {
+		t.Errorf("expected deprecated seconds field %d, got %d", ts.Unix(), r.Timestamp)
+	}
+
+	// The deprecated seconds fields still work
+	legacy, err := client.GetHistory(ctx, &pb.GetHistoryRequest{
+		StartTime: ts.Add(-time.Second).Unix(),
+		EndTime:   ts.Add(time.Second).Unix(),
+	})
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(legacy.Readings) != 1 {
+		t.Errorf("expected 1 reading via seconds fields, got %d", len(legacy.Readings))
+	}
+}
+
+func TestGetLightAsOf(t *testing.T) {
+	repo := newSQLiteRepo(t)
+	client := startTestServerWithRepo(t, repo)
+	ctx := context.Background()
+
+	at := time.Now().Add(-time.Hour)
+	for i, lux := range []float64{100, 200, 300} {
+		r, _ := domain.NewLightReading(lux)
+		r.Timestamp = at.Add(time.Duration(i-1) * 30 * time.Minute) // -30m, T, +30m
+		_ = repo.SaveReading(ctx, r)
+	}
+
+	resp, err := client.GetLightAsOf(ctx, &pb.GetLightAsOfRequest{AtMs: at.Add(10 * time.Minute).UnixMilli()})
+	if err != nil {
+		t.Fatalf("GetLightAsOf failed: %v", err)
+	}
+	if resp.Reading.Lux != 200 {
+		t.Errorf("expected 200 lux, got %v", resp.Reading.Lux)
+	}
+
+	_, err = client.GetLightAsOf(ctx, &pb.GetLightAsOfRequest{AtMs: at.Add(-2 * time.Hour).UnixMilli()})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound before first reading, got %v", err)
+	}
+}
+
+func TestGetStorageStats(t *testing.T) {
+	repo := newSQLiteRepo(t)
+	client := startTestServerWithRepo(t, repo)
+	ctx := context.Background()
+
+	resp, err := client.GetStorageStats(ctx, &pb.GetStorageStatsRequest{})
+	if err != nil {
+		t.Fatalf("GetStorageStats failed: %v", err)
+	}
+	if resp.ReadingCount != 0 || resp.OldestTimestampMs != 0 {
+		t.Errorf("expected empty stats, got %+v", resp)
+	}
+
+	ts := time.Now().Add(-time.Minute).Truncate(time.Millisecond)
+	r, _ := domain.NewLightReading(500)
+	r.Timestamp = ts
+	_ = repo.SaveReading(ctx, r)
+
+	resp, err = client.GetStorageStats(ctx, &pb.GetStorageStatsRequest{})
+	if err != nil {
+		t.Fatalf("GetStorageStats failed: %v", err)
+	}
+	if resp.ReadingCount != 1 {
+		t.Errorf("expected 1 reading, got %d", resp.ReadingCount)
+	}
+	if resp.OldestTimestampMs != ts.UnixMilli() || resp.NewestTimestampMs != ts.UnixMilli() {
+		t.Errorf("expected span at %d, got %d - %d", ts.UnixMilli(), resp.OldestTimestampMs, resp.NewestTimestampMs)
+	}
+	if resp.SizeBytes <= 0 {
+		t.Errorf("expected a positive database size, got %d", resp.SizeBytes)
+	}
+}
+
+// unavailableRepo fails every read, like a locked or corrupt database
+type unavailableRepo struct {
+	*memory.ReadingRepository
+}
+
+func (unavailableRepo) GetLatestReading(ctx context.Context) (*domain.LightReading, error) {
+	return nil, errors.New("database is locked")
+}
+
+func (unavailableRepo) SaveReading(ctx context.Context, reading *domain.LightReading) error {
+	panic("GetCurrentLight must not try to persist when the repository is unavailable")
+}
+
+// brokenSensor always fails
+type brokenSensor struct{}
+
+func (brokenSensor) ReadLux(ctx context.Context) (float64, error) {
+	return 0, domain.ErrSensorUnavailable
+}
+func (brokenSensor) Close() error { return nil }
+
+func TestGetCurrentLight_RepoUnavailableFallsBackToSensor(t *testing.T) {
+	client := startTestServerWithRepo(t, unavailableRepo{memory.NewReadingRepository()})
+
+	resp, err := client.GetCurrentLight(context.Background(), &pb.GetCurrentLightRequest{})
+	if err != nil {
+		t.Fatalf("expected live fallback, got %v", err)
+	}
+	if resp.Reading.Lux != 500 {
+		t.Errorf("expected live sensor value 500, got %v", resp.Reading.Lux)
+	}
+	if resp.Persisted {
+		t.Error("expected persisted=false for a fallback reading")
+	}
+}
+
+func TestGetCurrentLight_RepoAndSensorUnavailable(t *testing.T) {
+	client := startTestServerWithSensor(t, unavailableRepo{memory.NewReadingRepository()}, brokenSensor{})
+
+	_, err := client.GetCurrentLight(context.Background(), &pb.GetCurrentLightRequest{})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("expected Internal when both repository and sensor fail, got %v", err)
+	}
+}
+
+func TestGetCurrentLight_StoredReadingIsPersisted(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	r, _ := domain.NewLightReading(321)
+	_ = repo.SaveReading(context.Background(), r)
+
+	resp, err := startTestServerWithRepo(t, repo).GetCurrentLight(context.Background(), &pb.GetCurrentLightRequest{})
+	if err != nil {
+		t.Fatalf("GetCurrentLight failed: %v", err)
+	}
+	if !resp.Persisted || resp.Reading.Lux != 321 {
+		t.Errorf("expected stored 321 lux with persisted=true, got %v / %v", resp.Reading.Lux, resp.Persisted)
+	}
+}
+
+func TestGetHistory_TimeInCategory(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	client := startTestServerWithRepo(t, repo, WithMaxCategoryGap(time.Hour))
+	ctx := context.Background()
+
+	base := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+	for _, r := range []struct {
+		minutes int
+		lux     float64
+	}{{0, 100}, {30, 800}, {60, 800}} {
+		reading, _ := domain.NewLightReading(r.lux)
+		reading.Timestamp = base.Add(time.Duration(r.minutes) * time.Minute)
+		_ = repo.SaveReading(ctx, reading)
+	}
+
+	// Range ends 90m after base: low 30m, medium 30m + 30m for the last reading
+	resp, err := client.GetHistory(ctx, &pb.GetHistoryRequest{
+		StartTimeMs: base.UnixMilli(),
+		EndTimeMs:   base.Add(90 * time.Minute).UnixMilli(),
+	})
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+
+	want := []struct {
+		category string
+		seconds  float64
+		fraction float64
+	}{
+		{"Low Light", 1800, 1.0 / 3},
+		{"Medium Light", 3600, 2.0 / 3},
+		{"High Light", 0, 0},
+	}
+	if len(resp.TimeInCategory) != len(want) {
+		t.Fatalf("expected %d categories, got %d", len(want), len(resp.TimeInCategory))
+	}
+	for i, w := range want {
+		got := resp.TimeInCategory[i]
+		if got.Category != w.category || got.Seconds != w.seconds || math.Abs(got.Fraction-w.fraction) > 1e-9 {
+			t.Errorf("entry %d: expected %+v, got %+v", i, w, got)
+		}
+	}
+}
+
+func TestGetHistory_TimeInCategoryUsesScheme(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	scheme, err := domain.NewCategoryScheme([]string{"Dark", "Dim", "Moderate", "Bright", "Direct sun"}, []float64{10, 100, 1000, 10000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := startTestServerWithRepo(t, repo, WithCategoryScheme(scheme), WithMaxCategoryGap(time.Hour))
+	ctx := context.Background()
+
+	base := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+	for _, r := range []struct {
+		minutes int
+		lux     float64
+	}{{0, 5}, {30, 50000}} {
+		reading, _ := domain.NewLightReadingAt(r.lux, base.Add(time.Duration(r.minutes)*time.Minute))
+		_ = repo.SaveReading(ctx, reading)
+	}
+
+	resp, err := client.GetHistory(ctx, &pb.GetHistoryRequest{
+		StartTimeMs: base.UnixMilli(),
+		EndTimeMs:   base.Add(60 * time.Minute).UnixMilli(),
+	})
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+
+	want := []struct {
+		category string
+		seconds  float64
+	}{{"Dark", 1800}, {"Dim", 0}, {"Moderate", 0}, {"Bright", 0}, {"Direct sun", 1800}}
+	if len(resp.TimeInCategory) != len(want) {
+		t.Fatalf("expected %d levels, got %v", len(want), resp.TimeInCategory)
+	}
+	for i, w := range want {
+		if got := resp.TimeInCategory[i]; got.Category != w.category || got.Seconds != w.seconds {
+			t.Errorf("entry %d: expected %+v, got %+v", i, w, got)
+		}
+	}
+}
+
+func TestCompareRanges(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	client := startTestServerWithRepo(t, repo)
+	ctx := context.Background()
+
+	before := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	after := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	seed := func(base time.Time, values ...float64) {
+		for i, lux := range values {
+			reading, _ := domain.NewLightReading(lux)
+			reading.Timestamp = base.Add(time.Duration(i) * time.Minute)
+			_ = repo.SaveReading(ctx, reading)
+		}
+	}
+	seed(before, 100, 200, 300)
+	seed(after, 400, 600, 1100)
+
+	rangeAt := func(base time.Time) *pb.TimeRange {
+		return &pb.TimeRange{StartMs: base.UnixMilli(), EndMs: base.Add(time.Hour).UnixMilli()}
+	}
+
+	resp, err := client.CompareRanges(ctx, &pb.CompareRangesRequest{RangeA: rangeAt(before), RangeB: rangeAt(after)})
+	if err != nil {
+		t.Fatalf("CompareRanges failed: %v", err)
+	}
+	if resp.A.AverageLux != 200 || resp.B.AverageLux != 700 || resp.A.ReadingCount != 3 || resp.B.ReadingCount != 3 {
+		t.Errorf("unexpected range statistics: a=%+v b=%+v", resp.A, resp.B)
+	}
+	if !resp.Comparable || resp.AverageLuxDelta != 500 || resp.MinLuxDelta != 300 || resp.MaxLuxDelta != 800 {
+		t.Errorf("expected deltas 500/300/800, got %+v", resp)
+	}
+	// Each range's readings share one 15 minute bucket, which counts for
+	// the default gap of 15 minutes out of the hour
+	dli := func(lux float64) float64 { return lux * domain.LuxToPPFD * 900 / 1e6 * 24 }
+	if resp.A.Dli == nil || math.Abs(*resp.A.Dli-dli(200)) > 1e-9 || resp.B.Dli == nil || math.Abs(*resp.B.Dli-dli(700)) > 1e-9 {
+		t.Errorf("expected DLIs %v and %v, got %v and %v", dli(200), dli(700), resp.A.Dli, resp.B.Dli)
+	}
+	if resp.DliDelta == nil || math.Abs(*resp.DliDelta-dli(500)) > 1e-9 {
+		t.Errorf("expected a DLI delta of %v, got %v", dli(500), resp.DliDelta)
+	}
+
+	// An empty range is reported as such, with no deltas
+	empty := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	resp, err = client.CompareRanges(ctx, &pb.CompareRangesRequest{RangeA: rangeAt(before), RangeB: rangeAt(empty)})
+	if err != nil {
+		t.Fatalf("CompareRanges failed: %v", err)
+	}
+	if !resp.B.Empty || resp.B.AverageLux != 0 || resp.Comparable || resp.AverageLuxDelta != 0 {
+		t.Errorf("expected empty, non-comparable range b, got %+v", resp)
+	}
+	if resp.B.GetDli() != 0 || resp.DliDelta != nil {
+		t.Errorf("expected no light in range b and no DLI delta, got %v, %v", resp.B.Dli, resp.DliDelta)
+	}
+}
+
+// statisticsOnlyRepo fails raw range reads, so a handler that should only
+// ask the store for statistics can't fall back to loading the readings
+type statisticsOnlyRepo struct {
+	*memory.ReadingRepository
+}
+
+func (r *statisticsOnlyRepo) GetReadingsInRange(ctx context.Context, start, end time.Time, opts ...domain.RangeOption) ([]*domain.LightReading, error) {
+	return nil, errors.New("readings loaded")
+}
+
+func TestCompareRanges_UsesStoreStatistics(t *testing.T) {
+	repo := &statisticsOnlyRepo{memory.NewReadingRepository()}
+	client := startTestServerWithRepo(t, repo)
+	ctx := context.Background()
+
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	for i, lux := range []float64{100, 300} {
+		reading, _ := domain.NewLightReadingAt(lux, base.Add(time.Duration(i)*time.Hour))
+		_ = repo.SaveReading(ctx, reading)
+	}
+
+	// Ranges of years are fine, since no readings are loaded
+	resp, err := client.CompareRanges(ctx, &pb.CompareRangesRequest{
+		RangeA: &pb.TimeRange{StartMs: base.AddDate(-5, 0, 0).UnixMilli(), EndMs: base.UnixMilli()},
+		RangeB: &pb.TimeRange{StartMs: base.UnixMilli(), EndMs: base.AddDate(5, 0, 0).UnixMilli()},
+	})
+	if err != nil {
+		t.Fatalf("CompareRanges failed: %v", err)
+	}
+	if !resp.A.Empty || resp.B.ReadingCount != 2 || resp.B.AverageLux != 200 {
+		t.Errorf("unexpected range statistics: a=%+v b=%+v", resp.A, resp.B)
+	}
+	// Ranges longer than GetDailyLightIntegral's have no DLI
+	if resp.A.Dli != nil || resp.B.Dli != nil || resp.DliDelta != nil {
+		t.Errorf("expected no DLI for five-year ranges, got %v, %v, %v", resp.A.Dli, resp.B.Dli, resp.DliDelta)
+	}
+}
+
+func TestCompareRanges_InvalidRange(t *testing.T) {
+	client := startTestServer(t)
+
+	_, err := client.CompareRanges(context.Background(), &pb.CompareRangesRequest{
+		RangeA: &pb.TimeRange{StartMs: 2000, EndMs: 1000},
+		RangeB: &pb.TimeRange{StartMs: 0, EndMs: 1000},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestRecordReading_CategoryScheme(t *testing.T) {
+	scheme, err := domain.NewCategoryScheme(
+		[]string{"Very Low", "Low", "Medium", "High", "Direct Sun"},
+		[]float64{50, 200, 2500, 10000},
+	)
+	if err != nil {
+		t.Fatalf("NewCategoryScheme failed: %v", err)
+	}
+	client := startTestServerWithRepo(t, memory.NewReadingRepository(), WithCategoryScheme(scheme))
+
+	for lux, want := range map[float64]string{30: "Very Low", 150: "Low", 20000: "Direct Sun"} {
+		resp, err := client.RecordReading(context.Background(), &pb.RecordReadingRequest{Lux: lux})
+		if err != nil {
+			t.Fatalf("RecordReading failed: %v", err)
+		}
+		if resp.Reading.Category != want {
+			t.Errorf("%v lux: expected %q, got %q", lux, want, resp.Reading.Category)
+		}
+	}
+}
+
+func TestGetHistory_CategoryFilter(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	client := startTestServerWithRepo(t, repo)
+	ctx := context.Background()
+
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	for i, lux := range []float64{100, 3000, 800, 5000, 150} {
+		reading, _ := domain.NewLightReading(lux)
+		reading.Timestamp = base.Add(time.Duration(i) * time.Minute)
+		_ = repo.SaveReading(ctx, reading)
+	}
+
+	resp, err := client.GetHistory(ctx, &pb.GetHistoryRequest{
+		StartTimeMs: base.UnixMilli(),
+		EndTimeMs:   base.Add(time.Hour).UnixMilli(),
+		CategoryFilter: &pb.CategoryFilter{
+			Categories: []pb.LightCategory{pb.LightCategory_LIGHT_CATEGORY_HIGH},
+		},
+	})
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+
+	if len(resp.Readings) != 2 || resp.Readings[0].Lux != 3000 || resp.Readings[1].Lux != 5000 {
+		t.Fatalf("expected only the high readings, got %v", resp.Readings)
+	}
+	if resp.AverageLux != 4000 || resp.MinLux != 3000 || resp.MaxLux != 5000 {
+		t.Errorf("expected statistics over the filtered readings, got avg=%v min=%v max=%v",
+			resp.AverageLux, resp.MinLux, resp.MaxLux)
+	}
+	if len(resp.TimeInCategory) != 0 {
+		t.Errorf("expected no time-in-category breakdown when filtering, got %v", resp.TimeInCategory)
+	}
+
+	// Several categories at once
+	resp, err = client.GetHistory(ctx, &pb.GetHistoryRequest{
+		StartTimeMs: base.UnixMilli(),
+		EndTimeMs:   base.Add(time.Hour).UnixMilli(),
+		CategoryFilter: &pb.CategoryFilter{
+			Categories: []pb.LightCategory{pb.LightCategory_LIGHT_CATEGORY_LOW, pb.LightCategory_LIGHT_CATEGORY_MEDIUM},
+		},
+	})
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(resp.Readings) != 3 {
+		t.Errorf("expected 3 low/medium readings, got %d", len(resp.Readings))
+	}
+}
+
+func TestGetHistory_CategoryFilterUsesScheme(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	scheme, err := domain.NewCategoryScheme([]string{"Dark", "Dim", "Moderate", "Bright", "Direct sun"}, []float64{10, 100, 1000, 10000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := startTestServerWithRepo(t, repo, WithCategoryScheme(scheme))
+	ctx := context.Background()
+
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	for i, lux := range []float64{5, 50, 500, 5000, 50000} {
+		reading, _ := domain.NewLightReadingAt(lux, base.Add(time.Duration(i)*time.Minute))
+		_ = repo.SaveReading(ctx, reading)
+	}
+	request := func(filter *pb.CategoryFilter) *pb.GetHistoryRequest {
+		return &pb.GetHistoryRequest{
+			StartTimeMs:    base.UnixMilli(),
+			EndTimeMs:      base.Add(time.Hour).UnixMilli(),
+			CategoryFilter: filter,
+		}
+	}
+
+	resp, err := client.GetHistory(ctx, request(&pb.CategoryFilter{Levels: []int32{1, 4}}))
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(resp.Readings) != 2 || resp.Readings[0].Lux != 50 || resp.Readings[1].Lux != 50000 {
+		t.Errorf("expected the Dim and Direct sun readings, got %v", resp.Readings)
+	}
+
+	// The three-level enum can't name this scheme's levels
+	_, err = client.GetHistory(ctx, request(&pb.CategoryFilter{
+		Categories: []pb.LightCategory{pb.LightCategory_LIGHT_CATEGORY_HIGH},
+	}))
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for categories under a five-level scheme, got %v", err)
+	}
+	_, err = client.GetHistory(ctx, request(&pb.CategoryFilter{Levels: []int32{5}}))
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for a level outside the scheme, got %v", err)
+	}
+}
+
+func TestGetHistory_EmptyCategoryFilter(t *testing.T) {
+	client := startTestServer(t)
+
+	_, err := client.GetHistory(context.Background(), &pb.GetHistoryRequest{
+		EndTimeMs:      time.Now().UnixMilli(),
+		CategoryFilter: &pb.CategoryFilter{},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestGetRecorderStatus(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	sensor := &toggleSensor{}
+	recorder := ports.NewRecorder(sensor, repo, 5*time.Minute)
+	client := startTestServerWithRepo(t, repo, WithRecorderStatus(recorder))
+	ctx := context.Background()
+
+	status, err := client.GetRecorderStatus(ctx, &pb.GetRecorderStatusRequest{})
+	if err != nil {
+		t.Fatalf("GetRecorderStatus failed: %v", err)
+	}
+	if !status.Running || status.LastSuccessMs != 0 || status.IntervalMs != (5*time.Minute).Milliseconds() {
+		t.Errorf("unexpected initial status %+v", status)
+	}
+
+	// A successful cycle
+	before := time.Now()
+	runFirstCycle(t, recorder)
+	status, _ = client.GetRecorderStatus(ctx, &pb.GetRecorderStatusRequest{})
+	if status.LastSuccessMs < before.UnixMilli() || status.LastError != "" || status.ConsecutiveFailures != 0 {
+		t.Errorf("expected a recent success, got %+v", status)
+	}
+	lastSuccess := status.LastSuccessMs
+
+	// A failing cycle
+	sensor.failing.Store(true)
+	runFirstCycle(t, recorder)
+	status, _ = client.GetRecorderStatus(ctx, &pb.GetRecorderStatusRequest{})
+	if status.LastSuccessMs != lastSuccess || status.ConsecutiveFailures != 1 || status.LastError == "" {
+		t.Errorf("expected one failure after the success, got %+v", status)
+	}
+}
+
+func TestGetRecorderStatus_NoRecorder(t *testing.T) {
+	client := startTestServer(t)
+
+	status, err := client.GetRecorderStatus(context.Background(), &pb.GetRecorderStatusRequest{})
+	if err != nil {
+		t.Fatalf("GetRecorderStatus failed: %v", err)
+	}
+	if status.Running {
+		t.Error("expected recorder reported as not running")
+	}
+}
+
+// toggleSensor reads 500 lux until failing is set
+type toggleSensor struct {
+	failing atomic.Bool
+}
+
+func (s *toggleSensor) ReadLux(ctx context.Context) (float64, error) {
+	if s.failing.Load() {
+		return 0, domain.ErrSensorUnavailable
+	}
+	return 500, nil
+}
+
+func (s *toggleSensor) Close() error { return nil }
+
+// runFirstCycle starts the recorder, waits for its immediate first
+// recording to update the status, then stops it
+func runFirstCycle(t *testing.T, recorder *ports.Recorder) {
+	t.Helper()
+	before := recorder.Status()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		recorder.Start(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for recorder.Status() == before {
+		if time.Now().After(deadline) {
+			t.Fatal("recorder made no recording")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestGetRecordingDays(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	client := startTestServerWithRepo(t, repo)
+	ctx := context.Background()
+
+	// 03:30 UTC on 2 June is still 1 June in New York (UTC-4 in summer)
+	for _, ts := range []time.Time{
+		time.Date(2024, 6, 1, 15, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 2, 3, 30, 0, 0, time.UTC),
+		time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC),
+	} {
+		r, _ := domain.NewLightReading(300)
+		r.Timestamp = ts
+		_ = repo.SaveReading(ctx, r)
+	}
+
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+	tests := []struct {
+		zone string
+		want []string
+	}{
+		{"", []string{"2024-06-01", "2024-06-02", "2024-06-03"}},
+		{"America/New_York", []string{"2024-06-01", "2024-06-03"}},
+	}
+	for _, tt := range tests {
+		resp, err := client.GetRecordingDays(ctx, &pb.GetRecordingDaysRequest{
+			StartTimeMs: start.UnixMilli(),
+			EndTimeMs:   end.UnixMilli(),
+			TimeZone:    tt.zone,
+		})
+		if err != nil {
+			if tt.zone != "" && status.Code(err) == codes.InvalidArgument {
+				t.Skipf("time zone database unavailable: %v", err)
+			}
+			t.Fatalf("GetRecordingDays(%q) failed: %v", tt.zone, err)
+		}
+		if !slices.Equal(resp.Days, tt.want) {
+			t.Errorf("GetRecordingDays(%q) = %v, want %v", tt.zone, resp.Days, tt.want)
+		}
+	}
+
+	_, err := client.GetRecordingDays(ctx, &pb.GetRecordingDaysRequest{
+		StartTimeMs: start.UnixMilli(),
+		EndTimeMs:   end.UnixMilli(),
+		TimeZone:    "Not/AZone",
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for an unknown zone, got %v", err)
+	}
+}
+
+func TestGetReadingsByIDs(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	client := startTestServerWithRepo(t, repo)
+	ctx := context.Background()
+
+	var ids []int64
+	for _, lux := range []float64{100, 200, 300} {
+		r, _ := domain.NewLightReading(lux)
+		_ = repo.SaveReading(ctx, r)
+		ids = append(ids, r.ID)
+	}
+
+	resp, err := client.GetReadingsByIDs(ctx, &pb.GetReadingsByIDsRequest{
+		Ids: []int64{ids[2], 404, ids[0], 404, 405},
+	})
+	if err != nil {
+		t.Fatalf("GetReadingsByIDs failed: %v", err)
+	}
+	if len(resp.Readings) != 2 || resp.Readings[0].Id != ids[2] || resp.Readings[1].Id != ids[0] {
+		t.Errorf("expected readings %d and %d, got %v", ids[2], ids[0], resp.Readings)
+	}
+	if !slices.Equal(resp.MissingIds, []int64{404, 405}) {
+		t.Errorf("expected missing ids [404 405], got %v", resp.MissingIds)
+	}
+
+	_, err = client.GetReadingsByIDs(ctx, &pb.GetReadingsByIDsRequest{Ids: make([]int64, maxReadingsByIDs+1)})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for too many ids, got %v", err)
+	}
+}
+
+func TestGetCurrentLight_Smoothed(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	client := startTestServerWithRepo(t, repo)
+	ctx := context.Background()
+
+	// A passing shadow on the latest reading
+	base := time.Now().Add(-time.Hour)
+	for i, lux := range []float64{900, 1000, 1100, 1000, 100} {
+		r, _ := domain.NewLightReading(lux)
+		r.Timestamp = base.Add(time.Duration(i) * time.Minute)
+		_ = repo.SaveReading(ctx, r)
+	}
+
+	tests := []struct {
+		name      string
+		window    *pb.SmoothWindow
+		wantLux   float64
+		wantCount int32
+		wantCat   string
+	}{
+		{"unset returns latest", nil, 100, 0, "Low Light"},
+		{"zero count returns latest", &pb.SmoothWindow{Window: &pb.SmoothWindow_Count{Count: 0}}, 100, 0, "Low Light"},
+		{"last three", &pb.SmoothWindow{Window: &pb.SmoothWindow_Count{Count: 3}}, 2200.0 / 3, 3, "Medium Light"},
+		{"count beyond store", &pb.SmoothWindow{Window: &pb.SmoothWindow_Count{Count: 50}}, 820, 5, "Medium Light"},
+		{"last two minutes inclusive", &pb.SmoothWindow{Window: &pb.SmoothWindow_DurationMs{DurationMs: (2 * time.Minute).Milliseconds()}}, 2200.0 / 3, 3, "Medium Light"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.GetCurrentLight(ctx, &pb.GetCurrentLightRequest{SmoothWindow: tt.window})
+			if err != nil {
+				t.Fatalf("GetCurrentLight failed: %v", err)
+			}
+			if math.Abs(resp.Reading.Lux-tt.wantLux) > 1e-9 || resp.SmoothedCount != tt.wantCount {
+				t.Errorf("expected lux %v from %d readings, got %v from %d", tt.wantLux, tt.wantCount, resp.Reading.Lux, resp.SmoothedCount)
+			}
+			if resp.Reading.Category != tt.wantCat {
+				t.Errorf("expected category %q, got %q", tt.wantCat, resp.Reading.Category)
+			}
+			if want := base.Add(4 * time.Minute).UnixMilli(); resp.Reading.TimestampMs != want {
+				t.Errorf("expected the latest reading's timestamp, got %v", resp.Reading.TimestampMs)
+			}
+		})
+	}
+
+	_, err := client.GetCurrentLight(ctx, &pb.GetCurrentLightRequest{
+		SmoothWindow: &pb.SmoothWindow{Window: &pb.SmoothWindow_Count{Count: -1}},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for a negative window, got %v", err)
+	}
+}
+
+func TestGetCurrentLight_SmoothedWithNoReadingsReadsSensor(t *testing.T) {
+	client := startTestServer(t)
+
+	resp, err := client.GetCurrentLight(context.Background(), &pb.GetCurrentLightRequest{
+		SmoothWindow: &pb.SmoothWindow{Window: &pb.SmoothWindow_Count{Count: 5}},
+	})
+	if err != nil {
+		t.Fatalf("GetCurrentLight failed: %v", err)
+	}
+	if resp.Reading.Lux != 500 || resp.SmoothedCount != 0 {
+		t.Errorf("expected a live 500 lux read, got %v (smoothed from %d)", resp.Reading.Lux, resp.SmoothedCount)
+	}
+}
+
+func TestGetCurrentLight_SaturatedQuality(t *testing.T) {
+	sensor := mock.NewFakeSensorSeeded(90000, 0, 1)
+	sensor.SetSaturation(40000)
+	client := startTestServerWithSensor(t, memory.NewReadingRepository(), sensor)
+
+	// Read live, stored, then served from the store
+	for i := 0; i < 2; i++ {
+		resp, err := client.GetCurrentLight(context.Background(), &pb.GetCurrentLightRequest{})
+		if err != nil {
+			t.Fatalf("GetCurrentLight failed: %v", err)
+		}
+		if resp.Reading.Lux != 40000 || resp.Reading.Quality != pb.ReadingQuality_READING_QUALITY_SATURATED {
+			t.Errorf("call %d: expected saturated 40000 lux, got %v (%v)", i, resp.Reading.Lux, resp.Reading.Quality)
+		}
+	}
+}
+
+func TestCategorize_Boundaries(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	client := startTestServerWithRepo(t, repo)
+	ctx := context.Background()
+
+	tests := []struct {
+		lux               float64
+		category          string
+		low, medium, high bool
+	}{
+		{0, "Low Light", true, false, false},
+		{199.99, "Low Light", true, false, false},
+		{200, "Medium Light", false, true, false},
+		{2499.99, "Medium Light", false, true, false},
+		{2500, "High Light", false, false, true},
+		{100000, "High Light", false, false, true},
+	}
+	for _, tt := range tests {
+		resp, err := client.Categorize(ctx, &pb.CategorizeRequest{Lux: tt.lux})
+		if err != nil {
+			t.Fatalf("Categorize(%v) failed: %v", tt.lux, err)
+		}
+		if resp.Category != tt.category || resp.IsLowLight != tt.low || resp.IsMediumLight != tt.medium || resp.IsHighLight != tt.high {
+			t.Errorf("Categorize(%v) = %+v, want %s (%v/%v/%v)", tt.lux, resp, tt.category, tt.low, tt.medium, tt.high)
+		}
+	}
+
+	// Nothing is recorded
+	if stats, _ := repo.Stats(ctx); stats.ReadingCount != 0 {
+		t.Errorf("expected no readings stored, got %d", stats.ReadingCount)
+	}
+}
+
+func TestCategorize_CustomSchemeAndInvalidLux(t *testing.T) {
+	scheme, _ := domain.NewCategoryScheme([]string{"Dark", "Bright"}, []float64{1000})
+	client := startTestServerWithRepo(t, memory.NewReadingRepository(), WithCategoryScheme(scheme))
+	ctx := context.Background()
+
+	for lux, want := range map[float64]string{999.9: "Dark", 1000: "Bright"} {
+		resp, err := client.Categorize(ctx, &pb.CategorizeRequest{Lux: lux})
+		if err != nil || resp.Category != want {
+			t.Errorf("Categorize(%v) = %v, %v; want %s", lux, resp, err, want)
+		}
+	}
+
+	for _, lux := range []float64{-1, math.NaN(), math.Inf(1)} {
+		if _, err := client.Categorize(ctx, &pb.CategorizeRequest{Lux: lux}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Categorize(%v): expected InvalidArgument, got %v", lux, err)
+		}
+	}
+}
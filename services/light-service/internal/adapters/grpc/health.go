@@ -0,0 +1,114 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/ports"
+	pb "github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+// SensorHealthService is the health service name reporting the light
+// sensor alone, for probes that care whether readings are real even while a
+// fallback sensor keeps the service up
+const SensorHealthService = "light.v1.LightService.Sensor"
+
+// RegisterHealth registers the standard gRPC health service on srv,
+// reporting SERVING for the server as a whole and for LightService
+func RegisterHealth(srv *grpc.Server) *health.Server {
+	hs := health.NewServer()
+	hs.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	hs.SetServingStatus(pb.LightService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(srv, hs)
+	return hs
+}
+
+// StartDraining reports NOT_SERVING on every service and then waits for
+// grace, giving load balancers time to stop routing new requests here before
+// the server stops accepting them. The server keeps serving throughout.
+func StartDraining(hs *health.Server, grace time.Duration) {
+	hs.Shutdown()
+	log.Info().Dur("grace_period", grace).Msg("readiness set to NOT_SERVING; draining")
+	time.Sleep(grace)
+}
+
+// WatchRecorder polls the recorder's status every interval and reports
+// NOT_SERVING while it has failed threshold or more cycles in a row, i.e.
+// the sensor or repository keeps failing, and SERVING again once a cycle
+// succeeds. It returns when ctx is done. Once draining has started the
+// health server ignores further updates, so it stays NOT_SERVING.
+func WatchRecorder(ctx context.Context, hs *health.Server, status func() ports.RecorderStatus, threshold int, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	serving := true
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		s := status()
+		healthy := s.ConsecutiveFailures < threshold
+		if healthy == serving {
+			continue
+		}
+		serving = healthy
+
+		state := healthpb.HealthCheckResponse_SERVING
+		if healthy {
+			log.Info().Msg("recorder recovered; health set to SERVING")
+		} else {
+			state = healthpb.HealthCheckResponse_NOT_SERVING
+			log.Warn().
+				Int("consecutive_failures", s.ConsecutiveFailures).
+				Str("last_error", s.LastError).
+				Msg("recorder keeps failing; health set to NOT_SERVING")
+		}
+		hs.SetServingStatus("", state)
+		hs.SetServingStatus(pb.LightService_ServiceDesc.ServiceName, state)
+	}
+}
+
+// WatchSensor polls the sensor watchdog's status every interval and reports
+// it under SensorHealthService: NOT_SERVING while the sensor is unhealthy,
+// SERVING once it recovers. It returns when ctx is done.
+func WatchSensor(ctx context.Context, hs *health.Server, status func() ports.SensorHealth, interval time.Duration) {
+	hs.SetServingStatus(SensorHealthService, healthpb.HealthCheckResponse_SERVING)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	serving := true
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		s := status()
+		if s.Healthy == serving {
+			continue
+		}
+		serving = s.Healthy
+
+		state := healthpb.HealthCheckResponse_SERVING
+		if s.Healthy {
+			log.Info().Msg("light sensor recovered; sensor health set to SERVING")
+		} else {
+			state = healthpb.HealthCheckResponse_NOT_SERVING
+			log.Warn().
+				Int("consecutive_failures", s.ConsecutiveFailures).
+				Str("last_error", s.LastError).
+				Bool("using_fallback", s.UsingFallback).
+				Msg("light sensor unavailable; sensor health set to NOT_SERVING")
+		}
+		hs.SetServingStatus(SensorHealthService, state)
+	}
+}
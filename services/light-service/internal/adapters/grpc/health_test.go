@@ -0,0 +1,191 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/mock"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/ports"
+	pb "github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+func TestStartDraining_NotServingBeforeStop(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	srv := grpc.NewServer()
+	pb.RegisterLightServiceServer(srv, NewLightServiceHandler(memory.NewReadingRepository(), mock.NewFakeSensor(500, 0)))
+	hs := RegisterHealth(srv)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	// The same sequence main runs on SIGTERM
+	quit := make(chan os.Signal, 1)
+	stopped := make(chan struct{})
+	go func() {
+		<-quit
+		StartDraining(hs, 200*time.Millisecond)
+		srv.GracefulStop()
+		close(stopped)
+	}()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	defer conn.Close()
+	healthClient := healthpb.NewHealthClient(conn)
+	lightClient := pb.NewLightServiceClient(conn)
+	ctx := context.Background()
+
+	check := func() healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := healthClient.Check(ctx, &healthpb.HealthCheckRequest{Service: "light.v1.LightService"})
+		if err != nil {
+			t.Fatalf("health check failed: %v", err)
+		}
+		return resp.Status
+	}
+
+	if got := check(); got != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("expected SERVING before shutdown, got %v", got)
+	}
+
+	quit <- syscall.SIGTERM
+
+	deadline := time.Now().Add(time.Second)
+	for check() != healthpb.HealthCheckResponse_NOT_SERVING {
+		if time.Now().After(deadline) {
+			t.Fatal("readiness did not flip to NOT_SERVING")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Still draining: not ready, but requests are served
+	select {
+	case <-stopped:
+		t.Fatal("server stopped before the grace period")
+	default:
+	}
+	if _, err := lightClient.GetCurrentLight(ctx, &pb.GetCurrentLightRequest{}); err != nil {
+		t.Errorf("expected requests to be served while draining, got %v", err)
+	}
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not stop after the grace period")
+	}
+}
+
+func TestWatchRecorder_NotServingAfterConsecutiveFailures(t *testing.T) {
+	srv := grpc.NewServer()
+	hs := RegisterHealth(srv)
+
+	var mu sync.Mutex
+	failures := 0
+	status := func() ports.RecorderStatus {
+		mu.Lock()
+		defer mu.Unlock()
+		return ports.RecorderStatus{ConsecutiveFailures: failures}
+	}
+	setFailures := func(n int) {
+		mu.Lock()
+		failures = n
+		mu.Unlock()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go WatchRecorder(ctx, hs, status, 3, time.Millisecond)
+
+	waitFor := func(want healthpb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			resp, err := hs.Check(ctx, &healthpb.HealthCheckRequest{Service: "light.v1.LightService"})
+			if err != nil {
+				t.Fatalf("health check failed: %v", err)
+			}
+			if resp.Status == want {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("expected %v, still %v", want, resp.Status)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	setFailures(2)
+	time.Sleep(20 * time.Millisecond)
+	waitFor(healthpb.HealthCheckResponse_SERVING)
+
+	setFailures(3)
+	waitFor(healthpb.HealthCheckResponse_NOT_SERVING)
+
+	setFailures(0)
+	waitFor(healthpb.HealthCheckResponse_SERVING)
+}
+
+func TestWatchSensor_ReportsSensorSeparately(t *testing.T) {
+	srv := grpc.NewServer()
+	hs := RegisterHealth(srv)
+
+	var mu sync.Mutex
+	healthy := true
+	status := func() ports.SensorHealth {
+		mu.Lock()
+		defer mu.Unlock()
+		return ports.SensorHealth{Healthy: healthy}
+	}
+	setHealthy := func(h bool) {
+		mu.Lock()
+		healthy = h
+		mu.Unlock()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go WatchSensor(ctx, hs, status, time.Millisecond)
+
+	check := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := hs.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			return healthpb.HealthCheckResponse_SERVICE_UNKNOWN // not registered yet
+		}
+		return resp.Status
+	}
+	waitFor := func(want healthpb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for check(SensorHealthService) != want {
+			if time.Now().After(deadline) {
+				t.Fatalf("expected sensor %v, still %v", want, check(SensorHealthService))
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	waitFor(healthpb.HealthCheckResponse_SERVING)
+
+	setHealthy(false)
+	waitFor(healthpb.HealthCheckResponse_NOT_SERVING)
+	if got := check("light.v1.LightService"); got != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("expected the service itself still SERVING, got %v", got)
+	}
+
+	setHealthy(true)
+	waitFor(healthpb.HealthCheckResponse_SERVING)
+}
@@ -0,0 +1,95 @@
+package grpc
+
+import (
+	"context"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+// aggregatedHistory answers a GetHistory request with aggregation_interval_ms
+// set: per-bucket statistics computed by the repository, plus statistics
+// for the whole range derived from the buckets
+func (h *LightServiceHandler) aggregatedHistory(ctx context.Context, req *pb.GetHistoryRequest, start, end time.Time) (*pb.GetHistoryResponse, error) {
+	interval := time.Duration(req.AggregationIntervalMs) * time.Millisecond
+	if interval < time.Second || interval%time.Second != 0 {
+		return nil, status.Error(codes.InvalidArgument, "aggregation_interval_ms must be a positive whole number of seconds")
+	}
+	if req.Source != pb.ReadingSource_READING_SOURCE_UNSPECIFIED || req.CategoryFilter != nil ||
+		len(req.Percentiles) > 0 || req.Limit > 0 || req.PageToken != "" {
+		return nil, status.Error(codes.InvalidArgument,
+			"aggregation_interval_ms cannot be combined with source, category_filter, percentiles, limit or page_token")
+	}
+	if n := int64(end.Sub(start) / interval); !req.StatsOnly && h.maxHistory > 0 && n > int64(h.maxHistory) {
+		return nil, status.Errorf(codes.ResourceExhausted,
+			"range spans %d buckets of %s, more than the limit of %d; use a longer aggregation_interval_ms",
+			n, interval, h.maxHistory)
+	}
+
+	buckets, err := h.repo.AggregateReadingsInRange(ctx, start, end, interval)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to aggregate readings")
+		return nil, status.Error(codes.Internal, "failed to aggregate readings")
+	}
+
+	stats := bucketStatistics(buckets)
+	if req.Precision != nil {
+		stats = stats.rounded(int(*req.Precision))
+	}
+	resp := &pb.GetHistoryResponse{
+		AverageLux: stats.average,
+		MinLux:     stats.min,
+		MaxLux:     stats.max,
+	}
+	if req.StatsOnly {
+		return resp, nil
+	}
+
+	resp.Buckets = make([]*pb.ReadingBucket, len(buckets))
+	for i, b := range buckets {
+		s := statistics{count: int(b.Count), average: b.AverageLux, min: b.MinLux, max: b.MaxLux}
+		if req.Precision != nil {
+			s = s.rounded(int(*req.Precision))
+		}
+		resp.Buckets[i] = &pb.ReadingBucket{
+			StartTimeMs:  b.Start.UnixMilli(),
+			EndTimeMs:    b.Start.Add(interval).UnixMilli(),
+			ReadingCount: b.Count,
+			AverageLux:   s.average,
+			MinLux:       s.min,
+			MaxLux:       s.max,
+		}
+	}
+	if req.Order == pb.SortOrder_SORT_ORDER_DESCENDING {
+		slices.Reverse(resp.Buckets)
+	}
+	return resp, nil
+}
+
+// bucketStatistics combines per-bucket statistics into ones for the whole
+// range, weighting each bucket's average by its reading count
+func bucketStatistics(buckets []domain.ReadingBucket) statistics {
+	var sum float64
+	var count int
+	min := math.Inf(1)
+	max := math.Inf(-1)
+
+	for _, b := range buckets {
+		count += int(b.Count)
+		sum += b.AverageLux * float64(b.Count)
+		min = math.Min(min, b.MinLux)
+		max = math.Max(max, b.MaxLux)
+	}
+
+	if count == 0 {
+		return statistics{}
+	}
+	return statistics{count: count, average: sum / float64(count), min: min, max: max}
+}
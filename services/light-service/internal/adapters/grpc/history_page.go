@@ -0,0 +1,45 @@
+package grpc
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// errInvalidPageToken is returned for page tokens the server didn't issue,
+// or that were issued for the other order
+var errInvalidPageToken = errors.New("invalid page_token")
+
+// encodePageToken makes the opaque GetHistory page token resuming after
+// cursor. It records the order so a token can't be replayed against the
+// opposite one, where it would skip readings.
+func encodePageToken(cursor domain.ReadingCursor, descending bool) string {
+	order := "a"
+	if descending {
+		order = "d"
+	}
+	raw := fmt.Sprintf("%s:%d:%d", order, cursor.Timestamp.UnixNano(), cursor.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodePageToken parses a token from encodePageToken issued for the same order
+func decodePageToken(token string, descending bool) (domain.ReadingCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return domain.ReadingCursor{}, errInvalidPageToken
+	}
+
+	var order string
+	var nanos, id int64
+	if _, err := fmt.Sscanf(string(raw), "%1s:%d:%d", &order, &nanos, &id); err != nil {
+		return domain.ReadingCursor{}, errInvalidPageToken
+	}
+	if (order == "d") != descending || (order != "a" && order != "d") {
+		return domain.ReadingCursor{}, errInvalidPageToken
+	}
+
+	return domain.ReadingCursor{Timestamp: time.Unix(0, nanos), ID: id}, nil
+}
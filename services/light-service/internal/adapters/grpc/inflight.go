@@ -0,0 +1,42 @@
+package grpc
+
+import (
+	"context"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+)
+
+// InFlightCounter tracks how many RPCs are currently being served, so
+// shutdown can report what it is waiting on
+type InFlightCounter struct {
+	active atomic.Int64
+}
+
+// NewInFlightCounter creates a counter with no active RPCs
+func NewInFlightCounter() *InFlightCounter {
+	return &InFlightCounter{}
+}
+
+// Active returns the number of RPCs currently in progress
+func (c *InFlightCounter) Active() int64 {
+	return c.active.Load()
+}
+
+// UnaryInterceptor counts unary RPCs for the duration of the handler
+func (c *InFlightCounter) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		c.active.Add(1)
+		defer c.active.Add(-1)
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor counts streaming RPCs until the stream handler returns
+func (c *InFlightCounter) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		c.active.Add(1)
+		defer c.active.Add(-1)
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,103 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/mock"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+// blockingRepo holds GetLatestReading open until release is closed, to
+// simulate a slow RPC that is still running when shutdown begins
+type blockingRepo struct {
+	*memory.ReadingRepository
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (r *blockingRepo) GetLatestReading(ctx context.Context) (*domain.LightReading, error) {
+	close(r.entered)
+	<-r.release
+	return r.ReadingRepository.GetLatestReading(ctx)
+}
+
+func TestInFlightCounter_DrainsOnGracefulStop(t *testing.T) {
+	repo := &blockingRepo{
+		ReadingRepository: memory.NewReadingRepository(),
+		entered:           make(chan struct{}),
+		release:           make(chan struct{}),
+	}
+	counter := NewInFlightCounter()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(counter.UnaryInterceptor()),
+		grpc.ChainStreamInterceptor(counter.StreamInterceptor()),
+	)
+	pb.RegisterLightServiceServer(srv, NewLightServiceHandler(repo, mock.NewFakeSensor(500.0, 0)))
+	go srv.Serve(lis)
+
+	conn, err := grpc.NewClient(
+		lis.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewLightServiceClient(conn)
+
+	rpcDone := make(chan error, 1)
+	go func() {
+		_, err := client.GetCurrentLight(context.Background(), &pb.GetCurrentLightRequest{})
+		rpcDone <- err
+	}()
+
+	select {
+	case <-repo.entered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("slow RPC never reached the repository")
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+
+	// Shutdown has started but must wait for the slow RPC
+	if got := counter.Active(); got != 1 {
+		t.Fatalf("expected 1 active RPC during shutdown, got %d", got)
+	}
+	select {
+	case <-stopped:
+		t.Fatal("GracefulStop returned while an RPC was still in flight")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(repo.release)
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("GracefulStop did not return after the RPC finished")
+	}
+	if err := <-rpcDone; err != nil {
+		t.Errorf("in-flight RPC failed during shutdown: %v", err)
+	}
+	if got := counter.Active(); got != 0 {
+		t.Errorf("expected active RPCs to drain to 0, got %d", got)
+	}
+}
@@ -0,0 +1,66 @@
+package grpc
+
+import (
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+// KeepaliveConfig controls connection liveness and per-connection limits.
+// Without server pings, long-lived streams behind NAT are dropped silently
+// once the gateway forgets an idle mapping.
+type KeepaliveConfig struct {
+	// Time is how long a connection may be quiet before the server pings it
+	Time time.Duration
+
+	// Timeout is how long to wait for a ping ack before closing the connection
+	Timeout time.Duration
+
+	// MaxConnectionIdle closes connections with no active RPCs for this
+	// long; 0 keeps them open indefinitely
+	MaxConnectionIdle time.Duration
+
+	// MinPingInterval is the most often a client may ping; clients pinging
+	// faster are sent GOAWAY (too_many_pings)
+	MinPingInterval time.Duration
+
+	// PermitWithoutStream lets clients ping while they have no active RPC,
+	// so a client waiting to open the live stream keeps its connection
+	PermitWithoutStream bool
+
+	// MaxConcurrentStreams caps concurrent RPCs per connection; 0 leaves
+	// gRPC's default (unlimited)
+	MaxConcurrentStreams uint32
+}
+
+// DefaultKeepaliveConfig pings every 30s, well inside typical NAT timeouts,
+// and accepts client pings every 10s, which is grpc-go's own client minimum
+func DefaultKeepaliveConfig() KeepaliveConfig {
+	return KeepaliveConfig{
+		Time:                 30 * time.Second,
+		Timeout:              10 * time.Second,
+		MinPingInterval:      10 * time.Second,
+		PermitWithoutStream:  true,
+		MaxConcurrentStreams: 100,
+	}
+}
+
+// ServerOptions converts the config to gRPC server options
+func (c KeepaliveConfig) ServerOptions() []grpc.ServerOption {
+	opts := []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:              c.Time,
+			Timeout:           c.Timeout,
+			MaxConnectionIdle: c.MaxConnectionIdle,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             c.MinPingInterval,
+			PermitWithoutStream: c.PermitWithoutStream,
+		}),
+	}
+	if c.MaxConcurrentStreams > 0 {
+		opts = append(opts, grpc.MaxConcurrentStreams(c.MaxConcurrentStreams))
+	}
+	return opts
+}
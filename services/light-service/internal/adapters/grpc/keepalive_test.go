@@ -0,0 +1,109 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"golang.org/x/net/http2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// startKeepaliveServer serves the standard health service, whose Watch RPC
+// gives the tests a long-lived stream
+func startKeepaliveServer(t *testing.T, cfg KeepaliveConfig) (*health.Server, string) {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	srv := grpc.NewServer(cfg.ServerOptions()...)
+	hs := health.NewServer()
+	healthpb.RegisterHealthServer(srv, hs)
+
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	return hs, lis.Addr().String()
+}
+
+func TestKeepalive_StreamOutlivesIdleTimeout(t *testing.T) {
+	cfg := DefaultKeepaliveConfig()
+	cfg.MaxConnectionIdle = 50 * time.Millisecond
+	hs, addr := startKeepaliveServer(t, cfg)
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := healthpb.NewHealthClient(conn).Watch(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("initial Recv failed: %v", err)
+	}
+
+	// An open stream keeps the connection from counting as idle
+	time.Sleep(4 * cfg.MaxConnectionIdle)
+
+	hs.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("stream did not survive the idle period: %v", err)
+	}
+	if resp.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("expected NOT_SERVING update, got %v", resp.Status)
+	}
+}
+
+func TestKeepalive_RejectsAbusivePings(t *testing.T) {
+	cfg := DefaultKeepaliveConfig()
+	_, addr := startKeepaliveServer(t, cfg)
+
+	// grpc-go clients refuse to ping more often than every 10s, so speak
+	// HTTP/2 directly to flood the server with pings
+	nc, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	defer nc.Close()
+	_ = nc.SetDeadline(time.Now().Add(5 * time.Second))
+
+	if _, err := nc.Write([]byte(http2.ClientPreface)); err != nil {
+		t.Fatalf("failed to write preface: %v", err)
+	}
+	framer := http2.NewFramer(nc, nc)
+	if err := framer.WriteSettings(); err != nil {
+		t.Fatalf("failed to write settings: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		if err := framer.WritePing(false, [8]byte{byte(i)}); err != nil {
+			t.Fatalf("failed to write ping: %v", err)
+		}
+	}
+
+	for {
+		frame, err := framer.ReadFrame()
+		if err != nil {
+			t.Fatalf("connection ended without GOAWAY: %v", err)
+		}
+		if goAway, ok := frame.(*http2.GoAwayFrame); ok {
+			if goAway.ErrCode != http2.ErrCodeEnhanceYourCalm || string(goAway.DebugData()) != "too_many_pings" {
+				t.Errorf("expected ENHANCE_YOUR_CALM too_many_pings, got %v %q", goAway.ErrCode, goAway.DebugData())
+			}
+			return
+		}
+	}
+}
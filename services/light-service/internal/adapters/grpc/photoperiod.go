@@ -0,0 +1,77 @@
+package grpc
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+// maxPhotoperiodDays caps the dates one GetPhotoperiod call covers, as all
+// their readings are loaded at once
+const maxPhotoperiodDays = 366
+
+// WithPhotoperiodThreshold sets the lux at or above which GetPhotoperiod
+// counts a plant as lit, unless a request names its own
+func WithPhotoperiodThreshold(lux float64) HandlerOption {
+	return func(h *LightServiceHandler) {
+		h.photoperiodLux = lux
+	}
+}
+
+// GetPhotoperiod measures each date's light period in the range
+func (h *LightServiceHandler) GetPhotoperiod(ctx context.Context, req *pb.GetPhotoperiodRequest) (*pb.GetPhotoperiodResponse, error) {
+	zerolog.Ctx(ctx).Info().
+		Str("start_date", req.StartDate).
+		Str("end_date", req.EndDate).
+		Str("time_zone", req.TimeZone).
+		Float64("threshold_lux", req.ThresholdLux).
+		Msg("GetPhotoperiod called")
+
+	start, end, err := parseDateRange(req.StartDate, req.EndDate, req.TimeZone, maxPhotoperiodDays)
+	if err != nil {
+		return nil, err
+	}
+	if req.ThresholdLux < 0 || math.IsNaN(req.ThresholdLux) || math.IsInf(req.ThresholdLux, 0) {
+		return nil, status.Error(codes.InvalidArgument, "threshold_lux cannot be negative")
+	}
+
+	// As for DLI, a reading shortly before the first midnight still covers
+	// its start
+	readings, err := h.repo.GetReadingsInRange(ctx, start.Add(-h.maxGap), end)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to get readings")
+		return nil, status.Error(codes.Internal, "failed to get readings")
+	}
+
+	analyzer := domain.PhotoperiodAnalyzer{ThresholdLux: req.ThresholdLux, MaxGap: h.maxGap}
+	if analyzer.ThresholdLux == 0 {
+		analyzer.ThresholdLux = h.photoperiodLux
+	}
+	now := time.Now()
+	days := analyzer.Daily(readings, start, end, now)
+
+	resp := &pb.GetPhotoperiodResponse{
+		Days:         make([]*pb.DayPhotoperiod, len(days)),
+		ThresholdLux: analyzer.ThresholdLux,
+	}
+	for i, day := range days {
+		resp.Days[i] = &pb.DayPhotoperiod{
+			Date:         day.Day.Format(time.DateOnly),
+			LightHours:   day.Light.Hours(),
+			ReadingCount: int64(day.Readings),
+			Coverage:     dayCoverage(day.Day, day.Covered, now),
+		}
+		if !day.FirstLight.IsZero() {
+			resp.Days[i].FirstLightMs = day.FirstLight.UnixMilli()
+			resp.Days[i].LastLightMs = day.LastLight.UnixMilli()
+		}
+	}
+	return resp, nil
+}
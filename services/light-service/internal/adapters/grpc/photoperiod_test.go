@@ -0,0 +1,105 @@
+package grpc
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+func TestGetPhotoperiod(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	ctx := context.Background()
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	// Every 5 minutes on the first day: 500 lux until 08:00, 3000 lux until
+	// 20:00, then 500 lux until 22:00
+	for m := 6 * 60; m < 22*60; m += 5 {
+		lux := 500.0
+		if m >= 8*60 && m < 20*60 {
+			lux = 3000
+		}
+		r, _ := domain.NewLightReadingAt(lux, start.Add(time.Duration(m)*time.Minute))
+		_ = repo.SaveReading(ctx, r)
+	}
+	client := startTestServerWithRepo(t, repo)
+
+	resp, err := client.GetPhotoperiod(ctx, &pb.GetPhotoperiodRequest{
+		StartDate: "2024-06-01",
+		EndDate:   "2024-06-02",
+	})
+	if err != nil {
+		t.Fatalf("GetPhotoperiod failed: %v", err)
+	}
+	if len(resp.Days) != 2 || resp.Days[0].Date != "2024-06-01" || resp.Days[1].Date != "2024-06-02" {
+		t.Fatalf("expected 2024-06-01 and 2024-06-02, got %v", resp.Days)
+	}
+	if resp.ThresholdLux != domain.DefaultPhotoperiodThreshold {
+		t.Errorf("expected the default threshold, got %v", resp.ThresholdLux)
+	}
+
+	day := resp.Days[0]
+	if math.Abs(day.LightHours-12) > 1e-9 {
+		t.Errorf("expected 12 light hours, got %v", day.LightHours)
+	}
+	if want := start.Add(8 * time.Hour).UnixMilli(); day.FirstLightMs != want {
+		t.Errorf("expected first light at %d, got %d", want, day.FirstLightMs)
+	}
+	if want := start.Add(20 * time.Hour).UnixMilli(); day.LastLightMs != want {
+		t.Errorf("expected last light at %d, got %d", want, day.LastLightMs)
+	}
+	if day.ReadingCount != 192 {
+		t.Errorf("expected 192 readings, got %d", day.ReadingCount)
+	}
+	// 16 hours of readings, the last credited with the 15 minute cap
+	if want := (16 + 10.0/60) / 24; math.Abs(day.Coverage-want) > 1e-9 {
+		t.Errorf("expected coverage %v, got %v", want, day.Coverage)
+	}
+	if empty := resp.Days[1]; empty.LightHours != 0 || empty.FirstLightMs != 0 || empty.LastLightMs != 0 {
+		t.Errorf("expected no light on the second day, got %v", empty)
+	}
+
+	// A per-request threshold below the dim readings counts them too
+	dim, err := client.GetPhotoperiod(ctx, &pb.GetPhotoperiodRequest{
+		StartDate:    "2024-06-01",
+		EndDate:      "2024-06-01",
+		ThresholdLux: 100,
+	})
+	if err != nil {
+		t.Fatalf("GetPhotoperiod failed: %v", err)
+	}
+	if got := dim.Days[0]; math.Abs(got.LightHours-(16+10.0/60)) > 1e-9 || got.FirstLightMs != start.Add(6*time.Hour).UnixMilli() {
+		t.Errorf("expected light from 06:00 for all readings, got %v", got)
+	}
+}
+
+func TestGetPhotoperiod_InvalidRequests(t *testing.T) {
+	client := startTestServer(t)
+
+	tests := []struct {
+		name string
+		req  *pb.GetPhotoperiodRequest
+	}{
+		{"bad date", &pb.GetPhotoperiodRequest{StartDate: "June 1st", EndDate: "2024-06-02"}},
+		{"reversed", &pb.GetPhotoperiodRequest{StartDate: "2024-06-02", EndDate: "2024-06-01"}},
+		{"unknown zone", &pb.GetPhotoperiodRequest{StartDate: "2024-06-01", EndDate: "2024-06-01", TimeZone: "Mars/Olympus"}},
+		{"too many days", &pb.GetPhotoperiodRequest{StartDate: "2024-01-01", EndDate: "2025-06-01"}},
+		{"negative threshold", &pb.GetPhotoperiodRequest{StartDate: "2024-06-01", EndDate: "2024-06-01", ThresholdLux: -1}},
+		{"NaN threshold", &pb.GetPhotoperiodRequest{StartDate: "2024-06-01", EndDate: "2024-06-01", ThresholdLux: math.NaN()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.GetPhotoperiod(context.Background(), tt.req)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+// GenerateReport summarizes a time range from the same building blocks as
+// GetHistory and GetCategoryEvents
+func (h *LightServiceHandler) GenerateReport(ctx context.Context, req *pb.ReportRequest) (*pb.ReportResponse, error) {
+	zerolog.Ctx(ctx).Info().
+		Int64("start_ms", req.StartTimeMs).
+		Int64("end_ms", req.EndTimeMs).
+		Msg("GenerateReport called")
+
+	start, end := time.UnixMilli(req.StartTimeMs), time.UnixMilli(req.EndTimeMs)
+	if !end.After(start) {
+		return nil, status.Error(codes.InvalidArgument, "end_time_ms must be after start_time_ms")
+	}
+	if req.ExpectedIntervalMs < 0 || req.GapMultiplier < 0 || math.IsNaN(req.GapMultiplier) {
+		return nil, status.Error(codes.InvalidArgument, "expected_interval_ms and gap_multiplier cannot be negative")
+	}
+	gapThreshold := h.gapThreshold(req.ExpectedIntervalMs, req.GapMultiplier)
+
+	readings, err := h.repo.GetReadingsInRange(ctx, start, end)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to get readings")
+		return nil, status.Error(codes.Internal, "failed to get readings")
+	}
+	events, err := h.repo.GetCategoryEvents(ctx, start, end)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to get category events")
+		return nil, status.Error(codes.Internal, "failed to get category events")
+	}
+
+	// As in GetHistory, the last reading covers time up to the end of the
+	// range but not into the future
+	until := end
+	if now := time.Now(); now.Before(until) {
+		until = now
+	}
+
+	stats := calculateStatistics(readings)
+	resp := &pb.ReportResponse{
+		ReadingCount:    int64(stats.count),
+		AverageLux:      stats.average,
+		MinLux:          stats.min,
+		MaxLux:          stats.max,
+		MedianLux:       domain.Percentile(sortedLux(readings), 50),
+		Dli:             domain.DailyLightIntegral(h.dliEstimator(0).Integral(readings, until), until.Sub(start)),
+		TimeInCategory:  h.timeInCategory(readings, until),
+		CategoryChanges: int64(len(events)),
+	}
+
+	var gapTime time.Duration
+	resp.Gaps, gapTime = convertGapsToProto(domain.FindGaps(readings, gapThreshold))
+	if len(readings) > 0 {
+		resp.UptimeFraction = 1
+		if span := readings[len(readings)-1].Timestamp.Sub(readings[0].Timestamp); span > 0 {
+			resp.UptimeFraction = 1 - float64(gapTime)/float64(span)
+		}
+	}
+
+	resp.Summary = reportSummary(start, end, resp, gapTime)
+	return resp, nil
+}
+
+// reportSummary renders the report as text for people, e.g. in a
+// notification
+func reportSummary(start, end time.Time, r *pb.ReportResponse, gapTime time.Duration) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s to %s: ", start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339))
+	if r.ReadingCount == 0 {
+		b.WriteString("no readings.")
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "%d readings, average %.0f lux (min %.0f, median %.0f, max %.0f), DLI %.2f mol/m²/day.",
+		r.ReadingCount, r.AverageLux, r.MinLux, r.MedianLux, r.MaxLux, r.Dli)
+
+	shares := make([]string, len(r.TimeInCategory))
+	for i, c := range r.TimeInCategory {
+		shares[i] = fmt.Sprintf("%s %.0f%%", c.Category, c.Fraction*100)
+	}
+	fmt.Fprintf(&b, " %s. %d category changes.", strings.Join(shares, ", "), r.CategoryChanges)
+
+	if len(r.Gaps) == 0 {
+		b.WriteString(" No recording gaps.")
+	} else {
+		fmt.Fprintf(&b, " %d recording gaps totalling %s (uptime %.1f%%).", len(r.Gaps), gapTime, r.UptimeFraction*100)
+	}
+	return b.String()
+}
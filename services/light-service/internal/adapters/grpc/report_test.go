@@ -0,0 +1,115 @@
+package grpc
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+func TestGenerateReport(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	ctx := context.Background()
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	// Low for 30 minutes, then Medium, then an hour-long gap before one
+	// High reading five minutes before the end
+	save := func(minute int, lux float64) {
+		r, _ := domain.NewLightReadingAt(lux, start.Add(time.Duration(minute)*time.Minute))
+		_ = repo.SaveReading(ctx, r)
+	}
+	for m := 0; m < 30; m += 5 {
+		save(m, 100)
+	}
+	for m := 30; m < 60; m += 5 {
+		save(m, 1000)
+	}
+	save(115, 3000)
+	_ = repo.SaveCategoryEvent(ctx, &domain.CategoryEvent{From: domain.CategoryLow, To: domain.CategoryMedium, Lux: 1000, Timestamp: start.Add(30 * time.Minute)})
+	_ = repo.SaveCategoryEvent(ctx, &domain.CategoryEvent{From: domain.CategoryMedium, To: domain.CategoryHigh, Lux: 3000, Timestamp: start.Add(115 * time.Minute)})
+
+	client := startTestServerWithRepo(t, repo)
+	resp, err := client.GenerateReport(ctx, &pb.ReportRequest{
+		StartTimeMs: start.UnixMilli(),
+		EndTimeMs:   start.Add(2 * time.Hour).UnixMilli(),
+	})
+	if err != nil {
+		t.Fatalf("GenerateReport failed: %v", err)
+	}
+
+	approx := func(name string, got, want float64) {
+		t.Helper()
+		if math.Abs(got-want) > 1e-9 {
+			t.Errorf("%s: expected %v, got %v", name, want, got)
+		}
+	}
+	if resp.ReadingCount != 13 {
+		t.Errorf("expected 13 readings, got %d", resp.ReadingCount)
+	}
+	approx("average", resp.AverageLux, 9600.0/13)
+	approx("min", resp.MinLux, 100)
+	approx("max", resp.MaxLux, 3000)
+	approx("median", resp.MedianLux, 1000)
+
+	// 30m at 100 lux, 40m at 1000 (the last Medium reading is capped at
+	// 15m) and 5m at 3000, converted to mol/m² and scaled from 2h to a day
+	approx("dli", resp.Dli, (100*30+1000*40+3000*5)*60*domain.LuxToPPFD/1e6*12)
+
+	wantMinutes := []float64{30, 40, 5}
+	for i, c := range resp.TimeInCategory {
+		approx(c.Category, c.Seconds, wantMinutes[i]*60)
+	}
+	if resp.CategoryChanges != 2 {
+		t.Errorf("expected 2 category changes, got %d", resp.CategoryChanges)
+	}
+
+	if len(resp.Gaps) != 1 {
+		t.Fatalf("expected 1 gap, got %d", len(resp.Gaps))
+	}
+	if g := resp.Gaps[0]; g.StartTimeMs != start.Add(55*time.Minute).UnixMilli() || g.EndTimeMs != start.Add(115*time.Minute).UnixMilli() {
+		t.Errorf("unexpected gap %+v", g)
+	}
+	approx("uptime", resp.UptimeFraction, 55.0/115)
+
+	for _, want := range []string{"13 readings", "2 category changes", "1 recording gaps totalling 1h0m0s"} {
+		if !strings.Contains(resp.Summary, want) {
+			t.Errorf("summary %q should mention %q", resp.Summary, want)
+		}
+	}
+
+	// A longer expected interval tolerates the gap
+	resp, err = client.GenerateReport(ctx, &pb.ReportRequest{
+		StartTimeMs:        start.UnixMilli(),
+		EndTimeMs:          start.Add(2 * time.Hour).UnixMilli(),
+		ExpectedIntervalMs: (30 * time.Minute).Milliseconds(),
+	})
+	if err != nil {
+		t.Fatalf("GenerateReport failed: %v", err)
+	}
+	if len(resp.Gaps) != 0 || resp.UptimeFraction != 1 {
+		t.Errorf("expected no gaps at a 30m interval, got %d (uptime %v)", len(resp.Gaps), resp.UptimeFraction)
+	}
+}
+
+func TestGenerateReport_InvalidRange(t *testing.T) {
+	client := startTestServer(t)
+	now := time.Now().UnixMilli()
+
+	for _, req := range []*pb.ReportRequest{
+		{StartTimeMs: now, EndTimeMs: now},
+		{StartTimeMs: now, EndTimeMs: now - 1},
+		{StartTimeMs: now - 1000, EndTimeMs: now, GapMultiplier: -1},
+	} {
+		if _, err := client.GenerateReport(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%+v: expected InvalidArgument, got %v", req, err)
+		}
+	}
+}
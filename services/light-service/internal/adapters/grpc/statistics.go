@@ -0,0 +1,89 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+// maxStatisticsDays caps the days one daily GetStatistics call summarizes,
+// as each is a separate repository query
+const maxStatisticsDays = 366
+
+// GetStatistics summarizes lux over the range, and per day if asked
+func (h *LightServiceHandler) GetStatistics(ctx context.Context, req *pb.GetStatisticsRequest) (*pb.GetStatisticsResponse, error) {
+	zerolog.Ctx(ctx).Info().
+		Int64("start_time_ms", req.StartTimeMs).
+		Int64("end_time_ms", req.EndTimeMs).
+		Bool("daily", req.Daily).
+		Msg("GetStatistics called")
+
+	if req.EndTimeMs <= req.StartTimeMs {
+		return nil, status.Error(codes.InvalidArgument, "end_time_ms must be after start_time_ms")
+	}
+	start, end := time.UnixMilli(req.StartTimeMs), time.UnixMilli(req.EndTimeMs)
+
+	var days []time.Time
+	if req.Daily {
+		loc, err := time.LoadLocation(req.TimeZone)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown time_zone %q", req.TimeZone)
+		}
+		for day := domain.CalendarDay(start, loc); day.Before(end); day = day.AddDate(0, 0, 1) {
+			if len(days) == maxStatisticsDays {
+				return nil, status.Errorf(codes.InvalidArgument, "daily statistics cannot cover more than %d days", maxStatisticsDays)
+			}
+			days = append(days, day)
+		}
+	}
+
+	overall, err := h.repo.GetStatisticsInRange(ctx, start, end)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to get statistics")
+		return nil, status.Error(codes.Internal, "failed to get statistics")
+	}
+	resp := &pb.GetStatisticsResponse{Overall: convertLuxStatisticsToProto(overall)}
+
+	for _, day := range days {
+		// The first and last days are clipped to the range
+		dayStart, dayEnd := day, day.AddDate(0, 0, 1)
+		if dayStart.Before(start) {
+			dayStart = start
+		}
+		if dayEnd.After(end) {
+			dayEnd = end
+		}
+
+		stats, err := h.repo.GetStatisticsInRange(ctx, dayStart, dayEnd)
+		if err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Time("day", day).Msg("failed to get daily statistics")
+			return nil, status.Error(codes.Internal, "failed to get statistics")
+		}
+		resp.Days = append(resp.Days, &pb.DayStatistics{
+			Date:        day.Format(time.DateOnly),
+			StartTimeMs: dayStart.UnixMilli(),
+			EndTimeMs:   dayEnd.UnixMilli(),
+			Statistics:  convertLuxStatisticsToProto(stats),
+		})
+	}
+	return resp, nil
+}
+
+// convertLuxStatisticsToProto converts repository statistics to the API type
+func convertLuxStatisticsToProto(s domain.LuxStatistics) *pb.LuxStatistics {
+	return &pb.LuxStatistics{
+		ReadingCount: s.Count,
+		AverageLux:   s.AverageLux,
+		MinLux:       s.MinLux,
+		MaxLux:       s.MaxLux,
+		MedianLux:    s.MedianLux,
+		P95Lux:       s.P95Lux,
+		StddevLux:    s.StdDevLux,
+	}
+}
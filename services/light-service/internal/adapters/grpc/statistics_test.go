@@ -0,0 +1,109 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+func TestGetStatistics(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	ctx := context.Background()
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+
+	// Readings at 10:00 Paris time on 1 June (100, 300) and 2 June (500)
+	day := time.Date(2024, 6, 1, 10, 0, 0, 0, paris)
+	for _, r := range []struct {
+		at  time.Time
+		lux float64
+	}{
+		{day, 100},
+		{day.Add(time.Hour), 300},
+		{day.AddDate(0, 0, 1), 500},
+	} {
+		reading, _ := domain.NewLightReadingAt(r.lux, r.at)
+		_ = repo.SaveReading(ctx, reading)
+	}
+	client := startTestServerWithRepo(t, repo)
+
+	// From 06:00 on 1 June to 06:00 on 3 June, Paris time
+	start := time.Date(2024, 6, 1, 6, 0, 0, 0, paris)
+	end := start.AddDate(0, 0, 2)
+	resp, err := client.GetStatistics(ctx, &pb.GetStatisticsRequest{
+		StartTimeMs: start.UnixMilli(),
+		EndTimeMs:   end.UnixMilli(),
+		Daily:       true,
+		TimeZone:    "Europe/Paris",
+	})
+	if err != nil {
+		t.Fatalf("GetStatistics failed: %v", err)
+	}
+
+	overall := resp.Overall
+	if overall.ReadingCount != 3 || overall.AverageLux != 300 || overall.MinLux != 100 ||
+		overall.MaxLux != 500 || overall.MedianLux != 300 || overall.P95Lux != 480 {
+		t.Errorf("unexpected overall statistics %+v", overall)
+	}
+
+	if len(resp.Days) != 3 {
+		t.Fatalf("expected 3 days touched by the range, got %d", len(resp.Days))
+	}
+	first, last := resp.Days[0], resp.Days[2]
+	if first.Date != "2024-06-01" || first.StartTimeMs != start.UnixMilli() {
+		t.Errorf("expected the first day clipped to the range start, got %s from %d", first.Date, first.StartTimeMs)
+	}
+	if first.Statistics.ReadingCount != 2 || first.Statistics.AverageLux != 200 || first.Statistics.StddevLux != 100 {
+		t.Errorf("unexpected first day statistics %+v", first.Statistics)
+	}
+	if resp.Days[1].Statistics.ReadingCount != 1 || resp.Days[1].Statistics.MedianLux != 500 {
+		t.Errorf("unexpected second day statistics %+v", resp.Days[1].Statistics)
+	}
+	if last.Date != "2024-06-03" || last.EndTimeMs != end.UnixMilli() || last.Statistics.ReadingCount != 0 {
+		t.Errorf("expected an empty last day clipped to the range end, got %+v", last)
+	}
+
+	// Without daily, only the overall statistics come back
+	resp, err = client.GetStatistics(ctx, &pb.GetStatisticsRequest{StartTimeMs: start.UnixMilli(), EndTimeMs: end.UnixMilli()})
+	if err != nil {
+		t.Fatalf("GetStatistics failed: %v", err)
+	}
+	if resp.Overall.ReadingCount != 3 || len(resp.Days) != 0 {
+		t.Errorf("expected overall statistics only, got %d days", len(resp.Days))
+	}
+}
+
+func TestGetStatistics_InvalidRequests(t *testing.T) {
+	client := startTestServerWithRepo(t, memory.NewReadingRepository())
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		req  *pb.GetStatisticsRequest
+	}{
+		{"empty range", &pb.GetStatisticsRequest{StartTimeMs: start.UnixMilli(), EndTimeMs: start.UnixMilli()}},
+		{"unknown zone", &pb.GetStatisticsRequest{
+			StartTimeMs: start.UnixMilli(), EndTimeMs: start.Add(time.Hour).UnixMilli(), Daily: true, TimeZone: "Mars/Olympus",
+		}},
+		{"too many days", &pb.GetStatisticsRequest{
+			StartTimeMs: start.UnixMilli(), EndTimeMs: start.AddDate(2, 0, 0).UnixMilli(), Daily: true,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.GetStatistics(context.Background(), tt.req)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
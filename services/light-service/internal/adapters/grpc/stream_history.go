@@ -0,0 +1,76 @@
+package grpc
+
+import (
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	pb "github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+// StreamHistory streams the readings in a range one message each, paging
+// through the repository as DownloadReadings does. Send blocks while the
+// client's flow-control window is full, so the next page is only fetched
+// once the client has taken the current one.
+func (h *LightServiceHandler) StreamHistory(req *pb.StreamHistoryRequest, stream pb.LightService_StreamHistoryServer) error {
+	ctx := stream.Context()
+	zerolog.Ctx(ctx).Info().
+		Int64("start_ms", req.StartTimeMs).
+		Int64("end_ms", req.EndTimeMs).
+		Str("source", req.Source.String()).
+		Str("order", req.Order.String()).
+		Msg("StreamHistory called")
+
+	start, end := time.UnixMilli(req.StartTimeMs), time.UnixMilli(req.EndTimeMs)
+	if !end.After(start) {
+		return status.Error(codes.InvalidArgument, "end_time_ms must be after start_time_ms")
+	}
+
+	pageSize := int(req.PageSize)
+	switch {
+	case pageSize < 0:
+		return status.Error(codes.InvalidArgument, "page_size cannot be negative")
+	case pageSize == 0:
+		pageSize = defaultExportBatchSize
+	case pageSize > maxExportBatchSize:
+		pageSize = maxExportBatchSize
+	}
+
+	base := []domain.RangeOption{domain.WithLimit(pageSize)}
+	if req.Order == pb.SortOrder_SORT_ORDER_DESCENDING {
+		base = append(base, domain.WithDescending())
+	}
+	filterSource := req.Source != pb.ReadingSource_READING_SOURCE_UNSPECIFIED
+	source := convertSourceFromProto(req.Source)
+
+	opts := base
+	var streamed int
+	for {
+		page, err := h.repo.GetReadingsInRange(ctx, start, end, opts...)
+		if err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Msg("failed to get readings for history stream")
+			return status.Error(codes.Internal, "failed to get readings")
+		}
+
+		for _, r := range page {
+			if filterSource && r.Source != source {
+				continue
+			}
+			if err := stream.Send(h.convertReadingToProto(r)); err != nil {
+				return err
+			}
+			streamed++
+		}
+
+		if len(page) < pageSize {
+			break
+		}
+		opts = append(base[:len(base):len(base)], domain.WithCursor(domain.CursorAfter(page[len(page)-1])))
+	}
+
+	zerolog.Ctx(ctx).Info().Int("streamed", streamed).Msg("history stream completed")
+	return nil
+}
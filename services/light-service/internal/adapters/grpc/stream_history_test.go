@@ -0,0 +1,129 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+// pagingRepo records the limit of every range query, to check a stream
+// pages through the store rather than loading the range at once
+type pagingRepo struct {
+	*memory.ReadingRepository
+	mu     sync.Mutex
+	limits []int
+}
+
+func (r *pagingRepo) GetReadingsInRange(ctx context.Context, start, end time.Time, opts ...domain.RangeOption) ([]*domain.LightReading, error) {
+	r.mu.Lock()
+	r.limits = append(r.limits, domain.NewRangeQuery(opts...).Limit)
+	r.mu.Unlock()
+	return r.ReadingRepository.GetReadingsInRange(ctx, start, end, opts...)
+}
+
+// streamHistory collects a StreamHistory stream
+func streamHistory(t *testing.T, client pb.LightServiceClient, req *pb.StreamHistoryRequest) ([]*pb.LightReading, error) {
+	t.Helper()
+	stream, err := client.StreamHistory(context.Background(), req)
+	if err != nil {
+		t.Fatalf("StreamHistory failed: %v", err)
+	}
+	var readings []*pb.LightReading
+	for {
+		r, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return readings, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		readings = append(readings, r)
+	}
+}
+
+func TestStreamHistory(t *testing.T) {
+	repo := &pagingRepo{ReadingRepository: memory.NewReadingRepository()}
+	ctx := context.Background()
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 8 {
+		r, _ := domain.NewLightReadingAt(float64(100*(i+1)), start.Add(time.Duration(i)*time.Hour))
+		if i%2 == 1 {
+			r.Source = domain.SourceManual
+		}
+		_ = repo.SaveReading(ctx, r)
+	}
+	client := startTestServerWithRepo(t, repo)
+
+	// The last reading is outside the half-open range
+	readings, err := streamHistory(t, client, &pb.StreamHistoryRequest{
+		StartTimeMs: start.UnixMilli(),
+		EndTimeMs:   start.Add(7 * time.Hour).UnixMilli(),
+		PageSize:    3,
+	})
+	if err != nil {
+		t.Fatalf("stream failed: %v", err)
+	}
+	if len(readings) != 7 {
+		t.Fatalf("expected 7 readings, got %d", len(readings))
+	}
+	for i, r := range readings {
+		if r.Lux != float64(100*(i+1)) {
+			t.Errorf("reading %d: expected %v lux oldest first, got %v", i, 100*(i+1), r.Lux)
+		}
+	}
+	if len(repo.limits) != 3 || repo.limits[0] != 3 || repo.limits[2] != 3 {
+		t.Errorf("expected 3 pages of 3, got limits %v", repo.limits)
+	}
+
+	// Newest first, manual readings only
+	readings, err = streamHistory(t, client, &pb.StreamHistoryRequest{
+		StartTimeMs: start.UnixMilli(),
+		EndTimeMs:   start.Add(8 * time.Hour).UnixMilli(),
+		Source:      pb.ReadingSource_READING_SOURCE_MANUAL,
+		Order:       pb.SortOrder_SORT_ORDER_DESCENDING,
+		PageSize:    3,
+	})
+	if err != nil {
+		t.Fatalf("stream failed: %v", err)
+	}
+	var lux []float64
+	for _, r := range readings {
+		lux = append(lux, r.Lux)
+	}
+	if len(lux) != 4 || lux[0] != 800 || lux[3] != 200 {
+		t.Errorf("expected manual readings 800, 600, 400, 200, got %v", lux)
+	}
+}
+
+func TestStreamHistory_InvalidRequests(t *testing.T) {
+	client := startTestServer(t)
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		req  *pb.StreamHistoryRequest
+	}{
+		{"empty range", &pb.StreamHistoryRequest{StartTimeMs: start.UnixMilli(), EndTimeMs: start.UnixMilli()}},
+		{"negative page size", &pb.StreamHistoryRequest{
+			StartTimeMs: start.UnixMilli(), EndTimeMs: start.Add(time.Hour).UnixMilli(), PageSize: -1,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := streamHistory(t, client, tt.req)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package i2c
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// BH1750 addresses: ADDR pin low (the GY-302 module's default) or high
+const (
+	BH1750AddressLow  uint16 = 0x23
+	BH1750AddressHigh uint16 = 0x5c
+)
+
+// Mode is a BH1750 measurement mode, named by its opcode
+type Mode byte
+
+const (
+	// ContinuousHighRes measures continuously at 1 lx resolution (default)
+	ContinuousHighRes Mode = 0x10
+	// ContinuousHighRes2 measures continuously at 0.5 lx resolution
+	ContinuousHighRes2 Mode = 0x11
+	// ContinuousLowRes measures continuously at 4 lx resolution, but faster
+	ContinuousLowRes Mode = 0x13
+	// OneTimeHighRes measures once per read, powering down in between
+	OneTimeHighRes Mode = 0x20
+	// OneTimeHighRes2 is OneTimeHighRes at 0.5 lx resolution
+	OneTimeHighRes2 Mode = 0x21
+	// OneTimeLowRes is OneTimeHighRes at 4 lx resolution
+	OneTimeLowRes Mode = 0x23
+)
+
+var modeNames = map[string]Mode{
+	"continuous-high":  ContinuousHighRes,
+	"continuous-high2": ContinuousHighRes2,
+	"continuous-low":   ContinuousLowRes,
+	"one-time-high":    OneTimeHighRes,
+	"one-time-high2":   OneTimeHighRes2,
+	"one-time-low":     OneTimeLowRes,
+}
+
+// ParseMode parses a mode name such as "continuous-high" or "one-time-low";
+// empty means ContinuousHighRes
+func ParseMode(s string) (Mode, error) {
+	if s == "" {
+		return ContinuousHighRes, nil
+	}
+	mode, ok := modeNames[s]
+	if !ok {
+		return 0, fmt.Errorf("unknown BH1750 mode %q", s)
+	}
+	return mode, nil
+}
+
+func (m Mode) oneTime() bool {
+	return m&0xf0 == 0x20
+}
+
+// measurementTime is the datasheet's maximum conversion time for the mode
+func (m Mode) measurementTime() time.Duration {
+	if m&0x0f == 0x03 {
+		return 24 * time.Millisecond
+	}
+	return 180 * time.Millisecond
+}
+
+// luxPerCount converts a raw count to lux at the default sensitivity
+func (m Mode) luxPerCount() float64 {
+	if m&0x0f == 0x01 {
+		return 1 / 1.2 / 2
+	}
+	return 1 / 1.2
+}
+
+// BH1750 opcodes besides the measurement modes
+const (
+	bh1750PowerDown byte = 0x00
+	bh1750PowerOn   byte = 0x01
+)
+
+// bh1750MaxCount is the highest raw count; a reading there is saturated
+const bh1750MaxCount = 0xffff
+
+// BH1750 reads an ambient light sensor that measures lux directly, up to
+// about 65k lx
+// This implements the ports.LightSensor and ports.QualitySensor interfaces
+type BH1750 struct {
+	dev  Device
+	mode Mode
+
+	mu    sync.Mutex
+	ready time.Time // when a continuous mode's first measurement is done; zero once waited for
+	wait  func(ctx context.Context, d time.Duration) error
+}
+
+// NewBH1750 powers the sensor on and starts measuring in mode
+func NewBH1750(dev Device, mode Mode) (*BH1750, error) {
+	s := &BH1750{dev: dev, mode: mode, wait: sleep}
+	if err := dev.Tx([]byte{bh1750PowerOn}, nil); err != nil {
+		return nil, fmt.Errorf("failed to power on BH1750: %w", err)
+	}
+	if !mode.oneTime() {
+		if err := dev.Tx([]byte{byte(mode)}, nil); err != nil {
+			return nil, fmt.Errorf("failed to set BH1750 mode: %w", err)
+		}
+		s.ready = time.Now().Add(mode.measurementTime())
+	}
+	return s, nil
+}
+
+// ReadLux returns the current light level in lux
+func (s *BH1750) ReadLux(ctx context.Context) (float64, error) {
+	lux, _, err := s.ReadLuxWithQuality(ctx)
+	return lux, err
+}
+
+// ReadLuxWithQuality returns the current light level in lux, flagged as
+// saturated at the top of the sensor's range
+func (s *BH1750) ReadLuxWithQuality(ctx context.Context) (float64, domain.Quality, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.mode.oneTime() {
+		// The sensor powers down after each one-time measurement, and the
+		// mode command wakes it for the next
+		if err := s.dev.Tx([]byte{byte(s.mode)}, nil); err != nil {
+			return 0, "", fmt.Errorf("failed to start BH1750 measurement: %w", err)
+		}
+		if err := s.wait(ctx, s.mode.measurementTime()); err != nil {
+			return 0, "", err
+		}
+	} else if !s.ready.IsZero() {
+		if err := s.wait(ctx, time.Until(s.ready)); err != nil {
+			return 0, "", err
+		}
+		s.ready = time.Time{}
+	}
+
+	var buf [2]byte
+	if err := s.dev.Tx(nil, buf[:]); err != nil {
+		return 0, "", fmt.Errorf("failed to read BH1750: %w", err)
+	}
+	count := binary.BigEndian.Uint16(buf[:])
+
+	quality := domain.QualityOK
+	if count == bh1750MaxCount {
+		quality = domain.QualitySaturated
+	}
+	return float64(count) * s.mode.luxPerCount(), quality, nil
+}
+
+// Close powers the sensor down and releases the bus
+func (s *BH1750) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	powerErr := s.dev.Tx([]byte{bh1750PowerDown}, nil)
+	if err := s.dev.Close(); err != nil {
+		return err
+	}
+	return powerErr
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
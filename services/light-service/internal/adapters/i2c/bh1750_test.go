@@ -0,0 +1,162 @@
+package i2c
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// fakeDevice records writes and answers reads with a fixed count
+type fakeDevice struct {
+	writes [][]byte
+	count  [2]byte
+	closed bool
+}
+
+func (d *fakeDevice) Tx(w, r []byte) error {
+	if len(w) > 0 {
+		d.writes = append(d.writes, append([]byte(nil), w...))
+	}
+	copy(r, d.count[:])
+	return nil
+}
+
+func (d *fakeDevice) Close() error {
+	d.closed = true
+	return nil
+}
+
+// noWait skips measurement delays, recording them
+func noWait(waits *[]time.Duration) func(context.Context, time.Duration) error {
+	return func(ctx context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		return ctx.Err()
+	}
+}
+
+func TestBH1750_ReadLux(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    Mode
+		count   [2]byte
+		want    float64
+		quality domain.Quality
+	}{
+		{"high res", ContinuousHighRes, [2]byte{0x01, 0x2c}, 250, domain.QualityOK}, // 300 / 1.2
+		{"high res 2", ContinuousHighRes2, [2]byte{0x01, 0x2c}, 125, domain.QualityOK},
+		{"low res", OneTimeLowRes, [2]byte{0x00, 0x0c}, 10, domain.QualityOK},
+		{"dark", ContinuousHighRes, [2]byte{0, 0}, 0, domain.QualityOK},
+		{"saturated", ContinuousHighRes, [2]byte{0xff, 0xff}, 65535 / 1.2, domain.QualitySaturated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev := &fakeDevice{count: tt.count}
+			s, err := NewBH1750(dev, tt.mode)
+			if err != nil {
+				t.Fatalf("NewBH1750 failed: %v", err)
+			}
+			var waits []time.Duration
+			s.wait = noWait(&waits)
+
+			lux, quality, err := s.ReadLuxWithQuality(context.Background())
+			if err != nil {
+				t.Fatalf("ReadLuxWithQuality failed: %v", err)
+			}
+			if diff := lux - tt.want; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("expected %v lux, got %v", tt.want, lux)
+			}
+			if quality != tt.quality {
+				t.Errorf("expected quality %q, got %q", tt.quality, quality)
+			}
+		})
+	}
+}
+
+func TestBH1750_Commands(t *testing.T) {
+	// Continuous: power on and set the mode once, then just read
+	dev := &fakeDevice{}
+	s, err := NewBH1750(dev, ContinuousHighRes)
+	if err != nil {
+		t.Fatalf("NewBH1750 failed: %v", err)
+	}
+	var waits []time.Duration
+	s.wait = noWait(&waits)
+	for range 2 {
+		if _, err := s.ReadLux(context.Background()); err != nil {
+			t.Fatalf("ReadLux failed: %v", err)
+		}
+	}
+	if want := [][]byte{{0x01}, {0x10}}; !equalWrites(dev.writes, want) {
+		t.Errorf("expected writes %x, got %x", want, dev.writes)
+	}
+	if len(waits) != 1 {
+		t.Errorf("expected only the first read to wait, got %v", waits)
+	}
+
+	// One-time: start a measurement and wait for it on every read
+	dev = &fakeDevice{}
+	s, err = NewBH1750(dev, OneTimeHighRes)
+	if err != nil {
+		t.Fatalf("NewBH1750 failed: %v", err)
+	}
+	waits = nil
+	s.wait = noWait(&waits)
+	for range 2 {
+		if _, err := s.ReadLux(context.Background()); err != nil {
+			t.Fatalf("ReadLux failed: %v", err)
+		}
+	}
+	if want := [][]byte{{0x01}, {0x20}, {0x20}}; !equalWrites(dev.writes, want) {
+		t.Errorf("expected writes %x, got %x", want, dev.writes)
+	}
+	if len(waits) != 2 || waits[0] != 180*time.Millisecond {
+		t.Errorf("expected two 180ms waits, got %v", waits)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if last := dev.writes[len(dev.writes)-1]; !bytes.Equal(last, []byte{0x00}) || !dev.closed {
+		t.Errorf("expected power down and close, got last write %x, closed %v", last, dev.closed)
+	}
+}
+
+func TestBH1750_ReadHonorsContext(t *testing.T) {
+	s, err := NewBH1750(&fakeDevice{}, OneTimeHighRes)
+	if err != nil {
+		t.Fatalf("NewBH1750 failed: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := s.ReadLux(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	for name, want := range map[string]Mode{"": ContinuousHighRes, "continuous-low": ContinuousLowRes, "one-time-high2": OneTimeHighRes2} {
+		if got, err := ParseMode(name); err != nil || got != want {
+			t.Errorf("ParseMode(%q) = %#x, %v; want %#x", name, got, err, want)
+		}
+	}
+	if _, err := ParseMode("fast"); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+}
+
+func equalWrites(got, want [][]byte) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if !bytes.Equal(got[i], want[i]) {
+			return false
+		}
+	}
+	return true
+}
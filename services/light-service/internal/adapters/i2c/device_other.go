@@ -0,0 +1,10 @@
+//go:build !linux
+
+package i2c
+
+import "errors"
+
+// Open is only implemented on Linux, via i2c-dev
+func Open(bus int, addr uint16) (Device, error) {
+	return nil, errors.New("I2C is only supported on Linux")
+}
@@ -0,0 +1,195 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// chunkSize is how many readings are upserted per transaction. The body is
+// decoded one entry at a time, so memory use is bounded by this rather than
+// by the size of the upload.
+const chunkSize = 500
+
+// maxClockSkew is how far into the future an imported timestamp may be
+const maxClockSkew = time.Minute
+
+// maxReportedErrors caps the per-entry errors echoed back in the summary
+const maxReportedErrors = 100
+
+// entry is one element of the import array. Note is accepted so exports
+// from the old system load unchanged, but readings have nowhere to store it.
+type entry struct {
+	Timestamp time.Time `json:"timestamp"` // RFC 3339
+	Lux       *float64  `json:"lux"`
+	Note      string    `json:"note,omitempty"`
+}
+
+// EntryError describes why one array element was not imported
+type EntryError struct {
+	Index  int    `json:"index"`
+	Reason string `json:"reason"`
+}
+
+// Summary is the response body of an import. When saving a chunk fails,
+// Error says why and the counts cover the chunks saved before it.
+type Summary struct {
+	Imported int          `json:"imported"`
+	Skipped  int          `json:"skipped"` // repeats of a timestamp earlier in the same chunk
+	Errored  int          `json:"errored"`
+	Errors   []EntryError `json:"errors,omitempty"` // at most maxReportedErrors
+	Error    string       `json:"error,omitempty"`
+}
+
+// Handler serves POST /import, bulk-loading a JSON array of
+// {timestamp, lux, note?} objects with upsert-by-timestamp semantics
+type Handler struct {
+	repo domain.ReadingRepository
+	now  func() time.Time
+}
+
+// NewHandler creates an import handler backed by repo
+func NewHandler(repo domain.ReadingRepository) *Handler {
+	return &Handler{repo: repo, now: time.Now}
+}
+
+// ServeHTTP decodes the uploaded array entry by entry, saving valid readings
+// in chunks. Chunks already saved stay saved if a later one fails, and the
+// error response summarizes them. A timestamp repeated within a chunk keeps
+// its first reading, as one upsert can't write a row twice; a repeat in a
+// later chunk replaces it like any other existing reading.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	dec := json.NewDecoder(r.Body)
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		http.Error(w, "body must be a JSON array", http.StatusBadRequest)
+		return
+	}
+
+	var (
+		summary Summary
+		chunk   = make([]*domain.LightReading, 0, chunkSize)
+		seen    = make(map[int64]struct{})
+		now     = h.now()
+	)
+
+	flush := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+		if err := h.repo.UpsertReadings(r.Context(), chunk); err != nil {
+			return err
+		}
+		summary.Imported += len(chunk)
+		chunk = chunk[:0]
+		clear(seen)
+		return nil
+	}
+
+	for index := 0; dec.More(); index++ {
+		var e entry
+		if err := dec.Decode(&e); err != nil {
+			// The stream can't be resynchronised after a syntax error
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				http.Error(w, fmt.Sprintf("malformed JSON at entry %d", index), http.StatusBadRequest)
+				return
+			}
+			summary.addError(index, "invalid entry: "+err.Error())
+			continue
+		}
+
+		reading, err := newReading(e, now)
+		if err != nil {
+			summary.addError(index, err.Error())
+			continue
+		}
+
+		key := reading.Timestamp.UnixNano()
+		if _, dup := seen[key]; dup {
+			summary.Skipped++
+			continue
+		}
+		seen[key] = struct{}{}
+
+		chunk = append(chunk, reading)
+		if len(chunk) == chunkSize {
+			if err := flush(); err != nil {
+				writeSaveError(w, err, summary)
+				return
+			}
+		}
+	}
+
+	if _, err := dec.Token(); err != nil {
+		http.Error(w, "malformed JSON: unterminated array", http.StatusBadRequest)
+		return
+	}
+	if err := flush(); err != nil {
+		writeSaveError(w, err, summary)
+		return
+	}
+
+	log.Info().
+		Int("imported", summary.Imported).
+		Int("skipped", summary.Skipped).
+		Int("errored", summary.Errored).
+		Msg("JSON import completed")
+
+	writeSummary(w, http.StatusOK, summary)
+}
+
+// writeSummary sends summary as the response body
+func writeSummary(w http.ResponseWriter, code int, summary Summary) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(summary); err != nil {
+		log.Error().Err(err).Msg("failed to encode import summary")
+	}
+}
+
+// newReading validates one entry through the domain constructor
+func newReading(e entry, now time.Time) (*domain.LightReading, error) {
+	if e.Lux == nil {
+		return nil, errors.New("lux is required")
+	}
+	if e.Timestamp.IsZero() {
+		return nil, errors.New("timestamp is required")
+	}
+	if e.Timestamp.After(now.Add(maxClockSkew)) {
+		return nil, domain.ErrFutureTimestamp
+	}
+
+	reading, err := domain.NewLightReadingAt(*e.Lux, e.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+	reading.Source = domain.SourceImport
+	return reading, nil
+}
+
+// addError counts a rejected entry, keeping the first few reasons
+func (s *Summary) addError(index int, reason string) {
+	s.Errored++
+	if len(s.Errors) < maxReportedErrors {
+		s.Errors = append(s.Errors, EntryError{Index: index, Reason: reason})
+	}
+}
+
+// writeSaveError reports a failed chunk save along with what the chunks
+// before it imported
+func writeSaveError(w http.ResponseWriter, err error, summary Summary) {
+	if errors.Is(err, domain.ErrReadOnly) {
+		summary.Error = "service is in read-only mode"
+		writeSummary(w, http.StatusForbidden, summary)
+		return
+	}
+	log.Error().Err(err).Int("imported", summary.Imported).Msg("failed to save imported readings")
+	summary.Error = "failed to save readings"
+	writeSummary(w, http.StatusInternalServerError, summary)
+}
@@ -0,0 +1,175 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/readonly"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+func TestHandler_Import(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	ctx := context.Background()
+
+	// An existing reading at 08:00 is replaced rather than duplicated
+	existing, _ := domain.NewLightReading(1)
+	existing.Timestamp = time.Date(2023, 3, 1, 8, 0, 0, 0, time.UTC)
+	_ = repo.SaveReading(ctx, existing)
+
+	body := `[
+		{"timestamp": "2023-03-01T08:00:00Z", "lux": 120.5, "note": "dawn"},
+		{"timestamp": "2023-03-01T10:00:00+01:00", "lux": 800},
+		{"timestamp": "2023-03-01T08:00:00Z", "lux": 999},
+		{"timestamp": "2023-03-01T10:00:00Z", "lux": -5},
+		{"lux": 300},
+		{"timestamp": "2023-03-01T11:00:00Z", "lux": 3000}
+	]`
+
+	rec := httptest.NewRecorder()
+	NewHandler(repo).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/import", strings.NewReader(body)))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var summary Summary
+	if err := json.Unmarshal(rec.Body.Bytes(), &summary); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if summary.Imported != 3 || summary.Skipped != 1 || summary.Errored != 2 {
+		t.Errorf("expected 3 imported, 1 skipped, 2 errored, got %+v", summary)
+	}
+	if len(summary.Errors) != 2 || summary.Errors[0].Index != 3 || summary.Errors[1].Index != 4 {
+		t.Errorf("expected errors for entries 3 and 4, got %+v", summary.Errors)
+	}
+
+	readings, err := repo.GetReadingsInRange(ctx,
+		time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 3, 2, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GetReadingsInRange failed: %v", err)
+	}
+
+	want := []struct {
+		at  time.Time
+		lux float64
+	}{
+		{time.Date(2023, 3, 1, 8, 0, 0, 0, time.UTC), 120.5},
+		{time.Date(2023, 3, 1, 9, 0, 0, 0, time.UTC), 800},
+		{time.Date(2023, 3, 1, 11, 0, 0, 0, time.UTC), 3000},
+	}
+	if len(readings) != len(want) {
+		t.Fatalf("expected %d readings, got %d", len(want), len(readings))
+	}
+	for i, w := range want {
+		r := readings[i]
+		if !r.Timestamp.Equal(w.at) || r.Lux != w.lux || r.Source != domain.SourceImport {
+			t.Errorf("reading %d: expected %.1f lux imported at %v, got %.1f lux (%s) at %v",
+				i, w.lux, w.at, r.Lux, r.Source, r.Timestamp)
+		}
+	}
+	if readings[0].ID != existing.ID {
+		t.Errorf("expected upsert to keep ID %d, got %d", existing.ID, readings[0].ID)
+	}
+}
+
+func TestHandler_ImportRejectsNonArray(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewHandler(memory.NewReadingRepository()).ServeHTTP(rec,
+		httptest.NewRequest(http.MethodPost, "/import", strings.NewReader(`{"lux": 100}`)))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rec.Code)
+	}
+}
+
+func TestHandler_ImportReadOnly(t *testing.T) {
+	repo := readonly.NewReadingRepository(memory.NewReadingRepository())
+
+	rec := httptest.NewRecorder()
+	NewHandler(repo).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/import",
+		strings.NewReader(`[{"timestamp": "2023-03-01T08:00:00Z", "lux": 100}]`)))
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", rec.Code)
+	}
+}
+
+// importBody returns an import array of n readings a minute apart, then
+// extra entries verbatim
+func importBody(start time.Time, n int, extra ...string) string {
+	entries := make([]string, 0, n+len(extra))
+	for i := range n {
+		at := start.Add(time.Duration(i) * time.Minute)
+		entries = append(entries, fmt.Sprintf(`{"timestamp": %q, "lux": %d}`, at.Format(time.RFC3339), i+1))
+	}
+	return "[" + strings.Join(append(entries, extra...), ",") + "]"
+}
+
+func TestHandler_ImportRepeatInLaterChunkReplaces(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	start := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	body := importBody(start, chunkSize, `{"timestamp": "2023-03-01T00:00:00Z", "lux": 999}`)
+	rec := httptest.NewRecorder()
+	NewHandler(repo).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/import", strings.NewReader(body)))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var summary Summary
+	if err := json.Unmarshal(rec.Body.Bytes(), &summary); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if summary.Imported != chunkSize+1 || summary.Skipped != 0 {
+		t.Errorf("expected %d imported and none skipped, got %+v", chunkSize+1, summary)
+	}
+
+	first, err := repo.GetReadingsInRange(context.Background(), start, start.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("GetReadingsInRange failed: %v", err)
+	}
+	if len(first) != 1 || first[0].Lux != 999 {
+		t.Errorf("expected the later chunk's 999 lux to replace the first reading, got %v", first)
+	}
+}
+
+// failingUpsertRepo fails every upsert after the first few
+type failingUpsertRepo struct {
+	*memory.ReadingRepository
+	succeed int
+}
+
+func (r *failingUpsertRepo) UpsertReadings(ctx context.Context, readings []*domain.LightReading) error {
+	if r.succeed == 0 {
+		return errors.New("disk full")
+	}
+	r.succeed--
+	return r.ReadingRepository.UpsertReadings(ctx, readings)
+}
+
+func TestHandler_ImportFailedChunkReportsSaved(t *testing.T) {
+	repo := &failingUpsertRepo{ReadingRepository: memory.NewReadingRepository(), succeed: 1}
+
+	body := importBody(time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), chunkSize+10, `{"lux": 300}`)
+	rec := httptest.NewRecorder()
+	NewHandler(repo).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/import", strings.NewReader(body)))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var summary Summary
+	if err := json.Unmarshal(rec.Body.Bytes(), &summary); err != nil {
+		t.Fatalf("expected a JSON summary, got %q: %v", rec.Body.String(), err)
+	}
+	if summary.Imported != chunkSize || summary.Error == "" {
+		t.Errorf("expected the first chunk of %d imported and an error, got %+v", chunkSize, summary)
+	}
+}
@@ -0,0 +1,272 @@
+package influx
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// client speaks the parts of the InfluxDB 2.x HTTP API the repository uses
+type client struct {
+	baseURL string
+	token   string
+	org     string
+	bucket  string
+	http    *http.Client
+}
+
+// errNotFound is returned for a 404 response
+var errNotFound = errors.New("not found")
+
+// apiError is the JSON body InfluxDB returns with a failed request
+type apiError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// do sends a request and fails on any status other than 2xx
+func (c *client) do(ctx context.Context, method, path string, query url.Values, contentType string, body []byte) (*http.Response, error) {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Token "+c.token)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		detail := resp.Status
+		var e apiError
+		if json.Unmarshal(msg, &e) == nil && e.Message != "" {
+			detail = fmt.Sprintf("%s (%s)", e.Message, resp.Status)
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("influxdb %s %s: %s: %w", method, path, detail, errNotFound)
+		}
+		return nil, fmt.Errorf("influxdb %s %s: %s", method, path, detail)
+	}
+	return resp, nil
+}
+
+// doJSON sends body as JSON and decodes the response into out, if non-nil
+func (c *client) doJSON(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	resp, err := c.do(ctx, method, path, query, "application/json", data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// write stores points given in line protocol with nanosecond timestamps.
+// InfluxDB applies one request as a whole unless a point is malformed.
+func (c *client) write(ctx context.Context, lines []string) error {
+	if len(lines) == 0 {
+		return nil
+	}
+	q := url.Values{"org": {c.org}, "bucket": {c.bucket}, "precision": {"ns"}}
+	resp, err := c.do(ctx, http.MethodPost, "/api/v2/write", q, "text/plain; charset=utf-8", []byte(strings.Join(lines, "\n")))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// query runs a Flux query and returns its rows keyed by column name. The
+// rows of every result table are concatenated.
+func (c *client) query(ctx context.Context, flux string) ([]map[string]string, error) {
+	body := map[string]any{
+		"query": flux,
+		"type":  "flux",
+		"dialect": map[string]any{
+			"header":      true,
+			"annotations": []string{},
+		},
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(ctx, http.MethodPost, "/api/v2/query", url.Values{"org": {c.org}}, "application/json", data)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return parseCSV(resp.Body)
+}
+
+// parseCSV reads InfluxDB's CSV query response. Each table with a new set
+// of columns starts with its own header row; blank separator lines are
+// skipped by the CSV reader.
+func parseCSV(r io.Reader) ([]map[string]string, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	var header []string
+	var rows []map[string]string
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse query response: %w", err)
+		}
+		if isHeader(record) {
+			header = record
+			continue
+		}
+		if header == nil || len(record) != len(header) {
+			return nil, fmt.Errorf("failed to parse query response: unexpected row %q", record)
+		}
+		row := make(map[string]string, len(header))
+		for i, col := range header {
+			row[col] = record[i]
+		}
+		rows = append(rows, row)
+	}
+}
+
+// isHeader reports whether a CSV record names the columns, which it does
+// with "result" and "table" after the unnamed annotation column
+func isHeader(record []string) bool {
+	return len(record) >= 3 && record[1] == "result" && record[2] == "table"
+}
+
+// deletePoints removes the points of measurement with times in
+// [start, stop]; InfluxDB's delete range is inclusive at both ends
+func (c *client) deletePoints(ctx context.Context, measurement string, start, stop time.Time) error {
+	body := map[string]string{
+		"start":     start.UTC().Format(time.RFC3339Nano),
+		"stop":      stop.UTC().Format(time.RFC3339Nano),
+		"predicate": fmt.Sprintf("_measurement=%q", measurement),
+	}
+	return c.doJSON(ctx, http.MethodPost, "/api/v2/delete", url.Values{"org": {c.org}, "bucket": {c.bucket}}, body, nil)
+}
+
+// retentionRule is a bucket's expiry policy; 0 seconds keeps data forever
+type retentionRule struct {
+	Type         string `json:"type"`
+	EverySeconds int64  `json:"everySeconds"`
+}
+
+// bucket is the part of InfluxDB's bucket resource the repository reads
+type bucket struct {
+	ID             string          `json:"id"`
+	Name           string          `json:"name"`
+	RetentionRules []retentionRule `json:"retentionRules"`
+}
+
+// findBucket looks up the bucket, returning nil if it does not exist
+func (c *client) findBucket(ctx context.Context) (*bucket, error) {
+	var found struct {
+		Buckets []bucket `json:"buckets"`
+	}
+	// Some server versions answer 404 rather than an empty list
+	err := c.doJSON(ctx, http.MethodGet, "/api/v2/buckets", url.Values{"org": {c.org}, "name": {c.bucket}}, nil, &found)
+	if err != nil && !errors.Is(err, errNotFound) {
+		return nil, fmt.Errorf("failed to look up bucket: %w", err)
+	}
+	if len(found.Buckets) == 0 {
+		return nil, nil
+	}
+	return &found.Buckets[0], nil
+}
+
+// checkBucket fails unless the bucket exists, without creating or changing
+// it
+func (c *client) checkBucket(ctx context.Context) error {
+	b, err := c.findBucket(ctx)
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		return fmt.Errorf("bucket %q not found in organization %q", c.bucket, c.org)
+	}
+	return nil
+}
+
+// ensureBucket creates the bucket if it is missing. When retention is
+// non-nil the bucket's expiry is set to it (0 keeps data forever), so
+// InfluxDB drops old readings itself.
+func (c *client) ensureBucket(ctx context.Context, retention *time.Duration) error {
+	b, err := c.findBucket(ctx)
+	if err != nil {
+		return err
+	}
+
+	rules := []retentionRule{}
+	if retention != nil {
+		rules = []retentionRule{{Type: "expire", EverySeconds: int64(*retention / time.Second)}}
+	}
+
+	if b == nil {
+		var orgs struct {
+			Orgs []struct {
+				ID string `json:"id"`
+			} `json:"orgs"`
+		}
+		if err := c.doJSON(ctx, http.MethodGet, "/api/v2/orgs", url.Values{"org": {c.org}}, nil, &orgs); err != nil {
+			return fmt.Errorf("failed to look up organization: %w", err)
+		}
+		if len(orgs.Orgs) == 0 {
+			return fmt.Errorf("organization %q not found", c.org)
+		}
+		body := map[string]any{"orgID": orgs.Orgs[0].ID, "name": c.bucket, "retentionRules": rules}
+		if err := c.doJSON(ctx, http.MethodPost, "/api/v2/buckets", nil, body, nil); err != nil {
+			return fmt.Errorf("failed to create bucket: %w", err)
+		}
+		return nil
+	}
+
+	if retention == nil || sameRetention(b.RetentionRules, rules[0].EverySeconds) {
+		return nil
+	}
+	if err := c.doJSON(ctx, http.MethodPatch, "/api/v2/buckets/"+url.PathEscape(b.ID), nil, map[string]any{"retentionRules": rules}, nil); err != nil {
+		return fmt.Errorf("failed to set bucket retention: %w", err)
+	}
+	return nil
+}
+
+// sameRetention reports whether rules already expire data after seconds
+func sameRetention(rules []retentionRule, seconds int64) bool {
+	var current int64
+	for _, r := range rules {
+		if r.Type == "expire" {
+			current = r.EverySeconds
+		}
+	}
+	return current == seconds
+}
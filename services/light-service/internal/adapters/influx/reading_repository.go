@@ -0,0 +1,720 @@
+// Package influx implements domain.ReadingRepository on InfluxDB 2.x, which
+// suits a long, append-only series of readings better than a SQL table
+// once years of history pile up.
+//
+// Readings are points in the light_reading measurement with no tags, so a
+// point is identified by its timestamp alone: a reading's ID is its
+// timestamp in Unix nanoseconds, and saving a reading at the same instant
+// as a stored one replaces it. Retention is best left to the bucket's
+// expiry policy (see WithRetention); DeleteOldReadings still works.
+package influx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// Measurements the repository writes
+const (
+	readingMeasurement = "light_reading"
+	eventMeasurement   = "category_event"
+)
+
+// epoch is where all-time queries start
+var epoch = time.Unix(0, 0).UTC()
+
+// endOfTime is the latest instant InfluxDB can store
+var endOfTime = time.Unix(0, math.MaxInt64).UTC()
+
+var _ domain.ReadingRepository = (*ReadingRepository)(nil)
+
+// ReadingRepository implements domain.ReadingRepository with InfluxDB
+type ReadingRepository struct {
+	client       *client
+	queryTimeout time.Duration
+}
+
+// options holds settings applied when connecting
+type options struct {
+	token        string
+	retention    *time.Duration
+	queryTimeout time.Duration
+	httpClient   *http.Client
+	readOnly     bool
+}
+
+// Option configures how NewReadingRepository connects
+type Option func(*options)
+
+// WithToken authenticates with an API token
+func WithToken(token string) Option {
+	return func(o *options) { o.token = token }
+}
+
+// WithRetention sets the bucket to expire data older than d (0 keeps it
+// forever), so InfluxDB prunes old readings itself. Without it the
+// bucket's policy is left as it is.
+func WithRetention(d time.Duration) Option {
+	return func(o *options) { o.retention = &d }
+}
+
+// WithQueryTimeout bounds every repository call (default 0, no limit
+// beyond the caller's context)
+func WithQueryTimeout(d time.Duration) Option {
+	return func(o *options) { o.queryTimeout = d }
+}
+
+// WithReadOnly only checks that the bucket exists: it is neither created
+// nor has its retention changed, since only a writable instance may do so
+func WithReadOnly() Option {
+	return func(o *options) { o.readOnly = true }
+}
+
+// WithHTTPClient talks to InfluxDB with client instead of a default one,
+// e.g. to set up TLS
+func WithHTTPClient(c *http.Client) Option {
+	return func(o *options) { o.httpClient = c }
+}
+
+// NewReadingRepository connects to the InfluxDB server at serverURL and
+// makes sure bucket exists in org, creating it if needed unless read-only
+func NewReadingRepository(ctx context.Context, serverURL, org, bucket string, opts ...Option) (*ReadingRepository, error) {
+	o := options{httpClient: &http.Client{}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if serverURL == "" || org == "" || bucket == "" {
+		return nil, fmt.Errorf("server URL, organization and bucket are required")
+	}
+	if o.queryTimeout < 0 {
+		return nil, fmt.Errorf("query timeout cannot be negative")
+	}
+	if o.retention != nil && (*o.retention < 0 || (*o.retention > 0 && *o.retention < time.Hour)) {
+		return nil, fmt.Errorf("retention must be 0 or at least 1h, got %v", *o.retention)
+	}
+
+	var err error
+	r := &ReadingRepository{
+		client: &client{
+			baseURL: strings.TrimRight(serverURL, "/"),
+			token:   o.token,
+			org:     org,
+			bucket:  bucket,
+			http:    o.httpClient,
+		},
+		queryTimeout: o.queryTimeout,
+	}
+	if o.readOnly {
+		err = r.client.checkBucket(ctx)
+	} else {
+		err = r.client.ensureBucket(ctx, o.retention)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare bucket: %w", err)
+	}
+	return r, nil
+}
+
+// Close releases idle connections to the server
+func (r *ReadingRepository) Close() error {
+	r.client.http.CloseIdleConnections()
+	return nil
+}
+
+// withTimeout bounds ctx by the configured query timeout, if any
+func (r *ReadingRepository) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.queryTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, r.queryTimeout)
+}
+
+// SaveReading writes a reading, setting its ID from its timestamp. InfluxDB
+// keeps one point per timestamp, so unlike the SQL stores a reading at a
+// stored timestamp replaces it rather than failing with
+// domain.ErrDuplicateTimestamp.
+func (r *ReadingRepository) SaveReading(ctx context.Context, reading *domain.LightReading) error {
+	return r.SaveReadings(ctx, []*domain.LightReading{reading})
+}
+
+// SaveReadings writes several readings in one request
+func (r *ReadingRepository) SaveReadings(ctx context.Context, readings []*domain.LightReading) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	lines := make([]string, len(readings))
+	for i, reading := range readings {
+		if reading.Source == "" {
+			reading.Source = domain.SourceSensor
+		}
+		lines[i] = readingLine(reading)
+	}
+	if err := r.client.write(ctx, lines); err != nil {
+		return fmt.Errorf("failed to save readings: %w", err)
+	}
+	for _, reading := range readings {
+		reading.ID = reading.Timestamp.UnixNano()
+	}
+	return nil
+}
+
+// UpsertReading is SaveReading: a point at the same timestamp is always
+// replaced
+func (r *ReadingRepository) UpsertReading(ctx context.Context, reading *domain.LightReading) error {
+	return r.SaveReadings(ctx, []*domain.LightReading{reading})
+}
+
+// UpsertReadings is SaveReadings
+func (r *ReadingRepository) UpsertReadings(ctx context.Context, readings []*domain.LightReading) error {
+	return r.SaveReadings(ctx, readings)
+}
+
+// GetReading retrieves the reading taken at the instant its ID encodes
+func (r *ReadingRepository) GetReading(ctx context.Context, id int64) (*domain.LightReading, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	at := time.Unix(0, id)
+	readings, err := r.queryReadings(ctx, readingsFlux(r.client.bucket, at, at.Add(time.Nanosecond), ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reading: %w", err)
+	}
+	if len(readings) == 0 {
+		return nil, domain.ErrReadingNotFound
+	}
+	return readings[0], nil
+}
+
+// GetReadingsByIDs looks up each ID in turn; InfluxDB has no index on
+// anything but time to fetch an arbitrary set in one query
+func (r *ReadingRepository) GetReadingsByIDs(ctx context.Context, ids []int64) ([]*domain.LightReading, error) {
+	seen := make(map[int64]bool, len(ids))
+	var results []*domain.LightReading
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		reading, err := r.GetReading(ctx, id)
+		if errors.Is(err, domain.ErrReadingNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, reading)
+	}
+	return results, nil
+}
+
+// GetReadingsInRange retrieves readings in [start, end), paged by opts
+func (r *ReadingRepository) GetReadingsInRange(ctx context.Context, start, end time.Time, opts ...domain.RangeOption) ([]*domain.LightReading, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	q := domain.NewRangeQuery(opts...)
+	if q.After != nil {
+		if q.Descending {
+			end = minTime(end, q.After.Timestamp)
+		} else {
+			start = maxTime(start, q.After.Timestamp.Add(time.Nanosecond))
+		}
+	}
+	if !start.Before(end) {
+		return []*domain.LightReading{}, nil
+	}
+
+	readings, err := r.queryReadings(ctx, readingsFlux(r.client.bucket, start, end, pageFlux(q.Descending, q.Limit)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query readings: %w", err)
+	}
+	return q.Apply(readings), nil
+}
+
+// GetReadingsInCategories filters the range's readings by their level under
+// scheme, which is derived from lux rather than stored
+func (r *ReadingRepository) GetReadingsInCategories(ctx context.Context, start, end time.Time, scheme *domain.CategoryScheme, categories []domain.Category, opts ...domain.RangeOption) ([]*domain.LightReading, error) {
+	readings, err := r.GetReadingsInRange(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*domain.LightReading, 0, len(readings))
+	for _, reading := range readings {
+		if slices.Contains(categories, scheme.Categorize(reading.Lux)) {
+			results = append(results, reading)
+		}
+	}
+	return domain.NewRangeQuery(opts...).Apply(results), nil
+}
+
+// AggregateReadingsInRange summarizes lux per epoch-aligned window in Flux,
+// so only one row per non-empty bucket leaves the server
+func (r *ReadingRepository) AggregateReadingsInRange(ctx context.Context, start, end time.Time, interval time.Duration) ([]domain.ReadingBucket, error) {
+	seconds := int64(interval / time.Second)
+	if seconds <= 0 {
+		return nil, fmt.Errorf("interval must be at least one second, got %v", interval)
+	}
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	flux := fmt.Sprintf(`from(bucket: %s)
+  |> range(start: %s, stop: %s)
+  |> filter(fn: (r) => r._measurement == %q and r._field == "lux")
+  |> window(every: %ds)
+  |> reduce(
+      identity: {count: 0, sum: 0.0, min: %g, max: %g},
+      fn: (r, accumulator) => ({
+        count: accumulator.count + 1,
+        sum: accumulator.sum + r._value,
+        min: if r._value < accumulator.min then r._value else accumulator.min,
+        max: if r._value > accumulator.max then r._value else accumulator.max,
+      }),
+    )
+  |> group()`, fluxString(r.client.bucket), fluxTime(start), fluxTime(end), readingMeasurement, seconds, math.MaxFloat64, -math.MaxFloat64)
+
+	rows, err := r.client.query(ctx, flux)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate readings: %w", err)
+	}
+
+	buckets := make([]domain.ReadingBucket, 0, len(rows))
+	for _, row := range rows {
+		// A window's _start is clipped to the range; align it back
+		windowStart, err := parseTime(row["_start"])
+		if err != nil {
+			return nil, err
+		}
+		count, _ := strconv.ParseInt(row["count"], 10, 64)
+		if count == 0 {
+			continue
+		}
+		sum, _ := strconv.ParseFloat(row["sum"], 64)
+		minLux, _ := strconv.ParseFloat(row["min"], 64)
+		maxLux, _ := strconv.ParseFloat(row["max"], 64)
+		aligned := floorDiv(windowStart.Unix(), seconds) * seconds
+		buckets = append(buckets, domain.ReadingBucket{
+			Start:      time.Unix(aligned, 0),
+			Count:      count,
+			AverageLux: sum / float64(count),
+			MinLux:     minLux,
+			MaxLux:     maxLux,
+		})
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Start.Before(buckets[j].Start)
+	})
+	return buckets, nil
+}
+
+// GetStatisticsInRange summarizes the lux of the readings in [start, end).
+// Flux's quantile() either estimates or picks without interpolating, so the
+// readings are fetched and summarized here to match the other stores.
+func (r *ReadingRepository) GetStatisticsInRange(ctx context.Context, start, end time.Time) (domain.LuxStatistics, error) {
+	readings, err := r.GetReadingsInRange(ctx, start, end)
+	if err != nil {
+		return domain.LuxStatistics{}, err
+	}
+	return domain.SummarizeLux(readings), nil
+}
+
+// GetRecordingDays returns the local days in [start, end) with readings
+func (r *ReadingRepository) GetRecordingDays(ctx context.Context, start, end time.Time, loc *time.Location) ([]time.Time, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	flux := fmt.Sprintf(`from(bucket: %s)
+  |> range(start: %s, stop: %s)
+  |> filter(fn: (r) => r._measurement == %q and r._field == "lux")
+  |> keep(columns: ["_time"])`, fluxString(r.client.bucket), fluxTime(start), fluxTime(end), readingMeasurement)
+
+	rows, err := r.client.query(ctx, flux)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recording days: %w", err)
+	}
+
+	seen := make(map[time.Time]bool)
+	days := []time.Time{}
+	for _, row := range rows {
+		t, err := parseTime(row["_time"])
+		if err != nil {
+			return nil, err
+		}
+		day := domain.CalendarDay(t, loc)
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Before(days[j])
+	})
+	return days, nil
+}
+
+// GetRecentReadings returns the newest readings, oldest first
+func (r *ReadingRepository) GetRecentReadings(ctx context.Context, limit int) ([]*domain.LightReading, error) {
+	if limit <= 0 {
+		return []*domain.LightReading{}, nil
+	}
+	readings, err := r.GetReadingsInRange(ctx, epoch, endOfTime, domain.WithDescending(), domain.WithLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(readings)
+	return readings, nil
+}
+
+// ListReadings returns the page of readings after the cursor. IDs are
+// timestamps, so the cursor's timestamp alone positions it.
+func (r *ReadingRepository) ListReadings(ctx context.Context, after domain.ReadingCursor, limit int) ([]*domain.LightReading, error) {
+	if limit <= 0 {
+		return []*domain.LightReading{}, nil
+	}
+	var opts []domain.RangeOption
+	if !after.Timestamp.IsZero() {
+		opts = append(opts, domain.WithCursor(after))
+	}
+	return r.GetReadingsInRange(ctx, epoch, endOfTime, append(opts, domain.WithLimit(limit))...)
+}
+
+// GetLatestReading returns the most recent reading
+func (r *ReadingRepository) GetLatestReading(ctx context.Context) (*domain.LightReading, error) {
+	return r.GetReadingAsOf(ctx, endOfTime.Add(-time.Nanosecond))
+}
+
+// GetReadingAsOf returns the latest reading at or before at
+func (r *ReadingRepository) GetReadingAsOf(ctx context.Context, at time.Time) (*domain.LightReading, error) {
+	readings, err := r.GetReadingsInRange(ctx, epoch, at.Add(time.Nanosecond), domain.WithDescending(), domain.WithLimit(1))
+	if err != nil {
+		return nil, err
+	}
+	if len(readings) == 0 {
+		return nil, domain.ErrReadingNotFound
+	}
+	return readings[0], nil
+}
+
+// SaveCategoryEvent writes a category transition, setting its ID from its
+// timestamp
+func (r *ReadingRepository) SaveCategoryEvent(ctx context.Context, event *domain.CategoryEvent) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	if err := r.client.write(ctx, []string{eventLine(event)}); err != nil {
+		return fmt.Errorf("failed to save category event: %w", err)
+	}
+	event.ID = event.Timestamp.UnixNano()
+	return nil
+}
+
+// GetCategoryEvents returns category transitions in [start, end)
+func (r *ReadingRepository) GetCategoryEvents(ctx context.Context, start, end time.Time) ([]*domain.CategoryEvent, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	flux := fmt.Sprintf(`from(bucket: %s)
+  |> range(start: %s, stop: %s)
+  |> filter(fn: (r) => r._measurement == %q)
+  |> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")
+  |> group()
+  |> sort(columns: ["_time"])`, fluxString(r.client.bucket), fluxTime(start), fluxTime(end), eventMeasurement)
+
+	rows, err := r.client.query(ctx, flux)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query category events: %w", err)
+	}
+	events := make([]*domain.CategoryEvent, 0, len(rows))
+	for _, row := range rows {
+		event, err := eventFromRow(row)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// ReplaceCategoryEvents deletes the stored transitions in [start, end) and
+// writes events. InfluxDB has no transactions, so a failed write leaves
+// none stored in the range; rerunning the recompute restores them.
+func (r *ReadingRepository) ReplaceCategoryEvents(ctx context.Context, start, end time.Time, events []*domain.CategoryEvent) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	if start.Before(epoch) {
+		start = epoch
+	}
+	if err := r.client.deletePoints(ctx, eventMeasurement, start, end.Add(-time.Nanosecond)); err != nil {
+		return fmt.Errorf("failed to delete category events: %w", err)
+	}
+	lines := make([]string, len(events))
+	for i, event := range events {
+		lines[i] = eventLine(event)
+	}
+	if err := r.client.write(ctx, lines); err != nil {
+		return fmt.Errorf("failed to save category events: %w", err)
+	}
+	for _, event := range events {
+		event.ID = event.Timestamp.UnixNano()
+	}
+	return nil
+}
+
+// Stats reports how many readings are stored and their time span.
+// InfluxDB doesn't report a bucket's size, so SizeBytes is 0.
+func (r *ReadingRepository) Stats(ctx context.Context) (*domain.StorageStats, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	count, err := r.countReadings(ctx, endOfTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count readings: %w", err)
+	}
+	stats := &domain.StorageStats{ReadingCount: count}
+	if count == 0 {
+		return stats, nil
+	}
+
+	for _, edge := range []struct {
+		fn string
+		at *time.Time
+	}{{"first", &stats.Oldest}, {"last", &stats.Newest}} {
+		rows, err := r.client.query(ctx, luxFlux(r.client.bucket, endOfTime, edge.fn+"()"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to query %s reading: %w", edge.fn, err)
+		}
+		if len(rows) > 0 {
+			if *edge.at, err = parseTime(rows[0]["_time"]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return stats, nil
+}
+
+// DeleteOldReadings deletes readings older than olderThan, counting them
+// first since InfluxDB's delete API doesn't report how many it removed
+func (r *ReadingRepository) DeleteOldReadings(ctx context.Context, olderThan time.Duration) (int64, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	cutoff := time.Now().Add(-olderThan)
+	count, err := r.countReadings(ctx, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count old readings: %w", err)
+	}
+	if count == 0 {
+		return 0, nil
+	}
+	// The delete range includes its stop, and the cutoff itself is kept
+	if err := r.client.deletePoints(ctx, readingMeasurement, epoch, cutoff.Add(-time.Nanosecond)); err != nil {
+		return 0, fmt.Errorf("failed to delete old readings: %w", err)
+	}
+	return count, nil
+}
+
+// countReadings counts the readings before stop
+func (r *ReadingRepository) countReadings(ctx context.Context, stop time.Time) (int64, error) {
+	rows, err := r.client.query(ctx, luxFlux(r.client.bucket, stop, "count()"))
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	return strconv.ParseInt(rows[0]["_value"], 10, 64)
+}
+
+// queryReadings runs a query built by readingsFlux
+func (r *ReadingRepository) queryReadings(ctx context.Context, flux string) ([]*domain.LightReading, error) {
+	rows, err := r.client.query(ctx, flux)
+	if err != nil {
+		return nil, err
+	}
+	readings := make([]*domain.LightReading, 0, len(rows))
+	for _, row := range rows {
+		reading, err := readingFromRow(row)
+		if err != nil {
+			return nil, err
+		}
+		readings = append(readings, reading)
+	}
+	return readings, nil
+}
+
+// readingsFlux selects the readings in [start, stop), one row each,
+// oldest first. page, from pageFlux, runs on each field's series before
+// the fields are pivoted together, so only that page is pivoted.
+func readingsFlux(bucket string, start, stop time.Time, page string) string {
+	return fmt.Sprintf(`from(bucket: %s)
+  |> range(start: %s, stop: %s)
+  |> filter(fn: (r) => r._measurement == %q)%s
+  |> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")
+  |> filter(fn: (r) => exists r.lux)
+  |> group()
+  |> sort(columns: ["_time"])`, fluxString(bucket), fluxTime(start), fluxTime(stop), readingMeasurement, page)
+}
+
+// pageFlux keeps the first limit points of each field's series in the
+// requested order (all of them when limit is 0). An optional field such as
+// the temperature can then reach further than lux; readingsFlux drops the
+// rows that have no lux, and RangeQuery.Apply trims the rest.
+func pageFlux(descending bool, limit int) string {
+	if limit <= 0 {
+		return ""
+	}
+	if descending {
+		return fmt.Sprintf(`
+  |> sort(columns: ["_time"], desc: true)
+  |> limit(n: %d)`, limit)
+	}
+	return fmt.Sprintf(`
+  |> limit(n: %d)`, limit)
+}
+
+// luxFlux applies a selector or aggregate to the lux of readings before stop
+func luxFlux(bucket string, stop time.Time, fn string) string {
+	return fmt.Sprintf(`from(bucket: %s)
+  |> range(start: %s, stop: %s)
+  |> filter(fn: (r) => r._measurement == %q and r._field == "lux")
+  |> %s`, fluxString(bucket), fluxTime(epoch), fluxTime(stop), readingMeasurement, fn)
+}
+
+// readingLine encodes a reading as a line protocol point
+func readingLine(reading *domain.LightReading) string {
+	quality := reading.Quality
+	if quality == "" {
+		quality = domain.QualityOK
+	}
+	fields := []string{
+		"lux=" + formatFloat(reading.Lux),
+		"source=" + lineString(string(reading.Source)),
+		"quality=" + lineString(string(quality)),
+	}
+	if reading.TemperatureC != nil {
+		fields = append(fields, "temperature_c="+formatFloat(*reading.TemperatureC))
+	}
+	return fmt.Sprintf("%s %s %d", readingMeasurement, strings.Join(fields, ","), reading.Timestamp.UnixNano())
+}
+
+// eventLine encodes a category event as a line protocol point
+func eventLine(event *domain.CategoryEvent) string {
+	return fmt.Sprintf("%s from=%di,to=%di,lux=%s %d",
+		eventMeasurement, event.From, event.To, formatFloat(event.Lux), event.Timestamp.UnixNano())
+}
+
+// readingFromRow decodes a pivoted reading row
+func readingFromRow(row map[string]string) (*domain.LightReading, error) {
+	ts, err := parseTime(row["_time"])
+	if err != nil {
+		return nil, err
+	}
+	lux, err := strconv.ParseFloat(row["lux"], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lux %q: %w", row["lux"], err)
+	}
+	reading := &domain.LightReading{
+		ID:        ts.UnixNano(),
+		Lux:       lux,
+		Timestamp: ts,
+		Source:    domain.Source(row["source"]),
+		Quality:   domain.Quality(row["quality"]),
+	}
+	if v := row["temperature_c"]; v != "" {
+		temp, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid temperature %q: %w", v, err)
+		}
+		reading.TemperatureC = &temp
+	}
+	return reading, nil
+}
+
+// eventFromRow decodes a pivoted category event row
+func eventFromRow(row map[string]string) (*domain.CategoryEvent, error) {
+	ts, err := parseTime(row["_time"])
+	if err != nil {
+		return nil, err
+	}
+	from, err := strconv.Atoi(row["from"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid category %q: %w", row["from"], err)
+	}
+	to, err := strconv.Atoi(row["to"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid category %q: %w", row["to"], err)
+	}
+	lux, err := strconv.ParseFloat(row["lux"], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lux %q: %w", row["lux"], err)
+	}
+	return &domain.CategoryEvent{
+		ID:        ts.UnixNano(),
+		From:      domain.Category(from),
+		To:        domain.Category(to),
+		Lux:       lux,
+		Timestamp: ts,
+	}, nil
+}
+
+// parseTime parses a time column, returned in RFC 3339 with nanoseconds
+func parseTime(v string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: %w", v, err)
+	}
+	return t, nil
+}
+
+// fluxTime formats t as a Flux time literal
+func fluxTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// fluxString quotes s as a Flux string literal
+func fluxString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "${", `\${`).Replace(s) + `"`
+}
+
+// lineString quotes s as a line protocol string field value
+func lineString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// formatFloat formats a float field value
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// floorDiv divides rounding towards negative infinity
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
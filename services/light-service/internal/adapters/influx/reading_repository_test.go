@@ -0,0 +1,360 @@
+package influx
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// fakeServer stands in for the InfluxDB API: it serves one bucket, records
+// writes and bucket changes, and answers every query with queryCSV
+type fakeServer struct {
+	mu        sync.Mutex
+	bucket    *bucket
+	created   map[string]any
+	patched   map[string]any
+	writes    []string
+	writeURL  string
+	auth      string
+	queries   []string
+	queryCSV  string
+	queryFail bool
+}
+
+func (f *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.auth = r.Header.Get("Authorization")
+	body, _ := io.ReadAll(r.Body)
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v2/buckets":
+		var buckets []bucket
+		if f.bucket != nil {
+			buckets = append(buckets, *f.bucket)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"buckets": buckets})
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v2/orgs":
+		json.NewEncoder(w).Encode(map[string]any{"orgs": []map[string]string{{"id": "org1"}}})
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v2/buckets":
+		json.Unmarshal(body, &f.created)
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, "/api/v2/buckets/"):
+		json.Unmarshal(body, &f.patched)
+	case r.URL.Path == "/api/v2/write":
+		f.writeURL = r.URL.RawQuery
+		f.writes = append(f.writes, strings.Split(string(body), "\n")...)
+		w.WriteHeader(http.StatusNoContent)
+	case r.URL.Path == "/api/v2/query":
+		var q struct {
+			Query string `json:"query"`
+		}
+		json.Unmarshal(body, &q)
+		f.queries = append(f.queries, q.Query)
+		if f.queryFail {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":"invalid","message":"compilation failed"}`))
+			return
+		}
+		w.Write([]byte(f.queryCSV))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// newFakeRepo starts a fakeServer and connects a repository to it
+func newFakeRepo(t *testing.T, f *fakeServer, opts ...Option) *ReadingRepository {
+	t.Helper()
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	repo, err := NewReadingRepository(context.Background(), srv.URL, "home", "light", opts...)
+	if err != nil {
+		t.Fatalf("NewReadingRepository failed: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return repo
+}
+
+func TestNewReadingRepository_InvalidOptions(t *testing.T) {
+	ctx := context.Background()
+	if _, err := NewReadingRepository(ctx, "http://localhost:8086", "", "light"); err == nil {
+		t.Error("expected an error without an organization")
+	}
+	if _, err := NewReadingRepository(ctx, "http://localhost:8086", "home", "light", WithQueryTimeout(-time.Second)); err == nil {
+		t.Error("expected an error for a negative query timeout")
+	}
+	if _, err := NewReadingRepository(ctx, "http://localhost:8086", "home", "light", WithRetention(time.Minute)); err == nil {
+		t.Error("expected an error for a retention under an hour")
+	}
+}
+
+func TestNewReadingRepository_CreatesBucket(t *testing.T) {
+	f := &fakeServer{}
+	newFakeRepo(t, f, WithToken("secret"), WithRetention(30*24*time.Hour))
+
+	if f.created == nil {
+		t.Fatal("expected the bucket to be created")
+	}
+	if f.created["name"] != "light" || f.created["orgID"] != "org1" {
+		t.Errorf("unexpected bucket: %v", f.created)
+	}
+	rules, _ := f.created["retentionRules"].([]any)
+	if len(rules) != 1 || rules[0].(map[string]any)["everySeconds"] != float64(30*24*3600) {
+		t.Errorf("expected a 30 day expiry, got %v", f.created["retentionRules"])
+	}
+	if f.auth != "Token secret" {
+		t.Errorf("expected token auth, got %q", f.auth)
+	}
+}
+
+func TestNewReadingRepository_UpdatesRetention(t *testing.T) {
+	f := &fakeServer{bucket: &bucket{ID: "b1", Name: "light", RetentionRules: []retentionRule{{Type: "expire", EverySeconds: 3600}}}}
+	newFakeRepo(t, f, WithRetention(2*time.Hour))
+	if f.patched == nil {
+		t.Fatal("expected the bucket's retention to be updated")
+	}
+
+	// Unchanged or unset retention leaves the bucket alone
+	for _, opts := range [][]Option{{WithRetention(time.Hour)}, nil} {
+		f := &fakeServer{bucket: &bucket{ID: "b1", Name: "light", RetentionRules: []retentionRule{{Type: "expire", EverySeconds: 3600}}}}
+		newFakeRepo(t, f, opts...)
+		if f.patched != nil || f.created != nil {
+			t.Errorf("expected no bucket change, got patch %v, create %v", f.patched, f.created)
+		}
+	}
+}
+
+func TestNewReadingRepository_ReadOnly(t *testing.T) {
+	// An existing bucket is used as it is, whatever the retention option
+	f := &fakeServer{bucket: &bucket{ID: "b1", Name: "light", RetentionRules: []retentionRule{{Type: "expire", EverySeconds: 3600}}}}
+	newFakeRepo(t, f, WithReadOnly(), WithRetention(2*time.Hour))
+	if f.patched != nil || f.created != nil {
+		t.Errorf("expected no bucket change, got patch %v, create %v", f.patched, f.created)
+	}
+
+	// A missing bucket is refused rather than created
+	f = &fakeServer{}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	if _, err := NewReadingRepository(context.Background(), srv.URL, "home", "light", WithReadOnly()); err == nil {
+		t.Error("expected a missing bucket refused in read-only mode")
+	}
+	if f.created != nil {
+		t.Errorf("expected no bucket created, got %v", f.created)
+	}
+}
+
+func TestSaveReadings_LineProtocol(t *testing.T) {
+	f := &fakeServer{bucket: &bucket{ID: "b1", Name: "light"}}
+	repo := newFakeRepo(t, f)
+
+	temp := 21.5
+	ts := time.Unix(1700000000, 123456789)
+	readings := []*domain.LightReading{
+		{Lux: 500, Timestamp: ts, TemperatureC: &temp},
+		{Lux: 0.25, Timestamp: ts.Add(time.Second), Source: domain.SourceManual, Quality: domain.QualitySaturated},
+	}
+	if err := repo.SaveReadings(context.Background(), readings); err != nil {
+		t.Fatalf("SaveReadings failed: %v", err)
+	}
+
+	want := []string{
+		`light_reading lux=500,source="sensor",quality="ok",temperature_c=21.5 1700000000123456789`,
+		`light_reading lux=0.25,source="manual",quality="saturated" 1700000001123456789`,
+	}
+	if strings.Join(f.writes, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected lines:\n%s\nwant:\n%s", strings.Join(f.writes, "\n"), strings.Join(want, "\n"))
+	}
+	if !strings.Contains(f.writeURL, "precision=ns") || !strings.Contains(f.writeURL, "bucket=light") {
+		t.Errorf("unexpected write query %q", f.writeURL)
+	}
+	if readings[0].ID != ts.UnixNano() {
+		t.Errorf("expected ID %d, got %d", ts.UnixNano(), readings[0].ID)
+	}
+}
+
+func TestGetReadingsInRange_ParsesTables(t *testing.T) {
+	// Two result tables with different columns, as when only some rows
+	// carry a temperature
+	f := &fakeServer{
+		bucket: &bucket{ID: "b1", Name: "light"},
+		queryCSV: ",result,table,_start,_stop,_time,_measurement,lux,quality,source\r\n" +
+			",_result,0,1970-01-01T00:00:00Z,2024-01-01T00:00:00Z,2023-11-14T22:13:20Z,light_reading,500,ok,sensor\r\n" +
+			"\r\n" +
+			",result,table,_start,_stop,_time,_measurement,lux,quality,source,temperature_c\r\n" +
+			",_result,1,1970-01-01T00:00:00Z,2024-01-01T00:00:00Z,2023-11-14T22:13:21.5Z,light_reading,20,low_confidence,manual,19.25\r\n",
+	}
+	repo := newFakeRepo(t, f)
+
+	readings, err := repo.GetReadingsInRange(context.Background(), time.Unix(0, 0), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GetReadingsInRange failed: %v", err)
+	}
+	if len(readings) != 2 {
+		t.Fatalf("expected 2 readings, got %d", len(readings))
+	}
+	first, second := readings[0], readings[1]
+	if first.Lux != 500 || first.Source != domain.SourceSensor || first.TemperatureC != nil || first.ID != time.Unix(1700000000, 0).UnixNano() {
+		t.Errorf("unexpected first reading %+v", first)
+	}
+	if second.Quality != domain.QualityLowConfidence || second.TemperatureC == nil || *second.TemperatureC != 19.25 {
+		t.Errorf("unexpected second reading %+v", second)
+	}
+	if q := f.queries[0]; !strings.Contains(q, `range(start: 1970-01-01T00:00:00Z, stop: 2024-01-01T00:00:00Z)`) || !strings.Contains(q, "pivot(") {
+		t.Errorf("unexpected query:\n%s", q)
+	}
+}
+
+func TestGetReadingsInRange_PagesInFlux(t *testing.T) {
+	f := &fakeServer{bucket: &bucket{ID: "b1", Name: "light"}}
+	repo := newFakeRepo(t, f)
+
+	if _, err := repo.GetRecentReadings(context.Background(), 5); err != nil {
+		t.Fatalf("GetRecentReadings failed: %v", err)
+	}
+	if q := f.queries[0]; !strings.Contains(q, "desc: true") || !strings.Contains(q, "limit(n: 5)") {
+		t.Errorf("expected a descending page of 5, got:\n%s", q)
+	}
+}
+
+func TestQuery_ReportsServerError(t *testing.T) {
+	f := &fakeServer{bucket: &bucket{ID: "b1", Name: "light"}, queryFail: true}
+	repo := newFakeRepo(t, f)
+
+	_, err := repo.GetLatestReading(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "compilation failed") {
+		t.Errorf("expected the server's message, got %v", err)
+	}
+}
+
+func TestFluxString(t *testing.T) {
+	if got, want := fluxString(`a"b\c${d}`), `"a\"b\\c\${d}"`; got != want {
+		t.Errorf("fluxString = %s, want %s", got, want)
+	}
+}
+
+// newTestRepo connects to the server in INFLUX_TEST_URL, with
+// INFLUX_TEST_TOKEN and INFLUX_TEST_ORG, skipping the test when it is
+// unset, and empties the light_test bucket first
+func newTestRepo(t *testing.T) *ReadingRepository {
+	t.Helper()
+	url := os.Getenv("INFLUX_TEST_URL")
+	if url == "" {
+		t.Skip("INFLUX_TEST_URL not set")
+	}
+
+	ctx := context.Background()
+	repo, err := NewReadingRepository(ctx, url, os.Getenv("INFLUX_TEST_ORG"), "light_test", WithToken(os.Getenv("INFLUX_TEST_TOKEN")))
+	if err != nil {
+		t.Fatalf("failed to create InfluxDB repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	for _, m := range []string{readingMeasurement, eventMeasurement} {
+		if err := repo.client.deletePoints(ctx, m, epoch, endOfTime); err != nil {
+			t.Fatalf("failed to empty bucket: %v", err)
+		}
+	}
+	return repo
+}
+
+func TestIntegration_SaveAndQuery(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	var readings []*domain.LightReading
+	for i := range 5 {
+		readings = append(readings, &domain.LightReading{Lux: float64(100 * (i + 1)), Timestamp: base.Add(time.Duration(i) * time.Minute)})
+	}
+	if err := repo.SaveReadings(ctx, readings); err != nil {
+		t.Fatalf("SaveReadings failed: %v", err)
+	}
+
+	got, err := repo.GetReading(ctx, readings[2].ID)
+	if err != nil || got.Lux != 300 {
+		t.Fatalf("GetReading = %+v, %v", got, err)
+	}
+
+	page, err := repo.GetReadingsInRange(ctx, base, base.Add(time.Hour), domain.WithLimit(2))
+	if err != nil || len(page) != 2 || page[1].Lux != 200 {
+		t.Fatalf("first page = %v, %v", page, err)
+	}
+	next, err := repo.GetReadingsInRange(ctx, base, base.Add(time.Hour), domain.WithLimit(2), domain.WithCursor(domain.ReadingCursor{Timestamp: page[1].Timestamp, ID: page[1].ID}))
+	if err != nil || len(next) != 2 || next[0].Lux != 300 {
+		t.Fatalf("second page = %v, %v", next, err)
+	}
+
+	latest, err := repo.GetLatestReading(ctx)
+	if err != nil || latest.Lux != 500 {
+		t.Fatalf("GetLatestReading = %+v, %v", latest, err)
+	}
+
+	buckets, err := repo.AggregateReadingsInRange(ctx, base, base.Add(time.Hour), time.Hour)
+	if err != nil {
+		t.Fatalf("AggregateReadingsInRange failed: %v", err)
+	}
+	var count int64
+	for _, b := range buckets {
+		count += b.Count
+	}
+	if count != 5 {
+		t.Errorf("expected 5 readings across buckets, got %+v", buckets)
+	}
+
+	stats, err := repo.Stats(ctx)
+	if err != nil || stats.ReadingCount != 5 || !stats.Oldest.Equal(base) {
+		t.Errorf("Stats = %+v, %v", stats, err)
+	}
+}
+
+func TestIntegration_DeleteOldReadings(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	now := time.Now()
+	if err := repo.SaveReadings(ctx, []*domain.LightReading{
+		{Lux: 1, Timestamp: now.Add(-48 * time.Hour)},
+		{Lux: 2, Timestamp: now.Add(-time.Minute)},
+	}); err != nil {
+		t.Fatalf("SaveReadings failed: %v", err)
+	}
+
+	deleted, err := repo.DeleteOldReadings(ctx, 24*time.Hour)
+	if err != nil || deleted != 1 {
+		t.Fatalf("DeleteOldReadings = %d, %v", deleted, err)
+	}
+	recent, err := repo.GetRecentReadings(ctx, 10)
+	if err != nil || len(recent) != 1 || recent[0].Lux != 2 {
+		t.Errorf("expected only the recent reading left, got %v, %v", recent, err)
+	}
+}
+
+func TestIntegration_ReplaceCategoryEvents(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	now := time.Now().Truncate(time.Second)
+	if err := repo.SaveCategoryEvent(ctx, &domain.CategoryEvent{From: domain.CategoryMedium, To: domain.CategoryHigh, Lux: 3000, Timestamp: now.Add(-time.Hour)}); err != nil {
+		t.Fatalf("SaveCategoryEvent failed: %v", err)
+	}
+	if err := repo.ReplaceCategoryEvents(ctx, now.Add(-2*time.Hour), now, []*domain.CategoryEvent{
+		{From: domain.CategoryMedium, To: domain.CategoryLow, Lux: 80, Timestamp: now.Add(-time.Minute)},
+	}); err != nil {
+		t.Fatalf("ReplaceCategoryEvents failed: %v", err)
+	}
+
+	events, err := repo.GetCategoryEvents(ctx, now.Add(-2*time.Hour), now)
+	if err != nil || len(events) != 1 || events[0].To != domain.CategoryLow {
+		t.Errorf("expected only the replacement event, got %v, %v", events, err)
+	}
+}
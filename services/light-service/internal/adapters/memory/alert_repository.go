@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// AlertRepository implements domain.AlertRepository with in-memory storage.
+// Rules and alerts are lost on restart.
+type AlertRepository struct {
+	mu          sync.RWMutex
+	rules       []*domain.AlertRule // in creation order
+	nextRuleID  int64
+	alerts      []*domain.Alert
+	nextAlertID int64
+}
+
+// NewAlertRepository creates an empty in-memory alert repository
+func NewAlertRepository() *AlertRepository {
+	return &AlertRepository{nextRuleID: 1, nextAlertID: 1}
+}
+
+// CreateRule stores a new rule, assigning its ID and creation time
+func (r *AlertRepository) CreateRule(ctx context.Context, rule *domain.AlertRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rule.ID = r.nextRuleID
+	r.nextRuleID++
+	rule.CreatedAt = time.Now()
+
+	stored := *rule
+	r.rules = append(r.rules, &stored)
+	return nil
+}
+
+// ListRules returns every rule, oldest first
+func (r *AlertRepository) ListRules(ctx context.Context) ([]*domain.AlertRule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rules := make([]*domain.AlertRule, len(r.rules))
+	for i, rule := range r.rules {
+		copied := *rule
+		rules[i] = &copied
+	}
+	return rules, nil
+}
+
+// DeleteRule removes a rule, keeping the alerts it fired
+func (r *AlertRepository) DeleteRule(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := slices.IndexFunc(r.rules, func(rule *domain.AlertRule) bool { return rule.ID == id })
+	if i < 0 {
+		return domain.ErrAlertRuleNotFound
+	}
+	r.rules = slices.Delete(r.rules, i, i+1)
+	return nil
+}
+
+// SaveAlert stores a fired alert, assigning its ID
+func (r *AlertRepository) SaveAlert(ctx context.Context, alert *domain.Alert) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	alert.ID = r.nextAlertID
+	r.nextAlertID++
+
+	stored := *alert
+	r.alerts = append(r.alerts, &stored)
+	return nil
+}
+
+// GetAlerts returns the alerts fired in [start, end), oldest first
+func (r *AlertRepository) GetAlerts(ctx context.Context, start, end time.Time) ([]*domain.Alert, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var alerts []*domain.Alert
+	for _, alert := range r.alerts {
+		if !alert.FiredAt.Before(start) && alert.FiredAt.Before(end) {
+			copied := *alert
+			alerts = append(alerts, &copied)
+		}
+	}
+
+	slices.SortStableFunc(alerts, func(a, b *domain.Alert) int {
+		return a.FiredAt.Compare(b.FiredAt)
+	})
+	return alerts, nil
+}
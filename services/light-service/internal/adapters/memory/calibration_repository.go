@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// CalibrationRepository implements domain.CalibrationRepository with
+// in-memory storage. Calibrations are lost on restart.
+type CalibrationRepository struct {
+	mu           sync.RWMutex
+	calibrations map[string]domain.Calibration // by sensor ID
+}
+
+// NewCalibrationRepository creates an empty in-memory calibration repository
+func NewCalibrationRepository() *CalibrationRepository {
+	return &CalibrationRepository{calibrations: make(map[string]domain.Calibration)}
+}
+
+// GetCalibration returns a copy of the sensor's calibration
+func (r *CalibrationRepository) GetCalibration(ctx context.Context, sensorID string) (*domain.Calibration, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cal, ok := r.calibrations[sensorID]
+	if !ok {
+		return nil, domain.ErrCalibrationNotFound
+	}
+	return &cal, nil
+}
+
+// SaveCalibration stores a copy of cal, replacing the sensor's previous one
+func (r *CalibrationRepository) SaveCalibration(ctx context.Context, cal *domain.Calibration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cal.UpdatedAt = time.Now()
+	r.calibrations[cal.SensorID] = *cal
+	return nil
+}
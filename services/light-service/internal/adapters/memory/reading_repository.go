@@ -2,6 +2,8 @@ package memory
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"sort"
 	"sync"
 	"time"
@@ -15,13 +17,22 @@ type ReadingRepository struct {
 	mu       sync.RWMutex
 	readings map[int64]*domain.LightReading
 	nextID   int64
+
+	// byTimestamp indexes reading IDs by Timestamp.UnixNano, standing in
+	// for the SQL stores' unique timestamp index
+	byTimestamp map[int64]int64
+
+	events      []*domain.CategoryEvent
+	nextEventID int64
 }
 
 // NewReadingRepository creates an empty in-memory repository
 func NewReadingRepository() *ReadingRepository {
 	return &ReadingRepository{
-		readings: make(map[int64]*domain.LightReading),
-		nextID:   1,
+		readings:    make(map[int64]*domain.LightReading),
+		byTimestamp: make(map[int64]int64),
+		nextID:      1,
+		nextEventID: 1,
 	}
 }
 
@@ -30,17 +41,92 @@ func (r *ReadingRepository) SaveReading(ctx context.Context, reading *domain.Lig
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	// Assign ID if not set
+	return r.store(reading)
+}
+
+// SaveReadings stores several readings under a single lock. Like the SQL
+// stores' transaction, a duplicate timestamp stores none of them.
+func (r *ReadingRepository) SaveReadings(ctx context.Context, readings []*domain.LightReading) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	batch := make(map[int64]bool, len(readings))
+	for i, reading := range readings {
+		key := reading.Timestamp.UnixNano()
+		if r.taken(reading) || batch[key] {
+			return fmt.Errorf("reading %d: %w", i, domain.ErrDuplicateTimestamp)
+		}
+		batch[key] = true
+	}
+	for _, reading := range readings {
+		r.store(reading)
+	}
+	return nil
+}
+
+// taken reports whether another reading is stored at reading's timestamp;
+// callers hold the lock
+func (r *ReadingRepository) taken(reading *domain.LightReading) bool {
+	id, ok := r.byTimestamp[reading.Timestamp.UnixNano()]
+	return ok && id != reading.ID
+}
+
+// store assigns an ID if needed and saves the reading, refusing one whose
+// timestamp another reading holds; callers hold the write lock
+func (r *ReadingRepository) store(reading *domain.LightReading) error {
+	if r.taken(reading) {
+		return domain.ErrDuplicateTimestamp
+	}
+
+	// Assign ID if not set. An explicitly set ID moves nextID past it, so a
+	// later auto-assigned ID can't collide with it and overwrite the reading.
 	if reading.ID == 0 {
 		reading.ID = r.nextID
-		r.nextID++
 	}
+	r.nextID = max(r.nextID, reading.ID+1)
 
-	// Store
+	if reading.Source == "" {
+		reading.Source = domain.SourceSensor
+	}
+
+	// Store, dropping the index entry of any reading this replaces
+	if old, ok := r.readings[reading.ID]; ok {
+		delete(r.byTimestamp, old.Timestamp.UnixNano())
+	}
 	r.readings[reading.ID] = reading
+	r.byTimestamp[reading.Timestamp.UnixNano()] = reading.ID
 	return nil
 }
 
+// UpsertReading stores a reading, replacing any reading with the same timestamp
+func (r *ReadingRepository) UpsertReading(ctx context.Context, reading *domain.LightReading) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.upsert(reading)
+	return nil
+}
+
+// UpsertReadings upserts several readings under a single lock
+func (r *ReadingRepository) UpsertReadings(ctx context.Context, readings []*domain.LightReading) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, reading := range readings {
+		r.upsert(reading)
+	}
+	return nil
+}
+
+// upsert reuses the ID of an existing reading at the same timestamp so store
+// overwrites it; callers hold the write lock
+func (r *ReadingRepository) upsert(reading *domain.LightReading) {
+	if id, ok := r.byTimestamp[reading.Timestamp.UnixNano()]; ok {
+		reading.ID = id
+	}
+	r.store(reading)
+}
+
 // GetReading retrieves a reading by ID
 func (r *ReadingRepository) GetReading(ctx context.Context, id int64) (*domain.LightReading, error) {
 	r.mu.RLock()
@@ -54,8 +140,26 @@ func (r *ReadingRepository) GetReading(ctx context.Context, id int64) (*domain.L
 	return reading, nil
 }
 
-// GetReadingsInRange returns all readings within time range
-func (r *ReadingRepository) GetReadingsInRange(ctx context.Context, start, end time.Time) ([]*domain.LightReading, error) {
+// GetReadingsByIDs looks up each requested reading
+func (r *ReadingRepository) GetReadingsByIDs(ctx context.Context, ids []int64) ([]*domain.LightReading, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := make(map[int64]bool, len(ids))
+	var results []*domain.LightReading
+	for _, id := range ids {
+		if reading, exists := r.readings[id]; exists && !seen[id] {
+			seen[id] = true
+			results = append(results, reading)
+		}
+	}
+
+	return results, nil
+}
+
+// GetReadingsInRange returns the readings within time range, paged and
+// ordered by opts
+func (r *ReadingRepository) GetReadingsInRange(ctx context.Context, start, end time.Time, opts ...domain.RangeOption) ([]*domain.LightReading, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -66,11 +170,120 @@ func (r *ReadingRepository) GetReadingsInRange(ctx context.Context, start, end t
 		}
 	}
 
+	results = domain.NewRangeQuery(opts...).Apply(results)
+	if len(results) == 0 {
+		return nil, nil
+	}
+	return results, nil
+}
+
+// AggregateReadingsInRange summarizes the readings in [start, end) per bucket
+func (r *ReadingRepository) AggregateReadingsInRange(ctx context.Context, start, end time.Time, interval time.Duration) ([]domain.ReadingBucket, error) {
+	readings, err := r.GetReadingsInRange(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return domain.AggregateReadings(readings, interval), nil
+}
+
+// GetStatisticsInRange summarizes the lux of the readings in [start, end)
+func (r *ReadingRepository) GetStatisticsInRange(ctx context.Context, start, end time.Time) (domain.LuxStatistics, error) {
+	readings, err := r.GetReadingsInRange(ctx, start, end)
+	if err != nil {
+		return domain.LuxStatistics{}, err
+	}
+	return domain.SummarizeLux(readings), nil
+}
+
+// GetReadingsInCategories returns readings in [start, end) whose level
+// under scheme is one of categories
+func (r *ReadingRepository) GetReadingsInCategories(ctx context.Context, start, end time.Time, scheme *domain.CategoryScheme, categories []domain.Category, opts ...domain.RangeOption) ([]*domain.LightReading, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var results []*domain.LightReading
+	for _, reading := range r.readings {
+		if reading.Timestamp.Before(start) || !reading.Timestamp.Before(end) {
+			continue
+		}
+		if slices.Contains(categories, scheme.Categorize(reading.Lux)) {
+			results = append(results, reading)
+		}
+	}
+
+	return domain.NewRangeQuery(opts...).Apply(results), nil
+}
+
+// GetRecordingDays returns the local days in [start, end) with readings
+func (r *ReadingRepository) GetRecordingDays(ctx context.Context, start, end time.Time, loc *time.Location) ([]time.Time, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := make(map[time.Time]bool)
+	var days []time.Time
+	for _, reading := range r.readings {
+		if reading.Timestamp.Before(start) || !reading.Timestamp.Before(end) {
+			continue
+		}
+		day := domain.CalendarDay(reading.Timestamp, loc)
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Before(days[j])
+	})
+
+	return days, nil
+}
+
+// GetRecentReadings returns the newest readings, oldest first
+func (r *ReadingRepository) GetRecentReadings(ctx context.Context, limit int) ([]*domain.LightReading, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	results := make([]*domain.LightReading, 0, len(r.readings))
+	for _, reading := range r.readings {
+		results = append(results, reading)
+	}
+
 	// Sort by timestamp
 	sort.Slice(results, func(i, j int) bool {
 		return results[i].Timestamp.Before(results[j].Timestamp)
 	})
 
+	if limit < len(results) {
+		results = results[len(results)-limit:]
+	}
+
+	return results, nil
+}
+
+// ListReadings returns the page of readings after the cursor
+func (r *ReadingRepository) ListReadings(ctx context.Context, after domain.ReadingCursor, limit int) ([]*domain.LightReading, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	results := make([]*domain.LightReading, 0)
+	for _, reading := range r.readings {
+		if after.Before(reading) {
+			results = append(results, reading)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Timestamp.Equal(results[j].Timestamp) {
+			return results[i].ID < results[j].ID
+		}
+		return results[i].Timestamp.Before(results[j].Timestamp)
+	})
+
+	if limit < len(results) {
+		results = results[:limit]
+	}
+
 	return results, nil
 }
 
@@ -93,18 +306,111 @@ func (r *ReadingRepository) GetLatestReading(ctx context.Context) (*domain.Light
 	return latest, nil
 }
 
+// GetReadingAsOf returns the latest reading at or before the given time
+func (r *ReadingRepository) GetReadingAsOf(ctx context.Context, at time.Time) (*domain.LightReading, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var found *domain.LightReading
+	for _, reading := range r.readings {
+		if reading.Timestamp.After(at) {
+			continue
+		}
+		if found == nil || reading.Timestamp.After(found.Timestamp) {
+			found = reading
+		}
+	}
+
+	if found == nil {
+		return nil, domain.ErrReadingNotFound
+	}
+	return found, nil
+}
+
+// SaveCategoryEvent stores a category transition
+func (r *ReadingRepository) SaveCategoryEvent(ctx context.Context, event *domain.CategoryEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	event.ID = r.nextEventID
+	r.nextEventID++
+	r.events = append(r.events, event)
+	return nil
+}
+
+// ReplaceCategoryEvents swaps the stored category transitions in
+// [start, end) for events
+func (r *ReadingRepository) ReplaceCategoryEvents(ctx context.Context, start, end time.Time, events []*domain.CategoryEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := make([]*domain.CategoryEvent, 0, len(r.events)+len(events))
+	for _, event := range r.events {
+		if event.Timestamp.Before(start) || !event.Timestamp.Before(end) {
+			kept = append(kept, event)
+		}
+	}
+	for _, event := range events {
+		event.ID = r.nextEventID
+		r.nextEventID++
+		kept = append(kept, event)
+	}
+	r.events = kept
+	return nil
+}
+
+// GetCategoryEvents returns category transitions within the time range
+func (r *ReadingRepository) GetCategoryEvents(ctx context.Context, start, end time.Time) ([]*domain.CategoryEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var results []*domain.CategoryEvent
+	for _, event := range r.events {
+		if !event.Timestamp.Before(start) && event.Timestamp.Before(end) {
+			results = append(results, event)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Timestamp.Before(results[j].Timestamp)
+	})
+
+	return results, nil
+}
+
+// Stats reports the reading count and time span; there is no on-disk size
+func (r *ReadingRepository) Stats(ctx context.Context) (*domain.StorageStats, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stats := &domain.StorageStats{ReadingCount: int64(len(r.readings))}
+	for _, reading := range r.readings {
+		if stats.Oldest.IsZero() || reading.Timestamp.Before(stats.Oldest) {
+			stats.Oldest = reading.Timestamp
+		}
+		if reading.Timestamp.After(stats.Newest) {
+			stats.Newest = reading.Timestamp
+		}
+	}
+
+	return stats, nil
+}
+
 // DeleteOldReadings removes readings older than specified duration
-func (r *ReadingRepository) DeleteOldReadings(ctx context.Context, olderThan time.Duration) error {
+func (r *ReadingRepository) DeleteOldReadings(ctx context.Context, olderThan time.Duration) (int64, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	cutoff := time.Now().Add(-olderThan)
 
+	var deleted int64
 	for id, reading := range r.readings {
 		if reading.Timestamp.Before(cutoff) {
 			delete(r.readings, id)
+			delete(r.byTimestamp, reading.Timestamp.UnixNano())
+			deleted++
 		}
 	}
 
-	return nil
+	return deleted, nil
 }
@@ -2,6 +2,7 @@ package memory
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -135,12 +136,16 @@ func TestDeleteOldReadings(t *testing.T) {
 	_ = repo.SaveReading(ctx, old)
 	_ = repo.SaveReading(ctx, recent)
 
-	if err := repo.DeleteOldReadings(ctx, 24*time.Hour); err != nil {
+	deleted, err := repo.DeleteOldReadings(ctx, 24*time.Hour)
+	if err != nil {
 		t.Fatalf("DeleteOldReadings failed: %v", err)
 	}
+	if deleted != 1 {
+		t.Errorf("expected 1 deleted reading, got %d", deleted)
+	}
 
 	// Old reading should be gone
-	_, err := repo.GetReading(ctx, old.ID)
+	_, err = repo.GetReading(ctx, old.ID)
 	if err != domain.ErrReadingNotFound {
 		t.Errorf("expected old reading to be deleted, got err: %v", err)
 	}
@@ -151,3 +156,422 @@ func TestDeleteOldReadings(t *testing.T) {
 		t.Errorf("expected recent reading to remain, got err: %v", err)
 	}
 }
+
+func TestSaveReadings(t *testing.T) {
+	repo := NewReadingRepository()
+	ctx := context.Background()
+
+	var readings []*domain.LightReading
+	for _, lux := range []float64{100, 200, 300} {
+		r, _ := domain.NewLightReading(lux)
+		readings = append(readings, r)
+	}
+
+	if err := repo.SaveReadings(ctx, readings); err != nil {
+		t.Fatalf("SaveReadings failed: %v", err)
+	}
+
+	seen := make(map[int64]bool)
+	for _, r := range readings {
+		if r.ID == 0 {
+			t.Fatal("expected ID to be set after save")
+		}
+		if seen[r.ID] {
+			t.Fatalf("duplicate ID %d", r.ID)
+		}
+		seen[r.ID] = true
+
+		got, err := repo.GetReading(ctx, r.ID)
+		if err != nil {
+			t.Fatalf("GetReading(%d) failed: %v", r.ID, err)
+		}
+		if got.Lux != r.Lux {
+			t.Errorf("reading %d: got lux %v, want %v", r.ID, got.Lux, r.Lux)
+		}
+	}
+}
+
+func TestSaveReading_DuplicateTimestamp(t *testing.T) {
+	repo := NewReadingRepository()
+	ctx := context.Background()
+	ts := time.Now().Add(-time.Minute)
+
+	first, _ := domain.NewLightReadingAt(100, ts)
+	if err := repo.SaveReading(ctx, first); err != nil {
+		t.Fatalf("SaveReading failed: %v", err)
+	}
+	second, _ := domain.NewLightReadingAt(200, ts)
+	if err := repo.SaveReading(ctx, second); !errors.Is(err, domain.ErrDuplicateTimestamp) {
+		t.Errorf("expected ErrDuplicateTimestamp, got %v", err)
+	}
+
+	// A batch with a clash stores none of its readings
+	other, _ := domain.NewLightReadingAt(300, ts.Add(time.Second))
+	clash, _ := domain.NewLightReadingAt(400, ts)
+	if err := repo.SaveReadings(ctx, []*domain.LightReading{other, clash}); !errors.Is(err, domain.ErrDuplicateTimestamp) {
+		t.Errorf("expected ErrDuplicateTimestamp for the batch, got %v", err)
+	}
+
+	readings, err := repo.GetRecentReadings(ctx, 10)
+	if err != nil || len(readings) != 1 || readings[0].Lux != 100 {
+		t.Errorf("expected only the first reading stored, got %v, %v", readings, err)
+	}
+}
+
+func TestSaveReading_OptionalTemperature(t *testing.T) {
+	repo := NewReadingRepository()
+	ctx := context.Background()
+
+	withTemp, _ := domain.NewLightReading(500)
+	if err := withTemp.SetTemperature(22.5); err != nil {
+		t.Fatalf("SetTemperature failed: %v", err)
+	}
+	withoutTemp, _ := domain.NewLightReading(600)
+
+	if err := repo.SaveReading(ctx, withTemp); err != nil {
+		t.Fatalf("SaveReading failed: %v", err)
+	}
+	if err := repo.SaveReading(ctx, withoutTemp); err != nil {
+		t.Fatalf("SaveReading failed: %v", err)
+	}
+
+	got, err := repo.GetReading(ctx, withTemp.ID)
+	if err != nil {
+		t.Fatalf("GetReading failed: %v", err)
+	}
+	if got.TemperatureC == nil || *got.TemperatureC != 22.5 {
+		t.Errorf("expected temperature 22.5, got %v", got.TemperatureC)
+	}
+
+	got, err = repo.GetReading(ctx, withoutTemp.ID)
+	if err != nil {
+		t.Fatalf("GetReading failed: %v", err)
+	}
+	if got.TemperatureC != nil {
+		t.Errorf("expected no temperature, got %v", *got.TemperatureC)
+	}
+}
+
+func TestGetRecentReadings(t *testing.T) {
+	repo := NewReadingRepository()
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	// Save out of order so the repository has to sort
+	for _, offset := range []int{3, 1, 4, 0, 2} {
+		r, _ := domain.NewLightReading(float64(100 * offset))
+		r.Timestamp = now.Add(-time.Duration(offset) * time.Minute)
+		_ = repo.SaveReading(ctx, r)
+	}
+
+	results, err := repo.GetRecentReadings(ctx, 3)
+	if err != nil {
+		t.Fatalf("GetRecentReadings failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 readings, got %d", len(results))
+	}
+
+	// The three newest (offsets 2, 1, 0), oldest first
+	for i, want := range []float64{200, 100, 0} {
+		if results[i].Lux != want {
+			t.Errorf("result %d: expected lux %v, got %v", i, want, results[i].Lux)
+		}
+	}
+
+	all, err := repo.GetRecentReadings(ctx, 50)
+	if err != nil {
+		t.Fatalf("GetRecentReadings failed: %v", err)
+	}
+	if len(all) != 5 {
+		t.Errorf("expected all 5 readings when limit exceeds count, got %d", len(all))
+	}
+}
+
+func TestUpsertReadings_ReimportReplaces(t *testing.T) {
+	repo := NewReadingRepository()
+	ctx := context.Background()
+
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	batch := func(lux float64) []*domain.LightReading {
+		readings := make([]*domain.LightReading, 3)
+		for i := range readings {
+			readings[i], _ = domain.NewLightReading(lux + float64(i))
+			readings[i].Timestamp = base.Add(time.Duration(i) * time.Minute)
+			readings[i].Source = domain.SourceImport
+		}
+		return readings
+	}
+	countRows := func() int {
+		t.Helper()
+		all, _ := repo.GetReadingsInRange(ctx, base.Add(-time.Hour), base.Add(time.Hour))
+		return len(all)
+	}
+
+	first := batch(100)
+	if err := repo.UpsertReadings(ctx, first); err != nil {
+		t.Fatalf("first import failed: %v", err)
+	}
+
+	// Re-import the same timestamps with corrected values
+	second := batch(500)
+	if err := repo.UpsertReadings(ctx, second); err != nil {
+		t.Fatalf("re-import failed: %v", err)
+	}
+
+	if n := countRows(); n != 3 {
+		t.Errorf("expected re-import to keep 3 rows, got %d", n)
+	}
+	for i, r := range second {
+		if r.ID != first[i].ID {
+			t.Errorf("reading %d: expected ID %d to be reused, got %d", i, first[i].ID, r.ID)
+		}
+		stored, err := repo.GetReading(ctx, r.ID)
+		if err != nil {
+			t.Fatalf("GetReading failed: %v", err)
+		}
+		if stored.Lux != 500+float64(i) {
+			t.Errorf("reading %d: expected updated lux %v, got %v", i, 500+float64(i), stored.Lux)
+		}
+	}
+
+	// A single upsert at an existing timestamp also replaces
+	single, _ := domain.NewLightReading(42)
+	single.Timestamp = base
+	if err := repo.UpsertReading(ctx, single); err != nil {
+		t.Fatalf("UpsertReading failed: %v", err)
+	}
+	if single.ID != first[0].ID {
+		t.Errorf("expected UpsertReading to reuse ID %d, got %d", first[0].ID, single.ID)
+	}
+	if n := countRows(); n != 3 {
+		t.Errorf("expected 3 rows after single upsert, got %d", n)
+	}
+}
+
+func TestCategoryEvents(t *testing.T) {
+	repo := NewReadingRepository()
+	ctx := context.Background()
+
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	events := []*domain.CategoryEvent{
+		{From: domain.CategoryMedium, To: domain.CategoryHigh, Lux: 3000, Timestamp: base.Add(20 * time.Minute)},
+		{From: domain.CategoryLow, To: domain.CategoryMedium, Lux: 800, Timestamp: base.Add(10 * time.Minute)},
+		{From: domain.CategoryHigh, To: domain.CategoryLow, Lux: 50, Timestamp: base.Add(50 * time.Minute)},
+	}
+	for _, e := range events {
+		if err := repo.SaveCategoryEvent(ctx, e); err != nil {
+			t.Fatalf("SaveCategoryEvent failed: %v", err)
+		}
+		if e.ID == 0 {
+			t.Error("expected ID to be set after save")
+		}
+	}
+
+	got, err := repo.GetCategoryEvents(ctx, base, base.Add(30*time.Minute))
+	if err != nil {
+		t.Fatalf("GetCategoryEvents failed: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 events in range, got %d", len(got))
+	}
+	if got[0].To != domain.CategoryMedium || got[1].To != domain.CategoryHigh {
+		t.Errorf("expected events oldest first, got %v then %v", got[0].To, got[1].To)
+	}
+	if got[0].From != domain.CategoryLow || got[0].Lux != 800 {
+		t.Errorf("unexpected first event %+v", got[0])
+	}
+}
+
+func TestGetReadingAsOf(t *testing.T) {
+	repo := NewReadingRepository()
+	ctx := context.Background()
+
+	at := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for _, r := range []struct {
+		offset time.Duration
+		lux    float64
+	}{
+		{-10 * time.Minute, 100},
+		{-time.Minute, 200}, // latest at or before at
+		{time.Second, 300},
+		{10 * time.Minute, 400},
+	} {
+		reading, _ := domain.NewLightReading(r.lux)
+		reading.Timestamp = at.Add(r.offset)
+		_ = repo.SaveReading(ctx, reading)
+	}
+
+	got, err := repo.GetReadingAsOf(ctx, at)
+	if err != nil {
+		t.Fatalf("GetReadingAsOf failed: %v", err)
+	}
+	if got.Lux != 200 {
+		t.Errorf("expected the 200 lux reading before T, got %v", got.Lux)
+	}
+
+	// A reading exactly at T counts as current
+	exact, err := repo.GetReadingAsOf(ctx, at.Add(time.Second))
+	if err != nil {
+		t.Fatalf("GetReadingAsOf failed: %v", err)
+	}
+	if exact.Lux != 300 {
+		t.Errorf("expected the reading at exactly T (300 lux), got %v", exact.Lux)
+	}
+
+	if _, err := repo.GetReadingAsOf(ctx, at.Add(-time.Hour)); err != domain.ErrReadingNotFound {
+		t.Errorf("expected ErrReadingNotFound before any readings, got %v", err)
+	}
+}
+
+func TestStats(t *testing.T) {
+	repo := NewReadingRepository()
+	ctx := context.Background()
+
+	empty, err := repo.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	if empty.ReadingCount != 0 || !empty.Oldest.IsZero() || !empty.Newest.IsZero() {
+		t.Errorf("expected zero stats for empty repo, got %+v", empty)
+	}
+
+	base := time.Now().Truncate(time.Second)
+	for _, offset := range []time.Duration{-time.Hour, -3 * time.Hour, -2 * time.Hour} {
+		r, _ := domain.NewLightReading(100)
+		r.Timestamp = base.Add(offset)
+		_ = repo.SaveReading(ctx, r)
+	}
+
+	stats, err := repo.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	if stats.ReadingCount != 3 {
+		t.Errorf("expected 3 readings, got %d", stats.ReadingCount)
+	}
+	if !stats.Oldest.Equal(base.Add(-3 * time.Hour)) {
+		t.Errorf("expected oldest %v, got %v", base.Add(-3*time.Hour), stats.Oldest)
+	}
+	if !stats.Newest.Equal(base.Add(-time.Hour)) {
+		t.Errorf("expected newest %v, got %v", base.Add(-time.Hour), stats.Newest)
+	}
+	if stats.SizeBytes != 0 {
+		t.Errorf("expected no on-disk size for memory repo, got %d", stats.SizeBytes)
+	}
+}
+
+func TestGetRecordingDays_LocalDayBoundary(t *testing.T) {
+	repo := NewReadingRepository()
+	ctx := context.Background()
+
+	// UTC+5:30, so local midnight falls on a half hour in UTC
+	india := time.FixedZone("IST", 5*3600+30*60)
+	hawaii := time.FixedZone("HST", -10*3600)
+	for _, ts := range []time.Time{
+		time.Date(2024, 6, 1, 18, 29, 0, 0, time.UTC), // 23:59 on 1 June in India
+		time.Date(2024, 6, 1, 18, 31, 0, 0, time.UTC), // 00:01 on 2 June in India
+		time.Date(2024, 6, 2, 3, 0, 0, 0, time.UTC),   // 08:30 on 2 June in India
+		time.Date(2024, 6, 9, 12, 0, 0, 0, time.UTC),  // outside the range
+	} {
+		reading, _ := domain.NewLightReading(300)
+		reading.Timestamp = ts
+		if err := repo.SaveReading(ctx, reading); err != nil {
+			t.Fatalf("SaveReading failed: %v", err)
+		}
+	}
+
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+	tests := []struct {
+		name string
+		loc  *time.Location
+		want []time.Time
+	}{
+		{"India", india, []time.Time{
+			time.Date(2024, 6, 1, 0, 0, 0, 0, india),
+			time.Date(2024, 6, 2, 0, 0, 0, 0, india),
+		}},
+		{"UTC by default", nil, []time.Time{
+			time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC),
+		}},
+		{"behind UTC", hawaii, []time.Time{
+			time.Date(2024, 6, 1, 0, 0, 0, 0, hawaii),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			days, err := repo.GetRecordingDays(ctx, start, end, tt.loc)
+			if err != nil {
+				t.Fatalf("GetRecordingDays failed: %v", err)
+			}
+			if len(days) != len(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, days)
+			}
+			for i, w := range tt.want {
+				if !days[i].Equal(w) {
+					t.Errorf("day %d: expected %v, got %v", i, w, days[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGetReadingsByIDs(t *testing.T) {
+	repo := NewReadingRepository()
+	ctx := context.Background()
+
+	var ids []int64
+	for _, lux := range []float64{100, 200, 300} {
+		r, _ := domain.NewLightReading(lux)
+		_ = repo.SaveReading(ctx, r)
+		ids = append(ids, r.ID)
+	}
+
+	got, err := repo.GetReadingsByIDs(ctx, []int64{ids[1], 999, ids[1], ids[0]})
+	if err != nil {
+		t.Fatalf("GetReadingsByIDs failed: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != ids[1] || got[1].ID != ids[0] {
+		t.Errorf("expected readings %d then %d, got %v", ids[1], ids[0], got)
+	}
+}
+
+func TestSaveReading_ExplicitIDDoesNotCollide(t *testing.T) {
+	repo := NewReadingRepository()
+	ctx := context.Background()
+
+	explicit, _ := domain.NewLightReading(999)
+	explicit.ID = 3
+	if err := repo.SaveReading(ctx, explicit); err != nil {
+		t.Fatalf("SaveReading failed: %v", err)
+	}
+
+	var auto []*domain.LightReading
+	for i := 0; i < 5; i++ {
+		r, _ := domain.NewLightReading(float64(100 + i))
+		if err := repo.SaveReading(ctx, r); err != nil {
+			t.Fatalf("SaveReading failed: %v", err)
+		}
+		if r.ID == explicit.ID {
+			t.Fatalf("auto-assigned ID %d collides with the explicit one", r.ID)
+		}
+		auto = append(auto, r)
+	}
+
+	got, err := repo.GetReading(ctx, explicit.ID)
+	if err != nil || got.Lux != 999 {
+		t.Errorf("expected the explicit reading intact, got %v, %v", got, err)
+	}
+	for _, r := range auto {
+		got, err := repo.GetReading(ctx, r.ID)
+		if err != nil || got.Lux != r.Lux {
+			t.Errorf("reading %d: expected lux %v, got %v, %v", r.ID, r.Lux, got, err)
+		}
+	}
+	if stats, _ := repo.Stats(ctx); stats.ReadingCount != 6 {
+		t.Errorf("expected 6 readings, got %d", stats.ReadingCount)
+	}
+}
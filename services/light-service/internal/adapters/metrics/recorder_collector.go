@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/ports"
+)
+
+// RecorderStatusSource reports the background recorder's status
+type RecorderStatusSource interface {
+	Status() ports.RecorderStatus
+}
+
+var (
+	lastSuccessDesc = prometheus.NewDesc(
+		"light_recorder_last_success_timestamp_seconds",
+		"Unix time of the recorder's last successful cycle (0 if none yet).",
+		nil, nil,
+	)
+	consecutiveFailuresDesc = prometheus.NewDesc(
+		"light_recorder_consecutive_failures",
+		"Recording cycles that have failed in a row.",
+		nil, nil,
+	)
+)
+
+// RecorderCollector reports recorder health, for alerting when recording
+// goes stale
+type RecorderCollector struct {
+	source RecorderStatusSource
+}
+
+// NewRecorderCollector creates a collector for source's status
+func NewRecorderCollector(source RecorderStatusSource) *RecorderCollector {
+	return &RecorderCollector{source: source}
+}
+
+// Describe implements prometheus.Collector
+func (c *RecorderCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- lastSuccessDesc
+	ch <- consecutiveFailuresDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *RecorderCollector) Collect(ch chan<- prometheus.Metric) {
+	status := c.source.Status()
+	ch <- prometheus.MustNewConstMetric(lastSuccessDesc, prometheus.GaugeValue, unixSeconds(status.LastSuccess))
+	ch <- prometheus.MustNewConstMetric(consecutiveFailuresDesc, prometheus.GaugeValue, float64(status.ConsecutiveFailures))
+}
@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/ports"
+)
+
+// staticStatus is a RecorderStatusSource with a fixed status
+type staticStatus ports.RecorderStatus
+
+func (s staticStatus) Status() ports.RecorderStatus { return ports.RecorderStatus(s) }
+
+func TestRecorderCollector(t *testing.T) {
+	lastSuccess := time.Unix(1_700_000_000, 0)
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(NewRecorderCollector(staticStatus{LastSuccess: lastSuccess, ConsecutiveFailures: 3}))
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather failed: %v", err)
+	}
+
+	got := map[string]float64{}
+	for _, f := range families {
+		got[f.GetName()] = f.GetMetric()[0].GetGauge().GetValue()
+	}
+	if got["light_recorder_last_success_timestamp_seconds"] != float64(lastSuccess.Unix()) {
+		t.Errorf("unexpected last success gauge %v", got["light_recorder_last_success_timestamp_seconds"])
+	}
+	if got["light_recorder_consecutive_failures"] != 3 {
+		t.Errorf("unexpected failures gauge %v", got["light_recorder_consecutive_failures"])
+	}
+}
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/ports"
+)
+
+// SensorHealthSource reports the sensor watchdog's view of the sensor
+type SensorHealthSource interface {
+	Status() ports.SensorHealth
+}
+
+var (
+	sensorHealthyDesc = prometheus.NewDesc(
+		"light_sensor_healthy",
+		"1 while the light sensor reads successfully, 0 once it has failed too many reads in a row.",
+		nil, nil,
+	)
+	sensorFailuresDesc = prometheus.NewDesc(
+		"light_sensor_consecutive_failures",
+		"Light sensor reads that have failed in a row.",
+		nil, nil,
+	)
+	sensorReinitsDesc = prometheus.NewDesc(
+		"light_sensor_reinits_total",
+		"Times the light sensor was re-initialized after failing.",
+		nil, nil,
+	)
+	sensorFallbackReadsDesc = prometheus.NewDesc(
+		"light_sensor_fallback_reads_total",
+		"Reads served by the fallback light sensor.",
+		nil, nil,
+	)
+)
+
+// SensorCollector reports sensor health, for alerting on a failed sensor
+// even while a fallback keeps readings coming
+type SensorCollector struct {
+	source SensorHealthSource
+}
+
+// NewSensorCollector creates a collector for source's status
+func NewSensorCollector(source SensorHealthSource) *SensorCollector {
+	return &SensorCollector{source: source}
+}
+
+// Describe implements prometheus.Collector
+func (c *SensorCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- sensorHealthyDesc
+	ch <- sensorFailuresDesc
+	ch <- sensorReinitsDesc
+	ch <- sensorFallbackReadsDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *SensorCollector) Collect(ch chan<- prometheus.Metric) {
+	status := c.source.Status()
+	healthy := 0.0
+	if status.Healthy {
+		healthy = 1
+	}
+	ch <- prometheus.MustNewConstMetric(sensorHealthyDesc, prometheus.GaugeValue, healthy)
+	ch <- prometheus.MustNewConstMetric(sensorFailuresDesc, prometheus.GaugeValue, float64(status.ConsecutiveFailures))
+	ch <- prometheus.MustNewConstMetric(sensorReinitsDesc, prometheus.CounterValue, float64(status.Reinits))
+	ch <- prometheus.MustNewConstMetric(sensorFallbackReadsDesc, prometheus.CounterValue, float64(status.FallbackReads))
+}
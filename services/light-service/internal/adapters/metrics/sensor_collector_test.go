@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/ports"
+)
+
+// staticSensorHealth is a SensorHealthSource with a fixed status
+type staticSensorHealth ports.SensorHealth
+
+func (s staticSensorHealth) Status() ports.SensorHealth { return ports.SensorHealth(s) }
+
+func TestSensorCollector(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(NewSensorCollector(staticSensorHealth{ConsecutiveFailures: 4, Reinits: 1, FallbackReads: 2}))
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather failed: %v", err)
+	}
+
+	got := map[string]float64{}
+	for _, f := range families {
+		m := f.GetMetric()[0]
+		if f.GetType() == dto.MetricType_COUNTER {
+			got[f.GetName()] = m.GetCounter().GetValue()
+		} else {
+			got[f.GetName()] = m.GetGauge().GetValue()
+		}
+	}
+	want := map[string]float64{
+		"light_sensor_healthy":              0,
+		"light_sensor_consecutive_failures": 4,
+		"light_sensor_reinits_total":        1,
+		"light_sensor_fallback_reads_total": 2,
+	}
+	for name, value := range want {
+		if v, ok := got[name]; !ok || v != value {
+			t.Errorf("%s = %v (present %v), want %v", name, v, ok, value)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Package metrics exposes service state as Prometheus metrics.
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog/log"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// statsTimeout bounds how long a scrape waits on the repository
+const statsTimeout = 5 * time.Second
+
+var (
+	readingsDesc = prometheus.NewDesc(
+		"light_storage_readings",
+		"Number of light readings currently stored.",
+		nil, nil,
+	)
+	oldestDesc = prometheus.NewDesc(
+		"light_storage_oldest_reading_timestamp_seconds",
+		"Unix time of the oldest stored reading (0 when empty).",
+		nil, nil,
+	)
+	newestDesc = prometheus.NewDesc(
+		"light_storage_newest_reading_timestamp_seconds",
+		"Unix time of the newest stored reading (0 when empty).",
+		nil, nil,
+	)
+	sizeDesc = prometheus.NewDesc(
+		"light_storage_size_bytes",
+		"On-disk size of the reading store (0 for in-memory storage).",
+		nil, nil,
+	)
+)
+
+// StorageCollector reports repository size gauges, querying the repository
+// at scrape time so the values are never stale
+type StorageCollector struct {
+	repo domain.ReadingRepository
+}
+
+// NewStorageCollector creates a collector for repo's storage stats
+func NewStorageCollector(repo domain.ReadingRepository) *StorageCollector {
+	return &StorageCollector{repo: repo}
+}
+
+// Describe implements prometheus.Collector
+func (c *StorageCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- readingsDesc
+	ch <- oldestDesc
+	ch <- newestDesc
+	ch <- sizeDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *StorageCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), statsTimeout)
+	defer cancel()
+
+	stats, err := c.repo.Stats(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to collect storage stats")
+		ch <- prometheus.NewInvalidMetric(readingsDesc, err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(readingsDesc, prometheus.GaugeValue, float64(stats.ReadingCount))
+	ch <- prometheus.MustNewConstMetric(oldestDesc, prometheus.GaugeValue, unixSeconds(stats.Oldest))
+	ch <- prometheus.MustNewConstMetric(newestDesc, prometheus.GaugeValue, unixSeconds(stats.Newest))
+	ch <- prometheus.MustNewConstMetric(sizeDesc, prometheus.GaugeValue, float64(stats.SizeBytes))
+}
+
+// unixSeconds converts t to fractional Unix seconds, mapping the zero time to 0
+func unixSeconds(t time.Time) float64 {
+	if t.IsZero() {
+		return 0
+	}
+	return float64(t.UnixMilli()) / 1000
+}
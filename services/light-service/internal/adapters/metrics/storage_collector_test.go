@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+func TestStorageCollector(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	ctx := context.Background()
+
+	newest := time.Unix(1_700_000_000, 0)
+	for i := 0; i < 3; i++ {
+		r, _ := domain.NewLightReading(100)
+		r.Timestamp = newest.Add(-time.Duration(i) * time.Hour)
+		_ = repo.SaveReading(ctx, r)
+	}
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(NewStorageCollector(repo))
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather failed: %v", err)
+	}
+
+	got := map[string]float64{}
+	for _, f := range families {
+		got[f.GetName()] = f.GetMetric()[0].GetGauge().GetValue()
+	}
+
+	want := map[string]float64{
+		"light_storage_readings":                         3,
+		"light_storage_oldest_reading_timestamp_seconds": float64(newest.Add(-2 * time.Hour).Unix()),
+		"light_storage_newest_reading_timestamp_seconds": float64(newest.Unix()),
+		"light_storage_size_bytes":                       0,
+	}
+	for name, w := range want {
+		if v, ok := got[name]; !ok || v != w {
+			t.Errorf("%s: expected %v, got %v (present=%v)", name, w, v, ok)
+		}
+	}
+}
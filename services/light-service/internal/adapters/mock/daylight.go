@@ -0,0 +1,29 @@
+package mock
+
+import (
+	"math"
+	"time"
+)
+
+// Daylight model: a smooth arc between sunrise and sunset in the reading's
+// own time zone, peaking at solar noon, with a little light at night from
+// the room
+const (
+	sunriseHour = 6.0
+	sunsetHour  = 20.0
+	nightLux    = 5.0
+)
+
+// DaylightLux returns a plausible indoor light level at the given time of
+// day for a spot that sees peakLux at midday. The result depends only on the
+// time of day, so the same day generated twice is identical.
+func DaylightLux(at time.Time, peakLux float64) float64 {
+	hour := float64(at.Hour()) + float64(at.Minute())/60 + float64(at.Second())/3600
+	if hour <= sunriseHour || hour >= sunsetHour {
+		return nightLux
+	}
+
+	// Half a sine wave across the daylight hours
+	phase := (hour - sunriseHour) / (sunsetHour - sunriseHour)
+	return nightLux + (peakLux-nightLux)*math.Sin(phase*math.Pi)
+}
@@ -0,0 +1,206 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// DiurnalSensor simulates a sensor under natural light, following the sun
+// through the day: near-dark at night, a twilight ramp before sunrise, a
+// sine-shaped arc to a midday peak, and the same in reverse at dusk. Sun
+// times come from a latitude and the date, or are fixed with WithSunTimes.
+// Unlike FakeSensor's flat level, this gives daily light integrals, category
+// transitions and night mode something realistic to work on.
+// This implements the ports.LightSensor interface
+type DiurnalSensor struct {
+	peakLux  float64
+	nightLux float64
+	latitude float64
+	sunrise  time.Duration // times of day, used if fixedSun is set
+	sunset   time.Duration
+	fixedSun bool
+	twilight time.Duration
+	clouds   float64 // 0 is a clear sky
+	clock    domain.Clock
+	seed     int64
+
+	mu  sync.Mutex // rand.Rand is not safe for concurrent use
+	rng *rand.Rand
+}
+
+// Defaults for NewDiurnalSensor
+const (
+	DefaultDiurnalPeakLux  = 3000.0 // a bright windowsill
+	DefaultDiurnalNightLux = 1.0
+	DefaultTwilight        = 30 * time.Minute
+)
+
+// dawnRatio is the share of the peak reached at sunrise and sunset, where
+// twilight hands over to the daytime arc
+const dawnRatio = 0.02
+
+// axialTilt is the Earth's, in degrees
+const axialTilt = 23.44
+
+// DiurnalOption configures a DiurnalSensor
+type DiurnalOption func(*DiurnalSensor)
+
+// WithPeakLux sets the light level at solar noon on a clear day
+func WithPeakLux(lux float64) DiurnalOption {
+	return func(s *DiurnalSensor) { s.peakLux = lux }
+}
+
+// WithNightLux sets the light level between dusk and dawn
+func WithNightLux(lux float64) DiurnalOption {
+	return func(s *DiurnalSensor) { s.nightLux = lux }
+}
+
+// WithLatitude derives sunrise and sunset from latitude in degrees (north
+// positive) and the date, so days lengthen and shorten with the seasons.
+// Solar noon is taken as 12:00 in the reading's time zone. The default
+// latitude, the equator, gives 12-hour days all year.
+func WithLatitude(degrees float64) DiurnalOption {
+	return func(s *DiurnalSensor) { s.latitude = degrees }
+}
+
+// WithSunTimes fixes sunrise and sunset as times of day, e.g. 6h30m and
+// 20h, overriding the latitude
+func WithSunTimes(sunrise, sunset time.Duration) DiurnalOption {
+	return func(s *DiurnalSensor) {
+		s.sunrise = sunrise
+		s.sunset = sunset
+		s.fixedSun = true
+	}
+}
+
+// WithTwilight sets how long light ramps up before sunrise and down after
+// sunset
+func WithTwilight(d time.Duration) DiurnalOption {
+	return func(s *DiurnalSensor) { s.twilight = d }
+}
+
+// WithClouds makes passing clouds dim each read by up to fraction of the
+// clear-sky level (0.5 reads between 50% and 100% of it), drawn from seed so
+// runs are reproducible
+func WithClouds(fraction float64, seed int64) DiurnalOption {
+	return func(s *DiurnalSensor) {
+		s.clouds = fraction
+		s.seed = seed
+	}
+}
+
+// WithDiurnalClock sets the clock reads are timed by, so tests and demos can
+// run through a day quickly
+func WithDiurnalClock(clock domain.Clock) DiurnalOption {
+	return func(s *DiurnalSensor) { s.clock = clock }
+}
+
+// NewDiurnalSensor creates a sensor following the sun, by default peaking at
+// DefaultDiurnalPeakLux on the equator under a clear sky
+func NewDiurnalSensor(opts ...DiurnalOption) (*DiurnalSensor, error) {
+	s := &DiurnalSensor{
+		peakLux:  DefaultDiurnalPeakLux,
+		nightLux: DefaultDiurnalNightLux,
+		twilight: DefaultTwilight,
+		clock:    domain.RealClock{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	switch {
+	case s.peakLux <= 0:
+		return nil, fmt.Errorf("peak lux must be positive, got %v", s.peakLux)
+	case s.nightLux < 0 || s.nightLux > s.peakLux:
+		return nil, fmt.Errorf("night lux must be between 0 and the peak, got %v", s.nightLux)
+	case s.latitude < -90 || s.latitude > 90:
+		return nil, fmt.Errorf("latitude must be between -90 and 90, got %v", s.latitude)
+	case s.fixedSun && (s.sunrise < 0 || s.sunset > 24*time.Hour || s.sunrise >= s.sunset):
+		return nil, fmt.Errorf("sunrise %v must be before sunset %v within the day", s.sunrise, s.sunset)
+	case s.twilight < 0:
+		return nil, fmt.Errorf("twilight cannot be negative, got %v", s.twilight)
+	case s.clouds < 0 || s.clouds > 1:
+		return nil, fmt.Errorf("cloud fraction must be between 0 and 1, got %v", s.clouds)
+	}
+	s.rng = rand.New(rand.NewSource(s.seed))
+	return s, nil
+}
+
+// ReadLux returns the light level at the clock's current time
+func (s *DiurnalSensor) ReadLux(ctx context.Context) (float64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	lux := s.LuxAt(s.clock.Now())
+	if s.clouds > 0 {
+		s.mu.Lock()
+		r := s.rng.Float64()
+		s.mu.Unlock()
+		lux = s.nightLux + (lux-s.nightLux)*(1-s.clouds*r)
+	}
+	return lux, nil
+}
+
+// LuxAt returns the clear-sky light level at a time, in that time's zone.
+// It depends only on the time, so it can also generate history.
+func (s *DiurnalSensor) LuxAt(at time.Time) float64 {
+	sunrise, sunset, ok := s.SunTimes(at)
+	if !ok {
+		return s.nightLux
+	}
+	midnight := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, at.Location())
+	now := at.Sub(midnight)
+	dawnLux := s.nightLux + (s.peakLux-s.nightLux)*dawnRatio
+
+	switch {
+	case now >= sunrise && now <= sunset:
+		// Half a sine wave across the daylight hours
+		phase := float64(now-sunrise) / float64(sunset-sunrise)
+		return dawnLux + (s.peakLux-dawnLux)*math.Sin(phase*math.Pi)
+	case s.twilight > 0 && now < sunrise && now > sunrise-s.twilight:
+		return s.nightLux + (dawnLux-s.nightLux)*smoothstep(float64(now-(sunrise-s.twilight))/float64(s.twilight))
+	case s.twilight > 0 && now > sunset && now < sunset+s.twilight:
+		return s.nightLux + (dawnLux-s.nightLux)*smoothstep(float64(sunset+s.twilight-now)/float64(s.twilight))
+	default:
+		return s.nightLux
+	}
+}
+
+// SunTimes returns sunrise and sunset on at's day as times since midnight.
+// ok is false on a polar night, when the sun doesn't rise; under the
+// midnight sun they span the whole day.
+func (s *DiurnalSensor) SunTimes(at time.Time) (sunrise, sunset time.Duration, ok bool) {
+	if s.fixedSun {
+		return s.sunrise, s.sunset, true
+	}
+
+	// Solar declination, then the sunrise equation for the hour angle
+	declination := axialTilt * math.Sin(2*math.Pi*float64(284+at.YearDay())/365)
+	cosHourAngle := -math.Tan(radians(s.latitude)) * math.Tan(radians(declination))
+	if cosHourAngle >= 1 {
+		return 0, 0, false
+	}
+	cosHourAngle = max(cosHourAngle, -1)
+	halfDay := time.Duration(math.Acos(cosHourAngle) / math.Pi * 12 * float64(time.Hour))
+	return 12*time.Hour - halfDay, 12*time.Hour + halfDay, true
+}
+
+// Close is a no-op for the simulated sensor
+func (s *DiurnalSensor) Close() error {
+	return nil
+}
+
+// smoothstep eases x in [0, 1] in and out, so twilight has no sharp corners
+func smoothstep(x float64) float64 {
+	return x * x * (3 - 2*x)
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
@@ -0,0 +1,143 @@
+package mock
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func newDiurnal(t *testing.T, opts ...DiurnalOption) *DiurnalSensor {
+	t.Helper()
+	s, err := NewDiurnalSensor(opts...)
+	if err != nil {
+		t.Fatalf("NewDiurnalSensor failed: %v", err)
+	}
+	return s
+}
+
+func TestDiurnalSensor_DailyCurve(t *testing.T) {
+	s := newDiurnal(t, WithSunTimes(6*time.Hour, 18*time.Hour), WithPeakLux(1000), WithNightLux(1))
+	day := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) float64 { return s.LuxAt(day.Add(d)) }
+
+	if lux := at(3 * time.Hour); lux != 1 {
+		t.Errorf("expected night lux at 03:00, got %v", lux)
+	}
+	if lux := at(12 * time.Hour); lux != 1000 {
+		t.Errorf("expected the peak at noon, got %v", lux)
+	}
+	if lux := at(23 * time.Hour); lux != 1 {
+		t.Errorf("expected night lux at 23:00, got %v", lux)
+	}
+
+	// Light rises steadily from before dawn to noon, and mirrors it after
+	prev := 0.0
+	for d := 5 * time.Hour; d <= 12*time.Hour; d += 5 * time.Minute {
+		lux := at(d)
+		if lux < prev {
+			t.Fatalf("light fell from %v to %v at %v", prev, lux, d)
+		}
+		prev = lux
+		if mirror := at(24*time.Hour - d); mirror < lux-1e-9 || mirror > lux+1e-9 {
+			t.Errorf("expected %v at %v to mirror %v at %v", mirror, 24*time.Hour-d, lux, d)
+		}
+	}
+	if dawn := at(5*time.Hour + 45*time.Minute); dawn <= 1 || dawn >= at(6*time.Hour) {
+		t.Errorf("expected twilight between night and sunrise levels, got %v", dawn)
+	}
+}
+
+func TestDiurnalSensor_LatitudeFollowsSeasons(t *testing.T) {
+	london := newDiurnal(t, WithLatitude(51.5))
+	dayLength := func(at time.Time) time.Duration {
+		sunrise, sunset, ok := london.SunTimes(at)
+		if !ok {
+			t.Fatalf("expected the sun to rise on %v", at)
+		}
+		return sunset - sunrise
+	}
+
+	summer := dayLength(time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC))
+	winter := dayLength(time.Date(2024, 12, 21, 12, 0, 0, 0, time.UTC))
+	if summer < 16*time.Hour || summer > 17*time.Hour {
+		t.Errorf("expected about 16.5h of summer daylight, got %v", summer)
+	}
+	if winter < 7*time.Hour+30*time.Minute || winter > 8*time.Hour+30*time.Minute {
+		t.Errorf("expected about 8h of winter daylight, got %v", winter)
+	}
+
+	// Seasons are reversed south of the equator
+	sydney := newDiurnal(t, WithLatitude(-33.9))
+	s, e, _ := sydney.SunTimes(time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC))
+	if e-s >= 12*time.Hour {
+		t.Errorf("expected a short June day in Sydney, got %v", e-s)
+	}
+}
+
+func TestDiurnalSensor_PolarDays(t *testing.T) {
+	s := newDiurnal(t, WithLatitude(78), WithNightLux(0.5))
+
+	winterNoon := time.Date(2024, 12, 21, 12, 0, 0, 0, time.UTC)
+	if _, _, ok := s.SunTimes(winterNoon); ok {
+		t.Error("expected a polar night in December")
+	}
+	if lux := s.LuxAt(winterNoon); lux != 0.5 {
+		t.Errorf("expected night lux at noon on a polar night, got %v", lux)
+	}
+
+	summerMidnight := time.Date(2024, 6, 21, 0, 30, 0, 0, time.UTC)
+	if lux := s.LuxAt(summerMidnight); lux <= 0.5 {
+		t.Errorf("expected light at midnight under the midnight sun, got %v", lux)
+	}
+}
+
+func TestDiurnalSensor_ReadLuxFollowsClock(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 6, 1, 2, 0, 0, 0, time.UTC))
+	s := newDiurnal(t, WithDiurnalClock(clock), WithSunTimes(6*time.Hour, 18*time.Hour))
+	ctx := context.Background()
+
+	night, _ := s.ReadLux(ctx)
+	clock.Advance(10 * time.Hour)
+	noon, _ := s.ReadLux(ctx)
+	if night != DefaultDiurnalNightLux || noon != DefaultDiurnalPeakLux {
+		t.Errorf("expected %v at night and %v at noon, got %v and %v", DefaultDiurnalNightLux, DefaultDiurnalPeakLux, night, noon)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := s.ReadLux(cancelled); err == nil {
+		t.Error("expected a cancelled read to fail")
+	}
+}
+
+func TestDiurnalSensor_CloudsAreBoundedAndReproducible(t *testing.T) {
+	noon := NewFakeClock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	opts := []DiurnalOption{WithDiurnalClock(noon), WithClouds(0.4, 7)}
+	a, b := newDiurnal(t, opts...), newDiurnal(t, opts...)
+
+	for i := range 20 {
+		x, _ := a.ReadLux(context.Background())
+		y, _ := b.ReadLux(context.Background())
+		if x != y {
+			t.Fatalf("read %d: same seed gave %v and %v", i, x, y)
+		}
+		if x > DefaultDiurnalPeakLux || x < 0.6*DefaultDiurnalPeakLux {
+			t.Errorf("read %d: %v outside 60-100%% of the peak", i, x)
+		}
+	}
+}
+
+func TestNewDiurnalSensor_InvalidOptions(t *testing.T) {
+	tests := map[string][]DiurnalOption{
+		"zero peak":          {WithPeakLux(0)},
+		"night above peak":   {WithPeakLux(100), WithNightLux(200)},
+		"latitude":           {WithLatitude(91)},
+		"sunset before rise": {WithSunTimes(20*time.Hour, 6*time.Hour)},
+		"clouds":             {WithClouds(1.5, 0)},
+	}
+	for name, opts := range tests {
+		if _, err := NewDiurnalSensor(opts...); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
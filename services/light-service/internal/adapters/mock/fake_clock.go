@@ -0,0 +1,198 @@
+package mock
+
+import (
+	"sync"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// FakeClock is a domain.Clock that only moves when told to
+// This lets tests drive tickers and timers without real sleeping
+type FakeClock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	tickers []*fakeTicker
+	timers  []*fakeTimer
+}
+
+// NewFakeClock creates a clock stopped at start
+func NewFakeClock(start time.Time) *FakeClock {
+	c := &FakeClock{now: start}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now returns the fake current time
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTicker creates a ticker that fires as Advance moves time past each period
+func (c *FakeClock) NewTicker(d time.Duration) domain.Ticker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTicker{
+		clock:  c,
+		ch:     make(chan time.Time, 1),
+		period: d,
+		next:   c.now.Add(d),
+	}
+	c.tickers = append(c.tickers, t)
+	c.cond.Broadcast()
+	return t
+}
+
+// NewTimer creates a timer that fires once Advance moves time past d
+func (c *FakeClock) NewTimer(d time.Duration) domain.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTimer{
+		clock: c,
+		ch:    make(chan time.Time, 1),
+		at:    c.now.Add(d),
+	}
+	c.timers = append(c.timers, t)
+	c.cond.Broadcast()
+	return t
+}
+
+// Advance moves time forward by d, firing every ticker and timer that comes
+// due.
+// Like time.Ticker, a ticker whose previous tick hasn't been received drops
+// the new one rather than queueing it.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	for _, t := range c.tickers {
+		if t.stopped || t.next.After(c.now) {
+			continue
+		}
+		select {
+		case t.ch <- c.now:
+		default:
+		}
+		for !t.next.After(c.now) {
+			t.next = t.next.Add(t.period)
+		}
+	}
+
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if t.stopped {
+			continue
+		}
+		if t.at.After(c.now) {
+			pending = append(pending, t)
+			continue
+		}
+		t.ch <- c.now
+		t.stopped = true
+	}
+	c.timers = pending
+}
+
+// WaitForTickers blocks until at least n tickers are active, so a test can
+// be sure a goroutine has set up its tickers before advancing time
+func (c *FakeClock) WaitForTickers(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for c.activeTickers() < n {
+		c.cond.Wait()
+	}
+}
+
+// WaitForTickerPeriod blocks until an active ticker with period d exists,
+// e.g. after code under test replaces a ticker to change its interval
+func (c *FakeClock) WaitForTickerPeriod(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for !c.hasTicker(d) {
+		c.cond.Wait()
+	}
+}
+
+// WaitForTimers blocks until at least n timers are waiting to fire, so a
+// test can be sure a goroutine is blocked on one before advancing time
+func (c *FakeClock) WaitForTimers(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for c.activeTimers() < n {
+		c.cond.Wait()
+	}
+}
+
+// activeTimers counts timers yet to fire or be stopped; callers hold mu
+func (c *FakeClock) activeTimers() int {
+	n := 0
+	for _, t := range c.timers {
+		if !t.stopped {
+			n++
+		}
+	}
+	return n
+}
+
+// hasTicker reports whether an active ticker has period d; callers hold mu
+func (c *FakeClock) hasTicker(d time.Duration) bool {
+	for _, t := range c.tickers {
+		if !t.stopped && t.period == d {
+			return true
+		}
+	}
+	return false
+}
+
+// activeTickers counts unstopped tickers; callers hold mu
+func (c *FakeClock) activeTickers() int {
+	n := 0
+	for _, t := range c.tickers {
+		if !t.stopped {
+			n++
+		}
+	}
+	return n
+}
+
+// fakeTicker is a Ticker driven by FakeClock.Advance
+type fakeTicker struct {
+	clock   *FakeClock
+	ch      chan time.Time
+	period  time.Duration
+	next    time.Time
+	stopped bool
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.ch }
+
+func (t *fakeTicker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.stopped = true
+}
+
+// fakeTimer is a Timer driven by FakeClock.Advance
+type fakeTimer struct {
+	clock   *FakeClock
+	ch      chan time.Time
+	at      time.Time
+	stopped bool
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.ch }
+
+func (t *fakeTimer) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.stopped = true
+}
@@ -3,41 +3,92 @@ package mock
 import (
 	"context"
 	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
 )
 
 // FakeSensor simulates a light sensor for development
 // This implements the ports.LightSensor interface
 type FakeSensor struct {
-	baseValue float64
-	variation float64
+	baseValue  float64
+	variation  float64
+	seed       int64
+	saturation float64 // 0 means the sensor never saturates
+
+	mu  sync.Mutex // rand.Rand is not safe for concurrent use
+	rng *rand.Rand
 }
 
 // NewFakeSensor creates a sensor that returns realistic values
 // baseValue: average lux (e.g., 500 for indoor lighting)
 // variation: +/- range (e.g., 100 means 400-600)
+// The sequence is seeded from the time, so differs between runs.
 func NewFakeSensor(baseValue, variation float64) *FakeSensor {
+	return NewFakeSensorSeeded(baseValue, variation, time.Now().UnixNano())
+}
+
+// NewFakeSensorSeeded is NewFakeSensor with a fixed seed: sensors with the
+// same seed return the same sequence, so tests over generated data are
+// reproducible
+func NewFakeSensorSeeded(baseValue, variation float64, seed int64) *FakeSensor {
 	return &FakeSensor{
 		baseValue: baseValue,
 		variation: variation,
+		seed:      seed,
+		rng:       rand.New(rand.NewSource(seed)),
 	}
 }
 
+// Seed returns the seed the sensor's sequence was generated from, so a
+// failing run can be reproduced with NewFakeSensorSeeded
+func (s *FakeSensor) Seed() int64 {
+	return s.seed
+}
+
+// SetSaturation makes the sensor top out at maxLux like a real one: higher
+// values read as maxLux with QualitySaturated. Zero removes the limit.
+func (s *FakeSensor) SetSaturation(maxLux float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.saturation = maxLux
+}
+
 // ReadLux returns a simulated light reading
-// Simulates realistic variance (lights flicker, clouds pass, etc.)
 func (s *FakeSensor) ReadLux(ctx context.Context) (float64, error) {
+	lux, _, err := s.ReadLuxWithQuality(ctx)
+	return lux, err
+}
+
+// ReadLuxWithQuality returns a simulated light reading and its quality
+// Simulates realistic variance (lights flicker, clouds pass, etc.)
+func (s *FakeSensor) ReadLuxWithQuality(ctx context.Context) (float64, domain.Quality, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, "", err
+	}
+
+	s.mu.Lock()
+	r := s.rng.Float64()
+	saturation := s.saturation
+	s.mu.Unlock()
+
 	// Random value around base ± variation
-	variance := (rand.Float64() - 0.5) * 2 * s.variation
+	variance := (r - 0.5) * 2 * s.variation
 	lux := s.baseValue + variance
-	
+
 	// Ensure non-negative
 	if lux < 0 {
 		lux = 0
 	}
-	
-	return lux, nil
+
+	if saturation > 0 && lux >= saturation {
+		return saturation, domain.QualitySaturated, nil
+	}
+	return lux, domain.QualityOK, nil
 }
 
 // Close is a no-op for fake sensor
 func (s *FakeSensor) Close() error {
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,52 @@
+package mock
+
+import (
+	"context"
+	"testing"
+)
+
+// readSequence takes n readings from the sensor
+func readSequence(t *testing.T, s *FakeSensor, n int) []float64 {
+	t.Helper()
+	seq := make([]float64, n)
+	for i := range seq {
+		lux, err := s.ReadLux(context.Background())
+		if err != nil {
+			t.Fatalf("ReadLux failed: %v", err)
+		}
+		seq[i] = lux
+	}
+	return seq
+}
+
+func TestFakeSensorSeeded_Reproducible(t *testing.T) {
+	a := readSequence(t, NewFakeSensorSeeded(500, 100, 42), 20)
+	b := readSequence(t, NewFakeSensorSeeded(500, 100, 42), 20)
+
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("reading %d: same seed gave %v and %v", i, a[i], b[i])
+		}
+		if a[i] < 400 || a[i] > 600 {
+			t.Errorf("reading %d: %v outside 500±100", i, a[i])
+		}
+	}
+}
+
+func TestFakeSensorSeeded_DifferentSeedsDiverge(t *testing.T) {
+	a := readSequence(t, NewFakeSensorSeeded(500, 100, 1), 20)
+	b := readSequence(t, NewFakeSensorSeeded(500, 100, 2), 20)
+
+	for i := range a {
+		if a[i] != b[i] {
+			return
+		}
+	}
+	t.Error("different seeds produced identical sequences")
+}
+
+func TestFakeSensorSeeded_Seed(t *testing.T) {
+	if got := NewFakeSensorSeeded(500, 100, 7).Seed(); got != 7 {
+		t.Errorf("expected seed 7, got %d", got)
+	}
+}
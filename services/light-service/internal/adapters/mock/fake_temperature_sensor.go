@@ -0,0 +1,34 @@
+package mock
+
+import (
+	"context"
+	"math/rand"
+)
+
+// FakeTemperatureSensor simulates an ambient temperature sensor for development
+// This implements the ports.TemperatureSensor interface
+type FakeTemperatureSensor struct {
+	baseValue float64
+	variation float64
+}
+
+// NewFakeTemperatureSensor creates a sensor that returns realistic values
+// baseValue: average °C (e.g., 21 for a heated room)
+// variation: +/- range (e.g., 2 means 19-23)
+func NewFakeTemperatureSensor(baseValue, variation float64) *FakeTemperatureSensor {
+	return &FakeTemperatureSensor{
+		baseValue: baseValue,
+		variation: variation,
+	}
+}
+
+// ReadCelsius returns a simulated temperature reading
+func (s *FakeTemperatureSensor) ReadCelsius(ctx context.Context) (float64, error) {
+	variance := (rand.Float64() - 0.5) * 2 * s.variation
+	return s.baseValue + variance, nil
+}
+
+// Close is a no-op for fake sensor
+func (s *FakeTemperatureSensor) Close() error {
+	return nil
+}
@@ -0,0 +1,153 @@
+// Package nats publishes recorded readings and fired alerts to NATS, on the
+// subjects and in the format pkg/events defines, for services that react to
+// them without calling light-service
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/rs/zerolog/log"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/ports"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/events"
+)
+
+// readingEventBuffer is how many recorded readings may wait while the
+// publisher is busy before some are missed
+const readingEventBuffer = 64
+
+// drainTimeout bounds how long Close waits for buffered messages to go out
+const drainTimeout = 5 * time.Second
+
+// Publisher sends readings and alerts to a NATS server. The connection is
+// kept up for the life of the service: while the server is unreachable,
+// including at startup, messages are buffered by the client and sent once
+// it reconnects.
+type Publisher struct {
+	conn   *natsgo.Conn
+	scheme *domain.CategoryScheme
+}
+
+// Option configures a Publisher
+type Option func(*Publisher)
+
+// WithCategoryScheme labels readings with the levels of scheme, which
+// should be the recorder's (domain.DefaultCategoryScheme unless set)
+func WithCategoryScheme(scheme *domain.CategoryScheme) Option {
+	return func(p *Publisher) {
+		p.scheme = scheme
+	}
+}
+
+// NewPublisher connects to the NATS server at url, e.g.
+// nats://localhost:4222
+func NewPublisher(url string, opts ...Option) (*Publisher, error) {
+	p := &Publisher{scheme: domain.DefaultCategoryScheme}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	conn, err := natsgo.Connect(url,
+		natsgo.Name("light-service"),
+		natsgo.RetryOnFailedConnect(true),
+		natsgo.MaxReconnects(-1),
+		natsgo.DisconnectErrHandler(func(_ *natsgo.Conn, err error) {
+			// Close disconnects without an error
+			if err != nil {
+				log.Warn().Err(err).Msg("disconnected from NATS; buffering events")
+			}
+		}),
+		natsgo.ConnectHandler(func(c *natsgo.Conn) {
+			log.Info().Str("url", c.ConnectedUrlRedacted()).Msg("connected to NATS")
+		}),
+		natsgo.ReconnectHandler(func(c *natsgo.Conn) {
+			log.Info().Str("url", c.ConnectedUrlRedacted()).Msg("reconnected to NATS")
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	p.conn = conn
+	return p, nil
+}
+
+// Subscribe publishes the reading of each ReadingRecorded event on bus from
+// now until ctx is done
+func (p *Publisher) Subscribe(ctx context.Context, bus *ports.EventBus) {
+	bus.Handle(ctx, readingEventBuffer, func(event domain.ReadingRecorded) {
+		if err := p.PublishReading(event); err != nil {
+			log.Error().Err(err).Str("correlation_id", event.CorrelationID).Msg("failed to publish reading")
+		}
+	})
+}
+
+// PublishReading sends the event's reading on events.SubjectReading
+func (p *Publisher) PublishReading(event domain.ReadingRecorded) error {
+	return p.publish(events.SubjectReading, p.readingMessage(event))
+}
+
+// readingMessage converts the event's reading to its published form
+func (p *Publisher) readingMessage(event domain.ReadingRecorded) events.Reading {
+	r := event.Reading
+	quality := r.Quality
+	if quality == "" {
+		quality = domain.QualityOK
+	}
+	return events.Reading{
+		ID:                 r.ID,
+		Timestamp:          r.Timestamp.UTC(),
+		Lux:                r.Lux,
+		Category:           p.scheme.Label(event.Category),
+		Source:             string(r.Source),
+		Quality:            string(quality),
+		TemperatureCelsius: r.TemperatureC,
+		CorrelationID:      event.CorrelationID,
+	}
+}
+
+// Notify sends the alert on events.SubjectAlert, implementing
+// ports.Notifier. It returns once the message is buffered, not delivered.
+func (p *Publisher) Notify(ctx context.Context, alert *domain.Alert) error {
+	return p.publish(events.SubjectAlert, alertMessage(alert))
+}
+
+// alertMessage converts alert to its published form
+func alertMessage(alert *domain.Alert) events.Alert {
+	return events.Alert{
+		AlertID:      alert.ID,
+		RuleID:       alert.RuleID,
+		RuleName:     alert.RuleName,
+		Condition:    string(alert.Condition),
+		ThresholdLux: alert.ThresholdLux,
+		Lux:          alert.Lux,
+		Since:        alert.Since.UTC(),
+		FiredAt:      alert.FiredAt.UTC(),
+		Message:      alert.Message(),
+	}
+}
+
+// publish sends v as JSON on subject
+func (p *Publisher) publish(subject string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s message: %w", subject, err)
+	}
+	if err := p.conn.Publish(subject, data); err != nil {
+		return fmt.Errorf("failed to publish on %s: %w", subject, err)
+	}
+	return nil
+}
+
+// Close sends what is buffered, waiting up to drainTimeout, and disconnects
+func (p *Publisher) Close() error {
+	defer p.conn.Close()
+	if err := p.conn.FlushTimeout(drainTimeout); err != nil {
+		return fmt.Errorf("failed to flush NATS messages: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package nats
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	natsgo "github.com/nats-io/nats.go"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/ports"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/events"
+)
+
+// testURL returns the NATS server in NATS_TEST_URL, skipping the test when
+// it is unset
+func testURL(t *testing.T) string {
+	t.Helper()
+	url := os.Getenv("NATS_TEST_URL")
+	if url == "" {
+		t.Skip("NATS_TEST_URL not set")
+	}
+	return url
+}
+
+// subscriber connects a second client, as another service would
+func subscriber(t *testing.T, url string) *natsgo.Conn {
+	t.Helper()
+	conn, err := natsgo.Connect(url)
+	if err != nil {
+		t.Fatalf("failed to connect subscriber: %v", err)
+	}
+	t.Cleanup(conn.Close)
+	return conn
+}
+
+func TestPublisher_Readings(t *testing.T) {
+	url := testURL(t)
+	conn := subscriber(t, url)
+	received := make(chan events.Reading, 1)
+	if _, err := events.SubscribeReadings(conn, func(r events.Reading) { received <- r }); err != nil {
+		t.Fatalf("SubscribeReadings failed: %v", err)
+	}
+	if err := conn.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := NewPublisher(url)
+	if err != nil {
+		t.Fatalf("NewPublisher failed: %v", err)
+	}
+	defer p.Close()
+	bus := ports.NewEventBus()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p.Subscribe(ctx, bus)
+
+	reading, _ := domain.NewLightReadingAt(800, time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	reading.ID = 7
+	bus.Publish(domain.ReadingRecorded{Reading: reading, Category: domain.CategoryMedium, CorrelationID: "cycle-1"})
+
+	select {
+	case got := <-received:
+		if got.ID != 7 || got.Lux != 800 || got.Category != "Medium Light" || got.CorrelationID != "cycle-1" ||
+			!got.Timestamp.Equal(reading.Timestamp) || got.Quality != string(domain.QualityOK) {
+			t.Errorf("unexpected reading %+v", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the reading on plant.light.reading")
+	}
+}
+
+func TestPublisher_Alerts(t *testing.T) {
+	url := testURL(t)
+	conn := subscriber(t, url)
+	received := make(chan events.Alert, 1)
+	invalid := make(chan error, 1)
+	if _, err := events.SubscribeAlerts(conn, func(a events.Alert) { received <- a },
+		events.WithInvalidHandler(func(_ *natsgo.Msg, err error) { invalid <- err }),
+	); err != nil {
+		t.Fatalf("SubscribeAlerts failed: %v", err)
+	}
+	if err := conn.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := NewPublisher(url)
+	if err != nil {
+		t.Fatalf("NewPublisher failed: %v", err)
+	}
+	defer p.Close()
+
+	// A malformed message is reported, not handled
+	if err := conn.Publish(events.SubjectAlert, []byte("not json")); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-invalid:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the malformed message to be reported")
+	}
+
+	since := time.Date(2024, 6, 1, 18, 0, 0, 0, time.UTC)
+	alert := &domain.Alert{
+		ID: 3, RuleID: 1, RuleName: "too dark", Condition: domain.AlertBelow,
+		ThresholdLux: 150, Lux: 90, Since: since, FiredAt: since.Add(2 * time.Hour),
+	}
+	if err := p.Notify(context.Background(), alert); err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+
+	select {
+	case got := <-received:
+		if got.AlertID != 3 || got.RuleName != "too dark" || got.Condition != "below" ||
+			!got.FiredAt.Equal(alert.FiredAt) || got.Message != alert.Message() {
+			t.Errorf("unexpected alert %+v", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the alert on plant.light.alert")
+	}
+}
+
+func TestPublisher_ReadingMessage(t *testing.T) {
+	scheme, err := domain.NewCategoryScheme([]string{"Dark", "Dim", "Bright"}, []float64{1000, 2000})
+	if err != nil {
+		t.Fatalf("NewCategoryScheme failed: %v", err)
+	}
+	p := &Publisher{}
+	WithCategoryScheme(scheme)(p)
+
+	paris := time.FixedZone("CEST", 2*60*60)
+	temp := 21.5
+	reading, _ := domain.NewLightReadingAt(1500, time.Date(2024, 6, 1, 14, 0, 0, 0, paris))
+	reading.ID = 7
+	reading.TemperatureC = &temp
+
+	got := p.readingMessage(domain.ReadingRecorded{Reading: reading, Category: 1, CorrelationID: "cycle-1"})
+	if got.ID != 7 || got.Lux != 1500 || got.Category != "Dim" || got.CorrelationID != "cycle-1" ||
+		got.TemperatureCelsius == nil || *got.TemperatureCelsius != 21.5 {
+		t.Errorf("unexpected reading %+v", got)
+	}
+	// Sent in UTC, with unset quality reported as ok
+	if got.Timestamp.Location() != time.UTC || !got.Timestamp.Equal(reading.Timestamp) {
+		t.Errorf("expected the timestamp in UTC, got %v", got.Timestamp)
+	}
+	if got.Quality != string(domain.QualityOK) {
+		t.Errorf("expected quality ok, got %q", got.Quality)
+	}
+}
+
+func TestPublisher_AlertMessage(t *testing.T) {
+	paris := time.FixedZone("CEST", 2*60*60)
+	since := time.Date(2024, 6, 1, 20, 0, 0, 0, paris)
+	alert := &domain.Alert{
+		ID: 3, RuleID: 1, RuleName: "too dark", Condition: domain.AlertBelow,
+		ThresholdLux: 150, Lux: 90, Since: since, FiredAt: since.Add(2 * time.Hour),
+	}
+
+	got := alertMessage(alert)
+	if got.AlertID != 3 || got.RuleID != 1 || got.RuleName != "too dark" || got.Condition != "below" ||
+		got.ThresholdLux != 150 || got.Lux != 90 || got.Message != alert.Message() {
+		t.Errorf("unexpected alert %+v", got)
+	}
+	if got.Since.Location() != time.UTC || !got.Since.Equal(since) || !got.FiredAt.Equal(alert.FiredAt) {
+		t.Errorf("expected the times in UTC, got %v and %v", got.Since, got.FiredAt)
+	}
+}
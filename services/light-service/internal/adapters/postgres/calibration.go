@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+var _ domain.CalibrationRepository = (*ReadingRepository)(nil)
+
+// GetCalibration returns the sensor's stored calibration
+func (r *ReadingRepository) GetCalibration(ctx context.Context, sensorID string) (*domain.Calibration, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT sensor_id, scale, offset_lux, updated_at FROM sensor_calibrations WHERE sensor_id = $1`
+
+	var cal domain.Calibration
+	err := r.pool.QueryRow(ctx, query, sensorID).Scan(&cal.SensorID, &cal.Scale, &cal.OffsetLux, &cal.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrCalibrationNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query calibration: %w", err)
+	}
+	return &cal, nil
+}
+
+// SaveCalibration inserts or replaces the sensor's calibration
+func (r *ReadingRepository) SaveCalibration(ctx context.Context, cal *domain.Calibration) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO sensor_calibrations (sensor_id, scale, offset_lux, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (sensor_id) DO UPDATE SET
+			scale = EXCLUDED.scale,
+			offset_lux = EXCLUDED.offset_lux,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	updatedAt := time.Now()
+	if _, err := r.pool.Exec(ctx, query, cal.SensorID, cal.Scale, cal.OffsetLux, updatedAt); err != nil {
+		return fmt.Errorf("failed to save calibration: %w", err)
+	}
+	cal.UpdatedAt = updatedAt
+	return nil
+}
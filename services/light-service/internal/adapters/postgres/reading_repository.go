@@ -0,0 +1,811 @@
+// Package postgres implements domain.ReadingRepository on PostgreSQL, for
+// deployments that keep several services' data in one managed database
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// ReadingRepository implements domain.ReadingRepository with PostgreSQL
+type ReadingRepository struct {
+	pool         *pgxpool.Pool
+	queryTimeout time.Duration
+}
+
+// options holds pool tuning applied when connecting
+type options struct {
+	maxConns     int32
+	queryTimeout time.Duration
+	readOnly     bool
+}
+
+// Option configures how NewReadingRepository connects
+type Option func(*options)
+
+// WithMaxConns caps the connection pool size (default: pgxpool's, the
+// larger of 4 and the number of CPUs)
+func WithMaxConns(n int) Option {
+	return func(o *options) { o.maxConns = int32(n) }
+}
+
+// WithQueryTimeout bounds every repository call, including time spent
+// waiting for a pooled connection (default 0, no limit beyond the caller's
+// context)
+func WithQueryTimeout(d time.Duration) Option {
+	return func(o *options) { o.queryTimeout = d }
+}
+
+// WithReadOnly makes every session read-only (default_transaction_read_only)
+// and checks the schema instead of creating it: a database missing the
+// tables is refused, since only a writable instance may change it
+func WithReadOnly() Option {
+	return func(o *options) { o.readOnly = true }
+}
+
+// schemaLockID keys the advisory lock held while migrating, so replicas
+// starting together don't race on CREATE ... IF NOT EXISTS
+const schemaLockID = 0x6c69676874 // "light"
+
+// schema creates the tables on first start; every statement is idempotent
+const schema = `
+CREATE TABLE IF NOT EXISTS light_readings (
+	id BIGSERIAL PRIMARY KEY,
+	lux DOUBLE PRECISION NOT NULL,
+	timestamp TIMESTAMPTZ NOT NULL,
+	source TEXT NOT NULL DEFAULT 'sensor',
+	temperature_c DOUBLE PRECISION,
+	quality TEXT NOT NULL DEFAULT 'ok'
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_light_readings_timestamp ON light_readings(timestamp);
+CREATE TABLE IF NOT EXISTS category_events (
+	id BIGSERIAL PRIMARY KEY,
+	from_category INTEGER NOT NULL,
+	to_category INTEGER NOT NULL,
+	lux DOUBLE PRECISION NOT NULL,
+	timestamp TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_category_events_timestamp ON category_events(timestamp);
+CREATE TABLE IF NOT EXISTS sensor_calibrations (
+	sensor_id TEXT PRIMARY KEY,
+	scale DOUBLE PRECISION NOT NULL,
+	offset_lux DOUBLE PRECISION NOT NULL,
+	updated_at TIMESTAMPTZ NOT NULL
+);
+`
+
+// NewReadingRepository connects to the database at databaseURL (a
+// postgres:// URL or key=value DSN) and migrates its schema, or only checks
+// it when read-only
+func NewReadingRepository(ctx context.Context, databaseURL string, opts ...Option) (*ReadingRepository, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.maxConns < 0 {
+		return nil, fmt.Errorf("max connections cannot be negative")
+	}
+	if o.queryTimeout < 0 {
+		return nil, fmt.Errorf("query timeout cannot be negative")
+	}
+
+	cfg, err := pgxpool.ParseConfig(databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid database URL: %w", err)
+	}
+	if o.maxConns > 0 {
+		cfg.MaxConns = o.maxConns
+	}
+	if o.readOnly {
+		cfg.ConnConfig.RuntimeParams["default_transaction_read_only"] = "on"
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// The pool connects lazily; connect now so a bad URL or an unreachable
+	// server fails here rather than on the first query
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	if o.readOnly {
+		err = checkSchema(ctx, pool)
+	} else if err = migrate(ctx, pool); err != nil {
+		err = fmt.Errorf("failed to create schema: %w", err)
+	}
+	if err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	return &ReadingRepository{pool: pool, queryTimeout: o.queryTimeout}, nil
+}
+
+// migrate applies the schema under an advisory lock, in one transaction
+func migrate(ctx context.Context, pool *pgxpool.Pool) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, int64(schemaLockID)); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, schema); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// schemaTables are the tables schema creates, which checkSchema expects
+var schemaTables = []string{"light_readings", "category_events", "sensor_calibrations"}
+
+// checkSchema fails unless every table in schema exists, without changing
+// the database
+func checkSchema(ctx context.Context, pool *pgxpool.Pool) error {
+	for _, table := range schemaTables {
+		var exists bool
+		if err := pool.QueryRow(ctx, `SELECT to_regclass($1) IS NOT NULL`, table).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check schema: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("table %s is missing; start once without read-only mode to create the schema", table)
+		}
+	}
+	return nil
+}
+
+// withTimeout bounds one repository call by the configured query timeout
+func (r *ReadingRepository) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.queryTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, r.queryTimeout)
+}
+
+// readingColumns lists the columns scanReading expects, in order
+const readingColumns = "id, lux, timestamp, source, temperature_c, quality"
+
+// scanReading reads the readingColumns into a reading
+func scanReading(row pgx.Row) (*domain.LightReading, error) {
+	var reading domain.LightReading
+	var source, quality string
+
+	if err := row.Scan(&reading.ID, &reading.Lux, &reading.Timestamp, &source, &reading.TemperatureC, &quality); err != nil {
+		return nil, err
+	}
+	reading.Source = domain.Source(source)
+	reading.Quality = domain.Quality(quality)
+
+	return &reading, nil
+}
+
+// collectReadings scans every row of a readingColumns query
+func collectReadings(rows pgx.Rows) ([]*domain.LightReading, error) {
+	defer rows.Close()
+
+	var readings []*domain.LightReading
+	for rows.Next() {
+		reading, err := scanReading(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reading: %w", err)
+		}
+
+		readings = append(readings, reading)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate readings: %w", err)
+	}
+
+	return readings, nil
+}
+
+// insertReadingQuery inserts one reading and returns its ID; pair with
+// insertArgs
+const insertReadingQuery = `INSERT INTO light_readings (lux, timestamp, source, temperature_c, quality) VALUES ($1, $2, $3, $4, $5)`
+
+// insertArgs returns the insertReadingQuery arguments for a reading
+func insertArgs(reading *domain.LightReading) []any {
+	return []any{reading.Lux, reading.Timestamp, string(sourceOrDefault(reading.Source)), reading.TemperatureC, string(qualityOrDefault(reading.Quality))}
+}
+
+// upsertReadingQuery is insertReadingQuery, but a reading at an existing
+// timestamp overwrites that row instead of violating the unique index
+const upsertReadingQuery = insertReadingQuery + `
+	ON CONFLICT (timestamp) DO UPDATE SET
+		lux = excluded.lux,
+		source = excluded.source,
+		temperature_c = excluded.temperature_c,
+		quality = excluded.quality`
+
+// qualityOrDefault treats an unset quality as ok
+func qualityOrDefault(quality domain.Quality) domain.Quality {
+	if quality == "" {
+		return domain.QualityOK
+	}
+	return quality
+}
+
+// sourceOrDefault treats an unset source as a sensor reading
+func sourceOrDefault(source domain.Source) domain.Source {
+	if source == "" {
+		return domain.SourceSensor
+	}
+	return source
+}
+
+// SaveReading stores a reading in PostgreSQL
+func (r *ReadingRepository) SaveReading(ctx context.Context, reading *domain.LightReading) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var id int64
+	err := r.pool.QueryRow(ctx, insertReadingQuery+` RETURNING id`, insertArgs(reading)...).Scan(&id)
+	if isUniqueViolation(err) {
+		return domain.ErrDuplicateTimestamp
+	}
+	if err != nil {
+		return fmt.Errorf("failed to insert reading: %w", err)
+	}
+
+	reading.ID = id
+	reading.Source = sourceOrDefault(reading.Source)
+	return nil
+}
+
+// isUniqueViolation reports whether err is PostgreSQL rejecting a second
+// reading at a timestamp (SQLSTATE 23505, unique_violation)
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// SaveReadings stores several readings in a single transaction
+func (r *ReadingRepository) SaveReadings(ctx context.Context, readings []*domain.LightReading) error {
+	return r.saveBatch(ctx, insertReadingQuery+` RETURNING id`, "insert", readings)
+}
+
+// UpsertReading stores a reading, replacing any row with the same timestamp
+func (r *ReadingRepository) UpsertReading(ctx context.Context, reading *domain.LightReading) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var id int64
+	if err := r.pool.QueryRow(ctx, upsertReadingQuery+` RETURNING id`, insertArgs(reading)...).Scan(&id); err != nil {
+		return fmt.Errorf("failed to upsert reading: %w", err)
+	}
+
+	reading.ID = id
+	reading.Source = sourceOrDefault(reading.Source)
+	return nil
+}
+
+// UpsertReadings upserts several readings in a single transaction
+func (r *ReadingRepository) UpsertReadings(ctx context.Context, readings []*domain.LightReading) error {
+	return r.saveBatch(ctx, upsertReadingQuery+` RETURNING id`, "upsert", readings)
+}
+
+// saveBatch runs query once per reading, pipelined in one round trip and
+// one transaction, and only assigns IDs once the rows are committed
+func (r *ReadingRepository) saveBatch(ctx context.Context, query, verb string, readings []*domain.LightReading) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	batch := &pgx.Batch{}
+	for _, reading := range readings {
+		batch.Queue(query, insertArgs(reading)...)
+	}
+
+	results := tx.SendBatch(ctx, batch)
+	ids := make([]int64, len(readings))
+	for i := range readings {
+		if err := results.QueryRow().Scan(&ids[i]); err != nil {
+			results.Close()
+			if isUniqueViolation(err) {
+				return fmt.Errorf("reading %d: %w", i, domain.ErrDuplicateTimestamp)
+			}
+			return fmt.Errorf("failed to %s reading %d: %w", verb, i, err)
+		}
+	}
+	if err := results.Close(); err != nil {
+		return fmt.Errorf("failed to %s readings: %w", verb, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit readings: %w", err)
+	}
+
+	for i, reading := range readings {
+		reading.ID = ids[i]
+		reading.Source = sourceOrDefault(reading.Source)
+	}
+	return nil
+}
+
+// GetReading retrieves a reading by ID
+func (r *ReadingRepository) GetReading(ctx context.Context, id int64) (*domain.LightReading, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + readingColumns + ` FROM light_readings WHERE id = $1`
+
+	reading, err := scanReading(r.pool.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrReadingNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reading: %w", err)
+	}
+
+	return reading, nil
+}
+
+// GetReadingsByIDs fetches the readings in one query, passing the IDs as an
+// array so there is no bound-parameter limit to chunk around
+func (r *ReadingRepository) GetReadingsByIDs(ctx context.Context, ids []int64) ([]*domain.LightReading, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + readingColumns + ` FROM light_readings WHERE id = ANY($1)`
+	rows, err := r.pool.Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query readings: %w", err)
+	}
+	readings, err := collectReadings(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[int64]*domain.LightReading, len(readings))
+	for _, reading := range readings {
+		found[reading.ID] = reading
+	}
+
+	// Return them in the requested order, each once
+	results := make([]*domain.LightReading, 0, len(found))
+	for _, id := range ids {
+		if reading, ok := found[id]; ok {
+			results = append(results, reading)
+			delete(found, id)
+		}
+	}
+	return results, nil
+}
+
+// GetReadingsInRange returns the readings within time range, paged and
+// ordered by opts
+func (r *ReadingRepository) GetReadingsInRange(ctx context.Context, start, end time.Time, opts ...domain.RangeOption) ([]*domain.LightReading, error) {
+	return r.queryRange(ctx, start, end, domain.NewRangeQuery(opts...), nil)
+}
+
+// queryRange returns the readings in [start, end) ordered and paged by q.
+// filter, if set, adds a condition to the query, taking its arguments'
+// placeholders from the placeholder function it is given.
+func (r *ReadingRepository) queryRange(ctx context.Context, start, end time.Time, q domain.RangeQuery, filter func(placeholder func(any) string) string) ([]*domain.LightReading, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	order, past := "ASC", ">"
+	if q.Descending {
+		order, past = "DESC", "<"
+	}
+
+	var args []any
+	placeholder := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	where := "timestamp >= " + placeholder(start) + " AND timestamp < " + placeholder(end)
+	if filter != nil {
+		where += " AND (" + filter(placeholder) + ")"
+	}
+	if q.After != nil {
+		where += " AND (timestamp, id) " + past + " (" + placeholder(q.After.Timestamp) + ", " + placeholder(q.After.ID) + ")"
+	}
+
+	query := `
+		SELECT ` + readingColumns + `
+		FROM light_readings
+		WHERE ` + where + `
+		ORDER BY timestamp ` + order + `, id ` + order
+	if q.Limit > 0 {
+		query += ` LIMIT ` + placeholder(q.Limit)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query readings: %w", err)
+	}
+	return collectReadings(rows)
+}
+
+// AggregateReadingsInRange summarizes the readings in [start, end) per
+// bucket, grouping in SQL so only one row per bucket leaves the database
+func (r *ReadingRepository) AggregateReadingsInRange(ctx context.Context, start, end time.Time, interval time.Duration) ([]domain.ReadingBucket, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	seconds := int64(interval / time.Second)
+	if seconds <= 0 {
+		return nil, fmt.Errorf("aggregation interval must be at least a second")
+	}
+
+	query := `
+		SELECT floor(extract(epoch FROM timestamp) / $1)::BIGINT AS bucket,
+			COUNT(*), AVG(lux), MIN(lux), MAX(lux)
+		FROM light_readings
+		WHERE timestamp >= $2 AND timestamp < $3
+		GROUP BY bucket
+		ORDER BY bucket ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, seconds, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate readings: %w", err)
+	}
+	defer rows.Close()
+
+	var buckets []domain.ReadingBucket
+	for rows.Next() {
+		var key int64
+		var b domain.ReadingBucket
+		if err := rows.Scan(&key, &b.Count, &b.AverageLux, &b.MinLux, &b.MaxLux); err != nil {
+			return nil, fmt.Errorf("failed to scan bucket: %w", err)
+		}
+		b.Start = time.Unix(key*seconds, 0)
+		buckets = append(buckets, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate buckets: %w", err)
+	}
+
+	return buckets, nil
+}
+
+// GetStatisticsInRange summarizes the readings in [start, end) in one
+// query. percentile_cont interpolates as domain.Percentile does.
+func (r *ReadingRepository) GetStatisticsInRange(ctx context.Context, start, end time.Time) (domain.LuxStatistics, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT COUNT(*),
+			COALESCE(AVG(lux), 0), COALESCE(MIN(lux), 0), COALESCE(MAX(lux), 0),
+			COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY lux), 0),
+			COALESCE(percentile_cont(0.95) WITHIN GROUP (ORDER BY lux), 0),
+			COALESCE(stddev_pop(lux), 0)
+		FROM light_readings
+		WHERE timestamp >= $1 AND timestamp < $2
+	`
+
+	var stats domain.LuxStatistics
+	err := r.pool.QueryRow(ctx, query, start, end).Scan(&stats.Count,
+		&stats.AverageLux, &stats.MinLux, &stats.MaxLux, &stats.MedianLux, &stats.P95Lux, &stats.StdDevLux)
+	if err != nil {
+		return domain.LuxStatistics{}, fmt.Errorf("failed to query statistics: %w", err)
+	}
+	return stats, nil
+}
+
+// GetReadingsInCategories returns readings in [start, end) whose level under
+// scheme is one of categories, matching on the lux range of each level in SQL
+func (r *ReadingRepository) GetReadingsInCategories(ctx context.Context, start, end time.Time, scheme *domain.CategoryScheme, categories []domain.Category, opts ...domain.RangeOption) ([]*domain.LightReading, error) {
+	if len(categories) == 0 {
+		return nil, nil
+	}
+
+	filter := func(placeholder func(any) string) string {
+		ranges := make([]string, 0, len(categories))
+		for _, c := range categories {
+			lo, hi := scheme.LuxRange(c)
+			if math.IsInf(hi, 1) {
+				ranges = append(ranges, "lux >= "+placeholder(lo))
+			} else {
+				ranges = append(ranges, "(lux >= "+placeholder(lo)+" AND lux < "+placeholder(hi)+")")
+			}
+		}
+		return strings.Join(ranges, " OR ")
+	}
+	return r.queryRange(ctx, start, end, domain.NewRangeQuery(opts...), filter)
+}
+
+// recordingDayBucket is the granularity GetRecordingDays fetches. Every UTC
+// offset in use is a whole number of quarter hours, so a bucket never
+// straddles local midnight in any zone, across DST changes included.
+const recordingDayBucket = 15 * 60 // seconds
+
+// GetRecordingDays returns the local days in [start, end) with readings.
+// loc may be time.Local, which PostgreSQL can't name, so as with SQLite the
+// query returns distinct quarter-hour buckets and they are mapped to days
+// in loc here.
+func (r *ReadingRepository) GetRecordingDays(ctx context.Context, start, end time.Time, loc *time.Location) ([]time.Time, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT DISTINCT floor(extract(epoch FROM timestamp) / $1)::BIGINT
+		FROM light_readings
+		WHERE timestamp >= $2 AND timestamp < $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, recordingDayBucket, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recording days: %w", err)
+	}
+	defer rows.Close()
+
+	seen := make(map[time.Time]bool)
+	var days []time.Time
+	for rows.Next() {
+		var bucket int64
+		if err := rows.Scan(&bucket); err != nil {
+			return nil, fmt.Errorf("failed to scan recording day: %w", err)
+		}
+		day := domain.CalendarDay(time.Unix(bucket*recordingDayBucket, 0), loc)
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate recording days: %w", err)
+	}
+
+	slices.SortFunc(days, func(a, b time.Time) int { return a.Compare(b) })
+	return days, nil
+}
+
+// ListReadings returns the page of readings after the cursor
+func (r *ReadingRepository) ListReadings(ctx context.Context, after domain.ReadingCursor, limit int) ([]*domain.LightReading, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + readingColumns + `
+		FROM light_readings
+		WHERE (timestamp, id) > ($1, $2)
+		ORDER BY timestamp ASC, id ASC
+		LIMIT $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, after.Timestamp, after.ID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list readings: %w", err)
+	}
+	return collectReadings(rows)
+}
+
+// GetRecentReadings returns the newest readings, oldest first
+func (r *ReadingRepository) GetRecentReadings(ctx context.Context, limit int) ([]*domain.LightReading, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + readingColumns + `
+		FROM light_readings
+		ORDER BY timestamp DESC
+		LIMIT $1
+	`
+
+	rows, err := r.pool.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recent readings: %w", err)
+	}
+	readings, err := collectReadings(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// Newest-first from the query; callers want chronological order
+	slices.Reverse(readings)
+
+	return readings, nil
+}
+
+// GetLatestReading returns the most recent reading
+func (r *ReadingRepository) GetLatestReading(ctx context.Context) (*domain.LightReading, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + readingColumns + `
+		FROM light_readings
+		ORDER BY timestamp DESC
+		LIMIT 1
+	`
+
+	reading, err := scanReading(r.pool.QueryRow(ctx, query))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrReadingNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latest reading: %w", err)
+	}
+
+	return reading, nil
+}
+
+// GetReadingAsOf returns the latest reading at or before the given time
+func (r *ReadingRepository) GetReadingAsOf(ctx context.Context, at time.Time) (*domain.LightReading, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + readingColumns + `
+		FROM light_readings
+		WHERE timestamp <= $1
+		ORDER BY timestamp DESC
+		LIMIT 1
+	`
+
+	reading, err := scanReading(r.pool.QueryRow(ctx, query, at))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrReadingNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reading as of %s: %w", at, err)
+	}
+
+	return reading, nil
+}
+
+const insertCategoryEventQuery = `INSERT INTO category_events (from_category, to_category, lux, timestamp) VALUES ($1, $2, $3, $4) RETURNING id`
+
+// SaveCategoryEvent stores a category transition
+func (r *ReadingRepository) SaveCategoryEvent(ctx context.Context, event *domain.CategoryEvent) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var id int64
+	err := r.pool.QueryRow(ctx, insertCategoryEventQuery, int(event.From), int(event.To), event.Lux, event.Timestamp).Scan(&id)
+	if err != nil {
+		return fmt.Errorf("failed to insert category event: %w", err)
+	}
+
+	event.ID = id
+	return nil
+}
+
+// ReplaceCategoryEvents swaps the stored category transitions in
+// [start, end) for events in one transaction, so readers see either the
+// old set or the new one
+func (r *ReadingRepository) ReplaceCategoryEvents(ctx context.Context, start, end time.Time, events []*domain.CategoryEvent) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM category_events WHERE timestamp >= $1 AND timestamp < $2`, start, end); err != nil {
+		return fmt.Errorf("failed to clear category events: %w", err)
+	}
+
+	ids := make([]int64, len(events))
+	for i, event := range events {
+		err := tx.QueryRow(ctx, insertCategoryEventQuery, int(event.From), int(event.To), event.Lux, event.Timestamp).Scan(&ids[i])
+		if err != nil {
+			return fmt.Errorf("failed to insert category event %d: %w", i, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit category events: %w", err)
+	}
+
+	for i, event := range events {
+		event.ID = ids[i]
+	}
+	return nil
+}
+
+// GetCategoryEvents returns category transitions within the time range
+func (r *ReadingRepository) GetCategoryEvents(ctx context.Context, start, end time.Time) ([]*domain.CategoryEvent, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT id, from_category, to_category, lux, timestamp
+		FROM category_events
+		WHERE timestamp >= $1 AND timestamp < $2
+		ORDER BY timestamp ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query category events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*domain.CategoryEvent
+	for rows.Next() {
+		var event domain.CategoryEvent
+		var from, to int
+		if err := rows.Scan(&event.ID, &from, &to, &event.Lux, &event.Timestamp); err != nil {
+			return nil, fmt.Errorf("failed to scan category event: %w", err)
+		}
+		event.From = domain.Category(from)
+		event.To = domain.Category(to)
+
+		events = append(events, &event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate category events: %w", err)
+	}
+
+	return events, nil
+}
+
+// Stats reports the reading count, time span and on-disk size of the
+// service's tables, indexes included
+func (r *ReadingRepository) Stats(ctx context.Context) (*domain.StorageStats, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var stats domain.StorageStats
+	var oldest, newest *time.Time
+
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM light_readings),
+			(SELECT MIN(timestamp) FROM light_readings),
+			(SELECT MAX(timestamp) FROM light_readings),
+			pg_total_relation_size('light_readings') + pg_total_relation_size('category_events')
+	`
+	if err := r.pool.QueryRow(ctx, query).Scan(&stats.ReadingCount, &oldest, &newest, &stats.SizeBytes); err != nil {
+		return nil, fmt.Errorf("failed to query storage stats: %w", err)
+	}
+	if oldest != nil {
+		stats.Oldest = *oldest
+	}
+	if newest != nil {
+		stats.Newest = *newest
+	}
+
+	return &stats, nil
+}
+
+// DeleteOldReadings removes readings older than specified duration
+func (r *ReadingRepository) DeleteOldReadings(ctx context.Context, olderThan time.Duration) (int64, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	cutoff := time.Now().Add(-olderThan)
+	tag, err := r.pool.Exec(ctx, `DELETE FROM light_readings WHERE timestamp < $1`, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete old readings: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// Close closes the connection pool
+func (r *ReadingRepository) Close() error {
+	r.pool.Close()
+	return nil
+}
@@ -0,0 +1,264 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// newTestRepo connects to the database in POSTGRES_TEST_URL, skipping the
+// test when it is unset, and empties the tables first. Point it at a
+// throwaway database: the tables are truncated.
+func newTestRepo(t *testing.T) *ReadingRepository {
+	t.Helper()
+	url := os.Getenv("POSTGRES_TEST_URL")
+	if url == "" {
+		t.Skip("POSTGRES_TEST_URL not set")
+	}
+
+	ctx := context.Background()
+	repo, err := NewReadingRepository(ctx, url)
+	if err != nil {
+		t.Fatalf("failed to create Postgres repo: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	if _, err := repo.pool.Exec(ctx, `TRUNCATE light_readings, category_events, sensor_calibrations RESTART IDENTITY`); err != nil {
+		t.Fatalf("failed to truncate tables: %v", err)
+	}
+	return repo
+}
+
+func TestNewReadingRepository_InvalidOptions(t *testing.T) {
+	ctx := context.Background()
+	if _, err := NewReadingRepository(ctx, "postgres://localhost/db", WithQueryTimeout(-time.Second)); err == nil {
+		t.Error("expected an error for a negative query timeout")
+	}
+	if _, err := NewReadingRepository(ctx, "postgres://localhost:notaport/db"); err == nil {
+		t.Error("expected an error for an invalid URL")
+	}
+}
+
+func TestNewReadingRepository_ReadOnly(t *testing.T) {
+	newTestRepo(t) // creates the schema
+	ctx := context.Background()
+	repo, err := NewReadingRepository(ctx, os.Getenv("POSTGRES_TEST_URL"), WithReadOnly())
+	if err != nil {
+		t.Fatalf("failed to open read-only: %v", err)
+	}
+	defer repo.Close()
+
+	if _, err := repo.GetRecentReadings(ctx, 1); err != nil {
+		t.Errorf("expected reads to work, got %v", err)
+	}
+	reading, _ := domain.NewLightReading(500)
+	if err := repo.SaveReading(ctx, reading); err == nil {
+		t.Error("expected a write in a read-only session to fail")
+	}
+}
+
+func TestSaveAndGetReading(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	temp := 21.5
+	reading := &domain.LightReading{Lux: 500, Timestamp: time.Now().Add(-time.Minute).Truncate(time.Microsecond), TemperatureC: &temp}
+	if err := repo.SaveReading(ctx, reading); err != nil {
+		t.Fatalf("SaveReading failed: %v", err)
+	}
+	if reading.ID == 0 || reading.Source != domain.SourceSensor {
+		t.Fatalf("expected ID and default source to be set, got %+v", reading)
+	}
+
+	got, err := repo.GetReading(ctx, reading.ID)
+	if err != nil {
+		t.Fatalf("GetReading failed: %v", err)
+	}
+	if got.Lux != 500 || !got.Timestamp.Equal(reading.Timestamp) || got.Quality != domain.QualityOK {
+		t.Errorf("got %+v, want %+v", got, reading)
+	}
+	if got.TemperatureC == nil || *got.TemperatureC != temp {
+		t.Errorf("expected temperature %v, got %v", temp, got.TemperatureC)
+	}
+
+	if _, err := repo.GetReading(ctx, reading.ID+1); err != domain.ErrReadingNotFound {
+		t.Errorf("expected ErrReadingNotFound, got %v", err)
+	}
+}
+
+func TestSaveReading_DuplicateTimestamp(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+	ts := time.Now().Add(-time.Minute).Truncate(time.Microsecond)
+
+	first, _ := domain.NewLightReadingAt(100, ts)
+	if err := repo.SaveReading(ctx, first); err != nil {
+		t.Fatalf("SaveReading failed: %v", err)
+	}
+	second, _ := domain.NewLightReadingAt(200, ts)
+	if err := repo.SaveReading(ctx, second); !errors.Is(err, domain.ErrDuplicateTimestamp) {
+		t.Errorf("expected ErrDuplicateTimestamp, got %v", err)
+	}
+
+	// A batch with a clash stores none of its readings
+	other, _ := domain.NewLightReadingAt(300, ts.Add(time.Second))
+	clash, _ := domain.NewLightReadingAt(400, ts)
+	if err := repo.SaveReadings(ctx, []*domain.LightReading{other, clash}); !errors.Is(err, domain.ErrDuplicateTimestamp) {
+		t.Errorf("expected ErrDuplicateTimestamp for the batch, got %v", err)
+	}
+
+	readings, err := repo.GetRecentReadings(ctx, 10)
+	if err != nil || len(readings) != 1 || readings[0].Lux != 100 {
+		t.Errorf("expected only the first reading stored, got %v, %v", readings, err)
+	}
+}
+
+func TestUpsertReadings_ReimportReplaces(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	first := []*domain.LightReading{
+		{Lux: 100, Timestamp: base},
+		{Lux: 200, Timestamp: base.Add(time.Minute)},
+	}
+	if err := repo.SaveReadings(ctx, first); err != nil {
+		t.Fatalf("SaveReadings failed: %v", err)
+	}
+
+	again := []*domain.LightReading{
+		{Lux: 150, Timestamp: base},
+		{Lux: 300, Timestamp: base.Add(2 * time.Minute)},
+	}
+	if err := repo.UpsertReadings(ctx, again); err != nil {
+		t.Fatalf("UpsertReadings failed: %v", err)
+	}
+	if again[0].ID != first[0].ID {
+		t.Errorf("expected the upsert to keep ID %d, got %d", first[0].ID, again[0].ID)
+	}
+
+	got, err := repo.GetReadingsInRange(ctx, base, base.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GetReadingsInRange failed: %v", err)
+	}
+	want := []float64{150, 200, 300}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d readings, got %d", len(want), len(got))
+	}
+	for i, r := range got {
+		if r.Lux != want[i] {
+			t.Errorf("reading %d: got lux %v, want %v", i, r.Lux, want[i])
+		}
+	}
+}
+
+func TestGetStatisticsInRange(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	var readings []*domain.LightReading
+	for i, lux := range []float64{100, 300, 200, 50, 400, 10, 20} {
+		readings = append(readings, &domain.LightReading{Lux: lux, Timestamp: base.Add(time.Duration(i) * time.Minute)})
+	}
+	if err := repo.SaveReadings(ctx, readings); err != nil {
+		t.Fatalf("SaveReadings failed: %v", err)
+	}
+
+	got, err := repo.GetStatisticsInRange(ctx, base, base.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GetStatisticsInRange failed: %v", err)
+	}
+	want := domain.SummarizeLux(readings)
+	if got.Count != want.Count || got.MinLux != want.MinLux || got.MaxLux != want.MaxLux ||
+		math.Abs(got.AverageLux-want.AverageLux) > 1e-9 || math.Abs(got.MedianLux-want.MedianLux) > 1e-9 ||
+		math.Abs(got.P95Lux-want.P95Lux) > 1e-9 || math.Abs(got.StdDevLux-want.StdDevLux) > 1e-9 {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestListReadings_PagesInTimestampOrder(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	var readings []*domain.LightReading
+	for i := range 5 {
+		readings = append(readings, &domain.LightReading{Lux: float64(i), Timestamp: base.Add(time.Duration(4-i) * time.Minute)})
+	}
+	if err := repo.SaveReadings(ctx, readings); err != nil {
+		t.Fatalf("SaveReadings failed: %v", err)
+	}
+
+	var seen []float64
+	var cursor domain.ReadingCursor
+	for {
+		page, err := repo.ListReadings(ctx, cursor, 2)
+		if err != nil {
+			t.Fatalf("ListReadings failed: %v", err)
+		}
+		for _, r := range page {
+			seen = append(seen, r.Lux)
+		}
+		if len(page) < 2 {
+			break
+		}
+		cursor = domain.CursorAfter(page[len(page)-1])
+	}
+
+	want := []float64{4, 3, 2, 1, 0}
+	if len(seen) != len(want) {
+		t.Fatalf("expected %v, got %v", want, seen)
+	}
+	for i := range want {
+		if seen[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, seen)
+		}
+	}
+}
+
+func TestCategoryEventsAndStats(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	stats, err := repo.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	if stats.ReadingCount != 0 || !stats.Oldest.IsZero() {
+		t.Errorf("expected empty stats, got %+v", stats)
+	}
+
+	now := time.Now().Truncate(time.Second)
+	for _, age := range []time.Duration{48 * time.Hour, time.Hour} {
+		if err := repo.SaveReading(ctx, &domain.LightReading{Lux: 10, Timestamp: now.Add(-age)}); err != nil {
+			t.Fatalf("SaveReading failed: %v", err)
+		}
+	}
+	event := &domain.CategoryEvent{From: domain.CategoryLow, To: domain.CategoryMedium, Lux: 300, Timestamp: now.Add(-time.Hour)}
+	if err := repo.SaveCategoryEvent(ctx, event); err != nil || event.ID == 0 {
+		t.Fatalf("SaveCategoryEvent failed: %v (id %d)", err, event.ID)
+	}
+	events, err := repo.GetCategoryEvents(ctx, now.Add(-2*time.Hour), now)
+	if err != nil || len(events) != 1 || events[0].To != domain.CategoryMedium {
+		t.Fatalf("expected the saved event back, got %v, %v", events, err)
+	}
+
+	stats, err = repo.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	if stats.ReadingCount != 2 || !stats.Oldest.Equal(now.Add(-48*time.Hour)) || !stats.Newest.Equal(now.Add(-time.Hour)) || stats.SizeBytes <= 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+
+	deleted, err := repo.DeleteOldReadings(ctx, 24*time.Hour)
+	if err != nil || deleted != 1 {
+		t.Errorf("expected 1 reading deleted, got %d, %v", deleted, err)
+	}
+}
@@ -0,0 +1,30 @@
+package readonly
+
+import (
+	"context"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// CalibrationRepository decorates another calibration repository, passing
+// reads through and rejecting saves with domain.ErrReadOnly
+type CalibrationRepository struct {
+	inner domain.CalibrationRepository
+}
+
+var _ domain.CalibrationRepository = (*CalibrationRepository)(nil)
+
+// NewCalibrationRepository wraps inner so it cannot be written to
+func NewCalibrationRepository(inner domain.CalibrationRepository) *CalibrationRepository {
+	return &CalibrationRepository{inner: inner}
+}
+
+// GetCalibration reads from the wrapped repository
+func (r *CalibrationRepository) GetCalibration(ctx context.Context, sensorID string) (*domain.Calibration, error) {
+	return r.inner.GetCalibration(ctx, sensorID)
+}
+
+// SaveCalibration is rejected in read-only mode
+func (r *CalibrationRepository) SaveCalibration(ctx context.Context, cal *domain.Calibration) error {
+	return domain.ErrReadOnly
+}
@@ -0,0 +1,125 @@
+package readonly
+
+import (
+	"context"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// ReadingRepository decorates another repository, passing reads through and
+// rejecting every write with domain.ErrReadOnly. It lets the service run
+// against a production database for debugging without modifying it.
+//
+// Methods are forwarded explicitly rather than by embedding, so a write
+// method added to domain.ReadingRepository can't slip through unguarded.
+type ReadingRepository struct {
+	inner domain.ReadingRepository
+}
+
+var _ domain.ReadingRepository = (*ReadingRepository)(nil)
+
+// NewReadingRepository wraps inner so it cannot be written to
+func NewReadingRepository(inner domain.ReadingRepository) *ReadingRepository {
+	return &ReadingRepository{inner: inner}
+}
+
+// SaveReading is rejected in read-only mode
+func (r *ReadingRepository) SaveReading(ctx context.Context, reading *domain.LightReading) error {
+	return domain.ErrReadOnly
+}
+
+// SaveReadings is rejected in read-only mode
+func (r *ReadingRepository) SaveReadings(ctx context.Context, readings []*domain.LightReading) error {
+	return domain.ErrReadOnly
+}
+
+// UpsertReading is rejected in read-only mode
+func (r *ReadingRepository) UpsertReading(ctx context.Context, reading *domain.LightReading) error {
+	return domain.ErrReadOnly
+}
+
+// UpsertReadings is rejected in read-only mode
+func (r *ReadingRepository) UpsertReadings(ctx context.Context, readings []*domain.LightReading) error {
+	return domain.ErrReadOnly
+}
+
+// SaveCategoryEvent is rejected in read-only mode
+func (r *ReadingRepository) SaveCategoryEvent(ctx context.Context, event *domain.CategoryEvent) error {
+	return domain.ErrReadOnly
+}
+
+// ReplaceCategoryEvents is rejected in read-only mode
+func (r *ReadingRepository) ReplaceCategoryEvents(ctx context.Context, start, end time.Time, events []*domain.CategoryEvent) error {
+	return domain.ErrReadOnly
+}
+
+// DeleteOldReadings is rejected in read-only mode
+func (r *ReadingRepository) DeleteOldReadings(ctx context.Context, olderThan time.Duration) (int64, error) {
+	return 0, domain.ErrReadOnly
+}
+
+// GetReading reads from the wrapped repository
+func (r *ReadingRepository) GetReading(ctx context.Context, id int64) (*domain.LightReading, error) {
+	return r.inner.GetReading(ctx, id)
+}
+
+// GetReadingsInRange reads from the wrapped repository
+func (r *ReadingRepository) GetReadingsInRange(ctx context.Context, start, end time.Time, opts ...domain.RangeOption) ([]*domain.LightReading, error) {
+	return r.inner.GetReadingsInRange(ctx, start, end, opts...)
+}
+
+// GetReadingsByIDs reads from the wrapped repository
+func (r *ReadingRepository) GetReadingsByIDs(ctx context.Context, ids []int64) ([]*domain.LightReading, error) {
+	return r.inner.GetReadingsByIDs(ctx, ids)
+}
+
+// GetRecordingDays reads from the wrapped repository
+func (r *ReadingRepository) GetRecordingDays(ctx context.Context, start, end time.Time, loc *time.Location) ([]time.Time, error) {
+	return r.inner.GetRecordingDays(ctx, start, end, loc)
+}
+
+// GetRecentReadings reads from the wrapped repository
+func (r *ReadingRepository) GetRecentReadings(ctx context.Context, limit int) ([]*domain.LightReading, error) {
+	return r.inner.GetRecentReadings(ctx, limit)
+}
+
+// GetLatestReading reads from the wrapped repository
+func (r *ReadingRepository) GetLatestReading(ctx context.Context) (*domain.LightReading, error) {
+	return r.inner.GetLatestReading(ctx)
+}
+
+// ListReadings reads from the wrapped repository
+func (r *ReadingRepository) ListReadings(ctx context.Context, after domain.ReadingCursor, limit int) ([]*domain.LightReading, error) {
+	return r.inner.ListReadings(ctx, after, limit)
+}
+
+// AggregateReadingsInRange reads from the wrapped repository
+func (r *ReadingRepository) AggregateReadingsInRange(ctx context.Context, start, end time.Time, interval time.Duration) ([]domain.ReadingBucket, error) {
+	return r.inner.AggregateReadingsInRange(ctx, start, end, interval)
+}
+
+// GetStatisticsInRange reads from the wrapped repository
+func (r *ReadingRepository) GetStatisticsInRange(ctx context.Context, start, end time.Time) (domain.LuxStatistics, error) {
+	return r.inner.GetStatisticsInRange(ctx, start, end)
+}
+
+// GetReadingsInCategories reads from the wrapped repository
+func (r *ReadingRepository) GetReadingsInCategories(ctx context.Context, start, end time.Time, scheme *domain.CategoryScheme, categories []domain.Category, opts ...domain.RangeOption) ([]*domain.LightReading, error) {
+	return r.inner.GetReadingsInCategories(ctx, start, end, scheme, categories, opts...)
+}
+
+// GetReadingAsOf reads from the wrapped repository
+func (r *ReadingRepository) GetReadingAsOf(ctx context.Context, at time.Time) (*domain.LightReading, error) {
+	return r.inner.GetReadingAsOf(ctx, at)
+}
+
+// Stats reads from the wrapped repository
+func (r *ReadingRepository) Stats(ctx context.Context) (*domain.StorageStats, error) {
+	return r.inner.Stats(ctx)
+}
+
+// GetCategoryEvents reads from the wrapped repository
+func (r *ReadingRepository) GetCategoryEvents(ctx context.Context, start, end time.Time) ([]*domain.CategoryEvent, error) {
+	return r.inner.GetCategoryEvents(ctx, start, end)
+}
@@ -0,0 +1,76 @@
+package readonly
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+func TestReadingRepository_RejectsWrites(t *testing.T) {
+	inner := memory.NewReadingRepository()
+	repo := NewReadingRepository(inner)
+	ctx := context.Background()
+
+	reading, _ := domain.NewLightReading(500)
+	old, _ := domain.NewLightReading(10)
+	old.Timestamp = time.Now().Add(-48 * time.Hour)
+	_ = inner.SaveReading(ctx, old)
+
+	writes := map[string]func() error{
+		"SaveReading":    func() error { return repo.SaveReading(ctx, reading) },
+		"SaveReadings":   func() error { return repo.SaveReadings(ctx, []*domain.LightReading{reading}) },
+		"UpsertReading":  func() error { return repo.UpsertReading(ctx, reading) },
+		"UpsertReadings": func() error { return repo.UpsertReadings(ctx, []*domain.LightReading{reading}) },
+		"SaveCategoryEvent": func() error {
+			return repo.SaveCategoryEvent(ctx, &domain.CategoryEvent{Timestamp: time.Now()})
+		},
+		"DeleteOldReadings": func() error {
+			_, err := repo.DeleteOldReadings(ctx, time.Hour)
+			return err
+		},
+	}
+	for name, write := range writes {
+		if err := write(); !errors.Is(err, domain.ErrReadOnly) {
+			t.Errorf("%s: expected ErrReadOnly, got %v", name, err)
+		}
+	}
+
+	// Nothing reached the underlying store
+	all, _ := inner.GetReadingsInRange(ctx, time.Now().Add(-72*time.Hour), time.Now().Add(time.Hour))
+	if len(all) != 1 {
+		t.Errorf("expected underlying store untouched with 1 reading, got %d", len(all))
+	}
+}
+
+func TestReadingRepository_AllowsReads(t *testing.T) {
+	inner := memory.NewReadingRepository()
+	repo := NewReadingRepository(inner)
+	ctx := context.Background()
+
+	reading, _ := domain.NewLightReading(500)
+	_ = inner.SaveReading(ctx, reading)
+
+	got, err := repo.GetReading(ctx, reading.ID)
+	if err != nil || got.Lux != 500 {
+		t.Errorf("GetReading: expected 500 lux, got %v, %v", got, err)
+	}
+
+	latest, err := repo.GetLatestReading(ctx)
+	if err != nil || latest.ID != reading.ID {
+		t.Errorf("GetLatestReading: expected reading %d, got %v, %v", reading.ID, latest, err)
+	}
+
+	inRange, err := repo.GetReadingsInRange(ctx, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	if err != nil || len(inRange) != 1 {
+		t.Errorf("GetReadingsInRange: expected 1 reading, got %d, %v", len(inRange), err)
+	}
+
+	recent, err := repo.GetRecentReadings(ctx, 10)
+	if err != nil || len(recent) != 1 {
+		t.Errorf("GetRecentReadings: expected 1 reading, got %d, %v", len(recent), err)
+	}
+}
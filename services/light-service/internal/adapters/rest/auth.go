@@ -0,0 +1,74 @@
+package rest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/auth"
+)
+
+// RequireRole lets through only requests with an "Authorization: Bearer"
+// API key whose role allows role, answering others with 401 or 403. Handlers
+// find the caller with auth.PrincipalFromContext.
+func RequireRole(keys *auth.KeyStore, role auth.Role, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r, err := authorize(keys, r, role)
+		if err != nil {
+			writeAuthError(w, err)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// methodRole is the role an HTTP method requires: reads need a reader key,
+// anything that may change data a writer key
+func methodRole(method string) auth.Role {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return auth.RoleReader
+	default:
+		return auth.RoleWriter
+	}
+}
+
+// authorize returns r carrying the caller's principal, or a status error
+// as the gRPC interceptor would return
+func authorize(keys *auth.KeyStore, r *http.Request, role auth.Role) (*http.Request, error) {
+	logger := zerolog.Ctx(r.Context())
+	principal, err := keys.Authenticate(bearerToken(r))
+	if err != nil {
+		logger.Warn().Str("method", r.Method).Str("path", r.URL.Path).Str("remote_addr", r.RemoteAddr).
+			Msg("rejected request without a valid API key")
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid API key")
+	}
+	if !principal.Role.Allows(role) {
+		logger.Warn().Str("method", r.Method).Str("path", r.URL.Path).Str("remote_addr", r.RemoteAddr).
+			Str("principal", principal.Name).Str("role", principal.Role.String()).
+			Msg("rejected request beyond the key's role")
+		return nil, status.Errorf(codes.PermissionDenied, "%s %s requires a %s key", r.Method, r.URL.Path, role)
+	}
+	return r.WithContext(auth.ContextWithPrincipal(r.Context(), principal)), nil
+}
+
+// writeAuthError writes err as writeError does, inviting a key on a 401
+func writeAuthError(w http.ResponseWriter, err error) {
+	if status.Code(err) == codes.Unauthenticated {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="light-service"`)
+	}
+	writeError(w, err)
+}
+
+// bearerToken returns the API key from the Authorization header, or "" if
+// there is none
+func bearerToken(r *http.Request) string {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
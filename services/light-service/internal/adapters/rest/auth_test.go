@@ -0,0 +1,104 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/auth"
+)
+
+func testKeys(t *testing.T) *auth.KeyStore {
+	t.Helper()
+	keys := auth.NewKeyStore()
+	if err := keys.Add("guest", auth.RoleReader, "read-key"); err != nil {
+		t.Fatal(err)
+	}
+	if err := keys.Add("recorder", auth.RoleWriter, "write-key"); err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+// doWithKey sends a request with key as its bearer token, if set
+func doWithKey(t *testing.T, method, url, body, key string) *http.Response {
+	t.Helper()
+	req, _ := http.NewRequest(method, url, strings.NewReader(body))
+	if key != "" {
+		req.Header.Set("Authorization", "Bearer "+key)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, url, err)
+	}
+	resp.Body.Close()
+	return resp
+}
+
+func TestGateway_Auth(t *testing.T) {
+	srv := newTestGateway(t, WithAuth(testKeys(t)))
+
+	tests := []struct {
+		name, method, path, key string
+		want                    int
+	}{
+		{"no key", "GET", "/v1/light/current", "", http.StatusUnauthorized},
+		{"unknown key", "GET", "/v1/light/current", "guess", http.StatusUnauthorized},
+		{"reader reads", "GET", "/v1/light/history", "read-key", http.StatusOK},
+		{"reader records", "POST", "/v1/light/readings", "read-key", http.StatusForbidden},
+		{"writer records", "POST", "/v1/light/readings", "write-key", http.StatusOK},
+		{"writer reads", "GET", "/v1/light/current", "write-key", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := doWithKey(t, tt.method, srv.URL+tt.path, `{"lux": 300}`, tt.key)
+			if resp.StatusCode != tt.want {
+				t.Errorf("got status %d, want %d", resp.StatusCode, tt.want)
+			}
+			if tt.want == http.StatusUnauthorized && resp.Header.Get("WWW-Authenticate") == "" {
+				t.Error("expected a WWW-Authenticate challenge")
+			}
+		})
+	}
+}
+
+func TestGateway_AuthAllowsPreflight(t *testing.T) {
+	srv := newTestGateway(t, WithAuth(testKeys(t)), WithAllowedOrigins("*"))
+
+	req, _ := http.NewRequest("OPTIONS", srv.URL+"/v1/light/readings", nil)
+	req.Header.Set("Origin", "http://dashboard.local")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("preflight: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("preflight got status %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+	if !strings.Contains(resp.Header.Get("Access-Control-Allow-Headers"), "Authorization") {
+		t.Errorf("preflight should allow the Authorization header, got %q", resp.Header.Get("Access-Control-Allow-Headers"))
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	var seen string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, _ := auth.PrincipalFromContext(r.Context())
+		seen = p.Name
+	})
+	srv := httptest.NewServer(RequireRole(testKeys(t), auth.RoleWriter, next))
+	t.Cleanup(srv.Close)
+
+	if resp := doWithKey(t, "POST", srv.URL, "", "read-key"); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("reader got status %d, want %d", resp.StatusCode, http.StatusForbidden)
+	}
+	if resp := doWithKey(t, "POST", srv.URL, "", "write-key"); resp.StatusCode != http.StatusOK {
+		t.Errorf("writer got status %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if seen != "recorder" {
+		t.Errorf("handler saw principal %q, want recorder", seen)
+	}
+}
@@ -0,0 +1,322 @@
+// Package rest exposes the most used LightService RPCs as JSON over plain
+// HTTP, for clients such as browsers that can't speak gRPC
+package rest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/auth"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+// defaultHistoryRange is how far back GET /v1/light/history looks when the
+// request gives no start
+const defaultHistoryRange = 24 * time.Hour
+
+// maxBodyBytes bounds POST bodies; a reading is well under 1 KiB
+const maxBodyBytes = 64 << 10
+
+// Gateway maps REST routes onto a LightServiceServer:
+//
+//	GET  /v1/light/current
+//	GET  /v1/light/history?start=&end=&limit=&page_token=&order=&interval=
+//	POST /v1/light/readings
+//	GET  /v1/light/export?start=&end=&format=csv|ndjson
+//
+// Bodies are the RPCs' messages in protobuf's canonical JSON mapping, and
+// gRPC status codes are translated to HTTP ones.
+type Gateway struct {
+	light   pb.LightServiceServer
+	origins []string
+	keys    *auth.KeyStore // nil leaves the gateway open
+	mux     *http.ServeMux
+}
+
+// Option configures a Gateway
+type Option func(*Gateway)
+
+// WithAllowedOrigins lets browser pages served from these origins (e.g.
+// "http://localhost:3000", or "*" for any) call the gateway cross-origin
+func WithAllowedOrigins(origins ...string) Option {
+	return func(g *Gateway) { g.origins = origins }
+}
+
+// WithAuth requires an "Authorization: Bearer" API key from keys on every
+// request: a reader key for GETs, a writer key for POSTs. CORS preflights
+// carry no credentials and are answered without one.
+func WithAuth(keys *auth.KeyStore) Option {
+	return func(g *Gateway) { g.keys = keys }
+}
+
+// NewGateway creates a gateway calling light in-process
+func NewGateway(light pb.LightServiceServer, opts ...Option) *Gateway {
+	g := &Gateway{light: light, mux: http.NewServeMux()}
+	for _, opt := range opts {
+		opt(g)
+	}
+	g.mux.HandleFunc("GET /v1/light/current", g.handleCurrent)
+	g.mux.HandleFunc("GET /v1/light/history", g.handleHistory)
+	g.mux.HandleFunc("POST /v1/light/readings", g.handleRecord)
+	g.mux.HandleFunc("GET /v1/light/export", g.handleExport)
+	return g
+}
+
+// ServeHTTP answers CORS preflights, checks the API key if required, and
+// dispatches to the routes
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if origin := r.Header.Get("Origin"); origin != "" && g.allowOrigin(origin) {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Add("Vary", "Origin")
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST")
+			w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	if g.keys != nil {
+		// After the CORS headers, so pages can read why they were refused
+		authorized, err := authorize(g.keys, r, methodRole(r.Method))
+		if err != nil {
+			writeAuthError(w, err)
+			return
+		}
+		r = authorized
+	}
+	g.mux.ServeHTTP(w, r)
+}
+
+// allowOrigin reports whether pages from origin may call the gateway
+func (g *Gateway) allowOrigin(origin string) bool {
+	return slices.Contains(g.origins, "*") || slices.Contains(g.origins, origin)
+}
+
+// handleCurrent serves GetCurrentLight
+func (g *Gateway) handleCurrent(w http.ResponseWriter, r *http.Request) {
+	resp, err := g.light.GetCurrentLight(r.Context(), &pb.GetCurrentLightRequest{})
+	writeResponse(w, resp, err)
+}
+
+// handleHistory serves GetHistory. start and end are RFC 3339 times or Unix
+// milliseconds, defaulting to the last 24 hours; order is "asc" or "desc";
+// interval is a Go duration such as "1h" and returns buckets.
+func (g *Gateway) handleHistory(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	now := time.Now()
+
+	end, err := parseTime(q.Get("end"), now)
+	if err != nil {
+		writeError(w, status.Error(codes.InvalidArgument, "invalid end: "+err.Error()))
+		return
+	}
+	start, err := parseTime(q.Get("start"), end.Add(-defaultHistoryRange))
+	if err != nil {
+		writeError(w, status.Error(codes.InvalidArgument, "invalid start: "+err.Error()))
+		return
+	}
+
+	req := &pb.GetHistoryRequest{
+		StartTimeMs: start.UnixMilli(),
+		EndTimeMs:   end.UnixMilli(),
+		PageToken:   q.Get("page_token"),
+	}
+	if s := q.Get("limit"); s != "" {
+		n, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			writeError(w, status.Error(codes.InvalidArgument, "invalid limit"))
+			return
+		}
+		req.Limit = int32(n)
+	}
+	switch strings.ToLower(q.Get("order")) {
+	case "", "asc":
+	case "desc":
+		req.Order = pb.SortOrder_SORT_ORDER_DESCENDING
+	default:
+		writeError(w, status.Error(codes.InvalidArgument, `order must be "asc" or "desc"`))
+		return
+	}
+	if s := q.Get("interval"); s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			writeError(w, status.Error(codes.InvalidArgument, "invalid interval"))
+			return
+		}
+		req.AggregationIntervalMs = d.Milliseconds()
+	}
+
+	resp, err := g.light.GetHistory(r.Context(), req)
+	writeResponse(w, resp, err)
+}
+
+// handleRecord serves RecordReading; the body is a RecordReadingRequest,
+// e.g. {"lux": 420}
+func (g *Gateway) handleRecord(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		writeError(w, status.Error(codes.InvalidArgument, "failed to read body"))
+		return
+	}
+
+	var req pb.RecordReadingRequest
+	if err := protojson.Unmarshal(body, &req); err != nil {
+		writeError(w, status.Error(codes.InvalidArgument, "invalid body: "+err.Error()))
+		return
+	}
+
+	resp, err := g.light.RecordReading(r.Context(), &req)
+	writeResponse(w, resp, err)
+}
+
+// handleExport serves DownloadReadings as a file download, writing each
+// chunk as it arrives. start and end are as for history; format is "csv"
+// (the default) or "ndjson".
+func (g *Gateway) handleExport(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	now := time.Now()
+
+	end, err := parseTime(q.Get("end"), now)
+	if err != nil {
+		writeError(w, status.Error(codes.InvalidArgument, "invalid end: "+err.Error()))
+		return
+	}
+	start, err := parseTime(q.Get("start"), end.Add(-defaultHistoryRange))
+	if err != nil {
+		writeError(w, status.Error(codes.InvalidArgument, "invalid start: "+err.Error()))
+		return
+	}
+
+	req := &pb.DownloadReadingsRequest{
+		StartTimeMs: start.UnixMilli(),
+		EndTimeMs:   end.UnixMilli(),
+	}
+	contentType, ext := "text/csv; charset=utf-8", "csv"
+	switch strings.ToLower(q.Get("format")) {
+	case "", "csv":
+		req.Format = pb.ExportFormat_EXPORT_FORMAT_CSV
+	case "ndjson":
+		req.Format = pb.ExportFormat_EXPORT_FORMAT_NDJSON
+		contentType, ext = "application/x-ndjson", "ndjson"
+	default:
+		writeError(w, status.Error(codes.InvalidArgument, `format must be "csv" or "ndjson"`))
+		return
+	}
+
+	stream := &downloadStream{ctx: r.Context(), w: w, header: func() {
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", `attachment; filename="light-readings.`+ext+`"`)
+	}}
+	if err := g.light.DownloadReadings(req, stream); err != nil && !stream.started {
+		// Once the body has started the status is sent; the client sees a
+		// truncated download instead
+		writeError(w, err)
+	}
+}
+
+// downloadStream delivers DownloadReadings chunks straight to an HTTP
+// response. Only the methods the handler uses are implemented.
+type downloadStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	w       http.ResponseWriter
+	header  func() // sets the response headers before the first chunk
+	started bool
+}
+
+func (s *downloadStream) Context() context.Context { return s.ctx }
+
+func (s *downloadStream) Send(chunk *pb.DownloadChunk) error {
+	if !s.started {
+		s.header()
+		s.started = true
+	}
+	if _, err := s.w.Write(chunk.Data); err != nil {
+		return err
+	}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// parseTime reads an RFC 3339 time or Unix milliseconds, or returns def
+// for an empty string
+func parseTime(s string, def time.Time) (time.Time, error) {
+	if s == "" {
+		return def, nil
+	}
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.UnixMilli(ms), nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// marshaler writes zero values too, so clients see e.g. "lux": 0 rather
+// than a missing field
+var marshaler = protojson.MarshalOptions{EmitUnpopulated: true}
+
+// writeResponse writes resp as JSON, or err as an error response
+func writeResponse(w http.ResponseWriter, resp proto.Message, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	body, err := marshaler.Marshal(resp)
+	if err != nil {
+		writeError(w, status.Error(codes.Internal, "failed to encode response"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// writeError writes err's gRPC status as JSON with the matching HTTP status
+func writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	body, _ := marshaler.Marshal(st.Proto())
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus(st.Code()))
+	w.Write(body)
+}
+
+// httpStatus maps a gRPC code to the HTTP status grpc-gateway would use
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.OutOfRange, codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Canceled:
+		return 499 // client closed request
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,170 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/grpc"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/mock"
+)
+
+func newTestGateway(t *testing.T, opts ...Option) *httptest.Server {
+	t.Helper()
+	handler := grpc.NewLightServiceHandler(memory.NewReadingRepository(), mock.NewFakeSensor(500, 0))
+	srv := httptest.NewServer(NewGateway(handler, opts...))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// do sends a request and decodes the JSON response into out
+func do(t *testing.T, method, url, body string, out any) int {
+	t.Helper()
+	req, _ := http.NewRequest(method, url, strings.NewReader(body))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, url, err)
+	}
+	defer resp.Body.Close()
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("%s %s: decoding response: %v", method, url, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestGateway_RecordAndHistory(t *testing.T) {
+	srv := newTestGateway(t)
+
+	var recorded struct {
+		Reading struct {
+			ID  string  `json:"id"`
+			Lux float64 `json:"lux"`
+		} `json:"reading"`
+	}
+	if code := do(t, "POST", srv.URL+"/v1/light/readings", `{"lux": 420}`, &recorded); code != http.StatusOK {
+		t.Fatalf("POST readings: got status %d", code)
+	}
+	if recorded.Reading.Lux != 420 || recorded.Reading.ID == "" {
+		t.Errorf("unexpected recorded reading %+v", recorded.Reading)
+	}
+
+	var current struct {
+		Reading struct {
+			Lux float64 `json:"lux"`
+		} `json:"reading"`
+	}
+	if code := do(t, "GET", srv.URL+"/v1/light/current", "", &current); code != http.StatusOK {
+		t.Fatalf("GET current: got status %d", code)
+	}
+
+	var history struct {
+		Readings   []struct{ Lux float64 } `json:"readings"`
+		AverageLux float64                 `json:"averageLux"`
+	}
+	end := strconv.FormatInt(time.Now().Add(time.Minute).UnixMilli(), 10)
+	if code := do(t, "GET", srv.URL+"/v1/light/history?end="+end, "", &history); code != http.StatusOK {
+		t.Fatalf("GET history: got status %d", code)
+	}
+	if len(history.Readings) != 1 || history.AverageLux != 420 {
+		t.Errorf("expected the recorded reading in history, got %+v", history)
+	}
+}
+
+func TestGateway_Errors(t *testing.T) {
+	srv := newTestGateway(t)
+
+	tests := []struct {
+		method, path, body string
+		want               int
+	}{
+		{"POST", "/v1/light/readings", `{"lux": -1}`, http.StatusBadRequest},
+		{"POST", "/v1/light/readings", `not json`, http.StatusBadRequest},
+		{"GET", "/v1/light/history?start=yesterday", "", http.StatusBadRequest},
+		{"GET", "/v1/light/history?order=sideways", "", http.StatusBadRequest},
+		{"GET", "/v1/light/unknown", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		var body struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		}
+		var out any = &body
+		if tt.want == http.StatusNotFound {
+			out = nil // the mux's plain-text 404
+		}
+		if code := do(t, tt.method, srv.URL+tt.path, tt.body, out); code != tt.want {
+			t.Errorf("%s %s: got status %d, want %d", tt.method, tt.path, code, tt.want)
+		}
+		if out != nil && body.Message == "" {
+			t.Errorf("%s %s: expected an error message", tt.method, tt.path)
+		}
+	}
+}
+
+func TestGateway_CORS(t *testing.T) {
+	srv := newTestGateway(t, WithAllowedOrigins("http://dashboard.local"))
+
+	for origin, allowed := range map[string]bool{"http://dashboard.local": true, "http://evil.example": false} {
+		req, _ := http.NewRequest("OPTIONS", srv.URL+"/v1/light/readings", nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", "POST")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("preflight: %v", err)
+		}
+		resp.Body.Close()
+
+		if got := resp.Header.Get("Access-Control-Allow-Origin") == origin; got != allowed {
+			t.Errorf("origin %s: allowed = %v, want %v", origin, got, allowed)
+		}
+	}
+}
+
+func TestGateway_Export(t *testing.T) {
+	srv := newTestGateway(t)
+
+	for _, lux := range []string{"100", "200"} {
+		if code := do(t, "POST", srv.URL+"/v1/light/readings", `{"lux": `+lux+`}`, nil); code != http.StatusOK {
+			t.Fatalf("POST readings: got status %d", code)
+		}
+	}
+
+	end := strconv.FormatInt(time.Now().Add(time.Minute).UnixMilli(), 10)
+	resp, err := http.Get(srv.URL + "/v1/light/export?end=" + end)
+	if err != nil {
+		t.Fatalf("GET export: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/csv") {
+		t.Fatalf("expected a CSV download, got %d %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	if !strings.Contains(resp.Header.Get("Content-Disposition"), "attachment") {
+		t.Errorf("expected an attachment, got %q", resp.Header.Get("Content-Disposition"))
+	}
+	if lines := strings.Split(strings.TrimSpace(string(body)), "\n"); len(lines) != 3 {
+		t.Errorf("expected a header and 2 rows, got %q", body)
+	}
+
+	resp, err = http.Get(srv.URL + "/v1/light/export?format=ndjson&end=" + end)
+	if err != nil {
+		t.Fatalf("GET export: %v", err)
+	}
+	body, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.Header.Get("Content-Type") != "application/x-ndjson" || strings.Count(string(body), "\n") != 2 {
+		t.Errorf("expected 2 NDJSON lines, got %q %q", resp.Header.Get("Content-Type"), body)
+	}
+
+	if code := do(t, "GET", srv.URL+"/v1/light/export?format=xml", "", nil); code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown format, got %d", code)
+	}
+}
@@ -0,0 +1,55 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+var _ domain.CalibrationRepository = (*ReadingRepository)(nil)
+
+// GetCalibration returns the sensor's stored calibration
+func (r *ReadingRepository) GetCalibration(ctx context.Context, sensorID string) (*domain.Calibration, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT sensor_id, scale, offset_lux, updated_at FROM sensor_calibrations WHERE sensor_id = ?`
+
+	var cal domain.Calibration
+	var updatedAt int64
+	err := r.db.QueryRowContext(ctx, query, sensorID).Scan(&cal.SensorID, &cal.Scale, &cal.OffsetLux, &updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrCalibrationNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query calibration: %w", err)
+	}
+	cal.UpdatedAt = fromEpoch(updatedAt)
+	return &cal, nil
+}
+
+// SaveCalibration inserts or replaces the sensor's calibration
+func (r *ReadingRepository) SaveCalibration(ctx context.Context, cal *domain.Calibration) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO sensor_calibrations (sensor_id, scale, offset_lux, updated_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(sensor_id) DO UPDATE SET
+			scale = excluded.scale,
+			offset_lux = excluded.offset_lux,
+			updated_at = excluded.updated_at
+	`
+
+	updatedAt := toEpoch(time.Now())
+	if _, err := r.db.ExecContext(ctx, query, cal.SensorID, cal.Scale, cal.OffsetLux, updatedAt); err != nil {
+		return fmt.Errorf("failed to save calibration: %w", err)
+	}
+	cal.UpdatedAt = fromEpoch(updatedAt)
+	return nil
+}
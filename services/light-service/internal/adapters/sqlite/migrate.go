@@ -0,0 +1,246 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// migrationFiles holds the schema's history, one NNNN_description.sql file
+// per version. Add a file to change the schema; never edit one that has
+// shipped, as databases that already applied it won't run it again.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migration is one schema version's SQL
+type migration struct {
+	version int
+	name    string
+	sql     string
+}
+
+// loadMigrations reads the embedded migrations in version order, checking
+// they are numbered 1, 2, 3... without gaps
+func loadMigrations(files fs.FS) ([]migration, error) {
+	names, err := fs.Glob(files, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	// Glob sorts, and the zero-padded numbers sort by version
+	migrations := make([]migration, 0, len(names))
+	for i, name := range names {
+		base := path.Base(name)
+		prefix, _, ok := strings.Cut(base, "_")
+		version, err := strconv.Atoi(prefix)
+		if !ok || err != nil {
+			return nil, fmt.Errorf("migration %s: name must start with a version number and an underscore", base)
+		}
+		if version != i+1 {
+			return nil, fmt.Errorf("migration %s: expected version %d", base, i+1)
+		}
+		body, err := fs.ReadFile(files, name)
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, migration{version: version, name: base, sql: string(body)})
+	}
+	return migrations, nil
+}
+
+// migrate brings the schema up to the newest embedded migration. Each
+// migration runs in its own transaction together with its schema_version
+// row, so a failure leaves the database at the last version that applied
+// cleanly. A database from a newer build is refused rather than touched.
+func migrate(ctx context.Context, db *sql.DB, files fs.FS) error {
+	migrations, err := loadMigrations(files)
+	if err != nil {
+		return err
+	}
+
+	if _, err := db.ExecContext(ctx, `
+	CREATE TABLE IF NOT EXISTS schema_version (
+		version INTEGER PRIMARY KEY,
+		applied_at DATETIME NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("create schema_version: %w", err)
+	}
+
+	current, err := schemaVersion(ctx, db)
+	if err != nil {
+		return err
+	}
+	if current > len(migrations) {
+		return fmt.Errorf("database schema is at version %d, newer than this build's %d", current, len(migrations))
+	}
+	// 0001_initial.sql collapses duplicate timestamps left by old re-imports;
+	// count them first, since the SQL can't say how many it deleted
+	var duplicates int64
+	if current == 0 {
+		if err := adoptLegacySchema(ctx, db); err != nil {
+			return fmt.Errorf("upgrade pre-migration schema: %w", err)
+		}
+		if duplicates, err = countDuplicateTimestamps(ctx, db); err != nil {
+			return fmt.Errorf("count duplicate timestamps: %w", err)
+		}
+	}
+
+	for _, m := range migrations[current:] {
+		if err := apply(ctx, db, m); err != nil {
+			return fmt.Errorf("migration %s: %w", m.name, err)
+		}
+	}
+	if duplicates > 0 {
+		log.Warn().Int64("deleted", duplicates).Msg("deleted readings sharing a timestamp with a newer one while migrating")
+	}
+	return nil
+}
+
+// countDuplicateTimestamps returns how many light_readings rows share a
+// timestamp with another, beyond the first of each; 0 if there is no table
+func countDuplicateTimestamps(ctx context.Context, db *sql.DB) (int64, error) {
+	var tables int
+	if err := db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'light_readings'`,
+	).Scan(&tables); err != nil || tables == 0 {
+		return 0, err
+	}
+
+	var duplicates int64
+	err := db.QueryRowContext(ctx, `SELECT COUNT(*) - COUNT(DISTINCT timestamp) FROM light_readings`).Scan(&duplicates)
+	return duplicates, err
+}
+
+// checkSchema is migrate for a read-only database: it changes nothing, but
+// refuses a schema that isn't exactly this build's
+func checkSchema(ctx context.Context, db *sql.DB, files fs.FS) error {
+	migrations, err := loadMigrations(files)
+	if err != nil {
+		return err
+	}
+
+	var tables int
+	if err := db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'schema_version'`,
+	).Scan(&tables); err != nil {
+		return fmt.Errorf("read schema version: %w", err)
+	}
+	current := 0
+	if tables > 0 {
+		if current, err = schemaVersion(ctx, db); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case current < len(migrations):
+		return fmt.Errorf("database schema is at version %d, behind this build's %d; start once without read-only mode to migrate it", current, len(migrations))
+	case current > len(migrations):
+		return fmt.Errorf("database schema is at version %d, newer than this build's %d", current, len(migrations))
+	}
+	return nil
+}
+
+// apply runs one migration and records it
+func apply(ctx context.Context, db *sql.DB, m migration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, m.sql); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO schema_version (version, applied_at) VALUES (?, ?)`,
+		m.version, time.Now().UTC(),
+	); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// schemaVersion returns the newest applied migration, 0 for none
+func schemaVersion(ctx context.Context, db *sql.DB) (int, error) {
+	var version int
+	if err := db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_version`).Scan(&version); err != nil {
+		return 0, fmt.Errorf("read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// adoptLegacySchema adds the light_readings columns that databases created
+// before migrations may lack, so the first migration finds the table it
+// expects. New databases have no table yet and are left alone.
+func adoptLegacySchema(ctx context.Context, db *sql.DB) error {
+	var tables int
+	if err := db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'light_readings'`,
+	).Scan(&tables); err != nil {
+		return err
+	}
+	if tables == 0 {
+		return nil
+	}
+
+	for _, col := range []struct{ name, definition string }{
+		{"source", "TEXT NOT NULL DEFAULT 'sensor'"},
+		{"temperature_c", "REAL"},
+		{"quality", "TEXT NOT NULL DEFAULT 'ok'"},
+	} {
+		if err := ensureColumn(db, "light_readings", col.name, col.definition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureColumn adds a column to an existing table if it is missing
+func ensureColumn(db *sql.DB, table, column, definition string) error {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid       int
+			name      string
+			colType   string
+			notNull   int
+			dfltValue sql.NullString
+			pk        int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	_, err = db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	return err
+}
+
+// SchemaVersion returns the database's schema version: the number of the
+// newest migration applied
+func (r *ReadingRepository) SchemaVersion(ctx context.Context) (int, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+	return schemaVersion(ctx, r.db)
+}
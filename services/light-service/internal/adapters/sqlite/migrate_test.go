@@ -0,0 +1,348 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+func latestVersion(t *testing.T) int {
+	t.Helper()
+	migrations, err := loadMigrations(migrationFiles)
+	if err != nil {
+		t.Fatalf("embedded migrations are invalid: %v", err)
+	}
+	return len(migrations)
+}
+
+func TestMigrate_FreshDatabase(t *testing.T) {
+	repo := newTestRepo(t)
+
+	version, err := repo.SchemaVersion(context.Background())
+	if err != nil {
+		t.Fatalf("SchemaVersion failed: %v", err)
+	}
+	if want := latestVersion(t); version != want {
+		t.Errorf("expected a new database at version %d, got %d", want, version)
+	}
+}
+
+func TestMigrate_ReopenIsIdempotent(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	for i := 0; i < 2; i++ {
+		repo, err := NewReadingRepository(dbPath)
+		if err != nil {
+			t.Fatalf("open %d failed: %v", i+1, err)
+		}
+		repo.Close()
+	}
+
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	var rows int
+	if err := db.QueryRow("SELECT COUNT(*) FROM schema_version").Scan(&rows); err != nil {
+		t.Fatal(err)
+	}
+	if want := latestVersion(t); rows != want {
+		t.Errorf("expected each migration recorded once (%d rows), got %d", want, rows)
+	}
+}
+
+// writeLegacyDatabase creates the schema from before the source,
+// temperature_c and quality columns at dbPath, with a duplicate timestamp
+// left by an old re-import: 100 then 200 lux
+func writeLegacyDatabase(t *testing.T, dbPath string) {
+	t.Helper()
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ts := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for _, stmt := range []string{
+		`CREATE TABLE light_readings (id INTEGER PRIMARY KEY AUTOINCREMENT, lux REAL NOT NULL, timestamp DATETIME NOT NULL)`,
+		`CREATE INDEX idx_timestamp ON light_readings(timestamp)`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, lux := range []float64{100, 200} {
+		if _, err := db.Exec(`INSERT INTO light_readings (lux, timestamp) VALUES (?, ?)`, lux, ts); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestMigrate_AdoptsLegacyDatabase(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "legacy.db")
+	writeLegacyDatabase(t, dbPath)
+
+	var logs bytes.Buffer
+	original := log.Logger
+	log.Logger = zerolog.New(&logs)
+	t.Cleanup(func() { log.Logger = original })
+
+	repo, err := NewReadingRepository(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open legacy database: %v", err)
+	}
+	defer repo.Close()
+	ctx := context.Background()
+
+	if !strings.Contains(logs.String(), `"deleted":1`) {
+		t.Errorf("expected the deleted duplicate logged, got %s", logs.String())
+	}
+
+	if version, _ := repo.SchemaVersion(ctx); version != latestVersion(t) {
+		t.Errorf("expected the legacy database migrated to version %d, got %d", latestVersion(t), version)
+	}
+	readings, err := repo.GetRecentReadings(ctx, 10)
+	if err != nil {
+		t.Fatalf("GetRecentReadings failed: %v", err)
+	}
+	if len(readings) != 1 || readings[0].Lux != 200 {
+		t.Fatalf("expected the duplicate collapsed to the newest row, got %+v", readings)
+	}
+	if readings[0].Source != "sensor" || readings[0].Quality != "ok" {
+		t.Errorf("expected the added columns' defaults, got source %q quality %q", readings[0].Source, readings[0].Quality)
+	}
+}
+
+func TestMigrate_ConvertsTextTimestamps(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "v1.db")
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	// A version 1 database, its timestamps text in the writer's zone either
+	// side of a DST change and once in UTC
+	initial, err := fs.ReadFile(migrationFiles, "migrations/0001_initial.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := migrate(ctx, db, fstest.MapFS{"migrations/0001_initial.sql": {Data: initial}}); err != nil {
+		t.Fatalf("failed to create a version 1 database: %v", err)
+	}
+	for _, ts := range []string{
+		"2024-03-10 01:30:00.25-05:00",
+		"2024-03-10 03:00:00-04:00",
+		"2024-03-10 07:30:00Z",
+	} {
+		if _, err := db.Exec(`INSERT INTO light_readings (lux, timestamp) VALUES (100, ?)`, ts); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := db.Exec(`INSERT INTO category_events (from_category, to_category, lux, timestamp) VALUES (0, 1, 100, '2024-03-10 03:00:00-04:00')`); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	repo, err := NewReadingRepository(dbPath)
+	if err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	defer repo.Close()
+
+	base := time.Date(2024, 3, 10, 6, 30, 0, 0, time.UTC)
+	readings, err := repo.GetReadingsInRange(ctx, base, base.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("GetReadingsInRange failed: %v", err)
+	}
+	want := []time.Time{base.Add(250 * time.Millisecond), base.Add(30 * time.Minute), base.Add(time.Hour)}
+	if len(readings) != len(want) {
+		t.Fatalf("expected %d readings, got %d", len(want), len(readings))
+	}
+	for i, r := range readings {
+		if !r.Timestamp.Equal(want[i]) {
+			t.Errorf("reading %d: expected %s, got %s", i, want[i], r.Timestamp)
+		}
+	}
+
+	events, err := repo.GetCategoryEvents(ctx, base, base.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GetCategoryEvents failed: %v", err)
+	}
+	if len(events) != 1 || !events[0].Timestamp.Equal(base.Add(30*time.Minute)) {
+		t.Errorf("expected the category event converted, got %+v", events)
+	}
+}
+
+func TestMigrate_RefusesNewerDatabase(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	repo, err := NewReadingRepository(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	future := latestVersion(t) + 1
+	if _, err := repo.db.Exec(`INSERT INTO schema_version (version, applied_at) VALUES (?, ?)`, future, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	repo.Close()
+
+	_, err = NewReadingRepository(dbPath)
+	if err == nil || !strings.Contains(err.Error(), "newer than this build") {
+		t.Errorf("expected a database from a newer build refused, got %v", err)
+	}
+}
+
+// snapshotDir reads every file in dir, so a test can check none changed
+func snapshotDir(t *testing.T, dir string) map[string][]byte {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string][]byte, len(entries))
+	for _, e := range entries {
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[e.Name()] = data
+	}
+	return files
+}
+
+func TestReadOnly_RefusesAndLeavesOldSchemaUntouched(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "legacy.db")
+	writeLegacyDatabase(t, dbPath)
+	before := snapshotDir(t, dir)
+
+	_, err := NewReadingRepository(dbPath, WithReadOnly())
+	if err == nil || !strings.Contains(err.Error(), "behind this build") {
+		t.Errorf("expected an old schema refused in read-only mode, got %v", err)
+	}
+
+	after := snapshotDir(t, dir)
+	if len(after) != len(before) {
+		t.Errorf("expected files %v, got %v", slices.Sorted(maps.Keys(before)), slices.Sorted(maps.Keys(after)))
+	}
+	for name, data := range before {
+		if !bytes.Equal(after[name], data) {
+			t.Errorf("%s changed in read-only mode", name)
+		}
+	}
+}
+
+func TestReadOnly_OpensCurrentDatabase(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	repo, err := NewReadingRepository(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	reading, _ := domain.NewLightReadingAt(500, time.Now().Add(-time.Minute))
+	if err := repo.SaveReading(ctx, reading); err != nil {
+		t.Fatalf("SaveReading failed: %v", err)
+	}
+	repo.Close()
+
+	ro, err := NewReadingRepository(dbPath, WithReadOnly())
+	if err != nil {
+		t.Fatalf("failed to open read-only: %v", err)
+	}
+	defer ro.Close()
+
+	latest, err := ro.GetLatestReading(ctx)
+	if err != nil || latest.Lux != 500 {
+		t.Errorf("expected the stored reading, got %+v, %v", latest, err)
+	}
+	another, _ := domain.NewLightReading(600)
+	if err := ro.SaveReading(ctx, another); err == nil {
+		t.Error("expected a write to a read-only database to fail")
+	}
+}
+
+func TestReadOnly_RefusesMissingDatabase(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := NewReadingRepository(filepath.Join(dir, "missing.db"), WithReadOnly()); err == nil {
+		t.Error("expected a missing database refused in read-only mode")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("expected nothing created, got %v", entries)
+	}
+}
+
+func TestMigrate_AppliesPendingInOrder(t *testing.T) {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ctx := context.Background()
+
+	files := fstest.MapFS{
+		"migrations/0001_plants.sql": {Data: []byte(`CREATE TABLE plants (id INTEGER PRIMARY KEY, name TEXT NOT NULL);`)},
+	}
+	if err := migrate(ctx, db, files); err != nil {
+		t.Fatalf("first migration failed: %v", err)
+	}
+
+	// A later release adds a column; only the new migration runs
+	files["migrations/0002_plant_species.sql"] = &fstest.MapFile{Data: []byte(`ALTER TABLE plants ADD COLUMN species TEXT;`)}
+	if err := migrate(ctx, db, files); err != nil {
+		t.Fatalf("second migration failed: %v", err)
+	}
+	if version, _ := schemaVersion(ctx, db); version != 2 {
+		t.Errorf("expected version 2, got %d", version)
+	}
+
+	// A failing migration leaves the database at the last good version
+	files["migrations/0003_broken.sql"] = &fstest.MapFile{Data: []byte(`CREATE TABLE extra (id INTEGER); ALTER TABLE missing ADD COLUMN x TEXT;`)}
+	if err := migrate(ctx, db, files); err == nil || !strings.Contains(err.Error(), "0003_broken.sql") {
+		t.Fatalf("expected the broken migration named in the error, got %v", err)
+	}
+	if version, _ := schemaVersion(ctx, db); version != 2 {
+		t.Errorf("expected version 2 after the failed migration, got %d", version)
+	}
+	var tables int
+	db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE name = 'extra'`).Scan(&tables)
+	if tables != 0 {
+		t.Error("expected the failed migration rolled back")
+	}
+}
+
+func TestLoadMigrations_Numbering(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string
+		want  string
+	}{
+		{"gap", []string{"0001_a.sql", "0003_c.sql"}, "expected version 2"},
+		{"not starting at 1", []string{"0002_b.sql"}, "expected version 1"},
+		{"unnumbered", []string{"initial.sql"}, "version number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := fstest.MapFS{}
+			for _, name := range tt.files {
+				files["migrations/"+name] = &fstest.MapFile{Data: []byte("SELECT 1;")}
+			}
+			if _, err := loadMigrations(files); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
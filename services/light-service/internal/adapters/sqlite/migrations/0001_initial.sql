@@ -0,0 +1,35 @@
+-- The schema as it stood when migrations were introduced. Every statement
+-- tolerates existing objects, so databases created before then adopt it as
+-- their first version.
+CREATE TABLE IF NOT EXISTS light_readings (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	lux REAL NOT NULL,
+	timestamp DATETIME NOT NULL,
+	source TEXT NOT NULL DEFAULT 'sensor',
+	temperature_c REAL,
+	quality TEXT NOT NULL DEFAULT 'ok'
+);
+
+-- UpsertReading's ON CONFLICT clause needs a unique timestamp index. Any
+-- duplicates left by earlier re-imports are collapsed to the most recently
+-- inserted row first.
+DELETE FROM light_readings
+WHERE id NOT IN (SELECT MAX(id) FROM light_readings GROUP BY timestamp);
+DROP INDEX IF EXISTS idx_timestamp;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_timestamp_unique ON light_readings(timestamp);
+
+CREATE TABLE IF NOT EXISTS category_events (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	from_category INTEGER NOT NULL,
+	to_category INTEGER NOT NULL,
+	lux REAL NOT NULL,
+	timestamp DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_category_events_timestamp ON category_events(timestamp);
+
+CREATE TABLE IF NOT EXISTS sensor_calibrations (
+	sensor_id TEXT PRIMARY KEY,
+	scale REAL NOT NULL,
+	offset_lux REAL NOT NULL,
+	updated_at DATETIME NOT NULL
+);
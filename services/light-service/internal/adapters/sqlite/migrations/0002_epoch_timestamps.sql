@@ -0,0 +1,52 @@
+-- Timestamps were stored as text in whatever zone the writer used, and
+-- range queries compared them as strings against zone-less bounds, so they
+-- went wrong across DST changes and when clients and the Pi disagreed on the
+-- zone. Store them as UTC Unix nanoseconds instead. SQLite can't change a
+-- column's type in place, so each table is rebuilt; the INTEGER declared
+-- type also stops the driver converting the values back into times.
+
+CREATE TABLE light_readings_epoch (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	lux REAL NOT NULL,
+	timestamp INTEGER NOT NULL,
+	source TEXT NOT NULL DEFAULT 'sensor',
+	temperature_c REAL,
+	quality TEXT NOT NULL DEFAULT 'ok'
+);
+INSERT INTO light_readings_epoch (id, lux, timestamp, source, temperature_c, quality)
+SELECT id, lux, CAST(round(unixepoch(timestamp, 'subsec') * 1000) AS INTEGER) * 1000000, source, temperature_c, quality
+FROM light_readings;
+DROP TABLE light_readings;
+ALTER TABLE light_readings_epoch RENAME TO light_readings;
+
+-- Text timestamps written in different zones may name the same instant;
+-- keep the most recently inserted, as the first migration did
+DELETE FROM light_readings
+WHERE id NOT IN (SELECT MAX(id) FROM light_readings GROUP BY timestamp);
+CREATE UNIQUE INDEX idx_timestamp_unique ON light_readings(timestamp);
+
+CREATE TABLE category_events_epoch (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	from_category INTEGER NOT NULL,
+	to_category INTEGER NOT NULL,
+	lux REAL NOT NULL,
+	timestamp INTEGER NOT NULL
+);
+INSERT INTO category_events_epoch (id, from_category, to_category, lux, timestamp)
+SELECT id, from_category, to_category, lux, CAST(round(unixepoch(timestamp, 'subsec') * 1000) AS INTEGER) * 1000000
+FROM category_events;
+DROP TABLE category_events;
+ALTER TABLE category_events_epoch RENAME TO category_events;
+CREATE INDEX idx_category_events_timestamp ON category_events(timestamp);
+
+CREATE TABLE sensor_calibrations_epoch (
+	sensor_id TEXT PRIMARY KEY,
+	scale REAL NOT NULL,
+	offset_lux REAL NOT NULL,
+	updated_at INTEGER NOT NULL
+);
+INSERT INTO sensor_calibrations_epoch (sensor_id, scale, offset_lux, updated_at)
+SELECT sensor_id, scale, offset_lux, CAST(round(unixepoch(updated_at, 'subsec') * 1000) AS INTEGER) * 1000000
+FROM sensor_calibrations;
+DROP TABLE sensor_calibrations;
+ALTER TABLE sensor_calibrations_epoch RENAME TO sensor_calibrations;
@@ -3,47 +3,338 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"io/fs"
+	"math"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/mattn/go-sqlite3"
 	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
 )
 
 // ReadingRepository implements domain.ReadingRepository with SQLite
 type ReadingRepository struct {
-	db *sql.DB
+	db           *sql.DB
+	queryTimeout time.Duration
 }
 
+// options holds connection tuning applied when opening the database
+type options struct {
+	journalMode     string
+	busyTimeout     time.Duration
+	synchronous     string
+	maxOpenConns    int
+	maxIdleConns    int
+	connMaxLifetime time.Duration
+	queryTimeout    time.Duration
+	readOnly        bool
+}
+
+// Option configures how NewReadingRepository opens the database
+type Option func(*options)
+
+// WithJournalMode sets PRAGMA journal_mode (default WAL, which lets readers
+// proceed while the recorder writes)
+func WithJournalMode(mode string) Option {
+	return func(o *options) { o.journalMode = mode }
+}
+
+// WithBusyTimeout sets PRAGMA busy_timeout, how long a connection waits on a
+// locked database before failing with "database is locked" (default 5s)
+func WithBusyTimeout(d time.Duration) Option {
+	return func(o *options) { o.busyTimeout = d }
+}
+
+// WithSynchronous sets PRAGMA synchronous (default NORMAL, which is safe in WAL mode)
+func WithSynchronous(mode string) Option {
+	return func(o *options) { o.synchronous = mode }
+}
+
+// WithMaxOpenConns caps the connection pool size (default 1, so writers
+// queue in the pool instead of contending for SQLite's lock)
+func WithMaxOpenConns(n int) Option {
+	return func(o *options) { o.maxOpenConns = n }
+}
+
+// WithMaxIdleConns sets how many connections the pool keeps open while idle
+// (default 1)
+func WithMaxIdleConns(n int) Option {
+	return func(o *options) { o.maxIdleConns = n }
+}
+
+// WithConnMaxLifetime closes and replaces connections older than d
+// (default 0, never)
+func WithConnMaxLifetime(d time.Duration) Option {
+	return func(o *options) { o.connMaxLifetime = d }
+}
+
+// WithQueryTimeout bounds every repository call, including time spent
+// waiting for a pooled connection (default 0, no limit beyond the caller's
+// context). Waiting on SQLite's own lock is bounded by the busy timeout.
+func WithQueryTimeout(d time.Duration) Option {
+	return func(o *options) { o.queryTimeout = d }
+}
+
+// WithReadOnly opens the database read-only (SQLite's mode=ro) and checks
+// its schema instead of migrating it: a database that is missing or behind
+// this build is refused, since only a writable instance may change it
+func WithReadOnly() Option {
+	return func(o *options) { o.readOnly = true }
+}
+
+var (
+	validJournalModes = []string{"DELETE", "TRUNCATE", "PERSIST", "MEMORY", "WAL", "OFF"}
+	validSynchronous  = []string{"OFF", "NORMAL", "FULL", "EXTRA"}
+)
+
 // NewReadingRepository creates a SQLite-backed repository
-func NewReadingRepository(dbPath string) (*ReadingRepository, error) {
-	db, err := sql.Open("sqlite3", dbPath)
+func NewReadingRepository(dbPath string, opts ...Option) (*ReadingRepository, error) {
+	o := options{
+		journalMode:  "WAL",
+		busyTimeout:  5 * time.Second,
+		synchronous:  "NORMAL",
+		maxOpenConns: 1,
+		maxIdleConns: 1,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	dsn, err := buildDSN(dbPath, o)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.readOnly {
+		err = checkDBFile(dbPath)
+	} else {
+		err = prepareDBPath(dbPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite3", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
+	db.SetMaxOpenConns(o.maxOpenConns)
+	db.SetMaxIdleConns(o.maxIdleConns)
+	db.SetConnMaxLifetime(o.connMaxLifetime)
 
-	// Create table if not exists
-	schema := `
-	CREATE TABLE IF NOT EXISTS light_readings (
-		id INTEGER PRIMARY KEY AUTOINCREMENT,
-		lux REAL NOT NULL,
-		timestamp DATETIME NOT NULL
-	);
-	CREATE INDEX IF NOT EXISTS idx_timestamp ON light_readings(timestamp);
-	`
+	// sql.Open connects lazily; connect now so a bad database fails here
+	// rather than on the first query
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	if o.readOnly {
+		err = checkSchema(context.Background(), db, migrationFiles)
+	} else if err = migrate(context.Background(), db, migrationFiles); err != nil {
+		err = fmt.Errorf("failed to migrate schema: %w", err)
+	}
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
 
-	if _, err := db.Exec(schema); err != nil {
-		return nil, fmt.Errorf("failed to create schema: %w", err)
+	return &ReadingRepository{db: db, queryTimeout: o.queryTimeout}, nil
+}
+
+// withTimeout bounds one repository call by the configured query timeout
+func (r *ReadingRepository) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.queryTimeout <= 0 {
+		return ctx, func() {}
 	}
+	return context.WithTimeout(ctx, r.queryTimeout)
+}
+
+// buildDSN validates the options and encodes them as go-sqlite3 connection
+// parameters, so every pooled connection gets the same pragmas
+func buildDSN(dbPath string, o options) (string, error) {
+	journalMode := strings.ToUpper(o.journalMode)
+	if !slices.Contains(validJournalModes, journalMode) {
+		return "", fmt.Errorf("invalid journal mode %q (want one of %s)", o.journalMode, strings.Join(validJournalModes, ", "))
+	}
+
+	synchronous := strings.ToUpper(o.synchronous)
+	if !slices.Contains(validSynchronous, synchronous) {
+		return "", fmt.Errorf("invalid synchronous mode %q (want one of %s)", o.synchronous, strings.Join(validSynchronous, ", "))
+	}
+
+	if o.busyTimeout < 0 {
+		return "", fmt.Errorf("busy timeout cannot be negative")
+	}
+	if o.maxOpenConns < 1 {
+		return "", fmt.Errorf("max open connections must be at least 1")
+	}
+	if o.maxIdleConns < 0 {
+		return "", fmt.Errorf("max idle connections cannot be negative")
+	}
+	if o.connMaxLifetime < 0 {
+		return "", fmt.Errorf("connection lifetime cannot be negative")
+	}
+	if o.queryTimeout < 0 {
+		return "", fmt.Errorf("query timeout cannot be negative")
+	}
+
+	params := url.Values{}
+	if o.readOnly {
+		// Setting the journal mode writes the database header, so a
+		// read-only connection keeps whatever mode the file is in
+		params.Set("mode", "ro")
+	} else {
+		params.Set("_journal_mode", journalMode)
+	}
+	params.Set("_busy_timeout", fmt.Sprintf("%d", o.busyTimeout.Milliseconds()))
+	params.Set("_synchronous", synchronous)
+
+	return "file:" + dbPath + "?" + params.Encode(), nil
+}
+
+// prepareDBPath creates the database's parent directory if needed and checks
+// the location is usable, so a bad DB_PATH fails with a clear message rather
+// than an opaque "unable to open database file" on the first query
+func prepareDBPath(dbPath string) error {
+	if dbPath == ":memory:" {
+		return nil
+	}
+
+	info, err := os.Stat(dbPath)
+	switch {
+	case err == nil && info.IsDir():
+		return fmt.Errorf("database path %q is a directory, not a file", dbPath)
+	case err == nil:
+		// Existing database: it must be writable
+		f, err := os.OpenFile(dbPath, os.O_RDWR, 0)
+		if err != nil {
+			return fmt.Errorf("database file %q is not writable: %w", dbPath, err)
+		}
+		return f.Close()
+	case errors.Is(err, fs.ErrPermission):
+		return fmt.Errorf("cannot access database path %q: %w", dbPath, err)
+	}
+
+	dir := filepath.Dir(dbPath)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("cannot create database directory %q: %w", dir, err)
+	}
+
+	// New database: SQLite needs to create the file and its journal next to it
+	probe, err := os.CreateTemp(dir, ".light-service-write-check-*")
+	if err != nil {
+		return fmt.Errorf("database directory %q is not writable: %w", dir, err)
+	}
+	probe.Close()
+	return os.Remove(probe.Name())
+}
+
+// checkDBFile checks a database opened read-only exists, since there is no
+// creating it
+func checkDBFile(dbPath string) error {
+	info, err := os.Stat(dbPath)
+	switch {
+	case err != nil:
+		return fmt.Errorf("cannot open database %q read-only: %w", dbPath, err)
+	case info.IsDir():
+		return fmt.Errorf("database path %q is a directory, not a file", dbPath)
+	}
+	return nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// Timestamps are stored as UTC Unix nanoseconds, so they compare and sort
+// as numbers whatever zone the writer, the reader or the Pi is in, and
+// readings taken within the same millisecond stay distinct
+
+// Bounds of the stored form; UnixNano is undefined outside them
+var (
+	minEpoch = time.Unix(0, math.MinInt64)
+	maxEpoch = time.Unix(0, math.MaxInt64)
+)
+
+// toEpoch converts a time to its stored form, clamping times outside
+// roughly 1678-2262 so open-ended range bounds still compare correctly
+func toEpoch(t time.Time) int64 {
+	switch {
+	case t.Before(minEpoch):
+		return math.MinInt64
+	case t.After(maxEpoch):
+		return math.MaxInt64
+	}
+	return t.UnixNano()
+}
+
+// fromEpoch converts a stored timestamp back to a time, in UTC
+func fromEpoch(ns int64) time.Time {
+	return time.Unix(0, ns).UTC()
+}
+
+// readingColumns lists the columns scanReading expects, in order
+const readingColumns = "id, lux, timestamp, source, temperature_c, quality"
+
+// scanReading reads the readingColumns into a reading
+func scanReading(row rowScanner) (*domain.LightReading, error) {
+	var reading domain.LightReading
+	var timestamp int64
+	var source, quality string
+	var temperature sql.NullFloat64
+
+	if err := row.Scan(&reading.ID, &reading.Lux, &timestamp, &source, &temperature, &quality); err != nil {
+		return nil, err
+	}
+	reading.Timestamp = fromEpoch(timestamp)
+	reading.Source = domain.Source(source)
+	reading.Quality = domain.Quality(quality)
+	if temperature.Valid {
+		reading.TemperatureC = &temperature.Float64
+	}
+
+	return &reading, nil
+}
 
-	return &ReadingRepository{db: db}, nil
+// insertReadingQuery inserts one reading; pair with insertArgs
+const insertReadingQuery = `INSERT INTO light_readings (lux, timestamp, source, temperature_c, quality) VALUES (?, ?, ?, ?, ?)`
+
+// insertArgs returns the insertReadingQuery arguments for a reading
+func insertArgs(reading *domain.LightReading) []any {
+	var temperature sql.NullFloat64
+	if reading.TemperatureC != nil {
+		temperature = sql.NullFloat64{Float64: *reading.TemperatureC, Valid: true}
+	}
+	return []any{reading.Lux, toEpoch(reading.Timestamp), string(sourceOrDefault(reading.Source)), temperature, string(qualityOrDefault(reading.Quality))}
 }
 
+// upsertReadingQuery is insertReadingQuery, but a reading at an existing
+// timestamp overwrites that row instead of violating the unique index
+const upsertReadingQuery = insertReadingQuery + `
+	ON CONFLICT(timestamp) DO UPDATE SET
+		lux = excluded.lux,
+		source = excluded.source,
+		temperature_c = excluded.temperature_c,
+		quality = excluded.quality
+	RETURNING id`
+
 // SaveReading stores a reading in SQLite
 func (r *ReadingRepository) SaveReading(ctx context.Context, reading *domain.LightReading) error {
-	query := `INSERT INTO light_readings (lux, timestamp) VALUES (?, ?)`
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
 
-	result, err := r.db.ExecContext(ctx, query, reading.Lux, reading.Timestamp)
+	result, err := r.db.ExecContext(ctx, insertReadingQuery, insertArgs(reading)...)
+	if isUniqueViolation(err) {
+		return domain.ErrDuplicateTimestamp
+	}
 	if err != nil {
 		return fmt.Errorf("failed to insert reading: %w", err)
 	}
@@ -54,17 +345,138 @@ func (r *ReadingRepository) SaveReading(ctx context.Context, reading *domain.Lig
 	}
 
 	reading.ID = id
+	reading.Source = sourceOrDefault(reading.Source)
 	return nil
 }
 
+// isUniqueViolation reports whether err is SQLite rejecting a second
+// reading at a timestamp
+func isUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+}
+
+// SaveReadings stores several readings in a single transaction
+func (r *ReadingRepository) SaveReadings(ctx context.Context, readings []*domain.LightReading) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, insertReadingQuery)
+	if err != nil {
+		return fmt.Errorf("failed to prepare insert: %w", err)
+	}
+	defer stmt.Close()
+
+	ids := make([]int64, len(readings))
+	for i, reading := range readings {
+		result, err := stmt.ExecContext(ctx, insertArgs(reading)...)
+		if isUniqueViolation(err) {
+			return fmt.Errorf("reading %d: %w", i, domain.ErrDuplicateTimestamp)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to insert reading %d: %w", i, err)
+		}
+
+		ids[i], err = result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get insert id: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit readings: %w", err)
+	}
+
+	// Only expose IDs once the rows are actually committed
+	for i, reading := range readings {
+		reading.ID = ids[i]
+		reading.Source = sourceOrDefault(reading.Source)
+	}
+	return nil
+}
+
+// UpsertReading stores a reading, replacing any row with the same timestamp
+func (r *ReadingRepository) UpsertReading(ctx context.Context, reading *domain.LightReading) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	// LastInsertId is not meaningful when the conflict path updates, so
+	// read the affected row's ID back with RETURNING
+	var id int64
+	if err := r.db.QueryRowContext(ctx, upsertReadingQuery, insertArgs(reading)...).Scan(&id); err != nil {
+		return fmt.Errorf("failed to upsert reading: %w", err)
+	}
+
+	reading.ID = id
+	reading.Source = sourceOrDefault(reading.Source)
+	return nil
+}
+
+// UpsertReadings upserts several readings in a single transaction
+func (r *ReadingRepository) UpsertReadings(ctx context.Context, readings []*domain.LightReading) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, upsertReadingQuery)
+	if err != nil {
+		return fmt.Errorf("failed to prepare upsert: %w", err)
+	}
+	defer stmt.Close()
+
+	ids := make([]int64, len(readings))
+	for i, reading := range readings {
+		if err := stmt.QueryRowContext(ctx, insertArgs(reading)...).Scan(&ids[i]); err != nil {
+			return fmt.Errorf("failed to upsert reading %d: %w", i, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit readings: %w", err)
+	}
+
+	for i, reading := range readings {
+		reading.ID = ids[i]
+		reading.Source = sourceOrDefault(reading.Source)
+	}
+	return nil
+}
+
+// qualityOrDefault treats an unset quality as ok
+func qualityOrDefault(quality domain.Quality) domain.Quality {
+	if quality == "" {
+		return domain.QualityOK
+	}
+	return quality
+}
+
+// sourceOrDefault treats an unset source as a sensor reading
+func sourceOrDefault(source domain.Source) domain.Source {
+	if source == "" {
+		return domain.SourceSensor
+	}
+	return source
+}
+
 // GetReading retrieves a reading by ID
 func (r *ReadingRepository) GetReading(ctx context.Context, id int64) (*domain.LightReading, error) {
-	query := `SELECT id, lux, timestamp FROM light_readings WHERE id = ?`
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
 
-	var reading domain.LightReading
-	var timestamp string
+	query := `SELECT ` + readingColumns + ` FROM light_readings WHERE id = ?`
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(&reading.ID, &reading.Lux, &timestamp)
+	reading, err := scanReading(r.db.QueryRowContext(ctx, query, id))
 	if err == sql.ErrNoRows {
 		return nil, domain.ErrReadingNotFound
 	}
@@ -72,62 +484,388 @@ func (r *ReadingRepository) GetReading(ctx context.Context, id int64) (*domain.L
 		return nil, fmt.Errorf("failed to query reading: %w", err)
 	}
 
-	reading.Timestamp, err = time.Parse("2006-01-02 15:04:05", timestamp)
+	return reading, nil
+}
+
+// maxIDsPerQuery bounds the IN list GetReadingsByIDs sends in one query,
+// keeping well under SQLite's limit on bound parameters
+const maxIDsPerQuery = 500
+
+// GetReadingsByIDs fetches the readings maxIDsPerQuery IDs at a time
+func (r *ReadingRepository) GetReadingsByIDs(ctx context.Context, ids []int64) ([]*domain.LightReading, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	found := make(map[int64]*domain.LightReading, len(ids))
+	for chunk := range slices.Chunk(ids, maxIDsPerQuery) {
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(chunk)), ",")
+		args := make([]any, len(chunk))
+		for i, id := range chunk {
+			args[i] = id
+		}
+
+		query := `SELECT ` + readingColumns + ` FROM light_readings WHERE id IN (` + placeholders + `)`
+		rows, err := r.db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query readings: %w", err)
+		}
+		for rows.Next() {
+			reading, err := scanReading(rows)
+			if err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to scan reading: %w", err)
+			}
+			found[reading.ID] = reading
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate readings: %w", err)
+		}
+	}
+
+	// Return them in the requested order, each once
+	results := make([]*domain.LightReading, 0, len(found))
+	for _, id := range ids {
+		if reading, ok := found[id]; ok {
+			results = append(results, reading)
+			delete(found, id)
+		}
+	}
+	return results, nil
+}
+
+// GetReadingsInRange returns the readings within time range, paged and
+// ordered by opts
+func (r *ReadingRepository) GetReadingsInRange(ctx context.Context, start, end time.Time, opts ...domain.RangeOption) ([]*domain.LightReading, error) {
+	return r.queryRange(ctx, start, end, domain.NewRangeQuery(opts...), "", nil)
+}
+
+// queryRange returns the readings in [start, end) matching the SQL filter
+// (none if empty), ordered and paged by q
+func (r *ReadingRepository) queryRange(ctx context.Context, start, end time.Time, q domain.RangeQuery, filter string, filterArgs []any) ([]*domain.LightReading, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	order, past := "ASC", ">"
+	if q.Descending {
+		order, past = "DESC", "<"
+	}
+
+	where := "timestamp >= ? AND timestamp < ?"
+	args := []any{toEpoch(start), toEpoch(end)}
+	if filter != "" {
+		where += " AND (" + filter + ")"
+		args = append(args, filterArgs...)
+	}
+	if q.After != nil {
+		where += " AND (timestamp " + past + " ? OR (timestamp = ? AND id " + past + " ?))"
+		args = append(args, toEpoch(q.After.Timestamp), toEpoch(q.After.Timestamp), q.After.ID)
+	}
+
+	query := `
+		SELECT ` + readingColumns + `
+		FROM light_readings
+		WHERE ` + where + `
+		ORDER BY timestamp ` + order + `, id ` + order
+	if q.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, q.Limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse timestamp: %w", err)
+		return nil, fmt.Errorf("failed to query readings: %w", err)
 	}
+	defer rows.Close()
 
-	return &reading, nil
+	var readings []*domain.LightReading
+	for rows.Next() {
+		reading, err := scanReading(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reading: %w", err)
+		}
+
+		readings = append(readings, reading)
+	}
+
+	return readings, nil
 }
 
-// GetReadingsInRange returns all readings within time range
-func (r *ReadingRepository) GetReadingsInRange(ctx context.Context, start, end time.Time) ([]*domain.LightReading, error) {
+// AggregateReadingsInRange summarizes the readings in [start, end) per
+// bucket, grouping in SQL so only one row per bucket leaves the database
+func (r *ReadingRepository) AggregateReadingsInRange(ctx context.Context, start, end time.Time, interval time.Duration) ([]domain.ReadingBucket, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	if interval < time.Second {
+		return nil, fmt.Errorf("aggregation interval must be at least a second")
+	}
+	width := int64(interval.Truncate(time.Second))
+
+	// Readings are all after the epoch, so integer division floors
 	query := `
-		SELECT id, lux, timestamp 
-		FROM light_readings 
+		SELECT timestamp / ? AS bucket,
+			COUNT(*), AVG(lux), MIN(lux), MAX(lux)
+		FROM light_readings
 		WHERE timestamp >= ? AND timestamp < ?
-		ORDER BY timestamp ASC
+		GROUP BY bucket
+		ORDER BY bucket ASC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, start.Format("2006-01-02 15:04:05"), end.Format("2006-01-02 15:04:05"))
+	rows, err := r.db.QueryContext(ctx, query, width, toEpoch(start), toEpoch(end))
 	if err != nil {
-		return nil, fmt.Errorf("failed to query readings: %w", err)
+		return nil, fmt.Errorf("failed to aggregate readings: %w", err)
 	}
 	defer rows.Close()
 
-	var readings []*domain.LightReading
+	var buckets []domain.ReadingBucket
 	for rows.Next() {
-		var reading domain.LightReading
-		var timestamp string
+		var key int64
+		var b domain.ReadingBucket
+		if err := rows.Scan(&key, &b.Count, &b.AverageLux, &b.MinLux, &b.MaxLux); err != nil {
+			return nil, fmt.Errorf("failed to scan bucket: %w", err)
+		}
+		b.Start = fromEpoch(key * width)
+		buckets = append(buckets, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate buckets: %w", err)
+	}
 
-		if err := rows.Scan(&reading.ID, &reading.Lux, &timestamp); err != nil {
+	return buckets, nil
+}
+
+// GetStatisticsInRange summarizes the readings in [start, end) in SQL. The
+// moments come from one query; the median and 95th percentile then fetch
+// only the two readings either side of their rank. A read transaction keeps
+// the queries on one snapshot while the recorder writes.
+func (r *ReadingRepository) GetStatisticsInRange(ctx context.Context, start, end time.Time) (domain.LuxStatistics, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return domain.LuxStatistics{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// SQLite has no stddev(); average the squared deviations from the mean
+	// instead, and take the root here
+	query := `
+		SELECT COUNT(*),
+			COALESCE(AVG(lux), 0), COALESCE(MIN(lux), 0), COALESCE(MAX(lux), 0),
+			COALESCE(AVG((lux - mean) * (lux - mean)), 0)
+		FROM light_readings,
+			(SELECT AVG(lux) AS mean FROM light_readings WHERE timestamp >= ?1 AND timestamp < ?2)
+		WHERE timestamp >= ?1 AND timestamp < ?2
+	`
+
+	var stats domain.LuxStatistics
+	var variance float64
+	from, to := toEpoch(start), toEpoch(end)
+	err = tx.QueryRowContext(ctx, query, from, to).Scan(&stats.Count, &stats.AverageLux, &stats.MinLux, &stats.MaxLux, &variance)
+	if err != nil {
+		return domain.LuxStatistics{}, fmt.Errorf("failed to query statistics: %w", err)
+	}
+	if stats.Count == 0 {
+		return stats, nil
+	}
+	stats.StdDevLux = math.Sqrt(variance)
+
+	for _, p := range []struct {
+		percentile float64
+		dest       *float64
+	}{
+		{50, &stats.MedianLux},
+		{95, &stats.P95Lux},
+	} {
+		k, f := domain.PercentileRank(int(stats.Count), p.percentile)
+		rows, err := tx.QueryContext(ctx, `
+			SELECT lux FROM light_readings
+			WHERE timestamp >= ? AND timestamp < ?
+			ORDER BY lux ASC
+			LIMIT 2 OFFSET ?
+		`, from, to, k)
+		if err != nil {
+			return domain.LuxStatistics{}, fmt.Errorf("failed to query percentile: %w", err)
+		}
+		var nearest []float64
+		for rows.Next() {
+			var lux float64
+			if err := rows.Scan(&lux); err != nil {
+				rows.Close()
+				return domain.LuxStatistics{}, fmt.Errorf("failed to scan percentile: %w", err)
+			}
+			nearest = append(nearest, lux)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return domain.LuxStatistics{}, fmt.Errorf("failed to iterate percentile: %w", err)
+		}
+		if len(nearest) == 0 {
+			return domain.LuxStatistics{}, fmt.Errorf("no reading at rank %d of %d", k, stats.Count)
+		}
+		lo, hi := nearest[0], nearest[len(nearest)-1]
+		*p.dest = lo + f*(hi-lo)
+	}
+
+	return stats, nil
+}
+
+// GetReadingsInCategories returns readings in [start, end) whose level under
+// scheme is one of categories, matching on the lux range of each level in SQL
+func (r *ReadingRepository) GetReadingsInCategories(ctx context.Context, start, end time.Time, scheme *domain.CategoryScheme, categories []domain.Category, opts ...domain.RangeOption) ([]*domain.LightReading, error) {
+	if len(categories) == 0 {
+		return nil, nil
+	}
+
+	var args []any
+	ranges := make([]string, 0, len(categories))
+	for _, c := range categories {
+		lo, hi := scheme.LuxRange(c)
+		if math.IsInf(hi, 1) {
+			ranges = append(ranges, "lux >= ?")
+			args = append(args, lo)
+		} else {
+			ranges = append(ranges, "(lux >= ? AND lux < ?)")
+			args = append(args, lo, hi)
+		}
+	}
+	return r.queryRange(ctx, start, end, domain.NewRangeQuery(opts...), strings.Join(ranges, " OR "), args)
+}
+
+// recordingDayBucket is the granularity GetRecordingDays fetches from SQLite.
+// Every UTC offset in use is a whole number of quarter hours, so a bucket
+// never straddles local midnight in any zone, across DST changes included.
+const recordingDayBucket = int64(15 * time.Minute)
+
+// GetRecordingDays returns the local days in [start, end) with readings.
+// SQLite's date() only knows UTC (or a fixed offset), so the query returns
+// distinct quarter-hour buckets and they are mapped to days in loc here.
+func (r *ReadingRepository) GetRecordingDays(ctx context.Context, start, end time.Time, loc *time.Location) ([]time.Time, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT DISTINCT timestamp / ?
+		FROM light_readings
+		WHERE timestamp >= ? AND timestamp < ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, recordingDayBucket, toEpoch(start), toEpoch(end))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recording days: %w", err)
+	}
+	defer rows.Close()
+
+	seen := make(map[time.Time]bool)
+	var days []time.Time
+	for rows.Next() {
+		var bucket int64
+		if err := rows.Scan(&bucket); err != nil {
+			return nil, fmt.Errorf("failed to scan recording day: %w", err)
+		}
+		day := domain.CalendarDay(fromEpoch(bucket*recordingDayBucket), loc)
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate recording days: %w", err)
+	}
+
+	slices.SortFunc(days, func(a, b time.Time) int { return a.Compare(b) })
+	return days, nil
+}
+
+// ListReadings returns the page of readings after the cursor
+func (r *ReadingRepository) ListReadings(ctx context.Context, after domain.ReadingCursor, limit int) ([]*domain.LightReading, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + readingColumns + `
+		FROM light_readings
+		WHERE timestamp > ? OR (timestamp = ? AND id > ?)
+		ORDER BY timestamp ASC, id ASC
+		LIMIT ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, toEpoch(after.Timestamp), toEpoch(after.Timestamp), after.ID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list readings: %w", err)
+	}
+	defer rows.Close()
+
+	var readings []*domain.LightReading
+	for rows.Next() {
+		reading, err := scanReading(rows)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan reading: %w", err)
 		}
 
-		reading.Timestamp, err = time.Parse("2006-01-02 15:04:05", timestamp)
+		readings = append(readings, reading)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate readings: %w", err)
+	}
+
+	return readings, nil
+}
+
+// GetRecentReadings returns the newest readings, oldest first
+func (r *ReadingRepository) GetRecentReadings(ctx context.Context, limit int) ([]*domain.LightReading, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + readingColumns + `
+		FROM light_readings
+		ORDER BY timestamp DESC
+		LIMIT ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recent readings: %w", err)
+	}
+	defer rows.Close()
+
+	var readings []*domain.LightReading
+	for rows.Next() {
+		reading, err := scanReading(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse timestamp: %w", err)
+			return nil, fmt.Errorf("failed to scan reading: %w", err)
 		}
 
-		readings = append(readings, &reading)
+		readings = append(readings, reading)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate readings: %w", err)
 	}
 
+	// Newest-first from the query; callers want chronological order
+	slices.Reverse(readings)
+
 	return readings, nil
 }
 
 // GetLatestReading returns the most recent reading
 func (r *ReadingRepository) GetLatestReading(ctx context.Context) (*domain.LightReading, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
 	query := `
-		SELECT id, lux, timestamp 
+		SELECT ` + readingColumns + `
 		FROM light_readings 
 		ORDER BY timestamp DESC 
 		LIMIT 1
 	`
 
-	var reading domain.LightReading
-	var timestamp string
-
-	err := r.db.QueryRowContext(ctx, query).Scan(&reading.ID, &reading.Lux, &timestamp)
+	reading, err := scanReading(r.db.QueryRowContext(ctx, query))
 	if err == sql.ErrNoRows {
 		return nil, domain.ErrReadingNotFound
 	}
@@ -135,25 +873,189 @@ func (r *ReadingRepository) GetLatestReading(ctx context.Context) (*domain.Light
 		return nil, fmt.Errorf("failed to query latest reading: %w", err)
 	}
 
-	reading.Timestamp, err = time.Parse("2006-01-02 15:04:05", timestamp)
+	return reading, nil
+}
+
+// GetReadingAsOf returns the latest reading at or before the given time
+func (r *ReadingRepository) GetReadingAsOf(ctx context.Context, at time.Time) (*domain.LightReading, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + readingColumns + `
+		FROM light_readings
+		WHERE timestamp <= ?
+		ORDER BY timestamp DESC
+		LIMIT 1
+	`
+
+	reading, err := scanReading(r.db.QueryRowContext(ctx, query, toEpoch(at)))
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrReadingNotFound
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse timestamp: %w", err)
+		return nil, fmt.Errorf("failed to query reading as of %s: %w", at, err)
 	}
 
-	return &reading, nil
+	return reading, nil
+}
+
+const insertCategoryEventQuery = `INSERT INTO category_events (from_category, to_category, lux, timestamp) VALUES (?, ?, ?, ?)`
+
+// SaveCategoryEvent stores a category transition
+func (r *ReadingRepository) SaveCategoryEvent(ctx context.Context, event *domain.CategoryEvent) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, insertCategoryEventQuery, int(event.From), int(event.To), event.Lux, toEpoch(event.Timestamp))
+	if err != nil {
+		return fmt.Errorf("failed to insert category event: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get insert id: %w", err)
+	}
+
+	event.ID = id
+	return nil
+}
+
+// ReplaceCategoryEvents swaps the stored category transitions in
+// [start, end) for events in one transaction, so readers see either the
+// old set or the new one
+func (r *ReadingRepository) ReplaceCategoryEvents(ctx context.Context, start, end time.Time, events []*domain.CategoryEvent) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM category_events WHERE timestamp >= ? AND timestamp < ?`, toEpoch(start), toEpoch(end)); err != nil {
+		return fmt.Errorf("failed to clear category events: %w", err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, insertCategoryEventQuery)
+	if err != nil {
+		return fmt.Errorf("failed to prepare insert: %w", err)
+	}
+	defer stmt.Close()
+
+	ids := make([]int64, len(events))
+	for i, event := range events {
+		result, err := stmt.ExecContext(ctx, int(event.From), int(event.To), event.Lux, toEpoch(event.Timestamp))
+		if err != nil {
+			return fmt.Errorf("failed to insert category event %d: %w", i, err)
+		}
+
+		ids[i], err = result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get insert id: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit category events: %w", err)
+	}
+
+	for i, event := range events {
+		event.ID = ids[i]
+	}
+	return nil
+}
+
+// GetCategoryEvents returns category transitions within the time range
+func (r *ReadingRepository) GetCategoryEvents(ctx context.Context, start, end time.Time) ([]*domain.CategoryEvent, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT id, from_category, to_category, lux, timestamp
+		FROM category_events
+		WHERE timestamp >= ? AND timestamp < ?
+		ORDER BY timestamp ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, toEpoch(start), toEpoch(end))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query category events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*domain.CategoryEvent
+	for rows.Next() {
+		var event domain.CategoryEvent
+		var from, to int
+		var timestamp int64
+		if err := rows.Scan(&event.ID, &from, &to, &event.Lux, &timestamp); err != nil {
+			return nil, fmt.Errorf("failed to scan category event: %w", err)
+		}
+		event.Timestamp = fromEpoch(timestamp)
+		event.From = domain.Category(from)
+		event.To = domain.Category(to)
+
+		events = append(events, &event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate category events: %w", err)
+	}
+
+	return events, nil
+}
+
+// Stats reports the reading count, time span and database size
+func (r *ReadingRepository) Stats(ctx context.Context) (*domain.StorageStats, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var stats domain.StorageStats
+
+	var oldest, newest sql.NullInt64
+	query := `SELECT COUNT(*), MIN(timestamp), MAX(timestamp) FROM light_readings`
+	if err := r.db.QueryRowContext(ctx, query).Scan(&stats.ReadingCount, &oldest, &newest); err != nil {
+		return nil, fmt.Errorf("failed to count readings: %w", err)
+	}
+	if oldest.Valid {
+		stats.Oldest = fromEpoch(oldest.Int64)
+		stats.Newest = fromEpoch(newest.Int64)
+	}
+
+	// page_count * page_size is the main database file; it excludes any
+	// not-yet-checkpointed WAL, which stays small with autocheckpointing
+	var pageCount, pageSize int64
+	if err := r.db.QueryRowContext(ctx, `PRAGMA page_count`).Scan(&pageCount); err != nil {
+		return nil, fmt.Errorf("failed to query page count: %w", err)
+	}
+	if err := r.db.QueryRowContext(ctx, `PRAGMA page_size`).Scan(&pageSize); err != nil {
+		return nil, fmt.Errorf("failed to query page size: %w", err)
+	}
+	stats.SizeBytes = pageCount * pageSize
+
+	return &stats, nil
 }
 
 // DeleteOldReadings removes readings older than specified duration
-func (r *ReadingRepository) DeleteOldReadings(ctx context.Context, olderThan time.Duration) error {
+func (r *ReadingRepository) DeleteOldReadings(ctx context.Context, olderThan time.Duration) (int64, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
 	cutoff := time.Now().Add(-olderThan)
 	query := `DELETE FROM light_readings WHERE timestamp < ?`
 
-	_, err := r.db.ExecContext(ctx, query, cutoff.Format("2006-01-02 15:04:05"))
+	result, err := r.db.ExecContext(ctx, query, toEpoch(cutoff))
 	if err != nil {
-		return fmt.Errorf("failed to delete old readings: %w", err)
+		return 0, fmt.Errorf("failed to delete old readings: %w", err)
 	}
 
-	return nil
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count deleted readings: %w", err)
+	}
+
+	return deleted, nil
 }
 
 // Close closes the database connection
@@ -2,7 +2,14 @@ package sqlite
 
 import (
 	"context"
+	"errors"
+	"io/fs"
+	"math"
+	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -129,6 +136,44 @@ func TestGetReadingsInRange_ExclusiveEnd(t *testing.T) {
 	}
 }
 
+func TestGetReadingsInRange_ZoneIndependent(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+
+	// Half-hourly readings across the 2024 spring-forward, written in New
+	// York time: 01:30 EST is followed by 03:00 EDT
+	base := time.Date(2024, 3, 10, 6, 0, 0, 0, time.UTC)
+	for i := 0; i < 4; i++ {
+		r, _ := domain.NewLightReading(float64(100 + i))
+		r.Timestamp = base.Add(time.Duration(i) * 30 * time.Minute).In(ny)
+		if err := repo.SaveReading(ctx, r); err != nil {
+			t.Fatalf("SaveReading failed: %v", err)
+		}
+	}
+
+	// The same instants, however the bounds are zoned, select the same rows
+	for _, loc := range []*time.Location{time.UTC, ny, time.FixedZone("UTC+13", 13*3600)} {
+		start, end := base.Add(30*time.Minute).In(loc), base.Add(90*time.Minute).In(loc)
+		results, err := repo.GetReadingsInRange(ctx, start, end)
+		if err != nil {
+			t.Fatalf("GetReadingsInRange failed: %v", err)
+		}
+		if len(results) != 2 || results[0].Lux != 101 || results[1].Lux != 102 {
+			t.Errorf("bounds in %s: expected the 2 readings across the DST change, got %d", loc, len(results))
+		}
+		for _, r := range results {
+			if r.Timestamp.Location() != time.UTC {
+				t.Errorf("expected timestamps returned in UTC, got %s", r.Timestamp.Location())
+			}
+		}
+	}
+}
+
 func TestDeleteOldReadings(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
@@ -146,12 +191,16 @@ func TestDeleteOldReadings(t *testing.T) {
 	_ = repo.SaveReading(ctx, old)
 	_ = repo.SaveReading(ctx, recent)
 
-	if err := repo.DeleteOldReadings(ctx, 24*time.Hour); err != nil {
+	deleted, err := repo.DeleteOldReadings(ctx, 24*time.Hour)
+	if err != nil {
 		t.Fatalf("DeleteOldReadings failed: %v", err)
 	}
+	if deleted != 1 {
+		t.Errorf("expected 1 deleted reading, got %d", deleted)
+	}
 
 	// Old reading should be gone
-	_, err := repo.GetReading(ctx, old.ID)
+	_, err = repo.GetReading(ctx, old.ID)
 	if err != domain.ErrReadingNotFound {
 		t.Errorf("expected old reading to be deleted, got err: %v", err)
 	}
@@ -162,3 +211,1049 @@ func TestDeleteOldReadings(t *testing.T) {
 		t.Errorf("expected recent reading to remain, got err: %v", err)
 	}
 }
+
+func TestSaveReading_PersistsSource(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	sensorReading, _ := domain.NewLightReading(300)
+	manual, _ := domain.NewLightReading(400)
+	manual.Source = domain.SourceManual
+
+	_ = repo.SaveReading(ctx, sensorReading)
+	_ = repo.SaveReading(ctx, manual)
+
+	got, err := repo.GetReading(ctx, sensorReading.ID)
+	if err != nil {
+		t.Fatalf("GetReading failed: %v", err)
+	}
+	if got.Source != domain.SourceSensor {
+		t.Errorf("expected source %q, got %q", domain.SourceSensor, got.Source)
+	}
+
+	got, err = repo.GetReading(ctx, manual.ID)
+	if err != nil {
+		t.Fatalf("GetReading failed: %v", err)
+	}
+	if got.Source != domain.SourceManual {
+		t.Errorf("expected source %q, got %q", domain.SourceManual, got.Source)
+	}
+}
+
+func TestNewReadingRepository_AppliesPragmas(t *testing.T) {
+	repo := newTestRepo(t)
+
+	var journalMode string
+	if err := repo.db.QueryRow("PRAGMA journal_mode").Scan(&journalMode); err != nil {
+		t.Fatalf("query journal_mode: %v", err)
+	}
+	if journalMode != "wal" {
+		t.Errorf("expected journal_mode wal, got %q", journalMode)
+	}
+
+	var busyTimeout int
+	if err := repo.db.QueryRow("PRAGMA busy_timeout").Scan(&busyTimeout); err != nil {
+		t.Fatalf("query busy_timeout: %v", err)
+	}
+	if busyTimeout != 5000 {
+		t.Errorf("expected busy_timeout 5000, got %d", busyTimeout)
+	}
+
+	// NORMAL is reported as 1
+	var synchronous int
+	if err := repo.db.QueryRow("PRAGMA synchronous").Scan(&synchronous); err != nil {
+		t.Fatalf("query synchronous: %v", err)
+	}
+	if synchronous != 1 {
+		t.Errorf("expected synchronous 1 (NORMAL), got %d", synchronous)
+	}
+}
+
+func TestNewReadingRepository_CustomPragmas(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	repo, err := NewReadingRepository(dbPath,
+		WithJournalMode("delete"),
+		WithBusyTimeout(250*time.Millisecond),
+		WithSynchronous("full"),
+		WithMaxOpenConns(1),
+	)
+	if err != nil {
+		t.Fatalf("failed to create SQLite repo: %v", err)
+	}
+	defer repo.Close()
+
+	var journalMode string
+	if err := repo.db.QueryRow("PRAGMA journal_mode").Scan(&journalMode); err != nil {
+		t.Fatalf("query journal_mode: %v", err)
+	}
+	if journalMode != "delete" {
+		t.Errorf("expected journal_mode delete, got %q", journalMode)
+	}
+
+	var busyTimeout int
+	if err := repo.db.QueryRow("PRAGMA busy_timeout").Scan(&busyTimeout); err != nil {
+		t.Fatalf("query busy_timeout: %v", err)
+	}
+	if busyTimeout != 250 {
+		t.Errorf("expected busy_timeout 250, got %d", busyTimeout)
+	}
+
+	if got := repo.db.Stats().MaxOpenConnections; got != 1 {
+		t.Errorf("expected max open connections 1, got %d", got)
+	}
+}
+
+func TestNewReadingRepository_PoolSettings(t *testing.T) {
+	repo := newTestRepo(t)
+	if got := repo.db.Stats().MaxOpenConnections; got != 1 {
+		t.Errorf("expected one connection by default, got %d", got)
+	}
+
+	repo, err := NewReadingRepository(filepath.Join(t.TempDir(), "pool.db"),
+		WithMaxOpenConns(3),
+		WithMaxIdleConns(0),
+		WithConnMaxLifetime(time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("failed to create SQLite repo: %v", err)
+	}
+	defer repo.Close()
+
+	if got := repo.db.Stats().MaxOpenConnections; got != 3 {
+		t.Errorf("expected max open connections 3, got %d", got)
+	}
+
+	// With no idle connections allowed, each one is closed once released
+	ctx := context.Background()
+	if _, err := repo.Stats(ctx); err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	if stats := repo.db.Stats(); stats.Idle != 0 || stats.MaxIdleClosed == 0 {
+		t.Errorf("expected released connections closed, got %d idle and %d closed", stats.Idle, stats.MaxIdleClosed)
+	}
+}
+
+func TestQueryTimeout(t *testing.T) {
+	repo, err := NewReadingRepository(filepath.Join(t.TempDir(), "timeout.db"), WithQueryTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("failed to create SQLite repo: %v", err)
+	}
+	defer repo.Close()
+
+	// Hold the pool's only connection, so the next call waits for it
+	conn, err := repo.db.Conn(context.Background())
+	if err != nil {
+		t.Fatalf("failed to take connection: %v", err)
+	}
+	defer conn.Close()
+
+	start := time.Now()
+	_, err = repo.GetLatestReading(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected a deadline error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected the call to give up after the query timeout, took %v", elapsed)
+	}
+}
+
+func TestNewReadingRepository_InvalidOptions(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	cases := []struct {
+		name string
+		opt  Option
+	}{
+		{"bad journal mode", WithJournalMode("sideways")},
+		{"bad synchronous", WithSynchronous("sometimes")},
+		{"negative busy timeout", WithBusyTimeout(-time.Second)},
+		{"zero max conns", WithMaxOpenConns(0)},
+		{"negative idle conns", WithMaxIdleConns(-1)},
+		{"negative lifetime", WithConnMaxLifetime(-time.Second)},
+		{"negative query timeout", WithQueryTimeout(-time.Second)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewReadingRepository(dbPath, tc.opt); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestConcurrentWritesAndReads(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	const writers, readers, perWorker = 4, 4, 25
+
+	errs := make(chan error, (writers+readers)*perWorker)
+	var wg sync.WaitGroup
+
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				r, _ := domain.NewLightReading(float64(100 + i))
+				if err := repo.SaveReading(ctx, r); err != nil {
+					errs <- err
+				}
+			}
+		}()
+	}
+
+	for rd := 0; rd < readers; rd++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				now := time.Now()
+				if _, err := repo.GetReadingsInRange(ctx, now.Add(-time.Hour), now.Add(time.Hour)); err != nil {
+					errs <- err
+				}
+				if _, err := repo.GetLatestReading(ctx); err != nil && err != domain.ErrReadingNotFound {
+					errs <- err
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("unexpected error under concurrency: %v", err)
+	}
+
+	now := time.Now()
+	all, err := repo.GetReadingsInRange(ctx, now.Add(-time.Hour), now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GetReadingsInRange failed: %v", err)
+	}
+	if len(all) != writers*perWorker {
+		t.Errorf("expected %d readings, got %d", writers*perWorker, len(all))
+	}
+}
+
+func TestSaveReadings(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	var readings []*domain.LightReading
+	for _, lux := range []float64{100, 200, 300} {
+		r, _ := domain.NewLightReading(lux)
+		readings = append(readings, r)
+	}
+
+	if err := repo.SaveReadings(ctx, readings); err != nil {
+		t.Fatalf("SaveReadings failed: %v", err)
+	}
+
+	seen := make(map[int64]bool)
+	for _, r := range readings {
+		if r.ID == 0 {
+			t.Fatal("expected ID to be set after save")
+		}
+		if seen[r.ID] {
+			t.Fatalf("duplicate ID %d", r.ID)
+		}
+		seen[r.ID] = true
+
+		got, err := repo.GetReading(ctx, r.ID)
+		if err != nil {
+			t.Fatalf("GetReading(%d) failed: %v", r.ID, err)
+		}
+		if got.Lux != r.Lux {
+			t.Errorf("reading %d: got lux %v, want %v", r.ID, got.Lux, r.Lux)
+		}
+	}
+}
+
+func TestSaveReading_DuplicateTimestamp(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+	ts := time.Now().Add(-time.Minute)
+
+	first, _ := domain.NewLightReadingAt(100, ts)
+	if err := repo.SaveReading(ctx, first); err != nil {
+		t.Fatalf("SaveReading failed: %v", err)
+	}
+	second, _ := domain.NewLightReadingAt(200, ts)
+	if err := repo.SaveReading(ctx, second); !errors.Is(err, domain.ErrDuplicateTimestamp) {
+		t.Errorf("expected ErrDuplicateTimestamp, got %v", err)
+	}
+
+	// A batch with a clash stores none of its readings
+	other, _ := domain.NewLightReadingAt(300, ts.Add(time.Second))
+	clash, _ := domain.NewLightReadingAt(400, ts)
+	if err := repo.SaveReadings(ctx, []*domain.LightReading{other, clash}); !errors.Is(err, domain.ErrDuplicateTimestamp) {
+		t.Errorf("expected ErrDuplicateTimestamp for the batch, got %v", err)
+	}
+
+	readings, err := repo.GetRecentReadings(ctx, 10)
+	if err != nil || len(readings) != 1 || readings[0].Lux != 100 {
+		t.Errorf("expected only the first reading stored, got %v, %v", readings, err)
+	}
+}
+
+func TestSaveReading_OptionalTemperature(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	withTemp, _ := domain.NewLightReading(500)
+	if err := withTemp.SetTemperature(22.5); err != nil {
+		t.Fatalf("SetTemperature failed: %v", err)
+	}
+	withoutTemp, _ := domain.NewLightReading(600)
+
+	if err := repo.SaveReading(ctx, withTemp); err != nil {
+		t.Fatalf("SaveReading failed: %v", err)
+	}
+	if err := repo.SaveReading(ctx, withoutTemp); err != nil {
+		t.Fatalf("SaveReading failed: %v", err)
+	}
+
+	got, err := repo.GetReading(ctx, withTemp.ID)
+	if err != nil {
+		t.Fatalf("GetReading failed: %v", err)
+	}
+	if got.TemperatureC == nil || *got.TemperatureC != 22.5 {
+		t.Errorf("expected temperature 22.5, got %v", got.TemperatureC)
+	}
+
+	got, err = repo.GetReading(ctx, withoutTemp.ID)
+	if err != nil {
+		t.Fatalf("GetReading failed: %v", err)
+	}
+	if got.TemperatureC != nil {
+		t.Errorf("expected no temperature, got %v", *got.TemperatureC)
+	}
+}
+
+func TestGetRecentReadings(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	// Save out of order so the repository has to sort
+	for _, offset := range []int{3, 1, 4, 0, 2} {
+		r, _ := domain.NewLightReading(float64(100 * offset))
+		r.Timestamp = now.Add(-time.Duration(offset) * time.Minute)
+		_ = repo.SaveReading(ctx, r)
+	}
+
+	results, err := repo.GetRecentReadings(ctx, 3)
+	if err != nil {
+		t.Fatalf("GetRecentReadings failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 readings, got %d", len(results))
+	}
+
+	// The three newest (offsets 2, 1, 0), oldest first
+	for i, want := range []float64{200, 100, 0} {
+		if results[i].Lux != want {
+			t.Errorf("result %d: expected lux %v, got %v", i, want, results[i].Lux)
+		}
+	}
+
+	all, err := repo.GetRecentReadings(ctx, 50)
+	if err != nil {
+		t.Fatalf("GetRecentReadings failed: %v", err)
+	}
+	if len(all) != 5 {
+		t.Errorf("expected all 5 readings when limit exceeds count, got %d", len(all))
+	}
+}
+
+func TestUpsertReadings_ReimportReplaces(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	batch := func(lux float64) []*domain.LightReading {
+		readings := make([]*domain.LightReading, 3)
+		for i := range readings {
+			readings[i], _ = domain.NewLightReading(lux + float64(i))
+			readings[i].Timestamp = base.Add(time.Duration(i) * time.Minute)
+			readings[i].Source = domain.SourceImport
+		}
+		return readings
+	}
+	countRows := func() int {
+		t.Helper()
+		var n int
+		if err := repo.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM light_readings").Scan(&n); err != nil {
+			t.Fatalf("count failed: %v", err)
+		}
+		return n
+	}
+
+	first := batch(100)
+	if err := repo.UpsertReadings(ctx, first); err != nil {
+		t.Fatalf("first import failed: %v", err)
+	}
+
+	// Re-import the same timestamps with corrected values
+	second := batch(500)
+	if err := repo.UpsertReadings(ctx, second); err != nil {
+		t.Fatalf("re-import failed: %v", err)
+	}
+
+	if n := countRows(); n != 3 {
+		t.Errorf("expected re-import to keep 3 rows, got %d", n)
+	}
+	for i, r := range second {
+		if r.ID != first[i].ID {
+			t.Errorf("reading %d: expected ID %d to be reused, got %d", i, first[i].ID, r.ID)
+		}
+		stored, err := repo.GetReading(ctx, r.ID)
+		if err != nil {
+			t.Fatalf("GetReading failed: %v", err)
+		}
+		if stored.Lux != 500+float64(i) {
+			t.Errorf("reading %d: expected updated lux %v, got %v", i, 500+float64(i), stored.Lux)
+		}
+	}
+
+	// A single upsert at an existing timestamp also replaces
+	single, _ := domain.NewLightReading(42)
+	single.Timestamp = base
+	if err := repo.UpsertReading(ctx, single); err != nil {
+		t.Fatalf("UpsertReading failed: %v", err)
+	}
+	if single.ID != first[0].ID {
+		t.Errorf("expected UpsertReading to reuse ID %d, got %d", first[0].ID, single.ID)
+	}
+	if n := countRows(); n != 3 {
+		t.Errorf("expected 3 rows after single upsert, got %d", n)
+	}
+}
+
+func TestCategoryEvents(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	events := []*domain.CategoryEvent{
+		{From: domain.CategoryMedium, To: domain.CategoryHigh, Lux: 3000, Timestamp: base.Add(20 * time.Minute)},
+		{From: domain.CategoryLow, To: domain.CategoryMedium, Lux: 800, Timestamp: base.Add(10 * time.Minute)},
+		{From: domain.CategoryHigh, To: domain.CategoryLow, Lux: 50, Timestamp: base.Add(50 * time.Minute)},
+	}
+	for _, e := range events {
+		if err := repo.SaveCategoryEvent(ctx, e); err != nil {
+			t.Fatalf("SaveCategoryEvent failed: %v", err)
+		}
+		if e.ID == 0 {
+			t.Error("expected ID to be set after save")
+		}
+	}
+
+	got, err := repo.GetCategoryEvents(ctx, base, base.Add(30*time.Minute))
+	if err != nil {
+		t.Fatalf("GetCategoryEvents failed: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 events in range, got %d", len(got))
+	}
+	if got[0].To != domain.CategoryMedium || got[1].To != domain.CategoryHigh {
+		t.Errorf("expected events oldest first, got %v then %v", got[0].To, got[1].To)
+	}
+	if got[0].From != domain.CategoryLow || got[0].Lux != 800 {
+		t.Errorf("unexpected first event %+v", got[0])
+	}
+}
+
+func TestGetReadingAsOf(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	at := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for _, r := range []struct {
+		offset time.Duration
+		lux    float64
+	}{
+		{-10 * time.Minute, 100},
+		{-time.Minute, 200}, // latest at or before at
+		{time.Second, 300},
+		{10 * time.Minute, 400},
+	} {
+		reading, _ := domain.NewLightReading(r.lux)
+		reading.Timestamp = at.Add(r.offset)
+		_ = repo.SaveReading(ctx, reading)
+	}
+
+	got, err := repo.GetReadingAsOf(ctx, at)
+	if err != nil {
+		t.Fatalf("GetReadingAsOf failed: %v", err)
+	}
+	if got.Lux != 200 {
+		t.Errorf("expected the 200 lux reading before T, got %v", got.Lux)
+	}
+
+	// A reading exactly at T counts as current
+	exact, err := repo.GetReadingAsOf(ctx, at.Add(time.Second))
+	if err != nil {
+		t.Fatalf("GetReadingAsOf failed: %v", err)
+	}
+	if exact.Lux != 300 {
+		t.Errorf("expected the reading at exactly T (300 lux), got %v", exact.Lux)
+	}
+
+	if _, err := repo.GetReadingAsOf(ctx, at.Add(-time.Hour)); err != domain.ErrReadingNotFound {
+		t.Errorf("expected ErrReadingNotFound before any readings, got %v", err)
+	}
+}
+
+func TestStats(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	empty, err := repo.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	if empty.ReadingCount != 0 || !empty.Oldest.IsZero() {
+		t.Errorf("expected no readings, got %+v", empty)
+	}
+	if empty.SizeBytes <= 0 {
+		t.Errorf("expected a positive size for the schema alone, got %d", empty.SizeBytes)
+	}
+
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	readings := make([]*domain.LightReading, 2000)
+	for i := range readings {
+		readings[i], _ = domain.NewLightReading(float64(i))
+		readings[i].Timestamp = base.Add(time.Duration(i) * time.Second)
+	}
+	if err := repo.SaveReadings(ctx, readings); err != nil {
+		t.Fatalf("SaveReadings failed: %v", err)
+	}
+
+	stats, err := repo.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	if stats.ReadingCount != 2000 {
+		t.Errorf("expected 2000 readings, got %d", stats.ReadingCount)
+	}
+	if !stats.Oldest.Equal(base) || !stats.Newest.Equal(base.Add(1999*time.Second)) {
+		t.Errorf("unexpected span %v - %v", stats.Oldest, stats.Newest)
+	}
+	if stats.SizeBytes <= empty.SizeBytes {
+		t.Errorf("expected size to grow past %d bytes, got %d", empty.SizeBytes, stats.SizeBytes)
+	}
+}
+
+func TestNewReadingRepository_CreatesNestedDBPath(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "data", "light", "light.db")
+
+	repo, err := NewReadingRepository(dbPath)
+	if err != nil {
+		t.Fatalf("expected nested directories to be created, got %v", err)
+	}
+	defer repo.Close()
+
+	reading, _ := domain.NewLightReading(500)
+	if err := repo.SaveReading(context.Background(), reading); err != nil {
+		t.Fatalf("SaveReading failed: %v", err)
+	}
+	if _, err := os.Stat(dbPath); err != nil {
+		t.Errorf("expected database file at %s: %v", dbPath, err)
+	}
+}
+
+func TestNewReadingRepository_DBPathIsDirectory(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := NewReadingRepository(dir)
+	if err == nil || !strings.Contains(err.Error(), "is a directory") {
+		t.Errorf("expected a directory error, got %v", err)
+	}
+}
+
+func TestNewReadingRepository_ParentIsFile(t *testing.T) {
+	parent := filepath.Join(t.TempDir(), "not-a-dir")
+	if err := os.WriteFile(parent, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := NewReadingRepository(filepath.Join(parent, "light.db"))
+	if err == nil || !strings.Contains(err.Error(), "cannot create database directory") {
+		t.Errorf("expected a directory creation error, got %v", err)
+	}
+}
+
+func TestNewReadingRepository_DBPathPermissionDenied(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("root ignores directory permissions")
+	}
+
+	dir := filepath.Join(t.TempDir(), "readonly")
+	if err := os.Mkdir(dir, 0o500); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := NewReadingRepository(filepath.Join(dir, "light.db"))
+	if err == nil || !strings.Contains(err.Error(), "is not writable") || !errors.Is(err, fs.ErrPermission) {
+		t.Errorf("expected a helpful permission error, got %v", err)
+	}
+}
+
+func TestListReadings_PagesInTimestampOrder(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	// Saved out of order, so ID order differs from timestamp order
+	for _, offset := range []int{4, 0, 3, 1, 2} {
+		reading, _ := domain.NewLightReading(float64(100 * offset))
+		reading.Timestamp = base.Add(time.Duration(offset) * time.Minute)
+		if err := repo.SaveReading(ctx, reading); err != nil {
+			t.Fatalf("SaveReading failed: %v", err)
+		}
+	}
+
+	var got []float64
+	var cursor domain.ReadingCursor
+	for {
+		page, err := repo.ListReadings(ctx, cursor, 2)
+		if err != nil {
+			t.Fatalf("ListReadings failed: %v", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		for _, r := range page {
+			got = append(got, r.Lux)
+		}
+		cursor = domain.CursorAfter(page[len(page)-1])
+	}
+
+	want := []float64{0, 100, 200, 300, 400}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestGetReadingsInRange_Paged(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	for _, offset := range []int{4, 0, 3, 1, 2} {
+		reading, _ := domain.NewLightReading(float64(100 * offset))
+		reading.Timestamp = base.Add(time.Duration(offset) * time.Minute)
+		if err := repo.SaveReading(ctx, reading); err != nil {
+			t.Fatalf("SaveReading failed: %v", err)
+		}
+	}
+
+	for _, tt := range []struct {
+		name string
+		opts []domain.RangeOption
+		want []float64
+	}{
+		{"ascending", nil, []float64{0, 100, 200, 300, 400}},
+		{"descending", []domain.RangeOption{domain.WithDescending()}, []float64{400, 300, 200, 100, 0}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []float64
+			opts := append(tt.opts, domain.WithLimit(2))
+			for {
+				page, err := repo.GetReadingsInRange(ctx, base, base.Add(time.Hour), opts...)
+				if err != nil {
+					t.Fatalf("GetReadingsInRange failed: %v", err)
+				}
+				if len(page) == 0 {
+					break
+				}
+				for _, r := range page {
+					got = append(got, r.Lux)
+				}
+				opts = append(tt.opts, domain.WithLimit(2), domain.WithCursor(domain.CursorAfter(page[len(page)-1])))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAggregateReadingsInRange_MatchesGo(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	for i, lux := range []float64{100, 300, 200, 50, 400, 10, 20} {
+		reading, _ := domain.NewLightReading(lux)
+		reading.Timestamp = base.Add(time.Duration(i*25) * time.Minute)
+		if err := repo.SaveReading(ctx, reading); err != nil {
+			t.Fatalf("SaveReading failed: %v", err)
+		}
+	}
+
+	start, end := base.Add(-time.Hour), base.Add(24*time.Hour)
+	got, err := repo.AggregateReadingsInRange(ctx, start, end, time.Hour)
+	if err != nil {
+		t.Fatalf("AggregateReadingsInRange failed: %v", err)
+	}
+	readings, err := repo.GetReadingsInRange(ctx, start, end)
+	if err != nil {
+		t.Fatalf("GetReadingsInRange failed: %v", err)
+	}
+	want := domain.AggregateReadings(readings, time.Hour)
+
+	if len(got) != len(want) {
+		t.Fatalf("expected %d buckets, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if !got[i].Start.Equal(want[i].Start) || got[i].Count != want[i].Count ||
+			got[i].AverageLux != want[i].AverageLux || got[i].MinLux != want[i].MinLux || got[i].MaxLux != want[i].MaxLux {
+			t.Errorf("bucket %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if _, err := repo.AggregateReadingsInRange(ctx, start, end, time.Millisecond); err == nil {
+		t.Error("expected an error for a sub-second interval")
+	}
+}
+
+func TestGetStatisticsInRange_MatchesGo(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	for i, lux := range []float64{100, 300, 200, 50, 400, 10, 20, 1200, 75, 60, 35} {
+		reading, _ := domain.NewLightReading(lux)
+		reading.Timestamp = base.Add(time.Duration(i*25) * time.Minute)
+		if err := repo.SaveReading(ctx, reading); err != nil {
+			t.Fatalf("SaveReading failed: %v", err)
+		}
+	}
+
+	// The whole set, and a range that excludes the first and last readings
+	for _, r := range []struct{ start, end time.Time }{
+		{base.Add(-time.Hour), base.Add(24 * time.Hour)},
+		{base.Add(time.Minute), base.Add(250 * time.Minute)},
+	} {
+		got, err := repo.GetStatisticsInRange(ctx, r.start, r.end)
+		if err != nil {
+			t.Fatalf("GetStatisticsInRange failed: %v", err)
+		}
+		readings, err := repo.GetReadingsInRange(ctx, r.start, r.end)
+		if err != nil {
+			t.Fatalf("GetReadingsInRange failed: %v", err)
+		}
+		want := domain.SummarizeLux(readings)
+
+		if got.Count != want.Count || got.MinLux != want.MinLux || got.MaxLux != want.MaxLux ||
+			math.Abs(got.AverageLux-want.AverageLux) > 1e-9 || math.Abs(got.MedianLux-want.MedianLux) > 1e-9 ||
+			math.Abs(got.P95Lux-want.P95Lux) > 1e-9 || math.Abs(got.StdDevLux-want.StdDevLux) > 1e-9 {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	}
+
+	empty, err := repo.GetStatisticsInRange(ctx, base.Add(-2*time.Hour), base.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("GetStatisticsInRange failed on an empty range: %v", err)
+	}
+	if empty != (domain.LuxStatistics{}) {
+		t.Errorf("expected zero statistics for an empty range, got %+v", empty)
+	}
+}
+
+func TestGetReadingsInCategories(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	// Boundary values: 200 is medium, 2500 is high
+	for i, lux := range []float64{50, 199.9, 200, 800, 2499.9, 2500, 30000} {
+		reading, _ := domain.NewLightReading(lux)
+		reading.Timestamp = base.Add(time.Duration(i) * time.Minute)
+		if err := repo.SaveReading(ctx, reading); err != nil {
+			t.Fatalf("SaveReading failed: %v", err)
+		}
+	}
+
+	fiveLevels, err := domain.NewCategoryScheme([]string{"Dark", "Dim", "Moderate", "Bright", "Direct sun"}, []float64{10, 100, 1000, 10000})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		scheme     *domain.CategoryScheme
+		categories []domain.Category
+		want       []float64
+	}{
+		{"low", domain.DefaultCategoryScheme, []domain.Category{domain.CategoryLow}, []float64{50, 199.9}},
+		{"medium", domain.DefaultCategoryScheme, []domain.Category{domain.CategoryMedium}, []float64{200, 800, 2499.9}},
+		{"high", domain.DefaultCategoryScheme, []domain.Category{domain.CategoryHigh}, []float64{2500, 30000}},
+		{"low and high", domain.DefaultCategoryScheme, []domain.Category{domain.CategoryLow, domain.CategoryHigh}, []float64{50, 199.9, 2500, 30000}},
+		{"none", domain.DefaultCategoryScheme, nil, nil},
+		{"custom middle level", fiveLevels, []domain.Category{2}, []float64{199.9, 200, 800}},
+		{"custom top level", fiveLevels, []domain.Category{4}, []float64{30000}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readings, err := repo.GetReadingsInCategories(ctx, base, base.Add(time.Hour), tt.scheme, tt.categories)
+			if err != nil {
+				t.Fatalf("GetReadingsInCategories failed: %v", err)
+			}
+			if len(readings) != len(tt.want) {
+				t.Fatalf("expected %v, got %d readings", tt.want, len(readings))
+			}
+			for i, w := range tt.want {
+				if readings[i].Lux != w {
+					t.Errorf("reading %d: expected %v, got %v", i, w, readings[i].Lux)
+				}
+			}
+		})
+	}
+
+	// Paged like GetReadingsInRange, newest first
+	lowAndHigh := []domain.Category{domain.CategoryLow, domain.CategoryHigh}
+	page, err := repo.GetReadingsInCategories(ctx, base, base.Add(time.Hour), domain.DefaultCategoryScheme, lowAndHigh,
+		domain.WithDescending(), domain.WithLimit(2))
+	if err != nil {
+		t.Fatalf("GetReadingsInCategories failed: %v", err)
+	}
+	if len(page) != 2 || page[0].Lux != 30000 || page[1].Lux != 2500 {
+		t.Fatalf("expected 30000 then 2500, got %v", page)
+	}
+	page, err = repo.GetReadingsInCategories(ctx, base, base.Add(time.Hour), domain.DefaultCategoryScheme, lowAndHigh,
+		domain.WithDescending(), domain.WithLimit(2), domain.WithCursor(domain.CursorAfter(page[1])))
+	if err != nil {
+		t.Fatalf("GetReadingsInCategories failed: %v", err)
+	}
+	if len(page) != 2 || page[0].Lux != 199.9 || page[1].Lux != 50 {
+		t.Errorf("expected 199.9 then 50, got %v", page)
+	}
+}
+
+func TestGetRecordingDays_LocalDayBoundary(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	// UTC+5:30, so local midnight falls on a half hour in UTC
+	india := time.FixedZone("IST", 5*3600+30*60)
+	hawaii := time.FixedZone("HST", -10*3600)
+	for _, ts := range []time.Time{
+		time.Date(2024, 6, 1, 18, 29, 0, 0, time.UTC), // 23:59 on 1 June in India
+		time.Date(2024, 6, 1, 18, 31, 0, 0, time.UTC), // 00:01 on 2 June in India
+		time.Date(2024, 6, 2, 3, 0, 0, 0, time.UTC),   // 08:30 on 2 June in India
+		time.Date(2024, 6, 9, 12, 0, 0, 0, time.UTC),  // outside the range
+	} {
+		reading, _ := domain.NewLightReading(300)
+		reading.Timestamp = ts
+		if err := repo.SaveReading(ctx, reading); err != nil {
+			t.Fatalf("SaveReading failed: %v", err)
+		}
+	}
+
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+	tests := []struct {
+		name string
+		loc  *time.Location
+		want []time.Time
+	}{
+		{"India", india, []time.Time{
+			time.Date(2024, 6, 1, 0, 0, 0, 0, india),
+			time.Date(2024, 6, 2, 0, 0, 0, 0, india),
+		}},
+		{"UTC by default", nil, []time.Time{
+			time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC),
+		}},
+		{"behind UTC", hawaii, []time.Time{
+			time.Date(2024, 6, 1, 0, 0, 0, 0, hawaii),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			days, err := repo.GetRecordingDays(ctx, start, end, tt.loc)
+			if err != nil {
+				t.Fatalf("GetRecordingDays failed: %v", err)
+			}
+			if len(days) != len(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, days)
+			}
+			for i, w := range tt.want {
+				if !days[i].Equal(w) {
+					t.Errorf("day %d: expected %v, got %v", i, w, days[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGetReadingsByIDs(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	readings := make([]*domain.LightReading, 3)
+	for i := range readings {
+		readings[i], _ = domain.NewLightReading(float64(100 * (i + 1)))
+		readings[i].Timestamp = base.Add(time.Duration(i) * time.Minute)
+	}
+	if err := repo.SaveReadings(ctx, readings); err != nil {
+		t.Fatalf("SaveReadings failed: %v", err)
+	}
+
+	ids := []int64{readings[2].ID, 9999, readings[0].ID, readings[2].ID}
+	got, err := repo.GetReadingsByIDs(ctx, ids)
+	if err != nil {
+		t.Fatalf("GetReadingsByIDs failed: %v", err)
+	}
+	if len(got) != 2 || got[0].Lux != 300 || got[1].Lux != 100 {
+		t.Fatalf("expected readings 300 then 100, got %v", got)
+	}
+
+	none, err := repo.GetReadingsByIDs(ctx, nil)
+	if err != nil || len(none) != 0 {
+		t.Errorf("expected no readings for no ids, got %v, %v", none, err)
+	}
+}
+
+func TestGetReadingsByIDs_Chunked(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	// More readings than fit in one IN list, requested newest first with
+	// missing IDs mixed in
+	n := 2*maxIDsPerQuery + 10
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	readings := make([]*domain.LightReading, n)
+	for i := range readings {
+		readings[i], _ = domain.NewLightReading(float64(i))
+		readings[i].Timestamp = base.Add(time.Duration(i) * time.Second)
+	}
+	if err := repo.SaveReadings(ctx, readings); err != nil {
+		t.Fatalf("SaveReadings failed: %v", err)
+	}
+
+	var ids []int64
+	for i := n - 1; i >= 0; i-- {
+		ids = append(ids, readings[i].ID, -int64(i)-1)
+	}
+
+	got, err := repo.GetReadingsByIDs(ctx, ids)
+	if err != nil {
+		t.Fatalf("GetReadingsByIDs failed: %v", err)
+	}
+	if len(got) != n {
+		t.Fatalf("expected %d readings, got %d", n, len(got))
+	}
+	for i, r := range got {
+		if want := readings[n-1-i].ID; r.ID != want {
+			t.Fatalf("position %d: expected reading %d, got %d", i, want, r.ID)
+		}
+	}
+}
+
+func TestSaveReading_PersistsQuality(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	qualities := []domain.Quality{domain.QualityOK, domain.QualitySaturated, domain.QualityLowConfidence, ""}
+	readings := make([]*domain.LightReading, len(qualities))
+	for i, q := range qualities {
+		readings[i], _ = domain.NewLightReading(500)
+		readings[i].Timestamp = base.Add(time.Duration(i) * time.Minute)
+		readings[i].Quality = q
+	}
+	if err := repo.SaveReadings(ctx, readings); err != nil {
+		t.Fatalf("SaveReadings failed: %v", err)
+	}
+
+	want := []domain.Quality{domain.QualityOK, domain.QualitySaturated, domain.QualityLowConfidence, domain.QualityOK}
+	for i, w := range want {
+		got, err := repo.GetReading(ctx, readings[i].ID)
+		if err != nil {
+			t.Fatalf("GetReading failed: %v", err)
+		}
+		if got.Quality != w {
+			t.Errorf("reading %d: expected quality %q, got %q", i, w, got.Quality)
+		}
+	}
+}
+
+func TestReplaceCategoryEvents(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	old := &domain.CategoryEvent{From: domain.CategoryLow, To: domain.CategoryMedium, Lux: 800, Timestamp: base}
+	if err := repo.SaveCategoryEvent(ctx, old); err != nil {
+		t.Fatalf("SaveCategoryEvent failed: %v", err)
+	}
+	later := &domain.CategoryEvent{From: domain.CategoryMedium, To: domain.CategoryHigh, Lux: 3000, Timestamp: base.Add(time.Hour)}
+	if err := repo.SaveCategoryEvent(ctx, later); err != nil {
+		t.Fatalf("SaveCategoryEvent failed: %v", err)
+	}
+
+	replacements := []*domain.CategoryEvent{
+		{From: domain.CategoryLow, To: domain.CategoryMedium, Lux: 1500, Timestamp: base.Add(time.Minute)},
+		{From: domain.CategoryMedium, To: domain.CategoryLow, Lux: 50, Timestamp: base.Add(2 * time.Minute)},
+	}
+	if err := repo.ReplaceCategoryEvents(ctx, base, base.Add(time.Hour), replacements); err != nil {
+		t.Fatalf("ReplaceCategoryEvents failed: %v", err)
+	}
+	for _, e := range replacements {
+		if e.ID == 0 {
+			t.Error("expected ID to be set after replace")
+		}
+	}
+
+	// The event at the end of the range is outside it and kept
+	got, err := repo.GetCategoryEvents(ctx, time.Time{}, time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GetCategoryEvents failed: %v", err)
+	}
+	if len(got) != 3 || got[0].Lux != 1500 || got[1].Lux != 50 || got[2].ID != later.ID {
+		t.Errorf("expected the replacement events and the later one, got %+v", got)
+	}
+}
+
+func TestCalibration_SaveAndReplace(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	if _, err := repo.GetCalibration(ctx, "gpio"); !errors.Is(err, domain.ErrCalibrationNotFound) {
+		t.Fatalf("expected ErrCalibrationNotFound, got %v", err)
+	}
+
+	for _, scale := range []float64{1.25, 0.8} {
+		cal := &domain.Calibration{SensorID: "gpio", Scale: scale, OffsetLux: -12.5}
+		if err := repo.SaveCalibration(ctx, cal); err != nil {
+			t.Fatalf("SaveCalibration failed: %v", err)
+		}
+		if cal.UpdatedAt.IsZero() {
+			t.Error("expected UpdatedAt to be set")
+		}
+	}
+
+	got, err := repo.GetCalibration(ctx, "gpio")
+	if err != nil {
+		t.Fatalf("GetCalibration failed: %v", err)
+	}
+	if got.SensorID != "gpio" || got.Scale != 0.8 || got.OffsetLux != -12.5 || got.UpdatedAt.IsZero() {
+		t.Errorf("expected the second calibration, got %+v", got)
+	}
+	if _, err := repo.GetCalibration(ctx, "mock"); !errors.Is(err, domain.ErrCalibrationNotFound) {
+		t.Errorf("expected other sensors uncalibrated, got %v", err)
+	}
+}
@@ -0,0 +1,203 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// readingsKey is the span attribute counting readings written or returned
+const readingsKey = attribute.Key("plant_monitor.readings")
+
+// ReadingRepository decorates another repository, wrapping every call in a
+// client span named after the method
+type ReadingRepository struct {
+	inner  domain.ReadingRepository
+	system attribute.KeyValue
+}
+
+var _ domain.ReadingRepository = (*ReadingRepository)(nil)
+
+// NewReadingRepository wraps inner in spans. system names the store for the
+// db.system.name attribute, e.g. "sqlite" or "postgresql".
+func NewReadingRepository(inner domain.ReadingRepository, system string) *ReadingRepository {
+	return &ReadingRepository{inner: inner, system: semconv.DBSystemNameKey.String(system)}
+}
+
+// start opens the span for operation
+func (r *ReadingRepository) start(ctx context.Context, operation string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs, r.system, semconv.DBOperationName(operation))
+	return tracer.Start(ctx, "ReadingRepository."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// rangeAttrs describes a [start, end) query
+func rangeAttrs(start, end time.Time) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.Int64("plant_monitor.range.start_ms", start.UnixMilli()),
+		attribute.Int64("plant_monitor.range.end_ms", end.UnixMilli()),
+	}
+}
+
+// finishReadings records how many readings came back, then finishes span
+func finishReadings(span trace.Span, readings []*domain.LightReading, err error) {
+	span.SetAttributes(readingsKey.Int(len(readings)))
+	finish(span, err)
+}
+
+// SaveReading traces the wrapped repository's SaveReading
+func (r *ReadingRepository) SaveReading(ctx context.Context, reading *domain.LightReading) (err error) {
+	ctx, span := r.start(ctx, "SaveReading")
+	defer func() { finish(span, err) }()
+	return r.inner.SaveReading(ctx, reading)
+}
+
+// SaveReadings traces the wrapped repository's SaveReadings
+func (r *ReadingRepository) SaveReadings(ctx context.Context, readings []*domain.LightReading) (err error) {
+	ctx, span := r.start(ctx, "SaveReadings", readingsKey.Int(len(readings)))
+	defer func() { finish(span, err) }()
+	return r.inner.SaveReadings(ctx, readings)
+}
+
+// UpsertReading traces the wrapped repository's UpsertReading
+func (r *ReadingRepository) UpsertReading(ctx context.Context, reading *domain.LightReading) (err error) {
+	ctx, span := r.start(ctx, "UpsertReading")
+	defer func() { finish(span, err) }()
+	return r.inner.UpsertReading(ctx, reading)
+}
+
+// UpsertReadings traces the wrapped repository's UpsertReadings
+func (r *ReadingRepository) UpsertReadings(ctx context.Context, readings []*domain.LightReading) (err error) {
+	ctx, span := r.start(ctx, "UpsertReadings", readingsKey.Int(len(readings)))
+	defer func() { finish(span, err) }()
+	return r.inner.UpsertReadings(ctx, readings)
+}
+
+// GetReading traces the wrapped repository's GetReading
+func (r *ReadingRepository) GetReading(ctx context.Context, id int64) (_ *domain.LightReading, err error) {
+	ctx, span := r.start(ctx, "GetReading")
+	defer func() { finish(span, err) }()
+	return r.inner.GetReading(ctx, id)
+}
+
+// GetReadingsByIDs traces the wrapped repository's GetReadingsByIDs
+func (r *ReadingRepository) GetReadingsByIDs(ctx context.Context, ids []int64) (readings []*domain.LightReading, err error) {
+	ctx, span := r.start(ctx, "GetReadingsByIDs")
+	defer func() { finishReadings(span, readings, err) }()
+	return r.inner.GetReadingsByIDs(ctx, ids)
+}
+
+// GetReadingsInRange traces the wrapped repository's GetReadingsInRange
+func (r *ReadingRepository) GetReadingsInRange(ctx context.Context, start, end time.Time, opts ...domain.RangeOption) (readings []*domain.LightReading, err error) {
+	ctx, span := r.start(ctx, "GetReadingsInRange", rangeAttrs(start, end)...)
+	defer func() { finishReadings(span, readings, err) }()
+	return r.inner.GetReadingsInRange(ctx, start, end, opts...)
+}
+
+// GetReadingsInCategories traces the wrapped repository's GetReadingsInCategories
+func (r *ReadingRepository) GetReadingsInCategories(ctx context.Context, start, end time.Time, scheme *domain.CategoryScheme, categories []domain.Category, opts ...domain.RangeOption) (readings []*domain.LightReading, err error) {
+	ctx, span := r.start(ctx, "GetReadingsInCategories", rangeAttrs(start, end)...)
+	defer func() { finishReadings(span, readings, err) }()
+	return r.inner.GetReadingsInCategories(ctx, start, end, scheme, categories, opts...)
+}
+
+// AggregateReadingsInRange traces the wrapped repository's AggregateReadingsInRange
+func (r *ReadingRepository) AggregateReadingsInRange(ctx context.Context, start, end time.Time, interval time.Duration) (buckets []domain.ReadingBucket, err error) {
+	attrs := append(rangeAttrs(start, end), attribute.Int64("plant_monitor.interval_ms", interval.Milliseconds()))
+	ctx, span := r.start(ctx, "AggregateReadingsInRange", attrs...)
+	defer func() {
+		span.SetAttributes(attribute.Int("plant_monitor.buckets", len(buckets)))
+		finish(span, err)
+	}()
+	return r.inner.AggregateReadingsInRange(ctx, start, end, interval)
+}
+
+// GetStatisticsInRange traces the wrapped repository's GetStatisticsInRange
+func (r *ReadingRepository) GetStatisticsInRange(ctx context.Context, start, end time.Time) (stats domain.LuxStatistics, err error) {
+	ctx, span := r.start(ctx, "GetStatisticsInRange", rangeAttrs(start, end)...)
+	defer func() {
+		span.SetAttributes(readingsKey.Int64(stats.Count))
+		finish(span, err)
+	}()
+	return r.inner.GetStatisticsInRange(ctx, start, end)
+}
+
+// GetRecordingDays traces the wrapped repository's GetRecordingDays
+func (r *ReadingRepository) GetRecordingDays(ctx context.Context, start, end time.Time, loc *time.Location) (_ []time.Time, err error) {
+	ctx, span := r.start(ctx, "GetRecordingDays", rangeAttrs(start, end)...)
+	defer func() { finish(span, err) }()
+	return r.inner.GetRecordingDays(ctx, start, end, loc)
+}
+
+// GetRecentReadings traces the wrapped repository's GetRecentReadings
+func (r *ReadingRepository) GetRecentReadings(ctx context.Context, limit int) (readings []*domain.LightReading, err error) {
+	ctx, span := r.start(ctx, "GetRecentReadings")
+	defer func() { finishReadings(span, readings, err) }()
+	return r.inner.GetRecentReadings(ctx, limit)
+}
+
+// ListReadings traces the wrapped repository's ListReadings
+func (r *ReadingRepository) ListReadings(ctx context.Context, after domain.ReadingCursor, limit int) (readings []*domain.LightReading, err error) {
+	ctx, span := r.start(ctx, "ListReadings")
+	defer func() { finishReadings(span, readings, err) }()
+	return r.inner.ListReadings(ctx, after, limit)
+}
+
+// GetLatestReading traces the wrapped repository's GetLatestReading
+func (r *ReadingRepository) GetLatestReading(ctx context.Context) (_ *domain.LightReading, err error) {
+	ctx, span := r.start(ctx, "GetLatestReading")
+	defer func() { finish(span, err) }()
+	return r.inner.GetLatestReading(ctx)
+}
+
+// GetReadingAsOf traces the wrapped repository's GetReadingAsOf
+func (r *ReadingRepository) GetReadingAsOf(ctx context.Context, at time.Time) (_ *domain.LightReading, err error) {
+	ctx, span := r.start(ctx, "GetReadingAsOf")
+	defer func() { finish(span, err) }()
+	return r.inner.GetReadingAsOf(ctx, at)
+}
+
+// SaveCategoryEvent traces the wrapped repository's SaveCategoryEvent
+func (r *ReadingRepository) SaveCategoryEvent(ctx context.Context, event *domain.CategoryEvent) (err error) {
+	ctx, span := r.start(ctx, "SaveCategoryEvent")
+	defer func() { finish(span, err) }()
+	return r.inner.SaveCategoryEvent(ctx, event)
+}
+
+// GetCategoryEvents traces the wrapped repository's GetCategoryEvents
+func (r *ReadingRepository) GetCategoryEvents(ctx context.Context, start, end time.Time) (_ []*domain.CategoryEvent, err error) {
+	ctx, span := r.start(ctx, "GetCategoryEvents", rangeAttrs(start, end)...)
+	defer func() { finish(span, err) }()
+	return r.inner.GetCategoryEvents(ctx, start, end)
+}
+
+// ReplaceCategoryEvents traces the wrapped repository's ReplaceCategoryEvents
+func (r *ReadingRepository) ReplaceCategoryEvents(ctx context.Context, start, end time.Time, events []*domain.CategoryEvent) (err error) {
+	ctx, span := r.start(ctx, "ReplaceCategoryEvents")
+	defer func() { finish(span, err) }()
+	return r.inner.ReplaceCategoryEvents(ctx, start, end, events)
+}
+
+// Stats traces the wrapped repository's Stats
+func (r *ReadingRepository) Stats(ctx context.Context) (_ *domain.StorageStats, err error) {
+	ctx, span := r.start(ctx, "Stats")
+	defer func() { finish(span, err) }()
+	return r.inner.Stats(ctx)
+}
+
+// DeleteOldReadings traces the wrapped repository's DeleteOldReadings
+func (r *ReadingRepository) DeleteOldReadings(ctx context.Context, olderThan time.Duration) (deleted int64, err error) {
+	ctx, span := r.start(ctx, "DeleteOldReadings")
+	defer func() {
+		span.SetAttributes(readingsKey.Int64(deleted))
+		finish(span, err)
+	}()
+	return r.inner.DeleteOldReadings(ctx, olderThan)
+}
@@ -0,0 +1,52 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/ports"
+)
+
+// Sensor decorates a light sensor, wrapping every read in a span. It passes
+// the inner sensor's quality through when there is one.
+type Sensor struct {
+	inner ports.LightSensor
+	kind  attribute.KeyValue
+}
+
+var _ ports.QualitySensor = (*Sensor)(nil)
+
+// NewSensor wraps inner in spans. kind names the driver for the
+// plant_monitor.sensor attribute, e.g. "gpio" or "mock".
+func NewSensor(inner ports.LightSensor, kind string) *Sensor {
+	return &Sensor{inner: inner, kind: attribute.String("plant_monitor.sensor", kind)}
+}
+
+// ReadLux traces a read of the wrapped sensor
+func (s *Sensor) ReadLux(ctx context.Context) (float64, error) {
+	lux, _, err := s.ReadLuxWithQuality(ctx)
+	return lux, err
+}
+
+// ReadLuxWithQuality traces a read of the wrapped sensor, with its quality
+func (s *Sensor) ReadLuxWithQuality(ctx context.Context) (lux float64, quality domain.Quality, err error) {
+	ctx, span := tracer.Start(ctx, "LightSensor.ReadLux", trace.WithAttributes(s.kind))
+	defer func() {
+		if err == nil {
+			span.SetAttributes(
+				attribute.Float64("plant_monitor.lux", lux),
+				attribute.String("plant_monitor.quality", string(quality)),
+			)
+		}
+		finish(span, err)
+	}()
+	return ports.ReadLuxWithQuality(ctx, s.inner)
+}
+
+// Close closes the wrapped sensor
+func (s *Sensor) Close() error {
+	return s.inner.Close()
+}
@@ -0,0 +1,75 @@
+// Package tracing exports OpenTelemetry traces over OTLP and wraps the
+// repository and sensor ports in spans, so a slow RPC can be split into
+// storage, sensor and handler time
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer starts this package's spans. Global tracers forward to whichever
+// provider Setup installs, even when obtained before it.
+var tracer = otel.Tracer("github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/tracing")
+
+// Enabled reports whether the environment asks for traces: an OTLP endpoint
+// is set (OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT)
+// and OTEL_SDK_DISABLED isn't true
+func Enabled() bool {
+	if disabled, _ := strconv.ParseBool(os.Getenv("OTEL_SDK_DISABLED")); disabled {
+		return false
+	}
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// Setup installs a global tracer provider that batches spans to the OTLP/gRPC
+// exporter, and the W3C trace-context propagator so callers' traces continue
+// here. The exporter is configured by the standard OTEL_EXPORTER_OTLP_*
+// variables, and OTEL_SERVICE_NAME / OTEL_RESOURCE_ATTRIBUTES override
+// serviceName and add attributes. The returned function flushes and stops
+// the provider.
+func Setup(ctx context.Context, serviceName string) (shutdown func(context.Context) error, err error) {
+	exporter, err := otlptracegrpc.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Later options win, so the environment overrides the default name
+	res, err := resource.New(ctx,
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(semconv.ServiceName(serviceName)),
+		resource.WithFromEnv(),
+	)
+	if err != nil && !errors.Is(err, resource.ErrPartialResource) {
+		exporter.Shutdown(ctx)
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// finish records err on span, if any, and ends it
+func finish(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,173 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/mock"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// spans collects every span ended in this package's tests. The global
+// provider can only be delegated to once, so it is shared and reset per test.
+var spans = tracetest.NewInMemoryExporter()
+
+func TestMain(m *testing.M) {
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(spans)))
+	os.Exit(m.Run())
+}
+
+// endedSpans returns the spans ended since the last call
+func endedSpans(t *testing.T) tracetest.SpanStubs {
+	t.Helper()
+	ended := spans.GetSpans()
+	spans.Reset()
+	return ended
+}
+
+// attr finds key among a span's attributes
+func attr(span tracetest.SpanStub, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestReadingRepository_Spans(t *testing.T) {
+	endedSpans(t)
+	ctx := context.Background()
+	repo := NewReadingRepository(memory.NewReadingRepository(), "memory")
+
+	now := time.Now()
+	for i := range 3 {
+		reading, _ := domain.NewLightReadingAt(float64(100*(i+1)), now.Add(time.Duration(i)*time.Second))
+		if err := repo.SaveReading(ctx, reading); err != nil {
+			t.Fatalf("SaveReading failed: %v", err)
+		}
+	}
+	readings, err := repo.GetReadingsInRange(ctx, now, now.Add(time.Minute))
+	if err != nil || len(readings) != 3 {
+		t.Fatalf("expected 3 readings, got %d (%v)", len(readings), err)
+	}
+
+	ended := endedSpans(t)
+	if len(ended) != 4 {
+		t.Fatalf("expected 4 spans, got %d", len(ended))
+	}
+	span := ended[3]
+	if span.Name != "ReadingRepository.GetReadingsInRange" || span.SpanKind != trace.SpanKindClient {
+		t.Errorf("unexpected span %q of kind %v", span.Name, span.SpanKind)
+	}
+	if v, _ := attr(span, readingsKey); v.AsInt64() != 3 {
+		t.Errorf("expected 3 readings recorded, got %v", v.Emit())
+	}
+	if v, _ := attr(span, "db.system.name"); v.AsString() != "memory" {
+		t.Errorf("expected db.system.name memory, got %q", v.Emit())
+	}
+	if v, _ := attr(span, "plant_monitor.range.start_ms"); v.AsInt64() != now.UnixMilli() {
+		t.Errorf("expected the range start recorded, got %v", v.Emit())
+	}
+}
+
+func TestReadingRepository_Error(t *testing.T) {
+	endedSpans(t)
+	repo := NewReadingRepository(memory.NewReadingRepository(), "memory")
+
+	if _, err := repo.GetReading(context.Background(), 42); !errors.Is(err, domain.ErrReadingNotFound) {
+		t.Fatalf("expected the inner error to pass through, got %v", err)
+	}
+
+	ended := endedSpans(t)
+	if len(ended) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(ended))
+	}
+	if ended[0].Status.Code != codes.Error || len(ended[0].Events) != 1 {
+		t.Errorf("expected an error status and event, got %+v", ended[0].Status)
+	}
+}
+
+func TestReadingRepository_ParentSpan(t *testing.T) {
+	endedSpans(t)
+	repo := NewReadingRepository(memory.NewReadingRepository(), "memory")
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "GetHistory")
+	repo.GetLatestReading(ctx)
+	parent.End()
+
+	ended := endedSpans(t)
+	if len(ended) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(ended))
+	}
+	if ended[0].Parent.SpanID() != ended[1].SpanContext.SpanID() {
+		t.Error("expected the repository span to be a child of the caller's")
+	}
+}
+
+// failingSensor never produces a reading
+type failingSensor struct{}
+
+func (failingSensor) ReadLux(ctx context.Context) (float64, error) {
+	return 0, errors.New("i2c: no ACK")
+}
+
+func (failingSensor) Close() error { return nil }
+
+func TestSensor_Spans(t *testing.T) {
+	endedSpans(t)
+	ctx := context.Background()
+
+	sensor := NewSensor(mock.NewFakeSensorSeeded(500, 0, 1), "mock")
+	lux, quality, err := sensor.ReadLuxWithQuality(ctx)
+	if err != nil || lux != 500 || quality != domain.QualityOK {
+		t.Fatalf("expected 500 lux of ok quality, got %v %q (%v)", lux, quality, err)
+	}
+	if _, err := NewSensor(failingSensor{}, "gpio").ReadLux(ctx); err == nil {
+		t.Fatal("expected the sensor error to pass through")
+	}
+
+	ended := endedSpans(t)
+	if len(ended) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(ended))
+	}
+	if v, _ := attr(ended[0], "plant_monitor.lux"); v.AsFloat64() != 500 {
+		t.Errorf("expected the lux recorded, got %v", v.Emit())
+	}
+	if v, _ := attr(ended[1], "plant_monitor.sensor"); v.AsString() != "gpio" {
+		t.Errorf("expected the sensor kind recorded, got %q", v.Emit())
+	}
+	if _, ok := attr(ended[1], "plant_monitor.lux"); ok || ended[1].Status.Code != codes.Error {
+		t.Errorf("expected a failed read to record an error and no lux, got %+v", ended[1].Status)
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	t.Setenv("OTEL_SDK_DISABLED", "")
+	if Enabled() {
+		t.Error("expected tracing off without an endpoint")
+	}
+
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "http://collector:4317")
+	if !Enabled() {
+		t.Error("expected tracing on with a traces endpoint")
+	}
+
+	t.Setenv("OTEL_SDK_DISABLED", "true")
+	if Enabled() {
+		t.Error("expected OTEL_SDK_DISABLED to win")
+	}
+}
@@ -0,0 +1,103 @@
+// Package webhook sends fired alerts as JSON POSTs to an HTTP endpoint
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// DefaultTimeout bounds each POST unless overridden, so a hung endpoint
+// can't stall the recorder
+const DefaultTimeout = 10 * time.Second
+
+// Notifier implements ports.Notifier by POSTing each alert as JSON to a URL
+type Notifier struct {
+	url    string
+	client *http.Client
+}
+
+// Option configures a Notifier
+type Option func(*Notifier)
+
+// WithTimeout bounds each POST (DefaultTimeout unless set)
+func WithTimeout(d time.Duration) Option {
+	return func(n *Notifier) {
+		n.client = &http.Client{Timeout: d}
+	}
+}
+
+// WithHTTPClient sends with client instead of a default one, e.g. to set up
+// TLS towards the endpoint
+func WithHTTPClient(client *http.Client) Option {
+	return func(n *Notifier) {
+		n.client = client
+	}
+}
+
+// NewNotifier creates a notifier posting to url
+func NewNotifier(url string, opts ...Option) *Notifier {
+	n := &Notifier{
+		url:    url,
+		client: &http.Client{Timeout: DefaultTimeout},
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	return n
+}
+
+// payload is the JSON body of a notification
+type payload struct {
+	AlertID      int64   `json:"alert_id"`
+	RuleID       int64   `json:"rule_id"`
+	RuleName     string  `json:"rule_name"`
+	Condition    string  `json:"condition"`
+	ThresholdLux float64 `json:"threshold_lux"`
+	Lux          float64 `json:"lux"`
+	Since        string  `json:"since"`    // RFC 3339
+	FiredAt      string  `json:"fired_at"` // RFC 3339
+	Message      string  `json:"message"`
+}
+
+// Notify POSTs the alert. Any status other than 2xx is an error.
+func (n *Notifier) Notify(ctx context.Context, alert *domain.Alert) error {
+	body, err := json.Marshal(payload{
+		AlertID:      alert.ID,
+		RuleID:       alert.RuleID,
+		RuleName:     alert.RuleName,
+		Condition:    string(alert.Condition),
+		ThresholdLux: alert.ThresholdLux,
+		Lux:          alert.Lux,
+		Since:        alert.Since.UTC().Format(time.RFC3339),
+		FiredAt:      alert.FiredAt.UTC().Format(time.RFC3339),
+		Message:      alert.Message(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post alert: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body) // let the connection be reused
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+func TestNotifier_PostsAlert(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected %s request with content type %q", r.Method, r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	since := time.Date(2024, 6, 1, 18, 0, 0, 0, time.UTC)
+	err := NewNotifier(srv.URL).Notify(context.Background(), &domain.Alert{
+		ID:           7,
+		RuleID:       3,
+		RuleName:     "too dark",
+		Condition:    domain.AlertBelow,
+		ThresholdLux: 150,
+		Lux:          80,
+		Since:        since,
+		FiredAt:      since.Add(2 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+
+	if got["rule_name"] != "too dark" || got["condition"] != "below" || got["lux"] != 80.0 {
+		t.Errorf("unexpected payload %v", got)
+	}
+	if got["since"] != "2024-06-01T18:00:00Z" || got["fired_at"] != "2024-06-01T20:00:00Z" {
+		t.Errorf("expected RFC 3339 times, got since %v fired_at %v", got["since"], got["fired_at"])
+	}
+	if got["message"] == "" {
+		t.Error("expected a message")
+	}
+}
+
+func TestNotifier_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	if err := NewNotifier(srv.URL).Notify(context.Background(), &domain.Alert{}); err == nil {
+		t.Error("expected an error for a 502 response")
+	}
+}
+
+func TestNotifier_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	err := NewNotifier(srv.URL, WithTimeout(50*time.Millisecond)).Notify(context.Background(), &domain.Alert{})
+	if err == nil {
+		t.Error("expected a timeout error")
+	}
+}
@@ -0,0 +1,195 @@
+// Package auth authenticates callers by API key and decides which calls each
+// may make. It complements mTLS, which proves which machine is calling but
+// not what it may do: a key grants a role, and read-only keys let guests on
+// the LAN browse readings without being able to record, import or delete.
+package auth
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Role is what a key allows. Roles are ordered: a writer may do everything
+// a reader may.
+type Role int
+
+const (
+	// RoleNone is required by public calls such as health checks, which need
+	// no key at all
+	RoleNone Role = iota
+	// RoleReader may query readings, statistics, reports and alerts
+	RoleReader
+	// RoleWriter may also record, import, recompute and delete data, manage
+	// alert rules and calibrate the sensor
+	RoleWriter
+)
+
+// String returns the role's name as written in a key file
+func (r Role) String() string {
+	switch r {
+	case RoleNone:
+		return "none"
+	case RoleReader:
+		return "reader"
+	case RoleWriter:
+		return "writer"
+	default:
+		return fmt.Sprintf("Role(%d)", int(r))
+	}
+}
+
+// Allows reports whether a holder of r may make a call requiring required
+func (r Role) Allows(required Role) bool {
+	return r >= required
+}
+
+// ParseRole parses a role name: "reader" or "writer"
+func ParseRole(s string) (Role, error) {
+	switch strings.ToLower(s) {
+	case "reader":
+		return RoleReader, nil
+	case "writer":
+		return RoleWriter, nil
+	default:
+		return RoleNone, fmt.Errorf("unknown role %q (want reader or writer)", s)
+	}
+}
+
+// Principal is the holder of a key, identified by the name the key file
+// gives it
+type Principal struct {
+	Name string
+	Role Role
+}
+
+// ErrInvalidKey is returned for a key that isn't in the store
+var ErrInvalidKey = errors.New("invalid API key")
+
+// hashPrefix marks a key file entry given as the SHA-256 digest of the key,
+// so the file itself needn't hold secrets
+const hashPrefix = "sha256:"
+
+// KeyStore holds the API keys callers may present. Keys are kept and looked
+// up as SHA-256 digests, so neither memory nor lookup timing gives them away.
+type KeyStore struct {
+	keys map[[sha256.Size]byte]Principal
+}
+
+// NewKeyStore creates an empty store; add keys with Add
+func NewKeyStore() *KeyStore {
+	return &KeyStore{keys: make(map[[sha256.Size]byte]Principal)}
+}
+
+// Add grants role to the holder of key, named name in logs
+func (s *KeyStore) Add(name string, role Role, key string) error {
+	if key == "" {
+		return errors.New("empty key")
+	}
+	return s.addDigest(name, role, sha256.Sum256([]byte(key)))
+}
+
+func (s *KeyStore) addDigest(name string, role Role, digest [sha256.Size]byte) error {
+	if existing, ok := s.keys[digest]; ok {
+		return fmt.Errorf("key for %q is already used by %q", name, existing.Name)
+	}
+	s.keys[digest] = Principal{Name: name, Role: role}
+	return nil
+}
+
+// Len returns the number of keys in the store
+func (s *KeyStore) Len() int {
+	return len(s.keys)
+}
+
+// Authenticate returns the principal holding key, or ErrInvalidKey
+func (s *KeyStore) Authenticate(key string) (Principal, error) {
+	if key == "" {
+		return Principal{}, ErrInvalidKey
+	}
+	p, ok := s.keys[sha256.Sum256([]byte(key))]
+	if !ok {
+		return Principal{}, ErrInvalidKey
+	}
+	return p, nil
+}
+
+// LoadKeyFile reads a key file with one key per line:
+//
+//	# name   role    key
+//	kitchen  reader  7f3c9a...
+//	recorder writer  sha256:5e88489...
+//
+// A key given as "sha256:" and a hex digest is matched by its digest, so the
+// file can be shared without revealing the key. Blank lines and lines
+// starting with # are ignored. The file should be readable by the service
+// alone.
+func LoadKeyFile(path string) (*KeyStore, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	s := NewKeyStore()
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if err := s.parseLine(text); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	if s.Len() == 0 {
+		return nil, fmt.Errorf("%s: no keys", path)
+	}
+	return s, nil
+}
+
+// parseLine adds the key on one line of a key file
+func (s *KeyStore) parseLine(text string) error {
+	fields := strings.Fields(text)
+	if len(fields) != 3 {
+		return fmt.Errorf("want \"name role key\", got %d fields", len(fields))
+	}
+	name, key := fields[0], fields[2]
+	role, err := ParseRole(fields[1])
+	if err != nil {
+		return err
+	}
+
+	hexDigest, hashed := strings.CutPrefix(key, hashPrefix)
+	if !hashed {
+		return s.Add(name, role, key)
+	}
+	raw, err := hex.DecodeString(hexDigest)
+	if err != nil || len(raw) != sha256.Size {
+		return fmt.Errorf("key for %q is not a hex SHA-256 digest", name)
+	}
+	return s.addDigest(name, role, [sha256.Size]byte(raw))
+}
+
+// principalKey is the context key for the authenticated Principal
+type principalKey struct{}
+
+// ContextWithPrincipal returns a copy of ctx carrying p
+func ContextWithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the principal a call was authenticated as;
+// ok is false when auth is disabled or the call was public
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+func writeKeyFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "keys")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write key file: %v", err)
+	}
+	return path
+}
+
+func TestLoadKeyFile(t *testing.T) {
+	digest := sha256.Sum256([]byte("hashed-secret"))
+	path := writeKeyFile(t, `
+# name role key
+kitchen  reader  guest-secret
+recorder WRITER  sha256:`+hex.EncodeToString(digest[:])+`
+`)
+
+	keys, err := LoadKeyFile(path)
+	if err != nil {
+		t.Fatalf("LoadKeyFile failed: %v", err)
+	}
+	if keys.Len() != 2 {
+		t.Errorf("expected 2 keys, got %d", keys.Len())
+	}
+
+	tests := []struct {
+		key  string
+		want Principal
+	}{
+		{"guest-secret", Principal{Name: "kitchen", Role: RoleReader}},
+		{"hashed-secret", Principal{Name: "recorder", Role: RoleWriter}},
+	}
+	for _, tt := range tests {
+		got, err := keys.Authenticate(tt.key)
+		if err != nil {
+			t.Errorf("Authenticate(%q) failed: %v", tt.key, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Authenticate(%q) = %+v, want %+v", tt.key, got, tt.want)
+		}
+	}
+
+	for _, key := range []string{"", "wrong", "sha256:" + hex.EncodeToString(digest[:])} {
+		if _, err := keys.Authenticate(key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Authenticate(%q) error = %v, want ErrInvalidKey", key, err)
+		}
+	}
+}
+
+func TestLoadKeyFile_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"empty", "# no keys yet\n", "no keys"},
+		{"missing field", "kitchen reader\n", "fields"},
+		{"unknown role", "kitchen admin secret\n", "unknown role"},
+		{"bad digest", "kitchen reader sha256:abc\n", "digest"},
+		{"duplicate key", "kitchen reader secret\nhall writer secret\n", "already used"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadKeyFile(writeKeyFile(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestRequiredRole(t *testing.T) {
+	tests := []struct {
+		method string
+		want   Role
+	}{
+		{"/grpc.health.v1.Health/Check", RoleNone},
+		{"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo", RoleReader},
+		{pb.LightService_GetCurrentLight_FullMethodName, RoleReader},
+		{pb.LightService_StreamReadings_FullMethodName, RoleReader},
+		{pb.LightService_RecordReading_FullMethodName, RoleWriter},
+		{pb.LightService_PruneReadings_FullMethodName, RoleWriter},
+		{pb.LightService_DeleteAlertRule_FullMethodName, RoleWriter},
+		{"/light.v1.LightService/SomeFutureMethod", RoleWriter},
+	}
+	for _, tt := range tests {
+		if got := RequiredRole(tt.method); got != tt.want {
+			t.Errorf("RequiredRole(%q) = %v, want %v", tt.method, got, tt.want)
+		}
+	}
+}
+
+func TestRole_Allows(t *testing.T) {
+	if !RoleWriter.Allows(RoleReader) {
+		t.Error("a writer should be allowed to read")
+	}
+	if RoleReader.Allows(RoleWriter) {
+		t.Error("a reader should not be allowed to write")
+	}
+}
@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"strings"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+// readerMethods are the LightService RPCs that only read, open to reader
+// keys. Every other LightService RPC requires a writer key, so an RPC added
+// later stays writer-only until it is listed here.
+var readerMethods = map[string]bool{
+	pb.LightService_GetCurrentLight_FullMethodName:       true,
+	pb.LightService_GetHistory_FullMethodName:            true,
+	pb.LightService_GetReading_FullMethodName:            true,
+	pb.LightService_GetReadingsByIDs_FullMethodName:      true,
+	pb.LightService_GetLightAsOf_FullMethodName:          true,
+	pb.LightService_GetCategoryEvents_FullMethodName:     true,
+	pb.LightService_GetStorageStats_FullMethodName:       true,
+	pb.LightService_GetRecent_FullMethodName:             true,
+	pb.LightService_CompareRanges_FullMethodName:         true,
+	pb.LightService_ExportReadings_FullMethodName:        true,
+	pb.LightService_GetRecorderStatus_FullMethodName:     true,
+	pb.LightService_WatchDataChanges_FullMethodName:      true,
+	pb.LightService_GetRecordingDays_FullMethodName:      true,
+	pb.LightService_Categorize_FullMethodName:            true,
+	pb.LightService_GenerateReport_FullMethodName:        true,
+	pb.LightService_GetDailyLightIntegral_FullMethodName: true,
+	pb.LightService_DetectGaps_FullMethodName:            true,
+	pb.LightService_StreamReadings_FullMethodName:        true,
+	pb.LightService_ListAlertRules_FullMethodName:        true,
+	pb.LightService_GetAlerts_FullMethodName:             true,
+	pb.LightService_DownloadReadings_FullMethodName:      true,
+	pb.LightService_GetStatistics_FullMethodName:         true,
+	pb.LightService_GetPhotoperiod_FullMethodName:        true,
+	pb.LightService_StreamHistory_FullMethodName:         true,
+}
+
+// Service prefixes of full method names with a fixed requirement
+const (
+	healthService      = "/grpc.health.v1.Health/"
+	reflectionServices = "/grpc.reflection."
+)
+
+// RequiredRole returns the role a gRPC call to fullMethod (such as
+// "/light.v1.LightService/GetHistory") requires. Health checks are public so
+// orchestrators can probe without a key, listing services by reflection
+// needs a reader key, and anything not known to only read needs a writer key.
+func RequiredRole(fullMethod string) Role {
+	switch {
+	case strings.HasPrefix(fullMethod, healthService):
+		return RoleNone
+	case strings.HasPrefix(fullMethod, reflectionServices), readerMethods[fullMethod]:
+		return RoleReader
+	default:
+		return RoleWriter
+	}
+}
@@ -0,0 +1,294 @@
+// Package config loads light-service's configuration: defaults, overridden
+// by an optional YAML or TOML file, overridden in turn by environment
+// variables. Every setting's file key is its environment variable in lower
+// case, e.g. record_interval for RECORD_INTERVAL.
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	grpcAdapter "github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/grpc"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/i2c"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/mock"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/webhook"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/ports"
+)
+
+// Config holds application configuration
+type Config struct {
+	Port                  int           `yaml:"port" toml:"port" env:"PORT"`
+	MetricsPort           int           `yaml:"metrics_port" toml:"metrics_port" env:"METRICS_PORT"`                                     // HTTP port for /metrics, the REST gateway and the Grafana SimpleJSON endpoints
+	RecordInterval        time.Duration `yaml:"record_interval" toml:"record_interval" env:"RECORD_INTERVAL"`                            // how often the recorder saves a reading
+	MinRecordInterval     time.Duration `yaml:"record_interval_min" toml:"record_interval_min" env:"RECORD_INTERVAL_MIN"`                // RECORD_INTERVAL is clamped to at least this
+	MaxRecordInterval     time.Duration `yaml:"record_interval_max" toml:"record_interval_max" env:"RECORD_INTERVAL_MAX"`                // ...and at most this
+	PollInterval          time.Duration `yaml:"poll_interval" toml:"poll_interval" env:"POLL_INTERVAL"`                                  // sensor read cadence between recordings, aggregated into each (0 = read only when recording)
+	RepoType              string        `yaml:"repo_type" toml:"repo_type" env:"REPO_TYPE"`                                              // "memory" | "sqlite" | "postgres" | "influx"
+	DBPath                string        `yaml:"db_path" toml:"db_path" env:"DB_PATH"`                                                    // SQLite database file path (used when RepoType=sqlite)
+	DatabaseURL           string        `yaml:"database_url" toml:"database_url" env:"DATABASE_URL"`                                     // PostgreSQL connection URL (used when RepoType=postgres)
+	SQLiteJournalMode     string        `yaml:"sqlite_journal_mode" toml:"sqlite_journal_mode" env:"SQLITE_JOURNAL_MODE"`                // PRAGMA journal_mode (default WAL)
+	SQLiteBusyTimeout     time.Duration `yaml:"sqlite_busy_timeout" toml:"sqlite_busy_timeout" env:"SQLITE_BUSY_TIMEOUT"`                // PRAGMA busy_timeout (default 5s)
+	SQLiteSynchronous     string        `yaml:"sqlite_synchronous" toml:"sqlite_synchronous" env:"SQLITE_SYNCHRONOUS"`                   // PRAGMA synchronous (default NORMAL)
+	SQLiteMaxOpenConns    int           `yaml:"sqlite_max_open_conns" toml:"sqlite_max_open_conns" env:"SQLITE_MAX_OPEN_CONNS"`          // connection pool size (default 1)
+	SQLiteMaxIdleConns    int           `yaml:"sqlite_max_idle_conns" toml:"sqlite_max_idle_conns" env:"SQLITE_MAX_IDLE_CONNS"`          // connections kept open while idle (default 1)
+	SQLiteConnMaxLifetime time.Duration `yaml:"sqlite_conn_max_lifetime" toml:"sqlite_conn_max_lifetime" env:"SQLITE_CONN_MAX_LIFETIME"` // replace connections older than this (0 = never)
+	SQLiteQueryTimeout    time.Duration `yaml:"sqlite_query_timeout" toml:"sqlite_query_timeout" env:"SQLITE_QUERY_TIMEOUT"`             // limit on each repository call (default 30s)
+	InfluxURL             string        `yaml:"influx_url" toml:"influx_url" env:"INFLUX_URL"`                                           // InfluxDB 2.x server URL (used when RepoType=influx)
+	InfluxToken           string        `yaml:"influx_token" toml:"influx_token" env:"INFLUX_TOKEN"`                                     // API token with read and write access to the bucket
+	InfluxOrg             string        `yaml:"influx_org" toml:"influx_org" env:"INFLUX_ORG"`                                           // organization owning the bucket
+	InfluxBucket          string        `yaml:"influx_bucket" toml:"influx_bucket" env:"INFLUX_BUCKET"`                                  // bucket readings are written to, created if missing (default "light")
+	InfluxRetention       time.Duration `yaml:"influx_retention" toml:"influx_retention" env:"INFLUX_RETENTION"`                         // bucket expiry, at least 1h (0 = leave the bucket's policy unchanged)
+	SensorType            string        `yaml:"sensor_type" toml:"sensor_type" env:"SENSOR_TYPE"`                                        // "mock" | "gpio" | "diurnal"
+	SensorID              string        `yaml:"sensor_id" toml:"sensor_id" env:"SENSOR_ID"`                                              // key the sensor's calibration is stored under (default SensorType)
+	I2CBus                int           `yaml:"i2c_bus" toml:"i2c_bus" env:"I2C_BUS"`                                                    // /dev/i2c-N the gpio sensor is on (default 1)
+	I2CAddress            uint16        `yaml:"i2c_address" toml:"i2c_address" env:"I2C_ADDRESS"`                                        // gpio sensor address (default 0x23)
+	BH1750Mode            string        `yaml:"bh1750_mode" toml:"bh1750_mode" env:"BH1750_MODE"`                                        // e.g. "continuous-high" (default) or "one-time-low"
+	DiurnalPeakLux        float64       `yaml:"diurnal_peak_lux" toml:"diurnal_peak_lux" env:"DIURNAL_PEAK_LUX"`                         // diurnal sensor's clear-sky light at solar noon
+	DiurnalLatitude       float64       `yaml:"diurnal_latitude" toml:"diurnal_latitude" env:"DIURNAL_LATITUDE"`                         // degrees north the diurnal sensor's sun times are worked out for
+	DiurnalSunrise        time.Duration `yaml:"diurnal_sunrise" toml:"diurnal_sunrise" env:"DIURNAL_SUNRISE"`                            // fixed sunrise as time since midnight, e.g. 6h30m; with DiurnalSunset overrides the latitude
+	DiurnalSunset         time.Duration `yaml:"diurnal_sunset" toml:"diurnal_sunset" env:"DIURNAL_SUNSET"`                               // fixed sunset, e.g. 20h
+	DiurnalClouds         float64       `yaml:"diurnal_clouds" toml:"diurnal_clouds" env:"DIURNAL_CLOUDS"`                               // most a passing cloud dims a read, 0-1 (0 = clear sky)
+	SensorCacheTTL        time.Duration `yaml:"sensor_cache_ttl" toml:"sensor_cache_ttl" env:"SENSOR_CACHE_TTL"`                         // reuse a sensor read for this long (0 = always read)
+	MedianFilterWindow    int           `yaml:"median_filter_window" toml:"median_filter_window" env:"MEDIAN_FILTER_WINDOW"`             // sensor reads the reported median is taken over (0 or 1 disables)
+	TemperatureSensorType string        `yaml:"temperature_sensor_type" toml:"temperature_sensor_type" env:"TEMPERATURE_SENSOR_TYPE"`    // "none" | "mock"
+	Env                   string        `yaml:"env" toml:"env" env:"ENV"`                                                                // "development" allows plaintext gRPC and reflection; "production" requires TLS and disables reflection
+	TLSCert               string        `yaml:"tls_cert" toml:"tls_cert" env:"TLS_CERT,path"`                                            // path to this service's certificate
+	TLSKey                string        `yaml:"tls_key" toml:"tls_key" env:"TLS_KEY,path"`                                               // path to this service's private key
+	TLSCA                 string        `yaml:"tls_ca" toml:"tls_ca" env:"TLS_CA,path"`                                                  // path to the CA certificate
+	AuthKeysFile          string        `yaml:"auth_keys_file" toml:"auth_keys_file" env:"AUTH_KEYS_FILE,path"`                          // path to the API key file; empty disables key auth
+	CategoryLabels        []string      `yaml:"category_labels" toml:"category_labels" env:"CATEGORY_LABELS"`                            // overrides for the low, medium and high labels; empty uses defaults
+	CategoryScheme        string        `yaml:"category_scheme" toml:"category_scheme" env:"CATEGORY_SCHEME"`                            // "Label:upper_lux,...,Label" levels, darkest first; overrides CategoryLabels for readings
+	CategoryHysteresis    float64       `yaml:"category_hysteresis" toml:"category_hysteresis" env:"CATEGORY_HYSTERESIS"`                // lux margin required to change category (0 disables)
+	MinPruneRetention     time.Duration `yaml:"min_prune_retention" toml:"min_prune_retention" env:"MIN_PRUNE_RETENTION"`                // smallest retention PruneReadings accepts
+	MaxRecentLimit        int           `yaml:"max_recent_limit" toml:"max_recent_limit" env:"MAX_RECENT_LIMIT"`                         // most readings GetRecent returns per call
+	MaxBatchSize          int           `yaml:"max_batch_size" toml:"max_batch_size" env:"MAX_BATCH_SIZE"`                               // most readings one RecordReadingsBatch call carries (0 = no cap)
+	MaxCategoryGap        time.Duration `yaml:"max_category_gap" toml:"max_category_gap" env:"MAX_CATEGORY_GAP"`                         // longest time one reading counts towards its category (0 = no cap)
+	LuxToPPFD             float64       `yaml:"lux_to_ppfd" toml:"lux_to_ppfd" env:"LUX_TO_PPFD"`                                        // µmol/m²/s per lux for daily light integrals
+	PhotoperiodLux        float64       `yaml:"photoperiod_lux" toml:"photoperiod_lux" env:"PHOTOPERIOD_LUX"`                            // light level GetPhotoperiod counts as lit unless a request sets one
+	MaxHistorySpan        time.Duration `yaml:"max_history_span" toml:"max_history_span" env:"MAX_HISTORY_SPAN"`                         // longest range GetHistory accepts (0 = any)
+	MaxHistoryReadings    int           `yaml:"max_history_readings" toml:"max_history_readings" env:"MAX_HISTORY_READINGS"`             // most readings one GetHistory response carries (0 = no cap)
+	SamplesPerReading     int           `yaml:"samples_per_reading" toml:"samples_per_reading" env:"SAMPLES_PER_READING"`                // sensor reads averaged into each recording (default 1)
+	SampleInterval        time.Duration `yaml:"sample_interval" toml:"sample_interval" env:"SAMPLE_INTERVAL"`                            // delay between those reads
+	SampleDropOutliers    bool          `yaml:"sample_drop_outliers" toml:"sample_drop_outliers" env:"SAMPLE_DROP_OUTLIERS"`             // discard highest and lowest sample before averaging
+	DropSaturated         bool          `yaml:"drop_saturated" toml:"drop_saturated" env:"DROP_SATURATED"`                               // discard readings the sensor reports as saturated
+	StartupRetries        int           `yaml:"startup_retries" toml:"startup_retries" env:"STARTUP_RETRIES"`                            // attempts at the first recording before waiting for the next interval
+	StartupRetryDelay     time.Duration `yaml:"startup_retry_delay" toml:"startup_retry_delay" env:"STARTUP_RETRY_DELAY"`                // pause between startup attempts
+	DedupLuxEpsilon       float64       `yaml:"dedup_lux_epsilon" toml:"dedup_lux_epsilon" env:"DEDUP_LUX_EPSILON"`                      // lux difference below which a reading repeats the last one
+	DedupMaxSkip          time.Duration `yaml:"dedup_max_skip" toml:"dedup_max_skip" env:"DEDUP_MAX_SKIP"`                               // longest run of skipped repeats (0 = save every reading)
+	WriteBufferSize       int           `yaml:"write_buffer_size" toml:"write_buffer_size" env:"WRITE_BUFFER_SIZE"`                      // readings saved per batch insert (0 = save each as it is taken)
+	WriteBufferFlush      time.Duration `yaml:"write_buffer_flush" toml:"write_buffer_flush" env:"WRITE_BUFFER_FLUSH"`                   // longest a reading waits in the buffer (0 = until the batch is full)
+	NightModeEnterLux     float64       `yaml:"night_mode_enter_lux" toml:"night_mode_enter_lux" env:"NIGHT_MODE_ENTER_LUX"`             // darkness that starts night mode (0 disables it)
+	NightModeExitLux      float64       `yaml:"night_mode_exit_lux" toml:"night_mode_exit_lux" env:"NIGHT_MODE_EXIT_LUX"`                // light that ends it (0 = twice the enter level)
+	NightModeAfter        time.Duration `yaml:"night_mode_after" toml:"night_mode_after" env:"NIGHT_MODE_AFTER"`                         // how long darkness must last first
+	NightModeInterval     time.Duration `yaml:"night_mode_interval" toml:"night_mode_interval" env:"NIGHT_MODE_INTERVAL"`                // recording interval at night
+	RESTAllowedOrigins    []string      `yaml:"rest_allowed_origins" toml:"rest_allowed_origins" env:"REST_ALLOWED_ORIGINS"`             // origins browsers may call the REST gateway from ("*" for any)
+	PeerMetrics           bool          `yaml:"peer_metrics" toml:"peer_metrics" env:"PEER_METRICS"`                                     // label gRPC call counts by client certificate common name
+	EnablePprof           bool          `yaml:"enable_pprof" toml:"enable_pprof" env:"ENABLE_PPROF"`                                     // serve net/http/pprof under /debug/pprof/ on the metrics port
+	ReadOnly              bool          `yaml:"read_only" toml:"read_only" env:"READ_ONLY"`                                              // reject all writes, disable the recorder and open the store without migrating it
+	SeedData              bool          `yaml:"seed_data" toml:"seed_data" env:"SEED_DATA"`                                              // fill an empty store with a day of synthetic readings at startup
+	SeedDataForce         bool          `yaml:"seed_data_force" toml:"seed_data_force" env:"SEED_DATA_FORCE"`                            // seed even when the store already has readings
+	MaxMsgSize            int           `yaml:"max_msg_size" toml:"max_msg_size" env:"MAX_MSG_SIZE"`                                     // largest gRPC message sent or received, in bytes
+
+	// Connection liveness; see grpcAdapter.KeepaliveConfig
+	KeepaliveTime                time.Duration `yaml:"grpc_keepalive_time" toml:"grpc_keepalive_time" env:"GRPC_KEEPALIVE_TIME"`
+	KeepaliveTimeout             time.Duration `yaml:"grpc_keepalive_timeout" toml:"grpc_keepalive_timeout" env:"GRPC_KEEPALIVE_TIMEOUT"`
+	MaxConnectionIdle            time.Duration `yaml:"grpc_max_connection_idle" toml:"grpc_max_connection_idle" env:"GRPC_MAX_CONNECTION_IDLE"`
+	KeepaliveMinTime             time.Duration `yaml:"grpc_keepalive_min_time" toml:"grpc_keepalive_min_time" env:"GRPC_KEEPALIVE_MIN_TIME"`
+	KeepalivePermitWithoutStream bool          `yaml:"grpc_keepalive_permit_without_stream" toml:"grpc_keepalive_permit_without_stream" env:"GRPC_KEEPALIVE_PERMIT_WITHOUT_STREAM"`
+	MaxConcurrentStreams         uint32        `yaml:"grpc_max_concurrent_streams" toml:"grpc_max_concurrent_streams" env:"GRPC_MAX_CONCURRENT_STREAMS"`
+
+	MaxConnections         int           `yaml:"grpc_max_connections" toml:"grpc_max_connections" env:"GRPC_MAX_CONNECTIONS"`             // concurrent client connections (0 = unlimited)
+	LogLevel               string        `yaml:"log_level" toml:"log_level" env:"LOG_LEVEL"`                                              // zerolog level name; empty logs everything
+	DebugWindow            time.Duration `yaml:"debug_window" toml:"debug_window" env:"DEBUG_WINDOW"`                                     // how long SIGUSR1 enables debug logging
+	ShutdownGracePeriod    time.Duration `yaml:"shutdown_grace_period" toml:"shutdown_grace_period" env:"SHUTDOWN_GRACE_PERIOD"`          // NOT_SERVING period before the server stops accepting
+	HealthFailureThreshold int           `yaml:"health_failure_threshold" toml:"health_failure_threshold" env:"HEALTH_FAILURE_THRESHOLD"` // consecutive failed recordings before health reports NOT_SERVING (0 = never)
+	AlertWebhookURL        string        `yaml:"alert_webhook_url" toml:"alert_webhook_url" env:"ALERT_WEBHOOK_URL"`                      // where fired alerts are POSTed as JSON; empty only logs them
+	AlertWebhookTimeout    time.Duration `yaml:"alert_webhook_timeout" toml:"alert_webhook_timeout" env:"ALERT_WEBHOOK_TIMEOUT"`          // limit on each webhook POST
+	NATSURL                string        `yaml:"nats_url" toml:"nats_url" env:"NATS_URL"`                                                 // NATS server readings and alerts are published to, e.g. nats://localhost:4222; empty disables
+
+	// Sensor supervision; see ports.SensorWatchdog
+	SensorFailureThreshold int    `yaml:"sensor_failure_threshold" toml:"sensor_failure_threshold" env:"SENSOR_FAILURE_THRESHOLD"` // consecutive failed reads before the sensor is marked unhealthy (0 disables the watchdog)
+	SensorReinit           bool   `yaml:"sensor_reinit" toml:"sensor_reinit" env:"SENSOR_REINIT"`                                  // reopen the gpio sensor's I2C device once it is unhealthy
+	FallbackSensorType     string `yaml:"fallback_sensor_type" toml:"fallback_sensor_type" env:"FALLBACK_SENSOR_TYPE"`             // "" | "mock" | "gpio" | "diurnal": read while the sensor is unhealthy
+	FallbackI2CAddress     uint16 `yaml:"fallback_i2c_address" toml:"fallback_i2c_address" env:"FALLBACK_I2C_ADDRESS"`             // address of a gpio fallback on I2C_BUS (default 0x5c)
+}
+
+// Deployment environments ENV accepts
+const (
+	EnvDevelopment = "development"
+	EnvProduction  = "production"
+)
+
+// Default returns the configuration used for anything neither the file nor
+// the environment sets
+func Default() Config {
+	keepalive := grpcAdapter.DefaultKeepaliveConfig()
+	return Config{
+		Port:              50051,
+		MetricsPort:       9090,
+		RecordInterval:    5 * time.Minute,
+		MinRecordInterval: time.Second,
+		MaxRecordInterval: 24 * time.Hour,
+
+		Env: EnvDevelopment,
+
+		RepoType:           "memory",
+		DBPath:             "./light.db",
+		SQLiteJournalMode:  "WAL",
+		SQLiteBusyTimeout:  5 * time.Second,
+		SQLiteSynchronous:  "NORMAL",
+		SQLiteMaxOpenConns: 1,
+		SQLiteMaxIdleConns: 1,
+		SQLiteQueryTimeout: 30 * time.Second,
+		InfluxBucket:       "light",
+
+		SensorType:     "mock",
+		I2CBus:         1,
+		I2CAddress:     i2c.BH1750AddressLow,
+		DiurnalPeakLux: mock.DefaultDiurnalPeakLux,
+
+		SensorFailureThreshold: 3,
+		FallbackI2CAddress:     i2c.BH1750AddressHigh,
+
+		MinPruneRetention:  grpcAdapter.DefaultMinPruneRetention,
+		MaxRecentLimit:     grpcAdapter.DefaultMaxRecentLimit,
+		MaxCategoryGap:     grpcAdapter.DefaultMaxCategoryGap,
+		MaxHistoryReadings: grpcAdapter.DefaultMaxHistoryReadings,
+		// The default suits sunlight; grow lights need their own factor
+		LuxToPPFD:      domain.LuxToPPFD,
+		PhotoperiodLux: domain.DefaultPhotoperiodThreshold,
+
+		SamplesPerReading: 1,
+		SampleInterval:    50 * time.Millisecond,
+		// Give a sensor that is still initializing a few quick chances
+		// before falling back to the recording interval
+		StartupRetries:    5,
+		StartupRetryDelay: 2 * time.Second,
+		WriteBufferFlush:  time.Minute,
+		NightModeAfter:    30 * time.Minute,
+		NightModeInterval: 30 * time.Minute,
+
+		// gRPC's own default is 4 MiB, which a large RecordReadingsBatch or
+		// a long GetHistory response can exceed; clients must raise theirs
+		// to match
+		MaxMsgSize: 16 << 20,
+
+		KeepaliveTime:                keepalive.Time,
+		KeepaliveTimeout:             keepalive.Timeout,
+		MaxConnectionIdle:            keepalive.MaxConnectionIdle,
+		KeepaliveMinTime:             keepalive.MinPingInterval,
+		KeepalivePermitWithoutStream: keepalive.PermitWithoutStream,
+		MaxConcurrentStreams:         keepalive.MaxConcurrentStreams,
+
+		DebugWindow: 5 * time.Minute,
+		// Long enough for a load balancer polling readiness every few seconds
+		ShutdownGracePeriod:    5 * time.Second,
+		HealthFailureThreshold: 3,
+		AlertWebhookTimeout:    webhook.DefaultTimeout,
+	}
+}
+
+// Production reports whether the service runs as a production deployment,
+// which must serve gRPC over TLS and doesn't expose reflection
+func (c Config) Production() bool {
+	return c.Env == EnvProduction
+}
+
+// Keepalive gathers the connection liveness settings
+func (c Config) Keepalive() grpcAdapter.KeepaliveConfig {
+	return grpcAdapter.KeepaliveConfig{
+		Time:                 c.KeepaliveTime,
+		Timeout:              c.KeepaliveTimeout,
+		MaxConnectionIdle:    c.MaxConnectionIdle,
+		MinPingInterval:      c.KeepaliveMinTime,
+		PermitWithoutStream:  c.KeepalivePermitWithoutStream,
+		MaxConcurrentStreams: c.MaxConcurrentStreams,
+	}
+}
+
+// NightMode returns the recorder's night mode, or nil when it is disabled
+func (c Config) NightMode() *ports.NightMode {
+	if c.NightModeEnterLux == 0 {
+		return nil
+	}
+	exit := c.NightModeExitLux
+	if exit == 0 {
+		exit = 2 * c.NightModeEnterLux
+	}
+	return &ports.NightMode{
+		EnterBelow: c.NightModeEnterLux,
+		ExitAbove:  exit,
+		After:      c.NightModeAfter,
+		Interval:   c.NightModeInterval,
+	}
+}
+
+// DiurnalOptions configures the diurnal sensor, but for its clouds, whose
+// seed the caller chooses
+func (c Config) DiurnalOptions() []mock.DiurnalOption {
+	opts := []mock.DiurnalOption{
+		mock.WithPeakLux(c.DiurnalPeakLux),
+		mock.WithLatitude(c.DiurnalLatitude),
+	}
+	if c.DiurnalSunrise != 0 || c.DiurnalSunset != 0 {
+		opts = append(opts, mock.WithSunTimes(c.DiurnalSunrise, c.DiurnalSunset))
+	}
+	return opts
+}
+
+// Labels returns the category label overrides, in order low, medium, high;
+// nil when none are set
+func (c Config) Labels() domain.CategoryLabels {
+	if len(c.CategoryLabels) == 0 {
+		return nil
+	}
+	labels := domain.CategoryLabels{}
+	for i, label := range c.CategoryLabels {
+		if i > int(domain.CategoryHigh) {
+			break
+		}
+		labels[domain.Category(i)] = label
+	}
+	return labels
+}
+
+// Scheme parses CategoryScheme, returning nil when it is unset
+func (c Config) Scheme() (*domain.CategoryScheme, error) {
+	if c.CategoryScheme == "" {
+		return nil, nil
+	}
+	return parseCategoryScheme(c.CategoryScheme)
+}
+
+// parseCategoryScheme parses CATEGORY_SCHEME: comma-separated levels from
+// darkest to brightest, each "Label:upper_lux" except the last, which has no
+// upper bound, e.g. "Very Low:50,Low:200,Medium:2500,High:10000,Direct Sun"
+func parseCategoryScheme(s string) (*domain.CategoryScheme, error) {
+	levels := strings.Split(s, ",")
+	labels := make([]string, 0, len(levels))
+	boundaries := make([]float64, 0, len(levels)-1)
+
+	for i, level := range levels {
+		label, bound, hasBound := strings.Cut(level, ":")
+		labels = append(labels, strings.TrimSpace(label))
+
+		last := i == len(levels)-1
+		if hasBound == last {
+			return nil, fmt.Errorf("level %q: every level but the last needs an upper lux bound", level)
+		}
+		if last {
+			break
+		}
+
+		b, err := strconv.ParseFloat(strings.TrimSpace(bound), 64)
+		if err != nil {
+			return nil, fmt.Errorf("level %q: invalid lux bound: %w", level, err)
+		}
+		boundaries = append(boundaries, b)
+	}
+
+	return domain.NewCategoryScheme(labels, boundaries)
+}
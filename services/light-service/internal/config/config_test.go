@@ -0,0 +1,284 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeFile writes a config file named name into a temp directory
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !reflect.DeepEqual(cfg, Default()) {
+		t.Errorf("expected the defaults, got %+v", cfg)
+	}
+	if cfg.NightMode() != nil || cfg.Labels() != nil {
+		t.Error("expected night mode and label overrides off by default")
+	}
+}
+
+func TestLoad_YAMLWithEnvOverride(t *testing.T) {
+	path := writeFile(t, "light.yaml", `
+port: 6000
+record_interval: 30s
+repo_type: sqlite
+category_labels: [Dim, Mid, Bright]
+night_mode_enter_lux: 5
+rest_allowed_origins:
+  - https://dash.example
+`)
+	t.Setenv("RECORD_INTERVAL", "1m")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Port != 6000 || cfg.RepoType != "sqlite" {
+		t.Errorf("expected the file's settings, got port %d and repo %q", cfg.Port, cfg.RepoType)
+	}
+	if cfg.RecordInterval != time.Minute {
+		t.Errorf("expected RECORD_INTERVAL to override the file, got %v", cfg.RecordInterval)
+	}
+	if cfg.SQLiteJournalMode != "WAL" {
+		t.Errorf("expected unset keys to keep their defaults, got %q", cfg.SQLiteJournalMode)
+	}
+	if labels := cfg.Labels(); labels[2] != "Bright" {
+		t.Errorf("expected the high label Bright, got %v", labels)
+	}
+	if night := cfg.NightMode(); night == nil || night.ExitAbove != 10 || night.Interval != 30*time.Minute {
+		t.Errorf("expected night mode exiting at twice the enter level, got %+v", night)
+	}
+	if len(cfg.RESTAllowedOrigins) != 1 {
+		t.Errorf("expected one allowed origin, got %v", cfg.RESTAllowedOrigins)
+	}
+}
+
+func TestLoad_TOML(t *testing.T) {
+	path := writeFile(t, "light.toml", `
+metrics_port = 9191
+sensor_type = "gpio"
+i2c_address = 0x5c
+sample_interval = "100ms"
+grpc_keepalive_permit_without_stream = false
+`)
+	t.Setenv("CATEGORY_LABELS", "Dark, Dusk")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.MetricsPort != 9191 || cfg.SensorType != "gpio" || cfg.I2CAddress != 0x5c {
+		t.Errorf("unexpected settings %d, %q, %#x", cfg.MetricsPort, cfg.SensorType, cfg.I2CAddress)
+	}
+	if cfg.SampleInterval != 100*time.Millisecond {
+		t.Errorf("expected a 100ms sample interval, got %v", cfg.SampleInterval)
+	}
+	if ka := cfg.Keepalive(); ka.PermitWithoutStream || ka.MaxConcurrentStreams != 100 {
+		t.Errorf("expected keepalive defaults except permit_without_stream, got %+v", ka)
+	}
+	if labels := cfg.Labels(); labels[0] != "Dark" || labels[1] != "Dusk" || len(labels) != 2 {
+		t.Errorf("expected trimmed labels from the environment, got %v", labels)
+	}
+}
+
+func TestLoad_UnknownKey(t *testing.T) {
+	for name, content := range map[string]string{
+		"light.yaml": "record_intreval: 30s\n",
+		"light.toml": "record_intreval = \"30s\"\n",
+	} {
+		_, err := Load(writeFile(t, name, content))
+		if err == nil || !strings.Contains(err.Error(), "record_intreval") {
+			t.Errorf("%s: expected an error naming the misspelt key, got %v", name, err)
+		}
+	}
+
+	if _, err := Load(writeFile(t, "light.json", "{}")); err == nil {
+		t.Error("expected an unsupported extension to be an error")
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("expected a missing file to be an error")
+	}
+}
+
+func TestLoad_InvalidEnv(t *testing.T) {
+	t.Setenv("RECORD_INTERVAL", "5mins")
+	t.Setenv("PORT", "grpc")
+	t.Setenv("READ_ONLY", "yes please")
+
+	_, err := Load("")
+	if err == nil {
+		t.Fatal("expected unparseable variables to be errors, not fall back to defaults")
+	}
+	for _, want := range []string{`RECORD_INTERVAL: invalid duration "5mins"`, "PORT", "READ_ONLY"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q should mention %s", err, want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cfg := Default()
+	cfg.Port = 70000
+	cfg.RecordInterval = -time.Second
+	cfg.RepoType = "mysql"
+	cfg.I2CAddress = 0x80
+	cfg.CategoryScheme = "Dark:abc,Bright"
+	cfg.NightModeEnterLux = 10
+	cfg.NightModeExitLux = 5
+	cfg.LogLevel = "loud"
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{
+		"port (PORT): port must be between 1 and 65535, got 70000",
+		"record_interval (RECORD_INTERVAL): must be positive",
+		"repo_type (REPO_TYPE)",
+		"i2c_address (I2C_ADDRESS)",
+		"category_scheme (CATEGORY_SCHEME)",
+		"night_mode_exit_lux (NIGHT_MODE_EXIT_LUX)",
+		"log_level (LOG_LEVEL)",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error should mention %q, got:\n%v", want, err)
+		}
+	}
+
+	if err := Default().Validate(); err != nil {
+		t.Errorf("expected the defaults to be valid, got %v", err)
+	}
+}
+
+func TestValidate_DiurnalSensor(t *testing.T) {
+	cfg := Default()
+	cfg.SensorType = "diurnal"
+	cfg.DiurnalLatitude = 51.5
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected a latitude alone to be valid, got %v", err)
+	}
+
+	cfg.DiurnalSunrise = 20 * time.Hour
+	cfg.DiurnalSunset = 6 * time.Hour
+	cfg.DiurnalClouds = 2
+	err := cfg.Validate()
+	for _, want := range []string{"sensor_type (SENSOR_TYPE): diurnal sensor: sunrise", "diurnal_clouds (DIURNAL_CLOUDS)"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("error should mention %q, got %v", want, err)
+		}
+	}
+}
+
+func TestValidate_FallbackSensor(t *testing.T) {
+	cfg := Default()
+	cfg.SensorType = "gpio"
+	cfg.FallbackSensorType = "gpio"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected a gpio fallback at the other address to be valid, got %v", err)
+	}
+
+	cfg.FallbackI2CAddress = cfg.I2CAddress
+	cfg.SensorFailureThreshold = 0
+	err := cfg.Validate()
+	for _, want := range []string{"fallback_i2c_address (FALLBACK_I2C_ADDRESS): is the sensor's own", "needs SENSOR_FAILURE_THRESHOLD"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("error should mention %q, got %v", want, err)
+		}
+	}
+}
+
+func TestValidate_Production(t *testing.T) {
+	cfg := Default()
+	cfg.Env = EnvProduction
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "tls_cert (TLS_CERT): is required when ENV is production") {
+		t.Errorf("expected production without TLS to be refused, got %v", err)
+	}
+
+	cfg.TLSCert = "/etc/certs/light-service.crt"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("expected production with TLS to be valid, got %v", err)
+	}
+	if !cfg.Production() || Default().Production() {
+		t.Error("expected only ENV=production to be a production deployment")
+	}
+
+	cfg.Env = "staging"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "env (ENV)") {
+		t.Errorf("expected an unknown ENV to be refused, got %v", err)
+	}
+}
+
+// Every setting must be reachable from a file and the environment under
+// matching names
+func TestTagsMatch(t *testing.T) {
+	typ := reflect.TypeFor[Config]()
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		env, _, _ := strings.Cut(field.Tag.Get("env"), ",")
+		key := strings.ToLower(env)
+		if env == "" || field.Tag.Get("yaml") != key || field.Tag.Get("toml") != key {
+			t.Errorf("%s: yaml %q and toml %q should both be %q", field.Name, field.Tag.Get("yaml"), field.Tag.Get("toml"), key)
+		}
+	}
+}
+
+func TestPathFromEnv_FileIndirection(t *testing.T) {
+	t.Setenv("TLS_KEY", "/etc/certs/direct.key")
+
+	got, ok, err := pathFromEnv("TLS_KEY")
+	if err != nil || !ok || got != "/etc/certs/direct.key" {
+		t.Errorf("without _FILE: got %q, %v; want the direct path", got, err)
+	}
+
+	t.Setenv("TLS_KEY_FILE", "/run/secrets/tls_key")
+	got, ok, err = pathFromEnv("TLS_KEY")
+	if err != nil || !ok || got != "/run/secrets/tls_key" {
+		t.Errorf("with _FILE: got %q, %v; want the _FILE path", got, err)
+	}
+
+	got, ok, err = pathFromEnv("TLS_UNSET_FOR_TEST")
+	if err != nil || ok || got != "" {
+		t.Errorf("unset: got %q, %v, %v; want empty and not ok", got, ok, err)
+	}
+}
+
+func TestPathFromEnv_Expansion(t *testing.T) {
+	t.Setenv("CERT_DIR", "/var/run/certs")
+	t.Setenv("POD_NAME", "light-0")
+	t.Setenv("TLS_CERT", "$CERT_DIR/${POD_NAME}.crt")
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.TLSCert != "/var/run/certs/light-0.crt" {
+		t.Errorf("expected expanded path, got %q", cfg.TLSCert)
+	}
+
+	t.Setenv("TLS_CA_FILE", "${CERT_DIR}/${NO_SUCH_VAR_FOR_TEST}/ca.crt")
+	_, err = Load("")
+	if err == nil {
+		t.Fatal("expected an error for an undefined variable")
+	}
+	for _, want := range []string{"TLS_CA_FILE", "NO_SUCH_VAR_FOR_TEST"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q should mention %s", err, want)
+		}
+	}
+}
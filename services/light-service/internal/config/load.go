@@ -0,0 +1,196 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Load builds the configuration from the defaults, the file at path (if
+// path isn't empty) and then the environment, and validates it. Every
+// problem found is reported together, rather than just the first.
+func Load(path string) (Config, error) {
+	cfg := Default()
+	if path != "" {
+		if err := decodeFile(path, &cfg); err != nil {
+			return Config{}, fmt.Errorf("config file %s: %w", path, err)
+		}
+	}
+	if err := applyEnv(&cfg); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// decodeFile overlays the YAML (.yaml, .yml) or TOML (.toml) file at path
+// onto cfg. Unknown keys are errors, so a misspelt setting isn't ignored.
+func decodeFile(path string, cfg *Config) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(f)
+		dec.KnownFields(true)
+		if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+	case ".toml":
+		md, err := toml.NewDecoder(f).Decode(cfg)
+		if err != nil {
+			return err
+		}
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			keys := make([]string, len(undecoded))
+			for i, key := range undecoded {
+				keys[i] = key.String()
+			}
+			return fmt.Errorf("unknown keys %s", strings.Join(keys, ", "))
+		}
+	default:
+		return fmt.Errorf("unsupported extension %q (want .yaml, .yml or .toml)", ext)
+	}
+	return nil
+}
+
+var durationType = reflect.TypeFor[time.Duration]()
+
+// applyEnv overrides cfg's fields from the environment variables named by
+// their env tags. Unset variables leave the field alone; set ones must
+// parse. Lists are comma-separated, and "path" fields may instead be given
+// by NAME_FILE (see pathFromEnv).
+func applyEnv(cfg *Config) error {
+	var errs []error
+	v := reflect.ValueOf(cfg).Elem()
+	for i := range v.NumField() {
+		name, opt, _ := strings.Cut(v.Type().Field(i).Tag.Get("env"), ",")
+		if name == "" {
+			continue
+		}
+
+		var raw string
+		if opt == "path" {
+			path, ok, err := pathFromEnv(name)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if !ok {
+				continue
+			}
+			raw = path
+		} else {
+			var ok bool
+			if raw, ok = os.LookupEnv(name); !ok {
+				continue
+			}
+		}
+
+		if err := setField(v.Field(i), raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// setField parses raw into field according to its type
+func setField(field reflect.Value, raw string) error {
+	raw = strings.TrimSpace(raw)
+	if field.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q (use e.g. 30s or 5m)", raw)
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", raw)
+		}
+		field.SetBool(b)
+	case reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		field.SetInt(int64(n))
+	case reflect.Uint16, reflect.Uint32:
+		// Base 0 accepts hex, e.g. I2C_ADDRESS=0x5c
+		n, err := strconv.ParseUint(raw, 0, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid unsigned integer %q", raw)
+		}
+		field.SetUint(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		field.SetFloat(f)
+	case reflect.Slice:
+		var items []string
+		for item := range strings.SplitSeq(raw, ",") {
+			items = append(items, strings.TrimSpace(item))
+		}
+		// An empty variable clears the list rather than holding one ""
+		if slices.Equal(items, []string{""}) {
+			items = nil
+		}
+		field.Set(reflect.ValueOf(items))
+	default:
+		panic(fmt.Sprintf("config: unsupported field type %s", field.Type()))
+	}
+	return nil
+}
+
+// pathFromEnv reads a file path from the environment variable name, or from
+// name_FILE if that is set (the Docker secrets convention: it names the file
+// holding the secret, which for TLS material is the path wanted). $VAR and
+// ${VAR} references in the path are expanded; referencing an unset variable
+// is an error rather than silently producing a wrong path. ok is false when
+// neither variable is set.
+func pathFromEnv(name string) (path string, ok bool, err error) {
+	source := name + "_FILE"
+	raw, ok := os.LookupEnv(source)
+	if !ok {
+		source = name
+		if raw, ok = os.LookupEnv(name); !ok {
+			return "", false, nil
+		}
+	}
+
+	var missing []string
+	path = os.Expand(raw, func(v string) string {
+		value, ok := os.LookupEnv(v)
+		if !ok {
+			missing = append(missing, v)
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return "", false, fmt.Errorf("%s: undefined variable(s) %s in %q", source, strings.Join(missing, ", "), raw)
+	}
+	return path, true, nil
+}
@@ -0,0 +1,187 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/i2c"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/mock"
+)
+
+// problems collects validation failures, each naming the setting by both its
+// file key and its environment variable
+type problems []error
+
+func (p *problems) addf(env, format string, args ...any) {
+	*p = append(*p, fmt.Errorf("%s (%s): %s", strings.ToLower(env), env, fmt.Sprintf(format, args...)))
+}
+
+func (p *problems) port(env string, port int) {
+	if port < 1 || port > 65535 {
+		p.addf(env, "port must be between 1 and 65535, got %d", port)
+	}
+}
+
+func (p *problems) positive(env string, d time.Duration) {
+	if d <= 0 {
+		p.addf(env, "must be positive, got %v", d)
+	}
+}
+
+func (p *problems) nonNegative(env string, d time.Duration) {
+	if d < 0 {
+		p.addf(env, "must not be negative, got %v", d)
+	}
+}
+
+func (p *problems) atLeast(env string, n, lo int) {
+	if n < lo {
+		p.addf(env, "must be at least %d, got %d", lo, n)
+	}
+}
+
+func (p *problems) oneOf(env, value string, allowed ...string) {
+	if !slices.Contains(allowed, value) {
+		p.addf(env, "must be one of %s, got %q", strings.Join(allowed, ", "), value)
+	}
+}
+
+// Validate checks every setting on its own, returning all the problems
+// found. Checks relating settings to each other, such as clamping
+// RecordInterval to its range, are left to the caller.
+func (c Config) Validate() error {
+	var p problems
+
+	p.port("PORT", c.Port)
+	p.oneOf("ENV", c.Env, EnvDevelopment, EnvProduction)
+	if c.Production() && c.TLSCert == "" {
+		p.addf("TLS_CERT", "is required when ENV is production; plaintext gRPC is for development only")
+	}
+	p.port("METRICS_PORT", c.MetricsPort)
+	p.positive("RECORD_INTERVAL", c.RecordInterval)
+	p.positive("RECORD_INTERVAL_MIN", c.MinRecordInterval)
+	p.positive("RECORD_INTERVAL_MAX", c.MaxRecordInterval)
+	p.nonNegative("POLL_INTERVAL", c.PollInterval)
+
+	p.oneOf("REPO_TYPE", c.RepoType, "memory", "sqlite", "postgres", "influx")
+	p.nonNegative("SQLITE_BUSY_TIMEOUT", c.SQLiteBusyTimeout)
+	p.atLeast("SQLITE_MAX_OPEN_CONNS", c.SQLiteMaxOpenConns, 0)
+	p.atLeast("SQLITE_MAX_IDLE_CONNS", c.SQLiteMaxIdleConns, 0)
+	p.nonNegative("SQLITE_CONN_MAX_LIFETIME", c.SQLiteConnMaxLifetime)
+	p.nonNegative("SQLITE_QUERY_TIMEOUT", c.SQLiteQueryTimeout)
+	if c.RepoType == "influx" {
+		if c.InfluxURL == "" {
+			p.addf("INFLUX_URL", "is required when REPO_TYPE is influx")
+		}
+		if c.InfluxOrg == "" {
+			p.addf("INFLUX_ORG", "is required when REPO_TYPE is influx")
+		}
+		if c.InfluxBucket == "" {
+			p.addf("INFLUX_BUCKET", "is required when REPO_TYPE is influx")
+		}
+	}
+	if c.InfluxRetention != 0 && c.InfluxRetention < time.Hour {
+		p.addf("INFLUX_RETENTION", "must be 0 or at least 1h, got %v", c.InfluxRetention)
+	}
+
+	p.oneOf("SENSOR_TYPE", c.SensorType, "mock", "gpio", "diurnal")
+	if c.SensorType == "diurnal" {
+		if _, err := mock.NewDiurnalSensor(c.DiurnalOptions()...); err != nil {
+			p.addf("SENSOR_TYPE", "diurnal sensor: %v", err)
+		}
+		if c.DiurnalClouds < 0 || c.DiurnalClouds > 1 {
+			p.addf("DIURNAL_CLOUDS", "must be between 0 and 1, got %v", c.DiurnalClouds)
+		}
+	}
+	p.atLeast("I2C_BUS", c.I2CBus, 0)
+	if c.I2CAddress > 0x7f {
+		p.addf("I2C_ADDRESS", "must be a 7-bit address, got %#x", c.I2CAddress)
+	}
+	if _, err := i2c.ParseMode(c.BH1750Mode); err != nil {
+		p.addf("BH1750_MODE", "%v", err)
+	}
+	p.atLeast("SENSOR_FAILURE_THRESHOLD", c.SensorFailureThreshold, 0)
+	p.oneOf("FALLBACK_SENSOR_TYPE", c.FallbackSensorType, "", "mock", "gpio", "diurnal")
+	if c.FallbackSensorType != "" && c.SensorFailureThreshold == 0 {
+		p.addf("FALLBACK_SENSOR_TYPE", "needs SENSOR_FAILURE_THRESHOLD to decide when to fall back")
+	}
+	if c.FallbackI2CAddress > 0x7f {
+		p.addf("FALLBACK_I2C_ADDRESS", "must be a 7-bit address, got %#x", c.FallbackI2CAddress)
+	}
+	if c.SensorType == "gpio" && c.FallbackSensorType == "gpio" && c.FallbackI2CAddress == c.I2CAddress {
+		p.addf("FALLBACK_I2C_ADDRESS", "is the sensor's own address %#x", c.I2CAddress)
+	}
+	if c.FallbackSensorType == "diurnal" && c.SensorType != "diurnal" {
+		if _, err := mock.NewDiurnalSensor(c.DiurnalOptions()...); err != nil {
+			p.addf("FALLBACK_SENSOR_TYPE", "diurnal sensor: %v", err)
+		}
+	}
+	p.nonNegative("SENSOR_CACHE_TTL", c.SensorCacheTTL)
+	p.atLeast("MEDIAN_FILTER_WINDOW", c.MedianFilterWindow, 0)
+	p.oneOf("TEMPERATURE_SENSOR_TYPE", c.TemperatureSensorType, "", "none", "mock")
+
+	if _, err := c.Scheme(); err != nil {
+		p.addf("CATEGORY_SCHEME", "%v", err)
+	}
+	if c.CategoryHysteresis < 0 {
+		p.addf("CATEGORY_HYSTERESIS", "must not be negative, got %v", c.CategoryHysteresis)
+	}
+	p.nonNegative("MIN_PRUNE_RETENTION", c.MinPruneRetention)
+	p.atLeast("MAX_RECENT_LIMIT", c.MaxRecentLimit, 1)
+	p.atLeast("MAX_BATCH_SIZE", c.MaxBatchSize, 0)
+	p.nonNegative("MAX_CATEGORY_GAP", c.MaxCategoryGap)
+	if c.LuxToPPFD <= 0 {
+		p.addf("LUX_TO_PPFD", "must be positive, got %v", c.LuxToPPFD)
+	}
+	if c.PhotoperiodLux <= 0 {
+		p.addf("PHOTOPERIOD_LUX", "must be positive, got %v", c.PhotoperiodLux)
+	}
+	p.nonNegative("MAX_HISTORY_SPAN", c.MaxHistorySpan)
+	p.atLeast("MAX_HISTORY_READINGS", c.MaxHistoryReadings, 0)
+
+	p.atLeast("SAMPLES_PER_READING", c.SamplesPerReading, 1)
+	p.nonNegative("SAMPLE_INTERVAL", c.SampleInterval)
+	p.atLeast("STARTUP_RETRIES", c.StartupRetries, 1)
+	p.nonNegative("STARTUP_RETRY_DELAY", c.StartupRetryDelay)
+	if c.DedupLuxEpsilon < 0 {
+		p.addf("DEDUP_LUX_EPSILON", "must not be negative, got %v", c.DedupLuxEpsilon)
+	}
+	p.nonNegative("DEDUP_MAX_SKIP", c.DedupMaxSkip)
+	p.atLeast("WRITE_BUFFER_SIZE", c.WriteBufferSize, 0)
+	p.nonNegative("WRITE_BUFFER_FLUSH", c.WriteBufferFlush)
+	if c.NightModeEnterLux < 0 {
+		p.addf("NIGHT_MODE_ENTER_LUX", "must not be negative, got %v", c.NightModeEnterLux)
+	}
+	if c.NightModeExitLux != 0 && c.NightModeExitLux < c.NightModeEnterLux {
+		p.addf("NIGHT_MODE_EXIT_LUX", "must be at least night_mode_enter_lux (%v), got %v", c.NightModeEnterLux, c.NightModeExitLux)
+	}
+	p.nonNegative("NIGHT_MODE_AFTER", c.NightModeAfter)
+	p.positive("NIGHT_MODE_INTERVAL", c.NightModeInterval)
+
+	if slices.Contains(c.RESTAllowedOrigins, "") {
+		p.addf("REST_ALLOWED_ORIGINS", "origins must not be empty")
+	}
+	p.atLeast("MAX_MSG_SIZE", c.MaxMsgSize, 1)
+	p.nonNegative("GRPC_KEEPALIVE_TIME", c.KeepaliveTime)
+	p.nonNegative("GRPC_KEEPALIVE_TIMEOUT", c.KeepaliveTimeout)
+	p.nonNegative("GRPC_MAX_CONNECTION_IDLE", c.MaxConnectionIdle)
+	p.nonNegative("GRPC_KEEPALIVE_MIN_TIME", c.KeepaliveMinTime)
+	p.atLeast("GRPC_MAX_CONNECTIONS", c.MaxConnections, 0)
+
+	if c.LogLevel != "" {
+		if _, err := zerolog.ParseLevel(c.LogLevel); err != nil {
+			p.addf("LOG_LEVEL", "unknown level %q", c.LogLevel)
+		}
+	}
+	p.positive("DEBUG_WINDOW", c.DebugWindow)
+	p.nonNegative("SHUTDOWN_GRACE_PERIOD", c.ShutdownGracePeriod)
+	p.atLeast("HEALTH_FAILURE_THRESHOLD", c.HealthFailureThreshold, 0)
+	p.positive("ALERT_WEBHOOK_TIMEOUT", c.AlertWebhookTimeout)
+
+	return errors.Join(p...)
+}
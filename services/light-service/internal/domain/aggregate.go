@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+// ReadingBucket summarizes the readings in one aggregation interval
+type ReadingBucket struct {
+	Start      time.Time // aligned to a multiple of the interval since the Unix epoch
+	Count      int64
+	AverageLux float64
+	MinLux     float64
+	MaxLux     float64
+}
+
+// AggregateReadings groups readings into interval-wide buckets aligned to
+// the Unix epoch (so 1h buckets start on the hour) and summarizes each.
+// Empty buckets are omitted; the rest come oldest first. interval is
+// truncated to whole seconds, matching what SQL repositories can group on.
+func AggregateReadings(readings []*LightReading, interval time.Duration) []ReadingBucket {
+	seconds := int64(interval / time.Second)
+	if seconds <= 0 {
+		return nil
+	}
+
+	var buckets []ReadingBucket
+	index := make(map[int64]int)
+	for _, r := range readings {
+		key := floorDiv(r.Timestamp.Unix(), seconds)
+		i, ok := index[key]
+		if !ok {
+			i = len(buckets)
+			index[key] = i
+			buckets = append(buckets, ReadingBucket{
+				Start:  time.Unix(key*seconds, 0),
+				MinLux: math.Inf(1),
+				MaxLux: math.Inf(-1),
+			})
+		}
+		b := &buckets[i]
+		b.Count++
+		b.AverageLux += r.Lux // the sum until the end
+		b.MinLux = math.Min(b.MinLux, r.Lux)
+		b.MaxLux = math.Max(b.MaxLux, r.Lux)
+	}
+
+	for i := range buckets {
+		buckets[i].AverageLux /= float64(buckets[i].Count)
+	}
+	slices.SortFunc(buckets, func(a, b ReadingBucket) int { return a.Start.Compare(b.Start) })
+	return buckets
+}
+
+// floorDiv divides rounding towards negative infinity, so readings before
+// the epoch still land in the bucket that starts before them
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && a < 0 {
+		q--
+	}
+	return q
+}
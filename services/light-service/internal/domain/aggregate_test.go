@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAggregateReadings(t *testing.T) {
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minutes int, lux float64) *LightReading {
+		return &LightReading{Lux: lux, Timestamp: base.Add(time.Duration(minutes) * time.Minute)}
+	}
+	// Out of order, with an empty hour between the two buckets
+	readings := []*LightReading{at(130, 50), at(10, 100), at(50, 300), at(0, 200)}
+
+	buckets := AggregateReadings(readings, time.Hour)
+	want := []ReadingBucket{
+		{Start: base, Count: 3, AverageLux: 200, MinLux: 100, MaxLux: 300},
+		{Start: base.Add(2 * time.Hour), Count: 1, AverageLux: 50, MinLux: 50, MaxLux: 50},
+	}
+	if len(buckets) != len(want) {
+		t.Fatalf("expected %d buckets, got %+v", len(want), buckets)
+	}
+	for i := range want {
+		if !buckets[i].Start.Equal(want[i].Start) || buckets[i].Count != want[i].Count ||
+			buckets[i].AverageLux != want[i].AverageLux || buckets[i].MinLux != want[i].MinLux || buckets[i].MaxLux != want[i].MaxLux {
+			t.Errorf("bucket %d: got %+v, want %+v", i, buckets[i], want[i])
+		}
+	}
+
+	if got := AggregateReadings(readings, 0); got != nil {
+		t.Errorf("expected no buckets for a zero interval, got %+v", got)
+	}
+}
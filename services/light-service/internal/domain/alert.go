@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// AlertCondition says which side of its threshold breaches a rule
+type AlertCondition string
+
+const (
+	AlertBelow AlertCondition = "below" // lux < threshold
+	AlertAbove AlertCondition = "above" // lux > threshold
+)
+
+// AlertRule fires when lux stays on the wrong side of ThresholdLux for
+// longer than For, e.g. "lux < 150 for more than 2h"
+type AlertRule struct {
+	ID           int64
+	Name         string
+	Condition    AlertCondition
+	ThresholdLux float64
+	For          time.Duration // 0 fires on the first breaching reading
+	CreatedAt    time.Time
+}
+
+// Validate checks the rule can be evaluated. Surrounding whitespace is
+// trimmed from the name.
+func (r *AlertRule) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidAlertRule)
+	}
+	if r.Condition != AlertBelow && r.Condition != AlertAbove {
+		return fmt.Errorf("%w: condition must be %q or %q", ErrInvalidAlertRule, AlertBelow, AlertAbove)
+	}
+	if math.IsNaN(r.ThresholdLux) || math.IsInf(r.ThresholdLux, 0) || r.ThresholdLux < 0 {
+		return fmt.Errorf("%w: threshold must be finite and non-negative", ErrInvalidAlertRule)
+	}
+	if r.For < 0 {
+		return fmt.Errorf("%w: duration cannot be negative", ErrInvalidAlertRule)
+	}
+	return nil
+}
+
+// Breached reports whether lux is on the wrong side of the threshold
+func (r *AlertRule) Breached(lux float64) bool {
+	if r.Condition == AlertAbove {
+		return lux > r.ThresholdLux
+	}
+	return lux < r.ThresholdLux
+}
+
+// String describes the rule, e.g. "lux < 150 for 2h0m0s"
+func (r *AlertRule) String() string {
+	op := "<"
+	if r.Condition == AlertAbove {
+		op = ">"
+	}
+	s := fmt.Sprintf("lux %s %g", op, r.ThresholdLux)
+	if r.For > 0 {
+		s += " for " + r.For.String()
+	}
+	return s
+}
+
+// Alert records a rule firing
+type Alert struct {
+	ID           int64
+	RuleID       int64
+	RuleName     string
+	Condition    AlertCondition
+	ThresholdLux float64
+	Lux          float64   // the reading that fired the rule
+	Since        time.Time // when the condition was first breached
+	FiredAt      time.Time // timestamp of the reading that fired the rule
+}
+
+// Message describes the alert for a human
+func (a *Alert) Message() string {
+	return fmt.Sprintf("%s: lux has been %s %g since %s (now %g)",
+		a.RuleName, a.Condition, a.ThresholdLux, a.Since.UTC().Format(time.RFC3339), a.Lux)
+}
+
+// AlertRepository stores alert rules and the alerts they fire
+type AlertRepository interface {
+	// CreateRule stores a new rule, assigning its ID and creation time
+	CreateRule(ctx context.Context, rule *AlertRule) error
+
+	// ListRules returns every rule, oldest first
+	ListRules(ctx context.Context) ([]*AlertRule, error)
+
+	// DeleteRule removes a rule; alerts it fired are kept. Returns
+	// ErrAlertRuleNotFound if there is no such rule.
+	DeleteRule(ctx context.Context, id int64) error
+
+	// SaveAlert stores a fired alert, assigning its ID
+	SaveAlert(ctx context.Context, alert *Alert) error
+
+	// GetAlerts returns the alerts fired in [start, end), oldest first
+	GetAlerts(ctx context.Context, start, end time.Time) ([]*Alert, error)
+}
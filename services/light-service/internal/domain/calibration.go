@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Calibration corrects one sensor's raw readings against a reference meter:
+// corrected = raw × Scale + OffsetLux. Cheap photoresistor modules in
+// particular read consistently high or low.
+type Calibration struct {
+	SensorID  string
+	Scale     float64
+	OffsetLux float64
+	UpdatedAt time.Time // zero for the identity calibration
+}
+
+// IdentityCalibration leaves the sensor's readings unchanged
+func IdentityCalibration(sensorID string) Calibration {
+	return Calibration{SensorID: sensorID, Scale: 1}
+}
+
+// Validate checks the calibration can be applied. Surrounding whitespace is
+// trimmed from the sensor ID.
+func (c *Calibration) Validate() error {
+	c.SensorID = strings.TrimSpace(c.SensorID)
+	if c.SensorID == "" {
+		return fmt.Errorf("%w: sensor ID is required", ErrInvalidCalibration)
+	}
+	if math.IsNaN(c.Scale) || math.IsInf(c.Scale, 0) || c.Scale <= 0 {
+		return fmt.Errorf("%w: scale must be finite and positive", ErrInvalidCalibration)
+	}
+	if math.IsNaN(c.OffsetLux) || math.IsInf(c.OffsetLux, 0) {
+		return fmt.Errorf("%w: offset must be finite", ErrInvalidCalibration)
+	}
+	return nil
+}
+
+// Apply corrects a raw reading. A negative offset can't take the result
+// below 0 lux.
+func (c Calibration) Apply(lux float64) float64 {
+	return max(lux*c.Scale+c.OffsetLux, 0)
+}
+
+// IsIdentity reports whether the calibration leaves readings unchanged
+func (c Calibration) IsIdentity() bool {
+	return c.Scale == 1 && c.OffsetLux == 0
+}
+
+// CalibrationRepository stores a calibration per sensor
+type CalibrationRepository interface {
+	// GetCalibration returns the sensor's calibration, or
+	// ErrCalibrationNotFound if it has never been calibrated
+	GetCalibration(ctx context.Context, sensorID string) (*Calibration, error)
+
+	// SaveCalibration stores a calibration, replacing the sensor's previous
+	// one, and sets its UpdatedAt
+	SaveCalibration(ctx context.Context, cal *Calibration) error
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestCalibration_Validate(t *testing.T) {
+	for name, cal := range map[string]Calibration{
+		"no sensor":      {SensorID: "  ", Scale: 1},
+		"zero scale":     {SensorID: "a", Scale: 0},
+		"negative scale": {SensorID: "a", Scale: -2},
+		"NaN scale":      {SensorID: "a", Scale: math.NaN()},
+		"infinite scale": {SensorID: "a", Scale: math.Inf(1)},
+		"NaN offset":     {SensorID: "a", Scale: 1, OffsetLux: math.NaN()},
+	} {
+		if err := cal.Validate(); !errors.Is(err, ErrInvalidCalibration) {
+			t.Errorf("%s: expected ErrInvalidCalibration, got %v", name, err)
+		}
+	}
+
+	cal := Calibration{SensorID: " gpio ", Scale: 0.8, OffsetLux: -15}
+	if err := cal.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cal.SensorID != "gpio" {
+		t.Errorf("expected the sensor ID trimmed, got %q", cal.SensorID)
+	}
+}
+
+func TestCalibration_Apply(t *testing.T) {
+	cal := Calibration{SensorID: "a", Scale: 0.5, OffsetLux: -20}
+	if got := cal.Apply(1000); got != 480 {
+		t.Errorf("expected 480, got %v", got)
+	}
+	// The offset can't take a dark reading negative
+	if got := cal.Apply(10); got != 0 {
+		t.Errorf("expected 0, got %v", got)
+	}
+
+	identity := IdentityCalibration("a")
+	if !identity.IsIdentity() || identity.Apply(123.4) != 123.4 {
+		t.Errorf("expected the identity to leave readings unchanged")
+	}
+	if cal.IsIdentity() {
+		t.Errorf("expected %+v not to be the identity", cal)
+	}
+}
@@ -0,0 +1,93 @@
+package domain
+
+import "time"
+
+// Category is the numeric light category of a reading.
+// Determination is purely numeric; human-readable labels live in CategoryLabels.
+type Category int
+
+const (
+	// CategoryLow is below 200 lux
+	CategoryLow Category = iota
+
+	// CategoryMedium is 200-2500 lux
+	CategoryMedium
+
+	// CategoryHigh is 2500 lux and above
+	CategoryHigh
+)
+
+// CategoryLabels maps each category to a human-readable label
+type CategoryLabels map[Category]string
+
+// DefaultCategoryLabels are the English labels used when nothing is overridden
+var DefaultCategoryLabels = CategoryLabels{
+	CategoryLow:    "Low Light",
+	CategoryMedium: "Medium Light",
+	CategoryHigh:   "High Light",
+}
+
+// Label returns the label for c, falling back to the default English label
+// when the set doesn't override it
+func (l CategoryLabels) Label(c Category) string {
+	if label, ok := l[c]; ok && label != "" {
+		return label
+	}
+	return DefaultCategoryLabels[c]
+}
+
+// Categorizer assigns categories with hysteresis, remembering the previous
+// category so readings dithering around a boundary don't flap between labels.
+// It is not safe for concurrent use.
+type Categorizer struct {
+	scheme  *CategoryScheme
+	margin  float64
+	current Category
+	started bool
+}
+
+// NewCategorizer creates a Categorizer that requires crossing a boundary by
+// at least margin lux to change category
+func NewCategorizer(margin float64) *Categorizer {
+	return NewCategorizerIn(DefaultCategoryScheme, margin)
+}
+
+// NewCategorizerIn is NewCategorizer for the levels of a custom scheme
+func NewCategorizerIn(scheme *CategoryScheme, margin float64) *Categorizer {
+	return &Categorizer{scheme: scheme, margin: margin}
+}
+
+// Current returns the category the categorizer is in, and false if it has
+// not categorized or been seeded with anything yet
+func (c *Categorizer) Current() (Category, bool) {
+	return c.current, c.started
+}
+
+// Seed sets the starting category, e.g. from the last persisted reading so a
+// restart doesn't forget where hysteresis left off
+func (c *Categorizer) Seed(category Category) {
+	c.current = category
+	c.started = true
+}
+
+// Categorize returns the category for the reading, taking the previous
+// category into account. The first reading is categorized without hysteresis.
+func (c *Categorizer) Categorize(r *LightReading) Category {
+	if !c.started {
+		c.current = c.scheme.Categorize(r.Lux)
+		c.started = true
+		return c.current
+	}
+	c.current = c.scheme.CategorizeWithHysteresis(r.Lux, c.current, c.margin)
+	return c.current
+}
+
+// CategoryEvent records a change in light category, forming an audit trail
+// of when the plant's light conditions changed
+type CategoryEvent struct {
+	ID        int64
+	From      Category
+	To        Category
+	Lux       float64   // the reading that caused the change
+	Timestamp time.Time // when that reading was taken
+}
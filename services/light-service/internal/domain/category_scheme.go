@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// CategoryScheme is an ordered set of named light levels. Boundaries[i] is
+// the lux at which level i ends and level i+1 begins, so a scheme with n
+// boundaries has n+1 labels. Categories from a scheme are indexes into it,
+// with 0 the darkest.
+type CategoryScheme struct {
+	boundaries []float64
+	labels     []string
+}
+
+// DefaultCategoryScheme is the built-in Low/Medium/High scheme; its
+// categories coincide with CategoryLow, CategoryMedium and CategoryHigh
+var DefaultCategoryScheme = &CategoryScheme{
+	boundaries: []float64{200, 2500},
+	labels:     []string{"Low Light", "Medium Light", "High Light"},
+}
+
+// NewCategoryScheme validates and creates a scheme. Boundaries must be
+// finite, positive and strictly increasing, with one more label than
+// boundaries.
+func NewCategoryScheme(labels []string, boundaries []float64) (*CategoryScheme, error) {
+	if len(labels) != len(boundaries)+1 {
+		return nil, fmt.Errorf("category scheme needs %d labels for %d boundaries, got %d",
+			len(boundaries)+1, len(boundaries), len(labels))
+	}
+	for i, b := range boundaries {
+		if math.IsNaN(b) || math.IsInf(b, 0) || b <= 0 {
+			return nil, fmt.Errorf("category boundary %v must be a positive lux value", b)
+		}
+		if i > 0 && b <= boundaries[i-1] {
+			return nil, fmt.Errorf("category boundaries must be strictly increasing: %v follows %v", b, boundaries[i-1])
+		}
+	}
+	for _, l := range labels {
+		if l == "" {
+			return nil, fmt.Errorf("category labels cannot be empty")
+		}
+	}
+
+	return &CategoryScheme{
+		boundaries: append([]float64(nil), boundaries...),
+		labels:     append([]string(nil), labels...),
+	}, nil
+}
+
+// Levels returns how many categories the scheme has
+func (s *CategoryScheme) Levels() int {
+	return len(s.labels)
+}
+
+// Categorize returns the level lux falls in. A value exactly on a boundary
+// belongs to the level above it, as 200 lux is Medium in the default scheme.
+func (s *CategoryScheme) Categorize(lux float64) Category {
+	return Category(sort.Search(len(s.boundaries), func(i int) bool {
+		return lux < s.boundaries[i]
+	}))
+}
+
+// CategorizeWithHysteresis returns the level for lux given the previous one,
+// requiring lux to cross a boundary by at least margin before it changes,
+// as LightReading.CategoryWithHysteresis does
+func (s *CategoryScheme) CategorizeWithHysteresis(lux float64, prev Category, margin float64) Category {
+	raw := s.Categorize(lux)
+	switch {
+	case raw > prev:
+		return max(s.Categorize(lux-margin), prev)
+	case raw < prev:
+		return min(s.Categorize(lux+margin), prev)
+	}
+	return raw
+}
+
+// LuxRange returns the half-open lux interval [lo, hi) of level c. The top
+// level's hi is +Inf.
+func (s *CategoryScheme) LuxRange(c Category) (lo, hi float64) {
+	lo, hi = 0, math.Inf(1)
+	if c > 0 && int(c) <= len(s.boundaries) {
+		lo = s.boundaries[c-1]
+	}
+	if c >= 0 && int(c) < len(s.boundaries) {
+		hi = s.boundaries[c]
+	}
+	return lo, hi
+}
+
+// Label returns the name of level c, or "" if the scheme has no such level
+func (s *CategoryScheme) Label(c Category) string {
+	if c < 0 || int(c) >= len(s.labels) {
+		return ""
+	}
+	return s.labels[c]
+}
+
+// EqualWithin reports whether a and b are effectively the same measurement:
+// lux within luxEpsilon and the same level. Readings either side of one of
+// the scheme's boundaries are never equal, however close their lux.
+func (s *CategoryScheme) EqualWithin(a, b *LightReading, luxEpsilon float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return math.Abs(a.Lux-b.Lux) <= luxEpsilon && s.Categorize(a.Lux) == s.Categorize(b.Lux)
+}
+
+// LightCategoryIn returns the reading's label under the given scheme; nil
+// means DefaultCategoryScheme
+func (r *LightReading) LightCategoryIn(s *CategoryScheme) string {
+	if s == nil {
+		s = DefaultCategoryScheme
+	}
+	return s.Label(s.Categorize(r.Lux))
+}
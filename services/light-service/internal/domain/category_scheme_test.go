@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCategoryScheme_FiveLevels(t *testing.T) {
+	scheme, err := NewCategoryScheme(
+		[]string{"Very Low", "Low", "Medium", "High", "Direct Sun"},
+		[]float64{50, 200, 2500, 10000},
+	)
+	if err != nil {
+		t.Fatalf("NewCategoryScheme failed: %v", err)
+	}
+	if scheme.Levels() != 5 {
+		t.Errorf("expected 5 levels, got %d", scheme.Levels())
+	}
+
+	tests := []struct {
+		lux  float64
+		want string
+	}{
+		{0, "Very Low"},
+		{49.99, "Very Low"},
+		{50, "Low"},
+		{199.99, "Low"},
+		{200, "Medium"},
+		{2499.99, "Medium"},
+		{2500, "High"},
+		{9999.99, "High"},
+		{10000, "Direct Sun"},
+		{100000, "Direct Sun"},
+	}
+	for _, tt := range tests {
+		r := &LightReading{Lux: tt.lux}
+		if got := r.LightCategoryIn(scheme); got != tt.want {
+			t.Errorf("%v lux: expected %q, got %q", tt.lux, tt.want, got)
+		}
+	}
+}
+
+// fiveLevelScheme splits the default Low level at 50 lux and High at 10000
+func fiveLevelScheme(t *testing.T) *CategoryScheme {
+	t.Helper()
+	scheme, err := NewCategoryScheme(
+		[]string{"Very Low", "Low", "Medium", "High", "Direct Sun"},
+		[]float64{50, 200, 2500, 10000},
+	)
+	if err != nil {
+		t.Fatalf("NewCategoryScheme failed: %v", err)
+	}
+	return scheme
+}
+
+func TestCategoryScheme_TimeInCategoryFiveLevels(t *testing.T) {
+	scheme := fiveLevelScheme(t)
+	base := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+	at := func(minutes int, lux float64) *LightReading {
+		return &LightReading{Lux: lux, Timestamp: base.Add(time.Duration(minutes) * time.Minute)}
+	}
+
+	readings := []*LightReading{
+		at(0, 20),     // very low for 10m
+		at(10, 100),   // low for 20m
+		at(30, 20000), // direct sun for 5m
+		at(35, 5000),  // high: final reading, 25m until end
+	}
+	got := scheme.TimeInCategory(readings, base.Add(time.Hour), 0)
+
+	want := map[Category]time.Duration{
+		0: 10 * time.Minute,
+		1: 20 * time.Minute,
+		2: 0, // present although no reading was medium
+		3: 25 * time.Minute,
+		4: 5 * time.Minute,
+	}
+	if len(got) != len(want) {
+		t.Errorf("expected every level, got %v", got)
+	}
+	for c, w := range want {
+		if d, ok := got[c]; !ok || d != w {
+			t.Errorf("level %v: expected %v, got %v", c, w, got[c])
+		}
+	}
+}
+
+func TestCategoryScheme_EqualWithinFiveLevels(t *testing.T) {
+	scheme := fiveLevelScheme(t)
+	tests := []struct {
+		a, b float64
+		want bool
+	}{
+		{45, 49, true},
+		{48, 52, false},      // across the Very Low/Low boundary, which the default lacks
+		{9998, 10001, false}, // across High/Direct Sun
+		{190, 210, false},
+		{12000, 12004, true},
+	}
+	for _, tt := range tests {
+		a, b := &LightReading{Lux: tt.a}, &LightReading{Lux: tt.b}
+		if got := scheme.EqualWithin(a, b, 5); got != tt.want {
+			t.Errorf("EqualWithin(%v, %v, 5) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+	if !scheme.EqualWithin(nil, nil, 5) || scheme.EqualWithin(&LightReading{}, nil, 5) {
+		t.Error("expected nil readings equal only to each other")
+	}
+}
+
+func TestCategoryScheme_DefaultMatchesCategory(t *testing.T) {
+	for _, lux := range []float64{0, 199.99, 200, 2499.99, 2500, 50000} {
+		r := &LightReading{Lux: lux}
+		if got := DefaultCategoryScheme.Categorize(lux); got != r.Category() {
+			t.Errorf("%v lux: default scheme gives %v, Category gives %v", lux, got, r.Category())
+		}
+		if r.LightCategoryIn(nil) != r.LightCategory() {
+			t.Errorf("%v lux: expected nil scheme to use default labels", lux)
+		}
+	}
+}
+
+func TestNewCategoryScheme_Validation(t *testing.T) {
+	tests := []struct {
+		name       string
+		labels     []string
+		boundaries []float64
+	}{
+		{"too few labels", []string{"Low", "High"}, []float64{100, 200}},
+		{"too many labels", []string{"A", "B", "C"}, []float64{100}},
+		{"equal boundaries", []string{"A", "B", "C"}, []float64{100, 100}},
+		{"decreasing boundaries", []string{"A", "B", "C"}, []float64{200, 100}},
+		{"zero boundary", []string{"A", "B"}, []float64{0}},
+		{"empty label", []string{"A", ""}, []float64{100}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewCategoryScheme(tt.labels, tt.boundaries); err == nil {
+				t.Error("expected validation error")
+			}
+		})
+	}
+}
+
+func TestCategoryScheme_LuxRange(t *testing.T) {
+	tests := []struct {
+		c      Category
+		lo, hi float64
+	}{
+		{CategoryLow, 0, 200},
+		{CategoryMedium, 200, 2500},
+		{CategoryHigh, 2500, math.Inf(1)},
+	}
+	for _, tt := range tests {
+		lo, hi := DefaultCategoryScheme.LuxRange(tt.c)
+		if lo != tt.lo || hi != tt.hi {
+			t.Errorf("category %v: expected [%v, %v), got [%v, %v)", tt.c, tt.lo, tt.hi, lo, hi)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package domain
+
+import "time"
+
+// Clock abstracts the current time, periodic ticks and one-off waits so
+// time-dependent behaviour can be driven deterministically in tests
+type Clock interface {
+	Now() time.Time
+	NewTicker(d time.Duration) Ticker
+	NewTimer(d time.Duration) Timer
+}
+
+// Ticker is the subset of *time.Ticker that Clock users need
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// Timer is the subset of *time.Timer that Clock users need
+type Timer interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// RealClock is the wall clock
+type RealClock struct{}
+
+// Now returns time.Now()
+func (RealClock) Now() time.Time { return time.Now() }
+
+// NewTicker wraps time.NewTicker
+func (RealClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+// realTicker adapts *time.Ticker to Ticker
+type realTicker struct {
+	t *time.Ticker
+}
+
+func (r realTicker) C() <-chan time.Time { return r.t.C }
+func (r realTicker) Stop()               { r.t.Stop() }
+
+// NewTimer wraps time.NewTimer
+func (RealClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+// realTimer adapts *time.Timer to Timer
+type realTimer struct {
+	t *time.Timer
+}
+
+func (r realTimer) C() <-chan time.Time { return r.t.C }
+func (r realTimer) Stop()               { r.t.Stop() }
@@ -6,9 +6,42 @@ var (
 	// ErrInvalidLux indicates lux value is invalid
 	ErrInvalidLux = errors.New("lux value cannot be negative")
 
+	// ErrNonFiniteLux indicates lux is NaN or infinite, which would poison
+	// averages and min/max statistics
+	ErrNonFiniteLux = errors.New("lux value must be finite")
+
+	// ErrFutureTimestamp indicates a reading claims to be from the future
+	ErrFutureTimestamp = errors.New("timestamp is in the future")
+
 	// ErrReadingNotFound indicates requested reading doesn't exist
 	ErrReadingNotFound = errors.New("reading not found")
 
+	// ErrDuplicateTimestamp indicates a reading was saved at a timestamp
+	// another reading already holds; UpsertReading replaces it instead
+	ErrDuplicateTimestamp = errors.New("a reading already exists at this timestamp")
+
+	// ErrInvalidTemperature indicates temperature value is invalid
+	ErrInvalidTemperature = errors.New("temperature must be a finite value above absolute zero")
+
+	// ErrReadOnly indicates a write was attempted while the service is in
+	// read-only mode
+	ErrReadOnly = errors.New("repository is read-only")
+
 	// ErrSensorUnavailable indicates sensor cannot be read
 	ErrSensorUnavailable = errors.New("sensor unavailable")
+
+	// ErrSaturatedReading indicates a saturated reading was discarded
+	ErrSaturatedReading = errors.New("sensor saturated")
+
+	// ErrInvalidAlertRule indicates an alert rule can't be evaluated
+	ErrInvalidAlertRule = errors.New("invalid alert rule")
+
+	// ErrAlertRuleNotFound indicates requested alert rule doesn't exist
+	ErrAlertRuleNotFound = errors.New("alert rule not found")
+
+	// ErrInvalidCalibration indicates a calibration can't be applied
+	ErrInvalidCalibration = errors.New("invalid calibration")
+
+	// ErrCalibrationNotFound indicates a sensor has no stored calibration
+	ErrCalibrationNotFound = errors.New("calibration not found")
 )
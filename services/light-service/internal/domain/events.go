@@ -0,0 +1,9 @@
+package domain
+
+// ReadingRecorded is published once the recorder has saved a reading, or
+// handed it to its write buffer, in which case the reading has no ID yet
+type ReadingRecorded struct {
+	Reading       *LightReading
+	Category      Category // under the recorder's scheme and hysteresis
+	CorrelationID string   // the recording cycle's ID, as logged
+}
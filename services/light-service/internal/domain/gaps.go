@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// Gap is a stretch between two consecutive readings long enough to suggest
+// recording stopped
+type Gap struct {
+	Start time.Time // timestamp of the reading before the gap
+	End   time.Time // timestamp of the reading after it
+}
+
+// Duration returns how long the gap lasted
+func (g Gap) Duration() time.Duration {
+	return g.End.Sub(g.Start)
+}
+
+// FindGaps returns every interval between consecutive readings longer than
+// threshold. Readings must be in chronological order.
+func FindGaps(readings []*LightReading, threshold time.Duration) []Gap {
+	var gaps []Gap
+	for i := 1; i < len(readings); i++ {
+		prev, next := readings[i-1].Timestamp, readings[i].Timestamp
+		if next.Sub(prev) > threshold {
+			gaps = append(gaps, Gap{Start: prev, End: next})
+		}
+	}
+	return gaps
+}
@@ -0,0 +1,117 @@
+package domain
+
+import "time"
+
+// LuxToPPFD is the default factor for converting lux to photosynthetic photon
+// flux density in µmol/m²/s. It is for sunlight; lamps differ (white LEDs are
+// nearer 0.015), so integrals are estimates.
+const LuxToPPFD = 0.0185
+
+// DLIEstimator integrates the photosynthetic light readings represent.
+// Time is attributed as in TimeInCategory: each reading covers the interval
+// until the next one (the last until the end given), capped at MaxGap.
+type DLIEstimator struct {
+	LuxToPPFD float64       // µmol/m²/s per lux
+	MaxGap    time.Duration // longest time one reading counts for; 0 disables the cap
+}
+
+// DayLightIntegral is the light received on one calendar day
+type DayLightIntegral struct {
+	Day      time.Time     // midnight at the start of the day
+	Integral float64       // mol/m² received during the day
+	Covered  time.Duration // time during the day the readings account for
+	Readings int           // readings taken during the day
+}
+
+// covered returns the part of the interval r accounts for, given the time
+// the next reading was taken (or the end when r is the last)
+func (e DLIEstimator) covered(r *LightReading, until time.Time) time.Duration {
+	d := until.Sub(r.Timestamp)
+	if d <= 0 {
+		return 0
+	}
+	if e.MaxGap > 0 && d > e.MaxGap {
+		d = e.MaxGap
+	}
+	return d
+}
+
+// Integral estimates the light received over the time the readings cover,
+// in mol/m²
+func (e DLIEstimator) Integral(readings []*LightReading, end time.Time) float64 {
+	var micromoles float64
+	for i, r := range readings {
+		until := end
+		if i+1 < len(readings) {
+			until = readings[i+1].Timestamp
+		}
+		micromoles += r.Lux * e.LuxToPPFD * e.covered(r, until).Seconds()
+	}
+	return micromoles / 1e6
+}
+
+// Daily integrates readings separately for each calendar day from start up
+// to end, both midnights in the time zone whose days are wanted. A reading
+// whose interval crosses midnight is split between the days. The last reading
+// covers time up to until (e.g. now), and nothing after it is counted.
+// Readings must be in chronological order; ones before start count only for
+// the part of their interval after it.
+func (e DLIEstimator) Daily(readings []*LightReading, start, end, until time.Time) []DayLightIntegral {
+	var days []DayLightIntegral
+	for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+		days = append(days, DayLightIntegral{Day: d})
+	}
+	if len(days) == 0 {
+		return nil
+	}
+	if until.After(end) {
+		until = end
+	}
+
+	// Both cursors only move forward, as readings are in order
+	day, readingDay := 0, 0
+	for i, r := range readings {
+		if !r.Timestamp.Before(start) && r.Timestamp.Before(end) {
+			for readingDay+1 < len(days) && !r.Timestamp.Before(days[readingDay+1].Day) {
+				readingDay++
+			}
+			days[readingDay].Readings++
+		}
+
+		next := until
+		if i+1 < len(readings) {
+			next = readings[i+1].Timestamp
+		}
+		from, to := r.Timestamp, r.Timestamp.Add(e.covered(r, next))
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(until) {
+			to = until
+		}
+
+		for from.Before(to) {
+			for day+1 < len(days) && !from.Before(days[day+1].Day) {
+				day++
+			}
+			segment := to
+			if day+1 < len(days) && days[day+1].Day.Before(segment) {
+				segment = days[day+1].Day
+			}
+			d := segment.Sub(from)
+			days[day].Integral += r.Lux * e.LuxToPPFD * d.Seconds() / 1e6
+			days[day].Covered += d
+			from = segment
+		}
+	}
+	return days
+}
+
+// DailyLightIntegral scales a light integral received over span to the
+// average per day (mol/m²/day), the usual measure of a plant's light
+func DailyLightIntegral(integral float64, span time.Duration) float64 {
+	if span <= 0 {
+		return 0
+	}
+	return integral * float64(24*time.Hour) / float64(span)
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDLIEstimator_Daily(t *testing.T) {
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hours float64, lux float64) *LightReading {
+		r, _ := NewLightReadingAt(lux, start.Add(time.Duration(hours*float64(time.Hour))))
+		return r
+	}
+	// The first reading is from before the range and the third straddles
+	// midnight; recording stops at 30h, a day and a half in
+	readings := []*LightReading{
+		at(-1, 500),
+		at(12, 1000),
+		at(23, 2000),
+		at(25, 100),
+	}
+	e := DLIEstimator{LuxToPPFD: 0.02, MaxGap: 2 * time.Hour}
+	days := e.Daily(readings, start, start.AddDate(0, 0, 3), start.Add(30*time.Hour))
+	if len(days) != 3 {
+		t.Fatalf("expected 3 days, got %d", len(days))
+	}
+
+	mol := func(lux, hours float64) float64 { return lux * 0.02 * hours * 3600 / 1e6 }
+	want := []struct {
+		integral float64
+		covered  time.Duration
+		readings int
+	}{
+		// 1h of the pre-range reading's 2h cap, 2h at 1000 lux, 1h at 2000
+		{mol(500, 1) + mol(1000, 2) + mol(2000, 1), 4 * time.Hour, 2},
+		// the rest of the 2000 lux reading, then 100 lux capped at 2h
+		{mol(2000, 1) + mol(100, 2), 3 * time.Hour, 1},
+		{0, 0, 0},
+	}
+	for i, w := range want {
+		d := days[i]
+		if !d.Day.Equal(start.AddDate(0, 0, i)) {
+			t.Errorf("day %d: expected %v, got %v", i, start.AddDate(0, 0, i), d.Day)
+		}
+		if math.Abs(d.Integral-w.integral) > 1e-9 || d.Covered != w.covered || d.Readings != w.readings {
+			t.Errorf("day %d: expected %v mol/m² over %v from %d readings, got %v over %v from %d",
+				i, w.integral, w.covered, w.readings, d.Integral, d.Covered, d.Readings)
+		}
+	}
+}
+
+func TestDLIEstimator_DailyStopsAtUntil(t *testing.T) {
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	r, _ := NewLightReadingAt(1000, start.Add(20*time.Hour))
+
+	// Uncapped, the reading would run past midnight; until stops it at 22h
+	e := DLIEstimator{LuxToPPFD: LuxToPPFD}
+	days := e.Daily([]*LightReading{r}, start, start.AddDate(0, 0, 2), start.Add(22*time.Hour))
+	if days[0].Covered != 2*time.Hour || days[1].Covered != 0 {
+		t.Errorf("expected 2h on the first day only, got %v and %v", days[0].Covered, days[1].Covered)
+	}
+	if got := e.Integral([]*LightReading{r}, start.Add(22*time.Hour)); math.Abs(got-days[0].Integral) > 1e-12 {
+		t.Errorf("expected Integral to match the day's %v, got %v", days[0].Integral, got)
+	}
+}
+
+func TestDLIEstimator_DailyFollowsLocalMidnight(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	// The clocks go forward on 31 March 2024, so that day has 23 hours
+	start := time.Date(2024, 3, 31, 0, 0, 0, 0, paris)
+	r, _ := NewLightReadingAt(100, start)
+
+	e := DLIEstimator{LuxToPPFD: LuxToPPFD}
+	days := e.Daily([]*LightReading{r}, start, start.AddDate(0, 0, 2), start.AddDate(0, 0, 2))
+	if days[0].Covered != 23*time.Hour || days[1].Covered != 24*time.Hour {
+		t.Errorf("expected 23h then 24h, got %v and %v", days[0].Covered, days[1].Covered)
+	}
+	if days[1].Day.Hour() != 0 {
+		t.Errorf("expected the second day to start at local midnight, got %v", days[1].Day)
+	}
+}
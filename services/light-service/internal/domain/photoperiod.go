@@ -0,0 +1,96 @@
+package domain
+
+import "time"
+
+// DefaultPhotoperiodThreshold is the lux above which a plant counts as lit
+// unless configured otherwise: well above room lighting at night, well
+// below daylight or a grow light
+const DefaultPhotoperiodThreshold = 1000.0
+
+// PhotoperiodAnalyzer measures how long the light stayed at or above a
+// threshold each day. Time is attributed as in DLIEstimator: each reading
+// covers the interval until the next one (the last until the end given),
+// capped at MaxGap.
+type PhotoperiodAnalyzer struct {
+	ThresholdLux float64       // readings at or above this count as light
+	MaxGap       time.Duration // longest time one reading counts for; 0 disables the cap
+}
+
+// DayPhotoperiod is the light period of one calendar day
+type DayPhotoperiod struct {
+	Day        time.Time     // midnight at the start of the day
+	Light      time.Duration // time during the day at or above the threshold
+	FirstLight time.Time     // when the first lit interval of the day began; zero if none
+	LastLight  time.Time     // when the last lit interval of the day ended; zero if none
+	Covered    time.Duration // time during the day the readings account for, lit or not
+	Readings   int           // readings taken during the day
+}
+
+// Daily measures the light period of each calendar day from start up to
+// end, both midnights in the time zone whose days are wanted. A lit
+// interval crossing midnight is split between the days, so lights left on
+// overnight begin the next day's light at midnight. The last reading covers
+// time up to until (e.g. now), and nothing after it is counted. Readings
+// must be in chronological order; ones before start count only for the part
+// of their interval after it.
+func (a PhotoperiodAnalyzer) Daily(readings []*LightReading, start, end, until time.Time) []DayPhotoperiod {
+	var days []DayPhotoperiod
+	for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+		days = append(days, DayPhotoperiod{Day: d})
+	}
+	if len(days) == 0 {
+		return nil
+	}
+	if until.After(end) {
+		until = end
+	}
+
+	// Both cursors only move forward, as readings are in order
+	day, readingDay := 0, 0
+	for i, r := range readings {
+		if !r.Timestamp.Before(start) && r.Timestamp.Before(end) {
+			for readingDay+1 < len(days) && !r.Timestamp.Before(days[readingDay+1].Day) {
+				readingDay++
+			}
+			days[readingDay].Readings++
+		}
+
+		next := until
+		if i+1 < len(readings) {
+			next = readings[i+1].Timestamp
+		}
+		d := next.Sub(r.Timestamp)
+		if a.MaxGap > 0 && d > a.MaxGap {
+			d = a.MaxGap
+		}
+		from, to := r.Timestamp, r.Timestamp.Add(d)
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(until) {
+			to = until
+		}
+		lit := r.Lux >= a.ThresholdLux
+
+		for from.Before(to) {
+			for day+1 < len(days) && !from.Before(days[day+1].Day) {
+				day++
+			}
+			segment := to
+			if day+1 < len(days) && days[day+1].Day.Before(segment) {
+				segment = days[day+1].Day
+			}
+			p := &days[day]
+			p.Covered += segment.Sub(from)
+			if lit {
+				if p.FirstLight.IsZero() {
+					p.FirstLight = from
+				}
+				p.LastLight = segment
+				p.Light += segment.Sub(from)
+			}
+			from = segment
+		}
+	}
+	return days
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPhotoperiodAnalyzer_Daily(t *testing.T) {
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hours float64, lux float64) *LightReading {
+		r, _ := NewLightReadingAt(lux, start.Add(time.Duration(hours*float64(time.Hour))))
+		return r
+	}
+	// The first reading is lit from before the range, the 500 lux one is
+	// dark and the 1000 lux one, exactly at the threshold, is lit across
+	// midnight; recording stops at 30h
+	readings := []*LightReading{
+		at(-1, 1500),
+		at(6, 500),
+		at(7, 1200),
+		at(8, 2000),
+		at(23, 1000),
+		at(25, 10),
+	}
+	a := PhotoperiodAnalyzer{ThresholdLux: 1000, MaxGap: 2 * time.Hour}
+	days := a.Daily(readings, start, start.AddDate(0, 0, 3), start.Add(30*time.Hour))
+	if len(days) != 3 {
+		t.Fatalf("expected 3 days, got %d", len(days))
+	}
+
+	hour := func(h int) time.Time { return start.Add(time.Duration(h) * time.Hour) }
+	want := []DayPhotoperiod{
+		// 0-1h, 7-8h, 8-10h (capped) and 23-24h lit; 6-7h dark
+		{Day: start, Light: 5 * time.Hour, FirstLight: hour(0), LastLight: hour(24), Covered: 6 * time.Hour, Readings: 4},
+		// the rest of the 1000 lux reading, then 2h dark
+		{Day: hour(24), Light: time.Hour, FirstLight: hour(24), LastLight: hour(25), Covered: 3 * time.Hour, Readings: 1},
+		{Day: hour(48)},
+	}
+	for i, w := range want {
+		d := days[i]
+		if !d.Day.Equal(w.Day) || d.Light != w.Light || !d.FirstLight.Equal(w.FirstLight) ||
+			!d.LastLight.Equal(w.LastLight) || d.Covered != w.Covered || d.Readings != w.Readings {
+			t.Errorf("day %d: expected %+v, got %+v", i, w, d)
+		}
+	}
+}
+
+func TestPhotoperiodAnalyzer_DailyFollowsLocalMidnight(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, paris)
+
+	// Lit from 23:00 to 01:00 Paris time, which is 21:00 to 23:00 UTC
+	r, _ := NewLightReadingAt(5000, start.Add(-time.Hour))
+	a := PhotoperiodAnalyzer{ThresholdLux: DefaultPhotoperiodThreshold}
+	days := a.Daily([]*LightReading{r}, start.AddDate(0, 0, -1), start.AddDate(0, 0, 1), start.Add(time.Hour))
+	if days[0].Light != time.Hour || days[1].Light != time.Hour {
+		t.Errorf("expected an hour on each side of Paris midnight, got %v and %v", days[0].Light, days[1].Light)
+	}
+	if !days[1].FirstLight.Equal(start) {
+		t.Errorf("expected the second day's light to begin at midnight, got %v", days[1].FirstLight)
+	}
+}
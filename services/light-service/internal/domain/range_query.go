@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"cmp"
+	"slices"
+)
+
+// RangeQuery narrows GetReadingsInRange to one page of the range. The zero
+// value returns every reading in the range, oldest first.
+type RangeQuery struct {
+	Limit      int            // at most this many readings; 0 means no limit
+	After      *ReadingCursor // resume after this position, in the query's order
+	Descending bool           // newest first
+}
+
+// RangeOption configures a GetReadingsInRange call
+type RangeOption func(*RangeQuery)
+
+// WithLimit returns at most n readings (n <= 0 means no limit)
+func WithLimit(n int) RangeOption {
+	return func(q *RangeQuery) { q.Limit = n }
+}
+
+// WithCursor resumes after the reading the cursor was built from, so
+// passing CursorAfter(last reading of a page) fetches the next page
+func WithCursor(c ReadingCursor) RangeOption {
+	return func(q *RangeQuery) { q.After = &c }
+}
+
+// WithDescending returns the newest readings first
+func WithDescending() RangeOption {
+	return func(q *RangeQuery) { q.Descending = true }
+}
+
+// NewRangeQuery applies opts to the zero RangeQuery
+func NewRangeQuery(opts ...RangeOption) RangeQuery {
+	var q RangeQuery
+	for _, opt := range opts {
+		opt(&q)
+	}
+	return q
+}
+
+// Follows reports whether r comes after the query's cursor in its order;
+// every reading does when there is no cursor
+func (q RangeQuery) Follows(r *LightReading) bool {
+	if q.After == nil {
+		return true
+	}
+	if !q.Descending {
+		return q.After.Before(r)
+	}
+	if r.Timestamp.Equal(q.After.Timestamp) {
+		return r.ID < q.After.ID
+	}
+	return r.Timestamp.Before(q.After.Timestamp)
+}
+
+// Apply pages readings already in the range: it orders them by (timestamp,
+// ID) in the query's direction, drops those up to the cursor and truncates
+// to the limit. Repositories that can't page in their query use it.
+func (q RangeQuery) Apply(readings []*LightReading) []*LightReading {
+	results := make([]*LightReading, 0, len(readings))
+	for _, r := range readings {
+		if q.Follows(r) {
+			results = append(results, r)
+		}
+	}
+
+	slices.SortFunc(results, func(a, b *LightReading) int {
+		c := a.Timestamp.Compare(b.Timestamp)
+		if c == 0 {
+			c = cmp.Compare(a.ID, b.ID)
+		}
+		if q.Descending {
+			return -c
+		}
+		return c
+	})
+
+	if q.Limit > 0 && q.Limit < len(results) {
+		results = results[:q.Limit]
+	}
+	return results
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRangeQuery_Apply(t *testing.T) {
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	// Two readings share a timestamp, so the ID breaks the tie
+	readings := []*LightReading{
+		{ID: 3, Lux: 3, Timestamp: base.Add(time.Minute)},
+		{ID: 1, Lux: 1, Timestamp: base},
+		{ID: 4, Lux: 4, Timestamp: base.Add(2 * time.Minute)},
+		{ID: 2, Lux: 2, Timestamp: base.Add(time.Minute)},
+	}
+	ids := func(rs []*LightReading) []int64 {
+		var out []int64
+		for _, r := range rs {
+			out = append(out, r.ID)
+		}
+		return out
+	}
+	after := func(id int64) RangeOption {
+		for _, r := range readings {
+			if r.ID == id {
+				return WithCursor(CursorAfter(r))
+			}
+		}
+		t.Fatalf("no reading %d", id)
+		return nil
+	}
+
+	tests := []struct {
+		name string
+		opts []RangeOption
+		want []int64
+	}{
+		{"zero query", nil, []int64{1, 2, 3, 4}},
+		{"limit", []RangeOption{WithLimit(2)}, []int64{1, 2}},
+		{"cursor", []RangeOption{after(2)}, []int64{3, 4}},
+		{"descending", []RangeOption{WithDescending()}, []int64{4, 3, 2, 1}},
+		{"descending cursor", []RangeOption{WithDescending(), after(3), WithLimit(1)}, []int64{2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ids(NewRangeQuery(tt.opts...).Apply(readings))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
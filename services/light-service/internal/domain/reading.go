@@ -1,30 +1,120 @@
 package domain
 
 import (
+	"math"
 	"time"
 )
 
+// Source identifies which code path produced a reading
+type Source string
+
+const (
+	// SourceSensor marks readings taken by the background recorder or a live sensor read
+	SourceSensor Source = "sensor"
+
+	// SourceManual marks readings submitted by a client through RecordReading
+	SourceManual Source = "manual"
+
+	// SourceImport marks readings loaded from an external dataset
+	SourceImport Source = "import"
+)
+
+// Quality is how far the sensor trusts a reading
+type Quality string
+
+const (
+	// QualityOK marks an ordinary reading
+	QualityOK Quality = "ok"
+
+	// QualityLowConfidence marks a reading the sensor flagged as unreliable,
+	// e.g. too little light for its current gain
+	QualityLowConfidence Quality = "low_confidence"
+
+	// QualitySaturated marks a reading at the top of the sensor's range: the
+	// true light level is at least this high, possibly much higher
+	QualitySaturated Quality = "saturated"
+)
+
+// qualityRank orders qualities from best to worst
+var qualityRank = map[Quality]int{QualityOK: 0, QualityLowConfidence: 1, QualitySaturated: 2}
+
+// WorseQuality returns whichever of a and b is less trustworthy. An unset
+// quality counts as QualityOK.
+func WorseQuality(a, b Quality) Quality {
+	if qualityRank[b] > qualityRank[a] {
+		return b
+	}
+	if a == "" {
+		return QualityOK
+	}
+	return a
+}
+
 // LightReading represents a single light measurement
 // This is pure domain logic - no database, no gRPC, just business concepts
 type LightReading struct {
 	ID        int64
 	Lux       float64
 	Timestamp time.Time
+	Source    Source
+	Quality   Quality // empty for readings stored before quality was recorded; treat as QualityOK
+
+	// TemperatureC is the ambient temperature in °C when the sensor module
+	// reports one; nil means no temperature was recorded
+	TemperatureC *float64
 }
 
-// NewLightReading creates a new reading with validation
+// NewLightReading creates a new reading with validation, timestamped now
+// Readings default to SourceSensor; callers on other paths override Source
 func NewLightReading(lux float64) (*LightReading, error) {
-	// Business rule: Lux cannot be negative
+	return NewLightReadingAt(lux, time.Now())
+}
+
+// NewLightReadingAt is NewLightReading with an explicit timestamp, for
+// callers that take the time from a Clock or from the submitted data
+func NewLightReadingAt(lux float64, at time.Time) (*LightReading, error) {
+	// Business rule: Lux must be a real measurement...
+	if math.IsNaN(lux) || math.IsInf(lux, 0) {
+		return nil, ErrNonFiniteLux
+	}
+
+	// ...and cannot be negative
 	if lux < 0 {
 		return nil, ErrInvalidLux
 	}
 
 	return &LightReading{
 		Lux:       lux,
-		Timestamp: time.Now(),
+		Timestamp: at,
+		Source:    SourceSensor,
+		Quality:   QualityOK,
 	}, nil
 }
 
+// SetTemperature attaches an ambient temperature (°C) to the reading
+// Business rule: temperature cannot be below absolute zero
+func (r *LightReading) SetTemperature(celsius float64) error {
+	if math.IsNaN(celsius) || math.IsInf(celsius, 0) || celsius < AbsoluteZeroC {
+		return ErrInvalidTemperature
+	}
+	r.TemperatureC = &celsius
+	return nil
+}
+
+// AbsoluteZeroC is the lowest physically possible temperature in °C
+const AbsoluteZeroC = -273.15
+
+// categoryForLux applies the lux thresholds shared by the Is*Light methods
+func categoryForLux(lux float64) Category {
+	r := LightReading{Lux: lux}
+	if r.IsLowLight() {
+		return CategoryLow
+	} else if r.IsMediumLight() {
+		return CategoryMedium
+	}
+	return CategoryHigh
+}
+
 // IsLowLight returns true if reading indicates low light conditions
 // Business logic: < 200 lux is considered low light
 func (r *LightReading) IsLowLight() bool {
@@ -43,12 +133,70 @@ func (r *LightReading) IsHighLight() bool {
 	return r.Lux >= 2500
 }
 
-// LightCategory returns human-readable category
+// Category determines the numeric light category of the reading under the
+// default three-level scheme; use CategoryScheme.Categorize for another
+func (r *LightReading) Category() Category {
+	return categoryForLux(r.Lux)
+}
+
+// CategoryWithHysteresis determines the category given the previous one,
+// requiring lux to cross a boundary by at least margin before the category
+// changes: to reach boundary+margin going up, or to fall below
+// boundary-margin going down, as the boundary itself belongs to the level
+// above. A margin of zero behaves exactly like Category.
+func (r *LightReading) CategoryWithHysteresis(prev Category, margin float64) Category {
+	raw := r.Category()
+	switch {
+	case raw > prev:
+		// Moving up: judge as if the reading were margin lower, but never drop below prev
+		return max(categoryForLux(r.Lux-margin), prev)
+	case raw < prev:
+		// Moving down: judge as if the reading were margin higher, but never rise above prev
+		return min(categoryForLux(r.Lux+margin), prev)
+	}
+	return raw
+}
+
+// LightCategory returns human-readable category using the default English labels
 func (r *LightReading) LightCategory() string {
-	if r.IsLowLight() {
-		return "Low Light"
-	} else if r.IsMediumLight() {
-		return "Medium Light"
+	return DefaultCategoryLabels.Label(r.Category())
+}
+
+// EqualWithin is DefaultCategoryScheme.EqualWithin(r, other, luxEpsilon)
+func (r *LightReading) EqualWithin(other *LightReading, luxEpsilon float64) bool {
+	return DefaultCategoryScheme.EqualWithin(r, other, luxEpsilon)
+}
+
+// ReadingDiff describes how a reading differs from an earlier one
+type ReadingDiff struct {
+	LuxDelta           float64 // later minus earlier
+	From               Category
+	To                 Category
+	TemperatureChanged bool // set, cleared, or a different value
+	Elapsed            time.Duration
+}
+
+// CategoryChanged reports whether the readings fall in different categories
+func (d ReadingDiff) CategoryChanged() bool {
+	return d.From != d.To
+}
+
+// Diff describes what changed from r to the later reading next
+func (r *LightReading) Diff(next *LightReading) ReadingDiff {
+	return ReadingDiff{
+		LuxDelta:           next.Lux - r.Lux,
+		From:               r.Category(),
+		To:                 next.Category(),
+		TemperatureChanged: !equalTemperature(r.TemperatureC, next.TemperatureC),
+		Elapsed:            next.Timestamp.Sub(r.Timestamp),
+	}
+}
+
+// equalTemperature compares optional temperatures, treating two unset
+// values as equal
+func equalTemperature(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
 	}
-	return "High Light"
+	return *a == *b
 }
@@ -1,7 +1,10 @@
 package domain
 
 import (
+	"errors"
+	"math"
 	"testing"
+	"time"
 )
 
 func TestNewLightReading(t *testing.T) {
@@ -25,6 +28,21 @@ func TestNewLightReading(t *testing.T) {
 			lux:     -10.0,
 			wantErr: true,
 		},
+		{
+			name:    "NaN lux is invalid",
+			lux:     math.NaN(),
+			wantErr: true,
+		},
+		{
+			name:    "positive infinity is invalid",
+			lux:     math.Inf(1),
+			wantErr: true,
+		},
+		{
+			name:    "negative infinity is invalid",
+			lux:     math.Inf(-1),
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -90,3 +108,322 @@ func TestLightReading_LightCategory(t *testing.T) {
 		})
 	}
 }
+
+func TestCategoryLabels_CustomSet(t *testing.T) {
+	spanish := CategoryLabels{
+		CategoryLow:    "Poca luz",
+		CategoryMedium: "Luz media",
+		CategoryHigh:   "Mucha luz",
+	}
+
+	tests := []struct {
+		lux  float64
+		want string
+	}{
+		{lux: 100, want: "Poca luz"},
+		{lux: 500, want: "Luz media"},
+		{lux: 3000, want: "Mucha luz"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			reading, _ := NewLightReading(tt.lux)
+			if got := spanish.Label(reading.Category()); got != tt.want {
+				t.Errorf("Label() = %v, want %v for lux %v", got, tt.want, tt.lux)
+			}
+		})
+	}
+}
+
+func TestCategoryLabels_FallsBackToDefault(t *testing.T) {
+	partial := CategoryLabels{CategoryHigh: "Direct Sun"}
+
+	if got := partial.Label(CategoryLow); got != "Low Light" {
+		t.Errorf("Label(CategoryLow) = %v, want default %q", got, "Low Light")
+	}
+	if got := partial.Label(CategoryHigh); got != "Direct Sun" {
+		t.Errorf("Label(CategoryHigh) = %v, want %q", got, "Direct Sun")
+	}
+}
+
+func TestCategorizer_DitheringAroundBoundaryDoesNotFlap(t *testing.T) {
+	c := NewCategorizer(20)
+
+	// Start clearly low, then dither around the 200 lux boundary within the margin
+	first, _ := NewLightReading(150)
+	if got := c.Categorize(first); got != CategoryLow {
+		t.Fatalf("first reading: got %v, want CategoryLow", got)
+	}
+
+	for _, lux := range []float64{195, 205, 199, 210, 201, 219, 190, 215} {
+		r, _ := NewLightReading(lux)
+		if got := c.Categorize(r); got != CategoryLow {
+			t.Errorf("lux %v: got %v, want CategoryLow (within margin)", lux, got)
+		}
+	}
+
+	// Crossing the boundary by more than the margin switches category
+	r, _ := NewLightReading(221)
+	if got := c.Categorize(r); got != CategoryMedium {
+		t.Fatalf("lux 221: got %v, want CategoryMedium", got)
+	}
+
+	// Now medium: dipping back below 200 but within the margin stays medium
+	for _, lux := range []float64{199, 185, 205, 181} {
+		r, _ := NewLightReading(lux)
+		if got := c.Categorize(r); got != CategoryMedium {
+			t.Errorf("lux %v: got %v, want CategoryMedium (within margin)", lux, got)
+		}
+	}
+
+	r, _ = NewLightReading(179)
+	if got := c.Categorize(r); got != CategoryLow {
+		t.Errorf("lux 179: got %v, want CategoryLow", got)
+	}
+}
+
+func TestLightReading_CategoryWithHysteresis(t *testing.T) {
+	tests := []struct {
+		name   string
+		lux    float64
+		prev   Category
+		margin float64
+		want   Category
+	}{
+		{"zero margin behaves like Category", 200, CategoryLow, 0, CategoryMedium},
+		{"same category unaffected", 1000, CategoryMedium, 50, CategoryMedium},
+		{"up within margin stays", 2540, CategoryMedium, 50, CategoryMedium},
+		{"up beyond margin moves", 2551, CategoryMedium, 50, CategoryHigh},
+		{"up by exactly margin moves", 2550, CategoryMedium, 50, CategoryHigh},
+		{"down within margin stays", 2460, CategoryHigh, 50, CategoryHigh},
+		{"down to boundary minus margin stays", 2450, CategoryHigh, 50, CategoryHigh},
+		{"down beyond margin moves", 2449, CategoryHigh, 50, CategoryMedium},
+		{"jump two levels stops at the level cleared", 2520, CategoryLow, 50, CategoryMedium},
+		{"jump two levels clears both", 2600, CategoryLow, 50, CategoryHigh},
+		{"drop two levels stops at the level cleared", 180, CategoryHigh, 50, CategoryMedium},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := NewLightReading(tt.lux)
+			if got := r.CategoryWithHysteresis(tt.prev, tt.margin); got != tt.want {
+				t.Errorf("CategoryWithHysteresis(%v, %v) = %v, want %v for lux %v", tt.prev, tt.margin, got, tt.want, tt.lux)
+			}
+		})
+	}
+}
+
+func TestLightReading_SetTemperature(t *testing.T) {
+	tests := []struct {
+		name    string
+		celsius float64
+		wantErr bool
+	}{
+		{name: "room temperature", celsius: 21.5},
+		{name: "freezing", celsius: -10},
+		{name: "absolute zero is valid", celsius: AbsoluteZeroC},
+		{name: "below absolute zero", celsius: -300, wantErr: true},
+		{name: "NaN", celsius: math.NaN(), wantErr: true},
+		{name: "infinity", celsius: math.Inf(1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reading, _ := NewLightReading(500)
+			err := reading.SetTemperature(tt.celsius)
+
+			if tt.wantErr {
+				if err != ErrInvalidTemperature {
+					t.Errorf("expected ErrInvalidTemperature, got %v", err)
+				}
+				if reading.TemperatureC != nil {
+					t.Error("expected temperature to stay unset on error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if reading.TemperatureC == nil || *reading.TemperatureC != tt.celsius {
+				t.Errorf("expected temperature %v, got %v", tt.celsius, reading.TemperatureC)
+			}
+		})
+	}
+}
+
+func TestNewLightReading_NonFiniteError(t *testing.T) {
+	for _, lux := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if _, err := NewLightReading(lux); !errors.Is(err, ErrNonFiniteLux) {
+			t.Errorf("NewLightReading(%v): expected ErrNonFiniteLux, got %v", lux, err)
+		}
+	}
+}
+
+func TestNewLightReadingAt(t *testing.T) {
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	reading, err := NewLightReadingAt(500, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reading.Timestamp.Equal(at) {
+		t.Errorf("expected timestamp %v, got %v", at, reading.Timestamp)
+	}
+
+	if _, err := NewLightReadingAt(math.NaN(), at); !errors.Is(err, ErrNonFiniteLux) {
+		t.Errorf("expected ErrNonFiniteLux, got %v", err)
+	}
+}
+
+func TestTimeInCategory(t *testing.T) {
+	base := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+	at := func(minutes int, lux float64) *LightReading {
+		return &LightReading{Lux: lux, Timestamp: base.Add(time.Duration(minutes) * time.Minute)}
+	}
+
+	readings := []*LightReading{
+		at(0, 100),   // low for 10m
+		at(10, 800),  // medium for 20m
+		at(30, 900),  // medium for 5m
+		at(35, 3000), // high: next reading is 2h later, capped at 30m
+		at(155, 50),  // low: final reading, 10m until end
+	}
+	end := base.Add(165 * time.Minute)
+
+	got := TimeInCategory(readings, end, 30*time.Minute)
+
+	want := map[Category]time.Duration{
+		CategoryLow:    20 * time.Minute,
+		CategoryMedium: 25 * time.Minute,
+		CategoryHigh:   30 * time.Minute,
+	}
+	for c, w := range want {
+		if got[c] != w {
+			t.Errorf("category %v: expected %v, got %v", c, w, got[c])
+		}
+	}
+
+	// Without a cap the gap is attributed in full
+	uncapped := TimeInCategory(readings, end, 0)
+	if uncapped[CategoryHigh] != 2*time.Hour {
+		t.Errorf("expected uncapped high time 2h, got %v", uncapped[CategoryHigh])
+	}
+
+	// A final reading at or after end contributes nothing
+	if got := TimeInCategory([]*LightReading{at(0, 100)}, base, time.Hour); got[CategoryLow] != 0 {
+		t.Errorf("expected no time for a reading at end, got %v", got[CategoryLow])
+	}
+}
+
+func TestLightReading_EqualWithin(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    float64
+		epsilon float64
+		want    bool
+	}{
+		{"identical", 500, 500, 0, true},
+		{"within epsilon", 500, 504, 5, true},
+		{"exactly epsilon apart", 500, 505, 5, true},
+		{"beyond epsilon", 500, 506, 5, false},
+		{"zero epsilon needs exact match", 500, 500.01, 0, false},
+		{"order does not matter", 504, 500, 5, true},
+		{"within epsilon across low/medium boundary", 199.5, 200, 5, false},
+		{"within epsilon across medium/high boundary", 2499, 2500, 5, false},
+		{"both just below boundary", 195, 199.99, 5, true},
+		{"both on boundary", 200, 200, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &LightReading{Lux: tt.a}
+			b := &LightReading{Lux: tt.b}
+			if got := a.EqualWithin(b, tt.epsilon); got != tt.want {
+				t.Errorf("EqualWithin(%v, %v, %v) = %v, want %v", tt.a, tt.b, tt.epsilon, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("nil", func(t *testing.T) {
+		var none *LightReading
+		if !none.EqualWithin(nil, 0) {
+			t.Error("expected two nil readings to be equal")
+		}
+		if none.EqualWithin(&LightReading{Lux: 100}, 1000) {
+			t.Error("expected nil and non-nil readings to differ")
+		}
+		if (&LightReading{Lux: 100}).EqualWithin(nil, 1000) {
+			t.Error("expected non-nil and nil readings to differ")
+		}
+	})
+}
+
+func TestLightReading_Diff(t *testing.T) {
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	celsius := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name            string
+		before, after   LightReading
+		wantDelta       float64
+		wantCategory    bool
+		wantTemperature bool
+	}{
+		{
+			name:      "no change",
+			before:    LightReading{Lux: 500, Timestamp: base},
+			after:     LightReading{Lux: 500, Timestamp: base.Add(time.Minute)},
+			wantDelta: 0,
+		},
+		{
+			name:         "crosses into medium",
+			before:       LightReading{Lux: 199, Timestamp: base},
+			after:        LightReading{Lux: 200, Timestamp: base.Add(time.Minute)},
+			wantDelta:    1,
+			wantCategory: true,
+		},
+		{
+			name:         "drops into medium",
+			before:       LightReading{Lux: 2500, Timestamp: base},
+			after:        LightReading{Lux: 2400, Timestamp: base.Add(time.Minute)},
+			wantDelta:    -100,
+			wantCategory: true,
+		},
+		{
+			name:            "temperature set",
+			before:          LightReading{Lux: 500, Timestamp: base},
+			after:           LightReading{Lux: 500, Timestamp: base.Add(time.Minute), TemperatureC: celsius(20)},
+			wantTemperature: true,
+		},
+		{
+			name:            "temperature changed",
+			before:          LightReading{Lux: 500, Timestamp: base, TemperatureC: celsius(20)},
+			after:           LightReading{Lux: 500, Timestamp: base.Add(time.Minute), TemperatureC: celsius(21)},
+			wantTemperature: true,
+		},
+		{
+			name:   "same temperature",
+			before: LightReading{Lux: 500, Timestamp: base, TemperatureC: celsius(20)},
+			after:  LightReading{Lux: 500, Timestamp: base.Add(time.Minute), TemperatureC: celsius(20)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := tt.before.Diff(&tt.after)
+			if d.LuxDelta != tt.wantDelta {
+				t.Errorf("expected lux delta %v, got %v", tt.wantDelta, d.LuxDelta)
+			}
+			if d.CategoryChanged() != tt.wantCategory {
+				t.Errorf("expected category changed %v, got %v (%v -> %v)", tt.wantCategory, d.CategoryChanged(), d.From, d.To)
+			}
+			if d.TemperatureChanged != tt.wantTemperature {
+				t.Errorf("expected temperature changed %v, got %v", tt.wantTemperature, d.TemperatureChanged)
+			}
+			if d.Elapsed != time.Minute {
+				t.Errorf("expected elapsed 1m, got %v", d.Elapsed)
+			}
+		})
+	}
+}
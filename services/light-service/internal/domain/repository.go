@@ -8,20 +8,124 @@ import (
 // ReadingRepository defines operations for storing/retrieving readings
 // This is a PORT - adapters (SQLite, Postgres, Memory) will implement it
 type ReadingRepository interface {
-	// SaveReading persists a reading
+	// SaveReading persists a reading, failing with ErrDuplicateTimestamp if
+	// one is already stored at its timestamp
 	SaveReading(ctx context.Context, reading *LightReading) error
 
+	// SaveReadings persists several readings atomically: either all are
+	// stored (with IDs assigned) or none are
+	SaveReadings(ctx context.Context, readings []*LightReading) error
+
+	// UpsertReading saves a reading, replacing any existing reading with the
+	// same timestamp (keeping its ID) instead of inserting a duplicate
+	UpsertReading(ctx context.Context, reading *LightReading) error
+
+	// UpsertReadings upserts several readings atomically
+	UpsertReadings(ctx context.Context, readings []*LightReading) error
+
 	// GetReading retrieves a specific reading by ID
 	GetReading(ctx context.Context, id int64) (*LightReading, error)
 
-	// GetReadingsInRange retrieves all readings within time range.
-	// Uses a half-open interval: inclusive start, exclusive end [start, end).
-	GetReadingsInRange(ctx context.Context, start, end time.Time) ([]*LightReading, error)
+	// GetReadingsByIDs retrieves the readings with the given IDs, in the order
+	// requested with duplicates removed. IDs with no reading are skipped.
+	GetReadingsByIDs(ctx context.Context, ids []int64) ([]*LightReading, error)
+
+	// GetReadingsInRange retrieves all readings within time range, oldest
+	// first. Uses a half-open interval: inclusive start, exclusive end
+	// [start, end). Options page through the range instead (see RangeQuery).
+	GetReadingsInRange(ctx context.Context, start, end time.Time, opts ...RangeOption) ([]*LightReading, error)
+
+	// GetReadingsInCategories is GetReadingsInRange restricted to readings
+	// whose level under scheme is one of categories; options page through
+	// the matching readings
+	GetReadingsInCategories(ctx context.Context, start, end time.Time, scheme *CategoryScheme, categories []Category, opts ...RangeOption) ([]*LightReading, error)
+
+	// AggregateReadingsInRange summarizes the readings in [start, end) per
+	// interval-wide bucket, as AggregateReadings does: buckets are aligned
+	// to the Unix epoch, empty ones are omitted and the rest come oldest
+	// first. interval must be a positive whole number of seconds.
+	AggregateReadingsInRange(ctx context.Context, start, end time.Time, interval time.Duration) ([]ReadingBucket, error)
+
+	// GetStatisticsInRange summarizes the lux of the readings in [start,
+	// end) as SummarizeLux does, in the store's query language where it can
+	// so the readings themselves needn't be fetched
+	GetStatisticsInRange(ctx context.Context, start, end time.Time) (LuxStatistics, error)
+
+	// GetRecordingDays returns the distinct calendar days in loc (UTC if nil)
+	// that have readings in [start, end), each as midnight in loc, oldest first
+	GetRecordingDays(ctx context.Context, start, end time.Time, loc *time.Location) ([]time.Time, error)
+
+	// GetRecentReadings retrieves the most recent readings, at most limit of
+	// them, in chronological (ascending) order
+	GetRecentReadings(ctx context.Context, limit int) ([]*LightReading, error)
+
+	// ListReadings pages through all readings in (timestamp, ID) order,
+	// returning up to limit readings after the cursor. The zero cursor
+	// starts from the oldest reading; an empty result means the end.
+	ListReadings(ctx context.Context, after ReadingCursor, limit int) ([]*LightReading, error)
 
 	// GetLatestReading retrieves the most recent reading
 	GetLatestReading(ctx context.Context) (*LightReading, error)
 
-	// DeleteOldReadings removes readings older than specified duration
+	// GetReadingAsOf retrieves the reading that was current at the given
+	// time: the latest one at or before it. Returns ErrReadingNotFound if
+	// there are no readings that old.
+	GetReadingAsOf(ctx context.Context, at time.Time) (*LightReading, error)
+
+	// SaveCategoryEvent persists a category transition
+	SaveCategoryEvent(ctx context.Context, event *CategoryEvent) error
+
+	// GetCategoryEvents retrieves category transitions in [start, end),
+	// oldest first
+	GetCategoryEvents(ctx context.Context, start, end time.Time) ([]*CategoryEvent, error)
+
+	// ReplaceCategoryEvents atomically replaces the stored category
+	// transitions in [start, end) with events, which must fall in that
+	// range, assigning their IDs. Events outside the range are kept.
+	ReplaceCategoryEvents(ctx context.Context, start, end time.Time, events []*CategoryEvent) error
+
+	// Stats reports the size of the store
+	Stats(ctx context.Context) (*StorageStats, error)
+
+	// DeleteOldReadings removes readings older than specified duration and
+	// returns how many were deleted
 	// Business rule: We might want to retain only last 30 days
-	DeleteOldReadings(ctx context.Context, olderThan time.Duration) error
+	DeleteOldReadings(ctx context.Context, olderThan time.Duration) (int64, error)
+}
+
+// ReadingCursor marks a position in timestamp order. Build the next page's
+// cursor from the last reading of the previous page with CursorAfter.
+type ReadingCursor struct {
+	Timestamp time.Time
+	ID        int64
+}
+
+// CursorAfter returns the cursor positioned just after r
+func CursorAfter(r *LightReading) ReadingCursor {
+	return ReadingCursor{Timestamp: r.Timestamp, ID: r.ID}
+}
+
+// Before reports whether r sorts after the cursor position
+func (c ReadingCursor) Before(r *LightReading) bool {
+	if r.Timestamp.Equal(c.Timestamp) {
+		return r.ID > c.ID
+	}
+	return r.Timestamp.After(c.Timestamp)
+}
+
+// StorageStats describes how much the repository is holding
+type StorageStats struct {
+	ReadingCount int64
+	Oldest       time.Time // zero when there are no readings
+	Newest       time.Time // zero when there are no readings
+	SizeBytes    int64     // on-disk size; 0 for stores without one
+}
+
+// CalendarDay returns midnight in loc of the local day t falls on
+func CalendarDay(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
 }
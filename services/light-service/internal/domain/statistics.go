@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"math"
+	"slices"
+)
+
+// LuxStatistics summarizes the lux of the readings in a time range
+type LuxStatistics struct {
+	Count      int64
+	AverageLux float64
+	MinLux     float64
+	MaxLux     float64
+	MedianLux  float64
+	P95Lux     float64
+	StdDevLux  float64 // population standard deviation: the readings are the whole population, not a sample
+}
+
+// SummarizeLux computes LuxStatistics for readings, for stores that can't
+// in their own query language. Non-finite lux values are skipped. Without
+// readings every statistic is zero.
+func SummarizeLux(readings []*LightReading) LuxStatistics {
+	values := make([]float64, 0, len(readings))
+	var sum float64
+	for _, r := range readings {
+		if !math.IsNaN(r.Lux) && !math.IsInf(r.Lux, 0) {
+			values = append(values, r.Lux)
+			sum += r.Lux
+		}
+	}
+	if len(values) == 0 {
+		return LuxStatistics{}
+	}
+	slices.Sort(values)
+
+	n := float64(len(values))
+	mean := sum / n
+	var squares float64
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+
+	return LuxStatistics{
+		Count:      int64(len(values)),
+		AverageLux: mean,
+		MinLux:     values[0],
+		MaxLux:     values[len(values)-1],
+		MedianLux:  Percentile(values, 50),
+		P95Lux:     Percentile(values, 95),
+		StdDevLux:  math.Sqrt(squares / n),
+	}
+}
+
+// Percentile returns the p-th percentile (0-100) of sorted values by linear
+// interpolation between closest ranks: with rank p/100 * (n-1) split into
+// whole part k and fraction f, the result is
+// sorted[k] + f*(sorted[k+1]-sorted[k]). This is the "inclusive" method
+// (Excel's PERCENTILE.INC, NumPy's default, PostgreSQL's percentile_cont),
+// so p0 is the minimum, p100 the maximum and p50 the median. Empty input
+// gives 0.
+func Percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	k, f := PercentileRank(len(sorted), p)
+	if k >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[k] + f*(sorted[k+1]-sorted[k])
+}
+
+// PercentileRank splits the rank of the p-th percentile of n sorted values
+// into the index k of the value at or below it and the fraction f of the
+// way to the next one, for stores that fetch just those two values
+func PercentileRank(n int, p float64) (k int, f float64) {
+	rank := p / 100 * float64(n-1)
+	k = int(rank)
+	return k, rank - float64(k)
+}
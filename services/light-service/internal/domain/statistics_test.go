@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSummarizeLux(t *testing.T) {
+	var readings []*LightReading
+	for _, lux := range []float64{40, 10, 30, 20, 50, math.NaN()} {
+		readings = append(readings, &LightReading{Lux: lux, Timestamp: time.Now()})
+	}
+
+	got := SummarizeLux(readings)
+	want := LuxStatistics{
+		Count:      5, // the NaN is skipped
+		AverageLux: 30,
+		MinLux:     10,
+		MaxLux:     50,
+		MedianLux:  30,
+		P95Lux:     48, // rank 3.8: 40 + 0.8 * (50 - 40)
+		StdDevLux:  math.Sqrt(200),
+	}
+	if math.Abs(got.StdDevLux-want.StdDevLux) > 1e-9 {
+		t.Errorf("expected stddev %v, got %v", want.StdDevLux, got.StdDevLux)
+	}
+	got.StdDevLux = want.StdDevLux
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	if empty := SummarizeLux(nil); empty != (LuxStatistics{}) {
+		t.Errorf("expected zero statistics without readings, got %+v", empty)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []float64{10, 20, 30, 40}
+
+	tests := []struct {
+		p    float64
+		want float64
+	}{
+		{0, 10},
+		{50, 25},
+		{100, 40},
+		{90, 37},
+	}
+	for _, tt := range tests {
+		if got := Percentile(sorted, tt.p); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("p%v: got %v, want %v", tt.p, got, tt.want)
+		}
+	}
+	if got := Percentile([]float64{7}, 95); got != 7 {
+		t.Errorf("expected a single value for any percentile, got %v", got)
+	}
+	if got := Percentile(nil, 50); got != 0 {
+		t.Errorf("expected 0 without values, got %v", got)
+	}
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// TimeInCategory is DefaultCategoryScheme.TimeInCategory, attributing time
+// to the three default levels
+func TimeInCategory(readings []*LightReading, end time.Time, maxGap time.Duration) map[Category]time.Duration {
+	return DefaultCategoryScheme.TimeInCategory(readings, end, maxGap)
+}
+
+// TimeInCategory attributes the time covered by readings to the scheme's
+// levels, each of which is present in the result. Readings must be in
+// chronological order. Each interval between consecutive readings counts
+// towards the earlier reading's level; the final reading covers the time up
+// to end. No single reading is credited with more than maxGap, so a gap in
+// recording doesn't let one stale reading claim hours. A maxGap of 0
+// disables the cap.
+func (s *CategoryScheme) TimeInCategory(readings []*LightReading, end time.Time, maxGap time.Duration) map[Category]time.Duration {
+	durations := make(map[Category]time.Duration, s.Levels())
+	for c := range s.Levels() {
+		durations[Category(c)] = 0
+	}
+
+	for i, r := range readings {
+		until := end
+		if i+1 < len(readings) {
+			until = readings[i+1].Timestamp
+		}
+
+		d := until.Sub(r.Timestamp)
+		if d <= 0 {
+			continue
+		}
+		if maxGap > 0 && d > maxGap {
+			d = maxGap
+		}
+		durations[s.Categorize(r.Lux)] += d
+	}
+
+	return durations
+}
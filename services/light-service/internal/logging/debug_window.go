@@ -0,0 +1,89 @@
+package logging
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// DebugWindow temporarily lowers the global zerolog level to debug and
+// restores it automatically, so a running service can be diagnosed without
+// a restart. It is safe for concurrent use.
+type DebugWindow struct {
+	mu     sync.Mutex
+	active bool
+	base   zerolog.Level // level to restore when the window closes
+	timer  *time.Timer
+	gen    uint64 // bumped on every Open/Close so a superseded timer is ignored
+}
+
+// NewDebugWindow creates a closed window
+func NewDebugWindow() *DebugWindow {
+	return &DebugWindow{}
+}
+
+// Open enables debug logging for d. Opening an already-open window extends
+// it to d from now; the level restored afterwards is still the one in force
+// before the first Open.
+func (w *DebugWindow) Open(d time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.active {
+		w.base = zerolog.GlobalLevel()
+		w.active = true
+		if w.base > zerolog.DebugLevel {
+			zerolog.SetGlobalLevel(zerolog.DebugLevel)
+		}
+	}
+
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.gen++
+	gen := w.gen
+	w.timer = time.AfterFunc(d, func() { w.expire(gen) })
+
+	log.Info().Dur("duration", d).Msg("debug logging enabled")
+}
+
+// Close restores the original level now, if the window is open
+func (w *DebugWindow) Close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closeLocked()
+}
+
+// Active reports whether the window is open
+func (w *DebugWindow) Active() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.active
+}
+
+// expire closes the window unless it has been reopened or closed since the
+// timer for gen was set
+func (w *DebugWindow) expire(gen uint64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if gen == w.gen {
+		w.closeLocked()
+	}
+}
+
+func (w *DebugWindow) closeLocked() {
+	if !w.active {
+		return
+	}
+	if w.timer != nil {
+		w.timer.Stop()
+		w.timer = nil
+	}
+	w.gen++
+	w.active = false
+	zerolog.SetGlobalLevel(w.base)
+
+	log.Info().Str("level", w.base.String()).Msg("debug logging window closed")
+}
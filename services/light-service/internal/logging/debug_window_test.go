@@ -0,0 +1,121 @@
+package logging
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// captureLogs routes the global logger to a buffer at info level
+func captureLogs(t *testing.T) *syncBuffer {
+	t.Helper()
+
+	buf := &syncBuffer{}
+	originalLogger, originalLevel := log.Logger, zerolog.GlobalLevel()
+	log.Logger = zerolog.New(buf)
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	t.Cleanup(func() {
+		log.Logger = originalLogger
+		zerolog.SetGlobalLevel(originalLevel)
+	})
+	return buf
+}
+
+// syncBuffer is a bytes.Buffer safe to write from the expiry goroutine
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// waitClosed polls until the window expires
+func waitClosed(t *testing.T, w *DebugWindow) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for w.Active() {
+		if time.Now().After(deadline) {
+			t.Fatal("debug window did not close")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestDebugWindow_RaisesAndReverts(t *testing.T) {
+	buf := captureLogs(t)
+	w := NewDebugWindow()
+
+	log.Debug().Msg("before")
+	w.Open(50 * time.Millisecond)
+	log.Debug().Msg("during")
+	waitClosed(t, w)
+	log.Debug().Msg("after")
+
+	out := buf.String()
+	if strings.Contains(out, `"before"`) || strings.Contains(out, `"after"`) {
+		t.Errorf("expected debug suppressed outside the window, got %s", out)
+	}
+	if !strings.Contains(out, `"during"`) {
+		t.Errorf("expected debug output inside the window, got %s", out)
+	}
+	if zerolog.GlobalLevel() != zerolog.InfoLevel {
+		t.Errorf("expected level reverted to info, got %v", zerolog.GlobalLevel())
+	}
+}
+
+func TestDebugWindow_ConcurrentOpensRevertToOriginal(t *testing.T) {
+	captureLogs(t)
+	w := NewDebugWindow()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.Open(20 * time.Millisecond)
+		}()
+	}
+	wg.Wait()
+
+	if zerolog.GlobalLevel() != zerolog.DebugLevel {
+		t.Errorf("expected debug level while open, got %v", zerolog.GlobalLevel())
+	}
+	waitClosed(t, w)
+	if zerolog.GlobalLevel() != zerolog.InfoLevel {
+		t.Errorf("expected level reverted to info, got %v", zerolog.GlobalLevel())
+	}
+}
+
+func TestDebugWindow_ReopenExtends(t *testing.T) {
+	captureLogs(t)
+	w := NewDebugWindow()
+
+	w.Open(30 * time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	w.Open(time.Hour)
+	time.Sleep(30 * time.Millisecond)
+
+	// The first timer has fired by now but was superseded
+	if !w.Active() {
+		t.Fatal("expected reopened window to still be active")
+	}
+	w.Close()
+	if zerolog.GlobalLevel() != zerolog.InfoLevel {
+		t.Errorf("expected Close to revert to info, got %v", zerolog.GlobalLevel())
+	}
+}
@@ -0,0 +1,151 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// Notifier delivers fired alerts somewhere a person will see them
+// This is a PORT - adapters (webhook) will implement it
+type Notifier interface {
+	Notify(ctx context.Context, alert *domain.Alert) error
+}
+
+// Notifiers sends each alert to every notifier in turn, so one failing
+// doesn't keep the alert from the others
+type Notifiers []Notifier
+
+// Notify sends alert to each notifier, returning their errors joined
+func (ns Notifiers) Notify(ctx context.Context, alert *domain.Alert) error {
+	var errs []error
+	for _, n := range ns {
+		if err := n.Notify(ctx, alert); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// AlertEvaluator checks each recorded reading against the stored alert
+// rules. A rule fires once its condition has held for the rule's duration,
+// and fires again only after a reading clears the condition.
+type AlertEvaluator struct {
+	repo     domain.AlertRepository
+	notifier Notifier // nil only stores and logs alerts
+
+	mu       sync.Mutex
+	breached map[int64]time.Time // rule ID → timestamp of the first breaching reading
+	fired    map[int64]bool      // rules that fired during the current breach
+}
+
+// NewAlertEvaluator creates an evaluator over the rules in repo. notifier may
+// be nil.
+func NewAlertEvaluator(repo domain.AlertRepository, notifier Notifier) *AlertEvaluator {
+	return &AlertEvaluator{
+		repo:     repo,
+		notifier: notifier,
+		breached: make(map[int64]time.Time),
+		fired:    make(map[int64]bool),
+	}
+}
+
+// alertEventBuffer is how many recorded readings may wait while the
+// evaluator is busy, e.g. with a slow webhook, before some are missed
+const alertEventBuffer = 16
+
+// Subscribe evaluates the reading of each ReadingRecorded event published
+// on bus from now until ctx is done, logging under the recording cycle's
+// correlation ID
+func (e *AlertEvaluator) Subscribe(ctx context.Context, bus *EventBus) {
+	bus.Handle(ctx, alertEventBuffer, func(event domain.ReadingRecorded) {
+		logger := log.With().Str("correlation_id", event.CorrelationID).Logger()
+		ctx := logger.WithContext(WithCorrelationID(ctx, event.CorrelationID))
+		e.Evaluate(ctx, event.Reading)
+	})
+}
+
+// Evaluate advances every rule with a new reading, saving and sending any
+// alerts it fires. Failures are logged, never returned: alerting must not
+// hold up recording.
+func (e *AlertEvaluator) Evaluate(ctx context.Context, reading *domain.LightReading) {
+	logger := zerolog.Ctx(ctx)
+
+	rules, err := e.repo.ListRules(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to list alert rules")
+		return
+	}
+
+	for _, alert := range e.advance(rules, reading) {
+		if err := e.repo.SaveAlert(ctx, alert); err != nil {
+			logger.Error().Err(err).Int64("rule_id", alert.RuleID).Msg("failed to save alert")
+		}
+		logger.Warn().
+			Int64("rule_id", alert.RuleID).
+			Str("rule", alert.RuleName).
+			Float64("lux", alert.Lux).
+			Time("since", alert.Since).
+			Msg("alert fired")
+
+		if e.notifier != nil {
+			if err := e.notifier.Notify(ctx, alert); err != nil {
+				logger.Error().Err(err).Int64("rule_id", alert.RuleID).Msg("failed to send alert")
+			}
+		}
+	}
+}
+
+// advance updates the per-rule breach state with reading and returns the
+// alerts that fire
+func (e *AlertEvaluator) advance(rules []*domain.AlertRule, reading *domain.LightReading) []*domain.Alert {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var alerts []*domain.Alert
+	live := make(map[int64]bool, len(rules))
+	for _, rule := range rules {
+		live[rule.ID] = true
+
+		if !rule.Breached(reading.Lux) {
+			delete(e.breached, rule.ID)
+			delete(e.fired, rule.ID)
+			continue
+		}
+
+		since, ok := e.breached[rule.ID]
+		if !ok {
+			since = reading.Timestamp
+			e.breached[rule.ID] = since
+		}
+		if e.fired[rule.ID] || reading.Timestamp.Sub(since) < rule.For {
+			continue
+		}
+
+		e.fired[rule.ID] = true
+		alerts = append(alerts, &domain.Alert{
+			RuleID:       rule.ID,
+			RuleName:     rule.Name,
+			Condition:    rule.Condition,
+			ThresholdLux: rule.ThresholdLux,
+			Lux:          reading.Lux,
+			Since:        since,
+			FiredAt:      reading.Timestamp,
+		})
+	}
+
+	// Forget deleted rules
+	for id := range e.breached {
+		if !live[id] {
+			delete(e.breached, id)
+			delete(e.fired, id)
+		}
+	}
+	return alerts
+}
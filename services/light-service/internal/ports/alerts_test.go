@@ -0,0 +1,160 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/mock"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// recordingNotifier keeps every alert it is sent
+type recordingNotifier struct {
+	mu     sync.Mutex
+	alerts []*domain.Alert
+	err    error
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, alert *domain.Alert) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.alerts = append(n.alerts, alert)
+	return n.err
+}
+
+func (n *recordingNotifier) count() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return len(n.alerts)
+}
+
+func TestAlertEvaluator_FiresAfterDuration(t *testing.T) {
+	repo := memory.NewAlertRepository()
+	notifier := &recordingNotifier{}
+	evaluator := NewAlertEvaluator(repo, notifier)
+	ctx := context.Background()
+
+	_ = repo.CreateRule(ctx, &domain.AlertRule{Name: "too dark", Condition: domain.AlertBelow, ThresholdLux: 150, For: 2 * time.Hour})
+
+	start := time.Date(2024, 6, 1, 18, 0, 0, 0, time.UTC)
+	evaluate := func(lux float64, after time.Duration) {
+		r, _ := domain.NewLightReadingAt(lux, start.Add(after))
+		evaluator.Evaluate(ctx, r)
+	}
+
+	// Dark for 1h59m: not yet
+	evaluate(100, 0)
+	evaluate(120, time.Hour)
+	evaluate(90, 119*time.Minute)
+	if notifier.count() != 0 {
+		t.Fatalf("expected no alert before 2h, got %d", notifier.count())
+	}
+
+	// Two hours in it fires, once
+	evaluate(80, 2*time.Hour)
+	evaluate(70, 3*time.Hour)
+	if notifier.count() != 1 {
+		t.Fatalf("expected exactly 1 alert, got %d", notifier.count())
+	}
+	alert := notifier.alerts[0]
+	if !alert.Since.Equal(start) || !alert.FiredAt.Equal(start.Add(2*time.Hour)) || alert.Lux != 80 {
+		t.Errorf("unexpected alert %+v", alert)
+	}
+
+	stored, err := repo.GetAlerts(ctx, start, start.Add(24*time.Hour))
+	if err != nil || len(stored) != 1 || stored[0].RuleName != "too dark" {
+		t.Fatalf("expected the alert to be stored, got %d alerts, %v", len(stored), err)
+	}
+
+	// A bright reading clears the breach; the next one starts from scratch
+	evaluate(300, 4*time.Hour)
+	evaluate(100, 5*time.Hour)
+	evaluate(100, 6*time.Hour)
+	if notifier.count() != 1 {
+		t.Fatalf("expected the rule to wait another 2h after clearing, got %d alerts", notifier.count())
+	}
+	evaluate(100, 7*time.Hour)
+	if notifier.count() != 2 {
+		t.Errorf("expected a second alert, got %d", notifier.count())
+	}
+}
+
+func TestAlertEvaluator_AboveWithoutDuration(t *testing.T) {
+	repo := memory.NewAlertRepository()
+	evaluator := NewAlertEvaluator(repo, nil) // no notifier: alerts are only stored
+	ctx := context.Background()
+
+	_ = repo.CreateRule(ctx, &domain.AlertRule{Name: "scorching", Condition: domain.AlertAbove, ThresholdLux: 30000})
+
+	now := time.Now()
+	r, _ := domain.NewLightReadingAt(30000, now.Add(-time.Minute))
+	evaluator.Evaluate(ctx, r) // exactly at the threshold isn't above it
+	r, _ = domain.NewLightReadingAt(45000, now)
+	evaluator.Evaluate(ctx, r)
+
+	alerts, _ := repo.GetAlerts(ctx, now.Add(-time.Hour), now.Add(time.Hour))
+	if len(alerts) != 1 || alerts[0].Lux != 45000 {
+		t.Errorf("expected one alert at 45000 lux, got %d", len(alerts))
+	}
+}
+
+func TestAlertEvaluator_NotifierFailureStillStoresAlert(t *testing.T) {
+	repo := memory.NewAlertRepository()
+	notifier := &recordingNotifier{err: errors.New("webhook down")}
+	evaluator := NewAlertEvaluator(repo, notifier)
+	ctx := context.Background()
+
+	_ = repo.CreateRule(ctx, &domain.AlertRule{Name: "dark", Condition: domain.AlertBelow, ThresholdLux: 150})
+	r, _ := domain.NewLightReading(10)
+	evaluator.Evaluate(ctx, r)
+
+	alerts, _ := repo.GetAlerts(ctx, r.Timestamp, r.Timestamp.Add(time.Second))
+	if notifier.count() != 1 || len(alerts) != 1 {
+		t.Errorf("expected 1 notification attempt and 1 stored alert, got %d and %d", notifier.count(), len(alerts))
+	}
+}
+
+func TestNotifiers_SendsToEveryNotifier(t *testing.T) {
+	failing := &recordingNotifier{err: errors.New("endpoint down")}
+	working := &recordingNotifier{}
+	alert := &domain.Alert{RuleName: "dim", Lux: 100}
+
+	err := Notifiers{failing, working}.Notify(context.Background(), alert)
+	if err == nil || !strings.Contains(err.Error(), "endpoint down") {
+		t.Errorf("expected the failing notifier's error, got %v", err)
+	}
+	if failing.count() != 1 || working.count() != 1 {
+		t.Errorf("expected both notifiers to get the alert, got %d and %d", failing.count(), working.count())
+	}
+}
+
+func TestRecordOnce_EvaluatesAlerts(t *testing.T) {
+	alerts := memory.NewAlertRepository()
+	notifier := &recordingNotifier{}
+	ctx := context.Background()
+	_ = alerts.CreateRule(ctx, &domain.AlertRule{Name: "dim", Condition: domain.AlertBelow, ThresholdLux: 600})
+
+	bus := NewEventBus()
+	subscribed, stop := context.WithCancel(ctx)
+	defer stop()
+	NewAlertEvaluator(alerts, notifier).Subscribe(subscribed, bus)
+
+	recorder := NewRecorder(mock.NewFakeSensor(500.0, 0), memory.NewReadingRepository(), 0, WithEventBus(bus))
+	recorder.recordOnce(ctx)
+
+	// Evaluation happens on the subscriber's goroutine
+	deadline := time.Now().Add(5 * time.Second)
+	for notifier.count() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	notifier.mu.Lock()
+	defer notifier.mu.Unlock()
+	if len(notifier.alerts) != 1 || notifier.alerts[0].Lux != 500 {
+		t.Errorf("expected the recorded 500 lux reading to fire the rule, got %d alerts", len(notifier.alerts))
+	}
+}
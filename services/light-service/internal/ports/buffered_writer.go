@@ -0,0 +1,174 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// shutdownFlushTimeout bounds the final flush in Drain,
+// so an unreachable store can't hold up shutdown
+const shutdownFlushTimeout = 5 * time.Second
+
+// maxPendingBatches is how many batches' worth of readings a BufferedWriter
+// holds while the store keeps failing before it drops the oldest
+const maxPendingBatches = 10
+
+// BufferedWriter saves readings write-behind: it holds them in memory and
+// stores them with one SaveReadings call per batch, rather than one insert
+// per reading, which spares flash storage at short recording intervals. A
+// batch is flushed once it reaches its size or while Run is running, every
+// flush interval; Drain flushes whatever is left at shutdown.
+//
+// Buffered readings are not yet in the store, so history queries lag behind
+// by up to the flush interval.
+type BufferedWriter struct {
+	repo       domain.ReadingRepository
+	size       int
+	flushEvery time.Duration
+	clock      domain.Clock
+
+	mu      sync.Mutex
+	pending []*domain.LightReading
+
+	flushMu sync.Mutex // serializes flushes so batches are saved in order
+}
+
+// NewBufferedWriter creates a writer that saves to repo in batches of up to
+// size readings, flushing at least every flushEvery while Run is running
+func NewBufferedWriter(repo domain.ReadingRepository, size int, flushEvery time.Duration, clock domain.Clock) *BufferedWriter {
+	return &BufferedWriter{
+		repo:       repo,
+		size:       max(size, 1),
+		flushEvery: flushEvery,
+		clock:      clock,
+	}
+}
+
+// Write buffers a copy of reading, so the store never assigns an ID to a
+// reading the caller has shared, and flushes once a full batch is waiting.
+// The error is from that flush; the reading stays buffered for the next one.
+func (w *BufferedWriter) Write(ctx context.Context, reading *domain.LightReading) error {
+	saved := *reading
+
+	w.mu.Lock()
+	w.pending = append(w.pending, &saved)
+	full := len(w.pending) >= w.size
+	w.mu.Unlock()
+
+	if !full {
+		return nil
+	}
+	return w.Flush(ctx)
+}
+
+// Pending returns how many readings are waiting to be saved
+func (w *BufferedWriter) Pending() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.pending)
+}
+
+// Flush saves everything buffered. On failure the readings are kept for the
+// next flush, up to maxPendingBatches batches, beyond which the oldest are
+// dropped so a store that stays down can't exhaust memory. A batch refused
+// for a duplicate timestamp would fail every retry, so it is saved one
+// reading at a time instead, skipping the duplicates.
+func (w *BufferedWriter) Flush(ctx context.Context) error {
+	w.flushMu.Lock()
+	defer w.flushMu.Unlock()
+
+	w.mu.Lock()
+	batch := w.pending
+	w.pending = nil
+	w.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+	err := w.repo.SaveReadings(ctx, batch)
+	if errors.Is(err, domain.ErrDuplicateTimestamp) {
+		return w.saveEach(ctx, batch)
+	}
+	if err != nil {
+		w.requeue(batch)
+		return err
+	}
+	log.Debug().Int("readings", len(batch)).Msg("flushed buffered readings")
+	return nil
+}
+
+// saveEach saves batch reading by reading, dropping those whose timestamp
+// is already stored; on any other failure the rest are requeued
+func (w *BufferedWriter) saveEach(ctx context.Context, batch []*domain.LightReading) error {
+	skipped := 0
+	for i, reading := range batch {
+		err := w.repo.SaveReading(ctx, reading)
+		if errors.Is(err, domain.ErrDuplicateTimestamp) {
+			skipped++
+			continue
+		}
+		if err != nil {
+			w.requeue(batch[i:])
+			return err
+		}
+	}
+	log.Warn().Int("skipped", skipped).Int("readings", len(batch)).Msg("flushed buffered readings, skipping duplicate timestamps")
+	return nil
+}
+
+// requeue puts a failed batch back ahead of readings written since
+func (w *BufferedWriter) requeue(batch []*domain.LightReading) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.pending = append(batch, w.pending...)
+	if limit := w.size * maxPendingBatches; len(w.pending) > limit {
+		dropped := len(w.pending) - limit
+		w.pending = w.pending[dropped:]
+		log.Warn().Int("dropped", dropped).Msg("write buffer full; dropping oldest readings")
+	}
+}
+
+// Run flushes every flush interval until ctx is done. It leaves the last
+// readings buffered; call Drain once nothing more will be written.
+func (w *BufferedWriter) Run(ctx context.Context) {
+	if w.flushEvery <= 0 {
+		return
+	}
+	ticker := w.clock.NewTicker(w.flushEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C():
+			if err := w.Flush(ctx); err != nil && ctx.Err() == nil {
+				log.Error().Err(err).Int("pending", w.Pending()).Msg("failed to flush buffered readings")
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Drain flushes what is left at shutdown. It runs under its own deadline,
+// as ctx is usually cancelled by then; readings it can't save are lost.
+func (w *BufferedWriter) Drain(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownFlushTimeout)
+	defer cancel()
+
+	pending := w.Pending()
+	if err := w.Flush(ctx); err != nil {
+		log.Error().Err(err).Int("lost", w.Pending()).Msg("failed to flush buffered readings on shutdown")
+		return err
+	}
+	if pending > 0 {
+		log.Info().Int("readings", pending).Msg("flushed buffered readings on shutdown")
+	}
+	return nil
+}
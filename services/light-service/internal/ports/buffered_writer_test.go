@@ -0,0 +1,232 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/mock"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// batchRepo records the size of each SaveReadings call and fails them
+// while fail is set
+type batchRepo struct {
+	*memory.ReadingRepository
+
+	mu      sync.Mutex
+	batches []int
+	fail    bool
+}
+
+func (r *batchRepo) SaveReadings(ctx context.Context, readings []*domain.LightReading) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.fail {
+		return errors.New("disk full")
+	}
+	r.batches = append(r.batches, len(readings))
+	return r.ReadingRepository.SaveReadings(ctx, readings)
+}
+
+func (r *batchRepo) setFail(fail bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fail = fail
+}
+
+func (r *batchRepo) batchSizes() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int(nil), r.batches...)
+}
+
+func newBatchRepo() *batchRepo {
+	return &batchRepo{ReadingRepository: memory.NewReadingRepository()}
+}
+
+// writeReadings writes n readings a second apart
+func writeReadings(t *testing.T, w *BufferedWriter, n int) error {
+	t.Helper()
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	var err error
+	for i := range n {
+		reading, _ := domain.NewLightReadingAt(float64(100+i), base.Add(time.Duration(i)*time.Second))
+		err = w.Write(context.Background(), reading)
+	}
+	return err
+}
+
+func TestBufferedWriter_FlushesFullBatch(t *testing.T) {
+	repo := newBatchRepo()
+	w := NewBufferedWriter(repo, 3, 0, domain.RealClock{})
+
+	writeReadings(t, w, 2)
+	if got := repo.batchSizes(); len(got) != 0 || w.Pending() != 2 {
+		t.Fatalf("expected 2 readings buffered and none saved, got batches %v", got)
+	}
+
+	writeReadings(t, w, 1)
+	if got := repo.batchSizes(); len(got) != 1 || got[0] != 3 {
+		t.Errorf("expected one batch of 3, got %v", got)
+	}
+	if w.Pending() != 0 {
+		t.Errorf("expected an empty buffer, got %d", w.Pending())
+	}
+}
+
+func TestBufferedWriter_DoesNotAssignIDsToCallerReadings(t *testing.T) {
+	repo := newBatchRepo()
+	w := NewBufferedWriter(repo, 1, 0, domain.RealClock{})
+
+	reading, _ := domain.NewLightReading(500)
+	if err := w.Write(context.Background(), reading); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if reading.ID != 0 {
+		t.Errorf("expected the caller's reading untouched, got ID %d", reading.ID)
+	}
+	if latest, err := repo.GetLatestReading(context.Background()); err != nil || latest.Lux != 500 {
+		t.Errorf("expected the reading saved, got %v, %v", latest, err)
+	}
+}
+
+func TestBufferedWriter_FlushInterval(t *testing.T) {
+	clock := mock.NewFakeClock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	repo := newBatchRepo()
+	w := NewBufferedWriter(repo, 100, time.Minute, clock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		w.Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+	clock.WaitForTickers(1)
+
+	writeReadings(t, w, 5)
+	clock.Advance(time.Minute)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(repo.batchSizes()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the buffer to be flushed after the interval")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if got := repo.batchSizes(); got[0] != 5 {
+		t.Errorf("expected one batch of 5, got %v", got)
+	}
+}
+
+func TestBufferedWriter_RetriesFailedFlush(t *testing.T) {
+	repo := newBatchRepo()
+	repo.setFail(true)
+	w := NewBufferedWriter(repo, 2, 0, domain.RealClock{})
+
+	if err := writeReadings(t, w, 2); err == nil {
+		t.Fatal("expected the failed flush to be reported")
+	}
+	if w.Pending() != 2 {
+		t.Fatalf("expected the failed batch kept, got %d pending", w.Pending())
+	}
+
+	repo.setFail(false)
+	if err := w.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	if got := repo.batchSizes(); len(got) != 1 || got[0] != 2 {
+		t.Errorf("expected the kept readings saved in one batch, got %v", got)
+	}
+}
+
+func TestBufferedWriter_SkipsDuplicateTimestamps(t *testing.T) {
+	repo := newBatchRepo()
+	ctx := context.Background()
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	stored, _ := domain.NewLightReadingAt(50, base)
+	if err := repo.SaveReading(ctx, stored); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first reading clashes with the stored one, so the batch as a whole
+	// is refused; retrying it would fail forever
+	w := NewBufferedWriter(repo, 3, 0, domain.RealClock{})
+	if err := writeReadings(t, w, 3); err != nil {
+		t.Fatalf("expected the duplicate skipped, got %v", err)
+	}
+	if w.Pending() != 0 {
+		t.Errorf("expected nothing left buffered, got %d", w.Pending())
+	}
+
+	readings, err := repo.GetRecentReadings(ctx, 10)
+	if err != nil || len(readings) != 3 {
+		t.Fatalf("expected the stored reading and 2 new ones, got %v, %v", readings, err)
+	}
+	for _, r := range readings {
+		if r.Timestamp.Equal(base) && r.Lux != 50 {
+			t.Errorf("expected the stored reading kept, got %v lux", r.Lux)
+		}
+	}
+}
+
+func TestBufferedWriter_DropsOldestWhenStoreStaysDown(t *testing.T) {
+	repo := newBatchRepo()
+	repo.setFail(true)
+	w := NewBufferedWriter(repo, 2, 0, domain.RealClock{})
+
+	writeReadings(t, w, 2*maxPendingBatches+3)
+	if got, want := w.Pending(), 2*maxPendingBatches; got != want {
+		t.Fatalf("expected the buffer capped at %d, got %d", want, got)
+	}
+
+	repo.setFail(false)
+	w.Flush(context.Background())
+	oldest, err := repo.GetRecentReadings(context.Background(), 2*maxPendingBatches)
+	if err != nil || len(oldest) == 0 || oldest[0].Lux == 100 {
+		t.Errorf("expected the oldest readings dropped, got %v, %v", oldest, err)
+	}
+}
+
+func TestRecorder_WriteBufferFlushedOnShutdown(t *testing.T) {
+	clock := mock.NewFakeClock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	repo := newBatchRepo()
+	bus := NewEventBus()
+	published, unsubscribe := bus.Subscribe(4)
+	defer unsubscribe()
+	recorder := NewRecorder(mock.NewFakeSensor(500.0, 0), repo, time.Hour,
+		WithClock(clock), WithWriteBuffer(100, time.Hour), WithEventBus(bus))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		recorder.Start(ctx)
+		close(done)
+	}()
+
+	// The immediate recording is published but only buffered
+	select {
+	case event := <-published:
+		if event.Reading.Lux != 500 {
+			t.Errorf("expected a 500 lux reading, got %v", event.Reading.Lux)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the immediate recording to be published")
+	}
+	if got := repo.batchSizes(); len(got) != 0 {
+		t.Fatalf("expected nothing saved before shutdown, got batches %v", got)
+	}
+
+	cancel()
+	<-done
+	if got := repo.batchSizes(); len(got) != 1 || got[0] != 1 {
+		t.Errorf("expected the buffered reading flushed on shutdown, got batches %v", got)
+	}
+}
@@ -0,0 +1,84 @@
+package ports
+
+import (
+	"context"
+	"sync"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// Bus fans values out to subscribers. Publishing never blocks: a subscriber
+// whose buffer is full misses the value, so a stuck client can't hold up
+// the recorder.
+type Bus[T any] struct {
+	mu   sync.Mutex
+	subs map[chan T]struct{}
+}
+
+// NewBus creates a bus with no subscribers
+func NewBus[T any]() *Bus[T] {
+	return &Bus[T]{subs: make(map[chan T]struct{})}
+}
+
+// Subscribe returns a channel receiving values published from now on,
+// buffering up to buffer of them, and a function that unsubscribes and closes
+// the channel
+func (b *Bus[T]) Subscribe(buffer int) (<-chan T, func()) {
+	ch := make(chan T, buffer)
+
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subs, ch)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// Publish delivers v to every subscriber with room for it
+func (b *Bus[T]) Publish(v T) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subs {
+		select {
+		case ch <- v:
+		default:
+		}
+	}
+}
+
+// Handle calls handle with each value published from now on, from a
+// goroutine of its own until ctx is done, so a slow handler holds up
+// neither the publisher nor other subscribers. Up to buffer values wait
+// while handle is busy; as for any subscriber, later ones are missed.
+func (b *Bus[T]) Handle(ctx context.Context, buffer int, handle func(T)) {
+	values, unsubscribe := b.Subscribe(buffer)
+	go func() {
+		defer unsubscribe()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v := <-values:
+				handle(v)
+			}
+		}
+	}()
+}
+
+// EventBus carries the recorder's ReadingRecorded events to the live
+// streams, alert evaluation and any other integration acting on new
+// readings, none of which the recorder knows about
+type EventBus = Bus[domain.ReadingRecorded]
+
+// NewEventBus creates a bus with no subscribers
+func NewEventBus() *EventBus {
+	return NewBus[domain.ReadingRecorded]()
+}
@@ -0,0 +1,83 @@
+package ports
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestBus_HandleDoesNotBlockPublisher(t *testing.T) {
+	bus := NewBus[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	release := make(chan struct{})
+	handled := make(chan int, 4)
+	bus.Handle(ctx, 1, func(v int) {
+		<-release
+		handled <- v
+	})
+
+	// The handler is stuck on the first value and one more fits the buffer;
+	// the third is missed rather than holding up Publish
+	published := make(chan struct{})
+	go func() {
+		for v := range 3 {
+			bus.Publish(v)
+			time.Sleep(10 * time.Millisecond)
+		}
+		close(published)
+	}()
+	select {
+	case <-published:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected Publish not to wait for a busy handler")
+	}
+
+	close(release)
+	for want := range 2 {
+		select {
+		case got := <-handled:
+			if got != want {
+				t.Errorf("expected %d handled in order, got %d", want, got)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected %d to be handled", want)
+		}
+	}
+	select {
+	case got := <-handled:
+		t.Errorf("expected the value beyond the buffer to be missed, got %d", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestBus_HandleStopsWithContext(t *testing.T) {
+	bus := NewBus[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	handled := make(chan int, 1)
+	bus.Handle(ctx, 1, func(v int) { handled <- v })
+	cancel()
+
+	// Unsubscribing happens on the handler's goroutine
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		bus.mu.Lock()
+		n := len(bus.subs)
+		bus.mu.Unlock()
+		if n == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected the handler to unsubscribe once ctx is done")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	bus.Publish(1)
+	select {
+	case v := <-handled:
+		t.Errorf("expected nothing handled after cancel, got %d", v)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
@@ -0,0 +1,93 @@
+package ports
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// CachedSensor serves repeated reads from the last value for a short TTL,
+// protecting sensors that dislike rapid back-to-back reads when several
+// callers (the recorder, live reads, streams) poll at once
+type CachedSensor struct {
+	inner LightSensor
+	ttl   time.Duration
+	now   func() time.Time
+
+	mu       sync.Mutex
+	lux      float64
+	quality  domain.Quality
+	readAt   time.Time // zero until the first successful read
+	inFlight *sensorRead
+}
+
+// sensorRead is one underlying read shared by every caller that missed the
+// cache while it was running
+type sensorRead struct {
+	done    chan struct{}
+	lux     float64
+	quality domain.Quality
+	err     error
+}
+
+// NewCachedSensor wraps inner so reads within ttl of the last successful one
+// return its value. Failed reads are not cached.
+func NewCachedSensor(inner LightSensor, ttl time.Duration) *CachedSensor {
+	return &CachedSensor{inner: inner, ttl: ttl, now: time.Now}
+}
+
+// ReadLux returns the cached value if it is fresh, otherwise reads through
+func (s *CachedSensor) ReadLux(ctx context.Context) (float64, error) {
+	lux, _, err := s.ReadLuxWithQuality(ctx)
+	return lux, err
+}
+
+// ReadLuxWithQuality is ReadLux with the cached value's quality.
+// Concurrent misses share a single underlying read; a caller whose context
+// ends while waiting gives up without cancelling the read for the others.
+func (s *CachedSensor) ReadLuxWithQuality(ctx context.Context) (float64, domain.Quality, error) {
+	s.mu.Lock()
+	if !s.readAt.IsZero() && s.now().Sub(s.readAt) < s.ttl {
+		lux, quality := s.lux, s.quality
+		s.mu.Unlock()
+		return lux, quality, nil
+	}
+
+	call := s.inFlight
+	if call == nil {
+		call = &sensorRead{done: make(chan struct{})}
+		s.inFlight = call
+		go s.read(call)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.lux, call.quality, call.err
+	case <-ctx.Done():
+		return 0, "", ctx.Err()
+	}
+}
+
+// read performs the shared underlying read. It runs detached from any one
+// caller's context so an early cancellation doesn't fail the rest.
+func (s *CachedSensor) read(call *sensorRead) {
+	call.lux, call.quality, call.err = ReadLuxWithQuality(context.Background(), s.inner)
+
+	s.mu.Lock()
+	if call.err == nil {
+		s.lux, s.quality = call.lux, call.quality
+		s.readAt = s.now()
+	}
+	s.inFlight = nil
+	s.mu.Unlock()
+
+	close(call.done)
+}
+
+// Close closes the wrapped sensor
+func (s *CachedSensor) Close() error {
+	return s.inner.Close()
+}
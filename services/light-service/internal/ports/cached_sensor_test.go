@@ -0,0 +1,103 @@
+package ports
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// slowCountingSensor counts reads and holds each one until released, so
+// concurrent callers pile up on a single miss
+type slowCountingSensor struct {
+	reads   atomic.Int32
+	release chan struct{}
+	err     error
+}
+
+func (s *slowCountingSensor) ReadLux(ctx context.Context) (float64, error) {
+	n := s.reads.Add(1)
+	<-s.release
+	return float64(100 * n), s.err
+}
+
+func (s *slowCountingSensor) Close() error { return nil }
+
+func TestCachedSensor_ConcurrentReadsShareOneRead(t *testing.T) {
+	inner := &slowCountingSensor{release: make(chan struct{})}
+	sensor := NewCachedSensor(inner, time.Minute)
+
+	const callers = 50
+	var wg sync.WaitGroup
+	results := make([]float64, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			lux, err := sensor.ReadLux(context.Background())
+			if err != nil {
+				t.Errorf("ReadLux failed: %v", err)
+			}
+			results[i] = lux
+		}(i)
+	}
+
+	// Let the callers queue up behind the first miss before it completes
+	time.Sleep(20 * time.Millisecond)
+	close(inner.release)
+	wg.Wait()
+
+	if got := inner.reads.Load(); got != 1 {
+		t.Errorf("expected exactly 1 underlying read, got %d", got)
+	}
+	for i, lux := range results {
+		if lux != 100 {
+			t.Errorf("caller %d: expected 100 lux, got %v", i, lux)
+		}
+	}
+
+	// Within the TTL the cached value is served without another read
+	if lux, _ := sensor.ReadLux(context.Background()); lux != 100 || inner.reads.Load() != 1 {
+		t.Errorf("expected cached 100 lux from 1 read, got %v from %d", lux, inner.reads.Load())
+	}
+}
+
+func TestCachedSensor_ExpiresAfterTTL(t *testing.T) {
+	inner := &slowCountingSensor{release: make(chan struct{})}
+	close(inner.release)
+	sensor := NewCachedSensor(inner, time.Minute)
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	sensor.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, _ = sensor.ReadLux(ctx)
+	now = now.Add(59 * time.Second)
+	_, _ = sensor.ReadLux(ctx)
+	if got := inner.reads.Load(); got != 1 {
+		t.Fatalf("expected 1 read within TTL, got %d", got)
+	}
+
+	now = now.Add(time.Second)
+	if lux, _ := sensor.ReadLux(ctx); lux != 200 || inner.reads.Load() != 2 {
+		t.Errorf("expected a fresh read after TTL, got %v lux from %d reads", lux, inner.reads.Load())
+	}
+}
+
+func TestCachedSensor_ErrorsAreNotCached(t *testing.T) {
+	inner := &slowCountingSensor{release: make(chan struct{}), err: domain.ErrSensorUnavailable}
+	close(inner.release)
+	sensor := NewCachedSensor(inner, time.Minute)
+	ctx := context.Background()
+
+	if _, err := sensor.ReadLux(ctx); err == nil {
+		t.Fatal("expected the sensor error")
+	}
+	_, _ = sensor.ReadLux(ctx)
+	if got := inner.reads.Load(); got != 2 {
+		t.Errorf("expected a failed read to be retried, got %d reads", got)
+	}
+}
@@ -0,0 +1,72 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// CalibratedSensor applies a sensor's calibration to every read, so the
+// recorder and live reads both see corrected lux. The calibration can be
+// replaced while reads are in progress.
+type CalibratedSensor struct {
+	inner LightSensor
+
+	mu  sync.RWMutex
+	cal domain.Calibration
+}
+
+// NewCalibratedSensor wraps inner, correcting its reads with cal
+func NewCalibratedSensor(inner LightSensor, cal domain.Calibration) *CalibratedSensor {
+	return &CalibratedSensor{inner: inner, cal: cal}
+}
+
+// LoadCalibratedSensor wraps inner with the calibration stored for
+// sensorID, or the identity calibration if there is none
+func LoadCalibratedSensor(ctx context.Context, inner LightSensor, repo domain.CalibrationRepository, sensorID string) (*CalibratedSensor, error) {
+	cal, err := repo.GetCalibration(ctx, sensorID)
+	if errors.Is(err, domain.ErrCalibrationNotFound) {
+		identity := domain.IdentityCalibration(sensorID)
+		cal, err = &identity, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewCalibratedSensor(inner, *cal), nil
+}
+
+// Calibration returns the calibration currently applied
+func (s *CalibratedSensor) Calibration() domain.Calibration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cal
+}
+
+// SetCalibration replaces the calibration applied to later reads
+func (s *CalibratedSensor) SetCalibration(cal domain.Calibration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cal = cal
+}
+
+// ReadLux returns the inner sensor's reading, corrected
+func (s *CalibratedSensor) ReadLux(ctx context.Context) (float64, error) {
+	lux, _, err := s.ReadLuxWithQuality(ctx)
+	return lux, err
+}
+
+// ReadLuxWithQuality is ReadLux with the inner sensor's quality
+func (s *CalibratedSensor) ReadLuxWithQuality(ctx context.Context) (float64, domain.Quality, error) {
+	lux, quality, err := ReadLuxWithQuality(ctx, s.inner)
+	if err != nil {
+		return 0, quality, err
+	}
+	return s.Calibration().Apply(lux), quality, nil
+}
+
+// Close closes the inner sensor
+func (s *CalibratedSensor) Close() error {
+	return s.inner.Close()
+}
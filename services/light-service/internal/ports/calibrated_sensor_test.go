@@ -0,0 +1,68 @@
+package ports
+
+import (
+	"context"
+	"testing"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/mock"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+func TestCalibratedSensor_AppliesCalibration(t *testing.T) {
+	inner := mock.NewFakeSensorSeeded(500, 0, 1)
+	sensor := NewCalibratedSensor(inner, domain.Calibration{SensorID: "mock", Scale: 1.2, OffsetLux: 10})
+	ctx := context.Background()
+
+	if lux, err := sensor.ReadLux(ctx); err != nil || lux != 610 {
+		t.Errorf("expected 610, got %v, %v", lux, err)
+	}
+
+	sensor.SetCalibration(domain.IdentityCalibration("mock"))
+	if lux, err := sensor.ReadLux(ctx); err != nil || lux != 500 {
+		t.Errorf("expected 500 after resetting, got %v, %v", lux, err)
+	}
+
+	// Quality comes from the raw read
+	inner.SetSaturation(100)
+	sensor.SetCalibration(domain.Calibration{SensorID: "mock", Scale: 2})
+	if lux, quality, _ := sensor.ReadLuxWithQuality(ctx); lux != 200 || quality != domain.QualitySaturated {
+		t.Errorf("expected a saturated 200, got %v (%q)", lux, quality)
+	}
+}
+
+func TestCalibratedSensor_Error(t *testing.T) {
+	inner := &scriptedSensor{lux: 500, failures: 1}
+	sensor := NewCalibratedSensor(inner, domain.Calibration{SensorID: "a", Scale: 1, OffsetLux: 50})
+
+	if _, err := sensor.ReadLux(context.Background()); err == nil {
+		t.Fatal("expected the inner sensor's error")
+	}
+	if lux, err := sensor.ReadLux(context.Background()); err != nil || lux != 550 {
+		t.Errorf("expected 550, got %v, %v", lux, err)
+	}
+}
+
+func TestLoadCalibratedSensor(t *testing.T) {
+	repo := memory.NewCalibrationRepository()
+	ctx := context.Background()
+
+	sensor, err := LoadCalibratedSensor(ctx, &scriptedSensor{lux: 500}, repo, "gpio")
+	if err != nil {
+		t.Fatalf("LoadCalibratedSensor failed: %v", err)
+	}
+	if cal := sensor.Calibration(); !cal.IsIdentity() || cal.SensorID != "gpio" {
+		t.Errorf("expected the identity for an uncalibrated sensor, got %+v", cal)
+	}
+
+	if err := repo.SaveCalibration(ctx, &domain.Calibration{SensorID: "gpio", Scale: 0.9}); err != nil {
+		t.Fatalf("SaveCalibration failed: %v", err)
+	}
+	sensor, err = LoadCalibratedSensor(ctx, &scriptedSensor{lux: 500}, repo, "gpio")
+	if err != nil {
+		t.Fatalf("LoadCalibratedSensor failed: %v", err)
+	}
+	if lux, _ := sensor.ReadLux(ctx); lux != 450 {
+		t.Errorf("expected the stored calibration applied, got %v", lux)
+	}
+}
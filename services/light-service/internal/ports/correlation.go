@@ -0,0 +1,31 @@
+package ports
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// correlationIDKey is the context key for a correlation ID
+type correlationIDKey struct{}
+
+// WithCorrelationID returns a context carrying the given correlation ID
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationID returns the correlation ID carried by ctx, or "" if none
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
+
+// IDGenerator produces correlation IDs
+type IDGenerator func() string
+
+// NewRandomID returns a random 16-character hex ID
+func NewRandomID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,102 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// DataChangeKind says what happened to the stored data
+type DataChangeKind int
+
+const (
+	// DataChangeSaved means a reading was saved (or replaced by an upsert)
+	DataChangeSaved DataChangeKind = iota + 1
+
+	// DataChangePruned means every reading before Timestamp was deleted
+	DataChangePruned
+)
+
+// DataChange is one change to the stored readings, for clients that cache
+// them. For DataChangeSaved, ReadingID and Timestamp identify the reading; for
+// DataChangePruned, Timestamp is the deleted-before cutoff.
+type DataChange struct {
+	Kind      DataChangeKind
+	ReadingID int64
+	Timestamp time.Time
+}
+
+// DataChangeBus fans data changes out to subscribers
+type DataChangeBus = Bus[DataChange]
+
+// NewDataChangeBus creates a bus with no subscribers
+func NewDataChangeBus() *DataChangeBus {
+	return NewBus[DataChange]()
+}
+
+// NewPublishingRepository wraps repo so that every successful save, upsert
+// and DeleteOldReadings publishes to bus, whichever path (recorder, RPC,
+// import) made the change. Other methods pass straight through.
+func NewPublishingRepository(repo domain.ReadingRepository, bus *DataChangeBus) domain.ReadingRepository {
+	return &publishingRepository{ReadingRepository: repo, bus: bus}
+}
+
+type publishingRepository struct {
+	domain.ReadingRepository
+	bus *DataChangeBus
+}
+
+func (r *publishingRepository) SaveReading(ctx context.Context, reading *domain.LightReading) error {
+	if err := r.ReadingRepository.SaveReading(ctx, reading); err != nil {
+		return err
+	}
+	r.publishSaved(reading)
+	return nil
+}
+
+func (r *publishingRepository) SaveReadings(ctx context.Context, readings []*domain.LightReading) error {
+	if err := r.ReadingRepository.SaveReadings(ctx, readings); err != nil {
+		return err
+	}
+	r.publishSaved(readings...)
+	return nil
+}
+
+func (r *publishingRepository) UpsertReading(ctx context.Context, reading *domain.LightReading) error {
+	if err := r.ReadingRepository.UpsertReading(ctx, reading); err != nil {
+		return err
+	}
+	r.publishSaved(reading)
+	return nil
+}
+
+func (r *publishingRepository) UpsertReadings(ctx context.Context, readings []*domain.LightReading) error {
+	if err := r.ReadingRepository.UpsertReadings(ctx, readings); err != nil {
+		return err
+	}
+	r.publishSaved(readings...)
+	return nil
+}
+
+// DeleteOldReadings publishes a cutoff taken just after the delete. It is a
+// moment later than the repository's own, so a client evicting everything
+// before it may drop a reading that still exists, but never keeps one that
+// doesn't.
+func (r *publishingRepository) DeleteOldReadings(ctx context.Context, olderThan time.Duration) (int64, error) {
+	deleted, err := r.ReadingRepository.DeleteOldReadings(ctx, olderThan)
+	if err != nil {
+		return deleted, err
+	}
+	cutoff := time.Now().Add(-olderThan)
+	if deleted > 0 {
+		r.bus.Publish(DataChange{Kind: DataChangePruned, Timestamp: cutoff})
+	}
+	return deleted, nil
+}
+
+func (r *publishingRepository) publishSaved(readings ...*domain.LightReading) {
+	for _, reading := range readings {
+		r.bus.Publish(DataChange{Kind: DataChangeSaved, ReadingID: reading.ID, Timestamp: reading.Timestamp})
+	}
+}
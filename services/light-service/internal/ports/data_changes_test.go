@@ -0,0 +1,46 @@
+package ports
+
+import (
+	"testing"
+)
+
+func TestDataChangeBus_DropsForSlowSubscriber(t *testing.T) {
+	bus := NewDataChangeBus()
+	slow, unsubscribeSlow := bus.Subscribe(1)
+	defer unsubscribeSlow()
+	fast, unsubscribeFast := bus.Subscribe(3)
+	defer unsubscribeFast()
+
+	// Publish must not block even though slow never drains
+	for id := int64(1); id <= 3; id++ {
+		bus.Publish(DataChange{Kind: DataChangeSaved, ReadingID: id})
+	}
+
+	if got := (<-slow).ReadingID; got != 1 {
+		t.Errorf("slow subscriber: expected first change, got reading %d", got)
+	}
+	select {
+	case c := <-slow:
+		t.Errorf("slow subscriber: expected later changes dropped, got reading %d", c.ReadingID)
+	default:
+	}
+
+	for want := int64(1); want <= 3; want++ {
+		if got := (<-fast).ReadingID; got != want {
+			t.Errorf("fast subscriber: expected reading %d, got %d", want, got)
+		}
+	}
+}
+
+func TestDataChangeBus_Unsubscribe(t *testing.T) {
+	bus := NewDataChangeBus()
+	changes, unsubscribe := bus.Subscribe(1)
+
+	unsubscribe()
+	unsubscribe() // safe to repeat
+
+	bus.Publish(DataChange{Kind: DataChangePruned})
+	if _, ok := <-changes; ok {
+		t.Error("expected channel closed with nothing delivered")
+	}
+}
@@ -0,0 +1,114 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// SensorRole identifies which sensor of a FailoverSensor served a read
+type SensorRole string
+
+const (
+	SensorPrimary SensorRole = "primary"
+	SensorBackup  SensorRole = "backup"
+)
+
+// FailoverSensor reads from a primary sensor, falling back to a backup when
+// the primary keeps failing. The primary is tried first on every read, so
+// service returns to it as soon as it recovers.
+type FailoverSensor struct {
+	primary LightSensor
+	backup  LightSensor
+	retries int
+
+	mu        sync.Mutex
+	last      SensorRole
+	failovers int
+}
+
+// NewFailoverSensor creates a sensor that tries primary up to retries+1 times
+// per read before using backup
+func NewFailoverSensor(primary, backup LightSensor, retries int) *FailoverSensor {
+	return &FailoverSensor{
+		primary: primary,
+		backup:  backup,
+		retries: max(retries, 0),
+	}
+}
+
+// ReadLux returns the primary's reading, or the backup's if the primary
+// failed every attempt
+func (s *FailoverSensor) ReadLux(ctx context.Context) (float64, error) {
+	lux, _, err := s.ReadLuxWithQuality(ctx)
+	return lux, err
+}
+
+// ReadLuxWithQuality is ReadLux with the quality reported by whichever
+// sensor served the read
+func (s *FailoverSensor) ReadLuxWithQuality(ctx context.Context) (float64, domain.Quality, error) {
+	var primaryErr error
+	for attempt := 0; attempt <= s.retries; attempt++ {
+		lux, quality, err := ReadLuxWithQuality(ctx, s.primary)
+		if err == nil {
+			s.served(SensorPrimary)
+			return lux, quality, nil
+		}
+		primaryErr = err
+
+		if ctx.Err() != nil {
+			return 0, "", ctx.Err()
+		}
+	}
+
+	log.Warn().
+		Err(primaryErr).
+		Int("attempts", s.retries+1).
+		Msg("primary light sensor failed; failing over to backup")
+
+	lux, quality, err := ReadLuxWithQuality(ctx, s.backup)
+	if err != nil {
+		return 0, "", errors.Join(primaryErr, err)
+	}
+
+	s.mu.Lock()
+	s.failovers++
+	s.mu.Unlock()
+	s.served(SensorBackup)
+	return lux, quality, nil
+}
+
+// served records which sensor answered the last successful read
+func (s *FailoverSensor) served(role SensorRole) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if role == SensorPrimary && s.last == SensorBackup {
+		log.Info().Msg("primary light sensor recovered")
+	}
+	s.last = role
+}
+
+// LastSource reports which sensor served the most recent successful read,
+// or "" if none has succeeded yet
+func (s *FailoverSensor) LastSource() SensorRole {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.last
+}
+
+// Failovers reports how many reads were served by the backup
+func (s *FailoverSensor) Failovers() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.failovers
+}
+
+// Close closes both sensors
+func (s *FailoverSensor) Close() error {
+	return errors.Join(s.primary.Close(), s.backup.Close())
+}
@@ -0,0 +1,91 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// scriptedSensor fails its first failures reads, then returns lux
+type scriptedSensor struct {
+	lux      float64
+	failures int
+	reads    int
+	closed   bool
+}
+
+func (s *scriptedSensor) ReadLux(ctx context.Context) (float64, error) {
+	s.reads++
+	if s.failures < 0 || s.reads <= s.failures {
+		return 0, domain.ErrSensorUnavailable
+	}
+	return s.lux, nil
+}
+
+func (s *scriptedSensor) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestFailoverSensor_PrimaryDown(t *testing.T) {
+	primary := &scriptedSensor{lux: 500, failures: -1} // always fails
+	backup := &scriptedSensor{lux: 320}
+	sensor := NewFailoverSensor(primary, backup, 2)
+
+	lux, err := sensor.ReadLux(context.Background())
+	if err != nil {
+		t.Fatalf("ReadLux failed: %v", err)
+	}
+	if lux != 320 {
+		t.Errorf("expected backup value 320, got %v", lux)
+	}
+	if primary.reads != 3 {
+		t.Errorf("expected primary tried 3 times (1 + 2 retries), got %d", primary.reads)
+	}
+	if sensor.LastSource() != SensorBackup {
+		t.Errorf("expected last source backup, got %q", sensor.LastSource())
+	}
+	if sensor.Failovers() != 1 {
+		t.Errorf("expected 1 failover recorded, got %d", sensor.Failovers())
+	}
+}
+
+func TestFailoverSensor_PrimaryRecoversWithinRetries(t *testing.T) {
+	primary := &scriptedSensor{lux: 500, failures: 1}
+	backup := &scriptedSensor{lux: 320}
+	sensor := NewFailoverSensor(primary, backup, 1)
+
+	lux, err := sensor.ReadLux(context.Background())
+	if err != nil {
+		t.Fatalf("ReadLux failed: %v", err)
+	}
+	if lux != 500 || sensor.LastSource() != SensorPrimary {
+		t.Errorf("expected primary's 500 lux, got %v from %q", lux, sensor.LastSource())
+	}
+	if backup.reads != 0 || sensor.Failovers() != 0 {
+		t.Errorf("expected backup unused, got %d reads and %d failovers", backup.reads, sensor.Failovers())
+	}
+}
+
+func TestFailoverSensor_BothDown(t *testing.T) {
+	sensor := NewFailoverSensor(&scriptedSensor{failures: -1}, &scriptedSensor{failures: -1}, 0)
+
+	if _, err := sensor.ReadLux(context.Background()); !errors.Is(err, domain.ErrSensorUnavailable) {
+		t.Errorf("expected ErrSensorUnavailable, got %v", err)
+	}
+	if sensor.LastSource() != "" {
+		t.Errorf("expected no last source, got %q", sensor.LastSource())
+	}
+}
+
+func TestFailoverSensor_CloseClosesBoth(t *testing.T) {
+	primary, backup := &scriptedSensor{}, &scriptedSensor{}
+	if err := NewFailoverSensor(primary, backup, 0).Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if !primary.closed || !backup.closed {
+		t.Errorf("expected both sensors closed, got primary=%v backup=%v", primary.closed, backup.closed)
+	}
+}
@@ -0,0 +1,89 @@
+package ports
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// MedianFilterSensor returns the median of the inner sensor's last few reads
+// instead of the latest one. A single spike moves a mean but not a median,
+// so spikes are rejected rather than smeared across later readings.
+type MedianFilterSensor struct {
+	inner  LightSensor
+	window int
+
+	mu     sync.Mutex
+	recent []filteredRead // ring buffer of the last window reads
+	next   int
+}
+
+// filteredRead is one read in a MedianFilterSensor's window
+type filteredRead struct {
+	lux     float64
+	quality domain.Quality
+}
+
+// NewMedianFilterSensor creates a filter over the last window reads. Until
+// window reads have been taken the median is over those taken so far.
+func NewMedianFilterSensor(inner LightSensor, window int) *MedianFilterSensor {
+	window = max(window, 1)
+	return &MedianFilterSensor{
+		inner:  inner,
+		window: window,
+		recent: make([]filteredRead, 0, window),
+	}
+}
+
+// ReadLux takes a read from the inner sensor and returns the median of the
+// window. A failed read is returned as is and doesn't enter the window.
+func (s *MedianFilterSensor) ReadLux(ctx context.Context) (float64, error) {
+	lux, _, err := s.ReadLuxWithQuality(ctx)
+	return lux, err
+}
+
+// ReadLuxWithQuality is ReadLux with the quality of the read(s) the median
+// came from, so a rejected saturated spike doesn't taint the output
+func (s *MedianFilterSensor) ReadLuxWithQuality(ctx context.Context) (float64, domain.Quality, error) {
+	lux, quality, err := ReadLuxWithQuality(ctx, s.inner)
+	if err != nil {
+		return 0, "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	read := filteredRead{lux: lux, quality: quality}
+	if len(s.recent) < s.window {
+		s.recent = append(s.recent, read)
+	} else {
+		s.recent[s.next] = read
+	}
+	s.next = (s.next + 1) % s.window
+
+	lux, quality = median(s.recent)
+	return lux, quality, nil
+}
+
+// median returns the middle read of reads, or the mean of the two middle
+// reads (and the worse of their qualities) for an even count. reads is left
+// unchanged.
+func median(reads []filteredRead) (float64, domain.Quality) {
+	sorted := slices.Clone(reads)
+	slices.SortFunc(sorted, func(a, b filteredRead) int { return cmp.Compare(a.lux, b.lux) })
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		lo, hi := sorted[mid-1], sorted[mid]
+		return (lo.lux + hi.lux) / 2, domain.WorseQuality(lo.quality, hi.quality)
+	}
+	return sorted[mid].lux, sorted[mid].quality
+}
+
+// Close closes the inner sensor
+func (s *MedianFilterSensor) Close() error {
+	return s.inner.Close()
+}
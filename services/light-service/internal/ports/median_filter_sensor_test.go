@@ -0,0 +1,75 @@
+package ports
+
+import (
+	"context"
+	"testing"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/mock"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+func TestMedianFilterSensor_RejectsSpike(t *testing.T) {
+	inner := &sequenceSensor{values: []float64{500, 510, 505, 90000, 495, 500, 502}}
+	sensor := NewMedianFilterSensor(inner, 3)
+
+	for i := range inner.values {
+		lux, err := sensor.ReadLux(context.Background())
+		if err != nil {
+			t.Fatalf("read %d: %v", i, err)
+		}
+		// Once the window has filled, no output should be anywhere near the spike
+		if i >= 2 && (lux < 490 || lux > 515) {
+			t.Errorf("read %d: expected the spike rejected, got %v", i, lux)
+		}
+	}
+}
+
+func TestMedianFilterSensor_PartialAndEvenWindows(t *testing.T) {
+	inner := &sequenceSensor{values: []float64{100, 300, 200, 1000, 400}}
+	sensor := NewMedianFilterSensor(inner, 4)
+
+	// Partial fills: [100], [100 300], [100 200 300], then the full window
+	// [100 200 300 1000] and, with 100 evicted, [200 300 400 1000]
+	want := []float64{100, 200, 200, 250, 350}
+	for i, w := range want {
+		lux, err := sensor.ReadLux(context.Background())
+		if err != nil {
+			t.Fatalf("read %d: %v", i, err)
+		}
+		if lux != w {
+			t.Errorf("read %d: expected median %v, got %v", i, w, lux)
+		}
+	}
+}
+
+func TestMedianFilterSensor_ErrorsSkipWindow(t *testing.T) {
+	inner := &scriptedSensor{lux: 500, failures: 1}
+	sensor := NewMedianFilterSensor(inner, 3)
+
+	if _, err := sensor.ReadLux(context.Background()); err == nil {
+		t.Fatal("expected the inner sensor's error")
+	}
+	if lux, err := sensor.ReadLux(context.Background()); err != nil || lux != 500 {
+		t.Errorf("expected 500 with the failed read left out, got %v, %v", lux, err)
+	}
+}
+
+func TestMedianFilterSensor_QualityFollowsMedian(t *testing.T) {
+	inner := mock.NewFakeSensorSeeded(500, 0, 1)
+	sensor := NewMedianFilterSensor(inner, 3)
+	ctx := context.Background()
+
+	sensor.ReadLux(ctx)
+	sensor.ReadLux(ctx)
+
+	// One saturated spike is rejected along with its flag
+	inner.SetSaturation(100)
+	if lux, quality, _ := sensor.ReadLuxWithQuality(ctx); lux != 500 || quality != domain.QualityOK {
+		t.Errorf("expected the spike rejected, got %v (%q)", lux, quality)
+	}
+
+	// Once saturated reads are the majority, the median is one of them
+	if lux, quality, _ := sensor.ReadLuxWithQuality(ctx); lux != 100 || quality != domain.QualitySaturated {
+		t.Errorf("expected a saturated median, got %v (%q)", lux, quality)
+	}
+}
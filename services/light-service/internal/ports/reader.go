@@ -2,8 +2,11 @@ package ports
 
 import (
 	"context"
+	"slices"
+	"sync"
 	"time"
 
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
 	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
@@ -11,18 +14,248 @@ import (
 
 // Recorder handles periodic sensor reading and storage
 type Recorder struct {
-	sensor   LightSensor
-	repo     domain.ReadingRepository
-	interval time.Duration
+	sensor      LightSensor
+	repo        domain.ReadingRepository
+	interval    time.Duration
+	categorizer *domain.Categorizer
+	scheme      *domain.CategoryScheme
+	hysteresis  float64
+	tempSensor  TemperatureSensor
+
+	samples      int
+	sampleGap    time.Duration
+	dropOutliers bool
+	readTimeout  time.Duration
+
+	pollInterval time.Duration
+	pollMu       sync.Mutex
+	polls        []sample // taken since the last recording
+
+	dropSaturated bool
+
+	startupAttempts int
+	startupDelay    time.Duration
+
+	dedupEpsilon float64
+	dedupMaxSkip time.Duration
+	lastSaved    *domain.LightReading
+
+	nightMode *NightMode
+	night     bool
+	darkSince time.Time // when lux first fell below EnterBelow; zero if it hasn't
+
+	newID  IDGenerator
+	clock  domain.Clock
+	events *EventBus
+
+	bufferSize  int
+	bufferFlush time.Duration
+	writer      *BufferedWriter // nil saves each reading as it is taken
+
+	statusMu sync.Mutex
+	status   RecorderStatus
+}
+
+// RecorderStatus describes how recording is going, independently of what is
+// in the store (which may also hold manual readings)
+type RecorderStatus struct {
+	LastSuccess         time.Time // zero until a cycle succeeds
+	LastError           string    // from the most recent failed cycle; cleared on success
+	ConsecutiveFailures int
+	Interval            time.Duration // current effective interval (slower in night mode)
+}
+
+// RecorderOption configures optional Recorder behaviour
+type RecorderOption func(*Recorder)
+
+// WithCategoryHysteresis makes the recorder require crossing a category
+// boundary by at least margin lux before it reports a category change
+func WithCategoryHysteresis(margin float64) RecorderOption {
+	return func(r *Recorder) {
+		r.hysteresis = margin
+	}
+}
+
+// WithCategoryScheme makes the recorder report category changes between the
+// levels of scheme instead of the default Low/Medium/High
+func WithCategoryScheme(scheme *domain.CategoryScheme) RecorderOption {
+	return func(r *Recorder) {
+		r.scheme = scheme
+	}
+}
+
+// WithTemperatureSensor makes the recorder attach ambient temperature to each
+// reading. A failed temperature read is logged and the light reading is still saved.
+func WithTemperatureSensor(sensor TemperatureSensor) RecorderOption {
+	return func(r *Recorder) {
+		r.tempSensor = sensor
+	}
+}
+
+// WithSamplesPerReading makes each recording the mean of n sensor reads taken
+// gap apart, rather than a single instantaneous read. If dropOutliers is set
+// and n >= 3, the highest and lowest samples are discarded before averaging.
+func WithSamplesPerReading(n int, gap time.Duration, dropOutliers bool) RecorderOption {
+	return func(r *Recorder) {
+		r.samples = max(n, 1)
+		r.sampleGap = gap
+		r.dropOutliers = dropOutliers
+	}
 }
 
+// WithPollInterval makes the recorder read the sensor every d in the
+// background and record the mean of the reads taken since the last
+// recording, so a long recording interval still reflects the whole of it
+// rather than one instant. dropOutliers from WithSamplesPerReading applies
+// to the polls. A recording with no successful polls reads the sensor
+// directly. d should not exceed the recording interval.
+func WithPollInterval(d time.Duration) RecorderOption {
+	return func(r *Recorder) {
+		r.pollInterval = d
+	}
+}
+
+// WithIDGenerator replaces the random per-cycle correlation ID generator,
+// mainly so tests can assert on deterministic IDs
+func WithIDGenerator(gen IDGenerator) RecorderOption {
+	return func(r *Recorder) {
+		r.newID = gen
+	}
+}
+
+// WithClock replaces the wall clock used for reading timestamps, the
+// recording and cleanup tickers and the waits between samples
+func WithClock(clock domain.Clock) RecorderOption {
+	return func(r *Recorder) {
+		r.clock = clock
+	}
+}
+
+// WithStartupRetries makes the first recording at startup try up to attempts
+// times, delay apart, so a sensor that needs a moment to initialize still gets
+// its first reading promptly instead of waiting a full interval
+func WithStartupRetries(attempts int, delay time.Duration) RecorderOption {
+	return func(r *Recorder) {
+		r.startupAttempts = max(attempts, 1)
+		r.startupDelay = delay
+	}
+}
+
+// WithSkipUnchanged makes the recorder skip saving a reading that is
+// EqualWithin luxEpsilon of the last one it saved, so a steady light level
+// doesn't fill the store with copies. A reading is still saved at least every
+// maxSkip, so history queries never see a gap longer than that.
+func WithSkipUnchanged(luxEpsilon float64, maxSkip time.Duration) RecorderOption {
+	return func(r *Recorder) {
+		r.dedupEpsilon = luxEpsilon
+		r.dedupMaxSkip = maxSkip
+	}
+}
+
+// WithReadTimeout gives up on a sensor read that takes longer than d, so a
+// sensor stuck mid-read can't hold up a recording cycle indefinitely
+func WithReadTimeout(d time.Duration) RecorderOption {
+	return func(r *Recorder) {
+		r.readTimeout = d
+	}
+}
+
+// WithDropSaturated makes the recorder discard readings the sensor reports
+// as saturated instead of storing them with their quality flag. Discarded
+// cycles count as failures in Status, so a sensor pinned at its maximum
+// shows up there.
+func WithDropSaturated() RecorderOption {
+	return func(r *Recorder) {
+		r.dropSaturated = true
+	}
+}
+
+// NightMode slows recording while it is dark. The recorder enters night mode
+// once lux has stayed below EnterBelow for After, and leaves as soon as a
+// reading exceeds ExitAbove; ExitAbove above EnterBelow gives hysteresis so
+// dusk doesn't flip the mode back and forth.
+type NightMode struct {
+	EnterBelow float64
+	ExitAbove  float64
+	After      time.Duration
+	Interval   time.Duration // recording interval while in night mode
+}
+
+// WithNightMode enables night mode
+func WithNightMode(mode NightMode) RecorderOption {
+	return func(r *Recorder) {
+		r.nightMode = &mode
+	}
+}
+
+// WithEventBus publishes a ReadingRecorded event to bus for each reading
+// saved, for the subscribers acting on new readings
+func WithEventBus(bus *EventBus) RecorderOption {
+	return func(r *Recorder) {
+		r.events = bus
+	}
+}
+
+// WithWriteBuffer makes the recorder save readings write-behind through a
+// BufferedWriter, in batches of up to size or every flushEvery, whichever
+// comes first. Start flushes what is buffered before it returns. Readings
+// are published as they are taken, before they are saved, so published
+// readings carry no ID.
+func WithWriteBuffer(size int, flushEvery time.Duration) RecorderOption {
+	return func(r *Recorder) {
+		r.bufferSize = size
+		r.bufferFlush = flushEvery
+	}
+}
+
+// cleanupInterval is how often the recorder deletes expired readings
+const cleanupInterval = 24 * time.Hour
+
 // NewRecorder creates a new background recorder
-func NewRecorder(sensor LightSensor, repo domain.ReadingRepository, interval time.Duration) *Recorder {
-	return &Recorder{
-		sensor:   sensor,
-		repo:     repo,
-		interval: interval,
+func NewRecorder(sensor LightSensor, repo domain.ReadingRepository, interval time.Duration, opts ...RecorderOption) *Recorder {
+	r := &Recorder{
+		sensor:          sensor,
+		repo:            repo,
+		interval:        interval,
+		scheme:          domain.DefaultCategoryScheme,
+		samples:         1,
+		startupAttempts: 1,
+		newID:           NewRandomID,
+		clock:           domain.RealClock{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.categorizer = domain.NewCategorizerIn(r.scheme, r.hysteresis)
+	if r.bufferSize > 0 {
+		r.writer = NewBufferedWriter(r.repo, r.bufferSize, r.bufferFlush, r.clock)
+	}
+	r.status.Interval = r.interval
+	return r
+}
+
+// Status returns a snapshot of the recorder's health. Safe to call from any
+// goroutine.
+func (r *Recorder) Status() RecorderStatus {
+	r.statusMu.Lock()
+	defer r.statusMu.Unlock()
+	return r.status
+}
+
+// recordResult updates the status after a recording cycle
+func (r *Recorder) recordResult(err error) {
+	r.statusMu.Lock()
+	defer r.statusMu.Unlock()
+
+	if err != nil {
+		r.status.LastError = err.Error()
+		r.status.ConsecutiveFailures++
+	} else {
+		r.status.LastSuccess = r.clock.Now()
+		r.status.LastError = ""
+		r.status.ConsecutiveFailures = 0
 	}
+	r.status.Interval = r.currentInterval()
 }
 
 // Start begins periodic sensor reading
@@ -32,25 +265,56 @@ func (r *Recorder) Start(ctx context.Context) {
 		Dur("interval", r.interval).
 		Msg("starting background recorder")
 
-	ticker := time.NewTicker(r.interval)
-	defer ticker.Stop()
+	interval := r.currentInterval()
+	ticker := r.clock.NewTicker(interval)
+	defer func() { ticker.Stop() }()
+
+	// Night mode transitions change the interval, which needs a new ticker
+	retime := func() {
+		if next := r.currentInterval(); next != interval {
+			ticker.Stop()
+			interval = next
+			ticker = r.clock.NewTicker(interval)
+		}
+	}
 
-	cleanupTicker := time.NewTicker(24 * time.Hour)
+	cleanupTicker := r.clock.NewTicker(cleanupInterval)
 	defer cleanupTicker.Stop()
 
+	if r.pollInterval > 0 {
+		// Wait for the poll loop too, so nothing reads the sensor after
+		// Start returns and the caller closes it
+		var polling sync.WaitGroup
+		polling.Go(func() { r.pollLoop(ctx) })
+		defer polling.Wait()
+	}
+
+	if r.writer != nil {
+		// Drained once recording has stopped, so nothing is written after
+		// the final flush and Start returns with nothing left buffered
+		var flushing sync.WaitGroup
+		flushing.Go(func() { r.writer.Run(ctx) })
+		defer func() {
+			flushing.Wait()
+			r.writer.Drain(ctx)
+		}()
+	}
+
 	// Record immediately on start
-	r.recordOnce(ctx)
+	r.recordInitial(ctx)
+	retime()
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-ticker.C():
 			r.recordOnce(ctx)
+			retime()
 
-		case <-cleanupTicker.C:
-			if err := r.repo.DeleteOldReadings(ctx, 30*24*time.Hour); err != nil {
+		case <-cleanupTicker.C():
+			if deleted, err := r.repo.DeleteOldReadings(ctx, 30*24*time.Hour); err != nil {
 				log.Error().Err(err).Msg("failed to delete old readings")
 			} else {
-				log.Info().Msg("deleted readings older than 30 days")
+				log.Info().Int64("deleted", deleted).Msg("deleted readings older than 30 days")
 			}
 
 		case <-ctx.Done():
@@ -60,29 +324,348 @@ func (r *Recorder) Start(ctx context.Context) {
 	}
 }
 
-// recordOnce reads sensor and saves to repository
-func (r *Recorder) recordOnce(ctx context.Context) {
-	log.Debug().Msg("reading sensor")
+// pollLoop buffers a sensor read every poll interval until ctx is done
+func (r *Recorder) pollLoop(ctx context.Context) {
+	ticker := r.clock.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C():
+			r.pollOnce(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
 
-	lux, err := r.sensor.ReadLux(ctx)
+// pollOnce reads the sensor into the poll buffer. A failed poll is only
+// logged; the recording uses whichever polls succeeded.
+func (r *Recorder) pollOnce(ctx context.Context) {
+	lux, quality, err := r.readSensor(ctx)
 	if err != nil {
-		log.Error().Err(err).Msg("failed to read sensor")
+		if ctx.Err() == nil {
+			log.Warn().Err(err).Msg("sensor poll failed")
+		}
 		return
 	}
 
-	reading, err := domain.NewLightReading(lux)
+	r.pollMu.Lock()
+	r.polls = append(r.polls, sample{lux: lux, quality: quality})
+	r.pollMu.Unlock()
+}
+
+// takePolls empties the poll buffer, returning what it held
+func (r *Recorder) takePolls() []sample {
+	r.pollMu.Lock()
+	defer r.pollMu.Unlock()
+
+	polls := r.polls
+	r.polls = nil
+	return polls
+}
+
+// recordInitial takes the first recording, retrying within the startup
+// window if it fails. Cancellation stops the retries.
+func (r *Recorder) recordInitial(ctx context.Context) {
+	for attempt := 1; ; attempt++ {
+		if err := r.recordOnce(ctx); err == nil || attempt >= r.startupAttempts {
+			return
+		}
+
+		log.Warn().
+			Int("attempt", attempt).
+			Dur("retry_in", r.startupDelay).
+			Msg("initial recording failed; retrying")
+
+		if r.wait(ctx, r.startupDelay) != nil {
+			return
+		}
+	}
+}
+
+// recordOnce reads sensor and saves to repository. Failures are logged;
+// the error is returned so startup can retry.
+func (r *Recorder) recordOnce(ctx context.Context) (err error) {
+	defer func() { r.recordResult(err) }()
+
+	// Tag this cycle so its log lines, and anything the sensor or repository
+	// logs via the context, can be tied together
+	id := r.newID()
+	ctx = WithCorrelationID(ctx, id)
+	logger := log.With().Str("correlation_id", id).Logger()
+	ctx = logger.WithContext(ctx)
+
+	logger.Debug().Msg("reading sensor")
+
+	lux, quality, err := r.nextLux(ctx)
 	if err != nil {
-		log.Error().Err(err).Msg("failed to create reading")
-		return
+		logger.Error().Err(err).Msg("failed to read sensor")
+		return err
+	}
+	if quality == domain.QualitySaturated && r.dropSaturated {
+		logger.Warn().Float64("lux", lux).Msg("sensor saturated; dropping reading")
+		return domain.ErrSaturatedReading
 	}
 
-	if err := r.repo.SaveReading(ctx, reading); err != nil {
-		log.Error().Err(err).Msg("failed to save reading")
-		return
+	reading, err := domain.NewLightReadingAt(lux, r.clock.Now())
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to create reading")
+		return err
 	}
+	reading.Quality = quality
 
-	log.Info().
+	r.updateNightMode(logger, reading)
+
+	if r.tempSensor != nil {
+		r.attachTemperature(ctx, reading)
+	}
+
+	if r.unchanged(reading) {
+		logger.Debug().Float64("lux", lux).Msg("reading unchanged; not saving")
+		return nil
+	}
+
+	// Must be looked up before saving, or the latest reading is this one
+	previous, hasPrevious := r.previousCategory(ctx)
+
+	if err := r.save(ctx, reading); err != nil {
+		logger.Error().Err(err).Msg("failed to save reading")
+		return err
+	}
+	r.lastSaved = reading
+
+	category := r.categorizer.Categorize(reading)
+	if r.events != nil {
+		r.events.Publish(domain.ReadingRecorded{Reading: reading, Category: category, CorrelationID: id})
+	}
+
+	if hasPrevious && category != previous {
+		event := &domain.CategoryEvent{
+			From:      previous,
+			To:        category,
+			Lux:       reading.Lux,
+			Timestamp: reading.Timestamp,
+		}
+		if err := r.repo.SaveCategoryEvent(ctx, event); err != nil {
+			logger.Error().Err(err).Msg("failed to save category event")
+		} else {
+			logger.Info().
+				Str("from", r.scheme.Label(previous)).
+				Str("to", r.scheme.Label(category)).
+				Msg("light category changed")
+		}
+	}
+
+	logger.Info().
 		Float64("lux", lux).
-		Str("category", reading.LightCategory()).
+		Str("category", r.scheme.Label(category)).
 		Msg("recorded light reading")
+	return nil
+}
+
+// save stores reading directly or, with a write buffer, hands it to the
+// buffer. A buffered reading counts as saved even if the flush it
+// triggered failed, since the buffer retries it.
+func (r *Recorder) save(ctx context.Context, reading *domain.LightReading) error {
+	if r.writer == nil {
+		return r.repo.SaveReading(ctx, reading)
+	}
+	if err := r.writer.Write(ctx, reading); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Int("pending", r.writer.Pending()).Msg("failed to flush buffered readings; will retry")
+	}
+	return nil
+}
+
+// currentInterval is the recording interval for the current mode
+func (r *Recorder) currentInterval() time.Duration {
+	if r.night {
+		return r.nightMode.Interval
+	}
+	return r.interval
+}
+
+// updateNightMode advances the night mode state machine with a new reading
+func (r *Recorder) updateNightMode(logger zerolog.Logger, reading *domain.LightReading) {
+	if r.nightMode == nil {
+		return
+	}
+
+	if r.night {
+		if reading.Lux > r.nightMode.ExitAbove {
+			r.night = false
+			r.darkSince = time.Time{}
+			logger.Info().
+				Float64("lux", reading.Lux).
+				Dur("interval", r.interval).
+				Msg("light returned; leaving night mode")
+		}
+		return
+	}
+
+	if reading.Lux >= r.nightMode.EnterBelow {
+		r.darkSince = time.Time{}
+		return
+	}
+	if r.darkSince.IsZero() {
+		r.darkSince = reading.Timestamp
+	}
+	if reading.Timestamp.Sub(r.darkSince) >= r.nightMode.After {
+		r.night = true
+		logger.Info().
+			Float64("lux", reading.Lux).
+			Dur("dark_for", reading.Timestamp.Sub(r.darkSince)).
+			Dur("interval", r.nightMode.Interval).
+			Msg("entering night mode")
+	}
+}
+
+// unchanged reports whether reading can be skipped as a repeat of the last
+// saved one. Skipping is off unless WithSkipUnchanged set a max skip.
+func (r *Recorder) unchanged(reading *domain.LightReading) bool {
+	if r.dedupMaxSkip <= 0 || r.lastSaved == nil {
+		return false
+	}
+	if r.lastSaved.Diff(reading).Elapsed >= r.dedupMaxSkip {
+		return false
+	}
+	return r.scheme.EqualWithin(r.lastSaved, reading, r.dedupEpsilon)
+}
+
+// previousCategory returns the category before this cycle's reading. After
+// a restart the categorizer is seeded from the last persisted reading, so a
+// transition that spans the restart is still recorded.
+func (r *Recorder) previousCategory(ctx context.Context) (domain.Category, bool) {
+	if category, ok := r.categorizer.Current(); ok {
+		return category, true
+	}
+
+	latest, err := r.repo.GetLatestReading(ctx)
+	if err != nil {
+		return 0, false
+	}
+
+	category := r.scheme.Categorize(latest.Lux)
+	r.categorizer.Seed(category)
+	return category, true
+}
+
+// sample is one sensor read
+type sample struct {
+	lux     float64
+	quality domain.Quality
+}
+
+// nextLux returns the lux to record this cycle: the aggregate of the polls
+// since the last recording if there are any, otherwise a fresh sampleLux
+func (r *Recorder) nextLux(ctx context.Context) (float64, domain.Quality, error) {
+	if polls := r.takePolls(); len(polls) > 0 {
+		lux, quality := r.aggregate(polls)
+		return lux, quality, nil
+	}
+	return r.sampleLux(ctx)
+}
+
+// sampleLux takes the configured number of sensor reads and returns their
+// aggregate. Cancellation between samples aborts the
+// whole recording rather than storing a partial average.
+func (r *Recorder) sampleLux(ctx context.Context) (float64, domain.Quality, error) {
+	samples := make([]sample, 0, r.samples)
+	for i := 0; i < r.samples; i++ {
+		if i > 0 {
+			if err := r.wait(ctx, r.sampleGap); err != nil {
+				return 0, "", err
+			}
+		}
+
+		lux, quality, err := r.readSensor(ctx)
+		if err != nil {
+			return 0, "", err
+		}
+		samples = append(samples, sample{lux: lux, quality: quality})
+	}
+
+	lux, quality := r.aggregate(samples)
+	return lux, quality, nil
+}
+
+// aggregate returns the (optionally outlier-trimmed) mean of samples, with
+// the worst quality among them
+func (r *Recorder) aggregate(samples []sample) (float64, domain.Quality) {
+	quality := domain.QualityOK
+	values := make([]float64, len(samples))
+	for i, s := range samples {
+		values[i] = s.lux
+		quality = domain.WorseQuality(quality, s.quality)
+	}
+
+	if r.dropOutliers && len(values) >= 3 {
+		slices.Sort(values)
+		values = values[1 : len(values)-1]
+	}
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values)), quality
+}
+
+// wait blocks for d on the recorder's clock, returning early with the
+// context's error if it is cancelled first
+func (r *Recorder) wait(ctx context.Context, d time.Duration) error {
+	timer := r.clock.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C():
+		return nil
+	}
+}
+
+// readSensor reads the sensor, returning as soon as ctx is done or the read
+// timeout passes even if the sensor ignores its context (as a blocking I2C
+// read might). An abandoned read finishes in the background and is discarded.
+func (r *Recorder) readSensor(ctx context.Context) (float64, domain.Quality, error) {
+	if r.readTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.readTimeout)
+		defer cancel()
+	}
+
+	type result struct {
+		lux     float64
+		quality domain.Quality
+		err     error
+	}
+	done := make(chan result, 1) // buffered so an abandoned read doesn't leak its goroutine
+	go func() {
+		lux, quality, err := ReadLuxWithQuality(ctx, r.sensor)
+		done <- result{lux, quality, err}
+	}()
+
+	select {
+	case res := <-done:
+		return res.lux, res.quality, res.err
+	case <-ctx.Done():
+		return 0, "", ctx.Err()
+	}
+}
+
+// attachTemperature reads the optional temperature sensor into the reading,
+// leaving temperature unset if the read fails
+func (r *Recorder) attachTemperature(ctx context.Context, reading *domain.LightReading) {
+	logger := zerolog.Ctx(ctx)
+
+	celsius, err := r.tempSensor.ReadCelsius(ctx)
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to read temperature sensor; recording light only")
+		return
+	}
+
+	if err := reading.SetTemperature(celsius); err != nil {
+		logger.Warn().Err(err).Float64("celsius", celsius).Msg("discarding invalid temperature")
+	}
 }
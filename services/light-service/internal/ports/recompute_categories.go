@@ -0,0 +1,162 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// recomputeBatchSize is how many readings RecomputeCategories reads per
+// query, so a large history is never loaded or locked all at once
+const recomputeBatchSize = 1000
+
+// CategoryRecompute summarizes a RecomputeCategories run
+type CategoryRecompute struct {
+	ReadingsScanned int64
+	EventsUpdated   int // stored events removed or added; 0 if already current
+	EventsTotal     int // events stored afterwards
+}
+
+// RecomputeCategories re-derives the stored category transitions from the
+// readings under scheme and hysteresis margin, the way the recorder would
+// have reported them, and replaces the stored ones if they differ. Run it
+// after changing CATEGORY_SCHEME or CATEGORY_HYSTERESIS, since events
+// recorded before the change were categorized the old way.
+//
+// Readings are paged through in batches, and each batch's span of events
+// is swapped in its own transaction. The scan ends with the newest reading
+// stored when it began; anything the recorder saves while the recompute
+// runs is left alone, so live transitions aren't lost.
+func RecomputeCategories(ctx context.Context, repo domain.ReadingRepository, scheme *domain.CategoryScheme, margin float64) (CategoryRecompute, error) {
+	var result CategoryRecompute
+
+	latest, err := repo.GetLatestReading(ctx)
+	if errors.Is(err, domain.ErrReadingNotFound) {
+		return result, nil
+	}
+	if err != nil {
+		return result, fmt.Errorf("failed to get latest reading: %w", err)
+	}
+	// Events are replaced up to and including the newest reading's
+	highWater := domain.CursorAfter(latest)
+	scanEnd := latest.Timestamp.Add(time.Nanosecond)
+
+	categorizer := domain.NewCategorizerIn(scheme, margin)
+	var pending []*domain.CategoryEvent
+	var windowStart time.Time
+	var cursor domain.ReadingCursor
+	for done := false; !done; {
+		batch, err := repo.ListReadings(ctx, cursor, recomputeBatchSize)
+		if err != nil {
+			return result, fmt.Errorf("failed to list readings: %w", err)
+		}
+		done = len(batch) < recomputeBatchSize
+		for _, reading := range batch {
+			if highWater.Before(reading) {
+				done = true
+				break
+			}
+			result.ReadingsScanned++
+
+			previous, started := categorizer.Current()
+			category := categorizer.Categorize(reading)
+			if started && category != previous {
+				pending = append(pending, &domain.CategoryEvent{
+					From:      previous,
+					To:        category,
+					Lux:       reading.Lux,
+					Timestamp: reading.Timestamp,
+				})
+			}
+		}
+		if done {
+			break
+		}
+
+		// No two readings share a timestamp, so the window can close just
+		// after the batch's last one
+		last := batch[len(batch)-1]
+		windowEnd := last.Timestamp.Add(time.Nanosecond)
+		if err := replaceWindow(ctx, repo, windowStart, windowEnd, pending, &result); err != nil {
+			return result, err
+		}
+		pending = nil
+		windowStart = windowEnd
+		cursor = domain.CursorAfter(last)
+	}
+	if err := replaceWindow(ctx, repo, windowStart, scanEnd, pending, &result); err != nil {
+		return result, err
+	}
+
+	newer, err := repo.GetCategoryEvents(ctx, scanEnd, maxEventTime)
+	if err != nil {
+		return result, fmt.Errorf("failed to get category events: %w", err)
+	}
+	result.EventsTotal += len(newer)
+	return result, nil
+}
+
+// replaceWindow swaps the stored events in [start, end) for events if they
+// differ, adding the outcome to result
+func replaceWindow(ctx context.Context, repo domain.ReadingRepository, start, end time.Time, events []*domain.CategoryEvent, result *CategoryRecompute) error {
+	if !start.Before(end) {
+		return nil
+	}
+
+	existing, err := repo.GetCategoryEvents(ctx, start, end)
+	if err != nil {
+		return fmt.Errorf("failed to get category events: %w", err)
+	}
+
+	result.EventsTotal += len(events)
+	changed := changedEvents(existing, events)
+	if changed == 0 {
+		return nil
+	}
+	if err := repo.ReplaceCategoryEvents(ctx, start, end, events); err != nil {
+		return fmt.Errorf("failed to replace category events: %w", err)
+	}
+	result.EventsUpdated += changed
+	return nil
+}
+
+// maxEventTime bounds "every event" queries; far beyond any real timestamp
+var maxEventTime = time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// eventKey identifies an event by content, ignoring its ID. Events take
+// their timestamps from readings read back from the same store, so the
+// timestamps match to whatever precision it keeps.
+type eventKey struct {
+	from, to domain.Category
+	lux      float64
+	unixNano int64
+}
+
+func keyOf(e *domain.CategoryEvent) eventKey {
+	return eventKey{from: e.From, to: e.To, lux: e.Lux, unixNano: e.Timestamp.UnixNano()}
+}
+
+// changedEvents counts the events in only one of before and after
+func changedEvents(before, after []*domain.CategoryEvent) int {
+	counts := make(map[eventKey]int, len(before))
+	for _, e := range before {
+		counts[keyOf(e)]++
+	}
+
+	changed := 0
+	for _, e := range after {
+		k := keyOf(e)
+		if counts[k] > 0 {
+			counts[k]--
+		} else {
+			changed++ // added
+		}
+	}
+	for _, n := range counts {
+		changed += n // removed
+	}
+	return changed
+}
@@ -0,0 +1,205 @@
+package ports
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/mock"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+func TestRecomputeCategories_AfterSchemeChange(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	ctx := context.Background()
+
+	// Record under the default Low/Medium/High scheme (200 / 2500 lux)
+	clock := mock.NewFakeClock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	sensor := &sequenceSensor{values: []float64{100, 800, 1500, 3000, 1200, 50}}
+	recorder := NewRecorder(sensor, repo, 0, WithClock(clock))
+	for range sensor.values {
+		recorder.recordOnce(ctx)
+		clock.Advance(time.Minute)
+	}
+
+	// Switch to Dark/Dim/Bright with boundaries at 1000 and 2000 lux
+	scheme, err := domain.NewCategoryScheme([]string{"Dark", "Dim", "Bright"}, []float64{1000, 2000})
+	if err != nil {
+		t.Fatalf("NewCategoryScheme failed: %v", err)
+	}
+
+	result, err := RecomputeCategories(ctx, repo, scheme, 0)
+	if err != nil {
+		t.Fatalf("RecomputeCategories failed: %v", err)
+	}
+	// Only the first transition moves (800 lux Low->Medium becomes 1500 lux
+	// Dark->Dim): one event removed, one added. The newest reading is
+	// scanned too, and its Medium->Low event is Dim->Dark under the new
+	// scheme, so it stays.
+	if result.ReadingsScanned != 6 || result.EventsUpdated != 2 || result.EventsTotal != 4 {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	events, err := repo.GetCategoryEvents(ctx, time.Time{}, clock.Now())
+	if err != nil {
+		t.Fatalf("GetCategoryEvents failed: %v", err)
+	}
+	want := []struct {
+		from, to domain.Category
+		lux      float64
+	}{
+		{0, 1, 1500},
+		{1, 2, 3000},
+		{2, 1, 1200},
+		{1, 0, 50},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %d", len(want), len(events))
+	}
+	for i, w := range want {
+		e := events[i]
+		if e.From != w.from || e.To != w.to || e.Lux != w.lux {
+			t.Errorf("event %d: expected %s->%s at %v lux, got %s->%s at %v lux", i,
+				scheme.Label(w.from), scheme.Label(w.to), w.lux,
+				scheme.Label(e.From), scheme.Label(e.To), e.Lux)
+		}
+	}
+
+	// Running again finds nothing to change
+	if again, err := RecomputeCategories(ctx, repo, scheme, 0); err != nil || again.EventsUpdated != 0 {
+		t.Errorf("expected a no-op second run, got %+v, %v", again, err)
+	}
+}
+
+// racingRepo records a new reading and transition the first time the
+// recompute lists readings, the way a running recorder would
+type racingRepo struct {
+	*memory.ReadingRepository
+	raced bool
+	event *domain.CategoryEvent
+}
+
+func (r *racingRepo) ListReadings(ctx context.Context, after domain.ReadingCursor, limit int) ([]*domain.LightReading, error) {
+	if !r.raced {
+		r.raced = true
+		reading := &domain.LightReading{Lux: 3000, Timestamp: r.event.Timestamp}
+		if err := r.SaveReading(ctx, reading); err != nil {
+			return nil, err
+		}
+		if err := r.SaveCategoryEvent(ctx, r.event); err != nil {
+			return nil, err
+		}
+	}
+	return r.ReadingRepository.ListReadings(ctx, after, limit)
+}
+
+func TestRecomputeCategories_KeepsConcurrentTransitions(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	repo := &racingRepo{
+		ReadingRepository: memory.NewReadingRepository(),
+		event:             &domain.CategoryEvent{From: domain.CategoryLow, To: domain.CategoryHigh, Lux: 3000, Timestamp: base.Add(time.Hour)},
+	}
+	for i, lux := range []float64{100, 1500, 100} {
+		if err := repo.SaveReading(ctx, &domain.LightReading{Lux: lux, Timestamp: base.Add(time.Duration(i) * time.Minute)}); err != nil {
+			t.Fatalf("SaveReading failed: %v", err)
+		}
+	}
+
+	if _, err := RecomputeCategories(ctx, repo, domain.DefaultCategoryScheme, 0); err != nil {
+		t.Fatalf("RecomputeCategories failed: %v", err)
+	}
+	if !repo.raced {
+		t.Fatal("expected the recorder to race the recompute")
+	}
+
+	events, err := repo.GetCategoryEvents(ctx, base.Add(time.Hour), base.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("GetCategoryEvents failed: %v", err)
+	}
+	if len(events) != 1 || events[0] != repo.event {
+		t.Errorf("expected the concurrent transition to survive, got %+v", events)
+	}
+}
+
+func TestRecomputeCategories_AcrossBatches(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	ctx := context.Background()
+
+	// Alternate Low and High, so every batch edge falls between a transition
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	const n = 2*recomputeBatchSize + 100
+	for i := range n {
+		lux := 100.0
+		if i%2 == 1 {
+			lux = 3000
+		}
+		reading := &domain.LightReading{Lux: lux, Timestamp: base.Add(time.Duration(i) * time.Minute)}
+		if err := repo.SaveReading(ctx, reading); err != nil {
+			t.Fatalf("SaveReading failed: %v", err)
+		}
+	}
+
+	result, err := RecomputeCategories(ctx, repo, domain.DefaultCategoryScheme, 0)
+	if err != nil {
+		t.Fatalf("RecomputeCategories failed: %v", err)
+	}
+	// Every reading after the first is a transition, the newest included
+	if result.ReadingsScanned != n || result.EventsUpdated != n-1 || result.EventsTotal != n-1 {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	events, err := repo.GetCategoryEvents(ctx, base, base.Add(7*24*time.Hour))
+	if err != nil {
+		t.Fatalf("GetCategoryEvents failed: %v", err)
+	}
+	if len(events) != n-1 {
+		t.Errorf("expected %d events, got %d", n-1, len(events))
+	}
+
+	if again, err := RecomputeCategories(ctx, repo, domain.DefaultCategoryScheme, 0); err != nil || again.EventsUpdated != 0 {
+		t.Errorf("expected a no-op second run, got %+v, %v", again, err)
+	}
+}
+
+func TestRecomputeCategories_IncludesNewestReading(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	ctx := context.Background()
+
+	// Sub-second timestamps, and the only stored transition is the newest
+	// reading's Low->Medium at 800 lux
+	base := time.Date(2024, 6, 1, 12, 0, 0, 250_000_000, time.UTC)
+	for i, lux := range []float64{100, 800} {
+		if err := repo.SaveReading(ctx, &domain.LightReading{Lux: lux, Timestamp: base.Add(time.Duration(i) * 500 * time.Millisecond)}); err != nil {
+			t.Fatalf("SaveReading failed: %v", err)
+		}
+	}
+	event := &domain.CategoryEvent{From: domain.CategoryLow, To: domain.CategoryMedium, Lux: 800, Timestamp: base.Add(500 * time.Millisecond)}
+	if err := repo.SaveCategoryEvent(ctx, event); err != nil {
+		t.Fatalf("SaveCategoryEvent failed: %v", err)
+	}
+
+	// Unchanged, so nothing is replaced
+	result, err := RecomputeCategories(ctx, repo, domain.DefaultCategoryScheme, 0)
+	if err != nil {
+		t.Fatalf("RecomputeCategories failed: %v", err)
+	}
+	if result.ReadingsScanned != 2 || result.EventsUpdated != 0 || result.EventsTotal != 1 {
+		t.Errorf("expected the stored event to match, got %+v", result)
+	}
+
+	// Under a 1000 lux boundary 800 lux stays Dark, so the newest
+	// reading's event is removed
+	scheme, err := domain.NewCategoryScheme([]string{"Dark", "Bright"}, []float64{1000})
+	if err != nil {
+		t.Fatalf("NewCategoryScheme failed: %v", err)
+	}
+	result, err = RecomputeCategories(ctx, repo, scheme, 0)
+	if err != nil {
+		t.Fatalf("RecomputeCategories failed: %v", err)
+	}
+	if result.EventsUpdated != 1 || result.EventsTotal != 0 {
+		t.Errorf("expected the newest reading's event removed, got %+v", result)
+	}
+}
@@ -0,0 +1,866 @@
+package ports
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/mock"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+func TestRecordOnce_SourceIsSensor(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	recorder := NewRecorder(mock.NewFakeSensor(500.0, 0), repo, 0)
+	ctx := context.Background()
+
+	recorder.recordOnce(ctx)
+
+	latest, err := repo.GetLatestReading(ctx)
+	if err != nil {
+		t.Fatalf("GetLatestReading failed: %v", err)
+	}
+	if latest.Source != domain.SourceSensor {
+		t.Errorf("expected source %q, got %q", domain.SourceSensor, latest.Source)
+	}
+}
+
+func TestRecordOnce_PublishesSavedReading(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	bus := NewEventBus()
+	events, unsubscribe := bus.Subscribe(4)
+	defer unsubscribe()
+	recorder := NewRecorder(mock.NewFakeSensor(500.0, 0), repo, 0,
+		WithEventBus(bus), WithIDGenerator(func() string { return "cycle-1" }))
+	ctx := context.Background()
+
+	recorder.recordOnce(ctx)
+
+	select {
+	case event := <-events:
+		reading := event.Reading
+		latest, _ := repo.GetLatestReading(ctx)
+		if reading.ID != latest.ID || reading.Lux != 500 {
+			t.Errorf("expected published reading %d at 500 lux, got %d at %v", latest.ID, reading.ID, reading.Lux)
+		}
+		if event.Category != domain.CategoryMedium || event.CorrelationID != "cycle-1" {
+			t.Errorf("expected a medium reading from cycle-1, got %v from %q", event.Category, event.CorrelationID)
+		}
+	default:
+		t.Fatal("expected the saved reading to be published")
+	}
+}
+
+// failingTemperatureSensor always errors, like a disconnected probe
+type failingTemperatureSensor struct{}
+
+func (failingTemperatureSensor) ReadCelsius(ctx context.Context) (float64, error) {
+	return 0, domain.ErrSensorUnavailable
+}
+
+func (failingTemperatureSensor) Close() error { return nil }
+
+func TestRecordOnce_WithTemperatureSensor(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	recorder := NewRecorder(mock.NewFakeSensor(500.0, 0), repo, 0,
+		WithTemperatureSensor(mock.NewFakeTemperatureSensor(21.0, 0)),
+	)
+	ctx := context.Background()
+
+	recorder.recordOnce(ctx)
+
+	latest, err := repo.GetLatestReading(ctx)
+	if err != nil {
+		t.Fatalf("GetLatestReading failed: %v", err)
+	}
+	if latest.TemperatureC == nil || *latest.TemperatureC != 21.0 {
+		t.Errorf("expected temperature 21, got %v", latest.TemperatureC)
+	}
+}
+
+func TestRecordOnce_TemperatureFailureStillRecordsLight(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	recorder := NewRecorder(mock.NewFakeSensor(500.0, 0), repo, 0,
+		WithTemperatureSensor(failingTemperatureSensor{}),
+	)
+	ctx := context.Background()
+
+	recorder.recordOnce(ctx)
+
+	latest, err := repo.GetLatestReading(ctx)
+	if err != nil {
+		t.Fatalf("expected light reading despite temperature failure, got %v", err)
+	}
+	if latest.TemperatureC != nil {
+		t.Errorf("expected no temperature, got %v", *latest.TemperatureC)
+	}
+}
+
+// sequenceSensor returns values from a fixed sequence, one per read
+type sequenceSensor struct {
+	values []float64
+	reads  int
+}
+
+func (s *sequenceSensor) ReadLux(ctx context.Context) (float64, error) {
+	v := s.values[s.reads%len(s.values)]
+	s.reads++
+	return v, nil
+}
+
+func (s *sequenceSensor) Close() error { return nil }
+
+func TestRecordOnce_SamplesPerReading(t *testing.T) {
+	tests := []struct {
+		name         string
+		values       []float64
+		dropOutliers bool
+		want         float64
+	}{
+		{"mean of all samples", []float64{100, 200, 300, 400}, false, 250},
+		{"outliers dropped", []float64{100, 200, 300, 5000}, true, 250},
+		{"too few samples to trim", []float64{100, 300}, true, 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := memory.NewReadingRepository()
+			sensor := &sequenceSensor{values: tt.values}
+			recorder := NewRecorder(sensor, repo, 0,
+				WithSamplesPerReading(len(tt.values), time.Millisecond, tt.dropOutliers),
+			)
+			ctx := context.Background()
+
+			recorder.recordOnce(ctx)
+
+			if sensor.reads != len(tt.values) {
+				t.Errorf("expected %d sensor reads, got %d", len(tt.values), sensor.reads)
+			}
+			latest, err := repo.GetLatestReading(ctx)
+			if err != nil {
+				t.Fatalf("GetLatestReading failed: %v", err)
+			}
+			if latest.Lux != tt.want {
+				t.Errorf("expected stored lux %v, got %v", tt.want, latest.Lux)
+			}
+		})
+	}
+}
+
+func TestRecordOnce_SamplesWaitOnClock(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	sensor := &sequenceSensor{values: []float64{100, 200, 300}}
+	clock := mock.NewFakeClock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	recorder := NewRecorder(sensor, repo, 0,
+		WithSamplesPerReading(3, time.Minute, false),
+		WithClock(clock),
+	)
+	ctx := context.Background()
+
+	done := make(chan struct{})
+	go func() {
+		recorder.recordOnce(ctx)
+		close(done)
+	}()
+
+	// Each gap only passes when the fake clock moves
+	for range 2 {
+		clock.WaitForTimers(1)
+		clock.Advance(time.Minute)
+	}
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("recording did not finish after the sample gaps passed")
+	}
+
+	latest, err := repo.GetLatestReading(ctx)
+	if err != nil {
+		t.Fatalf("GetLatestReading failed: %v", err)
+	}
+	if latest.Lux != 200 {
+		t.Errorf("expected the mean of 3 samples, got %v", latest.Lux)
+	}
+}
+
+func TestRecordOnce_SamplingRespectsCancellation(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	sensor := &sequenceSensor{values: []float64{100}}
+	clock := mock.NewFakeClock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	recorder := NewRecorder(sensor, repo, 0,
+		WithSamplesPerReading(5, time.Hour, false),
+		WithClock(clock),
+	)
+
+	// Cancel once the recorder is waiting for the second sample
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		clock.WaitForTimers(1)
+		cancel()
+	}()
+
+	recorder.recordOnce(ctx)
+
+	if sensor.reads != 1 {
+		t.Errorf("expected sampling to stop after cancellation, got %d reads", sensor.reads)
+	}
+	if _, err := repo.GetLatestReading(context.Background()); !errors.Is(err, domain.ErrReadingNotFound) {
+		t.Errorf("expected no reading saved after cancellation, got %v", err)
+	}
+}
+
+// idCapturingSensor records the correlation ID it was called with
+type idCapturingSensor struct {
+	seen string
+}
+
+func (s *idCapturingSensor) ReadLux(ctx context.Context) (float64, error) {
+	s.seen = CorrelationID(ctx)
+	return 500, nil
+}
+
+func (s *idCapturingSensor) Close() error { return nil }
+
+func TestRecordOnce_CorrelationID(t *testing.T) {
+	var buf bytes.Buffer
+	original := log.Logger
+	log.Logger = zerolog.New(&buf).Level(zerolog.DebugLevel)
+	t.Cleanup(func() { log.Logger = original })
+
+	ids := []string{"cycle-1", "cycle-2"}
+	next := 0
+	sensor := &idCapturingSensor{}
+	recorder := NewRecorder(sensor, memory.NewReadingRepository(), 0,
+		// A failing temperature probe adds a warning to the cycle's logs
+		WithTemperatureSensor(failingTemperatureSensor{}),
+		WithIDGenerator(func() string {
+			id := ids[next]
+			next++
+			return id
+		}),
+	)
+
+	recorder.recordOnce(context.Background())
+
+	if sensor.seen != "cycle-1" {
+		t.Errorf("expected sensor context to carry cycle-1, got %q", sensor.seen)
+	}
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) < 3 {
+		t.Fatalf("expected debug, warn and info lines, got %d: %s", len(lines), buf.String())
+	}
+	for _, line := range lines {
+		var entry map[string]any
+		if err := json.Unmarshal(line, &entry); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		if entry["correlation_id"] != "cycle-1" {
+			t.Errorf("expected correlation_id cycle-1 on %q", line)
+		}
+	}
+
+	buf.Reset()
+	recorder.recordOnce(context.Background())
+	if sensor.seen != "cycle-2" {
+		t.Errorf("expected a new ID for the next cycle, got %q", sensor.seen)
+	}
+}
+
+func TestRecordOnce_PersistsCategoryTransitions(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	ctx := context.Background()
+
+	// A reading persisted before this recorder started: Low
+	before, _ := domain.NewLightReading(100)
+	before.Timestamp = time.Now().Add(-time.Minute)
+	_ = repo.SaveReading(ctx, before)
+
+	sensor := &sequenceSensor{values: []float64{150, 800, 900, 3000, 2000, 50}}
+	recorder := NewRecorder(sensor, repo, 0)
+	for range sensor.values {
+		recorder.recordOnce(ctx)
+	}
+
+	events, err := repo.GetCategoryEvents(ctx, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GetCategoryEvents failed: %v", err)
+	}
+
+	want := []struct {
+		from, to domain.Category
+		lux      float64
+	}{
+		{domain.CategoryLow, domain.CategoryMedium, 800},
+		{domain.CategoryMedium, domain.CategoryHigh, 3000},
+		{domain.CategoryHigh, domain.CategoryMedium, 2000},
+		{domain.CategoryMedium, domain.CategoryLow, 50},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %d", len(want), len(events))
+	}
+	for i, w := range want {
+		e := events[i]
+		if e.From != w.from || e.To != w.to || e.Lux != w.lux {
+			t.Errorf("event %d: expected %v->%v at %v lux, got %v->%v at %v lux",
+				i, w.from, w.to, w.lux, e.From, e.To, e.Lux)
+		}
+	}
+}
+
+// cleanupSignalRepo reports each DeleteOldReadings call on a channel
+type cleanupSignalRepo struct {
+	*memory.ReadingRepository
+	cleanups chan struct{}
+}
+
+func (r *cleanupSignalRepo) DeleteOldReadings(ctx context.Context, olderThan time.Duration) (int64, error) {
+	r.cleanups <- struct{}{}
+	return r.ReadingRepository.DeleteOldReadings(ctx, olderThan)
+}
+
+func TestRecorder_FakeClockDrivesCleanup(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := mock.NewFakeClock(start)
+	repo := &cleanupSignalRepo{
+		ReadingRepository: memory.NewReadingRepository(),
+		cleanups:          make(chan struct{}, 1),
+	}
+	recorder := NewRecorder(mock.NewFakeSensor(500.0, 0), repo, time.Hour, WithClock(clock))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		recorder.Start(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// Recording and cleanup tickers are both running
+	clock.WaitForTickers(2)
+
+	// The immediate recording happens just after the tickers are created
+	var latest *domain.LightReading
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		var err error
+		if latest, err = repo.GetLatestReading(ctx); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the immediate recording, got %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if !latest.Timestamp.Equal(start) {
+		t.Errorf("expected reading stamped with fake time %v, got %v", start, latest.Timestamp)
+	}
+
+	clock.Advance(23 * time.Hour)
+	select {
+	case <-repo.cleanups:
+		t.Fatal("cleanup ran before 24h had passed")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	clock.Advance(time.Hour)
+	select {
+	case <-repo.cleanups:
+	case <-time.After(5 * time.Second):
+		t.Fatal("cleanup did not run after 24h of fake time")
+	}
+}
+
+// warmingUpSensor fails its first few reads, like a sensor still initializing
+type warmingUpSensor struct {
+	mu       sync.Mutex
+	failures int
+	reads    int
+}
+
+func (s *warmingUpSensor) ReadLux(ctx context.Context) (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reads++
+	if s.reads <= s.failures {
+		return 0, domain.ErrSensorUnavailable
+	}
+	return 500, nil
+}
+
+func (s *warmingUpSensor) Close() error { return nil }
+
+func TestRecorder_StartupRetries(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	sensor := &warmingUpSensor{failures: 2}
+	clock := mock.NewFakeClock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	recorder := NewRecorder(sensor, repo, time.Hour,
+		WithStartupRetries(5, 10*time.Second),
+		WithClock(clock),
+	)
+	ctx := context.Background()
+
+	done := make(chan struct{})
+	go func() {
+		recorder.recordInitial(ctx)
+		close(done)
+	}()
+
+	// Each retry only happens once the fake clock passes the delay
+	for range 2 {
+		clock.WaitForTimers(1)
+		clock.Advance(10 * time.Second)
+	}
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("startup retries did not finish after the sensor warmed up")
+	}
+
+	if _, err := repo.GetLatestReading(ctx); err != nil {
+		t.Fatalf("expected a reading after the sensor warmed up, got %v", err)
+	}
+
+	sensor.mu.Lock()
+	defer sensor.mu.Unlock()
+	if sensor.reads != 3 {
+		t.Errorf("expected 3 sensor reads, got %d", sensor.reads)
+	}
+}
+
+func TestRecorder_StartupRetriesRespectCancellation(t *testing.T) {
+	sensor := &warmingUpSensor{failures: 100}
+	recorder := NewRecorder(sensor, memory.NewReadingRepository(), time.Hour,
+		WithStartupRetries(100, time.Hour))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		recorder.recordInitial(ctx)
+		close(done)
+	}()
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("startup retries did not stop on cancellation")
+	}
+}
+
+func TestRecordOnce_SkipUnchanged(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := mock.NewFakeClock(start)
+	repo := memory.NewReadingRepository()
+	// 199 and 201 are within epsilon but either side of the low/medium boundary
+	sensor := &sequenceSensor{values: []float64{500, 503, 510, 510, 510, 199, 201}}
+	recorder := NewRecorder(sensor, repo, time.Minute,
+		WithClock(clock),
+		WithSkipUnchanged(5, 10*time.Minute),
+	)
+	ctx := context.Background()
+
+	steps := []time.Duration{0, time.Minute, time.Minute, time.Minute, 10 * time.Minute, time.Minute, time.Minute}
+	for _, step := range steps {
+		clock.Advance(step)
+		recorder.recordOnce(ctx)
+	}
+
+	readings, err := repo.GetReadingsInRange(ctx, start, start.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GetReadingsInRange failed: %v", err)
+	}
+
+	// 503 is within 5 of 500, and the first 510 repeat is skipped; the second
+	// repeat comes after maxSkip so is saved anyway
+	want := []float64{500, 510, 510, 199, 201}
+	if len(readings) != len(want) {
+		t.Fatalf("expected %d saved readings, got %d", len(want), len(readings))
+	}
+	for i, w := range want {
+		if readings[i].Lux != w {
+			t.Errorf("reading %d: expected %v lux, got %v", i, w, readings[i].Lux)
+		}
+	}
+}
+
+func TestRecordOnce_SkipUnchangedUsesScheme(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := mock.NewFakeClock(start)
+	repo := memory.NewReadingRepository()
+	scheme, err := domain.NewCategoryScheme([]string{"Very Low", "Low", "Medium", "High", "Direct Sun"}, []float64{50, 200, 2500, 10000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 48 and 52 are within epsilon but either side of a boundary only the
+	// five-level scheme has
+	sensor := &sequenceSensor{values: []float64{48, 52}}
+	recorder := NewRecorder(sensor, repo, time.Minute,
+		WithClock(clock),
+		WithCategoryScheme(scheme),
+		WithSkipUnchanged(5, 10*time.Minute),
+	)
+	ctx := context.Background()
+
+	recorder.recordOnce(ctx)
+	clock.Advance(time.Minute)
+	recorder.recordOnce(ctx)
+
+	readings, err := repo.GetReadingsInRange(ctx, start, start.Add(time.Hour))
+	if err != nil || len(readings) != 2 {
+		t.Errorf("expected both readings saved, got %v, %v", readings, err)
+	}
+}
+
+func TestRecorder_NightMode(t *testing.T) {
+	var buf bytes.Buffer
+	original := log.Logger
+	log.Logger = zerolog.New(&buf)
+	t.Cleanup(func() { log.Logger = original })
+
+	clock := mock.NewFakeClock(time.Date(2024, 6, 1, 20, 0, 0, 0, time.UTC))
+	sensor := &sequenceSensor{values: []float64{
+		500, // day
+		5,   // dark for 0m
+		8,   // dark for 10m: not yet sustained
+		30,  // above enter (20) but the dark period restarts
+		5,   // dark for 0m
+		5,   // 10m
+		5,   // 20m: sustained, night mode
+		35,  // between thresholds: stays in night mode
+		60,  // above exit (50): back to normal
+	}}
+	recorder := NewRecorder(sensor, memory.NewReadingRepository(), 10*time.Minute,
+		WithClock(clock),
+		WithNightMode(NightMode{EnterBelow: 20, ExitAbove: 50, After: 20 * time.Minute, Interval: time.Hour}),
+	)
+	ctx := context.Background()
+
+	wantIntervals := []time.Duration{
+		10 * time.Minute, 10 * time.Minute, 10 * time.Minute, 10 * time.Minute,
+		10 * time.Minute, 10 * time.Minute, time.Hour, time.Hour, 10 * time.Minute,
+	}
+	for i, want := range wantIntervals {
+		recorder.recordOnce(ctx)
+		if got := recorder.currentInterval(); got != want {
+			t.Errorf("after reading %d (%v lux): expected interval %v, got %v", i, sensor.values[i], want, got)
+		}
+		clock.Advance(recorder.currentInterval())
+	}
+
+	var transitions []string
+	for _, line := range bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")) {
+		var entry map[string]any
+		if err := json.Unmarshal(line, &entry); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		if msg, _ := entry["message"].(string); strings.Contains(msg, "night mode") {
+			transitions = append(transitions, msg)
+		}
+	}
+	want := []string{"entering night mode", "light returned; leaving night mode"}
+	if len(transitions) != len(want) || transitions[0] != want[0] || transitions[1] != want[1] {
+		t.Errorf("expected transitions %q, got %q", want, transitions)
+	}
+}
+
+func TestRecorder_NightModeRetimesTicker(t *testing.T) {
+	clock := mock.NewFakeClock(time.Date(2024, 6, 1, 20, 0, 0, 0, time.UTC))
+	repo := memory.NewReadingRepository()
+	recorder := NewRecorder(mock.NewFakeSensor(1, 0), repo, time.Minute,
+		WithClock(clock),
+		// Dark from the first reading, so night mode starts immediately
+		WithNightMode(NightMode{EnterBelow: 20, ExitAbove: 50, After: 0, Interval: time.Hour}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		recorder.Start(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// Wait for the first reading and the switch to the hourly ticker
+	waitForReadings(t, repo, 1)
+	clock.WaitForTickerPeriod(time.Hour)
+
+	// A normal-interval tick would record again; the night ticker doesn't
+	clock.Advance(30 * time.Minute)
+	time.Sleep(20 * time.Millisecond)
+	if n := countReadings(t, repo); n != 1 {
+		t.Fatalf("expected no recording 30m into night mode, got %d readings", n)
+	}
+
+	clock.Advance(30 * time.Minute)
+	waitForReadings(t, repo, 2)
+}
+
+// countReadings returns how many readings the repository holds
+func countReadings(t *testing.T, repo *memory.ReadingRepository) int64 {
+	t.Helper()
+	stats, err := repo.Stats(context.Background())
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	return stats.ReadingCount
+}
+
+// waitForReadings polls until the repository holds at least n readings
+func waitForReadings(t *testing.T, repo *memory.ReadingRepository, n int64) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for countReadings(t, repo) < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d readings, got %d", n, countReadings(t, repo))
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRecorder_Status(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := mock.NewFakeClock(start)
+	sensor := &warmingUpSensor{}
+	recorder := NewRecorder(sensor, memory.NewReadingRepository(), 5*time.Minute, WithClock(clock))
+	ctx := context.Background()
+
+	status := recorder.Status()
+	if !status.LastSuccess.IsZero() || status.LastError != "" || status.ConsecutiveFailures != 0 || status.Interval != 5*time.Minute {
+		t.Errorf("unexpected initial status %+v", status)
+	}
+
+	_ = recorder.recordOnce(ctx)
+	status = recorder.Status()
+	if !status.LastSuccess.Equal(start) || status.LastError != "" {
+		t.Errorf("expected success at %v, got %+v", start, status)
+	}
+
+	// The sensor starts failing
+	sensor.mu.Lock()
+	sensor.failures = 100
+	sensor.mu.Unlock()
+	for i := 0; i < 2; i++ {
+		clock.Advance(5 * time.Minute)
+		_ = recorder.recordOnce(ctx)
+	}
+
+	status = recorder.Status()
+	if !status.LastSuccess.Equal(start) {
+		t.Errorf("expected last success to stay at %v, got %v", start, status.LastSuccess)
+	}
+	if status.ConsecutiveFailures != 2 || status.LastError != domain.ErrSensorUnavailable.Error() {
+		t.Errorf("expected 2 failures with the sensor error, got %+v", status)
+	}
+}
+
+// stuckSensor blocks every read until released, ignoring its context like a
+// hung blocking I2C read would
+type stuckSensor struct {
+	release chan struct{}
+}
+
+func (s *stuckSensor) ReadLux(ctx context.Context) (float64, error) {
+	<-s.release
+	return 500, nil
+}
+
+func (s *stuckSensor) Close() error { return nil }
+
+func TestRecorder_StopsPromptlyDuringStuckRead(t *testing.T) {
+	sensor := &stuckSensor{release: make(chan struct{})}
+	defer close(sensor.release)
+	repo := memory.NewReadingRepository()
+	recorder := NewRecorder(sensor, repo, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		recorder.Start(ctx)
+		close(done)
+	}()
+
+	time.Sleep(20 * time.Millisecond) // let the first read get stuck
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("recorder did not stop while a sensor read was stuck")
+	}
+
+	if got := recorder.Status(); !strings.Contains(got.LastError, context.Canceled.Error()) {
+		t.Errorf("expected the abandoned read recorded as cancelled, got %q", got.LastError)
+	}
+	if countReadings(t, repo) != 0 {
+		t.Error("expected nothing saved from an abandoned read")
+	}
+}
+
+func TestRecordOnce_ReadTimeout(t *testing.T) {
+	sensor := &stuckSensor{release: make(chan struct{})}
+	defer close(sensor.release)
+	recorder := NewRecorder(sensor, memory.NewReadingRepository(), time.Hour,
+		WithReadTimeout(20*time.Millisecond))
+
+	start := time.Now()
+	err := recorder.recordOnce(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("read timeout not applied: took %v", elapsed)
+	}
+}
+
+func TestRecordOnce_SaturatedQuality(t *testing.T) {
+	sensor := mock.NewFakeSensorSeeded(90000, 0, 1) // direct sun
+	sensor.SetSaturation(40000)
+
+	t.Run("stored with its flag", func(t *testing.T) {
+		repo := memory.NewReadingRepository()
+		recorder := NewRecorder(sensor, repo, time.Hour)
+
+		if err := recorder.recordOnce(context.Background()); err != nil {
+			t.Fatalf("recordOnce failed: %v", err)
+		}
+		latest, err := repo.GetLatestReading(context.Background())
+		if err != nil {
+			t.Fatalf("GetLatestReading failed: %v", err)
+		}
+		if latest.Lux != 40000 || latest.Quality != domain.QualitySaturated {
+			t.Errorf("expected saturated 40000 lux, got %v (%q)", latest.Lux, latest.Quality)
+		}
+	})
+
+	t.Run("dropped", func(t *testing.T) {
+		repo := memory.NewReadingRepository()
+		recorder := NewRecorder(sensor, repo, time.Hour, WithDropSaturated())
+
+		if err := recorder.recordOnce(context.Background()); !errors.Is(err, domain.ErrSaturatedReading) {
+			t.Errorf("expected ErrSaturatedReading, got %v", err)
+		}
+		if countReadings(t, repo) != 0 {
+			t.Error("expected the saturated reading dropped")
+		}
+	})
+
+	t.Run("unsaturated readings kept when dropping", func(t *testing.T) {
+		repo := memory.NewReadingRepository()
+		recorder := NewRecorder(mock.NewFakeSensorSeeded(500, 0, 1), repo, time.Hour, WithDropSaturated())
+
+		if err := recorder.recordOnce(context.Background()); err != nil {
+			t.Fatalf("recordOnce failed: %v", err)
+		}
+		latest, _ := repo.GetLatestReading(context.Background())
+		if latest == nil || latest.Quality != domain.QualityOK {
+			t.Errorf("expected an ok reading, got %v", latest)
+		}
+	})
+}
+
+func TestRecordOnce_AggregatesPolls(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	ctx := context.Background()
+	sensor := &sequenceSensor{values: []float64{100, 200, 300, 400, 500, 1000, 1000, 1000, 4000}}
+	recorder := NewRecorder(sensor, repo, 5*time.Minute, WithPollInterval(time.Minute))
+
+	// Five polls, then the recording: one reading of their mean
+	for range 5 {
+		recorder.pollOnce(ctx)
+	}
+	if countReadings(t, repo) != 0 {
+		t.Fatal("polls should not be persisted on their own")
+	}
+	if err := recorder.recordOnce(ctx); err != nil {
+		t.Fatalf("recordOnce failed: %v", err)
+	}
+
+	// The buffer starts afresh for the next interval
+	for range 4 {
+		recorder.pollOnce(ctx)
+	}
+	if err := recorder.recordOnce(ctx); err != nil {
+		t.Fatalf("recordOnce failed: %v", err)
+	}
+
+	readings, _ := repo.GetRecentReadings(ctx, 10)
+	if len(readings) != 2 || readings[0].Lux != 300 || readings[1].Lux != 1750 {
+		var got []float64
+		for _, r := range readings {
+			got = append(got, r.Lux)
+		}
+		t.Errorf("expected readings [300 1750], got %v", got)
+	}
+	if sensor.reads != 9 {
+		t.Errorf("expected recordings to use the polls rather than read again, got %d reads", sensor.reads)
+	}
+}
+
+// bufferedPolls reports how many polls the recorder is holding
+func bufferedPolls(r *Recorder) int {
+	r.pollMu.Lock()
+	defer r.pollMu.Unlock()
+	return len(r.polls)
+}
+
+// waitForPolls waits until the recorder is holding n polls
+func waitForPolls(t *testing.T, r *Recorder, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for bufferedPolls(r) != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d buffered polls, got %d", n, bufferedPolls(r))
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRecorder_PollLoopFeedsRecordings(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := mock.NewFakeClock(start)
+	repo := memory.NewReadingRepository()
+	sensor := mock.NewFakeSensor(500.0, 0)
+	recorder := NewRecorder(sensor, repo, 5*time.Minute, WithClock(clock), WithPollInterval(2*time.Minute))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		recorder.Start(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// Recording, cleanup and poll tickers, then the immediate recording
+	clock.WaitForTickers(3)
+	waitForReadings(t, repo, 1)
+
+	// Polls at 2m and 4m are buffered; the recording at 5m consumes them
+	for minute := 1; minute <= 5; minute++ {
+		clock.Advance(time.Minute)
+		if minute%2 == 0 {
+			waitForPolls(t, recorder, minute/2)
+		}
+	}
+	waitForReadings(t, repo, 2)
+	if n := bufferedPolls(recorder); n != 0 {
+		t.Errorf("expected the recording to drain the poll buffer, %d left", n)
+	}
+}
@@ -0,0 +1,57 @@
+package ports
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// SeedConfig describes the synthetic history SeedReadings generates
+type SeedConfig struct {
+	End      time.Time                  // last reading's timestamp
+	Span     time.Duration              // how far back from End readings go
+	Interval time.Duration              // spacing between readings
+	Lux      func(at time.Time) float64 // light model, e.g. mock.DaylightLux
+
+	// Force seeds even when the store already has readings. Without it a
+	// non-empty store is left alone, so seeding can't pollute real data.
+	Force bool
+}
+
+// SeedReadings fills an empty store with synthetic readings for demos. It
+// returns how many readings it inserted: zero when the store already had
+// data, which makes it safe to run on every startup.
+func SeedReadings(ctx context.Context, repo domain.ReadingRepository, cfg SeedConfig) (int, error) {
+	if cfg.Interval <= 0 {
+		return 0, fmt.Errorf("seed interval must be positive, got %v", cfg.Interval)
+	}
+
+	if !cfg.Force {
+		stats, err := repo.Stats(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("failed to check for existing readings: %w", err)
+		}
+		if stats.ReadingCount > 0 {
+			return 0, nil
+		}
+	}
+
+	var readings []*domain.LightReading
+	for at := cfg.End.Add(-cfg.Span); !at.After(cfg.End); at = at.Add(cfg.Interval) {
+		reading, err := domain.NewLightReadingAt(cfg.Lux(at), at)
+		if err != nil {
+			return 0, fmt.Errorf("seed reading at %v: %w", at, err)
+		}
+		reading.Source = domain.SourceImport
+		readings = append(readings, reading)
+	}
+
+	// Upsert so a forced re-seed replaces earlier seed readings rather than
+	// colliding with their timestamps
+	if err := repo.UpsertReadings(ctx, readings); err != nil {
+		return 0, fmt.Errorf("failed to save seed readings: %w", err)
+	}
+	return len(readings), nil
+}
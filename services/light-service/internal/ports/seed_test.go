@@ -0,0 +1,62 @@
+package ports
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/mock"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+func seedDay(force bool) SeedConfig {
+	return SeedConfig{
+		End:      time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+		Span:     24 * time.Hour,
+		Interval: 5 * time.Minute,
+		Lux:      func(at time.Time) float64 { return mock.DaylightLux(at, 3000) },
+		Force:    force,
+	}
+}
+
+func TestSeedReadings_EmptyStore(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	ctx := context.Background()
+
+	n, err := SeedReadings(ctx, repo, seedDay(false))
+	if err != nil {
+		t.Fatalf("SeedReadings failed: %v", err)
+	}
+	if want := 24*12 + 1; n != want || countReadings(t, repo) != int64(want) {
+		t.Errorf("expected %d seeded readings, got %d (store has %d)", want, n, countReadings(t, repo))
+	}
+
+	// Midday should be bright and midnight dark
+	noon, _ := repo.GetReadingAsOf(ctx, time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	midnight, _ := repo.GetReadingAsOf(ctx, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	if noon.Category() != domain.CategoryHigh || midnight.Category() != domain.CategoryLow {
+		t.Errorf("expected bright noon and dark midnight, got %v and %v lux", noon.Lux, midnight.Lux)
+	}
+}
+
+func TestSeedReadings_SkipsNonEmptyStore(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	ctx := context.Background()
+
+	existing, _ := domain.NewLightReading(420)
+	_ = repo.SaveReading(ctx, existing)
+
+	n, err := SeedReadings(ctx, repo, seedDay(false))
+	if err != nil {
+		t.Fatalf("SeedReadings failed: %v", err)
+	}
+	if n != 0 || countReadings(t, repo) != 1 {
+		t.Errorf("expected seeding skipped, got %d seeded and %d stored", n, countReadings(t, repo))
+	}
+
+	// Forcing seeds anyway
+	if n, err := SeedReadings(ctx, repo, seedDay(true)); err != nil || n == 0 {
+		t.Errorf("expected a forced seed, got %d, %v", n, err)
+	}
+}
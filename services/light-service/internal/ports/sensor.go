@@ -2,6 +2,8 @@ package ports
 
 import (
 	"context"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
 )
 
 // LightSensor defines how to read light levels
@@ -9,7 +11,37 @@ import (
 type LightSensor interface {
 	// ReadLux returns current light level in lux
 	ReadLux(ctx context.Context) (float64, error)
-	
+
+	// Close releases any resources
+	Close() error
+}
+
+// QualitySensor is a LightSensor that can also say how far to trust each
+// read, e.g. one that detects saturation. Its ReadLux is a thin wrapper that
+// drops the quality.
+type QualitySensor interface {
+	LightSensor
+
+	// ReadLuxWithQuality returns current light level in lux and its quality
+	ReadLuxWithQuality(ctx context.Context) (float64, domain.Quality, error)
+}
+
+// ReadLuxWithQuality reads sensor, with its quality if it is a QualitySensor
+// and QualityOK otherwise
+func ReadLuxWithQuality(ctx context.Context, sensor LightSensor) (float64, domain.Quality, error) {
+	if qs, ok := sensor.(QualitySensor); ok {
+		return qs.ReadLuxWithQuality(ctx)
+	}
+	lux, err := sensor.ReadLux(ctx)
+	return lux, domain.QualityOK, err
+}
+
+// TemperatureSensor defines how to read ambient temperature
+// Optional sibling of LightSensor for modules that report both
+type TemperatureSensor interface {
+	// ReadCelsius returns current ambient temperature in °C
+	ReadCelsius(ctx context.Context) (float64, error)
+
 	// Close releases any resources
 	Close() error
-}
\ No newline at end of file
+}
@@ -0,0 +1,209 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// SensorHealth is a SensorWatchdog's view of its sensor
+type SensorHealth struct {
+	Healthy             bool
+	ConsecutiveFailures int
+	LastError           string // empty until a read fails
+	Reinits             int    // times the sensor was successfully re-initialized
+	FallbackReads       int    // reads served by the fallback sensor
+	UsingFallback       bool   // the last successful read came from the fallback
+}
+
+// SensorWatchdog supervises a sensor: after threshold consecutive failed
+// reads it marks the sensor unhealthy, re-initializes it if it knows how,
+// and serves reads from a fallback sensor if there is one. Without a
+// fallback, reads from an unhealthy sensor fail with an error wrapping
+// domain.ErrSensorUnavailable. The sensor is tried first on every read, so
+// the watchdog notices as soon as it recovers.
+type SensorWatchdog struct {
+	threshold int
+	fallback  LightSensor                                    // nil without a fallback
+	reinit    func(ctx context.Context) (LightSensor, error) // nil if the sensor can't be re-initialized
+
+	readMu  sync.Mutex // serializes reads with swapping in a re-initialized sensor
+	primary LightSensor
+
+	mu     sync.Mutex
+	health SensorHealth
+}
+
+// WatchdogOption configures a SensorWatchdog
+type WatchdogOption func(*SensorWatchdog)
+
+// WithFallbackSensor serves reads from sensor while the watched one is
+// unhealthy
+func WithFallbackSensor(sensor LightSensor) WatchdogOption {
+	return func(w *SensorWatchdog) { w.fallback = sensor }
+}
+
+// WithReinit re-initializes an unhealthy sensor with open, e.g. by reopening
+// its I2C device, once it becomes unhealthy and again after every threshold
+// further failures. The old sensor is closed once open succeeds.
+func WithReinit(open func(ctx context.Context) (LightSensor, error)) WatchdogOption {
+	return func(w *SensorWatchdog) { w.reinit = open }
+}
+
+// NewSensorWatchdog supervises sensor, marking it unhealthy after threshold
+// consecutive failed reads
+func NewSensorWatchdog(sensor LightSensor, threshold int, opts ...WatchdogOption) *SensorWatchdog {
+	w := &SensorWatchdog{
+		threshold: max(threshold, 1),
+		primary:   sensor,
+		health:    SensorHealth{Healthy: true},
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// ReadLux reads the sensor, or the fallback while the sensor is unhealthy
+func (w *SensorWatchdog) ReadLux(ctx context.Context) (float64, error) {
+	lux, _, err := w.ReadLuxWithQuality(ctx)
+	return lux, err
+}
+
+// ReadLuxWithQuality is ReadLux with the quality reported by whichever
+// sensor served the read
+func (w *SensorWatchdog) ReadLuxWithQuality(ctx context.Context) (float64, domain.Quality, error) {
+	w.readMu.Lock()
+	defer w.readMu.Unlock()
+
+	lux, quality, err := ReadLuxWithQuality(ctx, w.primary)
+	if err == nil {
+		w.succeeded()
+		return lux, quality, nil
+	}
+	if ctx.Err() != nil {
+		// The caller gave up; that says nothing about the sensor
+		return 0, "", ctx.Err()
+	}
+
+	failures := w.failed(err)
+	if failures < w.threshold {
+		return 0, "", err
+	}
+
+	if w.reinit != nil && (failures-w.threshold)%w.threshold == 0 && w.reinitialize(ctx) {
+		lux, quality, err = ReadLuxWithQuality(ctx, w.primary)
+		if err == nil {
+			w.succeeded()
+			return lux, quality, nil
+		}
+	}
+
+	if w.fallback != nil {
+		lux, quality, fallbackErr := ReadLuxWithQuality(ctx, w.fallback)
+		if fallbackErr == nil {
+			w.servedByFallback()
+			return lux, quality, nil
+		}
+		err = errors.Join(err, fmt.Errorf("fallback sensor: %w", fallbackErr))
+	}
+	return 0, "", fmt.Errorf("%w: %w", domain.ErrSensorUnavailable, err)
+}
+
+// succeeded records a good read from the watched sensor
+func (w *SensorWatchdog) succeeded() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.health.Healthy {
+		log.Info().Int("failed_reads", w.health.ConsecutiveFailures).Msg("light sensor recovered")
+	}
+	w.health.Healthy = true
+	w.health.ConsecutiveFailures = 0
+	w.health.UsingFallback = false
+}
+
+// failed records a failed read from the watched sensor and returns how many
+// have failed in a row
+func (w *SensorWatchdog) failed(err error) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.health.ConsecutiveFailures++
+	w.health.LastError = err.Error()
+	if w.health.Healthy && w.health.ConsecutiveFailures >= w.threshold {
+		w.health.Healthy = false
+		log.Warn().
+			Err(err).
+			Int("failed_reads", w.health.ConsecutiveFailures).
+			Bool("fallback", w.fallback != nil).
+			Msg("light sensor keeps failing; marked unhealthy")
+	}
+	return w.health.ConsecutiveFailures
+}
+
+// servedByFallback records a read the fallback served
+func (w *SensorWatchdog) servedByFallback() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.health.UsingFallback {
+		log.Warn().Msg("serving reads from the fallback light sensor")
+	}
+	w.health.FallbackReads++
+	w.health.UsingFallback = true
+}
+
+// reinitialize swaps in a freshly opened sensor, reporting whether it could.
+// The caller holds readMu.
+func (w *SensorWatchdog) reinitialize(ctx context.Context) bool {
+	fresh, err := w.reinit(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to re-initialize light sensor")
+		return false
+	}
+	if err := w.primary.Close(); err != nil {
+		log.Debug().Err(err).Msg("failed to close the light sensor being replaced")
+	}
+	w.primary = fresh
+
+	w.mu.Lock()
+	w.health.Reinits++
+	w.mu.Unlock()
+	log.Info().Msg("re-initialized light sensor")
+	return true
+}
+
+// Status returns the sensor's health
+func (w *SensorWatchdog) Status() SensorHealth {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.health
+}
+
+// Err returns nil while the sensor is healthy, otherwise an error wrapping
+// domain.ErrSensorUnavailable with the last failure
+func (w *SensorWatchdog) Err() error {
+	health := w.Status()
+	if health.Healthy {
+		return nil
+	}
+	return fmt.Errorf("%w: %d failed reads, last: %s", domain.ErrSensorUnavailable, health.ConsecutiveFailures, health.LastError)
+}
+
+// Close closes the sensor and the fallback
+func (w *SensorWatchdog) Close() error {
+	w.readMu.Lock()
+	defer w.readMu.Unlock()
+
+	err := w.primary.Close()
+	if w.fallback != nil {
+		err = errors.Join(err, w.fallback.Close())
+	}
+	return err
+}
@@ -0,0 +1,138 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+func TestSensorWatchdog_MarksUnhealthyAndRecovers(t *testing.T) {
+	primary := &scriptedSensor{lux: 500, failures: 3}
+	watchdog := NewSensorWatchdog(primary, 3)
+	ctx := context.Background()
+
+	for i := 1; i <= 2; i++ {
+		if _, err := watchdog.ReadLux(ctx); err == nil {
+			t.Fatalf("read %d: expected the failure passed through", i)
+		}
+		if !watchdog.Status().Healthy || watchdog.Err() != nil {
+			t.Fatalf("read %d: expected healthy below the threshold, got %+v", i, watchdog.Status())
+		}
+	}
+
+	_, err := watchdog.ReadLux(ctx)
+	if !errors.Is(err, domain.ErrSensorUnavailable) {
+		t.Fatalf("expected ErrSensorUnavailable at the threshold, got %v", err)
+	}
+	status := watchdog.Status()
+	if status.Healthy || status.ConsecutiveFailures != 3 || status.LastError == "" {
+		t.Errorf("expected unhealthy after 3 failures, got %+v", status)
+	}
+	if !errors.Is(watchdog.Err(), domain.ErrSensorUnavailable) {
+		t.Errorf("expected Err to wrap ErrSensorUnavailable, got %v", watchdog.Err())
+	}
+
+	lux, err := watchdog.ReadLux(ctx)
+	if err != nil || lux != 500 {
+		t.Fatalf("expected the recovered sensor's 500 lux, got %v, %v", lux, err)
+	}
+	if status := watchdog.Status(); !status.Healthy || status.ConsecutiveFailures != 0 {
+		t.Errorf("expected healthy again, got %+v", status)
+	}
+}
+
+func TestSensorWatchdog_Fallback(t *testing.T) {
+	primary := &scriptedSensor{lux: 500, failures: -1} // always fails
+	fallback := &scriptedSensor{lux: 320}
+	watchdog := NewSensorWatchdog(primary, 2, WithFallbackSensor(fallback))
+	ctx := context.Background()
+
+	if _, err := watchdog.ReadLux(ctx); err == nil {
+		t.Fatal("expected the first failure passed through, below the threshold")
+	}
+	if fallback.reads != 0 {
+		t.Errorf("expected the fallback unused below the threshold, got %d reads", fallback.reads)
+	}
+
+	for i := 0; i < 2; i++ {
+		lux, err := watchdog.ReadLux(ctx)
+		if err != nil || lux != 320 {
+			t.Fatalf("expected the fallback's 320 lux, got %v, %v", lux, err)
+		}
+	}
+	status := watchdog.Status()
+	if status.Healthy || !status.UsingFallback || status.FallbackReads != 2 {
+		t.Errorf("expected unhealthy on the fallback with 2 reads, got %+v", status)
+	}
+	if primary.reads != 3 {
+		t.Errorf("expected the primary still tried on every read, got %d reads", primary.reads)
+	}
+
+	watchdog.Close()
+	if !primary.closed || !fallback.closed {
+		t.Error("expected Close to close both sensors")
+	}
+}
+
+func TestSensorWatchdog_FallbackFailsToo(t *testing.T) {
+	watchdog := NewSensorWatchdog(&scriptedSensor{failures: -1}, 1, WithFallbackSensor(&scriptedSensor{failures: -1}))
+
+	if _, err := watchdog.ReadLux(context.Background()); !errors.Is(err, domain.ErrSensorUnavailable) {
+		t.Errorf("expected ErrSensorUnavailable with both sensors down, got %v", err)
+	}
+}
+
+func TestSensorWatchdog_Reinit(t *testing.T) {
+	primary := &scriptedSensor{failures: -1} // broken until replaced
+	var opened []*scriptedSensor
+	open := func(ctx context.Context) (LightSensor, error) {
+		if len(opened) == 0 {
+			opened = append(opened, nil)
+			return nil, errors.New("device busy")
+		}
+		fresh := &scriptedSensor{lux: 410}
+		opened = append(opened, fresh)
+		return fresh, nil
+	}
+	watchdog := NewSensorWatchdog(primary, 2, WithReinit(open))
+	ctx := context.Background()
+
+	// The first attempt, at the threshold, fails to reopen the device
+	for i := 0; i < 2; i++ {
+		watchdog.ReadLux(ctx)
+	}
+	if len(opened) != 1 || watchdog.Status().Reinits != 0 {
+		t.Fatalf("expected one failed re-initialization, got %d attempts, %+v", len(opened), watchdog.Status())
+	}
+
+	// The next comes after another threshold of failures, and recovers
+	if _, err := watchdog.ReadLux(ctx); err == nil {
+		t.Fatal("expected no re-initialization between attempts")
+	}
+	lux, err := watchdog.ReadLux(ctx)
+	if err != nil || lux != 410 {
+		t.Fatalf("expected the re-initialized sensor's 410 lux, got %v, %v", lux, err)
+	}
+	status := watchdog.Status()
+	if !status.Healthy || status.Reinits != 1 {
+		t.Errorf("expected healthy after 1 re-initialization, got %+v", status)
+	}
+	if !primary.closed {
+		t.Error("expected the replaced sensor closed")
+	}
+}
+
+func TestSensorWatchdog_CancelledReadNotCounted(t *testing.T) {
+	watchdog := NewSensorWatchdog(&scriptedSensor{failures: -1}, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := watchdog.ReadLux(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if status := watchdog.Status(); !status.Healthy || status.ConsecutiveFailures != 0 {
+		t.Errorf("expected a cancelled read not to count against the sensor, got %+v", status)
+	}
+}
@@ -0,0 +1,154 @@
+// Package repository builds a ReadingRepository from configuration, so the
+// server, tests and tools all construct storage the same way
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/influx"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/postgres"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/sqlite"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// Supported repository types
+const (
+	TypeMemory   = "memory"
+	TypeSQLite   = "sqlite"
+	TypePostgres = "postgres"
+	TypeInflux   = "influx"
+)
+
+// RepoConfig selects and configures a repository
+type RepoConfig struct {
+	Type     string // TypeMemory (the default when empty), TypeSQLite, TypePostgres or TypeInflux
+	ReadOnly bool   // open the store without migrating or creating anything
+	SQLite   SQLiteConfig
+	Postgres PostgresConfig
+	Influx   InfluxConfig
+}
+
+// InfluxConfig configures the InfluxDB 2.x repository; zero values keep
+// the adapter's defaults
+type InfluxConfig struct {
+	URL          string // e.g. http://localhost:8086
+	Token        string
+	Org          string
+	Bucket       string        // created if missing
+	Retention    time.Duration // bucket expiry; 0 leaves the bucket's policy as it is
+	QueryTimeout time.Duration // bounds each repository call
+}
+
+// PostgresConfig configures the PostgreSQL repository; zero values keep the
+// adapter's defaults
+type PostgresConfig struct {
+	URL          string // postgres:// URL or key=value DSN
+	MaxConns     int
+	QueryTimeout time.Duration // bounds each repository call
+}
+
+// postgresConnectTimeout bounds connecting and migrating the schema, so an
+// unreachable server fails startup instead of hanging it
+const postgresConnectTimeout = 30 * time.Second
+
+// influxConnectTimeout bounds checking and creating the bucket at startup
+const influxConnectTimeout = 30 * time.Second
+
+// SQLiteConfig configures the SQLite repository; zero values keep the
+// adapter's defaults
+type SQLiteConfig struct {
+	Path         string
+	JournalMode  string
+	BusyTimeout  time.Duration
+	Synchronous  string
+	MaxOpenConns int
+
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	QueryTimeout    time.Duration // bounds each repository call
+}
+
+// New validates cfg and returns a ready repository. Repositories holding
+// resources (SQLite, PostgreSQL, InfluxDB) implement io.Closer; callers should close them.
+func New(cfg RepoConfig) (domain.ReadingRepository, error) {
+	switch cfg.Type {
+	case "", TypeMemory:
+		return memory.NewReadingRepository(), nil
+
+	case TypeSQLite:
+		if cfg.SQLite.Path == "" {
+			return nil, fmt.Errorf("sqlite repository needs a database path")
+		}
+		var opts []sqlite.Option
+		if cfg.SQLite.JournalMode != "" {
+			opts = append(opts, sqlite.WithJournalMode(cfg.SQLite.JournalMode))
+		}
+		if cfg.SQLite.BusyTimeout != 0 {
+			opts = append(opts, sqlite.WithBusyTimeout(cfg.SQLite.BusyTimeout))
+		}
+		if cfg.SQLite.Synchronous != "" {
+			opts = append(opts, sqlite.WithSynchronous(cfg.SQLite.Synchronous))
+		}
+		if cfg.SQLite.MaxOpenConns != 0 {
+			opts = append(opts, sqlite.WithMaxOpenConns(cfg.SQLite.MaxOpenConns))
+		}
+		if cfg.SQLite.MaxIdleConns != 0 {
+			opts = append(opts, sqlite.WithMaxIdleConns(cfg.SQLite.MaxIdleConns))
+		}
+		if cfg.SQLite.ConnMaxLifetime != 0 {
+			opts = append(opts, sqlite.WithConnMaxLifetime(cfg.SQLite.ConnMaxLifetime))
+		}
+		if cfg.SQLite.QueryTimeout != 0 {
+			opts = append(opts, sqlite.WithQueryTimeout(cfg.SQLite.QueryTimeout))
+		}
+		if cfg.ReadOnly {
+			opts = append(opts, sqlite.WithReadOnly())
+		}
+		return sqlite.NewReadingRepository(cfg.SQLite.Path, opts...)
+
+	case TypePostgres:
+		if cfg.Postgres.URL == "" {
+			return nil, fmt.Errorf("postgres repository needs a database URL")
+		}
+		var opts []postgres.Option
+		if cfg.Postgres.MaxConns != 0 {
+			opts = append(opts, postgres.WithMaxConns(cfg.Postgres.MaxConns))
+		}
+		if cfg.Postgres.QueryTimeout != 0 {
+			opts = append(opts, postgres.WithQueryTimeout(cfg.Postgres.QueryTimeout))
+		}
+		if cfg.ReadOnly {
+			opts = append(opts, postgres.WithReadOnly())
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), postgresConnectTimeout)
+		defer cancel()
+		return postgres.NewReadingRepository(ctx, cfg.Postgres.URL, opts...)
+
+	case TypeInflux:
+		if cfg.Influx.URL == "" || cfg.Influx.Org == "" || cfg.Influx.Bucket == "" {
+			return nil, fmt.Errorf("influx repository needs a server URL, organization and bucket")
+		}
+		var opts []influx.Option
+		if cfg.Influx.Token != "" {
+			opts = append(opts, influx.WithToken(cfg.Influx.Token))
+		}
+		if cfg.Influx.Retention != 0 {
+			opts = append(opts, influx.WithRetention(cfg.Influx.Retention))
+		}
+		if cfg.Influx.QueryTimeout != 0 {
+			opts = append(opts, influx.WithQueryTimeout(cfg.Influx.QueryTimeout))
+		}
+		if cfg.ReadOnly {
+			opts = append(opts, influx.WithReadOnly())
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), influxConnectTimeout)
+		defer cancel()
+		return influx.NewReadingRepository(ctx, cfg.Influx.URL, cfg.Influx.Org, cfg.Influx.Bucket, opts...)
+
+	default:
+		return nil, fmt.Errorf("unknown repository type %q (want %s, %s, %s or %s)", cfg.Type, TypeMemory, TypeSQLite, TypePostgres, TypeInflux)
+	}
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/sqlite"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+)
+
+// roundTrip checks the repository can store and return a reading
+func roundTrip(t *testing.T, repo domain.ReadingRepository) {
+	t.Helper()
+	ctx := context.Background()
+
+	reading, _ := domain.NewLightReading(500)
+	if err := repo.SaveReading(ctx, reading); err != nil {
+		t.Fatalf("SaveReading failed: %v", err)
+	}
+	latest, err := repo.GetLatestReading(ctx)
+	if err != nil || latest.Lux != 500 {
+		t.Fatalf("expected the saved reading back, got %v, %v", latest, err)
+	}
+}
+
+func TestNew_Memory(t *testing.T) {
+	for _, typ := range []string{"", TypeMemory} {
+		repo, err := New(RepoConfig{Type: typ})
+		if err != nil {
+			t.Fatalf("New(%q) failed: %v", typ, err)
+		}
+		if _, ok := repo.(*memory.ReadingRepository); !ok {
+			t.Errorf("New(%q): expected a memory repository, got %T", typ, repo)
+		}
+		roundTrip(t, repo)
+	}
+}
+
+func TestNew_SQLite(t *testing.T) {
+	repo, err := New(RepoConfig{
+		Type: TypeSQLite,
+		SQLite: SQLiteConfig{
+			Path:        filepath.Join(t.TempDir(), "light.db"),
+			JournalMode: "WAL",
+			BusyTimeout: time.Second,
+		},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if _, ok := repo.(*sqlite.ReadingRepository); !ok {
+		t.Errorf("expected a sqlite repository, got %T", repo)
+	}
+	closer, ok := repo.(io.Closer)
+	if !ok {
+		t.Fatal("expected the sqlite repository to be closable")
+	}
+	defer closer.Close()
+
+	roundTrip(t, repo)
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     RepoConfig
+		wantErr string
+	}{
+		{"unknown type", RepoConfig{Type: "mongo"}, `unknown repository type "mongo"`},
+		{"sqlite without path", RepoConfig{Type: TypeSQLite}, "needs a database path"},
+		{"sqlite bad journal mode", RepoConfig{Type: TypeSQLite, SQLite: SQLiteConfig{Path: "x.db", JournalMode: "BOGUS"}}, "invalid journal mode"},
+		{"postgres without URL", RepoConfig{Type: TypePostgres}, "needs a database URL"},
+		{"postgres bad URL", RepoConfig{Type: TypePostgres, Postgres: PostgresConfig{URL: "postgres://host:notaport/db"}}, "invalid database URL"},
+		{"influx without org", RepoConfig{Type: TypeInflux, Influx: InfluxConfig{URL: "http://localhost:8086", Bucket: "light"}}, "needs a server URL, organization and bucket"},
+		{"influx short retention", RepoConfig{Type: TypeInflux, Influx: InfluxConfig{URL: "http://localhost:8086", Org: "home", Bucket: "light", Retention: time.Minute}}, "retention must be 0 or at least 1h"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+// Package client provides a ready-to-use gRPC client for light-service,
+// wiring TLS and retry policy so consumers don't repeat the dialing boilerplate.
+// New returns the raw generated client; Dial returns a Client with typed
+// methods for the common calls.
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/middleware"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/tlsconfig"
+)
+
+// defaultServiceConfig retries the idempotent read RPCs on transient failures.
+// RecordReading is deliberately excluded: retrying it could store duplicates.
+const defaultServiceConfig = `{
+	"methodConfig": [{
+		"name": [
+			{"service": "light.v1.LightService", "method": "GetCurrentLight"},
+			{"service": "light.v1.LightService", "method": "GetHistory"}
+		],
+		"retryPolicy": {
+			"maxAttempts": 4,
+			"initialBackoff": "0.1s",
+			"maxBackoff": "2s",
+			"backoffMultiplier": 2,
+			"retryableStatusCodes": ["UNAVAILABLE", "RESOURCE_EXHAUSTED"]
+		}
+	}]
+}`
+
+// options collects the settings applied by Option functions
+type options struct {
+	certFile      string
+	keyFile       string
+	caFile        string
+	tlsConfig     *tls.Config
+	token         string
+	serviceConfig string
+	timeout       time.Duration
+	maxMsgSize    int
+	dialOpts      []grpc.DialOption
+}
+
+// Option configures the client created by New
+type Option func(*options)
+
+// WithTLS enables mTLS using this client's certificate, key and the CA certificate.
+// Without it the connection is insecure (dev mode only).
+func WithTLS(certFile, keyFile, caFile string) Option {
+	return func(o *options) {
+		o.certFile = certFile
+		o.keyFile = keyFile
+		o.caFile = caFile
+	}
+}
+
+// WithTLSConfig uses an already loaded TLS config, e.g. one shared with
+// clients of other services. It takes precedence over WithTLS.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(o *options) {
+		o.tlsConfig = cfg
+	}
+}
+
+// WithToken sends an API key with every call, for a server started with
+// AUTH_KEYS_FILE. Use it with TLS: without, the key crosses the network in
+// the clear.
+func WithToken(token string) Option {
+	return func(o *options) {
+		o.token = token
+	}
+}
+
+// WithTimeout bounds each unary call a Client makes, on top of any deadline
+// the caller's context already has (0 disables). Streams are not bounded.
+// It has no effect on the client returned by New.
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.timeout = d
+	}
+}
+
+// WithServiceConfig replaces the default retry service config with a custom JSON config.
+func WithServiceConfig(json string) Option {
+	return func(o *options) {
+		o.serviceConfig = json
+	}
+}
+
+// WithMaxMessageSize raises (or lowers) the largest message the client will
+// send or accept, in bytes. It should match the server's MAX_MSG_SIZE when
+// recording large batches or fetching long histories; gRPC's default receive
+// limit is 4 MiB.
+func WithMaxMessageSize(bytes int) Option {
+	return func(o *options) {
+		o.maxMsgSize = bytes
+	}
+}
+
+// WithDialOptions appends raw gRPC dial options, applied after the defaults.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOpts = append(o.dialOpts, opts...)
+	}
+}
+
+// New creates a LightService client for addr. The returned Closer releases the
+// underlying connection and must be called when the client is no longer needed.
+func New(addr string, opts ...Option) (pb.LightServiceClient, io.Closer, error) {
+	conn, _, err := dial(addr, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pb.NewLightServiceClient(conn), conn, nil
+}
+
+// dial applies opts and connects to addr
+func dial(addr string, opts []Option) (*grpc.ClientConn, options, error) {
+	o := options{serviceConfig: defaultServiceConfig, timeout: DefaultTimeout}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var creds credentials.TransportCredentials
+	switch {
+	case o.tlsConfig != nil:
+		creds = credentials.NewTLS(o.tlsConfig)
+	case o.certFile != "":
+		tlsCfg, err := tlsconfig.LoadClientTLS(o.certFile, o.keyFile, o.caFile)
+		if err != nil {
+			return nil, o, fmt.Errorf("load client TLS: %w", err)
+		}
+		creds = credentials.NewTLS(tlsCfg)
+	default:
+		creds = insecure.NewCredentials()
+	}
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultServiceConfig(o.serviceConfig),
+		// A caller serving a request passes its ID on to light-service
+		grpc.WithChainUnaryInterceptor(middleware.UnaryClientRequestID()),
+		grpc.WithChainStreamInterceptor(middleware.StreamClientRequestID()),
+	}
+	if o.token != "" {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(TokenCredentials(o.token)))
+	}
+	if o.maxMsgSize > 0 {
+		dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(o.maxMsgSize),
+			grpc.MaxCallSendMsgSize(o.maxMsgSize),
+		))
+	}
+	dialOpts = append(dialOpts, o.dialOpts...)
+
+	conn, err := grpc.NewClient(addr, dialOpts...)
+	if err != nil {
+		return nil, o, fmt.Errorf("dial light-service at %s: %w", addr, err)
+	}
+	return conn, o, nil
+}
+
+// tokenCredentials sends an API key as "authorization: Bearer" metadata
+type tokenCredentials string
+
+// TokenCredentials returns per-RPC credentials sending token as an API key,
+// for callers dialing light-service without this package. They are allowed
+// on insecure connections so dev setups work without certificates.
+func TokenCredentials(token string) credentials.PerRPCCredentials {
+	return tokenCredentials(token)
+}
+
+func (t tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
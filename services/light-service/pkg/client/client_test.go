@@ -0,0 +1,256 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	grpcAdapter "github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/grpc"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/mock"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/auth"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/tlsconfig"
+)
+
+// testCerts holds file paths for a throwaway CA plus server and client key pairs
+type testCerts struct {
+	ca, serverCert, serverKey, clientCert, clientKey string
+}
+
+// writeTestCerts generates a CA and CA-signed server/client certificates into dir.
+func writeTestCerts(t *testing.T, dir string) testCerts {
+	t.Helper()
+
+	caKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("create CA: %v", err)
+	}
+	caCert, _ := x509.ParseCertificate(caDER)
+
+	issue := func(name string, serial int64, usage x509.ExtKeyUsage) (certPath, keyPath string) {
+		key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		tmpl := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: name},
+			DNSNames:     []string{"localhost"},
+			IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, caCert, &key.PublicKey, caKey)
+		if err != nil {
+			t.Fatalf("create %s cert: %v", name, err)
+		}
+		keyDER, _ := x509.MarshalECPrivateKey(key)
+
+		certPath = filepath.Join(dir, name+".crt")
+		keyPath = filepath.Join(dir, name+".key")
+		writePEM(t, certPath, "CERTIFICATE", der)
+		writePEM(t, keyPath, "EC PRIVATE KEY", keyDER)
+		return certPath, keyPath
+	}
+
+	certs := testCerts{ca: filepath.Join(dir, "ca.crt")}
+	writePEM(t, certs.ca, "CERTIFICATE", caDER)
+	certs.serverCert, certs.serverKey = issue("light-service", 2, x509.ExtKeyUsageServerAuth)
+	certs.clientCert, certs.clientKey = issue("test-client", 3, x509.ExtKeyUsageClientAuth)
+	return certs
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+// startServer runs an in-process light-service on a random port and returns its address.
+func startServer(t *testing.T, opts ...grpc.ServerOption) string {
+	t.Helper()
+
+	handler := grpcAdapter.NewLightServiceHandler(memory.NewReadingRepository(), mock.NewFakeSensor(500.0, 0))
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	srv := grpc.NewServer(opts...)
+	pb.RegisterLightServiceServer(srv, handler)
+
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	return lis.Addr().String()
+}
+
+func TestNew_Insecure(t *testing.T) {
+	addr := startServer(t)
+
+	client, closer, err := New(addr)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer closer.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := client.GetCurrentLight(ctx, &pb.GetCurrentLightRequest{})
+	if err != nil {
+		t.Fatalf("GetCurrentLight failed: %v", err)
+	}
+	if resp.Reading.Lux != 500.0 {
+		t.Errorf("expected lux 500, got %v", resp.Reading.Lux)
+	}
+}
+
+func TestNew_MutualTLS(t *testing.T) {
+	certs := writeTestCerts(t, t.TempDir())
+
+	serverTLS, err := tlsconfig.LoadServerTLS(certs.serverCert, certs.serverKey, certs.ca)
+	if err != nil {
+		t.Fatalf("LoadServerTLS failed: %v", err)
+	}
+	addr := startServer(t, grpc.Creds(credentials.NewTLS(serverTLS)))
+
+	client, closer, err := New(addr, WithTLS(certs.clientCert, certs.clientKey, certs.ca))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer closer.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := client.GetCurrentLight(ctx, &pb.GetCurrentLightRequest{})
+	if err != nil {
+		t.Fatalf("GetCurrentLight over mTLS failed: %v", err)
+	}
+	if resp.Reading.Lux != 500.0 {
+		t.Errorf("expected lux 500, got %v", resp.Reading.Lux)
+	}
+}
+
+func TestNew_MutualTLS_RejectsInsecureClient(t *testing.T) {
+	certs := writeTestCerts(t, t.TempDir())
+
+	serverTLS, err := tlsconfig.LoadServerTLS(certs.serverCert, certs.serverKey, certs.ca)
+	if err != nil {
+		t.Fatalf("LoadServerTLS failed: %v", err)
+	}
+	addr := startServer(t, grpc.Creds(credentials.NewTLS(serverTLS)))
+
+	client, closer, err := New(addr)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer closer.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if _, err := client.GetCurrentLight(ctx, &pb.GetCurrentLightRequest{}); err == nil {
+		t.Error("expected plaintext client to be rejected by mTLS server")
+	}
+}
+
+func TestNew_MissingCertFiles(t *testing.T) {
+	_, _, err := New("127.0.0.1:0", WithTLS("missing.crt", "missing.key", "missing-ca.crt"))
+	if err == nil {
+		t.Error("expected error for missing certificate files")
+	}
+}
+
+func TestWithToken(t *testing.T) {
+	keys := auth.NewKeyStore()
+	keys.Add("guest", auth.RoleReader, "read-key")
+	authenticator := grpcAdapter.NewAuthenticator(keys, zerolog.Nop())
+	addr := startServer(t, grpc.UnaryInterceptor(authenticator.UnaryInterceptor()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for token, want := range map[string]codes.Code{"": codes.Unauthenticated, "read-key": codes.OK} {
+		client, closer, err := New(addr, WithToken(token))
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		_, err = client.GetCurrentLight(ctx, &pb.GetCurrentLightRequest{})
+		closer.Close()
+		if code := status.Code(err); code != want {
+			t.Errorf("token %q: got %v, want %v", token, code, want)
+		}
+	}
+}
+
+func TestWithMaxMessageSize(t *testing.T) {
+	// ~5 MiB on the wire: over gRPC's 4 MiB default receive limit
+	temp := 21.5
+	req := &pb.RecordReadingsBatchRequest{}
+	for i := 0; i < 250_000; i++ {
+		req.Readings = append(req.Readings, &pb.RecordReadingRequest{Lux: float64(i), TemperatureCelsius: &temp})
+	}
+
+	const raised = 64 << 20
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// Default server limit rejects the batch
+	client, closer, err := New(startServer(t))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer closer.Close()
+
+	if _, err := client.RecordReadingsBatch(ctx, req); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted at default limits, got %v", err)
+	}
+
+	// Raised on both sides it succeeds; the response is larger still
+	addr := startServer(t, grpc.MaxRecvMsgSize(raised), grpc.MaxSendMsgSize(raised))
+	client, closer, err = New(addr, WithMaxMessageSize(raised))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer closer.Close()
+
+	resp, err := client.RecordReadingsBatch(ctx, req)
+	if err != nil {
+		t.Fatalf("RecordReadingsBatch with raised limit failed: %v", err)
+	}
+	if len(resp.Readings) != len(req.Readings) {
+		t.Errorf("expected %d readings saved, got %d", len(req.Readings), len(resp.Readings))
+	}
+}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+// DefaultTimeout bounds each unary call a Client makes unless overridden
+// with WithTimeout
+const DefaultTimeout = 10 * time.Second
+
+// historyPageSize is how many readings History fetches per call, keeping
+// each response well under gRPC's default message size limit
+const historyPageSize = 5000
+
+// Reading is a light reading converted from its protobuf form
+type Reading struct {
+	ID                 int64 // 0 for live or smoothed reads that were not stored
+	Lux                float64
+	Time               time.Time
+	Category           string
+	Source             pb.ReadingSource
+	Quality            pb.ReadingQuality
+	TemperatureCelsius *float64 // nil when no temperature was recorded
+}
+
+// Client is a typed light-service client. Calls it doesn't wrap are
+// available through Raw.
+type Client struct {
+	conn    *grpc.ClientConn
+	rpc     pb.LightServiceClient
+	timeout time.Duration
+}
+
+// Dial creates a Client for addr, configured the same way as New. Close
+// releases the connection.
+func Dial(addr string, opts ...Option) (*Client, error) {
+	conn, o, err := dial(addr, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn, rpc: pb.NewLightServiceClient(conn), timeout: o.timeout}, nil
+}
+
+// Raw returns the generated client sharing this Client's connection
+func (c *Client) Raw() pb.LightServiceClient {
+	return c.rpc
+}
+
+// Close releases the underlying connection
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Current returns the latest reading
+func (c *Client) Current(ctx context.Context) (Reading, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.rpc.GetCurrentLight(ctx, &pb.GetCurrentLightRequest{})
+	if err != nil {
+		return Reading{}, fmt.Errorf("GetCurrentLight: %w", err)
+	}
+	return readingFromProto(resp.GetReading()), nil
+}
+
+// History returns the readings in [start, end), oldest first, fetching
+// long ranges a page at a time
+func (c *Client) History(ctx context.Context, start, end time.Time) ([]Reading, error) {
+	var readings []Reading
+	req := &pb.GetHistoryRequest{
+		StartTimeMs: start.UnixMilli(),
+		EndTimeMs:   end.UnixMilli(),
+		Limit:       historyPageSize,
+	}
+	for {
+		resp, err := c.historyPage(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("GetHistory: %w", err)
+		}
+		for _, r := range resp.GetReadings() {
+			readings = append(readings, readingFromProto(r))
+		}
+		if resp.GetNextPageToken() == "" {
+			return readings, nil
+		}
+		req.PageToken = resp.GetNextPageToken()
+	}
+}
+
+// historyPage fetches one page of History under its own timeout
+func (c *Client) historyPage(ctx context.Context, req *pb.GetHistoryRequest) (*pb.GetHistoryResponse, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	return c.rpc.GetHistory(ctx, req)
+}
+
+// ReadingStream receives readings as light-service records them
+type ReadingStream struct {
+	stream pb.LightService_StreamReadingsClient
+}
+
+// Stream subscribes to readings as they are recorded. It returns once the
+// server has confirmed the subscription, so no reading recorded after that
+// is missed. Cancel ctx to unsubscribe.
+func (c *Client) Stream(ctx context.Context) (*ReadingStream, error) {
+	stream, err := c.rpc.StreamReadings(ctx, &pb.StreamReadingsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("StreamReadings: %w", err)
+	}
+	// The server sends headers once it has subscribed. A call that failed
+	// straight away has none, and its status comes from Recv.
+	md, err := stream.Header()
+	if err == nil && md == nil {
+		_, err = stream.Recv()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("StreamReadings: %w", err)
+	}
+	return &ReadingStream{stream: stream}, nil
+}
+
+// Recv blocks until the next reading, or returns the error that ended the
+// stream (a Canceled status once ctx is cancelled)
+func (s *ReadingStream) Recv() (Reading, error) {
+	r, err := s.stream.Recv()
+	if err != nil {
+		return Reading{}, err
+	}
+	return readingFromProto(r), nil
+}
+
+// withTimeout bounds ctx by the client's timeout, if it has one
+func (c *Client) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+// readingFromProto converts a protobuf reading
+func readingFromProto(r *pb.LightReading) Reading {
+	return Reading{
+		ID:                 r.GetId(),
+		Lux:                r.GetLux(),
+		Time:               time.UnixMilli(r.GetTimestampMs()),
+		Category:           r.GetCategory(),
+		Source:             r.GetSource(),
+		Quality:            r.GetQuality(),
+		TemperatureCelsius: r.TemperatureCelsius,
+	}
+}
@@ -0,0 +1,164 @@
+package client
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	grpcAdapter "github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/grpc"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/adapters/mock"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/light-service/internal/ports"
+	"github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+)
+
+// startHandlerServer serves handler and returns its address
+func startHandlerServer(t *testing.T, handler pb.LightServiceServer) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	srv := grpc.NewServer()
+	pb.RegisterLightServiceServer(srv, handler)
+
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	return lis.Addr().String()
+}
+
+func dialTest(t *testing.T, addr string, opts ...Option) *Client {
+	t.Helper()
+	c, err := Dial(addr, opts...)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestClient_Current(t *testing.T) {
+	c := dialTest(t, startServer(t))
+
+	reading, err := c.Current(context.Background())
+	if err != nil {
+		t.Fatalf("Current failed: %v", err)
+	}
+	if reading.Lux != 500 || reading.Category == "" || reading.Source != pb.ReadingSource_READING_SOURCE_SENSOR {
+		t.Errorf("unexpected reading %+v", reading)
+	}
+	if time.Since(reading.Time) > time.Minute {
+		t.Errorf("expected a recent timestamp, got %v", reading.Time)
+	}
+}
+
+func TestClient_HistoryPages(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	ctx := context.Background()
+	start := time.Now().Add(-24 * time.Hour).Truncate(time.Millisecond)
+
+	// More than one page
+	const n = historyPageSize + 7
+	readings := make([]*domain.LightReading, n)
+	for i := range readings {
+		readings[i], _ = domain.NewLightReadingAt(float64(i), start.Add(time.Duration(i)*time.Second))
+	}
+	if err := repo.SaveReadings(ctx, readings); err != nil {
+		t.Fatalf("SaveReadings failed: %v", err)
+	}
+
+	handler := grpcAdapter.NewLightServiceHandler(repo, mock.NewFakeSensor(500, 0))
+	c := dialTest(t, startHandlerServer(t, handler))
+
+	got, err := c.History(ctx, start, time.Now())
+	if err != nil {
+		t.Fatalf("History failed: %v", err)
+	}
+	if len(got) != n {
+		t.Fatalf("expected %d readings, got %d", n, len(got))
+	}
+	for i, r := range got {
+		if r.Lux != float64(i) || !r.Time.Equal(readings[i].Timestamp) {
+			t.Fatalf("reading %d: expected %v lux at %v, got %+v", i, float64(i), readings[i].Timestamp, r)
+		}
+	}
+
+	// The end is exclusive
+	got, _ = c.History(ctx, start, start.Add(3*time.Second))
+	if len(got) != 3 {
+		t.Errorf("expected 3 readings in the first 3s, got %d", len(got))
+	}
+}
+
+func TestClient_Stream(t *testing.T) {
+	bus := ports.NewEventBus()
+	handler := grpcAdapter.NewLightServiceHandler(memory.NewReadingRepository(), mock.NewFakeSensor(500, 0), grpcAdapter.WithReadingStream(bus))
+	c := dialTest(t, startHandlerServer(t, handler))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := c.Stream(ctx)
+	if err != nil {
+		t.Fatalf("Stream failed: %v", err)
+	}
+
+	// Subscribed once Stream returns, so this is not missed
+	temp := 21.5
+	published, _ := domain.NewLightReading(320)
+	published.ID = 42
+	published.SetTemperature(temp)
+	bus.Publish(domain.ReadingRecorded{Reading: published})
+
+	got, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv failed: %v", err)
+	}
+	if got.ID != 42 || got.Lux != 320 || got.TemperatureCelsius == nil || *got.TemperatureCelsius != temp {
+		t.Errorf("unexpected reading %+v", got)
+	}
+
+	cancel()
+	if _, err := stream.Recv(); status.Code(err) != codes.Canceled {
+		t.Errorf("expected Canceled after cancelling, got %v", err)
+	}
+}
+
+func TestClient_StreamDisabled(t *testing.T) {
+	c := dialTest(t, startServer(t))
+
+	_, err := c.Stream(context.Background())
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition from Stream, got %v", err)
+	}
+}
+
+// stallingServer never answers GetCurrentLight
+type stallingServer struct {
+	pb.UnimplementedLightServiceServer
+}
+
+func (stallingServer) GetCurrentLight(ctx context.Context, _ *pb.GetCurrentLightRequest) (*pb.GetCurrentLightResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestClient_Timeout(t *testing.T) {
+	c := dialTest(t, startHandlerServer(t, stallingServer{}), WithTimeout(100*time.Millisecond))
+
+	begin := time.Now()
+	_, err := c.Current(context.Background())
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(begin); elapsed > 2*time.Second {
+		t.Errorf("expected the call cut short, took %v", elapsed)
+	}
+}
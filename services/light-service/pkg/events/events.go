@@ -0,0 +1,119 @@
+// Package events defines the messages light-service publishes to NATS when
+// it records a reading or fires an alert, and helps other services
+// subscribe to them without calling light-service over gRPC.
+//
+// Messages are JSON. Fields may be added over time, so subscribers should
+// ignore ones they don't know.
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Subjects light-service publishes on
+const (
+	SubjectReading = "plant.light.reading" // a Reading for each reading recorded
+	SubjectAlert   = "plant.light.alert"   // an Alert for each alert fired
+)
+
+// Reading is a light reading the recorder has taken. It is published once
+// saved or, when light-service buffers writes, once buffered: its ID is
+// then 0 and the reading may yet be lost if the store stays unreachable
+// until the buffer drops it.
+type Reading struct {
+	ID                 int64     `json:"id"` // 0 while the reading waits in a write buffer
+	Timestamp          time.Time `json:"timestamp"`
+	Lux                float64   `json:"lux"`
+	Category           string    `json:"category"` // the level's label, e.g. "Medium Light"
+	Source             string    `json:"source"`
+	Quality            string    `json:"quality"`
+	TemperatureCelsius *float64  `json:"temperature_celsius,omitempty"`
+	CorrelationID      string    `json:"correlation_id"` // the recording cycle, as in light-service's logs
+}
+
+// Alert is an alert rule firing
+type Alert struct {
+	AlertID      int64     `json:"alert_id"`
+	RuleID       int64     `json:"rule_id"`
+	RuleName     string    `json:"rule_name"`
+	Condition    string    `json:"condition"` // "below" or "above"
+	ThresholdLux float64   `json:"threshold_lux"`
+	Lux          float64   `json:"lux"`
+	Since        time.Time `json:"since"`    // when the condition was first breached
+	FiredAt      time.Time `json:"fired_at"` // timestamp of the reading that fired the rule
+	Message      string    `json:"message"`
+}
+
+// SubscribeOption configures a subscription
+type SubscribeOption func(*subscribeOptions)
+
+type subscribeOptions struct {
+	queue     string
+	onInvalid func(msg *nats.Msg, err error)
+}
+
+// WithQueue joins the queue group, so each message goes to only one of the
+// group's subscribers, e.g. one replica of a service
+func WithQueue(group string) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.queue = group
+	}
+}
+
+// WithInvalidHandler calls fn with each message that doesn't decode,
+// instead of dropping it silently
+func WithInvalidHandler(fn func(msg *nats.Msg, err error)) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.onInvalid = fn
+	}
+}
+
+// SubscribeReadings calls handle with each Reading published on conn until
+// the subscription is unsubscribed or the connection closed. As with any
+// NATS subscription, handle is called from one goroutine at a time.
+func SubscribeReadings(conn *nats.Conn, handle func(Reading), opts ...SubscribeOption) (*nats.Subscription, error) {
+	return subscribe(conn, SubjectReading, handle, opts)
+}
+
+// SubscribeAlerts calls handle with each Alert published on conn, as
+// SubscribeReadings does for readings
+func SubscribeAlerts(conn *nats.Conn, handle func(Alert), opts ...SubscribeOption) (*nats.Subscription, error) {
+	return subscribe(conn, SubjectAlert, handle, opts)
+}
+
+// subscribe decodes the messages on subject as T for handle
+func subscribe[T any](conn *nats.Conn, subject string, handle func(T), opts []SubscribeOption) (*nats.Subscription, error) {
+	o := newSubscribeOptions(opts)
+	decode := decoder(handle, o.onInvalid)
+	if o.queue != "" {
+		return conn.QueueSubscribe(subject, o.queue, decode)
+	}
+	return conn.Subscribe(subject, decode)
+}
+
+// newSubscribeOptions applies opts to the defaults
+func newSubscribeOptions(opts []SubscribeOption) subscribeOptions {
+	var o subscribeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// decoder returns a message handler that decodes each message as T for
+// handle, passing ones that don't decode to onInvalid if it is set
+func decoder[T any](handle func(T), onInvalid func(msg *nats.Msg, err error)) nats.MsgHandler {
+	return func(msg *nats.Msg) {
+		var v T
+		if err := json.Unmarshal(msg.Data, &v); err != nil {
+			if onInvalid != nil {
+				onInvalid(msg, err)
+			}
+			return
+		}
+		handle(v)
+	}
+}
@@ -0,0 +1,135 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+func TestDecoder_Reading(t *testing.T) {
+	var got []Reading
+	decode := decoder(func(r Reading) { got = append(got, r) }, nil)
+
+	// Fields added later are ignored
+	decode(&nats.Msg{Subject: SubjectReading, Data: []byte(`{
+		"id": 7,
+		"timestamp": "2024-06-01T12:00:00Z",
+		"lux": 800,
+		"category": "Medium Light",
+		"source": "sensor",
+		"quality": "ok",
+		"temperature_celsius": 21.5,
+		"correlation_id": "cycle-1",
+		"added_later": true
+	}`)})
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 reading, got %d", len(got))
+	}
+	r := got[0]
+	if r.ID != 7 || r.Lux != 800 || r.Category != "Medium Light" || r.Source != "sensor" ||
+		r.Quality != "ok" || r.CorrelationID != "cycle-1" {
+		t.Errorf("unexpected reading %+v", r)
+	}
+	if !r.Timestamp.Equal(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected timestamp %v", r.Timestamp)
+	}
+	if r.TemperatureCelsius == nil || *r.TemperatureCelsius != 21.5 {
+		t.Errorf("expected 21.5 °C, got %v", r.TemperatureCelsius)
+	}
+}
+
+func TestDecoder_Alert(t *testing.T) {
+	var got []Alert
+	decode := decoder(func(a Alert) { got = append(got, a) }, nil)
+
+	decode(&nats.Msg{Subject: SubjectAlert, Data: []byte(`{
+		"alert_id": 3,
+		"rule_id": 1,
+		"rule_name": "too dark",
+		"condition": "below",
+		"threshold_lux": 150,
+		"lux": 90,
+		"since": "2024-06-01T18:00:00Z",
+		"fired_at": "2024-06-01T20:00:00Z",
+		"message": "too dark: 90 lux below 150 lux since 18:00"
+	}`)})
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 alert, got %d", len(got))
+	}
+	a := got[0]
+	if a.AlertID != 3 || a.RuleID != 1 || a.RuleName != "too dark" || a.Condition != "below" ||
+		a.ThresholdLux != 150 || a.Lux != 90 || a.Message == "" {
+		t.Errorf("unexpected alert %+v", a)
+	}
+	if !a.FiredAt.Equal(time.Date(2024, 6, 1, 20, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected fired_at %v", a.FiredAt)
+	}
+}
+
+func TestDecoder_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"not json", `not json`},
+		{"wrong type", `{"lux": "bright"}`},
+		{"empty", ``},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handled := 0
+			var invalid *nats.Msg
+			var invalidErr error
+			decode := decoder(func(Reading) { handled++ }, func(msg *nats.Msg, err error) {
+				invalid, invalidErr = msg, err
+			})
+
+			msg := &nats.Msg{Subject: SubjectReading, Data: []byte(tt.data)}
+			decode(msg)
+
+			if handled != 0 {
+				t.Errorf("expected the message not to be handled, got %d calls", handled)
+			}
+			if invalid != msg || invalidErr == nil {
+				t.Errorf("expected the message to be reported invalid, got %v, %v", invalid, invalidErr)
+			}
+		})
+	}
+}
+
+func TestDecoder_InvalidWithoutHandlerIsDropped(t *testing.T) {
+	handled := 0
+	decode := decoder(func(Alert) { handled++ }, nil)
+
+	decode(&nats.Msg{Subject: SubjectAlert, Data: []byte(`not json`)})
+
+	if handled != 0 {
+		t.Errorf("expected the message to be dropped, got %d calls", handled)
+	}
+}
+
+func TestSubscribeOptions(t *testing.T) {
+	if o := newSubscribeOptions(nil); o.queue != "" || o.onInvalid != nil {
+		t.Errorf("expected no queue or invalid handler by default, got %+v", o)
+	}
+
+	called := false
+	o := newSubscribeOptions([]SubscribeOption{
+		WithQueue("plant-service"),
+		WithInvalidHandler(func(*nats.Msg, error) { called = true }),
+	})
+	if o.queue != "plant-service" {
+		t.Errorf("expected queue plant-service, got %q", o.queue)
+	}
+	if o.onInvalid == nil {
+		t.Fatal("expected the invalid handler to be set")
+	}
+	o.onInvalid(nil, nil)
+	if !called {
+		t.Error("expected the configured invalid handler")
+	}
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryLogging gives each call a logger tagged with its method and request
+// ID, which handlers retrieve with zerolog.Ctx, and logs the call's outcome
+// once it returns
+func UnaryLogging(logger zerolog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		l := requestLogger(ctx, logger, info.FullMethod)
+		resp, err := handler(l.WithContext(ctx), req)
+		logResult(l, start, err)
+		return resp, err
+	}
+}
+
+// StreamLogging is UnaryLogging for streaming calls, logging once the
+// stream ends
+func StreamLogging(logger zerolog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		l := requestLogger(ss.Context(), logger, info.FullMethod)
+		err := handler(srv, withContext(ss, l.WithContext(ss.Context())))
+		logResult(l, start, err)
+		return err
+	}
+}
+
+// requestLogger derives the logger for one call
+func requestLogger(ctx context.Context, logger zerolog.Logger, method string) zerolog.Logger {
+	c := logger.With().Str("grpc_method", method)
+	if id := RequestIDFromContext(ctx); id != "" {
+		c = c.Str("request_id", id)
+	}
+	return c.Logger()
+}
+
+// logResult logs a finished call at a level matching its status: successes
+// at debug, since the access log already records every call, the caller's
+// mistakes at warn and the server's failures at error
+func logResult(l zerolog.Logger, start time.Time, err error) {
+	code := status.Code(err)
+	var event *zerolog.Event
+	switch code {
+	case codes.OK:
+		event = l.Debug()
+	case codes.Unknown, codes.Internal, codes.DataLoss, codes.Unimplemented, codes.Unavailable, codes.DeadlineExceeded:
+		event = l.Error().Err(err)
+	default:
+		event = l.Warn().Err(err)
+	}
+	event.Str("grpc_code", code.String()).
+		Dur("duration", time.Since(start)).
+		Msg("request finished")
+}
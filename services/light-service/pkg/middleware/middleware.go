@@ -0,0 +1,47 @@
+// Package middleware provides gRPC interceptors shared by the plant-monitor
+// services: request IDs propagated through metadata, a request-scoped
+// zerolog logger with one line per call, and panic recovery. Chain them
+// outermost first, so the ID is set before anything logs and a recovered
+// panic is logged like any other failure:
+//
+//	grpc.ChainUnaryInterceptor(
+//		middleware.UnaryRequestID(),
+//		middleware.UnaryLogging(log.Logger),
+//		middleware.UnaryRecovery(log.Logger),
+//	)
+package middleware
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+)
+
+// wrappedStream overrides a server stream's context, which is otherwise
+// fixed when the stream is created
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *wrappedStream) Context() context.Context {
+	return s.ctx
+}
+
+// withContext returns ss with ctx as its context
+func withContext(ss grpc.ServerStream, ctx context.Context) grpc.ServerStream {
+	if w, ok := ss.(*wrappedStream); ok {
+		return &wrappedStream{ServerStream: w.ServerStream, ctx: ctx}
+	}
+	return &wrappedStream{ServerStream: ss, ctx: ctx}
+}
+
+// loggerFrom returns the request's logger if UnaryLogging or StreamLogging
+// attached one, otherwise fallback
+func loggerFrom(ctx context.Context, fallback zerolog.Logger) *zerolog.Logger {
+	if l := zerolog.Ctx(ctx); l != zerolog.DefaultContextLogger && l.GetLevel() != zerolog.Disabled {
+		return l
+	}
+	return &fallback
+}
@@ -0,0 +1,166 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// panickingHealth is a health server whose Check panics
+type panickingHealth struct {
+	healthpb.UnimplementedHealthServer
+}
+
+func (panickingHealth) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	panic("boom")
+}
+
+// startServer serves health through the full middleware stack, logging to
+// buf, and returns a client connection
+func startServer(t *testing.T, buf *bytes.Buffer, healthSrv healthpb.HealthServer) *grpc.ClientConn {
+	t.Helper()
+	logger := zerolog.New(buf)
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(UnaryRequestID(), UnaryLogging(logger), UnaryRecovery(logger)),
+		grpc.ChainStreamInterceptor(StreamRequestID(), StreamLogging(logger), StreamRecovery(logger)),
+	)
+	healthpb.RegisterHealthServer(srv, healthSrv)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(UnaryClientRequestID()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// logLines decodes the JSON log lines in buf
+func logLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var m map[string]any
+		if err := json.Unmarshal([]byte(line), &m); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		lines = append(lines, m)
+	}
+	return lines
+}
+
+func TestRequestID_PropagatedAndEchoed(t *testing.T) {
+	var buf bytes.Buffer
+	conn := startServer(t, &buf, health.NewServer())
+	client := healthpb.NewHealthClient(conn)
+
+	// A request ID in the caller's context travels through metadata
+	var header metadata.MD
+	ctx := ContextWithRequestID(context.Background(), "req-123")
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Header(&header)); err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if got := header.Get(RequestIDKey); len(got) != 1 || got[0] != "req-123" {
+		t.Errorf("expected the request ID echoed, got %v", got)
+	}
+	lines := logLines(t, &buf)
+	if lines[0]["request_id"] != "req-123" || lines[0]["grpc_code"] != "OK" || lines[0]["level"] != "debug" {
+		t.Errorf("unexpected log line %v", lines[0])
+	}
+
+	// Without one, or with an unusable one, the server makes one up
+	header = nil
+	ctx = metadata.AppendToOutgoingContext(context.Background(), RequestIDKey, "has spaces")
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Header(&header)); err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if got := header.Get(RequestIDKey); len(got) != 1 || len(got[0]) != 32 {
+		t.Errorf("expected a generated request ID, got %v", got)
+	}
+}
+
+func TestRequestID_Stream(t *testing.T) {
+	var buf bytes.Buffer
+	conn := startServer(t, &buf, health.NewServer())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := healthpb.NewHealthClient(conn).Watch(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	header, err := stream.Header()
+	if err != nil {
+		t.Fatalf("Header failed: %v", err)
+	}
+	if got := header.Get(RequestIDKey); len(got) != 1 || got[0] == "" {
+		t.Errorf("expected a request ID on the stream, got %v", got)
+	}
+}
+
+func TestRecovery_PanicBecomesInternal(t *testing.T) {
+	var buf bytes.Buffer
+	conn := startServer(t, &buf, panickingHealth{})
+
+	ctx := ContextWithRequestID(context.Background(), "req-boom")
+	_, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if status.Code(err) != codes.Internal || !strings.Contains(err.Error(), "req-boom") {
+		t.Fatalf("expected Internal quoting the request ID, got %v", err)
+	}
+
+	// The server survives to serve the next call
+	if _, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}); status.Code(err) != codes.Internal {
+		t.Errorf("expected the server to keep serving, got %v", err)
+	}
+
+	lines := logLines(t, &buf)
+	panicLine, resultLine := lines[0], lines[1]
+	if panicLine["panic"] != "boom" || panicLine["request_id"] != "req-boom" || panicLine["stack"] == nil {
+		t.Errorf("unexpected panic log line %v", panicLine)
+	}
+	if resultLine["level"] != "error" || resultLine["grpc_code"] != "Internal" {
+		t.Errorf("expected the failure logged at error, got %v", resultLine)
+	}
+}
+
+func TestLogging_HandlersGetRequestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	interceptor := UnaryLogging(zerolog.New(&buf))
+	ctx := ContextWithRequestID(context.Background(), "req-7")
+
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/light.v1.LightService/GetHistory"},
+		func(ctx context.Context, req any) (any, error) {
+			zerolog.Ctx(ctx).Info().Msg("from handler")
+			return nil, status.Error(codes.InvalidArgument, "bad range")
+		})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected the handler's error, got %v", err)
+	}
+
+	lines := logLines(t, &buf)
+	if lines[0]["message"] != "from handler" || lines[0]["request_id"] != "req-7" || lines[0]["grpc_method"] != "/light.v1.LightService/GetHistory" {
+		t.Errorf("expected the handler's line tagged with the request, got %v", lines[0])
+	}
+	if lines[1]["level"] != "warn" {
+		t.Errorf("expected a client error logged at warn, got %v", lines[1])
+	}
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryRecovery turns a panicking handler into a codes.Internal error, so
+// one bad request fails alone instead of taking the server down. The panic
+// and its stack are logged to the request's logger, or logger without one.
+// The client sees only the request ID to quote when reporting it.
+func UnaryRecovery(logger zerolog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = recovered(ctx, logger, info.FullMethod, p)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecovery is UnaryRecovery for streaming calls
+func StreamRecovery(logger zerolog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = recovered(ss.Context(), logger, info.FullMethod, p)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// recovered logs a panic and returns the error reported in its place
+func recovered(ctx context.Context, logger zerolog.Logger, method string, p any) error {
+	fallback := logger.With().Str("grpc_method", method).Logger()
+	loggerFrom(ctx, fallback).Error().
+		Interface("panic", p).
+		Bytes("stack", debug.Stack()).
+		Msg("recovered from panic in handler")
+
+	if id := RequestIDFromContext(ctx); id != "" {
+		return status.Errorf(codes.Internal, "internal error (request ID %s)", id)
+	}
+	return status.Error(codes.Internal, "internal error")
+}
@@ -21,27 +21,4765 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type SortOrder int32
+
+const (
+	SortOrder_SORT_ORDER_UNSPECIFIED SortOrder = 0
+	SortOrder_SORT_ORDER_ASCENDING   SortOrder = 1
+	SortOrder_SORT_ORDER_DESCENDING  SortOrder = 2
+)
+
+// Enum value maps for SortOrder.
+var (
+	SortOrder_name = map[int32]string{
+		0: "SORT_ORDER_UNSPECIFIED",
+		1: "SORT_ORDER_ASCENDING",
+		2: "SORT_ORDER_DESCENDING",
+	}
+	SortOrder_value = map[string]int32{
+		"SORT_ORDER_UNSPECIFIED": 0,
+		"SORT_ORDER_ASCENDING":   1,
+		"SORT_ORDER_DESCENDING":  2,
+	}
+)
+
+func (x SortOrder) Enum() *SortOrder {
+	p := new(SortOrder)
+	*p = x
+	return p
+}
+
+func (x SortOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_light_proto_enumTypes[0].Descriptor()
+}
+
+func (SortOrder) Type() protoreflect.EnumType {
+	return &file_api_proto_light_proto_enumTypes[0]
+}
+
+func (x SortOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortOrder.Descriptor instead.
+func (SortOrder) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{0}
+}
+
+// LightCategory is the three-level light category of a reading
+type LightCategory int32
+
+const (
+	LightCategory_LIGHT_CATEGORY_UNSPECIFIED LightCategory = 0
+	LightCategory_LIGHT_CATEGORY_LOW         LightCategory = 1
+	LightCategory_LIGHT_CATEGORY_MEDIUM      LightCategory = 2
+	LightCategory_LIGHT_CATEGORY_HIGH        LightCategory = 3
+)
+
+// Enum value maps for LightCategory.
+var (
+	LightCategory_name = map[int32]string{
+		0: "LIGHT_CATEGORY_UNSPECIFIED",
+		1: "LIGHT_CATEGORY_LOW",
+		2: "LIGHT_CATEGORY_MEDIUM",
+		3: "LIGHT_CATEGORY_HIGH",
+	}
+	LightCategory_value = map[string]int32{
+		"LIGHT_CATEGORY_UNSPECIFIED": 0,
+		"LIGHT_CATEGORY_LOW":         1,
+		"LIGHT_CATEGORY_MEDIUM":      2,
+		"LIGHT_CATEGORY_HIGH":        3,
+	}
+)
+
+func (x LightCategory) Enum() *LightCategory {
+	p := new(LightCategory)
+	*p = x
+	return p
+}
+
+func (x LightCategory) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LightCategory) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_light_proto_enumTypes[1].Descriptor()
+}
+
+func (LightCategory) Type() protoreflect.EnumType {
+	return &file_api_proto_light_proto_enumTypes[1]
+}
+
+func (x LightCategory) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LightCategory.Descriptor instead.
+func (LightCategory) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{1}
+}
+
+// ExportFormat is the text format DownloadReadings writes
+type ExportFormat int32
+
+const (
+	ExportFormat_EXPORT_FORMAT_UNSPECIFIED ExportFormat = 0 // CSV
+	ExportFormat_EXPORT_FORMAT_CSV         ExportFormat = 1 // a header row, then one row per reading
+	ExportFormat_EXPORT_FORMAT_NDJSON      ExportFormat = 2 // one JSON object per line
+)
+
+// Enum value maps for ExportFormat.
+var (
+	ExportFormat_name = map[int32]string{
+		0: "EXPORT_FORMAT_UNSPECIFIED",
+		1: "EXPORT_FORMAT_CSV",
+		2: "EXPORT_FORMAT_NDJSON",
+	}
+	ExportFormat_value = map[string]int32{
+		"EXPORT_FORMAT_UNSPECIFIED": 0,
+		"EXPORT_FORMAT_CSV":         1,
+		"EXPORT_FORMAT_NDJSON":      2,
+	}
+)
+
+func (x ExportFormat) Enum() *ExportFormat {
+	p := new(ExportFormat)
+	*p = x
+	return p
+}
+
+func (x ExportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_light_proto_enumTypes[2].Descriptor()
+}
+
+func (ExportFormat) Type() protoreflect.EnumType {
+	return &file_api_proto_light_proto_enumTypes[2]
+}
+
+func (x ExportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportFormat.Descriptor instead.
+func (ExportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{2}
+}
+
+// AlertCondition says which side of its threshold breaches a rule
+type AlertCondition int32
+
+const (
+	AlertCondition_ALERT_CONDITION_UNSPECIFIED AlertCondition = 0
+	AlertCondition_ALERT_CONDITION_BELOW       AlertCondition = 1 // lux < threshold_lux
+	AlertCondition_ALERT_CONDITION_ABOVE       AlertCondition = 2 // lux > threshold_lux
+)
+
+// Enum value maps for AlertCondition.
+var (
+	AlertCondition_name = map[int32]string{
+		0: "ALERT_CONDITION_UNSPECIFIED",
+		1: "ALERT_CONDITION_BELOW",
+		2: "ALERT_CONDITION_ABOVE",
+	}
+	AlertCondition_value = map[string]int32{
+		"ALERT_CONDITION_UNSPECIFIED": 0,
+		"ALERT_CONDITION_BELOW":       1,
+		"ALERT_CONDITION_ABOVE":       2,
+	}
+)
+
+func (x AlertCondition) Enum() *AlertCondition {
+	p := new(AlertCondition)
+	*p = x
+	return p
+}
+
+func (x AlertCondition) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AlertCondition) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_light_proto_enumTypes[3].Descriptor()
+}
+
+func (AlertCondition) Type() protoreflect.EnumType {
+	return &file_api_proto_light_proto_enumTypes[3]
+}
+
+func (x AlertCondition) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AlertCondition.Descriptor instead.
+func (AlertCondition) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{3}
+}
+
+// ReadingSource identifies which code path produced a reading
+type ReadingQuality int32
+
+const (
+	ReadingQuality_READING_QUALITY_UNSPECIFIED    ReadingQuality = 0
+	ReadingQuality_READING_QUALITY_OK             ReadingQuality = 1
+	ReadingQuality_READING_QUALITY_SATURATED      ReadingQuality = 2 // at the top of the sensor's range; true lux may be higher
+	ReadingQuality_READING_QUALITY_LOW_CONFIDENCE ReadingQuality = 3 // flagged unreliable by the sensor
+)
+
+// Enum value maps for ReadingQuality.
+var (
+	ReadingQuality_name = map[int32]string{
+		0: "READING_QUALITY_UNSPECIFIED",
+		1: "READING_QUALITY_OK",
+		2: "READING_QUALITY_SATURATED",
+		3: "READING_QUALITY_LOW_CONFIDENCE",
+	}
+	ReadingQuality_value = map[string]int32{
+		"READING_QUALITY_UNSPECIFIED":    0,
+		"READING_QUALITY_OK":             1,
+		"READING_QUALITY_SATURATED":      2,
+		"READING_QUALITY_LOW_CONFIDENCE": 3,
+	}
+)
+
+func (x ReadingQuality) Enum() *ReadingQuality {
+	p := new(ReadingQuality)
+	*p = x
+	return p
+}
+
+func (x ReadingQuality) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReadingQuality) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_light_proto_enumTypes[4].Descriptor()
+}
+
+func (ReadingQuality) Type() protoreflect.EnumType {
+	return &file_api_proto_light_proto_enumTypes[4]
+}
+
+func (x ReadingQuality) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReadingQuality.Descriptor instead.
+func (ReadingQuality) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{4}
+}
+
+type ReadingSource int32
+
+const (
+	ReadingSource_READING_SOURCE_UNSPECIFIED ReadingSource = 0
+	ReadingSource_READING_SOURCE_SENSOR      ReadingSource = 1 // background recorder or live sensor read
+	ReadingSource_READING_SOURCE_MANUAL      ReadingSource = 2 // submitted via RecordReading
+	ReadingSource_READING_SOURCE_IMPORT      ReadingSource = 3 // loaded from an external dataset
+)
+
+// Enum value maps for ReadingSource.
+var (
+	ReadingSource_name = map[int32]string{
+		0: "READING_SOURCE_UNSPECIFIED",
+		1: "READING_SOURCE_SENSOR",
+		2: "READING_SOURCE_MANUAL",
+		3: "READING_SOURCE_IMPORT",
+	}
+	ReadingSource_value = map[string]int32{
+		"READING_SOURCE_UNSPECIFIED": 0,
+		"READING_SOURCE_SENSOR":      1,
+		"READING_SOURCE_MANUAL":      2,
+		"READING_SOURCE_IMPORT":      3,
+	}
+)
+
+func (x ReadingSource) Enum() *ReadingSource {
+	p := new(ReadingSource)
+	*p = x
+	return p
+}
+
+func (x ReadingSource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReadingSource) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_light_proto_enumTypes[5].Descriptor()
+}
+
+func (ReadingSource) Type() protoreflect.EnumType {
+	return &file_api_proto_light_proto_enumTypes[5]
+}
+
+func (x ReadingSource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReadingSource.Descriptor instead.
+func (ReadingSource) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{5}
+}
+
 type GetCurrentLightRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// When set, return the average of recent stored readings rather than the
+	// single latest one, smoothing out momentary shadows. Unset or zero
+	// returns the latest reading.
+	SmoothWindow  *SmoothWindow `protobuf:"bytes,1,opt,name=smooth_window,json=smoothWindow,proto3" json:"smooth_window,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCurrentLightRequest) Reset() {
+	*x = GetCurrentLightRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCurrentLightRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrentLightRequest) ProtoMessage() {}
+
+func (x *GetCurrentLightRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrentLightRequest.ProtoReflect.Descriptor instead.
+func (*GetCurrentLightRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetCurrentLightRequest) GetSmoothWindow() *SmoothWindow {
+	if x != nil {
+		return x.SmoothWindow
+	}
+	return nil
+}
+
+type SmoothWindow struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Window:
+	//
+	//	*SmoothWindow_Count
+	//	*SmoothWindow_DurationMs
+	Window        isSmoothWindow_Window `protobuf_oneof:"window"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SmoothWindow) Reset() {
+	*x = SmoothWindow{}
+	mi := &file_api_proto_light_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SmoothWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SmoothWindow) ProtoMessage() {}
+
+func (x *SmoothWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SmoothWindow.ProtoReflect.Descriptor instead.
+func (*SmoothWindow) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SmoothWindow) GetWindow() isSmoothWindow_Window {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *SmoothWindow) GetCount() int32 {
+	if x != nil {
+		if x, ok := x.Window.(*SmoothWindow_Count); ok {
+			return x.Count
+		}
+	}
+	return 0
+}
+
+func (x *SmoothWindow) GetDurationMs() int64 {
+	if x != nil {
+		if x, ok := x.Window.(*SmoothWindow_DurationMs); ok {
+			return x.DurationMs
+		}
+	}
+	return 0
+}
+
+type isSmoothWindow_Window interface {
+	isSmoothWindow_Window()
+}
+
+type SmoothWindow_Count struct {
+	Count int32 `protobuf:"varint,1,opt,name=count,proto3,oneof"` // average the last count readings
+}
+
+type SmoothWindow_DurationMs struct {
+	DurationMs int64 `protobuf:"varint,2,opt,name=duration_ms,json=durationMs,proto3,oneof"` // average readings within this long of the latest
+}
+
+func (*SmoothWindow_Count) isSmoothWindow_Window() {}
+
+func (*SmoothWindow_DurationMs) isSmoothWindow_Window() {}
+
+type GetCurrentLightResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Reading *LightReading          `protobuf:"bytes,1,opt,name=reading,proto3" json:"reading,omitempty"`
+	// False when the repository was unavailable and the reading is a live
+	// sensor read that was not stored
+	Persisted bool `protobuf:"varint,2,opt,name=persisted,proto3" json:"persisted,omitempty"`
+	// How many stored readings were averaged into reading when smoothing;
+	// the averaged reading has no id and the latest one's timestamp
+	SmoothedCount int32 `protobuf:"varint,3,opt,name=smoothed_count,json=smoothedCount,proto3" json:"smoothed_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCurrentLightResponse) Reset() {
+	*x = GetCurrentLightResponse{}
+	mi := &file_api_proto_light_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCurrentLightResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrentLightResponse) ProtoMessage() {}
+
+func (x *GetCurrentLightResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrentLightResponse.ProtoReflect.Descriptor instead.
+func (*GetCurrentLightResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetCurrentLightResponse) GetReading() *LightReading {
+	if x != nil {
+		return x.Reading
+	}
+	return nil
+}
+
+func (x *GetCurrentLightResponse) GetPersisted() bool {
+	if x != nil {
+		return x.Persisted
+	}
+	return false
+}
+
+func (x *GetCurrentLightResponse) GetSmoothedCount() int32 {
+	if x != nil {
+		return x.SmoothedCount
+	}
+	return 0
+}
+
+type GetHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Start of time range (Unix timestamp). Superseded by start_time_ms.
+	//
+	// Deprecated: Marked as deprecated in api/proto/light.proto.
+	StartTime int64 `protobuf:"varint,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// End of time range (Unix timestamp). Superseded by end_time_ms.
+	//
+	// Deprecated: Marked as deprecated in api/proto/light.proto.
+	EndTime int64 `protobuf:"varint,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Only return readings from this source (UNSPECIFIED returns all)
+	Source ReadingSource `protobuf:"varint,3,opt,name=source,proto3,enum=light.v1.ReadingSource" json:"source,omitempty"`
+	// Round the statistics to this many decimal places (0-10); unset returns
+	// them unrounded
+	Precision *int32 `protobuf:"varint,4,opt,name=precision,proto3,oneof" json:"precision,omitempty"`
+	// Start and end of the time range in Unix milliseconds. When non-zero
+	// these take precedence over start_time / end_time.
+	StartTimeMs int64 `protobuf:"varint,5,opt,name=start_time_ms,json=startTimeMs,proto3" json:"start_time_ms,omitempty"`
+	EndTimeMs   int64 `protobuf:"varint,6,opt,name=end_time_ms,json=endTimeMs,proto3" json:"end_time_ms,omitempty"`
+	// Only return readings in these categories; statistics then describe the
+	// filtered readings and time_in_category is left empty
+	CategoryFilter *CategoryFilter `protobuf:"bytes,7,opt,name=category_filter,json=categoryFilter,proto3,oneof" json:"category_filter,omitempty"`
+	// Percentiles (0-100) of lux to return, e.g. [25, 75, 95]. Interpolated
+	// linearly between the nearest readings, so 0 and 100 are the min and max.
+	Percentiles []float64 `protobuf:"fixed64,8,rep,packed,name=percentiles,proto3" json:"percentiles,omitempty"`
+	// Return only the statistics, leaving readings empty. Such requests are
+	// not subject to the server's cap on readings per response.
+	StatsOnly bool `protobuf:"varint,9,opt,name=stats_only,json=statsOnly,proto3" json:"stats_only,omitempty"`
+	// Return at most this many readings and a next_page_token for the rest;
+	// 0 returns the whole range. The statistics then describe the page, and
+	// time_in_category is left empty.
+	Limit int32 `protobuf:"varint,10,opt,name=limit,proto3" json:"limit,omitempty"`
+	// next_page_token from the previous page of the same request
+	PageToken string `protobuf:"bytes,11,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Order of the readings (UNSPECIFIED is oldest first)
+	Order SortOrder `protobuf:"varint,12,opt,name=order,proto3,enum=light.v1.SortOrder" json:"order,omitempty"`
+	// Summarize the range per bucket of this many milliseconds (a whole
+	// number of seconds, e.g. 3600000 for hourly) instead of returning raw
+	// readings. Buckets are aligned to the Unix epoch and empty ones are
+	// left out. Cannot be combined with source, category_filter, percentiles
+	// or paging.
+	AggregationIntervalMs int64 `protobuf:"varint,13,opt,name=aggregation_interval_ms,json=aggregationIntervalMs,proto3" json:"aggregation_interval_ms,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *GetHistoryRequest) Reset() {
+	*x = GetHistoryRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryRequest) ProtoMessage() {}
+
+func (x *GetHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{3}
+}
+
+// Deprecated: Marked as deprecated in api/proto/light.proto.
+func (x *GetHistoryRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in api/proto/light.proto.
+func (x *GetHistoryRequest) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *GetHistoryRequest) GetSource() ReadingSource {
+	if x != nil {
+		return x.Source
+	}
+	return ReadingSource_READING_SOURCE_UNSPECIFIED
+}
+
+func (x *GetHistoryRequest) GetPrecision() int32 {
+	if x != nil && x.Precision != nil {
+		return *x.Precision
+	}
+	return 0
+}
+
+func (x *GetHistoryRequest) GetStartTimeMs() int64 {
+	if x != nil {
+		return x.StartTimeMs
+	}
+	return 0
+}
+
+func (x *GetHistoryRequest) GetEndTimeMs() int64 {
+	if x != nil {
+		return x.EndTimeMs
+	}
+	return 0
+}
+
+func (x *GetHistoryRequest) GetCategoryFilter() *CategoryFilter {
+	if x != nil {
+		return x.CategoryFilter
+	}
+	return nil
+}
+
+func (x *GetHistoryRequest) GetPercentiles() []float64 {
+	if x != nil {
+		return x.Percentiles
+	}
+	return nil
+}
+
+func (x *GetHistoryRequest) GetStatsOnly() bool {
+	if x != nil {
+		return x.StatsOnly
+	}
+	return false
+}
+
+func (x *GetHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetHistoryRequest) GetOrder() SortOrder {
+	if x != nil {
+		return x.Order
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
+func (x *GetHistoryRequest) GetAggregationIntervalMs() int64 {
+	if x != nil {
+		return x.AggregationIntervalMs
+	}
+	return 0
+}
+
+// CategoryFilter selects readings by their level under the server's
+// category scheme (CATEGORY_SCHEME, or Low/Medium/High by default). A
+// reading matching either list is kept.
+type CategoryFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Levels of a three-level scheme, LOW the darkest. Rejected when the
+	// server's scheme has another number of levels; use levels instead.
+	Categories []LightCategory `protobuf:"varint,1,rep,packed,name=categories,proto3,enum=light.v1.LightCategory" json:"categories,omitempty"`
+	// Level indexes into the server's scheme, 0 the darkest
+	Levels        []int32 `protobuf:"varint,2,rep,packed,name=levels,proto3" json:"levels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryFilter) Reset() {
+	*x = CategoryFilter{}
+	mi := &file_api_proto_light_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryFilter) ProtoMessage() {}
+
+func (x *CategoryFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryFilter.ProtoReflect.Descriptor instead.
+func (*CategoryFilter) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CategoryFilter) GetCategories() []LightCategory {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *CategoryFilter) GetLevels() []int32 {
+	if x != nil {
+		return x.Levels
+	}
+	return nil
+}
+
+type GetHistoryResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Readings []*LightReading        `protobuf:"bytes,1,rep,name=readings,proto3" json:"readings,omitempty"`
+	// Statistics
+	AverageLux float64 `protobuf:"fixed64,2,opt,name=average_lux,json=averageLux,proto3" json:"average_lux,omitempty"`
+	MinLux     float64 `protobuf:"fixed64,3,opt,name=min_lux,json=minLux,proto3" json:"min_lux,omitempty"`
+	MaxLux     float64 `protobuf:"fixed64,4,opt,name=max_lux,json=maxLux,proto3" json:"max_lux,omitempty"`
+	// Time spent in each of the category scheme's levels over the range,
+	// darkest first (low, medium, high by default)
+	TimeInCategory []*CategoryDuration `protobuf:"bytes,5,rep,name=time_in_category,json=timeInCategory,proto3" json:"time_in_category,omitempty"`
+	// Requested percentiles, in request order; lux is 0 when there are no readings
+	Percentiles []*Percentile `protobuf:"bytes,6,rep,name=percentiles,proto3" json:"percentiles,omitempty"`
+	// Pass as page_token to fetch the next page; empty on the last page
+	NextPageToken string `protobuf:"bytes,7,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Per-bucket statistics, in the requested order, when
+	// aggregation_interval_ms is set; readings is then empty
+	Buckets       []*ReadingBucket `protobuf:"bytes,8,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHistoryResponse) Reset() {
+	*x = GetHistoryResponse{}
+	mi := &file_api_proto_light_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryResponse) ProtoMessage() {}
+
+func (x *GetHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetHistoryResponse) GetReadings() []*LightReading {
+	if x != nil {
+		return x.Readings
+	}
+	return nil
+}
+
+func (x *GetHistoryResponse) GetAverageLux() float64 {
+	if x != nil {
+		return x.AverageLux
+	}
+	return 0
+}
+
+func (x *GetHistoryResponse) GetMinLux() float64 {
+	if x != nil {
+		return x.MinLux
+	}
+	return 0
+}
+
+func (x *GetHistoryResponse) GetMaxLux() float64 {
+	if x != nil {
+		return x.MaxLux
+	}
+	return 0
+}
+
+func (x *GetHistoryResponse) GetTimeInCategory() []*CategoryDuration {
+	if x != nil {
+		return x.TimeInCategory
+	}
+	return nil
+}
+
+func (x *GetHistoryResponse) GetPercentiles() []*Percentile {
+	if x != nil {
+		return x.Percentiles
+	}
+	return nil
+}
+
+func (x *GetHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *GetHistoryResponse) GetBuckets() []*ReadingBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+type ReadingBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartTimeMs   int64                  `protobuf:"varint,1,opt,name=start_time_ms,json=startTimeMs,proto3" json:"start_time_ms,omitempty"`
+	EndTimeMs     int64                  `protobuf:"varint,2,opt,name=end_time_ms,json=endTimeMs,proto3" json:"end_time_ms,omitempty"` // exclusive
+	ReadingCount  int64                  `protobuf:"varint,3,opt,name=reading_count,json=readingCount,proto3" json:"reading_count,omitempty"`
+	AverageLux    float64                `protobuf:"fixed64,4,opt,name=average_lux,json=averageLux,proto3" json:"average_lux,omitempty"`
+	MinLux        float64                `protobuf:"fixed64,5,opt,name=min_lux,json=minLux,proto3" json:"min_lux,omitempty"`
+	MaxLux        float64                `protobuf:"fixed64,6,opt,name=max_lux,json=maxLux,proto3" json:"max_lux,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadingBucket) Reset() {
+	*x = ReadingBucket{}
+	mi := &file_api_proto_light_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadingBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadingBucket) ProtoMessage() {}
+
+func (x *ReadingBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadingBucket.ProtoReflect.Descriptor instead.
+func (*ReadingBucket) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReadingBucket) GetStartTimeMs() int64 {
+	if x != nil {
+		return x.StartTimeMs
+	}
+	return 0
+}
+
+func (x *ReadingBucket) GetEndTimeMs() int64 {
+	if x != nil {
+		return x.EndTimeMs
+	}
+	return 0
+}
+
+func (x *ReadingBucket) GetReadingCount() int64 {
+	if x != nil {
+		return x.ReadingCount
+	}
+	return 0
+}
+
+func (x *ReadingBucket) GetAverageLux() float64 {
+	if x != nil {
+		return x.AverageLux
+	}
+	return 0
+}
+
+func (x *ReadingBucket) GetMinLux() float64 {
+	if x != nil {
+		return x.MinLux
+	}
+	return 0
+}
+
+func (x *ReadingBucket) GetMaxLux() float64 {
+	if x != nil {
+		return x.MaxLux
+	}
+	return 0
+}
+
+type Percentile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Percentile    float64                `protobuf:"fixed64,1,opt,name=percentile,proto3" json:"percentile,omitempty"`
+	Lux           float64                `protobuf:"fixed64,2,opt,name=lux,proto3" json:"lux,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Percentile) Reset() {
+	*x = Percentile{}
+	mi := &file_api_proto_light_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Percentile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Percentile) ProtoMessage() {}
+
+func (x *Percentile) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Percentile.ProtoReflect.Descriptor instead.
+func (*Percentile) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Percentile) GetPercentile() float64 {
+	if x != nil {
+		return x.Percentile
+	}
+	return 0
+}
+
+func (x *Percentile) GetLux() float64 {
+	if x != nil {
+		return x.Lux
+	}
+	return 0
+}
+
+type CategoryDuration struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Seconds       float64                `protobuf:"fixed64,2,opt,name=seconds,proto3" json:"seconds,omitempty"`
+	Fraction      float64                `protobuf:"fixed64,3,opt,name=fraction,proto3" json:"fraction,omitempty"` // share of the attributed time, 0-1
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryDuration) Reset() {
+	*x = CategoryDuration{}
+	mi := &file_api_proto_light_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryDuration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryDuration) ProtoMessage() {}
+
+func (x *CategoryDuration) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryDuration.ProtoReflect.Descriptor instead.
+func (*CategoryDuration) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CategoryDuration) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CategoryDuration) GetSeconds() float64 {
+	if x != nil {
+		return x.Seconds
+	}
+	return 0
+}
+
+func (x *CategoryDuration) GetFraction() float64 {
+	if x != nil {
+		return x.Fraction
+	}
+	return 0
+}
+
+type RecordReadingRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Lux   float64                `protobuf:"fixed64,1,opt,name=lux,proto3" json:"lux,omitempty"`
+	// Ambient temperature in °C, if the submitting device measured one
+	TemperatureCelsius *float64 `protobuf:"fixed64,2,opt,name=temperature_celsius,json=temperatureCelsius,proto3,oneof" json:"temperature_celsius,omitempty"`
+	// When the reading was taken (Unix timestamp); the server time is used if
+	// unset. Superseded by timestamp_ms.
+	//
+	// Deprecated: Marked as deprecated in api/proto/light.proto.
+	Timestamp *int64 `protobuf:"varint,3,opt,name=timestamp,proto3,oneof" json:"timestamp,omitempty"`
+	// When the reading was taken, in Unix milliseconds; takes precedence over
+	// timestamp. Lets a device that was offline submit readings late. Times
+	// more than a minute ahead of the server's clock are rejected.
+	TimestampMs   *int64 `protobuf:"varint,4,opt,name=timestamp_ms,json=timestampMs,proto3,oneof" json:"timestamp_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordReadingRequest) Reset() {
+	*x = RecordReadingRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordReadingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordReadingRequest) ProtoMessage() {}
+
+func (x *RecordReadingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordReadingRequest.ProtoReflect.Descriptor instead.
+func (*RecordReadingRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RecordReadingRequest) GetLux() float64 {
+	if x != nil {
+		return x.Lux
+	}
+	return 0
+}
+
+func (x *RecordReadingRequest) GetTemperatureCelsius() float64 {
+	if x != nil && x.TemperatureCelsius != nil {
+		return *x.TemperatureCelsius
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in api/proto/light.proto.
+func (x *RecordReadingRequest) GetTimestamp() int64 {
+	if x != nil && x.Timestamp != nil {
+		return *x.Timestamp
+	}
+	return 0
+}
+
+func (x *RecordReadingRequest) GetTimestampMs() int64 {
+	if x != nil && x.TimestampMs != nil {
+		return *x.TimestampMs
+	}
+	return 0
+}
+
+type RecordReadingResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The reading as persisted, including server-assigned id and timestamp
+	Reading       *LightReading `protobuf:"bytes,1,opt,name=reading,proto3" json:"reading,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordReadingResponse) Reset() {
+	*x = RecordReadingResponse{}
+	mi := &file_api_proto_light_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordReadingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordReadingResponse) ProtoMessage() {}
+
+func (x *RecordReadingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordReadingResponse.ProtoReflect.Descriptor instead.
+func (*RecordReadingResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RecordReadingResponse) GetReading() *LightReading {
+	if x != nil {
+		return x.Reading
+	}
+	return nil
+}
+
+type RecordReadingsBatchRequest struct {
+	state    protoimpl.MessageState  `protogen:"open.v1"`
+	Readings []*RecordReadingRequest `protobuf:"bytes,1,rep,name=readings,proto3" json:"readings,omitempty"`
+	// Import mode marks the readings as IMPORT and replaces any stored reading
+	// with the same timestamp instead of adding a duplicate, so a corrected
+	// dataset can be re-imported safely
+	ImportMode    bool `protobuf:"varint,2,opt,name=import_mode,json=importMode,proto3" json:"import_mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordReadingsBatchRequest) Reset() {
+	*x = RecordReadingsBatchRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordReadingsBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordReadingsBatchRequest) ProtoMessage() {}
+
+func (x *RecordReadingsBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordReadingsBatchRequest.ProtoReflect.Descriptor instead.
+func (*RecordReadingsBatchRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RecordReadingsBatchRequest) GetReadings() []*RecordReadingRequest {
+	if x != nil {
+		return x.Readings
+	}
+	return nil
+}
+
+func (x *RecordReadingsBatchRequest) GetImportMode() bool {
+	if x != nil {
+		return x.ImportMode
+	}
+	return false
+}
+
+type RecordReadingsBatchResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The readings as persisted, in request order, skipping rejected entries
+	Readings []*LightReading `protobuf:"bytes,1,rep,name=readings,proto3" json:"readings,omitempty"`
+	// One entry per rejected reading; fix these and resubmit only them
+	Errors        []*ReadingError `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	SavedCount    int32           `protobuf:"varint,3,opt,name=saved_count,json=savedCount,proto3" json:"saved_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordReadingsBatchResponse) Reset() {
+	*x = RecordReadingsBatchResponse{}
+	mi := &file_api_proto_light_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordReadingsBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordReadingsBatchResponse) ProtoMessage() {}
+
+func (x *RecordReadingsBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordReadingsBatchResponse.ProtoReflect.Descriptor instead.
+func (*RecordReadingsBatchResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RecordReadingsBatchResponse) GetReadings() []*LightReading {
+	if x != nil {
+		return x.Readings
+	}
+	return nil
+}
+
+func (x *RecordReadingsBatchResponse) GetErrors() []*ReadingError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *RecordReadingsBatchResponse) GetSavedCount() int32 {
+	if x != nil {
+		return x.SavedCount
+	}
+	return 0
+}
+
+type ReadingError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // position in RecordReadingsBatchRequest.readings
+	Field         string                 `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`  // request field at fault, e.g. "lux"
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadingError) Reset() {
+	*x = ReadingError{}
+	mi := &file_api_proto_light_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadingError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadingError) ProtoMessage() {}
+
+func (x *ReadingError) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadingError.ProtoReflect.Descriptor instead.
+func (*ReadingError) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ReadingError) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ReadingError) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ReadingError) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type GetReadingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReadingRequest) Reset() {
+	*x = GetReadingRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReadingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReadingRequest) ProtoMessage() {}
+
+func (x *GetReadingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReadingRequest.ProtoReflect.Descriptor instead.
+func (*GetReadingRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetReadingRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetReadingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reading       *LightReading          `protobuf:"bytes,1,opt,name=reading,proto3" json:"reading,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReadingResponse) Reset() {
+	*x = GetReadingResponse{}
+	mi := &file_api_proto_light_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReadingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReadingResponse) ProtoMessage() {}
+
+func (x *GetReadingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReadingResponse.ProtoReflect.Descriptor instead.
+func (*GetReadingResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetReadingResponse) GetReading() *LightReading {
+	if x != nil {
+		return x.Reading
+	}
+	return nil
+}
+
+type GetReadingsByIDsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReadingsByIDsRequest) Reset() {
+	*x = GetReadingsByIDsRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReadingsByIDsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReadingsByIDsRequest) ProtoMessage() {}
+
+func (x *GetReadingsByIDsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReadingsByIDsRequest.ProtoReflect.Descriptor instead.
+func (*GetReadingsByIDsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetReadingsByIDsRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type GetReadingsByIDsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Readings      []*LightReading        `protobuf:"bytes,1,rep,name=readings,proto3" json:"readings,omitempty"`                               // in the order requested, duplicates removed
+	MissingIds    []int64                `protobuf:"varint,2,rep,packed,name=missing_ids,json=missingIds,proto3" json:"missing_ids,omitempty"` // requested IDs with no reading
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReadingsByIDsResponse) Reset() {
+	*x = GetReadingsByIDsResponse{}
+	mi := &file_api_proto_light_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReadingsByIDsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReadingsByIDsResponse) ProtoMessage() {}
+
+func (x *GetReadingsByIDsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReadingsByIDsResponse.ProtoReflect.Descriptor instead.
+func (*GetReadingsByIDsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetReadingsByIDsResponse) GetReadings() []*LightReading {
+	if x != nil {
+		return x.Readings
+	}
+	return nil
+}
+
+func (x *GetReadingsByIDsResponse) GetMissingIds() []int64 {
+	if x != nil {
+		return x.MissingIds
+	}
+	return nil
+}
+
+type GetLightAsOfRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AtMs          int64                  `protobuf:"varint,1,opt,name=at_ms,json=atMs,proto3" json:"at_ms,omitempty"` // Unix milliseconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLightAsOfRequest) Reset() {
+	*x = GetLightAsOfRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLightAsOfRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLightAsOfRequest) ProtoMessage() {}
+
+func (x *GetLightAsOfRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLightAsOfRequest.ProtoReflect.Descriptor instead.
+func (*GetLightAsOfRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetLightAsOfRequest) GetAtMs() int64 {
+	if x != nil {
+		return x.AtMs
+	}
+	return 0
+}
+
+type GetLightAsOfResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reading       *LightReading          `protobuf:"bytes,1,opt,name=reading,proto3" json:"reading,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLightAsOfResponse) Reset() {
+	*x = GetLightAsOfResponse{}
+	mi := &file_api_proto_light_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLightAsOfResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLightAsOfResponse) ProtoMessage() {}
+
+func (x *GetLightAsOfResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLightAsOfResponse.ProtoReflect.Descriptor instead.
+func (*GetLightAsOfResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetLightAsOfResponse) GetReading() *LightReading {
+	if x != nil {
+		return x.Reading
+	}
+	return nil
+}
+
+type GetCategoryEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartTime     int64                  `protobuf:"varint,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"` // Unix timestamp, inclusive
+	EndTime       int64                  `protobuf:"varint,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`       // Unix timestamp, exclusive
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoryEventsRequest) Reset() {
+	*x = GetCategoryEventsRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryEventsRequest) ProtoMessage() {}
+
+func (x *GetCategoryEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryEventsRequest.ProtoReflect.Descriptor instead.
+func (*GetCategoryEventsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetCategoryEventsRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *GetCategoryEventsRequest) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+type GetCategoryEventsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Oldest first
+	Events        []*CategoryEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoryEventsResponse) Reset() {
+	*x = GetCategoryEventsResponse{}
+	mi := &file_api_proto_light_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryEventsResponse) ProtoMessage() {}
+
+func (x *GetCategoryEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryEventsResponse.ProtoReflect.Descriptor instead.
+func (*GetCategoryEventsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetCategoryEventsResponse) GetEvents() []*CategoryEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type CategoryEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	FromCategory  string                 `protobuf:"bytes,2,opt,name=from_category,json=fromCategory,proto3" json:"from_category,omitempty"`
+	ToCategory    string                 `protobuf:"bytes,3,opt,name=to_category,json=toCategory,proto3" json:"to_category,omitempty"`
+	Lux           float64                `protobuf:"fixed64,4,opt,name=lux,proto3" json:"lux,omitempty"`            // the reading that caused the change
+	Timestamp     int64                  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix timestamp of that reading
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryEvent) Reset() {
+	*x = CategoryEvent{}
+	mi := &file_api_proto_light_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryEvent) ProtoMessage() {}
+
+func (x *CategoryEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryEvent.ProtoReflect.Descriptor instead.
+func (*CategoryEvent) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CategoryEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CategoryEvent) GetFromCategory() string {
+	if x != nil {
+		return x.FromCategory
+	}
+	return ""
+}
+
+func (x *CategoryEvent) GetToCategory() string {
+	if x != nil {
+		return x.ToCategory
+	}
+	return ""
+}
+
+func (x *CategoryEvent) GetLux() float64 {
+	if x != nil {
+		return x.Lux
+	}
+	return 0
+}
+
+func (x *CategoryEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type GetStorageStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStorageStatsRequest) Reset() {
+	*x = GetStorageStatsRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStorageStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStorageStatsRequest) ProtoMessage() {}
+
+func (x *GetStorageStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStorageStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStorageStatsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{23}
+}
+
+type StorageStatsResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ReadingCount      int64                  `protobuf:"varint,1,opt,name=reading_count,json=readingCount,proto3" json:"reading_count,omitempty"`
+	OldestTimestampMs int64                  `protobuf:"varint,2,opt,name=oldest_timestamp_ms,json=oldestTimestampMs,proto3" json:"oldest_timestamp_ms,omitempty"` // Unix milliseconds; 0 when empty
+	NewestTimestampMs int64                  `protobuf:"varint,3,opt,name=newest_timestamp_ms,json=newestTimestampMs,proto3" json:"newest_timestamp_ms,omitempty"` // Unix milliseconds; 0 when empty
+	SizeBytes         int64                  `protobuf:"varint,4,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`                           // on-disk size; 0 for in-memory storage
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *StorageStatsResponse) Reset() {
+	*x = StorageStatsResponse{}
+	mi := &file_api_proto_light_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageStatsResponse) ProtoMessage() {}
+
+func (x *StorageStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageStatsResponse.ProtoReflect.Descriptor instead.
+func (*StorageStatsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *StorageStatsResponse) GetReadingCount() int64 {
+	if x != nil {
+		return x.ReadingCount
+	}
+	return 0
+}
+
+func (x *StorageStatsResponse) GetOldestTimestampMs() int64 {
+	if x != nil {
+		return x.OldestTimestampMs
+	}
+	return 0
+}
+
+func (x *StorageStatsResponse) GetNewestTimestampMs() int64 {
+	if x != nil {
+		return x.NewestTimestampMs
+	}
+	return 0
+}
+
+func (x *StorageStatsResponse) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+type GetRecentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of readings to return; capped at the server's configured maximum
+	Limit         int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecentRequest) Reset() {
+	*x = GetRecentRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecentRequest) ProtoMessage() {}
+
+func (x *GetRecentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecentRequest.ProtoReflect.Descriptor instead.
+func (*GetRecentRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetRecentRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetRecentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Chronological order (oldest first)
+	Readings      []*LightReading `protobuf:"bytes,1,rep,name=readings,proto3" json:"readings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecentResponse) Reset() {
+	*x = GetRecentResponse{}
+	mi := &file_api_proto_light_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecentResponse) ProtoMessage() {}
+
+func (x *GetRecentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecentResponse.ProtoReflect.Descriptor instead.
+func (*GetRecentResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetRecentResponse) GetReadings() []*LightReading {
+	if x != nil {
+		return x.Readings
+	}
+	return nil
+}
+
+// TimeRange is a half-open interval [start_ms, end_ms) in Unix milliseconds
+type TimeRange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartMs       int64                  `protobuf:"varint,1,opt,name=start_ms,json=startMs,proto3" json:"start_ms,omitempty"`
+	EndMs         int64                  `protobuf:"varint,2,opt,name=end_ms,json=endMs,proto3" json:"end_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeRange) Reset() {
+	*x = TimeRange{}
+	mi := &file_api_proto_light_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeRange) ProtoMessage() {}
+
+func (x *TimeRange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeRange.ProtoReflect.Descriptor instead.
+func (*TimeRange) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *TimeRange) GetStartMs() int64 {
+	if x != nil {
+		return x.StartMs
+	}
+	return 0
+}
+
+func (x *TimeRange) GetEndMs() int64 {
+	if x != nil {
+		return x.EndMs
+	}
+	return 0
+}
+
+type CompareRangesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RangeA        *TimeRange             `protobuf:"bytes,1,opt,name=range_a,json=rangeA,proto3" json:"range_a,omitempty"`
+	RangeB        *TimeRange             `protobuf:"bytes,2,opt,name=range_b,json=rangeB,proto3" json:"range_b,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompareRangesRequest) Reset() {
+	*x = CompareRangesRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareRangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareRangesRequest) ProtoMessage() {}
+
+func (x *CompareRangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareRangesRequest.ProtoReflect.Descriptor instead.
+func (*CompareRangesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CompareRangesRequest) GetRangeA() *TimeRange {
+	if x != nil {
+		return x.RangeA
+	}
+	return nil
+}
+
+func (x *CompareRangesRequest) GetRangeB() *TimeRange {
+	if x != nil {
+		return x.RangeB
+	}
+	return nil
+}
+
+type RangeStatistics struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ReadingCount int64                  `protobuf:"varint,1,opt,name=reading_count,json=readingCount,proto3" json:"reading_count,omitempty"`
+	AverageLux   float64                `protobuf:"fixed64,2,opt,name=average_lux,json=averageLux,proto3" json:"average_lux,omitempty"`
+	MinLux       float64                `protobuf:"fixed64,3,opt,name=min_lux,json=minLux,proto3" json:"min_lux,omitempty"`
+	MaxLux       float64                `protobuf:"fixed64,4,opt,name=max_lux,json=maxLux,proto3" json:"max_lux,omitempty"`
+	Empty        bool                   `protobuf:"varint,5,opt,name=empty,proto3" json:"empty,omitempty"` // no readings; the statistics are all zero
+	// Estimated daily light integral averaged over the range, in mol/m²/day,
+	// from the readings' averages per GetHistory time-in-category gap. Unset
+	// for ranges longer than GetDailyLightIntegral's 366 days.
+	Dli           *float64 `protobuf:"fixed64,6,opt,name=dli,proto3,oneof" json:"dli,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RangeStatistics) Reset() {
+	*x = RangeStatistics{}
+	mi := &file_api_proto_light_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RangeStatistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RangeStatistics) ProtoMessage() {}
+
+func (x *RangeStatistics) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RangeStatistics.ProtoReflect.Descriptor instead.
+func (*RangeStatistics) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RangeStatistics) GetReadingCount() int64 {
+	if x != nil {
+		return x.ReadingCount
+	}
+	return 0
+}
+
+func (x *RangeStatistics) GetAverageLux() float64 {
+	if x != nil {
+		return x.AverageLux
+	}
+	return 0
+}
+
+func (x *RangeStatistics) GetMinLux() float64 {
+	if x != nil {
+		return x.MinLux
+	}
+	return 0
+}
+
+func (x *RangeStatistics) GetMaxLux() float64 {
+	if x != nil {
+		return x.MaxLux
+	}
+	return 0
+}
+
+func (x *RangeStatistics) GetEmpty() bool {
+	if x != nil {
+		return x.Empty
+	}
+	return false
+}
+
+func (x *RangeStatistics) GetDli() float64 {
+	if x != nil && x.Dli != nil {
+		return *x.Dli
+	}
+	return 0
+}
+
+type CompareRangesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	A     *RangeStatistics       `protobuf:"bytes,1,opt,name=a,proto3" json:"a,omitempty"`
+	B     *RangeStatistics       `protobuf:"bytes,2,opt,name=b,proto3" json:"b,omitempty"`
+	// Deltas are b minus a; zero unless both ranges have readings
+	AverageLuxDelta float64  `protobuf:"fixed64,3,opt,name=average_lux_delta,json=averageLuxDelta,proto3" json:"average_lux_delta,omitempty"`
+	MinLuxDelta     float64  `protobuf:"fixed64,4,opt,name=min_lux_delta,json=minLuxDelta,proto3" json:"min_lux_delta,omitempty"`
+	MaxLuxDelta     float64  `protobuf:"fixed64,5,opt,name=max_lux_delta,json=maxLuxDelta,proto3" json:"max_lux_delta,omitempty"`
+	Comparable      bool     `protobuf:"varint,6,opt,name=comparable,proto3" json:"comparable,omitempty"`                    // both ranges have readings
+	DliDelta        *float64 `protobuf:"fixed64,7,opt,name=dli_delta,json=dliDelta,proto3,oneof" json:"dli_delta,omitempty"` // set when comparable and both have a dli
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CompareRangesResponse) Reset() {
+	*x = CompareRangesResponse{}
+	mi := &file_api_proto_light_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareRangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareRangesResponse) ProtoMessage() {}
+
+func (x *CompareRangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareRangesResponse.ProtoReflect.Descriptor instead.
+func (*CompareRangesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CompareRangesResponse) GetA() *RangeStatistics {
+	if x != nil {
+		return x.A
+	}
+	return nil
+}
+
+func (x *CompareRangesResponse) GetB() *RangeStatistics {
+	if x != nil {
+		return x.B
+	}
+	return nil
+}
+
+func (x *CompareRangesResponse) GetAverageLuxDelta() float64 {
+	if x != nil {
+		return x.AverageLuxDelta
+	}
+	return 0
+}
+
+func (x *CompareRangesResponse) GetMinLuxDelta() float64 {
+	if x != nil {
+		return x.MinLuxDelta
+	}
+	return 0
+}
+
+func (x *CompareRangesResponse) GetMaxLuxDelta() float64 {
+	if x != nil {
+		return x.MaxLuxDelta
+	}
+	return 0
+}
+
+func (x *CompareRangesResponse) GetComparable() bool {
+	if x != nil {
+		return x.Comparable
+	}
+	return false
+}
+
+func (x *CompareRangesResponse) GetDliDelta() float64 {
+	if x != nil && x.DliDelta != nil {
+		return *x.DliDelta
+	}
+	return 0
+}
+
+type ExportReadingsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Readings per streamed batch; 0 uses the server default
+	BatchSize     int32 `protobuf:"varint,1,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportReadingsRequest) Reset() {
+	*x = ExportReadingsRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportReadingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportReadingsRequest) ProtoMessage() {}
+
+func (x *ExportReadingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportReadingsRequest.ProtoReflect.Descriptor instead.
+func (*ExportReadingsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ExportReadingsRequest) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+type DownloadReadingsRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	StartTimeMs int64                  `protobuf:"varint,1,opt,name=start_time_ms,json=startTimeMs,proto3" json:"start_time_ms,omitempty"` // Unix milliseconds, inclusive
+	EndTimeMs   int64                  `protobuf:"varint,2,opt,name=end_time_ms,json=endTimeMs,proto3" json:"end_time_ms,omitempty"`       // Unix milliseconds, exclusive
+	Format      ExportFormat           `protobuf:"varint,3,opt,name=format,proto3,enum=light.v1.ExportFormat" json:"format,omitempty"`
+	// Readings per streamed chunk; 0 uses the server default
+	BatchSize     int32 `protobuf:"varint,4,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadReadingsRequest) Reset() {
+	*x = DownloadReadingsRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadReadingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadReadingsRequest) ProtoMessage() {}
+
+func (x *DownloadReadingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadReadingsRequest.ProtoReflect.Descriptor instead.
+func (*DownloadReadingsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DownloadReadingsRequest) GetStartTimeMs() int64 {
+	if x != nil {
+		return x.StartTimeMs
+	}
+	return 0
+}
+
+func (x *DownloadReadingsRequest) GetEndTimeMs() int64 {
+	if x != nil {
+		return x.EndTimeMs
+	}
+	return 0
+}
+
+func (x *DownloadReadingsRequest) GetFormat() ExportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ExportFormat_EXPORT_FORMAT_UNSPECIFIED
+}
+
+func (x *DownloadReadingsRequest) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+type DownloadChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadChunk) Reset() {
+	*x = DownloadChunk{}
+	mi := &file_api_proto_light_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadChunk) ProtoMessage() {}
+
+func (x *DownloadChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadChunk.ProtoReflect.Descriptor instead.
+func (*DownloadChunk) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DownloadChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type StreamHistoryRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	StartTimeMs int64                  `protobuf:"varint,1,opt,name=start_time_ms,json=startTimeMs,proto3" json:"start_time_ms,omitempty"` // Unix milliseconds, inclusive
+	EndTimeMs   int64                  `protobuf:"varint,2,opt,name=end_time_ms,json=endTimeMs,proto3" json:"end_time_ms,omitempty"`       // Unix milliseconds, exclusive
+	// Only stream readings from this source (UNSPECIFIED streams all)
+	Source ReadingSource `protobuf:"varint,3,opt,name=source,proto3,enum=light.v1.ReadingSource" json:"source,omitempty"`
+	// Readings fetched from the store at a time; 0 uses the server default
+	PageSize int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Order of the readings (UNSPECIFIED is oldest first)
+	Order         SortOrder `protobuf:"varint,5,opt,name=order,proto3,enum=light.v1.SortOrder" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamHistoryRequest) Reset() {
+	*x = StreamHistoryRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamHistoryRequest) ProtoMessage() {}
+
+func (x *StreamHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamHistoryRequest.ProtoReflect.Descriptor instead.
+func (*StreamHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *StreamHistoryRequest) GetStartTimeMs() int64 {
+	if x != nil {
+		return x.StartTimeMs
+	}
+	return 0
+}
+
+func (x *StreamHistoryRequest) GetEndTimeMs() int64 {
+	if x != nil {
+		return x.EndTimeMs
+	}
+	return 0
+}
+
+func (x *StreamHistoryRequest) GetSource() ReadingSource {
+	if x != nil {
+		return x.Source
+	}
+	return ReadingSource_READING_SOURCE_UNSPECIFIED
+}
+
+func (x *StreamHistoryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *StreamHistoryRequest) GetOrder() SortOrder {
+	if x != nil {
+		return x.Order
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
+type ReadingBatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Readings      []*LightReading        `protobuf:"bytes,1,rep,name=readings,proto3" json:"readings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadingBatch) Reset() {
+	*x = ReadingBatch{}
+	mi := &file_api_proto_light_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadingBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadingBatch) ProtoMessage() {}
+
+func (x *ReadingBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadingBatch.ProtoReflect.Descriptor instead.
+func (*ReadingBatch) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ReadingBatch) GetReadings() []*LightReading {
+	if x != nil {
+		return x.Readings
+	}
+	return nil
+}
+
+type ImportReadingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ImportedCount int64                  `protobuf:"varint,1,opt,name=imported_count,json=importedCount,proto3" json:"imported_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportReadingsResponse) Reset() {
+	*x = ImportReadingsResponse{}
+	mi := &file_api_proto_light_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportReadingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportReadingsResponse) ProtoMessage() {}
+
+func (x *ImportReadingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportReadingsResponse.ProtoReflect.Descriptor instead.
+func (*ImportReadingsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ImportReadingsResponse) GetImportedCount() int64 {
+	if x != nil {
+		return x.ImportedCount
+	}
+	return 0
+}
+
+type GetRecorderStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecorderStatusRequest) Reset() {
+	*x = GetRecorderStatusRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecorderStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecorderStatusRequest) ProtoMessage() {}
+
+func (x *GetRecorderStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecorderStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetRecorderStatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{37}
+}
+
+type GetRecorderStatusResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Running             bool                   `protobuf:"varint,1,opt,name=running,proto3" json:"running,omitempty"`                                    // false when the recorder is disabled (read-only mode)
+	LastSuccessMs       int64                  `protobuf:"varint,2,opt,name=last_success_ms,json=lastSuccessMs,proto3" json:"last_success_ms,omitempty"` // Unix milliseconds; 0 if no cycle has succeeded
+	LastError           string                 `protobuf:"bytes,3,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`                // empty unless the latest cycle failed
+	ConsecutiveFailures int32                  `protobuf:"varint,4,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures,omitempty"`
+	IntervalMs          int64                  `protobuf:"varint,5,opt,name=interval_ms,json=intervalMs,proto3" json:"interval_ms,omitempty"` // current effective recording interval
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetRecorderStatusResponse) Reset() {
+	*x = GetRecorderStatusResponse{}
+	mi := &file_api_proto_light_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecorderStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecorderStatusResponse) ProtoMessage() {}
+
+func (x *GetRecorderStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecorderStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetRecorderStatusResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetRecorderStatusResponse) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *GetRecorderStatusResponse) GetLastSuccessMs() int64 {
+	if x != nil {
+		return x.LastSuccessMs
+	}
+	return 0
+}
+
+func (x *GetRecorderStatusResponse) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *GetRecorderStatusResponse) GetConsecutiveFailures() int32 {
+	if x != nil {
+		return x.ConsecutiveFailures
+	}
+	return 0
+}
+
+func (x *GetRecorderStatusResponse) GetIntervalMs() int64 {
+	if x != nil {
+		return x.IntervalMs
+	}
+	return 0
+}
+
+type GetRecordingDaysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartTimeMs   int64                  `protobuf:"varint,1,opt,name=start_time_ms,json=startTimeMs,proto3" json:"start_time_ms,omitempty"` // Unix milliseconds, inclusive
+	EndTimeMs     int64                  `protobuf:"varint,2,opt,name=end_time_ms,json=endTimeMs,proto3" json:"end_time_ms,omitempty"`       // Unix milliseconds, exclusive
+	TimeZone      string                 `protobuf:"bytes,3,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`             // IANA name, e.g. "Europe/Paris", whose calendar days are listed; empty means UTC
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecordingDaysRequest) Reset() {
+	*x = GetRecordingDaysRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecordingDaysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecordingDaysRequest) ProtoMessage() {}
+
+func (x *GetRecordingDaysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecordingDaysRequest.ProtoReflect.Descriptor instead.
+func (*GetRecordingDaysRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetRecordingDaysRequest) GetStartTimeMs() int64 {
+	if x != nil {
+		return x.StartTimeMs
+	}
+	return 0
+}
+
+func (x *GetRecordingDaysRequest) GetEndTimeMs() int64 {
+	if x != nil {
+		return x.EndTimeMs
+	}
+	return 0
+}
+
+func (x *GetRecordingDaysRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type GetRecordingDaysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Days          []string               `protobuf:"bytes,1,rep,name=days,proto3" json:"days,omitempty"` // "YYYY-MM-DD", oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecordingDaysResponse) Reset() {
+	*x = GetRecordingDaysResponse{}
+	mi := &file_api_proto_light_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecordingDaysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecordingDaysResponse) ProtoMessage() {}
+
+func (x *GetRecordingDaysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecordingDaysResponse.ProtoReflect.Descriptor instead.
+func (*GetRecordingDaysResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetRecordingDaysResponse) GetDays() []string {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+type WatchDataChangesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchDataChangesRequest) Reset() {
+	*x = WatchDataChangesRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchDataChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchDataChangesRequest) ProtoMessage() {}
+
+func (x *WatchDataChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchDataChangesRequest.ProtoReflect.Descriptor instead.
+func (*WatchDataChangesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{41}
+}
+
+type StreamReadingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamReadingsRequest) Reset() {
+	*x = StreamReadingsRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamReadingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamReadingsRequest) ProtoMessage() {}
+
+func (x *StreamReadingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamReadingsRequest.ProtoReflect.Descriptor instead.
+func (*StreamReadingsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{42}
+}
+
+type DataChangeEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Change:
+	//
+	//	*DataChangeEvent_Saved
+	//	*DataChangeEvent_Pruned
+	Change        isDataChangeEvent_Change `protobuf_oneof:"change"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DataChangeEvent) Reset() {
+	*x = DataChangeEvent{}
+	mi := &file_api_proto_light_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataChangeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataChangeEvent) ProtoMessage() {}
+
+func (x *DataChangeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataChangeEvent.ProtoReflect.Descriptor instead.
+func (*DataChangeEvent) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *DataChangeEvent) GetChange() isDataChangeEvent_Change {
+	if x != nil {
+		return x.Change
+	}
+	return nil
+}
+
+func (x *DataChangeEvent) GetSaved() *ReadingSaved {
+	if x != nil {
+		if x, ok := x.Change.(*DataChangeEvent_Saved); ok {
+			return x.Saved
+		}
+	}
+	return nil
+}
+
+func (x *DataChangeEvent) GetPruned() *ReadingsPruned {
+	if x != nil {
+		if x, ok := x.Change.(*DataChangeEvent_Pruned); ok {
+			return x.Pruned
+		}
+	}
+	return nil
+}
+
+type isDataChangeEvent_Change interface {
+	isDataChangeEvent_Change()
+}
+
+type DataChangeEvent_Saved struct {
+	Saved *ReadingSaved `protobuf:"bytes,1,opt,name=saved,proto3,oneof"`
+}
+
+type DataChangeEvent_Pruned struct {
+	Pruned *ReadingsPruned `protobuf:"bytes,2,opt,name=pruned,proto3,oneof"`
+}
+
+func (*DataChangeEvent_Saved) isDataChangeEvent_Change() {}
+
+func (*DataChangeEvent_Pruned) isDataChangeEvent_Change() {}
+
+type ReadingSaved struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TimestampMs   int64                  `protobuf:"varint,2,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"` // Unix milliseconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadingSaved) Reset() {
+	*x = ReadingSaved{}
+	mi := &file_api_proto_light_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadingSaved) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadingSaved) ProtoMessage() {}
+
+func (x *ReadingSaved) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadingSaved.ProtoReflect.Descriptor instead.
+func (*ReadingSaved) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ReadingSaved) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ReadingSaved) GetTimestampMs() int64 {
+	if x != nil {
+		return x.TimestampMs
+	}
+	return 0
+}
+
+type ReadingsPruned struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	DeletedBeforeMs int64                  `protobuf:"varint,1,opt,name=deleted_before_ms,json=deletedBeforeMs,proto3" json:"deleted_before_ms,omitempty"` // every reading before this Unix millisecond time is gone
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ReadingsPruned) Reset() {
+	*x = ReadingsPruned{}
+	mi := &file_api_proto_light_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadingsPruned) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadingsPruned) ProtoMessage() {}
+
+func (x *ReadingsPruned) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadingsPruned.ProtoReflect.Descriptor instead.
+func (*ReadingsPruned) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ReadingsPruned) GetDeletedBeforeMs() int64 {
+	if x != nil {
+		return x.DeletedBeforeMs
+	}
+	return 0
+}
+
+type PruneRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Keep readings newer than this many seconds; must be at least the
+	// server's configured minimum retention
+	RetentionSeconds int64 `protobuf:"varint,1,opt,name=retention_seconds,json=retentionSeconds,proto3" json:"retention_seconds,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PruneRequest) Reset() {
+	*x = PruneRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneRequest) ProtoMessage() {}
+
+func (x *PruneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneRequest.ProtoReflect.Descriptor instead.
+func (*PruneRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *PruneRequest) GetRetentionSeconds() int64 {
+	if x != nil {
+		return x.RetentionSeconds
+	}
+	return 0
+}
+
+type PruneResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeletedCount  int64                  `protobuf:"varint,1,opt,name=deleted_count,json=deletedCount,proto3" json:"deleted_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PruneResponse) Reset() {
+	*x = PruneResponse{}
+	mi := &file_api_proto_light_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneResponse) ProtoMessage() {}
+
+func (x *PruneResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneResponse.ProtoReflect.Descriptor instead.
+func (*PruneResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *PruneResponse) GetDeletedCount() int64 {
+	if x != nil {
+		return x.DeletedCount
+	}
+	return 0
+}
+
+type CategorizeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lux           float64                `protobuf:"fixed64,1,opt,name=lux,proto3" json:"lux,omitempty"` // must be finite and non-negative
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategorizeRequest) Reset() {
+	*x = CategorizeRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategorizeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategorizeRequest) ProtoMessage() {}
+
+func (x *CategorizeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategorizeRequest.ProtoReflect.Descriptor instead.
+func (*CategorizeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *CategorizeRequest) GetLux() float64 {
+	if x != nil {
+		return x.Lux
+	}
+	return 0
+}
+
+type CategorizeResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Category string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"` // label under the server's category scheme, as on readings
+	// Low/Medium/High flags by the standard 200 and 2500 lux thresholds,
+	// whatever scheme names the category
+	IsLowLight    bool `protobuf:"varint,2,opt,name=is_low_light,json=isLowLight,proto3" json:"is_low_light,omitempty"`
+	IsMediumLight bool `protobuf:"varint,3,opt,name=is_medium_light,json=isMediumLight,proto3" json:"is_medium_light,omitempty"`
+	IsHighLight   bool `protobuf:"varint,4,opt,name=is_high_light,json=isHighLight,proto3" json:"is_high_light,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategorizeResponse) Reset() {
+	*x = CategorizeResponse{}
+	mi := &file_api_proto_light_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategorizeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategorizeResponse) ProtoMessage() {}
+
+func (x *CategorizeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategorizeResponse.ProtoReflect.Descriptor instead.
+func (*CategorizeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *CategorizeResponse) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CategorizeResponse) GetIsLowLight() bool {
+	if x != nil {
+		return x.IsLowLight
+	}
+	return false
+}
+
+func (x *CategorizeResponse) GetIsMediumLight() bool {
+	if x != nil {
+		return x.IsMediumLight
+	}
+	return false
+}
+
+func (x *CategorizeResponse) GetIsHighLight() bool {
+	if x != nil {
+		return x.IsHighLight
+	}
+	return false
+}
+
+type ReportRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	StartTimeMs int64                  `protobuf:"varint,1,opt,name=start_time_ms,json=startTimeMs,proto3" json:"start_time_ms,omitempty"`
+	EndTimeMs   int64                  `protobuf:"varint,2,opt,name=end_time_ms,json=endTimeMs,proto3" json:"end_time_ms,omitempty"`
+	// A gap is flagged where consecutive readings are more than
+	// gap_multiplier times expected_interval_ms apart. Zero uses the server's
+	// recording interval and a multiplier of 3.
+	ExpectedIntervalMs int64   `protobuf:"varint,3,opt,name=expected_interval_ms,json=expectedIntervalMs,proto3" json:"expected_interval_ms,omitempty"`
+	GapMultiplier      float64 `protobuf:"fixed64,4,opt,name=gap_multiplier,json=gapMultiplier,proto3" json:"gap_multiplier,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ReportRequest) Reset() {
+	*x = ReportRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportRequest) ProtoMessage() {}
+
+func (x *ReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportRequest.ProtoReflect.Descriptor instead.
+func (*ReportRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ReportRequest) GetStartTimeMs() int64 {
+	if x != nil {
+		return x.StartTimeMs
+	}
+	return 0
+}
+
+func (x *ReportRequest) GetEndTimeMs() int64 {
+	if x != nil {
+		return x.EndTimeMs
+	}
+	return 0
+}
+
+func (x *ReportRequest) GetExpectedIntervalMs() int64 {
+	if x != nil {
+		return x.ExpectedIntervalMs
+	}
+	return 0
+}
+
+func (x *ReportRequest) GetGapMultiplier() float64 {
+	if x != nil {
+		return x.GapMultiplier
+	}
+	return 0
+}
+
+type ReportResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ReadingCount int64                  `protobuf:"varint,1,opt,name=reading_count,json=readingCount,proto3" json:"reading_count,omitempty"`
+	AverageLux   float64                `protobuf:"fixed64,2,opt,name=average_lux,json=averageLux,proto3" json:"average_lux,omitempty"`
+	MinLux       float64                `protobuf:"fixed64,3,opt,name=min_lux,json=minLux,proto3" json:"min_lux,omitempty"`
+	MaxLux       float64                `protobuf:"fixed64,4,opt,name=max_lux,json=maxLux,proto3" json:"max_lux,omitempty"`
+	MedianLux    float64                `protobuf:"fixed64,5,opt,name=median_lux,json=medianLux,proto3" json:"median_lux,omitempty"`
+	// Estimated daily light integral in mol/m²/day, averaged over the range
+	Dli             float64             `protobuf:"fixed64,6,opt,name=dli,proto3" json:"dli,omitempty"`
+	TimeInCategory  []*CategoryDuration `protobuf:"bytes,7,rep,name=time_in_category,json=timeInCategory,proto3" json:"time_in_category,omitempty"`
+	CategoryChanges int64               `protobuf:"varint,8,opt,name=category_changes,json=categoryChanges,proto3" json:"category_changes,omitempty"`
+	Gaps            []*RecordingGap     `protobuf:"bytes,9,rep,name=gaps,proto3" json:"gaps,omitempty"`
+	UptimeFraction  float64             `protobuf:"fixed64,10,opt,name=uptime_fraction,json=uptimeFraction,proto3" json:"uptime_fraction,omitempty"` // share of the time between the first and last reading not in a gap
+	Summary         string              `protobuf:"bytes,11,opt,name=summary,proto3" json:"summary,omitempty"`                                       // the report as a sentence or two of text
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ReportResponse) Reset() {
+	*x = ReportResponse{}
+	mi := &file_api_proto_light_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportResponse) ProtoMessage() {}
+
+func (x *ReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportResponse.ProtoReflect.Descriptor instead.
+func (*ReportResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ReportResponse) GetReadingCount() int64 {
+	if x != nil {
+		return x.ReadingCount
+	}
+	return 0
+}
+
+func (x *ReportResponse) GetAverageLux() float64 {
+	if x != nil {
+		return x.AverageLux
+	}
+	return 0
+}
+
+func (x *ReportResponse) GetMinLux() float64 {
+	if x != nil {
+		return x.MinLux
+	}
+	return 0
+}
+
+func (x *ReportResponse) GetMaxLux() float64 {
+	if x != nil {
+		return x.MaxLux
+	}
+	return 0
+}
+
+func (x *ReportResponse) GetMedianLux() float64 {
+	if x != nil {
+		return x.MedianLux
+	}
+	return 0
+}
+
+func (x *ReportResponse) GetDli() float64 {
+	if x != nil {
+		return x.Dli
+	}
+	return 0
+}
+
+func (x *ReportResponse) GetTimeInCategory() []*CategoryDuration {
+	if x != nil {
+		return x.TimeInCategory
+	}
+	return nil
+}
+
+func (x *ReportResponse) GetCategoryChanges() int64 {
+	if x != nil {
+		return x.CategoryChanges
+	}
+	return 0
+}
+
+func (x *ReportResponse) GetGaps() []*RecordingGap {
+	if x != nil {
+		return x.Gaps
+	}
+	return nil
+}
+
+func (x *ReportResponse) GetUptimeFraction() float64 {
+	if x != nil {
+		return x.UptimeFraction
+	}
+	return 0
+}
+
+func (x *ReportResponse) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+type GetDailyLightIntegralRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartDate     string                 `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`     // "YYYY-MM-DD", inclusive
+	EndDate       string                 `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`           // "YYYY-MM-DD", inclusive
+	TimeZone      string                 `protobuf:"bytes,3,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`        // IANA name, e.g. "Europe/Paris", whose calendar days are used; empty means UTC
+	LuxToPpfd     float64                `protobuf:"fixed64,4,opt,name=lux_to_ppfd,json=luxToPpfd,proto3" json:"lux_to_ppfd,omitempty"` // µmol/m²/s per lux for this light source; 0 uses the server's factor
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDailyLightIntegralRequest) Reset() {
+	*x = GetDailyLightIntegralRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDailyLightIntegralRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDailyLightIntegralRequest) ProtoMessage() {}
+
+func (x *GetDailyLightIntegralRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDailyLightIntegralRequest.ProtoReflect.Descriptor instead.
+func (*GetDailyLightIntegralRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GetDailyLightIntegralRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *GetDailyLightIntegralRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *GetDailyLightIntegralRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *GetDailyLightIntegralRequest) GetLuxToPpfd() float64 {
+	if x != nil {
+		return x.LuxToPpfd
+	}
+	return 0
+}
+
+type GetDailyLightIntegralResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Days          []*DayLightIntegral    `protobuf:"bytes,1,rep,name=days,proto3" json:"days,omitempty"`                                // one per date in the range, oldest first
+	LuxToPpfd     float64                `protobuf:"fixed64,2,opt,name=lux_to_ppfd,json=luxToPpfd,proto3" json:"lux_to_ppfd,omitempty"` // the conversion factor used
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDailyLightIntegralResponse) Reset() {
+	*x = GetDailyLightIntegralResponse{}
+	mi := &file_api_proto_light_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDailyLightIntegralResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDailyLightIntegralResponse) ProtoMessage() {}
+
+func (x *GetDailyLightIntegralResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDailyLightIntegralResponse.ProtoReflect.Descriptor instead.
+func (*GetDailyLightIntegralResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetDailyLightIntegralResponse) GetDays() []*DayLightIntegral {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+func (x *GetDailyLightIntegralResponse) GetLuxToPpfd() float64 {
+	if x != nil {
+		return x.LuxToPpfd
+	}
+	return 0
+}
+
+type DayLightIntegral struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Date         string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"` // "YYYY-MM-DD"
+	Dli          float64                `protobuf:"fixed64,2,opt,name=dli,proto3" json:"dli,omitempty"` // mol/m² received during the day
+	ReadingCount int64                  `protobuf:"varint,3,opt,name=reading_count,json=readingCount,proto3" json:"reading_count,omitempty"`
+	// Share of the day (of the part so far, for today) the readings account
+	// for; a day with gaps in recording under-reports its DLI
+	Coverage      float64 `protobuf:"fixed64,4,opt,name=coverage,proto3" json:"coverage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DayLightIntegral) Reset() {
+	*x = DayLightIntegral{}
+	mi := &file_api_proto_light_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DayLightIntegral) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DayLightIntegral) ProtoMessage() {}
+
+func (x *DayLightIntegral) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DayLightIntegral.ProtoReflect.Descriptor instead.
+func (*DayLightIntegral) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *DayLightIntegral) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *DayLightIntegral) GetDli() float64 {
+	if x != nil {
+		return x.Dli
+	}
+	return 0
+}
+
+func (x *DayLightIntegral) GetReadingCount() int64 {
+	if x != nil {
+		return x.ReadingCount
+	}
+	return 0
+}
+
+func (x *DayLightIntegral) GetCoverage() float64 {
+	if x != nil {
+		return x.Coverage
+	}
+	return 0
+}
+
+type GetPhotoperiodRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartDate     string                 `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`            // "YYYY-MM-DD", inclusive
+	EndDate       string                 `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`                  // "YYYY-MM-DD", inclusive
+	TimeZone      string                 `protobuf:"bytes,3,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`               // IANA name, e.g. "Europe/Paris", whose calendar days are used; empty means UTC
+	ThresholdLux  float64                `protobuf:"fixed64,4,opt,name=threshold_lux,json=thresholdLux,proto3" json:"threshold_lux,omitempty"` // lux at or above which counts as light; 0 uses the server's threshold
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPhotoperiodRequest) Reset() {
+	*x = GetPhotoperiodRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPhotoperiodRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPhotoperiodRequest) ProtoMessage() {}
+
+func (x *GetPhotoperiodRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPhotoperiodRequest.ProtoReflect.Descriptor instead.
+func (*GetPhotoperiodRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetPhotoperiodRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *GetPhotoperiodRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *GetPhotoperiodRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *GetPhotoperiodRequest) GetThresholdLux() float64 {
+	if x != nil {
+		return x.ThresholdLux
+	}
+	return 0
+}
+
+type GetPhotoperiodResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Days          []*DayPhotoperiod      `protobuf:"bytes,1,rep,name=days,proto3" json:"days,omitempty"`                                       // one per date in the range, oldest first
+	ThresholdLux  float64                `protobuf:"fixed64,2,opt,name=threshold_lux,json=thresholdLux,proto3" json:"threshold_lux,omitempty"` // the threshold used
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPhotoperiodResponse) Reset() {
+	*x = GetPhotoperiodResponse{}
+	mi := &file_api_proto_light_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPhotoperiodResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPhotoperiodResponse) ProtoMessage() {}
+
+func (x *GetPhotoperiodResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPhotoperiodResponse.ProtoReflect.Descriptor instead.
+func (*GetPhotoperiodResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetPhotoperiodResponse) GetDays() []*DayPhotoperiod {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+func (x *GetPhotoperiodResponse) GetThresholdLux() float64 {
+	if x != nil {
+		return x.ThresholdLux
+	}
+	return 0
+}
+
+type DayPhotoperiod struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Date         string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`                                        // "YYYY-MM-DD"
+	LightHours   float64                `protobuf:"fixed64,2,opt,name=light_hours,json=lightHours,proto3" json:"light_hours,omitempty"`        // time at or above the threshold
+	FirstLightMs int64                  `protobuf:"varint,3,opt,name=first_light_ms,json=firstLightMs,proto3" json:"first_light_ms,omitempty"` // when the light first reached the threshold; 0 if it never did
+	LastLightMs  int64                  `protobuf:"varint,4,opt,name=last_light_ms,json=lastLightMs,proto3" json:"last_light_ms,omitempty"`    // when it last dropped below it (or the day ended); 0 if it never reached it
+	ReadingCount int64                  `protobuf:"varint,5,opt,name=reading_count,json=readingCount,proto3" json:"reading_count,omitempty"`
+	// Share of the day (of the part so far, for today) the readings account
+	// for; a day with gaps in recording under-reports its light hours
+	Coverage      float64 `protobuf:"fixed64,6,opt,name=coverage,proto3" json:"coverage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DayPhotoperiod) Reset() {
+	*x = DayPhotoperiod{}
+	mi := &file_api_proto_light_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DayPhotoperiod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DayPhotoperiod) ProtoMessage() {}
+
+func (x *DayPhotoperiod) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DayPhotoperiod.ProtoReflect.Descriptor instead.
+func (*DayPhotoperiod) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *DayPhotoperiod) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *DayPhotoperiod) GetLightHours() float64 {
+	if x != nil {
+		return x.LightHours
+	}
+	return 0
+}
+
+func (x *DayPhotoperiod) GetFirstLightMs() int64 {
+	if x != nil {
+		return x.FirstLightMs
+	}
+	return 0
+}
+
+func (x *DayPhotoperiod) GetLastLightMs() int64 {
+	if x != nil {
+		return x.LastLightMs
+	}
+	return 0
+}
+
+func (x *DayPhotoperiod) GetReadingCount() int64 {
+	if x != nil {
+		return x.ReadingCount
+	}
+	return 0
+}
+
+func (x *DayPhotoperiod) GetCoverage() float64 {
+	if x != nil {
+		return x.Coverage
+	}
+	return 0
+}
+
+type RecordingGap struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartTimeMs   int64                  `protobuf:"varint,1,opt,name=start_time_ms,json=startTimeMs,proto3" json:"start_time_ms,omitempty"` // the reading before the gap
+	EndTimeMs     int64                  `protobuf:"varint,2,opt,name=end_time_ms,json=endTimeMs,proto3" json:"end_time_ms,omitempty"`       // the reading after it
+	DurationMs    int64                  `protobuf:"varint,3,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordingGap) Reset() {
+	*x = RecordingGap{}
+	mi := &file_api_proto_light_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordingGap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordingGap) ProtoMessage() {}
+
+func (x *RecordingGap) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordingGap.ProtoReflect.Descriptor instead.
+func (*RecordingGap) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *RecordingGap) GetStartTimeMs() int64 {
+	if x != nil {
+		return x.StartTimeMs
+	}
+	return 0
+}
+
+func (x *RecordingGap) GetEndTimeMs() int64 {
+	if x != nil {
+		return x.EndTimeMs
+	}
+	return 0
+}
+
+func (x *RecordingGap) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+type DetectGapsRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	StartTimeMs int64                  `protobuf:"varint,1,opt,name=start_time_ms,json=startTimeMs,proto3" json:"start_time_ms,omitempty"`
+	EndTimeMs   int64                  `protobuf:"varint,2,opt,name=end_time_ms,json=endTimeMs,proto3" json:"end_time_ms,omitempty"`
+	// A gap is flagged where consecutive readings are more than tolerance
+	// times expected_interval_ms apart. Zero uses the server's recording
+	// interval and a tolerance of 3, as GenerateReport does.
+	ExpectedIntervalMs int64   `protobuf:"varint,3,opt,name=expected_interval_ms,json=expectedIntervalMs,proto3" json:"expected_interval_ms,omitempty"`
+	Tolerance          float64 `protobuf:"fixed64,4,opt,name=tolerance,proto3" json:"tolerance,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *DetectGapsRequest) Reset() {
+	*x = DetectGapsRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetectGapsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetectGapsRequest) ProtoMessage() {}
+
+func (x *DetectGapsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetectGapsRequest.ProtoReflect.Descriptor instead.
+func (*DetectGapsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *DetectGapsRequest) GetStartTimeMs() int64 {
+	if x != nil {
+		return x.StartTimeMs
+	}
+	return 0
+}
+
+func (x *DetectGapsRequest) GetEndTimeMs() int64 {
+	if x != nil {
+		return x.EndTimeMs
+	}
+	return 0
+}
+
+func (x *DetectGapsRequest) GetExpectedIntervalMs() int64 {
+	if x != nil {
+		return x.ExpectedIntervalMs
+	}
+	return 0
+}
+
+func (x *DetectGapsRequest) GetTolerance() float64 {
+	if x != nil {
+		return x.Tolerance
+	}
+	return 0
+}
+
+type DetectGapsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Gaps          []*RecordingGap        `protobuf:"bytes,1,rep,name=gaps,proto3" json:"gaps,omitempty"`
+	TotalGapMs    int64                  `protobuf:"varint,2,opt,name=total_gap_ms,json=totalGapMs,proto3" json:"total_gap_ms,omitempty"`
+	ReadingCount  int64                  `protobuf:"varint,3,opt,name=reading_count,json=readingCount,proto3" json:"reading_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DetectGapsResponse) Reset() {
+	*x = DetectGapsResponse{}
+	mi := &file_api_proto_light_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetectGapsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetectGapsResponse) ProtoMessage() {}
+
+func (x *DetectGapsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetectGapsResponse.ProtoReflect.Descriptor instead.
+func (*DetectGapsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *DetectGapsResponse) GetGaps() []*RecordingGap {
+	if x != nil {
+		return x.Gaps
+	}
+	return nil
+}
+
+func (x *DetectGapsResponse) GetTotalGapMs() int64 {
+	if x != nil {
+		return x.TotalGapMs
+	}
+	return 0
+}
+
+func (x *DetectGapsResponse) GetReadingCount() int64 {
+	if x != nil {
+		return x.ReadingCount
+	}
+	return 0
+}
+
+type RecomputeCategoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecomputeCategoriesRequest) Reset() {
+	*x = RecomputeCategoriesRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecomputeCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecomputeCategoriesRequest) ProtoMessage() {}
+
+func (x *RecomputeCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecomputeCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*RecomputeCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{61}
+}
+
+type RecomputeCategoriesResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ReadingsScanned int64                  `protobuf:"varint,1,opt,name=readings_scanned,json=readingsScanned,proto3" json:"readings_scanned,omitempty"`
+	EventsUpdated   int64                  `protobuf:"varint,2,opt,name=events_updated,json=eventsUpdated,proto3" json:"events_updated,omitempty"` // stored events removed or added; 0 if already current
+	EventsTotal     int64                  `protobuf:"varint,3,opt,name=events_total,json=eventsTotal,proto3" json:"events_total,omitempty"`       // events stored afterwards
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RecomputeCategoriesResponse) Reset() {
+	*x = RecomputeCategoriesResponse{}
+	mi := &file_api_proto_light_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecomputeCategoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecomputeCategoriesResponse) ProtoMessage() {}
+
+func (x *RecomputeCategoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecomputeCategoriesResponse.ProtoReflect.Descriptor instead.
+func (*RecomputeCategoriesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *RecomputeCategoriesResponse) GetReadingsScanned() int64 {
+	if x != nil {
+		return x.ReadingsScanned
+	}
+	return 0
+}
+
+func (x *RecomputeCategoriesResponse) GetEventsUpdated() int64 {
+	if x != nil {
+		return x.EventsUpdated
+	}
+	return 0
+}
+
+func (x *RecomputeCategoriesResponse) GetEventsTotal() int64 {
+	if x != nil {
+		return x.EventsTotal
+	}
+	return 0
+}
+
+type AlertRule struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name         string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Condition    AlertCondition         `protobuf:"varint,3,opt,name=condition,proto3,enum=light.v1.AlertCondition" json:"condition,omitempty"`
+	ThresholdLux float64                `protobuf:"fixed64,4,opt,name=threshold_lux,json=thresholdLux,proto3" json:"threshold_lux,omitempty"`
+	// How long the condition must hold before the rule fires; 0 fires on the
+	// first breaching reading. The rule fires again only after a reading
+	// clears the condition.
+	ForMs         int64  `protobuf:"varint,5,opt,name=for_ms,json=forMs,proto3" json:"for_ms,omitempty"`
+	CreatedAtMs   int64  `protobuf:"varint,6,opt,name=created_at_ms,json=createdAtMs,proto3" json:"created_at_ms,omitempty"`
+	Description   string `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"` // e.g. "lux < 150 for 2h0m0s"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlertRule) Reset() {
+	*x = AlertRule{}
+	mi := &file_api_proto_light_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlertRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertRule) ProtoMessage() {}
+
+func (x *AlertRule) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertRule.ProtoReflect.Descriptor instead.
+func (*AlertRule) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *AlertRule) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AlertRule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AlertRule) GetCondition() AlertCondition {
+	if x != nil {
+		return x.Condition
+	}
+	return AlertCondition_ALERT_CONDITION_UNSPECIFIED
+}
+
+func (x *AlertRule) GetThresholdLux() float64 {
+	if x != nil {
+		return x.ThresholdLux
+	}
+	return 0
+}
+
+func (x *AlertRule) GetForMs() int64 {
+	if x != nil {
+		return x.ForMs
+	}
+	return 0
+}
+
+func (x *AlertRule) GetCreatedAtMs() int64 {
+	if x != nil {
+		return x.CreatedAtMs
+	}
+	return 0
+}
+
+func (x *AlertRule) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type CreateAlertRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *AlertRule             `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"` // id, created_at_ms and description are ignored
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAlertRuleRequest) Reset() {
+	*x = CreateAlertRuleRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAlertRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAlertRuleRequest) ProtoMessage() {}
+
+func (x *CreateAlertRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAlertRuleRequest.ProtoReflect.Descriptor instead.
+func (*CreateAlertRuleRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *CreateAlertRuleRequest) GetRule() *AlertRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+type CreateAlertRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *AlertRule             `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAlertRuleResponse) Reset() {
+	*x = CreateAlertRuleResponse{}
+	mi := &file_api_proto_light_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAlertRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAlertRuleResponse) ProtoMessage() {}
+
+func (x *CreateAlertRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAlertRuleResponse.ProtoReflect.Descriptor instead.
+func (*CreateAlertRuleResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *CreateAlertRuleResponse) GetRule() *AlertRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+type ListAlertRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlertRulesRequest) Reset() {
+	*x = ListAlertRulesRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlertRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlertRulesRequest) ProtoMessage() {}
+
+func (x *ListAlertRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlertRulesRequest.ProtoReflect.Descriptor instead.
+func (*ListAlertRulesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{66}
+}
+
+type ListAlertRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*AlertRule           `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlertRulesResponse) Reset() {
+	*x = ListAlertRulesResponse{}
+	mi := &file_api_proto_light_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlertRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlertRulesResponse) ProtoMessage() {}
+
+func (x *ListAlertRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlertRulesResponse.ProtoReflect.Descriptor instead.
+func (*ListAlertRulesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ListAlertRulesResponse) GetRules() []*AlertRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type DeleteAlertRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAlertRuleRequest) Reset() {
+	*x = DeleteAlertRuleRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAlertRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAlertRuleRequest) ProtoMessage() {}
+
+func (x *DeleteAlertRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAlertRuleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAlertRuleRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *DeleteAlertRuleRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteAlertRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAlertRuleResponse) Reset() {
+	*x = DeleteAlertRuleResponse{}
+	mi := &file_api_proto_light_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAlertRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAlertRuleResponse) ProtoMessage() {}
+
+func (x *DeleteAlertRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAlertRuleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAlertRuleResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{69}
+}
+
+type GetAlertsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartTimeMs   int64                  `protobuf:"varint,1,opt,name=start_time_ms,json=startTimeMs,proto3" json:"start_time_ms,omitempty"` // Unix milliseconds, inclusive
+	EndTimeMs     int64                  `protobuf:"varint,2,opt,name=end_time_ms,json=endTimeMs,proto3" json:"end_time_ms,omitempty"`       // Unix milliseconds, exclusive
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAlertsRequest) Reset() {
+	*x = GetAlertsRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAlertsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAlertsRequest) ProtoMessage() {}
+
+func (x *GetAlertsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAlertsRequest.ProtoReflect.Descriptor instead.
+func (*GetAlertsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *GetAlertsRequest) GetStartTimeMs() int64 {
+	if x != nil {
+		return x.StartTimeMs
+	}
+	return 0
+}
+
+func (x *GetAlertsRequest) GetEndTimeMs() int64 {
+	if x != nil {
+		return x.EndTimeMs
+	}
+	return 0
+}
+
+type GetAlertsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Oldest first
+	Alerts        []*Alert `protobuf:"bytes,1,rep,name=alerts,proto3" json:"alerts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAlertsResponse) Reset() {
+	*x = GetAlertsResponse{}
+	mi := &file_api_proto_light_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAlertsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAlertsResponse) ProtoMessage() {}
+
+func (x *GetAlertsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAlertsResponse.ProtoReflect.Descriptor instead.
+func (*GetAlertsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *GetAlertsResponse) GetAlerts() []*Alert {
+	if x != nil {
+		return x.Alerts
+	}
+	return nil
+}
+
+type Alert struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	RuleId        int64                  `protobuf:"varint,2,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	RuleName      string                 `protobuf:"bytes,3,opt,name=rule_name,json=ruleName,proto3" json:"rule_name,omitempty"`
+	Condition     AlertCondition         `protobuf:"varint,4,opt,name=condition,proto3,enum=light.v1.AlertCondition" json:"condition,omitempty"`
+	ThresholdLux  float64                `protobuf:"fixed64,5,opt,name=threshold_lux,json=thresholdLux,proto3" json:"threshold_lux,omitempty"`
+	Lux           float64                `protobuf:"fixed64,6,opt,name=lux,proto3" json:"lux,omitempty"`                               // the reading that fired the rule
+	SinceMs       int64                  `protobuf:"varint,7,opt,name=since_ms,json=sinceMs,proto3" json:"since_ms,omitempty"`         // when the condition was first breached
+	FiredAtMs     int64                  `protobuf:"varint,8,opt,name=fired_at_ms,json=firedAtMs,proto3" json:"fired_at_ms,omitempty"` // timestamp of the reading that fired the rule
+	Message       string                 `protobuf:"bytes,9,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Alert) Reset() {
+	*x = Alert{}
+	mi := &file_api_proto_light_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Alert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alert) ProtoMessage() {}
+
+func (x *Alert) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alert.ProtoReflect.Descriptor instead.
+func (*Alert) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *Alert) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Alert) GetRuleId() int64 {
+	if x != nil {
+		return x.RuleId
+	}
+	return 0
+}
+
+func (x *Alert) GetRuleName() string {
+	if x != nil {
+		return x.RuleName
+	}
+	return ""
+}
+
+func (x *Alert) GetCondition() AlertCondition {
+	if x != nil {
+		return x.Condition
+	}
+	return AlertCondition_ALERT_CONDITION_UNSPECIFIED
+}
+
+func (x *Alert) GetThresholdLux() float64 {
+	if x != nil {
+		return x.ThresholdLux
+	}
+	return 0
+}
+
+func (x *Alert) GetLux() float64 {
+	if x != nil {
+		return x.Lux
+	}
+	return 0
+}
+
+func (x *Alert) GetSinceMs() int64 {
+	if x != nil {
+		return x.SinceMs
+	}
+	return 0
+}
+
+func (x *Alert) GetFiredAtMs() int64 {
+	if x != nil {
+		return x.FiredAtMs
+	}
+	return 0
+}
+
+func (x *Alert) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type CalibrateSensorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SensorId      string                 `protobuf:"bytes,1,opt,name=sensor_id,json=sensorId,proto3" json:"sensor_id,omitempty"`      // empty calibrates this service's sensor
+	Scale         *float64               `protobuf:"fixed64,2,opt,name=scale,proto3,oneof" json:"scale,omitempty"`                    // must be positive; unset is 1
+	OffsetLux     float64                `protobuf:"fixed64,3,opt,name=offset_lux,json=offsetLux,proto3" json:"offset_lux,omitempty"` // added after scaling
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalibrateSensorRequest) Reset() {
+	*x = CalibrateSensorRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalibrateSensorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalibrateSensorRequest) ProtoMessage() {}
+
+func (x *CalibrateSensorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalibrateSensorRequest.ProtoReflect.Descriptor instead.
+func (*CalibrateSensorRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *CalibrateSensorRequest) GetSensorId() string {
+	if x != nil {
+		return x.SensorId
+	}
+	return ""
+}
+
+func (x *CalibrateSensorRequest) GetScale() float64 {
+	if x != nil && x.Scale != nil {
+		return *x.Scale
+	}
+	return 0
+}
+
+func (x *CalibrateSensorRequest) GetOffsetLux() float64 {
+	if x != nil {
+		return x.OffsetLux
+	}
+	return 0
+}
+
+type CalibrateSensorResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Calibration   *Calibration           `protobuf:"bytes,1,opt,name=calibration,proto3" json:"calibration,omitempty"`
+	Previous      *Calibration           `protobuf:"bytes,2,opt,name=previous,proto3" json:"previous,omitempty"` // what it replaced; the identity if the sensor was uncalibrated
+	Active        bool                   `protobuf:"varint,3,opt,name=active,proto3" json:"active,omitempty"`    // applied to this service's sensor
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCurrentLightRequest) Reset() {
-	*x = GetCurrentLightRequest{}
-	mi := &file_api_proto_light_proto_msgTypes[0]
+func (x *CalibrateSensorResponse) Reset() {
+	*x = CalibrateSensorResponse{}
+	mi := &file_api_proto_light_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCurrentLightRequest) String() string {
+func (x *CalibrateSensorResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCurrentLightRequest) ProtoMessage() {}
+func (*CalibrateSensorResponse) ProtoMessage() {}
 
-func (x *GetCurrentLightRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_light_proto_msgTypes[0]
+func (x *CalibrateSensorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -52,33 +4790,57 @@ func (x *GetCurrentLightRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCurrentLightRequest.ProtoReflect.Descriptor instead.
-func (*GetCurrentLightRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_light_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use CalibrateSensorResponse.ProtoReflect.Descriptor instead.
+func (*CalibrateSensorResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{74}
 }
 
-type GetCurrentLightResponse struct {
+func (x *CalibrateSensorResponse) GetCalibration() *Calibration {
+	if x != nil {
+		return x.Calibration
+	}
+	return nil
+}
+
+func (x *CalibrateSensorResponse) GetPrevious() *Calibration {
+	if x != nil {
+		return x.Previous
+	}
+	return nil
+}
+
+func (x *CalibrateSensorResponse) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+type Calibration struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Reading       *LightReading          `protobuf:"bytes,1,opt,name=reading,proto3" json:"reading,omitempty"`
+	SensorId      string                 `protobuf:"bytes,1,opt,name=sensor_id,json=sensorId,proto3" json:"sensor_id,omitempty"`
+	Scale         float64                `protobuf:"fixed64,2,opt,name=scale,proto3" json:"scale,omitempty"`
+	OffsetLux     float64                `protobuf:"fixed64,3,opt,name=offset_lux,json=offsetLux,proto3" json:"offset_lux,omitempty"`
+	UpdatedAtMs   int64                  `protobuf:"varint,4,opt,name=updated_at_ms,json=updatedAtMs,proto3" json:"updated_at_ms,omitempty"` // 0 for the identity
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCurrentLightResponse) Reset() {
-	*x = GetCurrentLightResponse{}
-	mi := &file_api_proto_light_proto_msgTypes[1]
+func (x *Calibration) Reset() {
+	*x = Calibration{}
+	mi := &file_api_proto_light_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCurrentLightResponse) String() string {
+func (x *Calibration) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCurrentLightResponse) ProtoMessage() {}
+func (*Calibration) ProtoMessage() {}
 
-func (x *GetCurrentLightResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_light_proto_msgTypes[1]
+func (x *Calibration) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -89,43 +4851,65 @@ func (x *GetCurrentLightResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCurrentLightResponse.ProtoReflect.Descriptor instead.
-func (*GetCurrentLightResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_light_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use Calibration.ProtoReflect.Descriptor instead.
+func (*Calibration) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{75}
 }
 
-func (x *GetCurrentLightResponse) GetReading() *LightReading {
+func (x *Calibration) GetSensorId() string {
 	if x != nil {
-		return x.Reading
+		return x.SensorId
 	}
-	return nil
+	return ""
 }
 
-type GetHistoryRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Start of time range (Unix timestamp)
-	StartTime int64 `protobuf:"varint,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	// End of time range (Unix timestamp)
-	EndTime       int64 `protobuf:"varint,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+func (x *Calibration) GetScale() float64 {
+	if x != nil {
+		return x.Scale
+	}
+	return 0
+}
+
+func (x *Calibration) GetOffsetLux() float64 {
+	if x != nil {
+		return x.OffsetLux
+	}
+	return 0
+}
+
+func (x *Calibration) GetUpdatedAtMs() int64 {
+	if x != nil {
+		return x.UpdatedAtMs
+	}
+	return 0
+}
+
+type GetStatisticsRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	StartTimeMs int64                  `protobuf:"varint,1,opt,name=start_time_ms,json=startTimeMs,proto3" json:"start_time_ms,omitempty"` // Unix milliseconds, inclusive
+	EndTimeMs   int64                  `protobuf:"varint,2,opt,name=end_time_ms,json=endTimeMs,proto3" json:"end_time_ms,omitempty"`       // Unix milliseconds, exclusive
+	// Also summarize each calendar day the range touches, in time_zone
+	Daily         bool   `protobuf:"varint,3,opt,name=daily,proto3" json:"daily,omitempty"`
+	TimeZone      string `protobuf:"bytes,4,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"` // IANA name, e.g. "Europe/Paris"; empty means UTC
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetHistoryRequest) Reset() {
-	*x = GetHistoryRequest{}
-	mi := &file_api_proto_light_proto_msgTypes[2]
+func (x *GetStatisticsRequest) Reset() {
+	*x = GetStatisticsRequest{}
+	mi := &file_api_proto_light_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetHistoryRequest) String() string {
+func (x *GetStatisticsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetHistoryRequest) ProtoMessage() {}
+func (*GetStatisticsRequest) ProtoMessage() {}
 
-func (x *GetHistoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_light_proto_msgTypes[2]
+func (x *GetStatisticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -136,51 +4920,67 @@ func (x *GetHistoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetHistoryRequest.ProtoReflect.Descriptor instead.
-func (*GetHistoryRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_light_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use GetStatisticsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatisticsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{76}
 }
 
-func (x *GetHistoryRequest) GetStartTime() int64 {
+func (x *GetStatisticsRequest) GetStartTimeMs() int64 {
 	if x != nil {
-		return x.StartTime
+		return x.StartTimeMs
 	}
 	return 0
 }
 
-func (x *GetHistoryRequest) GetEndTime() int64 {
+func (x *GetStatisticsRequest) GetEndTimeMs() int64 {
 	if x != nil {
-		return x.EndTime
+		return x.EndTimeMs
 	}
 	return 0
 }
 
-type GetHistoryResponse struct {
-	state    protoimpl.MessageState `protogen:"open.v1"`
-	Readings []*LightReading        `protobuf:"bytes,1,rep,name=readings,proto3" json:"readings,omitempty"`
-	// Statistics
-	AverageLux    float64 `protobuf:"fixed64,2,opt,name=average_lux,json=averageLux,proto3" json:"average_lux,omitempty"`
-	MinLux        float64 `protobuf:"fixed64,3,opt,name=min_lux,json=minLux,proto3" json:"min_lux,omitempty"`
-	MaxLux        float64 `protobuf:"fixed64,4,opt,name=max_lux,json=maxLux,proto3" json:"max_lux,omitempty"`
+func (x *GetStatisticsRequest) GetDaily() bool {
+	if x != nil {
+		return x.Daily
+	}
+	return false
+}
+
+func (x *GetStatisticsRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type LuxStatistics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReadingCount  int64                  `protobuf:"varint,1,opt,name=reading_count,json=readingCount,proto3" json:"reading_count,omitempty"` // the statistics are all zero when this is
+	AverageLux    float64                `protobuf:"fixed64,2,opt,name=average_lux,json=averageLux,proto3" json:"average_lux,omitempty"`
+	MinLux        float64                `protobuf:"fixed64,3,opt,name=min_lux,json=minLux,proto3" json:"min_lux,omitempty"`
+	MaxLux        float64                `protobuf:"fixed64,4,opt,name=max_lux,json=maxLux,proto3" json:"max_lux,omitempty"`
+	MedianLux     float64                `protobuf:"fixed64,5,opt,name=median_lux,json=medianLux,proto3" json:"median_lux,omitempty"`
+	P95Lux        float64                `protobuf:"fixed64,6,opt,name=p95_lux,json=p95Lux,proto3" json:"p95_lux,omitempty"`          // interpolated as GetHistory's percentiles are
+	StddevLux     float64                `protobuf:"fixed64,7,opt,name=stddev_lux,json=stddevLux,proto3" json:"stddev_lux,omitempty"` // population standard deviation
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetHistoryResponse) Reset() {
-	*x = GetHistoryResponse{}
-	mi := &file_api_proto_light_proto_msgTypes[3]
+func (x *LuxStatistics) Reset() {
+	*x = LuxStatistics{}
+	mi := &file_api_proto_light_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetHistoryResponse) String() string {
+func (x *LuxStatistics) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetHistoryResponse) ProtoMessage() {}
+func (*LuxStatistics) ProtoMessage() {}
 
-func (x *GetHistoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_light_proto_msgTypes[3]
+func (x *LuxStatistics) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -191,61 +4991,87 @@ func (x *GetHistoryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetHistoryResponse.ProtoReflect.Descriptor instead.
-func (*GetHistoryResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_light_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use LuxStatistics.ProtoReflect.Descriptor instead.
+func (*LuxStatistics) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{77}
 }
 
-func (x *GetHistoryResponse) GetReadings() []*LightReading {
+func (x *LuxStatistics) GetReadingCount() int64 {
 	if x != nil {
-		return x.Readings
+		return x.ReadingCount
 	}
-	return nil
+	return 0
 }
 
-func (x *GetHistoryResponse) GetAverageLux() float64 {
+func (x *LuxStatistics) GetAverageLux() float64 {
 	if x != nil {
 		return x.AverageLux
 	}
 	return 0
 }
 
-func (x *GetHistoryResponse) GetMinLux() float64 {
+func (x *LuxStatistics) GetMinLux() float64 {
 	if x != nil {
 		return x.MinLux
 	}
 	return 0
 }
 
-func (x *GetHistoryResponse) GetMaxLux() float64 {
+func (x *LuxStatistics) GetMaxLux() float64 {
 	if x != nil {
 		return x.MaxLux
 	}
 	return 0
 }
 
-type RecordReadingRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Lux           float64                `protobuf:"fixed64,1,opt,name=lux,proto3" json:"lux,omitempty"`
+func (x *LuxStatistics) GetMedianLux() float64 {
+	if x != nil {
+		return x.MedianLux
+	}
+	return 0
+}
+
+func (x *LuxStatistics) GetP95Lux() float64 {
+	if x != nil {
+		return x.P95Lux
+	}
+	return 0
+}
+
+func (x *LuxStatistics) GetStddevLux() float64 {
+	if x != nil {
+		return x.StddevLux
+	}
+	return 0
+}
+
+type DayStatistics struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Date  string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"` // "YYYY-MM-DD"
+	// The part of the day inside the requested range: the first and last
+	// days may be partial
+	StartTimeMs   int64          `protobuf:"varint,2,opt,name=start_time_ms,json=startTimeMs,proto3" json:"start_time_ms,omitempty"`
+	EndTimeMs     int64          `protobuf:"varint,3,opt,name=end_time_ms,json=endTimeMs,proto3" json:"end_time_ms,omitempty"`
+	Statistics    *LuxStatistics `protobuf:"bytes,4,opt,name=statistics,proto3" json:"statistics,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RecordReadingRequest) Reset() {
-	*x = RecordReadingRequest{}
-	mi := &file_api_proto_light_proto_msgTypes[4]
+func (x *DayStatistics) Reset() {
+	*x = DayStatistics{}
+	mi := &file_api_proto_light_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RecordReadingRequest) String() string {
+func (x *DayStatistics) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecordReadingRequest) ProtoMessage() {}
+func (*DayStatistics) ProtoMessage() {}
 
-func (x *RecordReadingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_light_proto_msgTypes[4]
+func (x *DayStatistics) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -256,40 +5082,64 @@ func (x *RecordReadingRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecordReadingRequest.ProtoReflect.Descriptor instead.
-func (*RecordReadingRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_light_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use DayStatistics.ProtoReflect.Descriptor instead.
+func (*DayStatistics) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{78}
 }
 
-func (x *RecordReadingRequest) GetLux() float64 {
+func (x *DayStatistics) GetDate() string {
 	if x != nil {
-		return x.Lux
+		return x.Date
+	}
+	return ""
+}
+
+func (x *DayStatistics) GetStartTimeMs() int64 {
+	if x != nil {
+		return x.StartTimeMs
 	}
 	return 0
 }
 
-type RecordReadingResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Reading       *LightReading          `protobuf:"bytes,1,opt,name=reading,proto3" json:"reading,omitempty"`
+func (x *DayStatistics) GetEndTimeMs() int64 {
+	if x != nil {
+		return x.EndTimeMs
+	}
+	return 0
+}
+
+func (x *DayStatistics) GetStatistics() *LuxStatistics {
+	if x != nil {
+		return x.Statistics
+	}
+	return nil
+}
+
+type GetStatisticsResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Overall *LuxStatistics         `protobuf:"bytes,1,opt,name=overall,proto3" json:"overall,omitempty"`
+	// One per calendar day the range touches, oldest first, days without
+	// readings included; empty unless daily was requested
+	Days          []*DayStatistics `protobuf:"bytes,2,rep,name=days,proto3" json:"days,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RecordReadingResponse) Reset() {
-	*x = RecordReadingResponse{}
-	mi := &file_api_proto_light_proto_msgTypes[5]
+func (x *GetStatisticsResponse) Reset() {
+	*x = GetStatisticsResponse{}
+	mi := &file_api_proto_light_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RecordReadingResponse) String() string {
+func (x *GetStatisticsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecordReadingResponse) ProtoMessage() {}
+func (*GetStatisticsResponse) ProtoMessage() {}
 
-func (x *RecordReadingResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_light_proto_msgTypes[5]
+func (x *GetStatisticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_light_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -300,31 +5150,44 @@ func (x *RecordReadingResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecordReadingResponse.ProtoReflect.Descriptor instead.
-func (*RecordReadingResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_light_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use GetStatisticsResponse.ProtoReflect.Descriptor instead.
+func (*GetStatisticsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_light_proto_rawDescGZIP(), []int{79}
 }
 
-func (x *RecordReadingResponse) GetReading() *LightReading {
+func (x *GetStatisticsResponse) GetOverall() *LuxStatistics {
 	if x != nil {
-		return x.Reading
+		return x.Overall
+	}
+	return nil
+}
+
+func (x *GetStatisticsResponse) GetDays() []*DayStatistics {
+	if x != nil {
+		return x.Days
 	}
 	return nil
 }
 
 type LightReading struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Lux           float64                `protobuf:"fixed64,2,opt,name=lux,proto3" json:"lux,omitempty"`
-	Timestamp     int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix timestamp
-	Category      string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`    // "Low Light", "Medium Light", "High Light"
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Lux   float64                `protobuf:"fixed64,2,opt,name=lux,proto3" json:"lux,omitempty"`
+	// Deprecated: Marked as deprecated in api/proto/light.proto.
+	Timestamp          int64          `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix timestamp; use timestamp_ms
+	Category           string         `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`    // "Low Light", "Medium Light", "High Light"
+	Source             ReadingSource  `protobuf:"varint,5,opt,name=source,proto3,enum=light.v1.ReadingSource" json:"source,omitempty"`
+	TimestampRfc3339   string         `protobuf:"bytes,6,opt,name=timestamp_rfc3339,json=timestampRfc3339,proto3" json:"timestamp_rfc3339,omitempty"`               // same instant as timestamp, RFC 3339 in UTC
+	TemperatureCelsius *float64       `protobuf:"fixed64,7,opt,name=temperature_celsius,json=temperatureCelsius,proto3,oneof" json:"temperature_celsius,omitempty"` // unset when no temperature was recorded
+	TimestampMs        int64          `protobuf:"varint,8,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"`                             // Unix milliseconds
+	Quality            ReadingQuality `protobuf:"varint,9,opt,name=quality,proto3,enum=light.v1.ReadingQuality" json:"quality,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *LightReading) Reset() {
 	*x = LightReading{}
-	mi := &file_api_proto_light_proto_msgTypes[6]
+	mi := &file_api_proto_light_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -336,7 +5199,7 @@ func (x *LightReading) String() string {
 func (*LightReading) ProtoMessage() {}
 
 func (x *LightReading) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_light_proto_msgTypes[6]
+	mi := &file_api_proto_light_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -349,7 +5212,7 @@ func (x *LightReading) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LightReading.ProtoReflect.Descriptor instead.
 func (*LightReading) Descriptor() ([]byte, []int) {
-	return file_api_proto_light_proto_rawDescGZIP(), []int{6}
+	return file_api_proto_light_proto_rawDescGZIP(), []int{80}
 }
 
 func (x *LightReading) GetId() int64 {
@@ -366,6 +5229,7 @@ func (x *LightReading) GetLux() float64 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in api/proto/light.proto.
 func (x *LightReading) GetTimestamp() int64 {
 	if x != nil {
 		return x.Timestamp
@@ -380,38 +5244,480 @@ func (x *LightReading) GetCategory() string {
 	return ""
 }
 
+func (x *LightReading) GetSource() ReadingSource {
+	if x != nil {
+		return x.Source
+	}
+	return ReadingSource_READING_SOURCE_UNSPECIFIED
+}
+
+func (x *LightReading) GetTimestampRfc3339() string {
+	if x != nil {
+		return x.TimestampRfc3339
+	}
+	return ""
+}
+
+func (x *LightReading) GetTemperatureCelsius() float64 {
+	if x != nil && x.TemperatureCelsius != nil {
+		return *x.TemperatureCelsius
+	}
+	return 0
+}
+
+func (x *LightReading) GetTimestampMs() int64 {
+	if x != nil {
+		return x.TimestampMs
+	}
+	return 0
+}
+
+func (x *LightReading) GetQuality() ReadingQuality {
+	if x != nil {
+		return x.Quality
+	}
+	return ReadingQuality_READING_QUALITY_UNSPECIFIED
+}
+
 var File_api_proto_light_proto protoreflect.FileDescriptor
 
 const file_api_proto_light_proto_rawDesc = "" +
 	"\n" +
-	"\x15api/proto/light.proto\x12\blight.v1\"\x18\n" +
-	"\x16GetCurrentLightRequest\"K\n" +
+	"\x15api/proto/light.proto\x12\blight.v1\"U\n" +
+	"\x16GetCurrentLightRequest\x12;\n" +
+	"\rsmooth_window\x18\x01 \x01(\v2\x16.light.v1.SmoothWindowR\fsmoothWindow\"S\n" +
+	"\fSmoothWindow\x12\x16\n" +
+	"\x05count\x18\x01 \x01(\x05H\x00R\x05count\x12!\n" +
+	"\vduration_ms\x18\x02 \x01(\x03H\x00R\n" +
+	"durationMsB\b\n" +
+	"\x06window\"\x90\x01\n" +
 	"\x17GetCurrentLightResponse\x120\n" +
-	"\areading\x18\x01 \x01(\v2\x16.light.v1.LightReadingR\areading\"M\n" +
-	"\x11GetHistoryRequest\x12\x1d\n" +
+	"\areading\x18\x01 \x01(\v2\x16.light.v1.LightReadingR\areading\x12\x1c\n" +
+	"\tpersisted\x18\x02 \x01(\bR\tpersisted\x12%\n" +
+	"\x0esmoothed_count\x18\x03 \x01(\x05R\rsmoothedCount\"\xb0\x04\n" +
+	"\x11GetHistoryRequest\x12!\n" +
 	"\n" +
-	"start_time\x18\x01 \x01(\x03R\tstartTime\x12\x19\n" +
-	"\bend_time\x18\x02 \x01(\x03R\aendTime\"\x9b\x01\n" +
+	"start_time\x18\x01 \x01(\x03B\x02\x18\x01R\tstartTime\x12\x1d\n" +
+	"\bend_time\x18\x02 \x01(\x03B\x02\x18\x01R\aendTime\x12/\n" +
+	"\x06source\x18\x03 \x01(\x0e2\x17.light.v1.ReadingSourceR\x06source\x12!\n" +
+	"\tprecision\x18\x04 \x01(\x05H\x00R\tprecision\x88\x01\x01\x12\"\n" +
+	"\rstart_time_ms\x18\x05 \x01(\x03R\vstartTimeMs\x12\x1e\n" +
+	"\vend_time_ms\x18\x06 \x01(\x03R\tendTimeMs\x12F\n" +
+	"\x0fcategory_filter\x18\a \x01(\v2\x18.light.v1.CategoryFilterH\x01R\x0ecategoryFilter\x88\x01\x01\x12 \n" +
+	"\vpercentiles\x18\b \x03(\x01R\vpercentiles\x12\x1d\n" +
+	"\n" +
+	"stats_only\x18\t \x01(\bR\tstatsOnly\x12\x14\n" +
+	"\x05limit\x18\n" +
+	" \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\v \x01(\tR\tpageToken\x12)\n" +
+	"\x05order\x18\f \x01(\x0e2\x13.light.v1.SortOrderR\x05order\x126\n" +
+	"\x17aggregation_interval_ms\x18\r \x01(\x03R\x15aggregationIntervalMsB\f\n" +
+	"\n" +
+	"_precisionB\x12\n" +
+	"\x10_category_filter\"a\n" +
+	"\x0eCategoryFilter\x127\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\x0e2\x17.light.v1.LightCategoryR\n" +
+	"categories\x12\x16\n" +
+	"\x06levels\x18\x02 \x03(\x05R\x06levels\"\xf4\x02\n" +
 	"\x12GetHistoryResponse\x122\n" +
 	"\breadings\x18\x01 \x03(\v2\x16.light.v1.LightReadingR\breadings\x12\x1f\n" +
 	"\vaverage_lux\x18\x02 \x01(\x01R\n" +
 	"averageLux\x12\x17\n" +
 	"\amin_lux\x18\x03 \x01(\x01R\x06minLux\x12\x17\n" +
-	"\amax_lux\x18\x04 \x01(\x01R\x06maxLux\"(\n" +
+	"\amax_lux\x18\x04 \x01(\x01R\x06maxLux\x12D\n" +
+	"\x10time_in_category\x18\x05 \x03(\v2\x1a.light.v1.CategoryDurationR\x0etimeInCategory\x126\n" +
+	"\vpercentiles\x18\x06 \x03(\v2\x14.light.v1.PercentileR\vpercentiles\x12&\n" +
+	"\x0fnext_page_token\x18\a \x01(\tR\rnextPageToken\x121\n" +
+	"\abuckets\x18\b \x03(\v2\x17.light.v1.ReadingBucketR\abuckets\"\xcb\x01\n" +
+	"\rReadingBucket\x12\"\n" +
+	"\rstart_time_ms\x18\x01 \x01(\x03R\vstartTimeMs\x12\x1e\n" +
+	"\vend_time_ms\x18\x02 \x01(\x03R\tendTimeMs\x12#\n" +
+	"\rreading_count\x18\x03 \x01(\x03R\freadingCount\x12\x1f\n" +
+	"\vaverage_lux\x18\x04 \x01(\x01R\n" +
+	"averageLux\x12\x17\n" +
+	"\amin_lux\x18\x05 \x01(\x01R\x06minLux\x12\x17\n" +
+	"\amax_lux\x18\x06 \x01(\x01R\x06maxLux\">\n" +
+	"\n" +
+	"Percentile\x12\x1e\n" +
+	"\n" +
+	"percentile\x18\x01 \x01(\x01R\n" +
+	"percentile\x12\x10\n" +
+	"\x03lux\x18\x02 \x01(\x01R\x03lux\"d\n" +
+	"\x10CategoryDuration\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x18\n" +
+	"\aseconds\x18\x02 \x01(\x01R\aseconds\x12\x1a\n" +
+	"\bfraction\x18\x03 \x01(\x01R\bfraction\"\xe4\x01\n" +
 	"\x14RecordReadingRequest\x12\x10\n" +
-	"\x03lux\x18\x01 \x01(\x01R\x03lux\"I\n" +
+	"\x03lux\x18\x01 \x01(\x01R\x03lux\x124\n" +
+	"\x13temperature_celsius\x18\x02 \x01(\x01H\x00R\x12temperatureCelsius\x88\x01\x01\x12%\n" +
+	"\ttimestamp\x18\x03 \x01(\x03B\x02\x18\x01H\x01R\ttimestamp\x88\x01\x01\x12&\n" +
+	"\ftimestamp_ms\x18\x04 \x01(\x03H\x02R\vtimestampMs\x88\x01\x01B\x16\n" +
+	"\x14_temperature_celsiusB\f\n" +
+	"\n" +
+	"_timestampB\x0f\n" +
+	"\r_timestamp_ms\"I\n" +
 	"\x15RecordReadingResponse\x120\n" +
-	"\areading\x18\x01 \x01(\v2\x16.light.v1.LightReadingR\areading\"j\n" +
+	"\areading\x18\x01 \x01(\v2\x16.light.v1.LightReadingR\areading\"y\n" +
+	"\x1aRecordReadingsBatchRequest\x12:\n" +
+	"\breadings\x18\x01 \x03(\v2\x1e.light.v1.RecordReadingRequestR\breadings\x12\x1f\n" +
+	"\vimport_mode\x18\x02 \x01(\bR\n" +
+	"importMode\"\xa2\x01\n" +
+	"\x1bRecordReadingsBatchResponse\x122\n" +
+	"\breadings\x18\x01 \x03(\v2\x16.light.v1.LightReadingR\breadings\x12.\n" +
+	"\x06errors\x18\x02 \x03(\v2\x16.light.v1.ReadingErrorR\x06errors\x12\x1f\n" +
+	"\vsaved_count\x18\x03 \x01(\x05R\n" +
+	"savedCount\"R\n" +
+	"\fReadingError\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"#\n" +
+	"\x11GetReadingRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"F\n" +
+	"\x12GetReadingResponse\x120\n" +
+	"\areading\x18\x01 \x01(\v2\x16.light.v1.LightReadingR\areading\"+\n" +
+	"\x17GetReadingsByIDsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\"o\n" +
+	"\x18GetReadingsByIDsResponse\x122\n" +
+	"\breadings\x18\x01 \x03(\v2\x16.light.v1.LightReadingR\breadings\x12\x1f\n" +
+	"\vmissing_ids\x18\x02 \x03(\x03R\n" +
+	"missingIds\"*\n" +
+	"\x13GetLightAsOfRequest\x12\x13\n" +
+	"\x05at_ms\x18\x01 \x01(\x03R\x04atMs\"H\n" +
+	"\x14GetLightAsOfResponse\x120\n" +
+	"\areading\x18\x01 \x01(\v2\x16.light.v1.LightReadingR\areading\"T\n" +
+	"\x18GetCategoryEventsRequest\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x02 \x01(\x03R\aendTime\"L\n" +
+	"\x19GetCategoryEventsResponse\x12/\n" +
+	"\x06events\x18\x01 \x03(\v2\x17.light.v1.CategoryEventR\x06events\"\x95\x01\n" +
+	"\rCategoryEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12#\n" +
+	"\rfrom_category\x18\x02 \x01(\tR\ffromCategory\x12\x1f\n" +
+	"\vto_category\x18\x03 \x01(\tR\n" +
+	"toCategory\x12\x10\n" +
+	"\x03lux\x18\x04 \x01(\x01R\x03lux\x12\x1c\n" +
+	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\"\x18\n" +
+	"\x16GetStorageStatsRequest\"\xba\x01\n" +
+	"\x14StorageStatsResponse\x12#\n" +
+	"\rreading_count\x18\x01 \x01(\x03R\freadingCount\x12.\n" +
+	"\x13oldest_timestamp_ms\x18\x02 \x01(\x03R\x11oldestTimestampMs\x12.\n" +
+	"\x13newest_timestamp_ms\x18\x03 \x01(\x03R\x11newestTimestampMs\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x04 \x01(\x03R\tsizeBytes\"(\n" +
+	"\x10GetRecentRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\"G\n" +
+	"\x11GetRecentResponse\x122\n" +
+	"\breadings\x18\x01 \x03(\v2\x16.light.v1.LightReadingR\breadings\"=\n" +
+	"\tTimeRange\x12\x19\n" +
+	"\bstart_ms\x18\x01 \x01(\x03R\astartMs\x12\x15\n" +
+	"\x06end_ms\x18\x02 \x01(\x03R\x05endMs\"r\n" +
+	"\x14CompareRangesRequest\x12,\n" +
+	"\arange_a\x18\x01 \x01(\v2\x13.light.v1.TimeRangeR\x06rangeA\x12,\n" +
+	"\arange_b\x18\x02 \x01(\v2\x13.light.v1.TimeRangeR\x06rangeB\"\xbe\x01\n" +
+	"\x0fRangeStatistics\x12#\n" +
+	"\rreading_count\x18\x01 \x01(\x03R\freadingCount\x12\x1f\n" +
+	"\vaverage_lux\x18\x02 \x01(\x01R\n" +
+	"averageLux\x12\x17\n" +
+	"\amin_lux\x18\x03 \x01(\x01R\x06minLux\x12\x17\n" +
+	"\amax_lux\x18\x04 \x01(\x01R\x06maxLux\x12\x14\n" +
+	"\x05empty\x18\x05 \x01(\bR\x05empty\x12\x15\n" +
+	"\x03dli\x18\x06 \x01(\x01H\x00R\x03dli\x88\x01\x01B\x06\n" +
+	"\x04_dli\"\xad\x02\n" +
+	"\x15CompareRangesResponse\x12'\n" +
+	"\x01a\x18\x01 \x01(\v2\x19.light.v1.RangeStatisticsR\x01a\x12'\n" +
+	"\x01b\x18\x02 \x01(\v2\x19.light.v1.RangeStatisticsR\x01b\x12*\n" +
+	"\x11average_lux_delta\x18\x03 \x01(\x01R\x0faverageLuxDelta\x12\"\n" +
+	"\rmin_lux_delta\x18\x04 \x01(\x01R\vminLuxDelta\x12\"\n" +
+	"\rmax_lux_delta\x18\x05 \x01(\x01R\vmaxLuxDelta\x12\x1e\n" +
+	"\n" +
+	"comparable\x18\x06 \x01(\bR\n" +
+	"comparable\x12 \n" +
+	"\tdli_delta\x18\a \x01(\x01H\x00R\bdliDelta\x88\x01\x01B\f\n" +
+	"\n" +
+	"_dli_delta\"6\n" +
+	"\x15ExportReadingsRequest\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x01 \x01(\x05R\tbatchSize\"\xac\x01\n" +
+	"\x17DownloadReadingsRequest\x12\"\n" +
+	"\rstart_time_ms\x18\x01 \x01(\x03R\vstartTimeMs\x12\x1e\n" +
+	"\vend_time_ms\x18\x02 \x01(\x03R\tendTimeMs\x12.\n" +
+	"\x06format\x18\x03 \x01(\x0e2\x16.light.v1.ExportFormatR\x06format\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x04 \x01(\x05R\tbatchSize\"#\n" +
+	"\rDownloadChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\xd3\x01\n" +
+	"\x14StreamHistoryRequest\x12\"\n" +
+	"\rstart_time_ms\x18\x01 \x01(\x03R\vstartTimeMs\x12\x1e\n" +
+	"\vend_time_ms\x18\x02 \x01(\x03R\tendTimeMs\x12/\n" +
+	"\x06source\x18\x03 \x01(\x0e2\x17.light.v1.ReadingSourceR\x06source\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12)\n" +
+	"\x05order\x18\x05 \x01(\x0e2\x13.light.v1.SortOrderR\x05order\"B\n" +
+	"\fReadingBatch\x122\n" +
+	"\breadings\x18\x01 \x03(\v2\x16.light.v1.LightReadingR\breadings\"?\n" +
+	"\x16ImportReadingsResponse\x12%\n" +
+	"\x0eimported_count\x18\x01 \x01(\x03R\rimportedCount\"\x1a\n" +
+	"\x18GetRecorderStatusRequest\"\xd0\x01\n" +
+	"\x19GetRecorderStatusResponse\x12\x18\n" +
+	"\arunning\x18\x01 \x01(\bR\arunning\x12&\n" +
+	"\x0flast_success_ms\x18\x02 \x01(\x03R\rlastSuccessMs\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x03 \x01(\tR\tlastError\x121\n" +
+	"\x14consecutive_failures\x18\x04 \x01(\x05R\x13consecutiveFailures\x12\x1f\n" +
+	"\vinterval_ms\x18\x05 \x01(\x03R\n" +
+	"intervalMs\"z\n" +
+	"\x17GetRecordingDaysRequest\x12\"\n" +
+	"\rstart_time_ms\x18\x01 \x01(\x03R\vstartTimeMs\x12\x1e\n" +
+	"\vend_time_ms\x18\x02 \x01(\x03R\tendTimeMs\x12\x1b\n" +
+	"\ttime_zone\x18\x03 \x01(\tR\btimeZone\".\n" +
+	"\x18GetRecordingDaysResponse\x12\x12\n" +
+	"\x04days\x18\x01 \x03(\tR\x04days\"\x19\n" +
+	"\x17WatchDataChangesRequest\"\x17\n" +
+	"\x15StreamReadingsRequest\"\x7f\n" +
+	"\x0fDataChangeEvent\x12.\n" +
+	"\x05saved\x18\x01 \x01(\v2\x16.light.v1.ReadingSavedH\x00R\x05saved\x122\n" +
+	"\x06pruned\x18\x02 \x01(\v2\x18.light.v1.ReadingsPrunedH\x00R\x06prunedB\b\n" +
+	"\x06change\"A\n" +
+	"\fReadingSaved\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12!\n" +
+	"\ftimestamp_ms\x18\x02 \x01(\x03R\vtimestampMs\"<\n" +
+	"\x0eReadingsPruned\x12*\n" +
+	"\x11deleted_before_ms\x18\x01 \x01(\x03R\x0fdeletedBeforeMs\";\n" +
+	"\fPruneRequest\x12+\n" +
+	"\x11retention_seconds\x18\x01 \x01(\x03R\x10retentionSeconds\"4\n" +
+	"\rPruneResponse\x12#\n" +
+	"\rdeleted_count\x18\x01 \x01(\x03R\fdeletedCount\"%\n" +
+	"\x11CategorizeRequest\x12\x10\n" +
+	"\x03lux\x18\x01 \x01(\x01R\x03lux\"\x9e\x01\n" +
+	"\x12CategorizeResponse\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12 \n" +
+	"\fis_low_light\x18\x02 \x01(\bR\n" +
+	"isLowLight\x12&\n" +
+	"\x0fis_medium_light\x18\x03 \x01(\bR\risMediumLight\x12\"\n" +
+	"\ris_high_light\x18\x04 \x01(\bR\visHighLight\"\xac\x01\n" +
+	"\rReportRequest\x12\"\n" +
+	"\rstart_time_ms\x18\x01 \x01(\x03R\vstartTimeMs\x12\x1e\n" +
+	"\vend_time_ms\x18\x02 \x01(\x03R\tendTimeMs\x120\n" +
+	"\x14expected_interval_ms\x18\x03 \x01(\x03R\x12expectedIntervalMs\x12%\n" +
+	"\x0egap_multiplier\x18\x04 \x01(\x01R\rgapMultiplier\"\x99\x03\n" +
+	"\x0eReportResponse\x12#\n" +
+	"\rreading_count\x18\x01 \x01(\x03R\freadingCount\x12\x1f\n" +
+	"\vaverage_lux\x18\x02 \x01(\x01R\n" +
+	"averageLux\x12\x17\n" +
+	"\amin_lux\x18\x03 \x01(\x01R\x06minLux\x12\x17\n" +
+	"\amax_lux\x18\x04 \x01(\x01R\x06maxLux\x12\x1d\n" +
+	"\n" +
+	"median_lux\x18\x05 \x01(\x01R\tmedianLux\x12\x10\n" +
+	"\x03dli\x18\x06 \x01(\x01R\x03dli\x12D\n" +
+	"\x10time_in_category\x18\a \x03(\v2\x1a.light.v1.CategoryDurationR\x0etimeInCategory\x12)\n" +
+	"\x10category_changes\x18\b \x01(\x03R\x0fcategoryChanges\x12*\n" +
+	"\x04gaps\x18\t \x03(\v2\x16.light.v1.RecordingGapR\x04gaps\x12'\n" +
+	"\x0fuptime_fraction\x18\n" +
+	" \x01(\x01R\x0euptimeFraction\x12\x18\n" +
+	"\asummary\x18\v \x01(\tR\asummary\"\x95\x01\n" +
+	"\x1cGetDailyLightIntegralRequest\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x02 \x01(\tR\aendDate\x12\x1b\n" +
+	"\ttime_zone\x18\x03 \x01(\tR\btimeZone\x12\x1e\n" +
+	"\vlux_to_ppfd\x18\x04 \x01(\x01R\tluxToPpfd\"o\n" +
+	"\x1dGetDailyLightIntegralResponse\x12.\n" +
+	"\x04days\x18\x01 \x03(\v2\x1a.light.v1.DayLightIntegralR\x04days\x12\x1e\n" +
+	"\vlux_to_ppfd\x18\x02 \x01(\x01R\tluxToPpfd\"y\n" +
+	"\x10DayLightIntegral\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x10\n" +
+	"\x03dli\x18\x02 \x01(\x01R\x03dli\x12#\n" +
+	"\rreading_count\x18\x03 \x01(\x03R\freadingCount\x12\x1a\n" +
+	"\bcoverage\x18\x04 \x01(\x01R\bcoverage\"\x93\x01\n" +
+	"\x15GetPhotoperiodRequest\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x02 \x01(\tR\aendDate\x12\x1b\n" +
+	"\ttime_zone\x18\x03 \x01(\tR\btimeZone\x12#\n" +
+	"\rthreshold_lux\x18\x04 \x01(\x01R\fthresholdLux\"k\n" +
+	"\x16GetPhotoperiodResponse\x12,\n" +
+	"\x04days\x18\x01 \x03(\v2\x18.light.v1.DayPhotoperiodR\x04days\x12#\n" +
+	"\rthreshold_lux\x18\x02 \x01(\x01R\fthresholdLux\"\xd0\x01\n" +
+	"\x0eDayPhotoperiod\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x1f\n" +
+	"\vlight_hours\x18\x02 \x01(\x01R\n" +
+	"lightHours\x12$\n" +
+	"\x0efirst_light_ms\x18\x03 \x01(\x03R\ffirstLightMs\x12\"\n" +
+	"\rlast_light_ms\x18\x04 \x01(\x03R\vlastLightMs\x12#\n" +
+	"\rreading_count\x18\x05 \x01(\x03R\freadingCount\x12\x1a\n" +
+	"\bcoverage\x18\x06 \x01(\x01R\bcoverage\"s\n" +
+	"\fRecordingGap\x12\"\n" +
+	"\rstart_time_ms\x18\x01 \x01(\x03R\vstartTimeMs\x12\x1e\n" +
+	"\vend_time_ms\x18\x02 \x01(\x03R\tendTimeMs\x12\x1f\n" +
+	"\vduration_ms\x18\x03 \x01(\x03R\n" +
+	"durationMs\"\xa7\x01\n" +
+	"\x11DetectGapsRequest\x12\"\n" +
+	"\rstart_time_ms\x18\x01 \x01(\x03R\vstartTimeMs\x12\x1e\n" +
+	"\vend_time_ms\x18\x02 \x01(\x03R\tendTimeMs\x120\n" +
+	"\x14expected_interval_ms\x18\x03 \x01(\x03R\x12expectedIntervalMs\x12\x1c\n" +
+	"\ttolerance\x18\x04 \x01(\x01R\ttolerance\"\x87\x01\n" +
+	"\x12DetectGapsResponse\x12*\n" +
+	"\x04gaps\x18\x01 \x03(\v2\x16.light.v1.RecordingGapR\x04gaps\x12 \n" +
+	"\ftotal_gap_ms\x18\x02 \x01(\x03R\n" +
+	"totalGapMs\x12#\n" +
+	"\rreading_count\x18\x03 \x01(\x03R\freadingCount\"\x1c\n" +
+	"\x1aRecomputeCategoriesRequest\"\x92\x01\n" +
+	"\x1bRecomputeCategoriesResponse\x12)\n" +
+	"\x10readings_scanned\x18\x01 \x01(\x03R\x0freadingsScanned\x12%\n" +
+	"\x0eevents_updated\x18\x02 \x01(\x03R\reventsUpdated\x12!\n" +
+	"\fevents_total\x18\x03 \x01(\x03R\veventsTotal\"\xe9\x01\n" +
+	"\tAlertRule\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x126\n" +
+	"\tcondition\x18\x03 \x01(\x0e2\x18.light.v1.AlertConditionR\tcondition\x12#\n" +
+	"\rthreshold_lux\x18\x04 \x01(\x01R\fthresholdLux\x12\x15\n" +
+	"\x06for_ms\x18\x05 \x01(\x03R\x05forMs\x12\"\n" +
+	"\rcreated_at_ms\x18\x06 \x01(\x03R\vcreatedAtMs\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\"A\n" +
+	"\x16CreateAlertRuleRequest\x12'\n" +
+	"\x04rule\x18\x01 \x01(\v2\x13.light.v1.AlertRuleR\x04rule\"B\n" +
+	"\x17CreateAlertRuleResponse\x12'\n" +
+	"\x04rule\x18\x01 \x01(\v2\x13.light.v1.AlertRuleR\x04rule\"\x17\n" +
+	"\x15ListAlertRulesRequest\"C\n" +
+	"\x16ListAlertRulesResponse\x12)\n" +
+	"\x05rules\x18\x01 \x03(\v2\x13.light.v1.AlertRuleR\x05rules\"(\n" +
+	"\x16DeleteAlertRuleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x19\n" +
+	"\x17DeleteAlertRuleResponse\"V\n" +
+	"\x10GetAlertsRequest\x12\"\n" +
+	"\rstart_time_ms\x18\x01 \x01(\x03R\vstartTimeMs\x12\x1e\n" +
+	"\vend_time_ms\x18\x02 \x01(\x03R\tendTimeMs\"<\n" +
+	"\x11GetAlertsResponse\x12'\n" +
+	"\x06alerts\x18\x01 \x03(\v2\x0f.light.v1.AlertR\x06alerts\"\x91\x02\n" +
+	"\x05Alert\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\arule_id\x18\x02 \x01(\x03R\x06ruleId\x12\x1b\n" +
+	"\trule_name\x18\x03 \x01(\tR\bruleName\x126\n" +
+	"\tcondition\x18\x04 \x01(\x0e2\x18.light.v1.AlertConditionR\tcondition\x12#\n" +
+	"\rthreshold_lux\x18\x05 \x01(\x01R\fthresholdLux\x12\x10\n" +
+	"\x03lux\x18\x06 \x01(\x01R\x03lux\x12\x19\n" +
+	"\bsince_ms\x18\a \x01(\x03R\asinceMs\x12\x1e\n" +
+	"\vfired_at_ms\x18\b \x01(\x03R\tfiredAtMs\x12\x18\n" +
+	"\amessage\x18\t \x01(\tR\amessage\"y\n" +
+	"\x16CalibrateSensorRequest\x12\x1b\n" +
+	"\tsensor_id\x18\x01 \x01(\tR\bsensorId\x12\x19\n" +
+	"\x05scale\x18\x02 \x01(\x01H\x00R\x05scale\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"offset_lux\x18\x03 \x01(\x01R\toffsetLuxB\b\n" +
+	"\x06_scale\"\x9d\x01\n" +
+	"\x17CalibrateSensorResponse\x127\n" +
+	"\vcalibration\x18\x01 \x01(\v2\x15.light.v1.CalibrationR\vcalibration\x121\n" +
+	"\bprevious\x18\x02 \x01(\v2\x15.light.v1.CalibrationR\bprevious\x12\x16\n" +
+	"\x06active\x18\x03 \x01(\bR\x06active\"\x83\x01\n" +
+	"\vCalibration\x12\x1b\n" +
+	"\tsensor_id\x18\x01 \x01(\tR\bsensorId\x12\x14\n" +
+	"\x05scale\x18\x02 \x01(\x01R\x05scale\x12\x1d\n" +
+	"\n" +
+	"offset_lux\x18\x03 \x01(\x01R\toffsetLux\x12\"\n" +
+	"\rupdated_at_ms\x18\x04 \x01(\x03R\vupdatedAtMs\"\x8d\x01\n" +
+	"\x14GetStatisticsRequest\x12\"\n" +
+	"\rstart_time_ms\x18\x01 \x01(\x03R\vstartTimeMs\x12\x1e\n" +
+	"\vend_time_ms\x18\x02 \x01(\x03R\tendTimeMs\x12\x14\n" +
+	"\x05daily\x18\x03 \x01(\bR\x05daily\x12\x1b\n" +
+	"\ttime_zone\x18\x04 \x01(\tR\btimeZone\"\xde\x01\n" +
+	"\rLuxStatistics\x12#\n" +
+	"\rreading_count\x18\x01 \x01(\x03R\freadingCount\x12\x1f\n" +
+	"\vaverage_lux\x18\x02 \x01(\x01R\n" +
+	"averageLux\x12\x17\n" +
+	"\amin_lux\x18\x03 \x01(\x01R\x06minLux\x12\x17\n" +
+	"\amax_lux\x18\x04 \x01(\x01R\x06maxLux\x12\x1d\n" +
+	"\n" +
+	"median_lux\x18\x05 \x01(\x01R\tmedianLux\x12\x17\n" +
+	"\ap95_lux\x18\x06 \x01(\x01R\x06p95Lux\x12\x1d\n" +
+	"\n" +
+	"stddev_lux\x18\a \x01(\x01R\tstddevLux\"\xa0\x01\n" +
+	"\rDayStatistics\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\"\n" +
+	"\rstart_time_ms\x18\x02 \x01(\x03R\vstartTimeMs\x12\x1e\n" +
+	"\vend_time_ms\x18\x03 \x01(\x03R\tendTimeMs\x127\n" +
+	"\n" +
+	"statistics\x18\x04 \x01(\v2\x17.light.v1.LuxStatisticsR\n" +
+	"statistics\"w\n" +
+	"\x15GetStatisticsResponse\x121\n" +
+	"\aoverall\x18\x01 \x01(\v2\x17.light.v1.LuxStatisticsR\aoverall\x12+\n" +
+	"\x04days\x18\x02 \x03(\v2\x17.light.v1.DayStatisticsR\x04days\"\xf1\x02\n" +
 	"\fLightReading\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x10\n" +
-	"\x03lux\x18\x02 \x01(\x01R\x03lux\x12\x1c\n" +
-	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\x12\x1a\n" +
-	"\bcategory\x18\x04 \x01(\tR\bcategory2\x81\x02\n" +
+	"\x03lux\x18\x02 \x01(\x01R\x03lux\x12 \n" +
+	"\ttimestamp\x18\x03 \x01(\x03B\x02\x18\x01R\ttimestamp\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12/\n" +
+	"\x06source\x18\x05 \x01(\x0e2\x17.light.v1.ReadingSourceR\x06source\x12+\n" +
+	"\x11timestamp_rfc3339\x18\x06 \x01(\tR\x10timestampRfc3339\x124\n" +
+	"\x13temperature_celsius\x18\a \x01(\x01H\x00R\x12temperatureCelsius\x88\x01\x01\x12!\n" +
+	"\ftimestamp_ms\x18\b \x01(\x03R\vtimestampMs\x122\n" +
+	"\aquality\x18\t \x01(\x0e2\x18.light.v1.ReadingQualityR\aqualityB\x16\n" +
+	"\x14_temperature_celsius*\\\n" +
+	"\tSortOrder\x12\x1a\n" +
+	"\x16SORT_ORDER_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14SORT_ORDER_ASCENDING\x10\x01\x12\x19\n" +
+	"\x15SORT_ORDER_DESCENDING\x10\x02*{\n" +
+	"\rLightCategory\x12\x1e\n" +
+	"\x1aLIGHT_CATEGORY_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12LIGHT_CATEGORY_LOW\x10\x01\x12\x19\n" +
+	"\x15LIGHT_CATEGORY_MEDIUM\x10\x02\x12\x17\n" +
+	"\x13LIGHT_CATEGORY_HIGH\x10\x03*^\n" +
+	"\fExportFormat\x12\x1d\n" +
+	"\x19EXPORT_FORMAT_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11EXPORT_FORMAT_CSV\x10\x01\x12\x18\n" +
+	"\x14EXPORT_FORMAT_NDJSON\x10\x02*g\n" +
+	"\x0eAlertCondition\x12\x1f\n" +
+	"\x1bALERT_CONDITION_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15ALERT_CONDITION_BELOW\x10\x01\x12\x19\n" +
+	"\x15ALERT_CONDITION_ABOVE\x10\x02*\x8c\x01\n" +
+	"\x0eReadingQuality\x12\x1f\n" +
+	"\x1bREADING_QUALITY_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12READING_QUALITY_OK\x10\x01\x12\x1d\n" +
+	"\x19READING_QUALITY_SATURATED\x10\x02\x12\"\n" +
+	"\x1eREADING_QUALITY_LOW_CONFIDENCE\x10\x03*\x80\x01\n" +
+	"\rReadingSource\x12\x1e\n" +
+	"\x1aREADING_SOURCE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15READING_SOURCE_SENSOR\x10\x01\x12\x19\n" +
+	"\x15READING_SOURCE_MANUAL\x10\x02\x12\x19\n" +
+	"\x15READING_SOURCE_IMPORT\x10\x032\xe6\x14\n" +
 	"\fLightService\x12V\n" +
 	"\x0fGetCurrentLight\x12 .light.v1.GetCurrentLightRequest\x1a!.light.v1.GetCurrentLightResponse\x12G\n" +
 	"\n" +
 	"GetHistory\x12\x1b.light.v1.GetHistoryRequest\x1a\x1c.light.v1.GetHistoryResponse\x12P\n" +
-	"\rRecordReading\x12\x1e.light.v1.RecordReadingRequest\x1a\x1f.light.v1.RecordReadingResponseBBZ@github.com/quentinrf/plant-monitor/services/light-service/pkg/pbb\x06proto3"
+	"\rRecordReading\x12\x1e.light.v1.RecordReadingRequest\x1a\x1f.light.v1.RecordReadingResponse\x12b\n" +
+	"\x13RecordReadingsBatch\x12$.light.v1.RecordReadingsBatchRequest\x1a%.light.v1.RecordReadingsBatchResponse\x12G\n" +
+	"\n" +
+	"GetReading\x12\x1b.light.v1.GetReadingRequest\x1a\x1c.light.v1.GetReadingResponse\x12Y\n" +
+	"\x10GetReadingsByIDs\x12!.light.v1.GetReadingsByIDsRequest\x1a\".light.v1.GetReadingsByIDsResponse\x12@\n" +
+	"\rPruneReadings\x12\x16.light.v1.PruneRequest\x1a\x17.light.v1.PruneResponse\x12M\n" +
+	"\fGetLightAsOf\x12\x1d.light.v1.GetLightAsOfRequest\x1a\x1e.light.v1.GetLightAsOfResponse\x12\\\n" +
+	"\x11GetCategoryEvents\x12\".light.v1.GetCategoryEventsRequest\x1a#.light.v1.GetCategoryEventsResponse\x12S\n" +
+	"\x0fGetStorageStats\x12 .light.v1.GetStorageStatsRequest\x1a\x1e.light.v1.StorageStatsResponse\x12D\n" +
+	"\tGetRecent\x12\x1a.light.v1.GetRecentRequest\x1a\x1b.light.v1.GetRecentResponse\x12P\n" +
+	"\rCompareRanges\x12\x1e.light.v1.CompareRangesRequest\x1a\x1f.light.v1.CompareRangesResponse\x12K\n" +
+	"\x0eExportReadings\x12\x1f.light.v1.ExportReadingsRequest\x1a\x16.light.v1.ReadingBatch0\x01\x12L\n" +
+	"\x0eImportReadings\x12\x16.light.v1.ReadingBatch\x1a .light.v1.ImportReadingsResponse(\x01\x12\\\n" +
+	"\x11GetRecorderStatus\x12\".light.v1.GetRecorderStatusRequest\x1a#.light.v1.GetRecorderStatusResponse\x12R\n" +
+	"\x10WatchDataChanges\x12!.light.v1.WatchDataChangesRequest\x1a\x19.light.v1.DataChangeEvent0\x01\x12Y\n" +
+	"\x10GetRecordingDays\x12!.light.v1.GetRecordingDaysRequest\x1a\".light.v1.GetRecordingDaysResponse\x12b\n" +
+	"\x13RecomputeCategories\x12$.light.v1.RecomputeCategoriesRequest\x1a%.light.v1.RecomputeCategoriesResponse\x12G\n" +
+	"\n" +
+	"Categorize\x12\x1b.light.v1.CategorizeRequest\x1a\x1c.light.v1.CategorizeResponse\x12C\n" +
+	"\x0eGenerateReport\x12\x17.light.v1.ReportRequest\x1a\x18.light.v1.ReportResponse\x12h\n" +
+	"\x15GetDailyLightIntegral\x12&.light.v1.GetDailyLightIntegralRequest\x1a'.light.v1.GetDailyLightIntegralResponse\x12G\n" +
+	"\n" +
+	"DetectGaps\x12\x1b.light.v1.DetectGapsRequest\x1a\x1c.light.v1.DetectGapsResponse\x12K\n" +
+	"\x0eStreamReadings\x12\x1f.light.v1.StreamReadingsRequest\x1a\x16.light.v1.LightReading0\x01\x12V\n" +
+	"\x0fCreateAlertRule\x12 .light.v1.CreateAlertRuleRequest\x1a!.light.v1.CreateAlertRuleResponse\x12S\n" +
+	"\x0eListAlertRules\x12\x1f.light.v1.ListAlertRulesRequest\x1a .light.v1.ListAlertRulesResponse\x12V\n" +
+	"\x0fDeleteAlertRule\x12 .light.v1.DeleteAlertRuleRequest\x1a!.light.v1.DeleteAlertRuleResponse\x12D\n" +
+	"\tGetAlerts\x12\x1a.light.v1.GetAlertsRequest\x1a\x1b.light.v1.GetAlertsResponse\x12P\n" +
+	"\x10DownloadReadings\x12!.light.v1.DownloadReadingsRequest\x1a\x17.light.v1.DownloadChunk0\x01\x12I\n" +
+	"\rStreamHistory\x12\x1e.light.v1.StreamHistoryRequest\x1a\x16.light.v1.LightReading0\x01\x12V\n" +
+	"\x0fCalibrateSensor\x12 .light.v1.CalibrateSensorRequest\x1a!.light.v1.CalibrateSensorResponse\x12P\n" +
+	"\rGetStatistics\x12\x1e.light.v1.GetStatisticsRequest\x1a\x1f.light.v1.GetStatisticsResponse\x12S\n" +
+	"\x0eGetPhotoperiod\x12\x1f.light.v1.GetPhotoperiodRequest\x1a .light.v1.GetPhotoperiodResponseBBZ@github.com/quentinrf/plant-monitor/services/light-service/pkg/pbb\x06proto3"
 
 var (
 	file_api_proto_light_proto_rawDescOnce sync.Once
@@ -425,31 +5731,214 @@ func file_api_proto_light_proto_rawDescGZIP() []byte {
 	return file_api_proto_light_proto_rawDescData
 }
 
-var file_api_proto_light_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_api_proto_light_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_api_proto_light_proto_msgTypes = make([]protoimpl.MessageInfo, 81)
 var file_api_proto_light_proto_goTypes = []any{
-	(*GetCurrentLightRequest)(nil),  // 0: light.v1.GetCurrentLightRequest
-	(*GetCurrentLightResponse)(nil), // 1: light.v1.GetCurrentLightResponse
-	(*GetHistoryRequest)(nil),       // 2: light.v1.GetHistoryRequest
-	(*GetHistoryResponse)(nil),      // 3: light.v1.GetHistoryResponse
-	(*RecordReadingRequest)(nil),    // 4: light.v1.RecordReadingRequest
-	(*RecordReadingResponse)(nil),   // 5: light.v1.RecordReadingResponse
-	(*LightReading)(nil),            // 6: light.v1.LightReading
+	(SortOrder)(0),                        // 0: light.v1.SortOrder
+	(LightCategory)(0),                    // 1: light.v1.LightCategory
+	(ExportFormat)(0),                     // 2: light.v1.ExportFormat
+	(AlertCondition)(0),                   // 3: light.v1.AlertCondition
+	(ReadingQuality)(0),                   // 4: light.v1.ReadingQuality
+	(ReadingSource)(0),                    // 5: light.v1.ReadingSource
+	(*GetCurrentLightRequest)(nil),        // 6: light.v1.GetCurrentLightRequest
+	(*SmoothWindow)(nil),                  // 7: light.v1.SmoothWindow
+	(*GetCurrentLightResponse)(nil),       // 8: light.v1.GetCurrentLightResponse
+	(*GetHistoryRequest)(nil),             // 9: light.v1.GetHistoryRequest
+	(*CategoryFilter)(nil),                // 10: light.v1.CategoryFilter
+	(*GetHistoryResponse)(nil),            // 11: light.v1.GetHistoryResponse
+	(*ReadingBucket)(nil),                 // 12: light.v1.ReadingBucket
+	(*Percentile)(nil),                    // 13: light.v1.Percentile
+	(*CategoryDuration)(nil),              // 14: light.v1.CategoryDuration
+	(*RecordReadingRequest)(nil),          // 15: light.v1.RecordReadingRequest
+	(*RecordReadingResponse)(nil),         // 16: light.v1.RecordReadingResponse
+	(*RecordReadingsBatchRequest)(nil),    // 17: light.v1.RecordReadingsBatchRequest
+	(*RecordReadingsBatchResponse)(nil),   // 18: light.v1.RecordReadingsBatchResponse
+	(*ReadingError)(nil),                  // 19: light.v1.ReadingError
+	(*GetReadingRequest)(nil),             // 20: light.v1.GetReadingRequest
+	(*GetReadingResponse)(nil),            // 21: light.v1.GetReadingResponse
+	(*GetReadingsByIDsRequest)(nil),       // 22: light.v1.GetReadingsByIDsRequest
+	(*GetReadingsByIDsResponse)(nil),      // 23: light.v1.GetReadingsByIDsResponse
+	(*GetLightAsOfRequest)(nil),           // 24: light.v1.GetLightAsOfRequest
+	(*GetLightAsOfResponse)(nil),          // 25: light.v1.GetLightAsOfResponse
+	(*GetCategoryEventsRequest)(nil),      // 26: light.v1.GetCategoryEventsRequest
+	(*GetCategoryEventsResponse)(nil),     // 27: light.v1.GetCategoryEventsResponse
+	(*CategoryEvent)(nil),                 // 28: light.v1.CategoryEvent
+	(*GetStorageStatsRequest)(nil),        // 29: light.v1.GetStorageStatsRequest
+	(*StorageStatsResponse)(nil),          // 30: light.v1.StorageStatsResponse
+	(*GetRecentRequest)(nil),              // 31: light.v1.GetRecentRequest
+	(*GetRecentResponse)(nil),             // 32: light.v1.GetRecentResponse
+	(*TimeRange)(nil),                     // 33: light.v1.TimeRange
+	(*CompareRangesRequest)(nil),          // 34: light.v1.CompareRangesRequest
+	(*RangeStatistics)(nil),               // 35: light.v1.RangeStatistics
+	(*CompareRangesResponse)(nil),         // 36: light.v1.CompareRangesResponse
+	(*ExportReadingsRequest)(nil),         // 37: light.v1.ExportReadingsRequest
+	(*DownloadReadingsRequest)(nil),       // 38: light.v1.DownloadReadingsRequest
+	(*DownloadChunk)(nil),                 // 39: light.v1.DownloadChunk
+	(*StreamHistoryRequest)(nil),          // 40: light.v1.StreamHistoryRequest
+	(*ReadingBatch)(nil),                  // 41: light.v1.ReadingBatch
+	(*ImportReadingsResponse)(nil),        // 42: light.v1.ImportReadingsResponse
+	(*GetRecorderStatusRequest)(nil),      // 43: light.v1.GetRecorderStatusRequest
+	(*GetRecorderStatusResponse)(nil),     // 44: light.v1.GetRecorderStatusResponse
+	(*GetRecordingDaysRequest)(nil),       // 45: light.v1.GetRecordingDaysRequest
+	(*GetRecordingDaysResponse)(nil),      // 46: light.v1.GetRecordingDaysResponse
+	(*WatchDataChangesRequest)(nil),       // 47: light.v1.WatchDataChangesRequest
+	(*StreamReadingsRequest)(nil),         // 48: light.v1.StreamReadingsRequest
+	(*DataChangeEvent)(nil),               // 49: light.v1.DataChangeEvent
+	(*ReadingSaved)(nil),                  // 50: light.v1.ReadingSaved
+	(*ReadingsPruned)(nil),                // 51: light.v1.ReadingsPruned
+	(*PruneRequest)(nil),                  // 52: light.v1.PruneRequest
+	(*PruneResponse)(nil),                 // 53: light.v1.PruneResponse
+	(*CategorizeRequest)(nil),             // 54: light.v1.CategorizeRequest
+	(*CategorizeResponse)(nil),            // 55: light.v1.CategorizeResponse
+	(*ReportRequest)(nil),                 // 56: light.v1.ReportRequest
+	(*ReportResponse)(nil),                // 57: light.v1.ReportResponse
+	(*GetDailyLightIntegralRequest)(nil),  // 58: light.v1.GetDailyLightIntegralRequest
+	(*GetDailyLightIntegralResponse)(nil), // 59: light.v1.GetDailyLightIntegralResponse
+	(*DayLightIntegral)(nil),              // 60: light.v1.DayLightIntegral
+	(*GetPhotoperiodRequest)(nil),         // 61: light.v1.GetPhotoperiodRequest
+	(*GetPhotoperiodResponse)(nil),        // 62: light.v1.GetPhotoperiodResponse
+	(*DayPhotoperiod)(nil),                // 63: light.v1.DayPhotoperiod
+	(*RecordingGap)(nil),                  // 64: light.v1.RecordingGap
+	(*DetectGapsRequest)(nil),             // 65: light.v1.DetectGapsRequest
+	(*DetectGapsResponse)(nil),            // 66: light.v1.DetectGapsResponse
+	(*RecomputeCategoriesRequest)(nil),    // 67: light.v1.RecomputeCategoriesRequest
+	(*RecomputeCategoriesResponse)(nil),   // 68: light.v1.RecomputeCategoriesResponse
+	(*AlertRule)(nil),                     // 69: light.v1.AlertRule
+	(*CreateAlertRuleRequest)(nil),        // 70: light.v1.CreateAlertRuleRequest
+	(*CreateAlertRuleResponse)(nil),       // 71: light.v1.CreateAlertRuleResponse
+	(*ListAlertRulesRequest)(nil),         // 72: light.v1.ListAlertRulesRequest
+	(*ListAlertRulesResponse)(nil),        // 73: light.v1.ListAlertRulesResponse
+	(*DeleteAlertRuleRequest)(nil),        // 74: light.v1.DeleteAlertRuleRequest
+	(*DeleteAlertRuleResponse)(nil),       // 75: light.v1.DeleteAlertRuleResponse
+	(*GetAlertsRequest)(nil),              // 76: light.v1.GetAlertsRequest
+	(*GetAlertsResponse)(nil),             // 77: light.v1.GetAlertsResponse
+	(*Alert)(nil),                         // 78: light.v1.Alert
+	(*CalibrateSensorRequest)(nil),        // 79: light.v1.CalibrateSensorRequest
+	(*CalibrateSensorResponse)(nil),       // 80: light.v1.CalibrateSensorResponse
+	(*Calibration)(nil),                   // 81: light.v1.Calibration
+	(*GetStatisticsRequest)(nil),          // 82: light.v1.GetStatisticsRequest
+	(*LuxStatistics)(nil),                 // 83: light.v1.LuxStatistics
+	(*DayStatistics)(nil),                 // 84: light.v1.DayStatistics
+	(*GetStatisticsResponse)(nil),         // 85: light.v1.GetStatisticsResponse
+	(*LightReading)(nil),                  // 86: light.v1.LightReading
 }
 var file_api_proto_light_proto_depIdxs = []int32{
-	6, // 0: light.v1.GetCurrentLightResponse.reading:type_name -> light.v1.LightReading
-	6, // 1: light.v1.GetHistoryResponse.readings:type_name -> light.v1.LightReading
-	6, // 2: light.v1.RecordReadingResponse.reading:type_name -> light.v1.LightReading
-	0, // 3: light.v1.LightService.GetCurrentLight:input_type -> light.v1.GetCurrentLightRequest
-	2, // 4: light.v1.LightService.GetHistory:input_type -> light.v1.GetHistoryRequest
-	4, // 5: light.v1.LightService.RecordReading:input_type -> light.v1.RecordReadingRequest
-	1, // 6: light.v1.LightService.GetCurrentLight:output_type -> light.v1.GetCurrentLightResponse
-	3, // 7: light.v1.LightService.GetHistory:output_type -> light.v1.GetHistoryResponse
-	5, // 8: light.v1.LightService.RecordReading:output_type -> light.v1.RecordReadingResponse
-	6, // [6:9] is the sub-list for method output_type
-	3, // [3:6] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	7,  // 0: light.v1.GetCurrentLightRequest.smooth_window:type_name -> light.v1.SmoothWindow
+	86, // 1: light.v1.GetCurrentLightResponse.reading:type_name -> light.v1.LightReading
+	5,  // 2: light.v1.GetHistoryRequest.source:type_name -> light.v1.ReadingSource
+	10, // 3: light.v1.GetHistoryRequest.category_filter:type_name -> light.v1.CategoryFilter
+	0,  // 4: light.v1.GetHistoryRequest.order:type_name -> light.v1.SortOrder
+	1,  // 5: light.v1.CategoryFilter.categories:type_name -> light.v1.LightCategory
+	86, // 6: light.v1.GetHistoryResponse.readings:type_name -> light.v1.LightReading
+	14, // 7: light.v1.GetHistoryResponse.time_in_category:type_name -> light.v1.CategoryDuration
+	13, // 8: light.v1.GetHistoryResponse.percentiles:type_name -> light.v1.Percentile
+	12, // 9: light.v1.GetHistoryResponse.buckets:type_name -> light.v1.ReadingBucket
+	86, // 10: light.v1.RecordReadingResponse.reading:type_name -> light.v1.LightReading
+	15, // 11: light.v1.RecordReadingsBatchRequest.readings:type_name -> light.v1.RecordReadingRequest
+	86, // 12: light.v1.RecordReadingsBatchResponse.readings:type_name -> light.v1.LightReading
+	19, // 13: light.v1.RecordReadingsBatchResponse.errors:type_name -> light.v1.ReadingError
+	86, // 14: light.v1.GetReadingResponse.reading:type_name -> light.v1.LightReading
+	86, // 15: light.v1.GetReadingsByIDsResponse.readings:type_name -> light.v1.LightReading
+	86, // 16: light.v1.GetLightAsOfResponse.reading:type_name -> light.v1.LightReading
+	28, // 17: light.v1.GetCategoryEventsResponse.events:type_name -> light.v1.CategoryEvent
+	86, // 18: light.v1.GetRecentResponse.readings:type_name -> light.v1.LightReading
+	33, // 19: light.v1.CompareRangesRequest.range_a:type_name -> light.v1.TimeRange
+	33, // 20: light.v1.CompareRangesRequest.range_b:type_name -> light.v1.TimeRange
+	35, // 21: light.v1.CompareRangesResponse.a:type_name -> light.v1.RangeStatistics
+	35, // 22: light.v1.CompareRangesResponse.b:type_name -> light.v1.RangeStatistics
+	2,  // 23: light.v1.DownloadReadingsRequest.format:type_name -> light.v1.ExportFormat
+	5,  // 24: light.v1.StreamHistoryRequest.source:type_name -> light.v1.ReadingSource
+	0,  // 25: light.v1.StreamHistoryRequest.order:type_name -> light.v1.SortOrder
+	86, // 26: light.v1.ReadingBatch.readings:type_name -> light.v1.LightReading
+	50, // 27: light.v1.DataChangeEvent.saved:type_name -> light.v1.ReadingSaved
+	51, // 28: light.v1.DataChangeEvent.pruned:type_name -> light.v1.ReadingsPruned
+	14, // 29: light.v1.ReportResponse.time_in_category:type_name -> light.v1.CategoryDuration
+	64, // 30: light.v1.ReportResponse.gaps:type_name -> light.v1.RecordingGap
+	60, // 31: light.v1.GetDailyLightIntegralResponse.days:type_name -> light.v1.DayLightIntegral
+	63, // 32: light.v1.GetPhotoperiodResponse.days:type_name -> light.v1.DayPhotoperiod
+	64, // 33: light.v1.DetectGapsResponse.gaps:type_name -> light.v1.RecordingGap
+	3,  // 34: light.v1.AlertRule.condition:type_name -> light.v1.AlertCondition
+	69, // 35: light.v1.CreateAlertRuleRequest.rule:type_name -> light.v1.AlertRule
+	69, // 36: light.v1.CreateAlertRuleResponse.rule:type_name -> light.v1.AlertRule
+	69, // 37: light.v1.ListAlertRulesResponse.rules:type_name -> light.v1.AlertRule
+	78, // 38: light.v1.GetAlertsResponse.alerts:type_name -> light.v1.Alert
+	3,  // 39: light.v1.Alert.condition:type_name -> light.v1.AlertCondition
+	81, // 40: light.v1.CalibrateSensorResponse.calibration:type_name -> light.v1.Calibration
+	81, // 41: light.v1.CalibrateSensorResponse.previous:type_name -> light.v1.Calibration
+	83, // 42: light.v1.DayStatistics.statistics:type_name -> light.v1.LuxStatistics
+	83, // 43: light.v1.GetStatisticsResponse.overall:type_name -> light.v1.LuxStatistics
+	84, // 44: light.v1.GetStatisticsResponse.days:type_name -> light.v1.DayStatistics
+	5,  // 45: light.v1.LightReading.source:type_name -> light.v1.ReadingSource
+	4,  // 46: light.v1.LightReading.quality:type_name -> light.v1.ReadingQuality
+	6,  // 47: light.v1.LightService.GetCurrentLight:input_type -> light.v1.GetCurrentLightRequest
+	9,  // 48: light.v1.LightService.GetHistory:input_type -> light.v1.GetHistoryRequest
+	15, // 49: light.v1.LightService.RecordReading:input_type -> light.v1.RecordReadingRequest
+	17, // 50: light.v1.LightService.RecordReadingsBatch:input_type -> light.v1.RecordReadingsBatchRequest
+	20, // 51: light.v1.LightService.GetReading:input_type -> light.v1.GetReadingRequest
+	22, // 52: light.v1.LightService.GetReadingsByIDs:input_type -> light.v1.GetReadingsByIDsRequest
+	52, // 53: light.v1.LightService.PruneReadings:input_type -> light.v1.PruneRequest
+	24, // 54: light.v1.LightService.GetLightAsOf:input_type -> light.v1.GetLightAsOfRequest
+	26, // 55: light.v1.LightService.GetCategoryEvents:input_type -> light.v1.GetCategoryEventsRequest
+	29, // 56: light.v1.LightService.GetStorageStats:input_type -> light.v1.GetStorageStatsRequest
+	31, // 57: light.v1.LightService.GetRecent:input_type -> light.v1.GetRecentRequest
+	34, // 58: light.v1.LightService.CompareRanges:input_type -> light.v1.CompareRangesRequest
+	37, // 59: light.v1.LightService.ExportReadings:input_type -> light.v1.ExportReadingsRequest
+	41, // 60: light.v1.LightService.ImportReadings:input_type -> light.v1.ReadingBatch
+	43, // 61: light.v1.LightService.GetRecorderStatus:input_type -> light.v1.GetRecorderStatusRequest
+	47, // 62: light.v1.LightService.WatchDataChanges:input_type -> light.v1.WatchDataChangesRequest
+	45, // 63: light.v1.LightService.GetRecordingDays:input_type -> light.v1.GetRecordingDaysRequest
+	67, // 64: light.v1.LightService.RecomputeCategories:input_type -> light.v1.RecomputeCategoriesRequest
+	54, // 65: light.v1.LightService.Categorize:input_type -> light.v1.CategorizeRequest
+	56, // 66: light.v1.LightService.GenerateReport:input_type -> light.v1.ReportRequest
+	58, // 67: light.v1.LightService.GetDailyLightIntegral:input_type -> light.v1.GetDailyLightIntegralRequest
+	65, // 68: light.v1.LightService.DetectGaps:input_type -> light.v1.DetectGapsRequest
+	48, // 69: light.v1.LightService.StreamReadings:input_type -> light.v1.StreamReadingsRequest
+	70, // 70: light.v1.LightService.CreateAlertRule:input_type -> light.v1.CreateAlertRuleRequest
+	72, // 71: light.v1.LightService.ListAlertRules:input_type -> light.v1.ListAlertRulesRequest
+	74, // 72: light.v1.LightService.DeleteAlertRule:input_type -> light.v1.DeleteAlertRuleRequest
+	76, // 73: light.v1.LightService.GetAlerts:input_type -> light.v1.GetAlertsRequest
+	38, // 74: light.v1.LightService.DownloadReadings:input_type -> light.v1.DownloadReadingsRequest
+	40, // 75: light.v1.LightService.StreamHistory:input_type -> light.v1.StreamHistoryRequest
+	79, // 76: light.v1.LightService.CalibrateSensor:input_type -> light.v1.CalibrateSensorRequest
+	82, // 77: light.v1.LightService.GetStatistics:input_type -> light.v1.GetStatisticsRequest
+	61, // 78: light.v1.LightService.GetPhotoperiod:input_type -> light.v1.GetPhotoperiodRequest
+	8,  // 79: light.v1.LightService.GetCurrentLight:output_type -> light.v1.GetCurrentLightResponse
+	11, // 80: light.v1.LightService.GetHistory:output_type -> light.v1.GetHistoryResponse
+	16, // 81: light.v1.LightService.RecordReading:output_type -> light.v1.RecordReadingResponse
+	18, // 82: light.v1.LightService.RecordReadingsBatch:output_type -> light.v1.RecordReadingsBatchResponse
+	21, // 83: light.v1.LightService.GetReading:output_type -> light.v1.GetReadingResponse
+	23, // 84: light.v1.LightService.GetReadingsByIDs:output_type -> light.v1.GetReadingsByIDsResponse
+	53, // 85: light.v1.LightService.PruneReadings:output_type -> light.v1.PruneResponse
+	25, // 86: light.v1.LightService.GetLightAsOf:output_type -> light.v1.GetLightAsOfResponse
+	27, // 87: light.v1.LightService.GetCategoryEvents:output_type -> light.v1.GetCategoryEventsResponse
+	30, // 88: light.v1.LightService.GetStorageStats:output_type -> light.v1.StorageStatsResponse
+	32, // 89: light.v1.LightService.GetRecent:output_type -> light.v1.GetRecentResponse
+	36, // 90: light.v1.LightService.CompareRanges:output_type -> light.v1.CompareRangesResponse
+	41, // 91: light.v1.LightService.ExportReadings:output_type -> light.v1.ReadingBatch
+	42, // 92: light.v1.LightService.ImportReadings:output_type -> light.v1.ImportReadingsResponse
+	44, // 93: light.v1.LightService.GetRecorderStatus:output_type -> light.v1.GetRecorderStatusResponse
+	49, // 94: light.v1.LightService.WatchDataChanges:output_type -> light.v1.DataChangeEvent
+	46, // 95: light.v1.LightService.GetRecordingDays:output_type -> light.v1.GetRecordingDaysResponse
+	68, // 96: light.v1.LightService.RecomputeCategories:output_type -> light.v1.RecomputeCategoriesResponse
+	55, // 97: light.v1.LightService.Categorize:output_type -> light.v1.CategorizeResponse
+	57, // 98: light.v1.LightService.GenerateReport:output_type -> light.v1.ReportResponse
+	59, // 99: light.v1.LightService.GetDailyLightIntegral:output_type -> light.v1.GetDailyLightIntegralResponse
+	66, // 100: light.v1.LightService.DetectGaps:output_type -> light.v1.DetectGapsResponse
+	86, // 101: light.v1.LightService.StreamReadings:output_type -> light.v1.LightReading
+	71, // 102: light.v1.LightService.CreateAlertRule:output_type -> light.v1.CreateAlertRuleResponse
+	73, // 103: light.v1.LightService.ListAlertRules:output_type -> light.v1.ListAlertRulesResponse
+	75, // 104: light.v1.LightService.DeleteAlertRule:output_type -> light.v1.DeleteAlertRuleResponse
+	77, // 105: light.v1.LightService.GetAlerts:output_type -> light.v1.GetAlertsResponse
+	39, // 106: light.v1.LightService.DownloadReadings:output_type -> light.v1.DownloadChunk
+	86, // 107: light.v1.LightService.StreamHistory:output_type -> light.v1.LightReading
+	80, // 108: light.v1.LightService.CalibrateSensor:output_type -> light.v1.CalibrateSensorResponse
+	85, // 109: light.v1.LightService.GetStatistics:output_type -> light.v1.GetStatisticsResponse
+	62, // 110: light.v1.LightService.GetPhotoperiod:output_type -> light.v1.GetPhotoperiodResponse
+	79, // [79:111] is the sub-list for method output_type
+	47, // [47:79] is the sub-list for method input_type
+	47, // [47:47] is the sub-list for extension type_name
+	47, // [47:47] is the sub-list for extension extendee
+	0,  // [0:47] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_light_proto_init() }
@@ -457,18 +5946,33 @@ func file_api_proto_light_proto_init() {
 	if File_api_proto_light_proto != nil {
 		return
 	}
+	file_api_proto_light_proto_msgTypes[1].OneofWrappers = []any{
+		(*SmoothWindow_Count)(nil),
+		(*SmoothWindow_DurationMs)(nil),
+	}
+	file_api_proto_light_proto_msgTypes[3].OneofWrappers = []any{}
+	file_api_proto_light_proto_msgTypes[9].OneofWrappers = []any{}
+	file_api_proto_light_proto_msgTypes[29].OneofWrappers = []any{}
+	file_api_proto_light_proto_msgTypes[30].OneofWrappers = []any{}
+	file_api_proto_light_proto_msgTypes[43].OneofWrappers = []any{
+		(*DataChangeEvent_Saved)(nil),
+		(*DataChangeEvent_Pruned)(nil),
+	}
+	file_api_proto_light_proto_msgTypes[73].OneofWrappers = []any{}
+	file_api_proto_light_proto_msgTypes[80].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_light_proto_rawDesc), len(file_api_proto_light_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      6,
+			NumMessages:   81,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_api_proto_light_proto_goTypes,
 		DependencyIndexes: file_api_proto_light_proto_depIdxs,
+		EnumInfos:         file_api_proto_light_proto_enumTypes,
 		MessageInfos:      file_api_proto_light_proto_msgTypes,
 	}.Build()
 	File_api_proto_light_proto = out.File
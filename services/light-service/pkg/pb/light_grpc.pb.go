@@ -19,9 +19,38 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	LightService_GetCurrentLight_FullMethodName = "/light.v1.LightService/GetCurrentLight"
-	LightService_GetHistory_FullMethodName      = "/light.v1.LightService/GetHistory"
-	LightService_RecordReading_FullMethodName   = "/light.v1.LightService/RecordReading"
+	LightService_GetCurrentLight_FullMethodName       = "/light.v1.LightService/GetCurrentLight"
+	LightService_GetHistory_FullMethodName            = "/light.v1.LightService/GetHistory"
+	LightService_RecordReading_FullMethodName         = "/light.v1.LightService/RecordReading"
+	LightService_RecordReadingsBatch_FullMethodName   = "/light.v1.LightService/RecordReadingsBatch"
+	LightService_GetReading_FullMethodName            = "/light.v1.LightService/GetReading"
+	LightService_GetReadingsByIDs_FullMethodName      = "/light.v1.LightService/GetReadingsByIDs"
+	LightService_PruneReadings_FullMethodName         = "/light.v1.LightService/PruneReadings"
+	LightService_GetLightAsOf_FullMethodName          = "/light.v1.LightService/GetLightAsOf"
+	LightService_GetCategoryEvents_FullMethodName     = "/light.v1.LightService/GetCategoryEvents"
+	LightService_GetStorageStats_FullMethodName       = "/light.v1.LightService/GetStorageStats"
+	LightService_GetRecent_FullMethodName             = "/light.v1.LightService/GetRecent"
+	LightService_CompareRanges_FullMethodName         = "/light.v1.LightService/CompareRanges"
+	LightService_ExportReadings_FullMethodName        = "/light.v1.LightService/ExportReadings"
+	LightService_ImportReadings_FullMethodName        = "/light.v1.LightService/ImportReadings"
+	LightService_GetRecorderStatus_FullMethodName     = "/light.v1.LightService/GetRecorderStatus"
+	LightService_WatchDataChanges_FullMethodName      = "/light.v1.LightService/WatchDataChanges"
+	LightService_GetRecordingDays_FullMethodName      = "/light.v1.LightService/GetRecordingDays"
+	LightService_RecomputeCategories_FullMethodName   = "/light.v1.LightService/RecomputeCategories"
+	LightService_Categorize_FullMethodName            = "/light.v1.LightService/Categorize"
+	LightService_GenerateReport_FullMethodName        = "/light.v1.LightService/GenerateReport"
+	LightService_GetDailyLightIntegral_FullMethodName = "/light.v1.LightService/GetDailyLightIntegral"
+	LightService_DetectGaps_FullMethodName            = "/light.v1.LightService/DetectGaps"
+	LightService_StreamReadings_FullMethodName        = "/light.v1.LightService/StreamReadings"
+	LightService_CreateAlertRule_FullMethodName       = "/light.v1.LightService/CreateAlertRule"
+	LightService_ListAlertRules_FullMethodName        = "/light.v1.LightService/ListAlertRules"
+	LightService_DeleteAlertRule_FullMethodName       = "/light.v1.LightService/DeleteAlertRule"
+	LightService_GetAlerts_FullMethodName             = "/light.v1.LightService/GetAlerts"
+	LightService_DownloadReadings_FullMethodName      = "/light.v1.LightService/DownloadReadings"
+	LightService_StreamHistory_FullMethodName         = "/light.v1.LightService/StreamHistory"
+	LightService_CalibrateSensor_FullMethodName       = "/light.v1.LightService/CalibrateSensor"
+	LightService_GetStatistics_FullMethodName         = "/light.v1.LightService/GetStatistics"
+	LightService_GetPhotoperiod_FullMethodName        = "/light.v1.LightService/GetPhotoperiod"
 )
 
 // LightServiceClient is the client API for LightService service.
@@ -36,6 +65,99 @@ type LightServiceClient interface {
 	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
 	// RecordReading manually records a light reading (for testing)
 	RecordReading(ctx context.Context, in *RecordReadingRequest, opts ...grpc.CallOption) (*RecordReadingResponse, error)
+	// RecordReadingsBatch records several readings in one transaction, e.g. to
+	// backfill history from another logger. Batches over the server's
+	// configured limit are rejected with INVALID_ARGUMENT.
+	RecordReadingsBatch(ctx context.Context, in *RecordReadingsBatchRequest, opts ...grpc.CallOption) (*RecordReadingsBatchResponse, error)
+	// GetReading returns a single stored reading by ID
+	GetReading(ctx context.Context, in *GetReadingRequest, opts ...grpc.CallOption) (*GetReadingResponse, error)
+	// GetReadingsByIDs fetches several readings by ID in one call
+	GetReadingsByIDs(ctx context.Context, in *GetReadingsByIDsRequest, opts ...grpc.CallOption) (*GetReadingsByIDsResponse, error)
+	// PruneReadings deletes readings older than the requested retention (admin)
+	PruneReadings(ctx context.Context, in *PruneRequest, opts ...grpc.CallOption) (*PruneResponse, error)
+	// GetLightAsOf returns the reading that was current at a past moment:
+	// the latest reading at or before the given time
+	GetLightAsOf(ctx context.Context, in *GetLightAsOfRequest, opts ...grpc.CallOption) (*GetLightAsOfResponse, error)
+	// GetCategoryEvents returns the light category transitions in a time range
+	GetCategoryEvents(ctx context.Context, in *GetCategoryEventsRequest, opts ...grpc.CallOption) (*GetCategoryEventsResponse, error)
+	// GetStorageStats reports how much the reading store is holding
+	GetStorageStats(ctx context.Context, in *GetStorageStatsRequest, opts ...grpc.CallOption) (*StorageStatsResponse, error)
+	// GetRecent returns the latest N readings regardless of time range
+	GetRecent(ctx context.Context, in *GetRecentRequest, opts ...grpc.CallOption) (*GetRecentResponse, error)
+	// CompareRanges returns statistics for two time ranges and the change
+	// from the first to the second, e.g. before and after moving a lamp
+	CompareRanges(ctx context.Context, in *CompareRangesRequest, opts ...grpc.CallOption) (*CompareRangesResponse, error)
+	// ExportReadings streams every stored reading, oldest first, in batches
+	ExportReadings(ctx context.Context, in *ExportReadingsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ReadingBatch], error)
+	// ImportReadings restores batches produced by ExportReadings, replacing
+	// any existing reading with the same timestamp
+	ImportReadings(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ReadingBatch, ImportReadingsResponse], error)
+	// GetRecorderStatus reports how the background recorder is doing,
+	// independently of what the store holds
+	GetRecorderStatus(ctx context.Context, in *GetRecorderStatusRequest, opts ...grpc.CallOption) (*GetRecorderStatusResponse, error)
+	// WatchDataChanges streams saves and prunes as they happen, so clients
+	// caching history know what to add or evict. A client too slow to keep up
+	// misses events rather than holding up the server.
+	WatchDataChanges(ctx context.Context, in *WatchDataChangesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DataChangeEvent], error)
+	// GetRecordingDays lists the calendar days that have readings, e.g. for a
+	// calendar heatmap, without fetching the readings themselves
+	GetRecordingDays(ctx context.Context, in *GetRecordingDaysRequest, opts ...grpc.CallOption) (*GetRecordingDaysResponse, error)
+	// RecomputeCategories re-derives the stored category transitions under the
+	// current category scheme and hysteresis (admin), e.g. after changing them
+	RecomputeCategories(ctx context.Context, in *RecomputeCategoriesRequest, opts ...grpc.CallOption) (*RecomputeCategoriesResponse, error)
+	// Categorize reports which category a lux value falls in, without
+	// recording anything, e.g. to preview a slider position in a UI
+	Categorize(ctx context.Context, in *CategorizeRequest, opts ...grpc.CallOption) (*CategorizeResponse, error)
+	// GenerateReport summarizes a time range, e.g. a day or week: lux
+	// statistics, daily light integral, time in each category, category
+	// changes and recording gaps
+	GenerateReport(ctx context.Context, in *ReportRequest, opts ...grpc.CallOption) (*ReportResponse, error)
+	// GetDailyLightIntegral estimates the light received on each calendar day
+	// of a date range (DLI, in mol/m²/day)
+	GetDailyLightIntegral(ctx context.Context, in *GetDailyLightIntegralRequest, opts ...grpc.CallOption) (*GetDailyLightIntegralResponse, error)
+	// DetectGaps lists the stretches of a time range where recording stopped,
+	// e.g. to judge whether a day's DLI can be trusted
+	DetectGaps(ctx context.Context, in *DetectGapsRequest, opts ...grpc.CallOption) (*DetectGapsResponse, error)
+	// StreamReadings sends each reading as the background recorder saves it,
+	// instead of clients polling GetCurrentLight. As with WatchDataChanges, a
+	// client too slow to keep up misses readings. With a write buffer
+	// (WRITE_BUFFER_SIZE) readings are sent as they are taken, before they
+	// are stored: their id is 0, and one whose flush keeps failing until the
+	// buffer drops it is never stored at all.
+	StreamReadings(ctx context.Context, in *StreamReadingsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LightReading], error)
+	// CreateAlertRule adds a rule the recorder checks each reading against,
+	// e.g. "lux below 150 for 2 hours"
+	CreateAlertRule(ctx context.Context, in *CreateAlertRuleRequest, opts ...grpc.CallOption) (*CreateAlertRuleResponse, error)
+	// ListAlertRules returns every alert rule, oldest first
+	ListAlertRules(ctx context.Context, in *ListAlertRulesRequest, opts ...grpc.CallOption) (*ListAlertRulesResponse, error)
+	// DeleteAlertRule removes an alert rule; alerts it already fired are kept
+	DeleteAlertRule(ctx context.Context, in *DeleteAlertRuleRequest, opts ...grpc.CallOption) (*DeleteAlertRuleResponse, error)
+	// GetAlerts returns the alerts fired in a time range
+	GetAlerts(ctx context.Context, in *GetAlertsRequest, opts ...grpc.CallOption) (*GetAlertsResponse, error)
+	// DownloadReadings streams the readings in a time range as CSV or NDJSON
+	// text for offline analysis, e.g. in pandas or a spreadsheet. The chunks'
+	// data concatenates to the file. The server pages through the store, so a
+	// multi-month range is never held in memory at once.
+	DownloadReadings(ctx context.Context, in *DownloadReadingsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadChunk], error)
+	// StreamHistory streams the readings in a time range one by one, for
+	// exports too large for GetHistory's single response. The server pages
+	// through the store and only fetches the next page once the client has
+	// taken the last, so a slow client holds the server to one page.
+	StreamHistory(ctx context.Context, in *StreamHistoryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LightReading], error)
+	// CalibrateSensor stores a sensor's correction against a reference meter,
+	// corrected = raw × scale + offset_lux. When it is this service's sensor
+	// it applies at once to recorded and live readings; readings already
+	// stored are not changed.
+	CalibrateSensor(ctx context.Context, in *CalibrateSensorRequest, opts ...grpc.CallOption) (*CalibrateSensorResponse, error)
+	// GetStatistics summarizes lux over a time range without sending the
+	// readings: count, average, min, max, median, 95th percentile and
+	// standard deviation, for the whole range and optionally per calendar day.
+	// The store computes them in its query language where it can.
+	GetStatistics(ctx context.Context, in *GetStatisticsRequest, opts ...grpc.CallOption) (*GetStatisticsResponse, error)
+	// GetPhotoperiod reports, for each calendar day of a date range, how long
+	// the light stayed at or above a threshold and when it first came on and
+	// last went off, e.g. to check seedlings get their 14 hours
+	GetPhotoperiod(ctx context.Context, in *GetPhotoperiodRequest, opts ...grpc.CallOption) (*GetPhotoperiodResponse, error)
 }
 
 type lightServiceClient struct {
@@ -76,6 +198,344 @@ func (c *lightServiceClient) RecordReading(ctx context.Context, in *RecordReadin
 	return out, nil
 }
 
+func (c *lightServiceClient) RecordReadingsBatch(ctx context.Context, in *RecordReadingsBatchRequest, opts ...grpc.CallOption) (*RecordReadingsBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordReadingsBatchResponse)
+	err := c.cc.Invoke(ctx, LightService_RecordReadingsBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightServiceClient) GetReading(ctx context.Context, in *GetReadingRequest, opts ...grpc.CallOption) (*GetReadingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReadingResponse)
+	err := c.cc.Invoke(ctx, LightService_GetReading_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightServiceClient) GetReadingsByIDs(ctx context.Context, in *GetReadingsByIDsRequest, opts ...grpc.CallOption) (*GetReadingsByIDsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReadingsByIDsResponse)
+	err := c.cc.Invoke(ctx, LightService_GetReadingsByIDs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightServiceClient) PruneReadings(ctx context.Context, in *PruneRequest, opts ...grpc.CallOption) (*PruneResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PruneResponse)
+	err := c.cc.Invoke(ctx, LightService_PruneReadings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightServiceClient) GetLightAsOf(ctx context.Context, in *GetLightAsOfRequest, opts ...grpc.CallOption) (*GetLightAsOfResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLightAsOfResponse)
+	err := c.cc.Invoke(ctx, LightService_GetLightAsOf_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightServiceClient) GetCategoryEvents(ctx context.Context, in *GetCategoryEventsRequest, opts ...grpc.CallOption) (*GetCategoryEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCategoryEventsResponse)
+	err := c.cc.Invoke(ctx, LightService_GetCategoryEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightServiceClient) GetStorageStats(ctx context.Context, in *GetStorageStatsRequest, opts ...grpc.CallOption) (*StorageStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StorageStatsResponse)
+	err := c.cc.Invoke(ctx, LightService_GetStorageStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightServiceClient) GetRecent(ctx context.Context, in *GetRecentRequest, opts ...grpc.CallOption) (*GetRecentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRecentResponse)
+	err := c.cc.Invoke(ctx, LightService_GetRecent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightServiceClient) CompareRanges(ctx context.Context, in *CompareRangesRequest, opts ...grpc.CallOption) (*CompareRangesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompareRangesResponse)
+	err := c.cc.Invoke(ctx, LightService_CompareRanges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightServiceClient) ExportReadings(ctx context.Context, in *ExportReadingsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ReadingBatch], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LightService_ServiceDesc.Streams[0], LightService_ExportReadings_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportReadingsRequest, ReadingBatch]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LightService_ExportReadingsClient = grpc.ServerStreamingClient[ReadingBatch]
+
+func (c *lightServiceClient) ImportReadings(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ReadingBatch, ImportReadingsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LightService_ServiceDesc.Streams[1], LightService_ImportReadings_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ReadingBatch, ImportReadingsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LightService_ImportReadingsClient = grpc.ClientStreamingClient[ReadingBatch, ImportReadingsResponse]
+
+func (c *lightServiceClient) GetRecorderStatus(ctx context.Context, in *GetRecorderStatusRequest, opts ...grpc.CallOption) (*GetRecorderStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRecorderStatusResponse)
+	err := c.cc.Invoke(ctx, LightService_GetRecorderStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightServiceClient) WatchDataChanges(ctx context.Context, in *WatchDataChangesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DataChangeEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LightService_ServiceDesc.Streams[2], LightService_WatchDataChanges_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchDataChangesRequest, DataChangeEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LightService_WatchDataChangesClient = grpc.ServerStreamingClient[DataChangeEvent]
+
+func (c *lightServiceClient) GetRecordingDays(ctx context.Context, in *GetRecordingDaysRequest, opts ...grpc.CallOption) (*GetRecordingDaysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRecordingDaysResponse)
+	err := c.cc.Invoke(ctx, LightService_GetRecordingDays_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightServiceClient) RecomputeCategories(ctx context.Context, in *RecomputeCategoriesRequest, opts ...grpc.CallOption) (*RecomputeCategoriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecomputeCategoriesResponse)
+	err := c.cc.Invoke(ctx, LightService_RecomputeCategories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightServiceClient) Categorize(ctx context.Context, in *CategorizeRequest, opts ...grpc.CallOption) (*CategorizeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CategorizeResponse)
+	err := c.cc.Invoke(ctx, LightService_Categorize_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightServiceClient) GenerateReport(ctx context.Context, in *ReportRequest, opts ...grpc.CallOption) (*ReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportResponse)
+	err := c.cc.Invoke(ctx, LightService_GenerateReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightServiceClient) GetDailyLightIntegral(ctx context.Context, in *GetDailyLightIntegralRequest, opts ...grpc.CallOption) (*GetDailyLightIntegralResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDailyLightIntegralResponse)
+	err := c.cc.Invoke(ctx, LightService_GetDailyLightIntegral_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightServiceClient) DetectGaps(ctx context.Context, in *DetectGapsRequest, opts ...grpc.CallOption) (*DetectGapsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DetectGapsResponse)
+	err := c.cc.Invoke(ctx, LightService_DetectGaps_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightServiceClient) StreamReadings(ctx context.Context, in *StreamReadingsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LightReading], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LightService_ServiceDesc.Streams[3], LightService_StreamReadings_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamReadingsRequest, LightReading]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LightService_StreamReadingsClient = grpc.ServerStreamingClient[LightReading]
+
+func (c *lightServiceClient) CreateAlertRule(ctx context.Context, in *CreateAlertRuleRequest, opts ...grpc.CallOption) (*CreateAlertRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateAlertRuleResponse)
+	err := c.cc.Invoke(ctx, LightService_CreateAlertRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightServiceClient) ListAlertRules(ctx context.Context, in *ListAlertRulesRequest, opts ...grpc.CallOption) (*ListAlertRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAlertRulesResponse)
+	err := c.cc.Invoke(ctx, LightService_ListAlertRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightServiceClient) DeleteAlertRule(ctx context.Context, in *DeleteAlertRuleRequest, opts ...grpc.CallOption) (*DeleteAlertRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAlertRuleResponse)
+	err := c.cc.Invoke(ctx, LightService_DeleteAlertRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightServiceClient) GetAlerts(ctx context.Context, in *GetAlertsRequest, opts ...grpc.CallOption) (*GetAlertsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAlertsResponse)
+	err := c.cc.Invoke(ctx, LightService_GetAlerts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightServiceClient) DownloadReadings(ctx context.Context, in *DownloadReadingsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LightService_ServiceDesc.Streams[4], LightService_DownloadReadings_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DownloadReadingsRequest, DownloadChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LightService_DownloadReadingsClient = grpc.ServerStreamingClient[DownloadChunk]
+
+func (c *lightServiceClient) StreamHistory(ctx context.Context, in *StreamHistoryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LightReading], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LightService_ServiceDesc.Streams[5], LightService_StreamHistory_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamHistoryRequest, LightReading]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LightService_StreamHistoryClient = grpc.ServerStreamingClient[LightReading]
+
+func (c *lightServiceClient) CalibrateSensor(ctx context.Context, in *CalibrateSensorRequest, opts ...grpc.CallOption) (*CalibrateSensorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CalibrateSensorResponse)
+	err := c.cc.Invoke(ctx, LightService_CalibrateSensor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightServiceClient) GetStatistics(ctx context.Context, in *GetStatisticsRequest, opts ...grpc.CallOption) (*GetStatisticsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatisticsResponse)
+	err := c.cc.Invoke(ctx, LightService_GetStatistics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightServiceClient) GetPhotoperiod(ctx context.Context, in *GetPhotoperiodRequest, opts ...grpc.CallOption) (*GetPhotoperiodResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPhotoperiodResponse)
+	err := c.cc.Invoke(ctx, LightService_GetPhotoperiod_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LightServiceServer is the server API for LightService service.
 // All implementations must embed UnimplementedLightServiceServer
 // for forward compatibility.
@@ -88,6 +548,99 @@ type LightServiceServer interface {
 	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
 	// RecordReading manually records a light reading (for testing)
 	RecordReading(context.Context, *RecordReadingRequest) (*RecordReadingResponse, error)
+	// RecordReadingsBatch records several readings in one transaction, e.g. to
+	// backfill history from another logger. Batches over the server's
+	// configured limit are rejected with INVALID_ARGUMENT.
+	RecordReadingsBatch(context.Context, *RecordReadingsBatchRequest) (*RecordReadingsBatchResponse, error)
+	// GetReading returns a single stored reading by ID
+	GetReading(context.Context, *GetReadingRequest) (*GetReadingResponse, error)
+	// GetReadingsByIDs fetches several readings by ID in one call
+	GetReadingsByIDs(context.Context, *GetReadingsByIDsRequest) (*GetReadingsByIDsResponse, error)
+	// PruneReadings deletes readings older than the requested retention (admin)
+	PruneReadings(context.Context, *PruneRequest) (*PruneResponse, error)
+	// GetLightAsOf returns the reading that was current at a past moment:
+	// the latest reading at or before the given time
+	GetLightAsOf(context.Context, *GetLightAsOfRequest) (*GetLightAsOfResponse, error)
+	// GetCategoryEvents returns the light category transitions in a time range
+	GetCategoryEvents(context.Context, *GetCategoryEventsRequest) (*GetCategoryEventsResponse, error)
+	// GetStorageStats reports how much the reading store is holding
+	GetStorageStats(context.Context, *GetStorageStatsRequest) (*StorageStatsResponse, error)
+	// GetRecent returns the latest N readings regardless of time range
+	GetRecent(context.Context, *GetRecentRequest) (*GetRecentResponse, error)
+	// CompareRanges returns statistics for two time ranges and the change
+	// from the first to the second, e.g. before and after moving a lamp
+	CompareRanges(context.Context, *CompareRangesRequest) (*CompareRangesResponse, error)
+	// ExportReadings streams every stored reading, oldest first, in batches
+	ExportReadings(*ExportReadingsRequest, grpc.ServerStreamingServer[ReadingBatch]) error
+	// ImportReadings restores batches produced by ExportReadings, replacing
+	// any existing reading with the same timestamp
+	ImportReadings(grpc.ClientStreamingServer[ReadingBatch, ImportReadingsResponse]) error
+	// GetRecorderStatus reports how the background recorder is doing,
+	// independently of what the store holds
+	GetRecorderStatus(context.Context, *GetRecorderStatusRequest) (*GetRecorderStatusResponse, error)
+	// WatchDataChanges streams saves and prunes as they happen, so clients
+	// caching history know what to add or evict. A client too slow to keep up
+	// misses events rather than holding up the server.
+	WatchDataChanges(*WatchDataChangesRequest, grpc.ServerStreamingServer[DataChangeEvent]) error
+	// GetRecordingDays lists the calendar days that have readings, e.g. for a
+	// calendar heatmap, without fetching the readings themselves
+	GetRecordingDays(context.Context, *GetRecordingDaysRequest) (*GetRecordingDaysResponse, error)
+	// RecomputeCategories re-derives the stored category transitions under the
+	// current category scheme and hysteresis (admin), e.g. after changing them
+	RecomputeCategories(context.Context, *RecomputeCategoriesRequest) (*RecomputeCategoriesResponse, error)
+	// Categorize reports which category a lux value falls in, without
+	// recording anything, e.g. to preview a slider position in a UI
+	Categorize(context.Context, *CategorizeRequest) (*CategorizeResponse, error)
+	// GenerateReport summarizes a time range, e.g. a day or week: lux
+	// statistics, daily light integral, time in each category, category
+	// changes and recording gaps
+	GenerateReport(context.Context, *ReportRequest) (*ReportResponse, error)
+	// GetDailyLightIntegral estimates the light received on each calendar day
+	// of a date range (DLI, in mol/m²/day)
+	GetDailyLightIntegral(context.Context, *GetDailyLightIntegralRequest) (*GetDailyLightIntegralResponse, error)
+	// DetectGaps lists the stretches of a time range where recording stopped,
+	// e.g. to judge whether a day's DLI can be trusted
+	DetectGaps(context.Context, *DetectGapsRequest) (*DetectGapsResponse, error)
+	// StreamReadings sends each reading as the background recorder saves it,
+	// instead of clients polling GetCurrentLight. As with WatchDataChanges, a
+	// client too slow to keep up misses readings. With a write buffer
+	// (WRITE_BUFFER_SIZE) readings are sent as they are taken, before they
+	// are stored: their id is 0, and one whose flush keeps failing until the
+	// buffer drops it is never stored at all.
+	StreamReadings(*StreamReadingsRequest, grpc.ServerStreamingServer[LightReading]) error
+	// CreateAlertRule adds a rule the recorder checks each reading against,
+	// e.g. "lux below 150 for 2 hours"
+	CreateAlertRule(context.Context, *CreateAlertRuleRequest) (*CreateAlertRuleResponse, error)
+	// ListAlertRules returns every alert rule, oldest first
+	ListAlertRules(context.Context, *ListAlertRulesRequest) (*ListAlertRulesResponse, error)
+	// DeleteAlertRule removes an alert rule; alerts it already fired are kept
+	DeleteAlertRule(context.Context, *DeleteAlertRuleRequest) (*DeleteAlertRuleResponse, error)
+	// GetAlerts returns the alerts fired in a time range
+	GetAlerts(context.Context, *GetAlertsRequest) (*GetAlertsResponse, error)
+	// DownloadReadings streams the readings in a time range as CSV or NDJSON
+	// text for offline analysis, e.g. in pandas or a spreadsheet. The chunks'
+	// data concatenates to the file. The server pages through the store, so a
+	// multi-month range is never held in memory at once.
+	DownloadReadings(*DownloadReadingsRequest, grpc.ServerStreamingServer[DownloadChunk]) error
+	// StreamHistory streams the readings in a time range one by one, for
+	// exports too large for GetHistory's single response. The server pages
+	// through the store and only fetches the next page once the client has
+	// taken the last, so a slow client holds the server to one page.
+	StreamHistory(*StreamHistoryRequest, grpc.ServerStreamingServer[LightReading]) error
+	// CalibrateSensor stores a sensor's correction against a reference meter,
+	// corrected = raw × scale + offset_lux. When it is this service's sensor
+	// it applies at once to recorded and live readings; readings already
+	// stored are not changed.
+	CalibrateSensor(context.Context, *CalibrateSensorRequest) (*CalibrateSensorResponse, error)
+	// GetStatistics summarizes lux over a time range without sending the
+	// readings: count, average, min, max, median, 95th percentile and
+	// standard deviation, for the whole range and optionally per calendar day.
+	// The store computes them in its query language where it can.
+	GetStatistics(context.Context, *GetStatisticsRequest) (*GetStatisticsResponse, error)
+	// GetPhotoperiod reports, for each calendar day of a date range, how long
+	// the light stayed at or above a threshold and when it first came on and
+	// last went off, e.g. to check seedlings get their 14 hours
+	GetPhotoperiod(context.Context, *GetPhotoperiodRequest) (*GetPhotoperiodResponse, error)
 	mustEmbedUnimplementedLightServiceServer()
 }
 
@@ -107,6 +660,93 @@ func (UnimplementedLightServiceServer) GetHistory(context.Context, *GetHistoryRe
 func (UnimplementedLightServiceServer) RecordReading(context.Context, *RecordReadingRequest) (*RecordReadingResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method RecordReading not implemented")
 }
+func (UnimplementedLightServiceServer) RecordReadingsBatch(context.Context, *RecordReadingsBatchRequest) (*RecordReadingsBatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RecordReadingsBatch not implemented")
+}
+func (UnimplementedLightServiceServer) GetReading(context.Context, *GetReadingRequest) (*GetReadingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetReading not implemented")
+}
+func (UnimplementedLightServiceServer) GetReadingsByIDs(context.Context, *GetReadingsByIDsRequest) (*GetReadingsByIDsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetReadingsByIDs not implemented")
+}
+func (UnimplementedLightServiceServer) PruneReadings(context.Context, *PruneRequest) (*PruneResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PruneReadings not implemented")
+}
+func (UnimplementedLightServiceServer) GetLightAsOf(context.Context, *GetLightAsOfRequest) (*GetLightAsOfResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLightAsOf not implemented")
+}
+func (UnimplementedLightServiceServer) GetCategoryEvents(context.Context, *GetCategoryEventsRequest) (*GetCategoryEventsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCategoryEvents not implemented")
+}
+func (UnimplementedLightServiceServer) GetStorageStats(context.Context, *GetStorageStatsRequest) (*StorageStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetStorageStats not implemented")
+}
+func (UnimplementedLightServiceServer) GetRecent(context.Context, *GetRecentRequest) (*GetRecentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRecent not implemented")
+}
+func (UnimplementedLightServiceServer) CompareRanges(context.Context, *CompareRangesRequest) (*CompareRangesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CompareRanges not implemented")
+}
+func (UnimplementedLightServiceServer) ExportReadings(*ExportReadingsRequest, grpc.ServerStreamingServer[ReadingBatch]) error {
+	return status.Error(codes.Unimplemented, "method ExportReadings not implemented")
+}
+func (UnimplementedLightServiceServer) ImportReadings(grpc.ClientStreamingServer[ReadingBatch, ImportReadingsResponse]) error {
+	return status.Error(codes.Unimplemented, "method ImportReadings not implemented")
+}
+func (UnimplementedLightServiceServer) GetRecorderStatus(context.Context, *GetRecorderStatusRequest) (*GetRecorderStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRecorderStatus not implemented")
+}
+func (UnimplementedLightServiceServer) WatchDataChanges(*WatchDataChangesRequest, grpc.ServerStreamingServer[DataChangeEvent]) error {
+	return status.Error(codes.Unimplemented, "method WatchDataChanges not implemented")
+}
+func (UnimplementedLightServiceServer) GetRecordingDays(context.Context, *GetRecordingDaysRequest) (*GetRecordingDaysResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRecordingDays not implemented")
+}
+func (UnimplementedLightServiceServer) RecomputeCategories(context.Context, *RecomputeCategoriesRequest) (*RecomputeCategoriesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RecomputeCategories not implemented")
+}
+func (UnimplementedLightServiceServer) Categorize(context.Context, *CategorizeRequest) (*CategorizeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Categorize not implemented")
+}
+func (UnimplementedLightServiceServer) GenerateReport(context.Context, *ReportRequest) (*ReportResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GenerateReport not implemented")
+}
+func (UnimplementedLightServiceServer) GetDailyLightIntegral(context.Context, *GetDailyLightIntegralRequest) (*GetDailyLightIntegralResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDailyLightIntegral not implemented")
+}
+func (UnimplementedLightServiceServer) DetectGaps(context.Context, *DetectGapsRequest) (*DetectGapsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DetectGaps not implemented")
+}
+func (UnimplementedLightServiceServer) StreamReadings(*StreamReadingsRequest, grpc.ServerStreamingServer[LightReading]) error {
+	return status.Error(codes.Unimplemented, "method StreamReadings not implemented")
+}
+func (UnimplementedLightServiceServer) CreateAlertRule(context.Context, *CreateAlertRuleRequest) (*CreateAlertRuleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateAlertRule not implemented")
+}
+func (UnimplementedLightServiceServer) ListAlertRules(context.Context, *ListAlertRulesRequest) (*ListAlertRulesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAlertRules not implemented")
+}
+func (UnimplementedLightServiceServer) DeleteAlertRule(context.Context, *DeleteAlertRuleRequest) (*DeleteAlertRuleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteAlertRule not implemented")
+}
+func (UnimplementedLightServiceServer) GetAlerts(context.Context, *GetAlertsRequest) (*GetAlertsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAlerts not implemented")
+}
+func (UnimplementedLightServiceServer) DownloadReadings(*DownloadReadingsRequest, grpc.ServerStreamingServer[DownloadChunk]) error {
+	return status.Error(codes.Unimplemented, "method DownloadReadings not implemented")
+}
+func (UnimplementedLightServiceServer) StreamHistory(*StreamHistoryRequest, grpc.ServerStreamingServer[LightReading]) error {
+	return status.Error(codes.Unimplemented, "method StreamHistory not implemented")
+}
+func (UnimplementedLightServiceServer) CalibrateSensor(context.Context, *CalibrateSensorRequest) (*CalibrateSensorResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CalibrateSensor not implemented")
+}
+func (UnimplementedLightServiceServer) GetStatistics(context.Context, *GetStatisticsRequest) (*GetStatisticsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetStatistics not implemented")
+}
+func (UnimplementedLightServiceServer) GetPhotoperiod(context.Context, *GetPhotoperiodRequest) (*GetPhotoperiodResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPhotoperiod not implemented")
+}
 func (UnimplementedLightServiceServer) mustEmbedUnimplementedLightServiceServer() {}
 func (UnimplementedLightServiceServer) testEmbeddedByValue()                      {}
 
@@ -182,10 +822,486 @@ func _LightService_RecordReading_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
-// LightService_ServiceDesc is the grpc.ServiceDesc for LightService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var LightService_ServiceDesc = grpc.ServiceDesc{
+func _LightService_RecordReadingsBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordReadingsBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightServiceServer).RecordReadingsBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LightService_RecordReadingsBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightServiceServer).RecordReadingsBatch(ctx, req.(*RecordReadingsBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LightService_GetReading_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReadingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightServiceServer).GetReading(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LightService_GetReading_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightServiceServer).GetReading(ctx, req.(*GetReadingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LightService_GetReadingsByIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReadingsByIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightServiceServer).GetReadingsByIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LightService_GetReadingsByIDs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightServiceServer).GetReadingsByIDs(ctx, req.(*GetReadingsByIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LightService_PruneReadings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightServiceServer).PruneReadings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LightService_PruneReadings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightServiceServer).PruneReadings(ctx, req.(*PruneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LightService_GetLightAsOf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLightAsOfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightServiceServer).GetLightAsOf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LightService_GetLightAsOf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightServiceServer).GetLightAsOf(ctx, req.(*GetLightAsOfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LightService_GetCategoryEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCategoryEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightServiceServer).GetCategoryEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LightService_GetCategoryEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightServiceServer).GetCategoryEvents(ctx, req.(*GetCategoryEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LightService_GetStorageStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStorageStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightServiceServer).GetStorageStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LightService_GetStorageStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightServiceServer).GetStorageStats(ctx, req.(*GetStorageStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LightService_GetRecent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightServiceServer).GetRecent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LightService_GetRecent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightServiceServer).GetRecent(ctx, req.(*GetRecentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LightService_CompareRanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompareRangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightServiceServer).CompareRanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LightService_CompareRanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightServiceServer).CompareRanges(ctx, req.(*CompareRangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LightService_ExportReadings_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportReadingsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightServiceServer).ExportReadings(m, &grpc.GenericServerStream[ExportReadingsRequest, ReadingBatch]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LightService_ExportReadingsServer = grpc.ServerStreamingServer[ReadingBatch]
+
+func _LightService_ImportReadings_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LightServiceServer).ImportReadings(&grpc.GenericServerStream[ReadingBatch, ImportReadingsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LightService_ImportReadingsServer = grpc.ClientStreamingServer[ReadingBatch, ImportReadingsResponse]
+
+func _LightService_GetRecorderStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecorderStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightServiceServer).GetRecorderStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LightService_GetRecorderStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightServiceServer).GetRecorderStatus(ctx, req.(*GetRecorderStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LightService_WatchDataChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchDataChangesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightServiceServer).WatchDataChanges(m, &grpc.GenericServerStream[WatchDataChangesRequest, DataChangeEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LightService_WatchDataChangesServer = grpc.ServerStreamingServer[DataChangeEvent]
+
+func _LightService_GetRecordingDays_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecordingDaysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightServiceServer).GetRecordingDays(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LightService_GetRecordingDays_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightServiceServer).GetRecordingDays(ctx, req.(*GetRecordingDaysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LightService_RecomputeCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecomputeCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightServiceServer).RecomputeCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LightService_RecomputeCategories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightServiceServer).RecomputeCategories(ctx, req.(*RecomputeCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LightService_Categorize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CategorizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightServiceServer).Categorize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LightService_Categorize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightServiceServer).Categorize(ctx, req.(*CategorizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LightService_GenerateReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightServiceServer).GenerateReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LightService_GenerateReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightServiceServer).GenerateReport(ctx, req.(*ReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LightService_GetDailyLightIntegral_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDailyLightIntegralRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightServiceServer).GetDailyLightIntegral(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LightService_GetDailyLightIntegral_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightServiceServer).GetDailyLightIntegral(ctx, req.(*GetDailyLightIntegralRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LightService_DetectGaps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DetectGapsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightServiceServer).DetectGaps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LightService_DetectGaps_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightServiceServer).DetectGaps(ctx, req.(*DetectGapsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LightService_StreamReadings_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamReadingsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightServiceServer).StreamReadings(m, &grpc.GenericServerStream[StreamReadingsRequest, LightReading]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LightService_StreamReadingsServer = grpc.ServerStreamingServer[LightReading]
+
+func _LightService_CreateAlertRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAlertRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightServiceServer).CreateAlertRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LightService_CreateAlertRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightServiceServer).CreateAlertRule(ctx, req.(*CreateAlertRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LightService_ListAlertRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAlertRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightServiceServer).ListAlertRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LightService_ListAlertRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightServiceServer).ListAlertRules(ctx, req.(*ListAlertRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LightService_DeleteAlertRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAlertRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightServiceServer).DeleteAlertRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LightService_DeleteAlertRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightServiceServer).DeleteAlertRule(ctx, req.(*DeleteAlertRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LightService_GetAlerts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAlertsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightServiceServer).GetAlerts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LightService_GetAlerts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightServiceServer).GetAlerts(ctx, req.(*GetAlertsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LightService_DownloadReadings_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadReadingsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightServiceServer).DownloadReadings(m, &grpc.GenericServerStream[DownloadReadingsRequest, DownloadChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LightService_DownloadReadingsServer = grpc.ServerStreamingServer[DownloadChunk]
+
+func _LightService_StreamHistory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamHistoryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightServiceServer).StreamHistory(m, &grpc.GenericServerStream[StreamHistoryRequest, LightReading]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LightService_StreamHistoryServer = grpc.ServerStreamingServer[LightReading]
+
+func _LightService_CalibrateSensor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalibrateSensorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightServiceServer).CalibrateSensor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LightService_CalibrateSensor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightServiceServer).CalibrateSensor(ctx, req.(*CalibrateSensorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LightService_GetStatistics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatisticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightServiceServer).GetStatistics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LightService_GetStatistics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightServiceServer).GetStatistics(ctx, req.(*GetStatisticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LightService_GetPhotoperiod_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPhotoperiodRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightServiceServer).GetPhotoperiod(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LightService_GetPhotoperiod_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightServiceServer).GetPhotoperiod(ctx, req.(*GetPhotoperiodRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LightService_ServiceDesc is the grpc.ServiceDesc for LightService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LightService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "light.v1.LightService",
 	HandlerType: (*LightServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
@@ -201,7 +1317,130 @@ var LightService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RecordReading",
 			Handler:    _LightService_RecordReading_Handler,
 		},
+		{
+			MethodName: "RecordReadingsBatch",
+			Handler:    _LightService_RecordReadingsBatch_Handler,
+		},
+		{
+			MethodName: "GetReading",
+			Handler:    _LightService_GetReading_Handler,
+		},
+		{
+			MethodName: "GetReadingsByIDs",
+			Handler:    _LightService_GetReadingsByIDs_Handler,
+		},
+		{
+			MethodName: "PruneReadings",
+			Handler:    _LightService_PruneReadings_Handler,
+		},
+		{
+			MethodName: "GetLightAsOf",
+			Handler:    _LightService_GetLightAsOf_Handler,
+		},
+		{
+			MethodName: "GetCategoryEvents",
+			Handler:    _LightService_GetCategoryEvents_Handler,
+		},
+		{
+			MethodName: "GetStorageStats",
+			Handler:    _LightService_GetStorageStats_Handler,
+		},
+		{
+			MethodName: "GetRecent",
+			Handler:    _LightService_GetRecent_Handler,
+		},
+		{
+			MethodName: "CompareRanges",
+			Handler:    _LightService_CompareRanges_Handler,
+		},
+		{
+			MethodName: "GetRecorderStatus",
+			Handler:    _LightService_GetRecorderStatus_Handler,
+		},
+		{
+			MethodName: "GetRecordingDays",
+			Handler:    _LightService_GetRecordingDays_Handler,
+		},
+		{
+			MethodName: "RecomputeCategories",
+			Handler:    _LightService_RecomputeCategories_Handler,
+		},
+		{
+			MethodName: "Categorize",
+			Handler:    _LightService_Categorize_Handler,
+		},
+		{
+			MethodName: "GenerateReport",
+			Handler:    _LightService_GenerateReport_Handler,
+		},
+		{
+			MethodName: "GetDailyLightIntegral",
+			Handler:    _LightService_GetDailyLightIntegral_Handler,
+		},
+		{
+			MethodName: "DetectGaps",
+			Handler:    _LightService_DetectGaps_Handler,
+		},
+		{
+			MethodName: "CreateAlertRule",
+			Handler:    _LightService_CreateAlertRule_Handler,
+		},
+		{
+			MethodName: "ListAlertRules",
+			Handler:    _LightService_ListAlertRules_Handler,
+		},
+		{
+			MethodName: "DeleteAlertRule",
+			Handler:    _LightService_DeleteAlertRule_Handler,
+		},
+		{
+			MethodName: "GetAlerts",
+			Handler:    _LightService_GetAlerts_Handler,
+		},
+		{
+			MethodName: "CalibrateSensor",
+			Handler:    _LightService_CalibrateSensor_Handler,
+		},
+		{
+			MethodName: "GetStatistics",
+			Handler:    _LightService_GetStatistics_Handler,
+		},
+		{
+			MethodName: "GetPhotoperiod",
+			Handler:    _LightService_GetPhotoperiod_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportReadings",
+			Handler:       _LightService_ExportReadings_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportReadings",
+			Handler:       _LightService_ImportReadings_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchDataChanges",
+			Handler:       _LightService_WatchDataChanges_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamReadings",
+			Handler:       _LightService_StreamReadings_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "DownloadReadings",
+			Handler:       _LightService_DownloadReadings_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamHistory",
+			Handler:       _LightService_StreamHistory_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/light.proto",
 }
@@ -0,0 +1,153 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeCert writes a self-signed certificate with the serial number and its
+// key to certFile and keyFile, dating both modifications at
+func writeCert(t *testing.T, certFile, keyFile string, serial int64, at time.Time) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "light-service"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), at)
+	writeFile(t, keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), at)
+}
+
+// writeFile writes data to name, dating the modification at so that
+// rewrites are told apart however coarse the file system's clock
+func writeFile(t *testing.T, name string, data []byte, at time.Time) {
+	t.Helper()
+	if err := os.WriteFile(name, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(name, at, at); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func servedSerial(t *testing.T, r *CertReloader) int64 {
+	t.Helper()
+	cert, err := r.GetCertificate(nil)
+	if err != nil {
+		t.Fatalf("GetCertificate failed: %v", err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return leaf.SerialNumber.Int64()
+}
+
+func TestCertReloader_PicksUpRotation(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	epoch := time.Now().Add(-time.Hour)
+	writeCert(t, certFile, keyFile, 1, epoch)
+
+	var reloads []error
+	r, err := NewCertReloader(certFile, keyFile,
+		WithReloadInterval(time.Nanosecond),
+		WithReloadHook(func(err error) { reloads = append(reloads, err) }),
+	)
+	if err != nil {
+		t.Fatalf("NewCertReloader failed: %v", err)
+	}
+	if got := servedSerial(t, r); got != 1 {
+		t.Fatalf("expected serial 1, got %d", got)
+	}
+	if len(reloads) != 0 {
+		t.Errorf("expected no reload of unchanged files, got %v", reloads)
+	}
+
+	writeCert(t, certFile, keyFile, 2, epoch.Add(time.Minute))
+	if got := servedSerial(t, r); got != 2 {
+		t.Errorf("expected the rotated serial 2, got %d", got)
+	}
+	if len(reloads) != 1 || reloads[0] != nil {
+		t.Errorf("expected one successful reload, got %v", reloads)
+	}
+	if cert, _ := r.GetClientCertificate(nil); cert == nil || len(cert.Certificate) == 0 {
+		t.Error("expected the client certificate to be served too")
+	}
+}
+
+func TestCertReloader_KeepsCertificateOnBadFiles(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	epoch := time.Now().Add(-time.Hour)
+	writeCert(t, certFile, keyFile, 1, epoch)
+
+	var reloads []error
+	r, err := NewCertReloader(certFile, keyFile,
+		WithReloadInterval(time.Nanosecond),
+		WithReloadHook(func(err error) { reloads = append(reloads, err) }),
+	)
+	if err != nil {
+		t.Fatalf("NewCertReloader failed: %v", err)
+	}
+
+	// A rotation caught half way, with the key not yet written
+	writeFile(t, keyFile, []byte("not a key"), epoch.Add(time.Minute))
+	if got := servedSerial(t, r); got != 1 {
+		t.Errorf("expected the old serial 1 to be kept, got %d", got)
+	}
+	if len(reloads) != 1 || reloads[0] == nil {
+		t.Fatalf("expected a failed reload, got %v", reloads)
+	}
+
+	// The finished rotation is picked up at the next check
+	writeCert(t, certFile, keyFile, 2, epoch.Add(2*time.Minute))
+	if got := servedSerial(t, r); got != 2 {
+		t.Errorf("expected serial 2 once the rotation finished, got %d", got)
+	}
+}
+
+func TestCertReloader_ZeroIntervalLoadsOnce(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	epoch := time.Now().Add(-time.Hour)
+	writeCert(t, certFile, keyFile, 1, epoch)
+
+	r, err := NewCertReloader(certFile, keyFile, WithReloadInterval(0))
+	if err != nil {
+		t.Fatalf("NewCertReloader failed: %v", err)
+	}
+	writeCert(t, certFile, keyFile, 2, epoch.Add(time.Minute))
+	if got := servedSerial(t, r); got != 1 {
+		t.Errorf("expected reloading to be disabled, got serial %d", got)
+	}
+}
+
+func TestNewCertReloader_MissingFiles(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := NewCertReloader(filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")); err == nil {
+		t.Error("expected an error for missing files")
+	}
+}
@@ -8,36 +8,48 @@ import (
 )
 
 // LoadServerTLS creates a tls.Config for a gRPC server requiring client certs (mTLS).
-func LoadServerTLS(certFile, keyFile, caFile string) (*tls.Config, error) {
-	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+// The server's certificate is reloaded when its files change; see CertReloader.
+// The CA is read once, so rotating the CA itself needs a restart.
+func LoadServerTLS(certFile, keyFile, caFile string, opts ...Option) (*tls.Config, error) {
+	certs, err := NewCertReloader(certFile, keyFile, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("load key pair: %w", err)
+		return nil, err
 	}
 
-	caCert, err := os.ReadFile(caFile)
+	caPool, err := loadCAPool(caFile)
 	if err != nil {
-		return nil, fmt.Errorf("read CA cert: %w", err)
-	}
-
-	caPool := x509.NewCertPool()
-	if !caPool.AppendCertsFromPEM(caCert) {
-		return nil, fmt.Errorf("failed to parse CA certificate")
+		return nil, err
 	}
 
 	return &tls.Config{
-		Certificates: []tls.Certificate{cert},
-		ClientCAs:    caPool,
-		ClientAuth:   tls.RequireAndVerifyClientCert,
+		GetCertificate: certs.GetCertificate,
+		ClientCAs:      caPool,
+		ClientAuth:     tls.RequireAndVerifyClientCert,
 	}, nil
 }
 
 // LoadClientTLS creates a tls.Config for a gRPC client that presents a cert (mTLS).
-func LoadClientTLS(certFile, keyFile, caFile string) (*tls.Config, error) {
-	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+// The client's certificate is reloaded when its files change; see CertReloader.
+// The CA is read once, so rotating the CA itself needs a restart.
+func LoadClientTLS(certFile, keyFile, caFile string, opts ...Option) (*tls.Config, error) {
+	certs, err := NewCertReloader(certFile, keyFile, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("load key pair: %w", err)
+		return nil, err
 	}
 
+	caPool, err := loadCAPool(caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		GetClientCertificate: certs.GetClientCertificate,
+		RootCAs:              caPool,
+	}, nil
+}
+
+// loadCAPool reads the CA certificates peers are verified against
+func loadCAPool(caFile string) (*x509.CertPool, error) {
 	caCert, err := os.ReadFile(caFile)
 	if err != nil {
 		return nil, fmt.Errorf("read CA cert: %w", err)
@@ -47,9 +59,5 @@ func LoadClientTLS(certFile, keyFile, caFile string) (*tls.Config, error) {
 	if !caPool.AppendCertsFromPEM(caCert) {
 		return nil, fmt.Errorf("failed to parse CA certificate")
 	}
-
-	return &tls.Config{
-		Certificates: []tls.Certificate{cert},
-		RootCAs:      caPool,
-	}, nil
+	return caPool, nil
 }
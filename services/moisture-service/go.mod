@@ -0,0 +1,19 @@
+module github.com/quentinrf/plant-monitor/services/moisture-service
+
+go 1.25.0
+
+require (
+	github.com/mattn/go-sqlite3 v1.14.34
+	github.com/rs/zerolog v1.34.0
+	google.golang.org/grpc v1.79.1
+	google.golang.org/protobuf v1.36.11
+)
+
+require (
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.19 // indirect
+	golang.org/x/net v0.57.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	golang.org/x/text v0.40.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20251202230838-ff82c1b0f217 // indirect
+)
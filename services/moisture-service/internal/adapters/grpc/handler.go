@@ -0,0 +1,157 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/moisture-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/moisture-service/internal/ports"
+	"github.com/quentinrf/plant-monitor/services/moisture-service/pkg/pb"
+)
+
+// MoistureServiceHandler implements the gRPC MoistureService
+type MoistureServiceHandler struct {
+	pb.UnimplementedMoistureServiceServer
+	repo   domain.ReadingRepository
+	sensor ports.MoistureSensor
+}
+
+// NewMoistureServiceHandler creates a new gRPC handler
+func NewMoistureServiceHandler(repo domain.ReadingRepository, sensor ports.MoistureSensor) *MoistureServiceHandler {
+	return &MoistureServiceHandler{
+		repo:   repo,
+		sensor: sensor,
+	}
+}
+
+// GetCurrentMoisture returns the most recent reading, reading the sensor if
+// nothing has been recorded yet
+func (h *MoistureServiceHandler) GetCurrentMoisture(ctx context.Context, req *pb.GetCurrentMoistureRequest) (*pb.GetCurrentMoistureResponse, error) {
+	log.Info().Msg("GetCurrentMoisture called")
+
+	reading, err := h.repo.GetLatestReading(ctx)
+	if errors.Is(err, domain.ErrReadingNotFound) {
+		log.Info().Msg("no readings in database, reading sensor")
+
+		percent, err := h.sensor.ReadPercent(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to read sensor")
+			return nil, status.Error(codes.Unavailable, "failed to read sensor")
+		}
+
+		reading, err = domain.NewMoistureReading(percent)
+		if err != nil {
+			log.Error().Err(err).Float64("percent", percent).Msg("sensor returned an invalid reading")
+			return nil, status.Error(codes.Internal, "sensor returned an invalid reading")
+		}
+
+		// Save for next time
+		if err := h.repo.SaveReading(ctx, reading); err != nil {
+			log.Error().Err(err).Msg("failed to save reading")
+			// Don't fail - we still have the reading
+		}
+	} else if err != nil {
+		log.Error().Err(err).Msg("failed to get latest reading")
+		return nil, status.Error(codes.Internal, "failed to get reading")
+	}
+
+	return &pb.GetCurrentMoistureResponse{
+		Reading: convertReadingToProto(reading),
+	}, nil
+}
+
+// GetHistory returns readings within time range with statistics
+func (h *MoistureServiceHandler) GetHistory(ctx context.Context, req *pb.GetHistoryRequest) (*pb.GetHistoryResponse, error) {
+	log.Info().
+		Int64("start_ms", req.StartTimeMs).
+		Int64("end_ms", req.EndTimeMs).
+		Msg("GetHistory called")
+
+	start, end := time.UnixMilli(req.StartTimeMs), time.UnixMilli(req.EndTimeMs)
+	if end.Before(start) {
+		return nil, status.Error(codes.InvalidArgument, "end_time_ms cannot be before start_time_ms")
+	}
+
+	readings, err := h.repo.GetReadingsInRange(ctx, start, end)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to get readings")
+		return nil, status.Error(codes.Internal, "failed to get readings")
+	}
+
+	// Convert to protobuf
+	pbReadings := make([]*pb.MoistureReading, len(readings))
+	for i, r := range readings {
+		pbReadings[i] = convertReadingToProto(r)
+	}
+
+	stats := calculateStatistics(readings)
+	return &pb.GetHistoryResponse{
+		Readings:       pbReadings,
+		AveragePercent: stats.average,
+		MinPercent:     stats.min,
+		MaxPercent:     stats.max,
+	}, nil
+}
+
+// RecordReading manually records a reading (useful for testing)
+func (h *MoistureServiceHandler) RecordReading(ctx context.Context, req *pb.RecordReadingRequest) (*pb.RecordReadingResponse, error) {
+	log.Info().Float64("percent", req.Percent).Msg("RecordReading called")
+
+	reading, err := domain.NewMoistureReading(req.Percent)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := h.repo.SaveReading(ctx, reading); err != nil {
+		log.Error().Err(err).Msg("failed to save reading")
+		return nil, status.Error(codes.Internal, "failed to save reading")
+	}
+
+	return &pb.RecordReadingResponse{
+		Reading: convertReadingToProto(reading),
+	}, nil
+}
+
+// convertReadingToProto converts domain model to protobuf
+func convertReadingToProto(r *domain.MoistureReading) *pb.MoistureReading {
+	return &pb.MoistureReading{
+		Id:          r.ID,
+		Percent:     r.Percent,
+		TimestampMs: r.Timestamp.UnixMilli(),
+		Category:    r.MoistureCategory(),
+	}
+}
+
+// statistics holds calculated statistics
+type statistics struct {
+	average float64
+	min     float64
+	max     float64
+}
+
+// calculateStatistics computes stats for a set of readings; all zero if
+// there are none
+func calculateStatistics(readings []*domain.MoistureReading) statistics {
+	if len(readings) == 0 {
+		return statistics{}
+	}
+
+	var sum float64
+	lo, hi := readings[0].Percent, readings[0].Percent
+	for _, r := range readings {
+		sum += r.Percent
+		lo = min(lo, r.Percent)
+		hi = max(hi, r.Percent)
+	}
+
+	return statistics{
+		average: sum / float64(len(readings)),
+		min:     lo,
+		max:     hi,
+	}
+}
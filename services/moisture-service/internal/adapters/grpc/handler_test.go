@@ -0,0 +1,132 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/moisture-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/moisture-service/internal/adapters/mock"
+	"github.com/quentinrf/plant-monitor/services/moisture-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/moisture-service/pkg/pb"
+)
+
+// startTestServerWithRepo runs the handler on a loopback listener and
+// returns a client connected to it
+func startTestServerWithRepo(t *testing.T, repo domain.ReadingRepository) pb.MoistureServiceClient {
+	t.Helper()
+
+	sensor := mock.NewFakeSensor(45.0, 0) // deterministic: always 45%
+	server := grpc.NewServer()
+	pb.RegisterMoistureServiceServer(server, NewMoistureServiceHandler(repo, sensor))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewMoistureServiceClient(conn)
+}
+
+func TestGetCurrentMoisture_ReadsSensorWhenEmpty(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	client := startTestServerWithRepo(t, repo)
+	ctx := context.Background()
+
+	resp, err := client.GetCurrentMoisture(ctx, &pb.GetCurrentMoistureRequest{})
+	if err != nil {
+		t.Fatalf("GetCurrentMoisture failed: %v", err)
+	}
+	if resp.Reading.Percent != 45 || resp.Reading.Category != "Moist" {
+		t.Errorf("expected 45%% Moist, got %v", resp.Reading)
+	}
+
+	// Saved for next time
+	if _, err := repo.GetLatestReading(ctx); err != nil {
+		t.Errorf("expected the live reading to be saved, got %v", err)
+	}
+}
+
+func TestRecordReading(t *testing.T) {
+	client := startTestServerWithRepo(t, memory.NewReadingRepository())
+	ctx := context.Background()
+
+	resp, err := client.RecordReading(ctx, &pb.RecordReadingRequest{Percent: 12})
+	if err != nil {
+		t.Fatalf("RecordReading failed: %v", err)
+	}
+	if resp.Reading.Id == 0 || resp.Reading.Category != "Dry" {
+		t.Errorf("expected a saved Dry reading, got %v", resp.Reading)
+	}
+
+	current, err := client.GetCurrentMoisture(ctx, &pb.GetCurrentMoistureRequest{})
+	if err != nil {
+		t.Fatalf("GetCurrentMoisture failed: %v", err)
+	}
+	if current.Reading.Id != resp.Reading.Id {
+		t.Errorf("expected current reading %d, got %d", resp.Reading.Id, current.Reading.Id)
+	}
+
+	for _, percent := range []float64{-1, 101} {
+		_, err := client.RecordReading(ctx, &pb.RecordReadingRequest{Percent: percent})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected InvalidArgument for %v%%, got %v", percent, err)
+		}
+	}
+}
+
+func TestGetHistory(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	client := startTestServerWithRepo(t, repo)
+	ctx := context.Background()
+
+	now := time.Now()
+	for i, percent := range []float64{60, 40, 20} {
+		r, _ := domain.NewMoistureReadingAt(percent, now.Add(time.Duration(i-3)*time.Hour))
+		_ = repo.SaveReading(ctx, r)
+	}
+
+	resp, err := client.GetHistory(ctx, &pb.GetHistoryRequest{
+		StartTimeMs: now.Add(-4 * time.Hour).UnixMilli(),
+		EndTimeMs:   now.UnixMilli(),
+	})
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(resp.Readings) != 3 {
+		t.Fatalf("expected 3 readings, got %d", len(resp.Readings))
+	}
+	if resp.AveragePercent != 40 || resp.MinPercent != 20 || resp.MaxPercent != 60 {
+		t.Errorf("expected 40/20/60, got %v/%v/%v", resp.AveragePercent, resp.MinPercent, resp.MaxPercent)
+	}
+
+	// Empty range
+	resp, err = client.GetHistory(ctx, &pb.GetHistoryRequest{
+		StartTimeMs: now.Add(-48 * time.Hour).UnixMilli(),
+		EndTimeMs:   now.Add(-47 * time.Hour).UnixMilli(),
+	})
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(resp.Readings) != 0 || resp.AveragePercent != 0 {
+		t.Errorf("expected no readings and zero statistics, got %d readings", len(resp.Readings))
+	}
+
+	_, err = client.GetHistory(ctx, &pb.GetHistoryRequest{StartTimeMs: 2000, EndTimeMs: 1000})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for a reversed range, got %v", err)
+	}
+}
@@ -0,0 +1,34 @@
+package mock
+
+import (
+	"context"
+	"math/rand"
+)
+
+// FakeSensor simulates a soil moisture sensor for development
+// This implements the ports.MoistureSensor interface
+type FakeSensor struct {
+	baseValue float64
+	variation float64
+}
+
+// NewFakeSensor creates a sensor that returns realistic values
+// baseValue: average percent (e.g., 45 for recently watered soil)
+// variation: +/- range (e.g., 5 means 40-50)
+func NewFakeSensor(baseValue, variation float64) *FakeSensor {
+	return &FakeSensor{
+		baseValue: baseValue,
+		variation: variation,
+	}
+}
+
+// ReadPercent returns a simulated moisture reading, clamped to 0-100
+func (s *FakeSensor) ReadPercent(ctx context.Context) (float64, error) {
+	variance := (rand.Float64() - 0.5) * 2 * s.variation
+	return min(max(s.baseValue+variance, 0), 100), nil
+}
+
+// Close is a no-op for fake sensor
+func (s *FakeSensor) Close() error {
+	return nil
+}
@@ -0,0 +1,84 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/moisture-service/internal/domain"
+)
+
+func newTestRepo(t *testing.T) *ReadingRepository {
+	t.Helper()
+	repo, err := NewReadingRepository(filepath.Join(t.TempDir(), "moisture.db"))
+	if err != nil {
+		t.Fatalf("NewReadingRepository failed: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return repo
+}
+
+func TestReadingRepository_SaveAndGet(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	if _, err := repo.GetLatestReading(ctx); !errors.Is(err, domain.ErrReadingNotFound) {
+		t.Errorf("expected ErrReadingNotFound from an empty repository, got %v", err)
+	}
+
+	now := time.Now()
+	for i, percent := range []float64{40, 35, 30} {
+		r, _ := domain.NewMoistureReadingAt(percent, now.Add(time.Duration(i-3)*time.Hour))
+		if err := repo.SaveReading(ctx, r); err != nil {
+			t.Fatalf("SaveReading failed: %v", err)
+		}
+	}
+
+	got, err := repo.GetReading(ctx, 2)
+	if err != nil {
+		t.Fatalf("GetReading failed: %v", err)
+	}
+	if got.Percent != 35 || got.Timestamp.UnixMilli() != now.Add(-2*time.Hour).UnixMilli() {
+		t.Errorf("unexpected reading %+v", got)
+	}
+	if _, err := repo.GetReading(ctx, 99); !errors.Is(err, domain.ErrReadingNotFound) {
+		t.Errorf("expected ErrReadingNotFound, got %v", err)
+	}
+
+	latest, err := repo.GetLatestReading(ctx)
+	if err != nil || latest.Percent != 30 {
+		t.Errorf("expected latest at 30%%, got %+v, %v", latest, err)
+	}
+
+	// [start, end): the reading exactly at end is excluded
+	inRange, err := repo.GetReadingsInRange(ctx, now.Add(-3*time.Hour), now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("GetReadingsInRange failed: %v", err)
+	}
+	if len(inRange) != 2 || inRange[0].Percent != 40 || inRange[1].Percent != 35 {
+		t.Errorf("expected readings at 40%% then 35%%, got %d readings", len(inRange))
+	}
+}
+
+func TestReadingRepository_DeleteOldReadings(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	old, _ := domain.NewMoistureReadingAt(50, time.Now().Add(-48*time.Hour))
+	recent, _ := domain.NewMoistureReading(45)
+	_ = repo.SaveReading(ctx, old)
+	_ = repo.SaveReading(ctx, recent)
+
+	deleted, err := repo.DeleteOldReadings(ctx, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("DeleteOldReadings failed: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("expected 1 deleted, got %d", deleted)
+	}
+	if _, err := repo.GetReading(ctx, recent.ID); err != nil {
+		t.Errorf("expected recent reading kept, got %v", err)
+	}
+}
@@ -0,0 +1,14 @@
+package domain
+
+import "errors"
+
+var (
+	// ErrInvalidPercent indicates a moisture value outside 0-100
+	ErrInvalidPercent = errors.New("moisture must be between 0 and 100 percent")
+
+	// ErrReadingNotFound indicates requested reading doesn't exist
+	ErrReadingNotFound = errors.New("reading not found")
+
+	// ErrSensorUnavailable indicates sensor cannot be read
+	ErrSensorUnavailable = errors.New("sensor unavailable")
+)
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// MoistureReading represents a single soil moisture measurement
+// This is pure domain logic - no database, no gRPC, just business concepts
+type MoistureReading struct {
+	ID        int64
+	Percent   float64 // volumetric water content, 0-100
+	Timestamp time.Time
+}
+
+// NewMoistureReading creates a new reading taken now, with validation
+func NewMoistureReading(percent float64) (*MoistureReading, error) {
+	return NewMoistureReadingAt(percent, time.Now())
+}
+
+// NewMoistureReadingAt creates a new reading taken at the given time
+func NewMoistureReadingAt(percent float64, at time.Time) (*MoistureReading, error) {
+	// Business rule: moisture is a percentage
+	if math.IsNaN(percent) || percent < 0 || percent > 100 {
+		return nil, ErrInvalidPercent
+	}
+
+	return &MoistureReading{
+		Percent:   percent,
+		Timestamp: at,
+	}, nil
+}
+
+// IsDry returns true if the soil needs watering
+// Business logic: < 30% is dry for most potted plants
+func (r *MoistureReading) IsDry() bool {
+	return r.Percent < 30
+}
+
+// IsWet returns true if the soil is saturated
+// Business logic: >= 70% risks root rot if it persists
+func (r *MoistureReading) IsWet() bool {
+	return r.Percent >= 70
+}
+
+// MoistureCategory returns human-readable category
+func (r *MoistureReading) MoistureCategory() string {
+	if r.IsDry() {
+		return "Dry"
+	} else if r.IsWet() {
+		return "Wet"
+	}
+	return "Moist"
+}
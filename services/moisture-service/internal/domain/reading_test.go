@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNewMoistureReading(t *testing.T) {
+	tests := []struct {
+		name    string
+		percent float64
+		wantErr bool
+	}{
+		{"bone dry", 0, false},
+		{"typical", 45.5, false},
+		{"saturated", 100, false},
+		{"negative", -1, true},
+		{"above 100", 100.1, true},
+		{"NaN", math.NaN(), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reading, err := NewMoistureReading(tt.percent)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidPercent) {
+					t.Errorf("expected ErrInvalidPercent, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if reading.Percent != tt.percent || reading.Timestamp.IsZero() {
+				t.Errorf("unexpected reading %+v", reading)
+			}
+		})
+	}
+}
+
+func TestMoistureCategory(t *testing.T) {
+	tests := []struct {
+		percent float64
+		want    string
+	}{
+		{0, "Dry"},
+		{29.9, "Dry"},
+		{30, "Moist"},
+		{69.9, "Moist"},
+		{70, "Wet"},
+		{100, "Wet"},
+	}
+	for _, tt := range tests {
+		r := &MoistureReading{Percent: tt.percent}
+		if got := r.MoistureCategory(); got != tt.want {
+			t.Errorf("MoistureCategory(%v) = %q, want %q", tt.percent, got, tt.want)
+		}
+	}
+}
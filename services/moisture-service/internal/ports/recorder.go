@@ -0,0 +1,120 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/quentinrf/plant-monitor/services/moisture-service/internal/domain"
+)
+
+// DefaultRetention is how long readings are kept unless overridden
+const DefaultRetention = 30 * 24 * time.Hour
+
+// cleanupInterval is how often the recorder deletes expired readings
+const cleanupInterval = 24 * time.Hour
+
+// Recorder handles periodic sensor reading and storage
+type Recorder struct {
+	sensor    MoistureSensor
+	repo      domain.ReadingRepository
+	interval  time.Duration
+	retention time.Duration
+}
+
+// RecorderOption configures optional Recorder behaviour
+type RecorderOption func(*Recorder)
+
+// WithRetention sets how long readings are kept (0 keeps them forever)
+func WithRetention(d time.Duration) RecorderOption {
+	return func(r *Recorder) {
+		r.retention = d
+	}
+}
+
+// NewRecorder creates a new background recorder
+func NewRecorder(sensor MoistureSensor, repo domain.ReadingRepository, interval time.Duration, opts ...RecorderOption) *Recorder {
+	r := &Recorder{
+		sensor:    sensor,
+		repo:      repo,
+		interval:  interval,
+		retention: DefaultRetention,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Start begins periodic sensor reading
+// This runs in a goroutine until context is cancelled
+func (r *Recorder) Start(ctx context.Context) {
+	log.Info().
+		Dur("interval", r.interval).
+		Msg("starting background recorder")
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	cleanupTicker := time.NewTicker(cleanupInterval)
+	defer cleanupTicker.Stop()
+
+	// Record immediately on start
+	r.recordOnce(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			r.recordOnce(ctx)
+
+		case <-cleanupTicker.C:
+			r.cleanup(ctx)
+
+		case <-ctx.Done():
+			log.Info().Msg("stopping background recorder")
+			return
+		}
+	}
+}
+
+// recordOnce reads sensor and saves to repository
+func (r *Recorder) recordOnce(ctx context.Context) error {
+	log.Debug().Msg("reading sensor")
+
+	percent, err := r.sensor.ReadPercent(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to read sensor")
+		return err
+	}
+
+	reading, err := domain.NewMoistureReading(percent)
+	if err != nil {
+		log.Error().Err(err).Float64("percent", percent).Msg("failed to create reading")
+		return err
+	}
+
+	if err := r.repo.SaveReading(ctx, reading); err != nil {
+		log.Error().Err(err).Msg("failed to save reading")
+		return err
+	}
+
+	log.Info().
+		Float64("percent", percent).
+		Str("category", reading.MoistureCategory()).
+		Msg("recorded moisture reading")
+	return nil
+}
+
+// cleanup deletes readings past the retention period
+func (r *Recorder) cleanup(ctx context.Context) {
+	if r.retention <= 0 {
+		return
+	}
+	deleted, err := r.repo.DeleteOldReadings(ctx, r.retention)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to delete old readings")
+		return
+	}
+	log.Info().Int64("deleted", deleted).Dur("retention", r.retention).Msg("deleted old readings")
+}
@@ -0,0 +1,48 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/quentinrf/plant-monitor/services/moisture-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/moisture-service/internal/adapters/mock"
+	"github.com/quentinrf/plant-monitor/services/moisture-service/internal/domain"
+)
+
+// fixedSensor always returns percent
+type fixedSensor struct{ percent float64 }
+
+func (s fixedSensor) ReadPercent(ctx context.Context) (float64, error) { return s.percent, nil }
+func (s fixedSensor) Close() error                                     { return nil }
+
+func TestRecordOnce_SavesReading(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	recorder := NewRecorder(mock.NewFakeSensor(45, 0), repo, 0)
+	ctx := context.Background()
+
+	if err := recorder.recordOnce(ctx); err != nil {
+		t.Fatalf("recordOnce failed: %v", err)
+	}
+
+	latest, err := repo.GetLatestReading(ctx)
+	if err != nil {
+		t.Fatalf("GetLatestReading failed: %v", err)
+	}
+	if latest.Percent != 45 {
+		t.Errorf("expected 45%%, got %v", latest.Percent)
+	}
+}
+
+func TestRecordOnce_RejectsOutOfRangeSensor(t *testing.T) {
+	repo := memory.NewReadingRepository()
+	recorder := NewRecorder(fixedSensor{percent: 120}, repo, 0)
+	ctx := context.Background()
+
+	if err := recorder.recordOnce(ctx); !errors.Is(err, domain.ErrInvalidPercent) {
+		t.Errorf("expected ErrInvalidPercent, got %v", err)
+	}
+	if _, err := repo.GetLatestReading(ctx); !errors.Is(err, domain.ErrReadingNotFound) {
+		t.Errorf("expected nothing saved, got %v", err)
+	}
+}
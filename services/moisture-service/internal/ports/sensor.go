@@ -0,0 +1,13 @@
+package ports
+
+import "context"
+
+// MoistureSensor defines how to read soil moisture
+// This is a PORT - adapters (capacitive probe, Mock) will implement it
+type MoistureSensor interface {
+	// ReadPercent returns current volumetric water content, 0-100
+	ReadPercent(ctx context.Context) (float64, error)
+
+	// Close releases any resources
+	Close() error
+}
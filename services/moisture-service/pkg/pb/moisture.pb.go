@@ -0,0 +1,476 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.4
+// source: api/proto/moisture.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetCurrentMoistureRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCurrentMoistureRequest) Reset() {
+	*x = GetCurrentMoistureRequest{}
+	mi := &file_api_proto_moisture_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCurrentMoistureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrentMoistureRequest) ProtoMessage() {}
+
+func (x *GetCurrentMoistureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_moisture_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrentMoistureRequest.ProtoReflect.Descriptor instead.
+func (*GetCurrentMoistureRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_moisture_proto_rawDescGZIP(), []int{0}
+}
+
+type GetCurrentMoistureResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reading       *MoistureReading       `protobuf:"bytes,1,opt,name=reading,proto3" json:"reading,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCurrentMoistureResponse) Reset() {
+	*x = GetCurrentMoistureResponse{}
+	mi := &file_api_proto_moisture_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCurrentMoistureResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrentMoistureResponse) ProtoMessage() {}
+
+func (x *GetCurrentMoistureResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_moisture_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrentMoistureResponse.ProtoReflect.Descriptor instead.
+func (*GetCurrentMoistureResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_moisture_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetCurrentMoistureResponse) GetReading() *MoistureReading {
+	if x != nil {
+		return x.Reading
+	}
+	return nil
+}
+
+type GetHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Half-open range [start_time_ms, end_time_ms), in Unix milliseconds
+	StartTimeMs   int64 `protobuf:"varint,1,opt,name=start_time_ms,json=startTimeMs,proto3" json:"start_time_ms,omitempty"`
+	EndTimeMs     int64 `protobuf:"varint,2,opt,name=end_time_ms,json=endTimeMs,proto3" json:"end_time_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHistoryRequest) Reset() {
+	*x = GetHistoryRequest{}
+	mi := &file_api_proto_moisture_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryRequest) ProtoMessage() {}
+
+func (x *GetHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_moisture_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_moisture_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetHistoryRequest) GetStartTimeMs() int64 {
+	if x != nil {
+		return x.StartTimeMs
+	}
+	return 0
+}
+
+func (x *GetHistoryRequest) GetEndTimeMs() int64 {
+	if x != nil {
+		return x.EndTimeMs
+	}
+	return 0
+}
+
+type GetHistoryResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Readings []*MoistureReading     `protobuf:"bytes,1,rep,name=readings,proto3" json:"readings,omitempty"`
+	// Statistics; zero when there are no readings
+	AveragePercent float64 `protobuf:"fixed64,2,opt,name=average_percent,json=averagePercent,proto3" json:"average_percent,omitempty"`
+	MinPercent     float64 `protobuf:"fixed64,3,opt,name=min_percent,json=minPercent,proto3" json:"min_percent,omitempty"`
+	MaxPercent     float64 `protobuf:"fixed64,4,opt,name=max_percent,json=maxPercent,proto3" json:"max_percent,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetHistoryResponse) Reset() {
+	*x = GetHistoryResponse{}
+	mi := &file_api_proto_moisture_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryResponse) ProtoMessage() {}
+
+func (x *GetHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_moisture_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_moisture_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetHistoryResponse) GetReadings() []*MoistureReading {
+	if x != nil {
+		return x.Readings
+	}
+	return nil
+}
+
+func (x *GetHistoryResponse) GetAveragePercent() float64 {
+	if x != nil {
+		return x.AveragePercent
+	}
+	return 0
+}
+
+func (x *GetHistoryResponse) GetMinPercent() float64 {
+	if x != nil {
+		return x.MinPercent
+	}
+	return 0
+}
+
+func (x *GetHistoryResponse) GetMaxPercent() float64 {
+	if x != nil {
+		return x.MaxPercent
+	}
+	return 0
+}
+
+type RecordReadingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Percent       float64                `protobuf:"fixed64,1,opt,name=percent,proto3" json:"percent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordReadingRequest) Reset() {
+	*x = RecordReadingRequest{}
+	mi := &file_api_proto_moisture_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordReadingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordReadingRequest) ProtoMessage() {}
+
+func (x *RecordReadingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_moisture_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordReadingRequest.ProtoReflect.Descriptor instead.
+func (*RecordReadingRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_moisture_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RecordReadingRequest) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+type RecordReadingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reading       *MoistureReading       `protobuf:"bytes,1,opt,name=reading,proto3" json:"reading,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordReadingResponse) Reset() {
+	*x = RecordReadingResponse{}
+	mi := &file_api_proto_moisture_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordReadingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordReadingResponse) ProtoMessage() {}
+
+func (x *RecordReadingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_moisture_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordReadingResponse.ProtoReflect.Descriptor instead.
+func (*RecordReadingResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_moisture_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RecordReadingResponse) GetReading() *MoistureReading {
+	if x != nil {
+		return x.Reading
+	}
+	return nil
+}
+
+type MoistureReading struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Percent       float64                `protobuf:"fixed64,2,opt,name=percent,proto3" json:"percent,omitempty"`                           // volumetric water content, 0-100
+	TimestampMs   int64                  `protobuf:"varint,3,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"` // Unix milliseconds
+	Category      string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`                           // "Dry", "Moist", "Wet"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoistureReading) Reset() {
+	*x = MoistureReading{}
+	mi := &file_api_proto_moisture_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoistureReading) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoistureReading) ProtoMessage() {}
+
+func (x *MoistureReading) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_moisture_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoistureReading.ProtoReflect.Descriptor instead.
+func (*MoistureReading) Descriptor() ([]byte, []int) {
+	return file_api_proto_moisture_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MoistureReading) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MoistureReading) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *MoistureReading) GetTimestampMs() int64 {
+	if x != nil {
+		return x.TimestampMs
+	}
+	return 0
+}
+
+func (x *MoistureReading) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+var File_api_proto_moisture_proto protoreflect.FileDescriptor
+
+const file_api_proto_moisture_proto_rawDesc = "" +
+	"\n" +
+	"\x18api/proto/moisture.proto\x12\vmoisture.v1\"\x1b\n" +
+	"\x19GetCurrentMoistureRequest\"T\n" +
+	"\x1aGetCurrentMoistureResponse\x126\n" +
+	"\areading\x18\x01 \x01(\v2\x1c.moisture.v1.MoistureReadingR\areading\"W\n" +
+	"\x11GetHistoryRequest\x12\"\n" +
+	"\rstart_time_ms\x18\x01 \x01(\x03R\vstartTimeMs\x12\x1e\n" +
+	"\vend_time_ms\x18\x02 \x01(\x03R\tendTimeMs\"\xb9\x01\n" +
+	"\x12GetHistoryResponse\x128\n" +
+	"\breadings\x18\x01 \x03(\v2\x1c.moisture.v1.MoistureReadingR\breadings\x12'\n" +
+	"\x0faverage_percent\x18\x02 \x01(\x01R\x0eaveragePercent\x12\x1f\n" +
+	"\vmin_percent\x18\x03 \x01(\x01R\n" +
+	"minPercent\x12\x1f\n" +
+	"\vmax_percent\x18\x04 \x01(\x01R\n" +
+	"maxPercent\"0\n" +
+	"\x14RecordReadingRequest\x12\x18\n" +
+	"\apercent\x18\x01 \x01(\x01R\apercent\"O\n" +
+	"\x15RecordReadingResponse\x126\n" +
+	"\areading\x18\x01 \x01(\v2\x1c.moisture.v1.MoistureReadingR\areading\"z\n" +
+	"\x0fMoistureReading\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x18\n" +
+	"\apercent\x18\x02 \x01(\x01R\apercent\x12!\n" +
+	"\ftimestamp_ms\x18\x03 \x01(\x03R\vtimestampMs\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory2\x9f\x02\n" +
+	"\x0fMoistureService\x12e\n" +
+	"\x12GetCurrentMoisture\x12&.moisture.v1.GetCurrentMoistureRequest\x1a'.moisture.v1.GetCurrentMoistureResponse\x12M\n" +
+	"\n" +
+	"GetHistory\x12\x1e.moisture.v1.GetHistoryRequest\x1a\x1f.moisture.v1.GetHistoryResponse\x12V\n" +
+	"\rRecordReading\x12!.moisture.v1.RecordReadingRequest\x1a\".moisture.v1.RecordReadingResponseBEZCgithub.com/quentinrf/plant-monitor/services/moisture-service/pkg/pbb\x06proto3"
+
+var (
+	file_api_proto_moisture_proto_rawDescOnce sync.Once
+	file_api_proto_moisture_proto_rawDescData []byte
+)
+
+func file_api_proto_moisture_proto_rawDescGZIP() []byte {
+	file_api_proto_moisture_proto_rawDescOnce.Do(func() {
+		file_api_proto_moisture_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_moisture_proto_rawDesc), len(file_api_proto_moisture_proto_rawDesc)))
+	})
+	return file_api_proto_moisture_proto_rawDescData
+}
+
+var file_api_proto_moisture_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_api_proto_moisture_proto_goTypes = []any{
+	(*GetCurrentMoistureRequest)(nil),  // 0: moisture.v1.GetCurrentMoistureRequest
+	(*GetCurrentMoistureResponse)(nil), // 1: moisture.v1.GetCurrentMoistureResponse
+	(*GetHistoryRequest)(nil),          // 2: moisture.v1.GetHistoryRequest
+	(*GetHistoryResponse)(nil),         // 3: moisture.v1.GetHistoryResponse
+	(*RecordReadingRequest)(nil),       // 4: moisture.v1.RecordReadingRequest
+	(*RecordReadingResponse)(nil),      // 5: moisture.v1.RecordReadingResponse
+	(*MoistureReading)(nil),            // 6: moisture.v1.MoistureReading
+}
+var file_api_proto_moisture_proto_depIdxs = []int32{
+	6, // 0: moisture.v1.GetCurrentMoistureResponse.reading:type_name -> moisture.v1.MoistureReading
+	6, // 1: moisture.v1.GetHistoryResponse.readings:type_name -> moisture.v1.MoistureReading
+	6, // 2: moisture.v1.RecordReadingResponse.reading:type_name -> moisture.v1.MoistureReading
+	0, // 3: moisture.v1.MoistureService.GetCurrentMoisture:input_type -> moisture.v1.GetCurrentMoistureRequest
+	2, // 4: moisture.v1.MoistureService.GetHistory:input_type -> moisture.v1.GetHistoryRequest
+	4, // 5: moisture.v1.MoistureService.RecordReading:input_type -> moisture.v1.RecordReadingRequest
+	1, // 6: moisture.v1.MoistureService.GetCurrentMoisture:output_type -> moisture.v1.GetCurrentMoistureResponse
+	3, // 7: moisture.v1.MoistureService.GetHistory:output_type -> moisture.v1.GetHistoryResponse
+	5, // 8: moisture.v1.MoistureService.RecordReading:output_type -> moisture.v1.RecordReadingResponse
+	6, // [6:9] is the sub-list for method output_type
+	3, // [3:6] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_moisture_proto_init() }
+func file_api_proto_moisture_proto_init() {
+	if File_api_proto_moisture_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_moisture_proto_rawDesc), len(file_api_proto_moisture_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_proto_moisture_proto_goTypes,
+		DependencyIndexes: file_api_proto_moisture_proto_depIdxs,
+		MessageInfos:      file_api_proto_moisture_proto_msgTypes,
+	}.Build()
+	File_api_proto_moisture_proto = out.File
+	file_api_proto_moisture_proto_goTypes = nil
+	file_api_proto_moisture_proto_depIdxs = nil
+}
@@ -0,0 +1,207 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.1
+// - protoc             v6.33.4
+// source: api/proto/moisture.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MoistureService_GetCurrentMoisture_FullMethodName = "/moisture.v1.MoistureService/GetCurrentMoisture"
+	MoistureService_GetHistory_FullMethodName         = "/moisture.v1.MoistureService/GetHistory"
+	MoistureService_RecordReading_FullMethodName      = "/moisture.v1.MoistureService/RecordReading"
+)
+
+// MoistureServiceClient is the client API for MoistureService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MoistureService provides soil moisture monitoring
+type MoistureServiceClient interface {
+	// GetCurrentMoisture returns the most recent soil moisture reading
+	GetCurrentMoisture(ctx context.Context, in *GetCurrentMoistureRequest, opts ...grpc.CallOption) (*GetCurrentMoistureResponse, error)
+	// GetHistory returns soil moisture readings within a time range
+	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
+	// RecordReading manually records a soil moisture reading (for testing)
+	RecordReading(ctx context.Context, in *RecordReadingRequest, opts ...grpc.CallOption) (*RecordReadingResponse, error)
+}
+
+type moistureServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMoistureServiceClient(cc grpc.ClientConnInterface) MoistureServiceClient {
+	return &moistureServiceClient{cc}
+}
+
+func (c *moistureServiceClient) GetCurrentMoisture(ctx context.Context, in *GetCurrentMoistureRequest, opts ...grpc.CallOption) (*GetCurrentMoistureResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCurrentMoistureResponse)
+	err := c.cc.Invoke(ctx, MoistureService_GetCurrentMoisture_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *moistureServiceClient) GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHistoryResponse)
+	err := c.cc.Invoke(ctx, MoistureService_GetHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *moistureServiceClient) RecordReading(ctx context.Context, in *RecordReadingRequest, opts ...grpc.CallOption) (*RecordReadingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordReadingResponse)
+	err := c.cc.Invoke(ctx, MoistureService_RecordReading_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MoistureServiceServer is the server API for MoistureService service.
+// All implementations must embed UnimplementedMoistureServiceServer
+// for forward compatibility.
+//
+// MoistureService provides soil moisture monitoring
+type MoistureServiceServer interface {
+	// GetCurrentMoisture returns the most recent soil moisture reading
+	GetCurrentMoisture(context.Context, *GetCurrentMoistureRequest) (*GetCurrentMoistureResponse, error)
+	// GetHistory returns soil moisture readings within a time range
+	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
+	// RecordReading manually records a soil moisture reading (for testing)
+	RecordReading(context.Context, *RecordReadingRequest) (*RecordReadingResponse, error)
+	mustEmbedUnimplementedMoistureServiceServer()
+}
+
+// UnimplementedMoistureServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMoistureServiceServer struct{}
+
+func (UnimplementedMoistureServiceServer) GetCurrentMoisture(context.Context, *GetCurrentMoistureRequest) (*GetCurrentMoistureResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCurrentMoisture not implemented")
+}
+func (UnimplementedMoistureServiceServer) GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetHistory not implemented")
+}
+func (UnimplementedMoistureServiceServer) RecordReading(context.Context, *RecordReadingRequest) (*RecordReadingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RecordReading not implemented")
+}
+func (UnimplementedMoistureServiceServer) mustEmbedUnimplementedMoistureServiceServer() {}
+func (UnimplementedMoistureServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeMoistureServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MoistureServiceServer will
+// result in compilation errors.
+type UnsafeMoistureServiceServer interface {
+	mustEmbedUnimplementedMoistureServiceServer()
+}
+
+func RegisterMoistureServiceServer(s grpc.ServiceRegistrar, srv MoistureServiceServer) {
+	// If the following call panics, it indicates UnimplementedMoistureServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MoistureService_ServiceDesc, srv)
+}
+
+func _MoistureService_GetCurrentMoisture_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCurrentMoistureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MoistureServiceServer).GetCurrentMoisture(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MoistureService_GetCurrentMoisture_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MoistureServiceServer).GetCurrentMoisture(ctx, req.(*GetCurrentMoistureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MoistureService_GetHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MoistureServiceServer).GetHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MoistureService_GetHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MoistureServiceServer).GetHistory(ctx, req.(*GetHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MoistureService_RecordReading_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordReadingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MoistureServiceServer).RecordReading(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MoistureService_RecordReading_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MoistureServiceServer).RecordReading(ctx, req.(*RecordReadingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MoistureService_ServiceDesc is the grpc.ServiceDesc for MoistureService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MoistureService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "moisture.v1.MoistureService",
+	HandlerType: (*MoistureServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCurrentMoisture",
+			Handler:    _MoistureService_GetCurrentMoisture_Handler,
+		},
+		{
+			MethodName: "GetHistory",
+			Handler:    _MoistureService_GetHistory_Handler,
+		},
+		{
+			MethodName: "RecordReading",
+			Handler:    _MoistureService_RecordReading_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/proto/moisture.proto",
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"net"
 	"os"
@@ -16,6 +17,9 @@ import (
 	"google.golang.org/grpc/reflection"
 
 	grpcAdapter "github.com/quentinrf/plant-monitor/services/plant-service/internal/adapters/grpc"
+	"github.com/quentinrf/plant-monitor/services/plant-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/plant-service/internal/adapters/sqlite"
+	"github.com/quentinrf/plant-monitor/services/plant-service/internal/ports"
 	"github.com/quentinrf/plant-monitor/services/plant-service/pkg/pb"
 	"github.com/quentinrf/plant-monitor/services/plant-service/pkg/tlsconfig"
 )
@@ -27,22 +31,25 @@ func main() {
 	log.Info().Msg("starting plant-service")
 
 	config := loadConfig()
+	if err := config.checkEnv(); err != nil {
+		log.Fatal().Err(err).Msg("invalid configuration")
+	}
 
 	// Build the TLS config for the outbound call to light-service (client role).
 	var lightTLSCfg *tls.Config
 	if config.TLSCert != "" {
-		cfg, err := tlsconfig.LoadClientTLS(config.TLSCert, config.TLSKey, config.TLSCA)
+		cfg, err := tlsconfig.LoadClientTLS(config.TLSCert, config.TLSKey, config.TLSCA, logCertReload(config.TLSCert))
 		if err != nil {
 			log.Fatal().Err(err).Msg("failed to load client TLS config")
 		}
 		lightTLSCfg = cfg
 		log.Info().Msg("mTLS enabled for light-service connection")
 	} else {
-		log.Warn().Msg("TLS_CERT not set — connecting to light-service without TLS (dev mode only)")
+		log.Warn().Msg("TLS_CERT not set — connecting to light-service without TLS (ENV=development only)")
 	}
 
 	// Connect to light-service.
-	lightClient, err := grpcAdapter.NewLightClientAdapter(config.LightServiceAddr, lightTLSCfg)
+	lightClient, err := grpcAdapter.NewLightClientAdapter(config.LightServiceAddr, lightTLSCfg, config.LightToken)
 	if err != nil {
 		log.Fatal().Err(err).Str("addr", config.LightServiceAddr).Msg("failed to connect to light-service")
 	}
@@ -50,24 +57,43 @@ func main() {
 
 	log.Info().Str("addr", config.LightServiceAddr).Msg("connected to light-service")
 
+	// Initialize the plant profile repository.
+	var plants ports.PlantRepository
+	switch config.RepoType {
+	case "sqlite":
+		r, err := sqlite.NewPlantRepository(config.DBPath)
+		if err != nil {
+			log.Fatal().Err(err).Str("db_path", config.DBPath).Msg("failed to open SQLite database")
+		}
+		defer r.Close()
+		plants = r
+		log.Info().Str("db_path", config.DBPath).Msg("initialized SQLite plant repository")
+	default:
+		plants = memory.NewPlantRepository()
+		log.Info().Msg("initialized in-memory plant repository")
+	}
+
 	// Build gRPC server — mTLS if certs provided, insecure otherwise.
-	handler := grpcAdapter.NewPlantServiceHandler(lightClient)
+	handler := grpcAdapter.NewPlantServiceHandler(lightClient, plants)
 
 	var serverOpts []grpc.ServerOption
 	if config.TLSCert != "" {
-		tlsCfg, err := tlsconfig.LoadServerTLS(config.TLSCert, config.TLSKey, config.TLSCA)
+		tlsCfg, err := tlsconfig.LoadServerTLS(config.TLSCert, config.TLSKey, config.TLSCA, logCertReload(config.TLSCert))
 		if err != nil {
 			log.Fatal().Err(err).Msg("failed to load server TLS config")
 		}
 		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsCfg)))
 		log.Info().Msg("mTLS enabled for incoming connections")
 	} else {
-		log.Warn().Msg("starting gRPC server without TLS (dev mode only)")
+		log.Warn().Msg("starting gRPC server without TLS (ENV=development only)")
 	}
 
 	grpcServer := grpc.NewServer(serverOpts...)
 	pb.RegisterPlantServiceServer(grpcServer, handler)
-	reflection.Register(grpcServer)
+	// Reflection is for grpcurl during development only
+	if !config.production() {
+		reflection.Register(grpcServer)
+	}
 
 	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", config.Port))
 	if err != nil {
@@ -98,7 +124,11 @@ func main() {
 // Config holds application configuration read from environment variables.
 type Config struct {
 	Port             string
+	Env              string // "development" | "production": whether plaintext gRPC and reflection are allowed
 	LightServiceAddr string
+	LightToken       string // API key for light-service, if it requires one
+	RepoType         string // "memory" | "sqlite" — where plant profiles are kept
+	DBPath           string // SQLite database file path (used when RepoType=sqlite)
 	TLSCert          string
 	TLSKey           string
 	TLSCA            string
@@ -115,11 +145,63 @@ func loadConfig() Config {
 		lightAddr = "localhost:50051"
 	}
 
+	repoType := os.Getenv("REPO_TYPE")
+	if repoType == "" {
+		repoType = "memory"
+	}
+
+	dbPath := os.Getenv("DB_PATH")
+	if dbPath == "" {
+		dbPath = "./plants.db"
+	}
+
+	env := os.Getenv("ENV")
+	if env == "" {
+		env = "development"
+	}
+
 	return Config{
+		Env:              env,
 		Port:             port,
 		LightServiceAddr: lightAddr,
+		LightToken:       os.Getenv("LIGHT_SERVICE_TOKEN"),
+		RepoType:         repoType,
+		DBPath:           dbPath,
 		TLSCert:          os.Getenv("TLS_CERT"),
 		TLSKey:           os.Getenv("TLS_KEY"),
 		TLSCA:            os.Getenv("TLS_CA"),
 	}
 }
+
+// checkEnv rejects an unknown ENV and, in production, a missing TLS_CERT:
+// production refuses plaintext rather than warning about it. The services
+// share no module, so each main carries its own copy; keep them in step.
+func (c Config) checkEnv() error {
+	switch c.Env {
+	case "development":
+		return nil
+	case "production":
+		if c.TLSCert == "" {
+			return errors.New("TLS_CERT is required when ENV is production")
+		}
+		return nil
+	}
+	return fmt.Errorf("ENV must be development or production, got %q", c.Env)
+}
+
+// production reports whether ENV is production, which turns off reflection
+func (c Config) production() bool {
+	return c.Env == "production"
+}
+
+// logCertReload logs each reload of a rotated certificate, which is picked
+// up at the next handshake after the periodic check
+func logCertReload(certFile string) tlsconfig.Option {
+	return tlsconfig.WithReloadHook(func(err error) {
+		if err != nil {
+			log.Error().Err(err).Msg("failed to reload TLS certificate, keeping the current one")
+			return
+		}
+		log.Info().Str("cert", certFile).Msg("TLS certificate reloaded")
+	})
+}
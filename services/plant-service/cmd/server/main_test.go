@@ -0,0 +1,38 @@
+package main
+
+import "testing"
+
+func TestCheckEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{name: "development without TLS", config: Config{Env: "development"}},
+		{name: "development with TLS", config: Config{Env: "development", TLSCert: "cert.pem"}},
+		{name: "production with TLS", config: Config{Env: "production", TLSCert: "cert.pem"}},
+		{name: "production without TLS", config: Config{Env: "production"}, wantErr: true},
+		{name: "unknown", config: Config{Env: "staging", TLSCert: "cert.pem"}, wantErr: true},
+		{name: "wrong case", config: Config{Env: "Production", TLSCert: "cert.pem"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.checkEnv()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkEnv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_EnvDefaultsToDevelopment(t *testing.T) {
+	t.Setenv("ENV", "")
+	if c := loadConfig(); c.Env != "development" || c.production() {
+		t.Errorf("expected development, got %q", c.Env)
+	}
+
+	t.Setenv("ENV", "production")
+	if c := loadConfig(); !c.production() {
+		t.Errorf("expected production, got %q", c.Env)
+	}
+}
@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/rs/zerolog/log"
@@ -17,17 +18,30 @@ import (
 type PlantServiceHandler struct {
 	pb.UnimplementedPlantServiceServer
 	lightClient ports.LightClient
+	plants      ports.PlantRepository
 }
 
-// NewPlantServiceHandler creates the handler wired to the given LightClient.
-func NewPlantServiceHandler(lightClient ports.LightClient) *PlantServiceHandler {
-	return &PlantServiceHandler{lightClient: lightClient}
+// NewPlantServiceHandler creates the handler wired to the given LightClient
+// and PlantRepository.
+func NewPlantServiceHandler(lightClient ports.LightClient, plants ports.PlantRepository) *PlantServiceHandler {
+	return &PlantServiceHandler{lightClient: lightClient, plants: plants}
 }
 
 // GetPlantStatus fetches the current lux reading and the last hour of history,
-// runs domain analysis, and returns a PlantStatus.
-func (h *PlantServiceHandler) GetPlantStatus(ctx context.Context, _ *pb.GetPlantStatusRequest) (*pb.GetPlantStatusResponse, error) {
-	log.Info().Msg("GetPlantStatus called")
+// runs domain analysis, and returns a PlantStatus. With a plant_id the light is
+// also evaluated against that plant's requirements, which then drive the
+// recommendation.
+func (h *PlantServiceHandler) GetPlantStatus(ctx context.Context, req *pb.GetPlantStatusRequest) (*pb.GetPlantStatusResponse, error) {
+	log.Info().Int64("plant_id", req.PlantId).Msg("GetPlantStatus called")
+
+	var plant *domain.Plant
+	if req.PlantId != 0 {
+		p, err := h.plants.GetPlant(ctx, req.PlantId)
+		if err != nil {
+			return nil, plantError(err)
+		}
+		plant = p
+	}
 
 	current, err := h.lightClient.GetCurrentLux(ctx)
 	if err != nil {
@@ -44,15 +58,20 @@ func (h *PlantServiceHandler) GetPlantStatus(ctx context.Context, _ *pb.GetPlant
 
 	analysis := domain.Analyze(current.Lux, luxSlice(history))
 
-	return &pb.GetPlantStatusResponse{
-		Status: &pb.PlantStatus{
-			Recommendation: analysis.Recommendation,
-			LightCategory:  analysis.Category,
-			CurrentLux:     analysis.CurrentLux,
-			Trend:          analysis.Trend,
-			Timestamp:      current.Timestamp.Unix(),
-		},
-	}, nil
+	st := &pb.PlantStatus{
+		Recommendation: analysis.Recommendation,
+		LightCategory:  analysis.Category,
+		CurrentLux:     analysis.CurrentLux,
+		Trend:          analysis.Trend,
+		Timestamp:      current.Timestamp.Unix(),
+	}
+	if plant != nil {
+		st.Fit = string(plant.Fit(current.Lux))
+		st.Recommendation = plant.Recommendation(current.Lux)
+		st.Plant = plantToProto(plant)
+	}
+
+	return &pb.GetPlantStatusResponse{Status: st}, nil
 }
 
 // GetHistory fetches readings for the requested time range, maps them to
@@ -86,6 +105,106 @@ func (h *PlantServiceHandler) GetHistory(ctx context.Context, req *pb.GetHistory
 	}, nil
 }
 
+// CreatePlant validates and stores a new plant profile.
+func (h *PlantServiceHandler) CreatePlant(ctx context.Context, req *pb.CreatePlantRequest) (*pb.CreatePlantResponse, error) {
+	plant := plantFromProto(req.GetPlant())
+	log.Info().Str("name", plant.Name).Msg("CreatePlant called")
+
+	if err := plant.Validate(); err != nil {
+		return nil, plantError(err)
+	}
+	if err := h.plants.CreatePlant(ctx, plant); err != nil {
+		return nil, plantError(err)
+	}
+
+	return &pb.CreatePlantResponse{Plant: plantToProto(plant)}, nil
+}
+
+// GetPlant returns a single plant profile.
+func (h *PlantServiceHandler) GetPlant(ctx context.Context, req *pb.GetPlantRequest) (*pb.GetPlantResponse, error) {
+	plant, err := h.plants.GetPlant(ctx, req.Id)
+	if err != nil {
+		return nil, plantError(err)
+	}
+	return &pb.GetPlantResponse{Plant: plantToProto(plant)}, nil
+}
+
+// ListPlants returns every plant profile, ordered by name.
+func (h *PlantServiceHandler) ListPlants(ctx context.Context, _ *pb.ListPlantsRequest) (*pb.ListPlantsResponse, error) {
+	plants, err := h.plants.ListPlants(ctx)
+	if err != nil {
+		return nil, plantError(err)
+	}
+
+	resp := &pb.ListPlantsResponse{Plants: make([]*pb.Plant, len(plants))}
+	for i, p := range plants {
+		resp.Plants[i] = plantToProto(p)
+	}
+	return resp, nil
+}
+
+// UpdatePlant validates and replaces an existing plant profile.
+func (h *PlantServiceHandler) UpdatePlant(ctx context.Context, req *pb.UpdatePlantRequest) (*pb.UpdatePlantResponse, error) {
+	plant := plantFromProto(req.GetPlant())
+	log.Info().Int64("id", plant.ID).Str("name", plant.Name).Msg("UpdatePlant called")
+
+	if plant.ID == 0 {
+		return nil, status.Error(codes.InvalidArgument, "plant id is required")
+	}
+	if err := plant.Validate(); err != nil {
+		return nil, plantError(err)
+	}
+	if err := h.plants.UpdatePlant(ctx, plant); err != nil {
+		return nil, plantError(err)
+	}
+
+	return &pb.UpdatePlantResponse{Plant: plantToProto(plant)}, nil
+}
+
+// plantError maps plant repository and validation errors to gRPC statuses.
+func plantError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrPlantNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrPlantExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrPlantNameRequired), errors.Is(err, domain.ErrInvalidLightRange):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		log.Error().Err(err).Msg("plant repository error")
+		return status.Errorf(codes.Internal, "plant repository error: %v", err)
+	}
+}
+
+// plantFromProto maps a pb.Plant to the domain type. Timestamps are
+// server-assigned, so they are not read.
+func plantFromProto(p *pb.Plant) *domain.Plant {
+	return &domain.Plant{
+		ID:       p.GetId(),
+		Name:     p.GetName(),
+		Species:  p.GetSpecies(),
+		MinLux:   p.GetMinLux(),
+		MaxLux:   p.GetMaxLux(),
+		Location: p.GetLocation(),
+		SensorID: p.GetSensorId(),
+	}
+}
+
+// plantToProto maps a domain plant to its wire form.
+func plantToProto(p *domain.Plant) *pb.Plant {
+	return &pb.Plant{
+		Id:          p.ID,
+		Name:        p.Name,
+		Species:     p.Species,
+		MinLux:      p.MinLux,
+		MaxLux:      p.MaxLux,
+		Location:    p.Location,
+		SensorId:    p.SensorID,
+		CreatedAtMs: p.CreatedAt.UnixMilli(),
+		UpdatedAtMs: p.UpdatedAt.UnixMilli(),
+	}
+}
+
 // luxSlice extracts the lux values from a slice of LightReadings.
 func luxSlice(readings []ports.LightReading) []float64 {
 	lux := make([]float64, len(readings))
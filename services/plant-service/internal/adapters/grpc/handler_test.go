@@ -0,0 +1,267 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/quentinrf/plant-monitor/services/plant-service/internal/adapters/memory"
+	"github.com/quentinrf/plant-monitor/services/plant-service/internal/domain"
+	"github.com/quentinrf/plant-monitor/services/plant-service/internal/ports"
+	"github.com/quentinrf/plant-monitor/services/plant-service/pkg/pb"
+)
+
+// fakeLightClient serves a fixed current reading and no history
+type fakeLightClient struct {
+	lux float64
+	err error
+}
+
+func (c *fakeLightClient) GetCurrentLux(ctx context.Context) (*ports.LightReading, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &ports.LightReading{Lux: c.lux, Timestamp: time.Now()}, nil
+}
+
+func (c *fakeLightClient) GetHistory(ctx context.Context, start, end time.Time) ([]ports.LightReading, error) {
+	return nil, nil
+}
+
+func (c *fakeLightClient) Close() error { return nil }
+
+// startTestServer creates an in-process gRPC server backed by an in-memory
+// plant repository and returns a connected client. The server is stopped
+// when the test ends.
+func startTestServer(t *testing.T, light ports.LightClient) pb.PlantServiceClient {
+	t.Helper()
+
+	handler := NewPlantServiceHandler(light, memory.NewPlantRepository())
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	srv := grpc.NewServer()
+	pb.RegisterPlantServiceServer(srv, handler)
+
+	go srv.Serve(lis)
+	t.Cleanup(func() {
+		srv.GracefulStop()
+	})
+
+	conn, err := grpc.NewClient(
+		lis.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewPlantServiceClient(conn)
+}
+
+// createPlant stores a plant through the API and returns it
+func createPlant(t *testing.T, client pb.PlantServiceClient, name string, minLux, maxLux float64) *pb.Plant {
+	t.Helper()
+
+	resp, err := client.CreatePlant(context.Background(), &pb.CreatePlantRequest{
+		Plant: &pb.Plant{Name: name, MinLux: minLux, MaxLux: maxLux},
+	})
+	if err != nil {
+		t.Fatalf("CreatePlant failed: %v", err)
+	}
+	return resp.Plant
+}
+
+func TestCreatePlant_AssignsID(t *testing.T) {
+	client := startTestServer(t, &fakeLightClient{})
+
+	plant := createPlant(t, client, "  Monstera  ", 1000, 5000)
+	if plant.Id == 0 {
+		t.Error("expected an ID to be assigned")
+	}
+	if plant.Name != "Monstera" {
+		t.Errorf("expected the name to be trimmed, got %q", plant.Name)
+	}
+	if plant.CreatedAtMs == 0 || plant.UpdatedAtMs == 0 {
+		t.Errorf("expected timestamps to be set, got %+v", plant)
+	}
+}
+
+func TestCreatePlant_DuplicateName(t *testing.T) {
+	client := startTestServer(t, &fakeLightClient{})
+	createPlant(t, client, "Monstera", 1000, 5000)
+
+	_, err := client.CreatePlant(context.Background(), &pb.CreatePlantRequest{
+		Plant: &pb.Plant{Name: "Monstera", MinLux: 500, MaxLux: 2000},
+	})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("expected AlreadyExists, got %v", err)
+	}
+}
+
+func TestCreatePlant_InvalidArgument(t *testing.T) {
+	client := startTestServer(t, &fakeLightClient{})
+
+	tests := []struct {
+		name  string
+		plant *pb.Plant
+	}{
+		{"missing name", &pb.Plant{MinLux: 100, MaxLux: 1000}},
+		{"min above max", &pb.Plant{Name: "Fern", MinLux: 2000, MaxLux: 1000}},
+		{"negative min", &pb.Plant{Name: "Fern", MinLux: -1, MaxLux: 1000}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.CreatePlant(context.Background(), &pb.CreatePlantRequest{Plant: tt.plant})
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
+
+func TestGetPlant(t *testing.T) {
+	client := startTestServer(t, &fakeLightClient{})
+	ctx := context.Background()
+	created := createPlant(t, client, "Monstera", 1000, 5000)
+
+	resp, err := client.GetPlant(ctx, &pb.GetPlantRequest{Id: created.Id})
+	if err != nil {
+		t.Fatalf("GetPlant failed: %v", err)
+	}
+	if resp.Plant.Name != "Monstera" || resp.Plant.MinLux != 1000 || resp.Plant.MaxLux != 5000 {
+		t.Errorf("unexpected plant %+v", resp.Plant)
+	}
+
+	_, err = client.GetPlant(ctx, &pb.GetPlantRequest{Id: created.Id + 1})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
+
+func TestListPlants_OrderedByName(t *testing.T) {
+	client := startTestServer(t, &fakeLightClient{})
+	createPlant(t, client, "Snake plant", 50, 2000)
+	createPlant(t, client, "Fern", 200, 1500)
+
+	resp, err := client.ListPlants(context.Background(), &pb.ListPlantsRequest{})
+	if err != nil {
+		t.Fatalf("ListPlants failed: %v", err)
+	}
+	if len(resp.Plants) != 2 || resp.Plants[0].Name != "Fern" || resp.Plants[1].Name != "Snake plant" {
+		t.Errorf("expected Fern then Snake plant, got %+v", resp.Plants)
+	}
+}
+
+func TestUpdatePlant(t *testing.T) {
+	client := startTestServer(t, &fakeLightClient{})
+	ctx := context.Background()
+	created := createPlant(t, client, "Monstera", 1000, 5000)
+
+	resp, err := client.UpdatePlant(ctx, &pb.UpdatePlantRequest{
+		Plant: &pb.Plant{Id: created.Id, Name: "Monstera", MinLux: 800, MaxLux: 4000, Location: "hallway"},
+	})
+	if err != nil {
+		t.Fatalf("UpdatePlant failed: %v", err)
+	}
+	if resp.Plant.MinLux != 800 || resp.Plant.Location != "hallway" {
+		t.Errorf("unexpected plant %+v", resp.Plant)
+	}
+
+	tests := []struct {
+		name  string
+		plant *pb.Plant
+		code  codes.Code
+	}{
+		{"missing id", &pb.Plant{Name: "Monstera", MinLux: 800, MaxLux: 4000}, codes.InvalidArgument},
+		{"invalid range", &pb.Plant{Id: created.Id, Name: "Monstera", MinLux: 4000, MaxLux: 800}, codes.InvalidArgument},
+		{"unknown plant", &pb.Plant{Id: created.Id + 1, Name: "Fern", MinLux: 200, MaxLux: 1500}, codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.UpdatePlant(ctx, &pb.UpdatePlantRequest{Plant: tt.plant})
+			if status.Code(err) != tt.code {
+				t.Errorf("expected %v, got %v", tt.code, err)
+			}
+		})
+	}
+}
+
+func TestGetPlantStatus_EvaluatesPlantRequirements(t *testing.T) {
+	client := startTestServer(t, &fakeLightClient{lux: 300})
+	ctx := context.Background()
+	plant := createPlant(t, client, "Monstera", 1000, 5000)
+
+	resp, err := client.GetPlantStatus(ctx, &pb.GetPlantStatusRequest{PlantId: plant.Id})
+	if err != nil {
+		t.Fatalf("GetPlantStatus failed: %v", err)
+	}
+
+	st := resp.Status
+	if st.Fit != string(domain.FitTooDark) {
+		t.Errorf("expected fit %q, got %q", domain.FitTooDark, st.Fit)
+	}
+	if st.Plant.GetId() != plant.Id {
+		t.Errorf("expected the plant in the status, got %+v", st.Plant)
+	}
+	if want := "Monstera needs more light — move it closer to a window or add a grow light"; st.Recommendation != want {
+		t.Errorf("expected recommendation %q, got %q", want, st.Recommendation)
+	}
+	if st.CurrentLux != 300 {
+		t.Errorf("expected 300 lux, got %v", st.CurrentLux)
+	}
+}
+
+func TestGetPlantStatus_WithoutPlant(t *testing.T) {
+	client := startTestServer(t, &fakeLightClient{lux: 300})
+
+	resp, err := client.GetPlantStatus(context.Background(), &pb.GetPlantStatusRequest{})
+	if err != nil {
+		t.Fatalf("GetPlantStatus failed: %v", err)
+	}
+	if resp.Status.Fit != "" || resp.Status.Plant != nil {
+		t.Errorf("expected no plant evaluation, got %+v", resp.Status)
+	}
+}
+
+func TestGetPlantStatus_Errors(t *testing.T) {
+	_, err := startTestServer(t, &fakeLightClient{}).GetPlantStatus(context.Background(), &pb.GetPlantStatusRequest{PlantId: 42})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound for an unknown plant, got %v", err)
+	}
+
+	down := &fakeLightClient{err: errors.New("connection refused")}
+	_, err = startTestServer(t, down).GetPlantStatus(context.Background(), &pb.GetPlantStatusRequest{})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("expected Unavailable when light-service is down, got %v", err)
+	}
+}
+
+func TestPlantError(t *testing.T) {
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{domain.ErrPlantNotFound, codes.NotFound},
+		{domain.ErrPlantExists, codes.AlreadyExists},
+		{domain.ErrPlantNameRequired, codes.InvalidArgument},
+		{domain.ErrInvalidLightRange, codes.InvalidArgument},
+		{errors.New("disk full"), codes.Internal},
+	}
+	for _, tt := range tests {
+		if got := status.Code(plantError(tt.err)); got != tt.code {
+			t.Errorf("plantError(%v): expected %v, got %v", tt.err, tt.code, got)
+		}
+	}
+}
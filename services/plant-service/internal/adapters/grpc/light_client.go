@@ -3,81 +3,70 @@ package grpc
 import (
 	"context"
 	"crypto/tls"
-	"fmt"
 	"time"
 
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/credentials/insecure"
-
 	"github.com/quentinrf/plant-monitor/services/plant-service/internal/ports"
 
-	lightpb "github.com/quentinrf/plant-monitor/services/light-service/pkg/pb"
+	lightclient "github.com/quentinrf/plant-monitor/services/light-service/pkg/client"
 )
 
 // LightClientAdapter implements ports.LightClient by calling light-service over gRPC.
 type LightClientAdapter struct {
-	conn   *grpc.ClientConn
-	client lightpb.LightServiceClient
+	client *lightclient.Client
 }
 
-// NewLightClientAdapter dials light-service. Pass nil tlsConfig for insecure (dev) mode.
-func NewLightClientAdapter(addr string, tlsConfig *tls.Config) (*LightClientAdapter, error) {
-	var dialOpt grpc.DialOption
+// NewLightClientAdapter dials light-service. Pass nil tlsConfig for insecure (dev) mode,
+// and an empty token if light-service doesn't require API keys.
+func NewLightClientAdapter(addr string, tlsConfig *tls.Config, token string) (*LightClientAdapter, error) {
+	var opts []lightclient.Option
 	if tlsConfig != nil {
-		dialOpt = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
-	} else {
-		dialOpt = grpc.WithTransportCredentials(insecure.NewCredentials())
+		opts = append(opts, lightclient.WithTLSConfig(tlsConfig))
+	}
+	if token != "" {
+		opts = append(opts, lightclient.WithToken(token))
 	}
 
-	conn, err := grpc.NewClient(addr, dialOpt)
+	client, err := lightclient.Dial(addr, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("dial light-service at %s: %w", addr, err)
+		return nil, err
 	}
-
-	return &LightClientAdapter{
-		conn:   conn,
-		client: lightpb.NewLightServiceClient(conn),
-	}, nil
+	return &LightClientAdapter{client: client}, nil
 }
 
 // GetCurrentLux fetches the most recent lux reading from light-service.
 func (a *LightClientAdapter) GetCurrentLux(ctx context.Context) (*ports.LightReading, error) {
-	resp, err := a.client.GetCurrentLight(ctx, &lightpb.GetCurrentLightRequest{})
+	r, err := a.client.Current(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("GetCurrentLight: %w", err)
+		return nil, err
 	}
-
-	r := resp.GetReading()
-	return &ports.LightReading{
-		Lux:       r.GetLux(),
-		Timestamp: time.Unix(r.GetTimestamp(), 0),
-		Category:  r.GetCategory(),
-	}, nil
+	reading := convertLightReading(r)
+	return &reading, nil
 }
 
 // GetHistory fetches readings from light-service in the half-open interval [start, end).
 func (a *LightClientAdapter) GetHistory(ctx context.Context, start, end time.Time) ([]ports.LightReading, error) {
-	resp, err := a.client.GetHistory(ctx, &lightpb.GetHistoryRequest{
-		StartTime: start.Unix(),
-		EndTime:   end.Unix(),
-	})
+	history, err := a.client.History(ctx, start, end)
 	if err != nil {
-		return nil, fmt.Errorf("GetHistory: %w", err)
+		return nil, err
 	}
 
-	readings := make([]ports.LightReading, len(resp.GetReadings()))
-	for i, r := range resp.GetReadings() {
-		readings[i] = ports.LightReading{
-			Lux:       r.GetLux(),
-			Timestamp: time.Unix(r.GetTimestamp(), 0),
-			Category:  r.GetCategory(),
-		}
+	readings := make([]ports.LightReading, len(history))
+	for i, r := range history {
+		readings[i] = convertLightReading(r)
 	}
 	return readings, nil
 }
 
 // Close releases the underlying gRPC connection.
 func (a *LightClientAdapter) Close() error {
-	return a.conn.Close()
+	return a.client.Close()
+}
+
+// convertLightReading keeps the fields plant-service uses
+func convertLightReading(r lightclient.Reading) ports.LightReading {
+	return ports.LightReading{
+		Lux:       r.Lux,
+		Timestamp: r.Time,
+		Category:  r.Category,
+	}
 }
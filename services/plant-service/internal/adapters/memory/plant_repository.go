@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/quentinrf/plant-monitor/services/plant-service/internal/domain"
+)
+
+// PlantRepository implements ports.PlantRepository with in-memory storage.
+// Plants are lost on restart; use the SQLite adapter to keep them.
+type PlantRepository struct {
+	mu     sync.RWMutex
+	plants map[int64]*domain.Plant
+	nextID int64
+}
+
+// NewPlantRepository creates an empty in-memory repository
+func NewPlantRepository() *PlantRepository {
+	return &PlantRepository{
+		plants: make(map[int64]*domain.Plant),
+		nextID: 1,
+	}
+}
+
+// CreatePlant stores a new plant, assigning its ID and timestamps
+func (r *PlantRepository) CreatePlant(ctx context.Context, plant *domain.Plant) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.nameTaken(plant.Name, 0) {
+		return domain.ErrPlantExists
+	}
+
+	now := time.Now()
+	plant.ID = r.nextID
+	plant.CreatedAt = now
+	plant.UpdatedAt = now
+	r.nextID++
+
+	// Store a copy, so later changes by the caller don't leak in
+	stored := *plant
+	r.plants[plant.ID] = &stored
+	return nil
+}
+
+// GetPlant retrieves a plant by ID
+func (r *PlantRepository) GetPlant(ctx context.Context, id int64) (*domain.Plant, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	plant, exists := r.plants[id]
+	if !exists {
+		return nil, domain.ErrPlantNotFound
+	}
+
+	copied := *plant
+	return &copied, nil
+}
+
+// ListPlants returns every plant, ordered by name
+func (r *PlantRepository) ListPlants(ctx context.Context) ([]*domain.Plant, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	results := make([]*domain.Plant, 0, len(r.plants))
+	for _, plant := range r.plants {
+		copied := *plant
+		results = append(results, &copied)
+	}
+
+	slices.SortFunc(results, func(a, b *domain.Plant) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return results, nil
+}
+
+// UpdatePlant replaces the stored plant with the same ID, keeping its
+// creation time
+func (r *PlantRepository) UpdatePlant(ctx context.Context, plant *domain.Plant) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.plants[plant.ID]
+	if !exists {
+		return domain.ErrPlantNotFound
+	}
+	if r.nameTaken(plant.Name, plant.ID) {
+		return domain.ErrPlantExists
+	}
+
+	plant.CreatedAt = existing.CreatedAt
+	plant.UpdatedAt = time.Now()
+
+	stored := *plant
+	r.plants[plant.ID] = &stored
+	return nil
+}
+
+// nameTaken reports whether a plant other than exceptID has the name.
+// Callers must hold the lock.
+func (r *PlantRepository) nameTaken(name string, exceptID int64) bool {
+	for id, plant := range r.plants {
+		if id != exceptID && plant.Name == name {
+			return true
+		}
+	}
+	return false
+}
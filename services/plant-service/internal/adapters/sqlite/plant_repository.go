@@ -0,0 +1,199 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/quentinrf/plant-monitor/services/plant-service/internal/domain"
+)
+
+// PlantRepository implements ports.PlantRepository with SQLite.
+// Timestamps are stored as Unix milliseconds; names are unique.
+type PlantRepository struct {
+	db *sql.DB
+}
+
+const schema = `
+CREATE TABLE IF NOT EXISTS plants (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL UNIQUE,
+	species TEXT NOT NULL DEFAULT '',
+	min_lux REAL NOT NULL,
+	max_lux REAL NOT NULL,
+	location TEXT NOT NULL DEFAULT '',
+	sensor_id TEXT NOT NULL DEFAULT '',
+	created_at_ms INTEGER NOT NULL,
+	updated_at_ms INTEGER NOT NULL
+);
+`
+
+// NewPlantRepository creates a SQLite-backed repository
+func NewPlantRepository(dbPath string) (*PlantRepository, error) {
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	// SQLite serializes writes anyway; one connection avoids "database is
+	// locked" errors and keeps :memory: databases to a single instance
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	return &PlantRepository{db: db}, nil
+}
+
+// CreatePlant stores a new plant, assigning its ID and timestamps
+func (r *PlantRepository) CreatePlant(ctx context.Context, plant *domain.Plant) error {
+	query := `
+		INSERT INTO plants (name, species, min_lux, max_lux, location, sensor_id, created_at_ms, updated_at_ms)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	now := time.UnixMilli(time.Now().UnixMilli())
+	result, err := r.db.ExecContext(ctx, query,
+		plant.Name, plant.Species, plant.MinLux, plant.MaxLux, plant.Location, plant.SensorID,
+		now.UnixMilli(), now.UnixMilli())
+	if isUniqueViolation(err) {
+		return domain.ErrPlantExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to insert plant: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get insert id: %w", err)
+	}
+
+	plant.ID = id
+	plant.CreatedAt = now
+	plant.UpdatedAt = now
+	return nil
+}
+
+// GetPlant retrieves a plant by ID
+func (r *PlantRepository) GetPlant(ctx context.Context, id int64) (*domain.Plant, error) {
+	query := `
+		SELECT id, name, species, min_lux, max_lux, location, sensor_id, created_at_ms, updated_at_ms
+		FROM plants
+		WHERE id = ?
+	`
+
+	plant, err := scanPlant(r.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrPlantNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query plant: %w", err)
+	}
+	return plant, nil
+}
+
+// ListPlants returns every plant, ordered by name
+func (r *PlantRepository) ListPlants(ctx context.Context) ([]*domain.Plant, error) {
+	query := `
+		SELECT id, name, species, min_lux, max_lux, location, sensor_id, created_at_ms, updated_at_ms
+		FROM plants
+		ORDER BY name ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query plants: %w", err)
+	}
+	defer rows.Close()
+
+	plants := []*domain.Plant{}
+	for rows.Next() {
+		plant, err := scanPlant(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan plant: %w", err)
+		}
+		plants = append(plants, plant)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate plants: %w", err)
+	}
+
+	return plants, nil
+}
+
+// UpdatePlant replaces the stored plant with the same ID, keeping its
+// creation time
+func (r *PlantRepository) UpdatePlant(ctx context.Context, plant *domain.Plant) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var createdAtMs int64
+	err = tx.QueryRowContext(ctx, `SELECT created_at_ms FROM plants WHERE id = ?`, plant.ID).Scan(&createdAtMs)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrPlantNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to query plant: %w", err)
+	}
+
+	query := `
+		UPDATE plants
+		SET name = ?, species = ?, min_lux = ?, max_lux = ?, location = ?, sensor_id = ?, updated_at_ms = ?
+		WHERE id = ?
+	`
+
+	now := time.UnixMilli(time.Now().UnixMilli())
+	_, err = tx.ExecContext(ctx, query,
+		plant.Name, plant.Species, plant.MinLux, plant.MaxLux, plant.Location, plant.SensorID,
+		now.UnixMilli(), plant.ID)
+	if isUniqueViolation(err) {
+		return domain.ErrPlantExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update plant: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit plant update: %w", err)
+	}
+
+	plant.CreatedAt = time.UnixMilli(createdAtMs)
+	plant.UpdatedAt = now
+	return nil
+}
+
+// Close closes the database connection
+func (r *PlantRepository) Close() error {
+	return r.db.Close()
+}
+
+// scanner is satisfied by *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPlant(s scanner) (*domain.Plant, error) {
+	var plant domain.Plant
+	var createdAtMs, updatedAtMs int64
+	err := s.Scan(&plant.ID, &plant.Name, &plant.Species, &plant.MinLux, &plant.MaxLux,
+		&plant.Location, &plant.SensorID, &createdAtMs, &updatedAtMs)
+	if err != nil {
+		return nil, err
+	}
+	plant.CreatedAt = time.UnixMilli(createdAtMs)
+	plant.UpdatedAt = time.UnixMilli(updatedAtMs)
+	return &plant, nil
+}
+
+// isUniqueViolation reports whether err is SQLite rejecting a duplicate name
+func isUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+}
@@ -0,0 +1,128 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/quentinrf/plant-monitor/services/plant-service/internal/domain"
+)
+
+func newTestRepo(t *testing.T) *PlantRepository {
+	t.Helper()
+	repo, err := NewPlantRepository(filepath.Join(t.TempDir(), "plants.db"))
+	if err != nil {
+		t.Fatalf("NewPlantRepository failed: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return repo
+}
+
+func TestPlantRepository_CreateAndGet(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	plant := &domain.Plant{Name: "Fern", Species: "Nephrolepis exaltata", MinLux: 200, MaxLux: 1000}
+	if err := repo.CreatePlant(ctx, plant); err != nil {
+		t.Fatalf("CreatePlant failed: %v", err)
+	}
+	if plant.ID != 1 || plant.CreatedAt.IsZero() || !plant.UpdatedAt.Equal(plant.CreatedAt) {
+		t.Errorf("expected ID and timestamps to be assigned, got %+v", plant)
+	}
+
+	got, err := repo.GetPlant(ctx, plant.ID)
+	if err != nil {
+		t.Fatalf("GetPlant failed: %v", err)
+	}
+	if got.Name != "Fern" || got.Species != plant.Species || got.MaxLux != 1000 ||
+		got.CreatedAt.UnixMilli() != plant.CreatedAt.UnixMilli() {
+		t.Errorf("got %+v, want %+v", got, plant)
+	}
+
+	if _, err := repo.GetPlant(ctx, 99); !errors.Is(err, domain.ErrPlantNotFound) {
+		t.Errorf("expected ErrPlantNotFound, got %v", err)
+	}
+	if err := repo.CreatePlant(ctx, &domain.Plant{Name: "Fern"}); !errors.Is(err, domain.ErrPlantExists) {
+		t.Errorf("expected ErrPlantExists for a duplicate name, got %v", err)
+	}
+}
+
+func TestPlantRepository_ListPlants_OrderedByName(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	for _, name := range []string{"Snake plant", "Aloe", "Monstera"} {
+		if err := repo.CreatePlant(ctx, &domain.Plant{Name: name}); err != nil {
+			t.Fatalf("CreatePlant failed: %v", err)
+		}
+	}
+
+	plants, err := repo.ListPlants(ctx)
+	if err != nil {
+		t.Fatalf("ListPlants failed: %v", err)
+	}
+	if len(plants) != 3 || plants[0].Name != "Aloe" || plants[1].Name != "Monstera" || plants[2].Name != "Snake plant" {
+		t.Errorf("expected plants ordered by name, got %d plants", len(plants))
+	}
+}
+
+func TestPlantRepository_UpdatePlant(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	fern := &domain.Plant{Name: "Fern", MinLux: 200, MaxLux: 1000}
+	aloe := &domain.Plant{Name: "Aloe", MinLux: 2000, MaxLux: 10000}
+	_ = repo.CreatePlant(ctx, fern)
+	_ = repo.CreatePlant(ctx, aloe)
+
+	update := &domain.Plant{ID: fern.ID, Name: "Boston fern", MinLux: 300, MaxLux: 1200, Location: "bathroom"}
+	if err := repo.UpdatePlant(ctx, update); err != nil {
+		t.Fatalf("UpdatePlant failed: %v", err)
+	}
+	if !update.CreatedAt.Equal(fern.CreatedAt) || update.UpdatedAt.Before(fern.UpdatedAt) {
+		t.Errorf("expected creation time kept and update time refreshed, got %+v", update)
+	}
+
+	got, _ := repo.GetPlant(ctx, fern.ID)
+	if got.Name != "Boston fern" || got.MinLux != 300 || got.Location != "bathroom" {
+		t.Errorf("unexpected plant after update %+v", got)
+	}
+
+	// Keeping its own name is fine; taking another plant's isn't
+	if err := repo.UpdatePlant(ctx, &domain.Plant{ID: fern.ID, Name: "Boston fern"}); err != nil {
+		t.Errorf("UpdatePlant with unchanged name failed: %v", err)
+	}
+	if err := repo.UpdatePlant(ctx, &domain.Plant{ID: fern.ID, Name: "Aloe"}); !errors.Is(err, domain.ErrPlantExists) {
+		t.Errorf("expected ErrPlantExists, got %v", err)
+	}
+	if err := repo.UpdatePlant(ctx, &domain.Plant{ID: 99, Name: "Ghost"}); !errors.Is(err, domain.ErrPlantNotFound) {
+		t.Errorf("expected ErrPlantNotFound, got %v", err)
+	}
+}
+
+func TestPlantRepository_SurvivesReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plants.db")
+	ctx := context.Background()
+
+	repo, err := NewPlantRepository(path)
+	if err != nil {
+		t.Fatalf("NewPlantRepository failed: %v", err)
+	}
+	_ = repo.CreatePlant(ctx, &domain.Plant{Name: "Fern", MinLux: 200, MaxLux: 1000, SensorID: "bh1750-0"})
+	repo.Close()
+
+	repo, err = NewPlantRepository(path)
+	if err != nil {
+		t.Fatalf("reopening failed: %v", err)
+	}
+	defer repo.Close()
+
+	plants, err := repo.ListPlants(ctx)
+	if err != nil {
+		t.Fatalf("ListPlants failed: %v", err)
+	}
+	if len(plants) != 1 || plants[0].SensorID != "bh1750-0" {
+		t.Errorf("expected the fern to survive reopening, got %d plants", len(plants))
+	}
+}
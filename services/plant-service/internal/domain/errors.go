@@ -8,4 +8,17 @@ var (
 
 	// ErrLightClientUnavailable indicates the upstream light-service cannot be reached.
 	ErrLightClientUnavailable = errors.New("light client unavailable")
+
+	// ErrPlantNotFound indicates the requested plant doesn't exist.
+	ErrPlantNotFound = errors.New("plant not found")
+
+	// ErrPlantExists indicates another plant already has the name.
+	ErrPlantExists = errors.New("a plant with that name already exists")
+
+	// ErrPlantNameRequired indicates a plant was given an empty name.
+	ErrPlantNameRequired = errors.New("plant name is required")
+
+	// ErrInvalidLightRange indicates a plant's lux requirement range is
+	// negative, non-finite or inverted.
+	ErrInvalidLightRange = errors.New("light requirement must satisfy 0 <= min_lux <= max_lux")
 )
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// Plant is a plant profile: what it is, where it stands and how much light
+// it needs. Readings are evaluated against its range rather than the
+// generic low/medium/high thresholds.
+type Plant struct {
+	ID        int64
+	Name      string
+	Species   string
+	MinLux    float64 // light requirement range, inclusive
+	MaxLux    float64
+	Location  string // free text, e.g. "living room window"
+	SensorID  string // light sensor the plant is read from
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Validate checks the plant can be stored: it needs a name and a sensible
+// light requirement range. Surrounding whitespace is trimmed from the name.
+func (p *Plant) Validate() error {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return ErrPlantNameRequired
+	}
+	if !isFinite(p.MinLux) || !isFinite(p.MaxLux) || p.MinLux < 0 || p.MinLux > p.MaxLux {
+		return ErrInvalidLightRange
+	}
+	return nil
+}
+
+// LightFit says how a lux value compares with a plant's requirement
+type LightFit string
+
+const (
+	FitTooDark   LightFit = "too_dark"
+	FitOK        LightFit = "ok"
+	FitTooBright LightFit = "too_bright"
+)
+
+// Fit evaluates lux against the plant's requirement range
+func (p *Plant) Fit(lux float64) LightFit {
+	switch {
+	case lux < p.MinLux:
+		return FitTooDark
+	case lux > p.MaxLux:
+		return FitTooBright
+	default:
+		return FitOK
+	}
+}
+
+// Recommendation turns the fit of lux into plant care advice
+func (p *Plant) Recommendation(lux float64) string {
+	switch p.Fit(lux) {
+	case FitTooDark:
+		return p.Name + " needs more light — move it closer to a window or add a grow light"
+	case FitTooBright:
+		return p.Name + " is getting too much light — move it back or filter the light"
+	default:
+		return p.Name + " is getting the light it needs"
+	}
+}
+
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
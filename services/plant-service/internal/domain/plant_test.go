@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestPlant_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		plant Plant
+		want  error
+	}{
+		{"valid", Plant{Name: "Fern", MinLux: 200, MaxLux: 1000}, nil},
+		{"single value range", Plant{Name: "Fern", MinLux: 500, MaxLux: 500}, nil},
+		{"blank name", Plant{Name: "  ", MinLux: 200, MaxLux: 1000}, ErrPlantNameRequired},
+		{"negative min", Plant{Name: "Fern", MinLux: -1, MaxLux: 1000}, ErrInvalidLightRange},
+		{"inverted range", Plant{Name: "Fern", MinLux: 1000, MaxLux: 200}, ErrInvalidLightRange},
+		{"NaN max", Plant{Name: "Fern", MinLux: 200, MaxLux: math.NaN()}, ErrInvalidLightRange},
+		{"infinite max", Plant{Name: "Fern", MinLux: 200, MaxLux: math.Inf(1)}, ErrInvalidLightRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.plant.Validate(); !errors.Is(err, tt.want) {
+				t.Errorf("Validate: got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlant_Validate_TrimsName(t *testing.T) {
+	p := Plant{Name: "  Fern \n", MinLux: 200, MaxLux: 1000}
+	if err := p.Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if p.Name != "Fern" {
+		t.Errorf("name: got %q, want %q", p.Name, "Fern")
+	}
+}
+
+func TestPlant_Fit(t *testing.T) {
+	p := Plant{Name: "Fern", MinLux: 200, MaxLux: 1000}
+
+	tests := []struct {
+		lux  float64
+		want LightFit
+	}{
+		{199, FitTooDark},
+		{200, FitOK}, // range is inclusive
+		{1000, FitOK},
+		{1001, FitTooBright},
+	}
+	for _, tt := range tests {
+		if got := p.Fit(tt.lux); got != tt.want {
+			t.Errorf("Fit(%v): got %q, want %q", tt.lux, got, tt.want)
+		}
+	}
+}
+
+func TestPlant_Recommendation(t *testing.T) {
+	p := Plant{Name: "Fern", MinLux: 200, MaxLux: 1000}
+
+	if rec := p.Recommendation(50); !strings.Contains(rec, "needs more light") {
+		t.Errorf("recommendation %q should ask for more light", rec)
+	}
+	if rec := p.Recommendation(5000); !strings.Contains(rec, "too much light") {
+		t.Errorf("recommendation %q should warn about too much light", rec)
+	}
+	if rec := p.Recommendation(500); !strings.HasPrefix(rec, "Fern") {
+		t.Errorf("recommendation %q should name the plant", rec)
+	}
+}
@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/quentinrf/plant-monitor/services/plant-service/internal/domain"
+)
+
+// PlantRepository is the port for storing plant profiles.
+// Implementations: adapters/memory (default), adapters/sqlite.
+type PlantRepository interface {
+	// CreatePlant stores a new plant, assigning its ID and timestamps.
+	// Returns domain.ErrPlantExists if the name is taken.
+	CreatePlant(ctx context.Context, plant *domain.Plant) error
+
+	// GetPlant returns the plant with the ID, or domain.ErrPlantNotFound.
+	GetPlant(ctx context.Context, id int64) (*domain.Plant, error)
+
+	// ListPlants returns every plant, ordered by name.
+	ListPlants(ctx context.Context) ([]*domain.Plant, error)
+
+	// UpdatePlant replaces the stored plant with the same ID, keeping its
+	// creation time and refreshing its update time. Returns
+	// domain.ErrPlantNotFound or domain.ErrPlantExists.
+	UpdatePlant(ctx context.Context, plant *domain.Plant) error
+}
@@ -15,12 +15,17 @@ import (
 )
 
 const (
+	// Verify that this generated code is sufficiently up-to-date.
 	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
 type GetPlantStatusRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Evaluate the light against this plant's requirements; 0 uses the
+	// generic low/medium/high categories only
+	PlantId       int64 `protobuf:"varint,1,opt,name=plant_id,json=plantId,proto3" json:"plant_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -50,10 +55,18 @@ func (x *GetPlantStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
+// Deprecated: Use GetPlantStatusRequest.ProtoReflect.Descriptor instead.
 func (*GetPlantStatusRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_plant_proto_rawDescGZIP(), []int{0}
 }
 
+func (x *GetPlantStatusRequest) GetPlantId() int64 {
+	if x != nil {
+		return x.PlantId
+	}
+	return 0
+}
+
 type GetPlantStatusResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Status        *PlantStatus           `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
@@ -86,6 +99,7 @@ func (x *GetPlantStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
+// Deprecated: Use GetPlantStatusResponse.ProtoReflect.Descriptor instead.
 func (*GetPlantStatusResponse) Descriptor() ([]byte, []int) {
 	return file_api_proto_plant_proto_rawDescGZIP(), []int{1}
 }
@@ -130,6 +144,7 @@ func (x *GetHistoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
+// Deprecated: Use GetHistoryRequest.ProtoReflect.Descriptor instead.
 func (*GetHistoryRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_plant_proto_rawDescGZIP(), []int{2}
 }
@@ -151,7 +166,7 @@ func (x *GetHistoryRequest) GetEndTime() int64 {
 type GetHistoryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Points        []*HistoryPoint        `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
-	Trend         string                 `protobuf:"bytes,2,opt,name=trend,proto3" json:"trend,omitempty"`
+	Trend         string                 `protobuf:"bytes,2,opt,name=trend,proto3" json:"trend,omitempty"` // "stable" | "brightening" | "darkening"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -181,6 +196,7 @@ func (x *GetHistoryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
+// Deprecated: Use GetHistoryResponse.ProtoReflect.Descriptor instead.
 func (*GetHistoryResponse) Descriptor() ([]byte, []int) {
 	return file_api_proto_plant_proto_rawDescGZIP(), []int{3}
 }
@@ -201,11 +217,13 @@ func (x *GetHistoryResponse) GetTrend() string {
 
 type PlantStatus struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
-	Recommendation string                 `protobuf:"bytes,1,opt,name=recommendation,proto3" json:"recommendation,omitempty"`
-	LightCategory  string                 `protobuf:"bytes,2,opt,name=light_category,json=lightCategory,proto3" json:"light_category,omitempty"`
+	Recommendation string                 `protobuf:"bytes,1,opt,name=recommendation,proto3" json:"recommendation,omitempty"`                    // e.g. "Low Light — most houseplants will thrive here"
+	LightCategory  string                 `protobuf:"bytes,2,opt,name=light_category,json=lightCategory,proto3" json:"light_category,omitempty"` // "Low Light" | "Medium Light" | "High Light"
 	CurrentLux     float64                `protobuf:"fixed64,3,opt,name=current_lux,json=currentLux,proto3" json:"current_lux,omitempty"`
 	Trend          string                 `protobuf:"bytes,4,opt,name=trend,proto3" json:"trend,omitempty"`
 	Timestamp      int64                  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Fit            string                 `protobuf:"bytes,6,opt,name=fit,proto3" json:"fit,omitempty"`     // "too_dark" | "ok" | "too_bright"; empty without plant_id
+	Plant          *Plant                 `protobuf:"bytes,7,opt,name=plant,proto3" json:"plant,omitempty"` // the plant evaluated against, if any
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -235,6 +253,7 @@ func (x *PlantStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
+// Deprecated: Use PlantStatus.ProtoReflect.Descriptor instead.
 func (*PlantStatus) Descriptor() ([]byte, []int) {
 	return file_api_proto_plant_proto_rawDescGZIP(), []int{4}
 }
@@ -274,6 +293,472 @@ func (x *PlantStatus) GetTimestamp() int64 {
 	return 0
 }
 
+func (x *PlantStatus) GetFit() string {
+	if x != nil {
+		return x.Fit
+	}
+	return ""
+}
+
+func (x *PlantStatus) GetPlant() *Plant {
+	if x != nil {
+		return x.Plant
+	}
+	return nil
+}
+
+type Plant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"` // unique
+	Species       string                 `protobuf:"bytes,3,opt,name=species,proto3" json:"species,omitempty"`
+	MinLux        float64                `protobuf:"fixed64,4,opt,name=min_lux,json=minLux,proto3" json:"min_lux,omitempty"` // light requirement range, inclusive
+	MaxLux        float64                `protobuf:"fixed64,5,opt,name=max_lux,json=maxLux,proto3" json:"max_lux,omitempty"`
+	Location      string                 `protobuf:"bytes,6,opt,name=location,proto3" json:"location,omitempty"`                 // free text, e.g. "living room window"
+	SensorId      string                 `protobuf:"bytes,7,opt,name=sensor_id,json=sensorId,proto3" json:"sensor_id,omitempty"` // light sensor the plant is read from
+	CreatedAtMs   int64                  `protobuf:"varint,8,opt,name=created_at_ms,json=createdAtMs,proto3" json:"created_at_ms,omitempty"`
+	UpdatedAtMs   int64                  `protobuf:"varint,9,opt,name=updated_at_ms,json=updatedAtMs,proto3" json:"updated_at_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Plant) Reset() {
+	*x = Plant{}
+	mi := &file_api_proto_plant_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Plant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Plant) ProtoMessage() {}
+
+func (x *Plant) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_plant_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Plant.ProtoReflect.Descriptor instead.
+func (*Plant) Descriptor() ([]byte, []int) {
+	return file_api_proto_plant_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Plant) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Plant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Plant) GetSpecies() string {
+	if x != nil {
+		return x.Species
+	}
+	return ""
+}
+
+func (x *Plant) GetMinLux() float64 {
+	if x != nil {
+		return x.MinLux
+	}
+	return 0
+}
+
+func (x *Plant) GetMaxLux() float64 {
+	if x != nil {
+		return x.MaxLux
+	}
+	return 0
+}
+
+func (x *Plant) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *Plant) GetSensorId() string {
+	if x != nil {
+		return x.SensorId
+	}
+	return ""
+}
+
+func (x *Plant) GetCreatedAtMs() int64 {
+	if x != nil {
+		return x.CreatedAtMs
+	}
+	return 0
+}
+
+func (x *Plant) GetUpdatedAtMs() int64 {
+	if x != nil {
+		return x.UpdatedAtMs
+	}
+	return 0
+}
+
+type CreatePlantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plant         *Plant                 `protobuf:"bytes,1,opt,name=plant,proto3" json:"plant,omitempty"` // id and timestamps are assigned by the server
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePlantRequest) Reset() {
+	*x = CreatePlantRequest{}
+	mi := &file_api_proto_plant_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePlantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePlantRequest) ProtoMessage() {}
+
+func (x *CreatePlantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_plant_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePlantRequest.ProtoReflect.Descriptor instead.
+func (*CreatePlantRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_plant_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreatePlantRequest) GetPlant() *Plant {
+	if x != nil {
+		return x.Plant
+	}
+	return nil
+}
+
+type CreatePlantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plant         *Plant                 `protobuf:"bytes,1,opt,name=plant,proto3" json:"plant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePlantResponse) Reset() {
+	*x = CreatePlantResponse{}
+	mi := &file_api_proto_plant_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePlantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePlantResponse) ProtoMessage() {}
+
+func (x *CreatePlantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_plant_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePlantResponse.ProtoReflect.Descriptor instead.
+func (*CreatePlantResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_plant_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreatePlantResponse) GetPlant() *Plant {
+	if x != nil {
+		return x.Plant
+	}
+	return nil
+}
+
+type GetPlantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlantRequest) Reset() {
+	*x = GetPlantRequest{}
+	mi := &file_api_proto_plant_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlantRequest) ProtoMessage() {}
+
+func (x *GetPlantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_plant_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlantRequest.ProtoReflect.Descriptor instead.
+func (*GetPlantRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_plant_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetPlantRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetPlantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plant         *Plant                 `protobuf:"bytes,1,opt,name=plant,proto3" json:"plant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlantResponse) Reset() {
+	*x = GetPlantResponse{}
+	mi := &file_api_proto_plant_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlantResponse) ProtoMessage() {}
+
+func (x *GetPlantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_plant_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlantResponse.ProtoReflect.Descriptor instead.
+func (*GetPlantResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_plant_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetPlantResponse) GetPlant() *Plant {
+	if x != nil {
+		return x.Plant
+	}
+	return nil
+}
+
+type ListPlantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPlantsRequest) Reset() {
+	*x = ListPlantsRequest{}
+	mi := &file_api_proto_plant_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPlantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPlantsRequest) ProtoMessage() {}
+
+func (x *ListPlantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_plant_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPlantsRequest.ProtoReflect.Descriptor instead.
+func (*ListPlantsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_plant_proto_rawDescGZIP(), []int{10}
+}
+
+type ListPlantsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plants        []*Plant               `protobuf:"bytes,1,rep,name=plants,proto3" json:"plants,omitempty"` // by name
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPlantsResponse) Reset() {
+	*x = ListPlantsResponse{}
+	mi := &file_api_proto_plant_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPlantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPlantsResponse) ProtoMessage() {}
+
+func (x *ListPlantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_plant_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPlantsResponse.ProtoReflect.Descriptor instead.
+func (*ListPlantsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_plant_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListPlantsResponse) GetPlants() []*Plant {
+	if x != nil {
+		return x.Plants
+	}
+	return nil
+}
+
+type UpdatePlantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plant         *Plant                 `protobuf:"bytes,1,opt,name=plant,proto3" json:"plant,omitempty"` // replaces every field of the plant with this id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePlantRequest) Reset() {
+	*x = UpdatePlantRequest{}
+	mi := &file_api_proto_plant_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePlantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePlantRequest) ProtoMessage() {}
+
+func (x *UpdatePlantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_plant_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePlantRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePlantRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_plant_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdatePlantRequest) GetPlant() *Plant {
+	if x != nil {
+		return x.Plant
+	}
+	return nil
+}
+
+type UpdatePlantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plant         *Plant                 `protobuf:"bytes,1,opt,name=plant,proto3" json:"plant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePlantResponse) Reset() {
+	*x = UpdatePlantResponse{}
+	mi := &file_api_proto_plant_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePlantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePlantResponse) ProtoMessage() {}
+
+func (x *UpdatePlantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_plant_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePlantResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePlantResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_plant_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdatePlantResponse) GetPlant() *Plant {
+	if x != nil {
+		return x.Plant
+	}
+	return nil
+}
+
 type HistoryPoint struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Timestamp     int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
@@ -285,7 +770,7 @@ type HistoryPoint struct {
 
 func (x *HistoryPoint) Reset() {
 	*x = HistoryPoint{}
-	mi := &file_api_proto_plant_proto_msgTypes[5]
+	mi := &file_api_proto_plant_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -297,7 +782,7 @@ func (x *HistoryPoint) String() string {
 func (*HistoryPoint) ProtoMessage() {}
 
 func (x *HistoryPoint) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_plant_proto_msgTypes[5]
+	mi := &file_api_proto_plant_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -308,8 +793,9 @@ func (x *HistoryPoint) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
+// Deprecated: Use HistoryPoint.ProtoReflect.Descriptor instead.
 func (*HistoryPoint) Descriptor() ([]byte, []int) {
-	return file_api_proto_plant_proto_rawDescGZIP(), []int{5}
+	return file_api_proto_plant_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *HistoryPoint) GetTimestamp() int64 {
@@ -336,7 +822,66 @@ func (x *HistoryPoint) GetCategory() string {
 var File_api_proto_plant_proto protoreflect.FileDescriptor
 
 const file_api_proto_plant_proto_rawDesc = "" +
-	"\n\x15api/proto/plant.proto\x12\bplant.v1\"\x17\n\x15GetPlantStatusRequest\"G\n\x16GetPlantStatusResponse\x12-\n\x06status\x18\x01 \x01(\v2\x15.plant.v1.PlantStatusR\x06status\"M\n\x11GetHistoryRequest\x12\x1d\n\nstart_time\x18\x01 \x01(\x03R\tstartTime\x12\x19\n\bend_time\x18\x02 \x01(\x03R\aendTime\"Z\n\x12GetHistoryResponse\x12.\n\x06points\x18\x01 \x03(\v2\x16.plant.v1.HistoryPointR\x06points\x12\x14\n\x05trend\x18\x02 \x01(\tR\x05trend\"\xb1\x01\n\vPlantStatus\x12&\n\x0erecommendation\x18\x01 \x01(\tR\x0erecommendation\x12%\n\x0elight_category\x18\x02 \x01(\tR\rlightCategory\x12\x1f\n\vcurrent_lux\x18\x03 \x01(\x01R\ncurrentLux\x12\x14\n\x05trend\x18\x04 \x01(\tR\x05trend\x12\x1c\n\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\"Z\n\fHistoryPoint\x12\x1c\n\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12\x10\n\x03lux\x18\x02 \x01(\x01R\x03lux\x12\x1a\n\bcategory\x18\x03 \x01(\tR\bcategory2\xac\x01\n\fPlantService\x12S\n\x0eGetPlantStatus\x12\x1f.plant.v1.GetPlantStatusRequest\x1a .plant.v1.GetPlantStatusResponse\x12G\n\nGetHistory\x12\x1b.plant.v1.GetHistoryRequest\x1a\x1c.plant.v1.GetHistoryResponseBBZ@github.com/quentinrf/plant-monitor/services/plant-service/pkg/pbb\x06proto3"
+	"\n" +
+	"\x15api/proto/plant.proto\x12\bplant.v1\"2\n" +
+	"\x15GetPlantStatusRequest\x12\x19\n" +
+	"\bplant_id\x18\x01 \x01(\x03R\aplantId\"G\n" +
+	"\x16GetPlantStatusResponse\x12-\n" +
+	"\x06status\x18\x01 \x01(\v2\x15.plant.v1.PlantStatusR\x06status\"M\n" +
+	"\x11GetHistoryRequest\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x02 \x01(\x03R\aendTime\"Z\n" +
+	"\x12GetHistoryResponse\x12.\n" +
+	"\x06points\x18\x01 \x03(\v2\x16.plant.v1.HistoryPointR\x06points\x12\x14\n" +
+	"\x05trend\x18\x02 \x01(\tR\x05trend\"\xea\x01\n" +
+	"\vPlantStatus\x12&\n" +
+	"\x0erecommendation\x18\x01 \x01(\tR\x0erecommendation\x12%\n" +
+	"\x0elight_category\x18\x02 \x01(\tR\rlightCategory\x12\x1f\n" +
+	"\vcurrent_lux\x18\x03 \x01(\x01R\n" +
+	"currentLux\x12\x14\n" +
+	"\x05trend\x18\x04 \x01(\tR\x05trend\x12\x1c\n" +
+	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\x12\x10\n" +
+	"\x03fit\x18\x06 \x01(\tR\x03fit\x12%\n" +
+	"\x05plant\x18\a \x01(\v2\x0f.plant.v1.PlantR\x05plant\"\xf8\x01\n" +
+	"\x05Plant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aspecies\x18\x03 \x01(\tR\aspecies\x12\x17\n" +
+	"\amin_lux\x18\x04 \x01(\x01R\x06minLux\x12\x17\n" +
+	"\amax_lux\x18\x05 \x01(\x01R\x06maxLux\x12\x1a\n" +
+	"\blocation\x18\x06 \x01(\tR\blocation\x12\x1b\n" +
+	"\tsensor_id\x18\a \x01(\tR\bsensorId\x12\"\n" +
+	"\rcreated_at_ms\x18\b \x01(\x03R\vcreatedAtMs\x12\"\n" +
+	"\rupdated_at_ms\x18\t \x01(\x03R\vupdatedAtMs\";\n" +
+	"\x12CreatePlantRequest\x12%\n" +
+	"\x05plant\x18\x01 \x01(\v2\x0f.plant.v1.PlantR\x05plant\"<\n" +
+	"\x13CreatePlantResponse\x12%\n" +
+	"\x05plant\x18\x01 \x01(\v2\x0f.plant.v1.PlantR\x05plant\"!\n" +
+	"\x0fGetPlantRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"9\n" +
+	"\x10GetPlantResponse\x12%\n" +
+	"\x05plant\x18\x01 \x01(\v2\x0f.plant.v1.PlantR\x05plant\"\x13\n" +
+	"\x11ListPlantsRequest\"=\n" +
+	"\x12ListPlantsResponse\x12'\n" +
+	"\x06plants\x18\x01 \x03(\v2\x0f.plant.v1.PlantR\x06plants\";\n" +
+	"\x12UpdatePlantRequest\x12%\n" +
+	"\x05plant\x18\x01 \x01(\v2\x0f.plant.v1.PlantR\x05plant\"<\n" +
+	"\x13UpdatePlantResponse\x12%\n" +
+	"\x05plant\x18\x01 \x01(\v2\x0f.plant.v1.PlantR\x05plant\"Z\n" +
+	"\fHistoryPoint\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12\x10\n" +
+	"\x03lux\x18\x02 \x01(\x01R\x03lux\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory2\xd0\x03\n" +
+	"\fPlantService\x12S\n" +
+	"\x0eGetPlantStatus\x12\x1f.plant.v1.GetPlantStatusRequest\x1a .plant.v1.GetPlantStatusResponse\x12G\n" +
+	"\n" +
+	"GetHistory\x12\x1b.plant.v1.GetHistoryRequest\x1a\x1c.plant.v1.GetHistoryResponse\x12J\n" +
+	"\vCreatePlant\x12\x1c.plant.v1.CreatePlantRequest\x1a\x1d.plant.v1.CreatePlantResponse\x12A\n" +
+	"\bGetPlant\x12\x19.plant.v1.GetPlantRequest\x1a\x1a.plant.v1.GetPlantResponse\x12G\n" +
+	"\n" +
+	"ListPlants\x12\x1b.plant.v1.ListPlantsRequest\x1a\x1c.plant.v1.ListPlantsResponse\x12J\n" +
+	"\vUpdatePlant\x12\x1c.plant.v1.UpdatePlantRequest\x1a\x1d.plant.v1.UpdatePlantResponseBBZ@github.com/quentinrf/plant-monitor/services/plant-service/pkg/pbb\x06proto3"
 
 var (
 	file_api_proto_plant_proto_rawDescOnce sync.Once
@@ -350,27 +895,51 @@ func file_api_proto_plant_proto_rawDescGZIP() []byte {
 	return file_api_proto_plant_proto_rawDescData
 }
 
-var file_api_proto_plant_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_api_proto_plant_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_api_proto_plant_proto_goTypes = []any{
 	(*GetPlantStatusRequest)(nil),  // 0: plant.v1.GetPlantStatusRequest
 	(*GetPlantStatusResponse)(nil), // 1: plant.v1.GetPlantStatusResponse
 	(*GetHistoryRequest)(nil),      // 2: plant.v1.GetHistoryRequest
 	(*GetHistoryResponse)(nil),     // 3: plant.v1.GetHistoryResponse
 	(*PlantStatus)(nil),            // 4: plant.v1.PlantStatus
-	(*HistoryPoint)(nil),           // 5: plant.v1.HistoryPoint
+	(*Plant)(nil),                  // 5: plant.v1.Plant
+	(*CreatePlantRequest)(nil),     // 6: plant.v1.CreatePlantRequest
+	(*CreatePlantResponse)(nil),    // 7: plant.v1.CreatePlantResponse
+	(*GetPlantRequest)(nil),        // 8: plant.v1.GetPlantRequest
+	(*GetPlantResponse)(nil),       // 9: plant.v1.GetPlantResponse
+	(*ListPlantsRequest)(nil),      // 10: plant.v1.ListPlantsRequest
+	(*ListPlantsResponse)(nil),     // 11: plant.v1.ListPlantsResponse
+	(*UpdatePlantRequest)(nil),     // 12: plant.v1.UpdatePlantRequest
+	(*UpdatePlantResponse)(nil),    // 13: plant.v1.UpdatePlantResponse
+	(*HistoryPoint)(nil),           // 14: plant.v1.HistoryPoint
 }
 var file_api_proto_plant_proto_depIdxs = []int32{
-	4, // 0: plant.v1.GetPlantStatusResponse.status:type_name -> plant.v1.PlantStatus
-	5, // 1: plant.v1.GetHistoryResponse.points:type_name -> plant.v1.HistoryPoint
-	0, // 2: plant.v1.PlantService.GetPlantStatus:input_type -> plant.v1.GetPlantStatusRequest
-	2, // 3: plant.v1.PlantService.GetHistory:input_type -> plant.v1.GetHistoryRequest
-	1, // 4: plant.v1.PlantService.GetPlantStatus:output_type -> plant.v1.GetPlantStatusResponse
-	3, // 5: plant.v1.PlantService.GetHistory:output_type -> plant.v1.GetHistoryResponse
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	4,  // 0: plant.v1.GetPlantStatusResponse.status:type_name -> plant.v1.PlantStatus
+	14, // 1: plant.v1.GetHistoryResponse.points:type_name -> plant.v1.HistoryPoint
+	5,  // 2: plant.v1.PlantStatus.plant:type_name -> plant.v1.Plant
+	5,  // 3: plant.v1.CreatePlantRequest.plant:type_name -> plant.v1.Plant
+	5,  // 4: plant.v1.CreatePlantResponse.plant:type_name -> plant.v1.Plant
+	5,  // 5: plant.v1.GetPlantResponse.plant:type_name -> plant.v1.Plant
+	5,  // 6: plant.v1.ListPlantsResponse.plants:type_name -> plant.v1.Plant
+	5,  // 7: plant.v1.UpdatePlantRequest.plant:type_name -> plant.v1.Plant
+	5,  // 8: plant.v1.UpdatePlantResponse.plant:type_name -> plant.v1.Plant
+	0,  // 9: plant.v1.PlantService.GetPlantStatus:input_type -> plant.v1.GetPlantStatusRequest
+	2,  // 10: plant.v1.PlantService.GetHistory:input_type -> plant.v1.GetHistoryRequest
+	6,  // 11: plant.v1.PlantService.CreatePlant:input_type -> plant.v1.CreatePlantRequest
+	8,  // 12: plant.v1.PlantService.GetPlant:input_type -> plant.v1.GetPlantRequest
+	10, // 13: plant.v1.PlantService.ListPlants:input_type -> plant.v1.ListPlantsRequest
+	12, // 14: plant.v1.PlantService.UpdatePlant:input_type -> plant.v1.UpdatePlantRequest
+	1,  // 15: plant.v1.PlantService.GetPlantStatus:output_type -> plant.v1.GetPlantStatusResponse
+	3,  // 16: plant.v1.PlantService.GetHistory:output_type -> plant.v1.GetHistoryResponse
+	7,  // 17: plant.v1.PlantService.CreatePlant:output_type -> plant.v1.CreatePlantResponse
+	9,  // 18: plant.v1.PlantService.GetPlant:output_type -> plant.v1.GetPlantResponse
+	11, // 19: plant.v1.PlantService.ListPlants:output_type -> plant.v1.ListPlantsResponse
+	13, // 20: plant.v1.PlantService.UpdatePlant:output_type -> plant.v1.UpdatePlantResponse
+	15, // [15:21] is the sub-list for method output_type
+	9,  // [9:15] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_plant_proto_init() }
@@ -384,7 +953,7 @@ func file_api_proto_plant_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_plant_proto_rawDesc), len(file_api_proto_plant_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
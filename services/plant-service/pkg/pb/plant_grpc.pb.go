@@ -21,6 +21,10 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	PlantService_GetPlantStatus_FullMethodName = "/plant.v1.PlantService/GetPlantStatus"
 	PlantService_GetHistory_FullMethodName     = "/plant.v1.PlantService/GetHistory"
+	PlantService_CreatePlant_FullMethodName    = "/plant.v1.PlantService/CreatePlant"
+	PlantService_GetPlant_FullMethodName       = "/plant.v1.PlantService/GetPlant"
+	PlantService_ListPlants_FullMethodName     = "/plant.v1.PlantService/ListPlants"
+	PlantService_UpdatePlant_FullMethodName    = "/plant.v1.PlantService/UpdatePlant"
 )
 
 // PlantServiceClient is the client API for PlantService service.
@@ -29,6 +33,12 @@ const (
 type PlantServiceClient interface {
 	GetPlantStatus(ctx context.Context, in *GetPlantStatusRequest, opts ...grpc.CallOption) (*GetPlantStatusResponse, error)
 	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
+	// Plant profiles: each plant's light requirements, against which
+	// GetPlantStatus can evaluate the current light
+	CreatePlant(ctx context.Context, in *CreatePlantRequest, opts ...grpc.CallOption) (*CreatePlantResponse, error)
+	GetPlant(ctx context.Context, in *GetPlantRequest, opts ...grpc.CallOption) (*GetPlantResponse, error)
+	ListPlants(ctx context.Context, in *ListPlantsRequest, opts ...grpc.CallOption) (*ListPlantsResponse, error)
+	UpdatePlant(ctx context.Context, in *UpdatePlantRequest, opts ...grpc.CallOption) (*UpdatePlantResponse, error)
 }
 
 type plantServiceClient struct {
@@ -59,12 +69,58 @@ func (c *plantServiceClient) GetHistory(ctx context.Context, in *GetHistoryReque
 	return out, nil
 }
 
+func (c *plantServiceClient) CreatePlant(ctx context.Context, in *CreatePlantRequest, opts ...grpc.CallOption) (*CreatePlantResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePlantResponse)
+	err := c.cc.Invoke(ctx, PlantService_CreatePlant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *plantServiceClient) GetPlant(ctx context.Context, in *GetPlantRequest, opts ...grpc.CallOption) (*GetPlantResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPlantResponse)
+	err := c.cc.Invoke(ctx, PlantService_GetPlant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *plantServiceClient) ListPlants(ctx context.Context, in *ListPlantsRequest, opts ...grpc.CallOption) (*ListPlantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPlantsResponse)
+	err := c.cc.Invoke(ctx, PlantService_ListPlants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *plantServiceClient) UpdatePlant(ctx context.Context, in *UpdatePlantRequest, opts ...grpc.CallOption) (*UpdatePlantResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdatePlantResponse)
+	err := c.cc.Invoke(ctx, PlantService_UpdatePlant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PlantServiceServer is the server API for PlantService service.
 // All implementations must embed UnimplementedPlantServiceServer
 // for forward compatibility.
 type PlantServiceServer interface {
 	GetPlantStatus(context.Context, *GetPlantStatusRequest) (*GetPlantStatusResponse, error)
 	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
+	// Plant profiles: each plant's light requirements, against which
+	// GetPlantStatus can evaluate the current light
+	CreatePlant(context.Context, *CreatePlantRequest) (*CreatePlantResponse, error)
+	GetPlant(context.Context, *GetPlantRequest) (*GetPlantResponse, error)
+	ListPlants(context.Context, *ListPlantsRequest) (*ListPlantsResponse, error)
+	UpdatePlant(context.Context, *UpdatePlantRequest) (*UpdatePlantResponse, error)
 	mustEmbedUnimplementedPlantServiceServer()
 }
 
@@ -81,6 +137,18 @@ func (UnimplementedPlantServiceServer) GetPlantStatus(context.Context, *GetPlant
 func (UnimplementedPlantServiceServer) GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetHistory not implemented")
 }
+func (UnimplementedPlantServiceServer) CreatePlant(context.Context, *CreatePlantRequest) (*CreatePlantResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreatePlant not implemented")
+}
+func (UnimplementedPlantServiceServer) GetPlant(context.Context, *GetPlantRequest) (*GetPlantResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPlant not implemented")
+}
+func (UnimplementedPlantServiceServer) ListPlants(context.Context, *ListPlantsRequest) (*ListPlantsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPlants not implemented")
+}
+func (UnimplementedPlantServiceServer) UpdatePlant(context.Context, *UpdatePlantRequest) (*UpdatePlantResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdatePlant not implemented")
+}
 func (UnimplementedPlantServiceServer) mustEmbedUnimplementedPlantServiceServer() {}
 func (UnimplementedPlantServiceServer) testEmbeddedByValue()                      {}
 
@@ -92,6 +160,10 @@ type UnsafePlantServiceServer interface {
 }
 
 func RegisterPlantServiceServer(s grpc.ServiceRegistrar, srv PlantServiceServer) {
+	// If the following call panics, it indicates UnimplementedPlantServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
 	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
 		t.testEmbeddedByValue()
 	}
@@ -134,6 +206,78 @@ func _PlantService_GetHistory_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PlantService_CreatePlant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePlantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlantServiceServer).CreatePlant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlantService_CreatePlant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlantServiceServer).CreatePlant(ctx, req.(*CreatePlantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlantService_GetPlant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlantServiceServer).GetPlant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlantService_GetPlant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlantServiceServer).GetPlant(ctx, req.(*GetPlantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlantService_ListPlants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPlantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlantServiceServer).ListPlants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlantService_ListPlants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlantServiceServer).ListPlants(ctx, req.(*ListPlantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlantService_UpdatePlant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePlantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlantServiceServer).UpdatePlant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlantService_UpdatePlant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlantServiceServer).UpdatePlant(ctx, req.(*UpdatePlantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PlantService_ServiceDesc is the grpc.ServiceDesc for PlantService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -149,6 +293,22 @@ var PlantService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetHistory",
 			Handler:    _PlantService_GetHistory_Handler,
 		},
+		{
+			MethodName: "CreatePlant",
+			Handler:    _PlantService_CreatePlant_Handler,
+		},
+		{
+			MethodName: "GetPlant",
+			Handler:    _PlantService_GetPlant_Handler,
+		},
+		{
+			MethodName: "ListPlants",
+			Handler:    _PlantService_ListPlants_Handler,
+		},
+		{
+			MethodName: "UpdatePlant",
+			Handler:    _PlantService_UpdatePlant_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/plant.proto",